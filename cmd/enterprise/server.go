@@ -107,7 +107,7 @@ func runServer(ctx context.Context, config signoz.Config, logger *slog.Logger) e
 		signoz.NewWebProviderFactories(config.Global),
 		sqlschemaProviderFactories,
 		sqlstoreProviderFactories(),
-		signoz.NewTelemetryStoreProviderFactories(),
+		signoz.NewTelemetryStoreProviderFactories,
 		func(ctx context.Context, providerSettings factory.ProviderSettings, store authtypes.AuthNStore, licensing licensing.Licensing) (map[authtypes.AuthNProvider]authn.AuthN, error) {
 			samlCallbackAuthN, err := samlcallbackauthn.New(ctx, store, licensing, config.Global)
 			if err != nil {
@@ -170,7 +170,7 @@ func runServer(ctx context.Context, config signoz.Config, logger *slog.Logger) e
 			communityHandler := querier.NewHandler(ps, q, a)
 			return eequerier.NewHandler(ps, q, communityHandler)
 		},
-		func(sqlStore sqlstore.SQLStore, dashboardModule dashboard.Module, global global.Global, zeus zeus.Zeus, gateway gateway.Gateway, licensing licensing.Licensing, serviceAccount serviceaccount.Module, config cloudintegration.Config) (cloudintegration.Module, error) {
+		func(sqlStore sqlstore.SQLStore, dashboardModule dashboard.Module, global global.Global, zeus zeus.Zeus, gateway gateway.Gateway, licensing licensing.Licensing, serviceAccount serviceaccount.Module, metadataStore telemetrytypes.MetadataStore, config cloudintegration.Config) (cloudintegration.Module, error) {
 			defStore := pkgcloudintegration.NewServiceDefinitionStore()
 			awsCloudProviderModule, err := implcloudprovider.NewAWSCloudProvider(defStore)
 			if err != nil {
@@ -178,13 +178,17 @@ func runServer(ctx context.Context, config signoz.Config, logger *slog.Logger) e
 			}
 			azureCloudProviderModule := implcloudprovider.NewAzureCloudProvider(defStore)
 			gcpCloudProviderModule := implcloudprovider.NewGCPCloudProvider(defStore)
+			ociCloudProviderModule := implcloudprovider.NewOCICloudProvider(defStore)
+			digitalOceanCloudProviderModule := implcloudprovider.NewDigitalOceanCloudProvider(defStore)
 			cloudProvidersMap := map[cloudintegrationtypes.CloudProviderType]cloudintegration.CloudProviderModule{
-				cloudintegrationtypes.CloudProviderTypeAWS:   awsCloudProviderModule,
-				cloudintegrationtypes.CloudProviderTypeAzure: azureCloudProviderModule,
-				cloudintegrationtypes.CloudProviderTypeGCP:   gcpCloudProviderModule,
+				cloudintegrationtypes.CloudProviderTypeAWS:          awsCloudProviderModule,
+				cloudintegrationtypes.CloudProviderTypeAzure:        azureCloudProviderModule,
+				cloudintegrationtypes.CloudProviderTypeGCP:          gcpCloudProviderModule,
+				cloudintegrationtypes.CloudProviderTypeOCI:          ociCloudProviderModule,
+				cloudintegrationtypes.CloudProviderTypeDigitalOcean: digitalOceanCloudProviderModule,
 			}
 
-			return implcloudintegration.NewModule(pkgcloudintegration.NewStore(sqlStore), dashboardModule, global, zeus, gateway, licensing, serviceAccount, cloudProvidersMap, config)
+			return implcloudintegration.NewModule(pkgcloudintegration.NewStore(sqlStore), dashboardModule, global, zeus, gateway, licensing, serviceAccount, metadataStore, cloudProvidersMap, config)
 		},
 		func(sqlStore sqlstore.SQLStore, ts telemetrystore.TelemetryStore, dashboardModule dashboard.Module, queryParser queryparser.QueryParser, lic licensing.Licensing, flgr pkgflagger.Flagger, ms telemetrytypes.MetadataStore, ps factory.ProviderSettings, threads int) metricreductionrule.Module {
 			return eeimplmetricreductionrule.NewModule(sqlStore, ts, dashboardModule, queryParser, lic, flgr, ms, ps, threads)
@@ -0,0 +1,98 @@
+package implcloudintegration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/cloudintegrationtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// accountIDLabel and serviceIDLabel label the health alerts of a connected account and
+	// its service.
+	accountIDLabel = "cloud_integration_account_id"
+	serviceIDLabel = "cloud_integration_service_id"
+)
+
+// newServiceMetricsAbsentAlertRule returns the rule firing when the first metric the service
+// definition lists stops arriving for the account: no data points within the staleness window
+// or no data at all for as long.
+func newServiceMetricsAbsentAlertRule(account *cloudintegrationtypes.Account, def *cloudintegrationtypes.ServiceDefinition, staleAfter time.Duration) *ruletypes.PostableRule {
+	metricName := def.DataCollected.Metrics[0].Name
+	window := valuer.MustParseTextDuration(staleAfter.String())
+	minimum := 1.0
+
+	var filter *qbtypes.Filter
+	attribute := cloudintegrationtypes.ProviderAccountAttribute(account.Provider)
+	if attribute != "" && account.ProviderAccountID != nil && *account.ProviderAccountID != "" {
+		filter = &qbtypes.Filter{Expression: fmt.Sprintf("%s = '%s'", attribute, *account.ProviderAccountID)}
+	}
+
+	return &ruletypes.PostableRule{
+		AlertName:     fmt.Sprintf("%s telemetry missing for %s account", def.Title, account.Provider.StringValue()),
+		AlertType:     ruletypes.AlertTypeMetric,
+		Description:   fmt.Sprintf("Metric %s of the %s integration has not been received for %s", metricName, def.Title, staleAfter),
+		RuleType:      ruletypes.RuleTypeThreshold,
+		Version:       "v5",
+		SchemaVersion: ruletypes.SchemaVersionV2Alpha1,
+		RuleCondition: &ruletypes.RuleCondition{
+			CompositeQuery: &ruletypes.AlertCompositeQuery{
+				QueryType: ruletypes.QueryTypeBuilder,
+				PanelType: ruletypes.PanelTypeGraph,
+				Queries: []qbtypes.QueryEnvelope{
+					{
+						Type: qbtypes.QueryTypeBuilder,
+						Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+							Name:   "A",
+							Signal: telemetrytypes.SignalMetrics,
+							Aggregations: []qbtypes.MetricAggregation{
+								{
+									MetricName:       metricName,
+									TimeAggregation:  metrictypes.TimeAggregationCount,
+									SpaceAggregation: metrictypes.SpaceAggregationSum,
+								},
+							},
+							Filter: filter,
+						},
+					},
+				},
+			},
+			SelectedQuery: "A",
+			AlertOnAbsent: true,
+			AbsentFor:     uint64(staleAfter / time.Minute),
+			Thresholds: &ruletypes.RuleThresholdData{
+				Kind: ruletypes.BasicThresholdKind,
+				Spec: ruletypes.BasicRuleThresholds{
+					{Name: "critical", TargetValue: &minimum, MatchType: ruletypes.InTotalLiteral, CompareOperator: ruletypes.ValueIsBelowLiteral},
+				},
+			},
+		},
+		Evaluation: &ruletypes.EvaluationEnvelope{
+			Kind: ruletypes.RollingEvaluation,
+			Spec: ruletypes.RollingWindow{
+				EvalWindow: window,
+				Frequency:  valuer.MustParseTextDuration("5m"),
+			},
+		},
+		NotificationSettings: &ruletypes.NotificationSettings{
+			Renotify: &ruletypes.Renotify{
+				Enabled:          true,
+				ReNotifyInterval: valuer.MustParseTextDuration("24h"),
+				AlertStates:      []ruletypes.AlertState{ruletypes.StateFiring, ruletypes.StateNoData},
+			},
+		},
+		Labels: map[string]string{
+			accountIDLabel: account.ID.StringValue(),
+			serviceIDLabel: def.ID,
+		},
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("%s telemetry is missing", def.Title),
+			"description": fmt.Sprintf("Metric %s of the %s integration of %s account %s has not been received within %s.", metricName, def.Title, account.Provider.StringValue(), account.ID.StringValue(), staleAfter),
+		},
+	}
+}
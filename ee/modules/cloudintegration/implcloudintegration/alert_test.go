@@ -0,0 +1,45 @@
+package implcloudintegration
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/cloudintegrationtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServiceMetricsAbsentAlertRule(t *testing.T) {
+	providerAccountID := "123456789012"
+	account := cloudintegrationtypes.NewAccount(valuer.GenerateUUID(), cloudintegrationtypes.CloudProviderTypeAWS, &cloudintegrationtypes.AccountConfig{})
+	account.ProviderAccountID = &providerAccountID
+	def := &cloudintegrationtypes.ServiceDefinition{
+		ServiceDefinitionMetadata: cloudintegrationtypes.ServiceDefinitionMetadata{ID: "ec2", Title: "EC2"},
+		DataCollected: cloudintegrationtypes.DataCollected{
+			Metrics: []cloudintegrationtypes.CollectedMetric{{Name: "aws_EC2_CPUUtilization_max"}},
+		},
+	}
+
+	// the rule reaches the rules manager as JSON
+	data, err := json.Marshal(newServiceMetricsAbsentAlertRule(account, def, 2*time.Hour))
+	require.NoError(t, err)
+
+	rule := new(ruletypes.PostableRule)
+	require.NoError(t, json.Unmarshal(data, rule))
+	require.NoError(t, rule.Validate())
+
+	require.Len(t, rule.RuleCondition.CompositeQuery.Queries, 1)
+	spec, ok := rule.RuleCondition.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation])
+	require.True(t, ok)
+	assert.Equal(t, "aws_EC2_CPUUtilization_max", spec.Aggregations[0].MetricName)
+	assert.Equal(t, "cloud.account.id = '123456789012'", spec.Filter.Expression)
+
+	assert.True(t, rule.RuleCondition.AlertOnAbsent)
+	assert.Equal(t, uint64(120), rule.RuleCondition.AbsentFor)
+	assert.Equal(t, account.ID.StringValue(), rule.Labels[accountIDLabel])
+	assert.Equal(t, "ec2", rule.Labels[serviceIDLabel])
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/types/cloudintegrationtypes"
 	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/types/serviceaccounttypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/types/zeustypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/SigNoz/signoz/pkg/zeus"
@@ -29,6 +31,7 @@ type module struct {
 	licensing         licensing.Licensing
 	global            global.Global
 	serviceAccount    serviceaccount.Module
+	metadataStore     telemetrytypes.MetadataStore
 	cloudProvidersMap map[cloudintegrationtypes.CloudProviderType]cloudintegration.CloudProviderModule
 	config            cloudintegration.Config
 }
@@ -41,6 +44,7 @@ func NewModule(
 	gateway gateway.Gateway,
 	licensing licensing.Licensing,
 	serviceAccount serviceaccount.Module,
+	metadataStore telemetrytypes.MetadataStore,
 	cloudProvidersMap map[cloudintegrationtypes.CloudProviderType]cloudintegration.CloudProviderModule,
 	config cloudintegration.Config,
 ) (cloudintegration.Module, error) {
@@ -52,6 +56,7 @@ func NewModule(
 		gateway:           gateway,
 		licensing:         licensing,
 		serviceAccount:    serviceAccount,
+		metadataStore:     metadataStore,
 		cloudProvidersMap: cloudProvidersMap,
 		config:            config,
 	}, nil
@@ -195,12 +200,13 @@ func (module *module) AgentCheckIn(ctx context.Context, orgID valuer.UUID, provi
 			req.ProviderAccountID,
 			account.ID.StringValue(),
 			new(cloudintegrationtypes.ProviderIntegrationConfig),
+			"",
 			account.RemovedAt,
 		), nil
 	}
 
 	// update account with cloud provider account id and agent report (heartbeat)
-	account.Update(&req.ProviderAccountID, cloudintegrationtypes.NewAgentReport(req.Data))
+	account.Update(&req.ProviderAccountID, cloudintegrationtypes.NewAgentReport(req.Data, req.ConfigVersion))
 
 	err = module.store.UpdateAccount(ctx, account)
 	if err != nil {
@@ -229,14 +235,143 @@ func (module *module) AgentCheckIn(ctx context.Context, orgID valuer.UUID, provi
 		return nil, err
 	}
 
+	configVersion, err := cloudintegrationtypes.NewConfigVersion(integrationConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return cloudintegrationtypes.NewAgentCheckInResponse(
 		req.ProviderAccountID,
 		account.ID.StringValue(),
 		integrationConfig,
+		configVersion,
 		account.RemovedAt,
 	), nil
 }
 
+func (module *module) GetAccountHealth(ctx context.Context, orgID, accountID valuer.UUID, provider cloudintegrationtypes.CloudProviderType) (*cloudintegrationtypes.AccountHealth, error) {
+	_, err := module.licensing.GetActive(ctx, orgID)
+	if err != nil {
+		return nil, errors.New(errors.TypeLicenseUnavailable, errors.CodeLicenseUnavailable, "a valid license is not available").WithAdditional("this feature requires a valid license").WithAdditional(err.Error())
+	}
+
+	storableAccount, err := module.store.GetConnectedAccount(ctx, orgID, accountID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := cloudintegrationtypes.NewAccountFromStorable(storableAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudProvider, err := module.getCloudProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	storedServices, err := module.store.ListServices(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the config the agent would get on check-in now, to compare with the one it reported running.
+	integrationConfig, err := cloudProvider.BuildIntegrationConfig(ctx, account, storedServices)
+	if err != nil {
+		return nil, err
+	}
+
+	configVersion, err := cloudintegrationtypes.NewConfigVersion(integrationConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	enabledServices, err := module.listEnabledServices(ctx, cloudProvider, provider, storedServices)
+	if err != nil {
+		return nil, err
+	}
+
+	var metricNames []string
+	var logFields []telemetrytypes.FieldCardinalityKey
+	for _, svc := range enabledServices {
+		if svc.config.IsMetricsEnabled(provider) {
+			metricNames = append(metricNames, svc.definition.ExpectedMetricNames()...)
+		}
+		if svc.config.IsLogsEnabled(provider) {
+			logFields = append(logFields, svc.definition.ExpectedLogFields()...)
+		}
+	}
+
+	// metrics are only attributed to the account once its agent reported the provider account id.
+	metricsLastSeen := make(map[string]int64)
+	attribute := cloudintegrationtypes.ProviderAccountAttribute(provider)
+	if len(metricNames) > 0 && attribute != "" && account.ProviderAccountID != nil && *account.ProviderAccountID != "" {
+		metricsLastSeen, err = module.metadataStore.FetchLastSeenInfoMultiByLabel(ctx, orgID, attribute, *account.ProviderAccountID, metricNames...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logsLastSeen, err := module.metadataStore.FetchLogFieldLastSeenInfoMulti(ctx, orgID, logFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	services := make([]*cloudintegrationtypes.ServiceHealth, 0, len(enabledServices))
+	for _, svc := range enabledServices {
+		serviceHealth, err := cloudintegrationtypes.NewServiceHealth(provider, svc.definition, svc.config, metricsLastSeen, logsLastSeen, now, module.config.Health.TelemetryStaleAfter)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, serviceHealth)
+	}
+
+	return cloudintegrationtypes.NewAccountHealth(account, configVersion, services, now, module.config.Health.CheckInStaleAfter), nil
+}
+
+func (module *module) GetAccountHealthAlertRules(ctx context.Context, orgID, accountID valuer.UUID, provider cloudintegrationtypes.CloudProviderType) ([]*ruletypes.PostableRule, error) {
+	_, err := module.licensing.GetActive(ctx, orgID)
+	if err != nil {
+		return nil, errors.New(errors.TypeLicenseUnavailable, errors.CodeLicenseUnavailable, "a valid license is not available").WithAdditional("this feature requires a valid license").WithAdditional(err.Error())
+	}
+
+	storableAccount, err := module.store.GetConnectedAccount(ctx, orgID, accountID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := cloudintegrationtypes.NewAccountFromStorable(storableAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudProvider, err := module.getCloudProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	storedServices, err := module.store.ListServices(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabledServices, err := module.listEnabledServices(ctx, cloudProvider, provider, storedServices)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*ruletypes.PostableRule, 0, len(enabledServices))
+	for _, svc := range enabledServices {
+		if !svc.config.IsMetricsEnabled(provider) || len(svc.definition.DataCollected.Metrics) == 0 {
+			continue
+		}
+		rules = append(rules, newServiceMetricsAbsentAlertRule(account, svc.definition, module.config.Health.TelemetryStaleAfter))
+	}
+
+	return rules, nil
+}
+
 func (module *module) UpdateAccount(ctx context.Context, account *cloudintegrationtypes.Account) error {
 	_, err := module.licensing.GetActive(ctx, account.OrgID)
 	if err != nil {
@@ -483,6 +618,35 @@ func (module *module) Collect(ctx context.Context, orgID valuer.UUID) (map[strin
 	return stats, nil
 }
 
+type enabledService struct {
+	definition *cloudintegrationtypes.ServiceDefinition
+	config     *cloudintegrationtypes.ServiceConfig
+}
+
+// listEnabledServices returns the definition and config of the stored services with a signal enabled.
+func (module *module) listEnabledServices(ctx context.Context, cloudProvider cloudintegration.CloudProviderModule, provider cloudintegrationtypes.CloudProviderType, storedServices []*cloudintegrationtypes.StorableCloudIntegrationService) ([]*enabledService, error) {
+	enabledServices := make([]*enabledService, 0, len(storedServices))
+	for _, storedService := range storedServices {
+		serviceConfig, err := cloudintegrationtypes.NewServiceConfigFromJSON(provider, storedService.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		if !serviceConfig.IsServiceEnabled(provider) {
+			continue
+		}
+
+		serviceDefinition, err := cloudProvider.GetServiceDefinition(ctx, storedService.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		enabledServices = append(enabledServices, &enabledService{definition: serviceDefinition, config: serviceConfig})
+	}
+
+	return enabledServices, nil
+}
+
 func (module *module) getCloudProvider(provider cloudintegrationtypes.CloudProviderType) (cloudintegration.CloudProviderModule, error) {
 	if cloudProviderModule, ok := module.cloudProvidersMap[provider]; ok {
 		return cloudProviderModule, nil
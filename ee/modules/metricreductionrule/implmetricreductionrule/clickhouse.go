@@ -18,8 +18,12 @@ var (
 	reductionRulesTable = metricstelemetryschema.DBName + "." + metricstelemetryschema.ReductionRulesTableName
 	metadataTable       = metricstelemetryschema.DBName + "." + metricstelemetryschema.AttributesMetadataTableName
 	bufferSeriesTable   = metricstelemetryschema.DBName + "." + metricstelemetryschema.TimeseriesV4BufferTableName
+	queryLogTable       = "system.query_log"
 )
 
+// queriedLabelPattern captures the label keys the querier reads from the labels JSON.
+const queriedLabelPattern = `JSONExtractString\(labels, '([^']+)'\)`
+
 const timeSeriesBucketMilli = int64(time.Hour / time.Millisecond)
 
 const sampleBucketExpr = "toInt64(toUnixTimestamp(toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalMinute(10)))) * 1000 AS bucket"
@@ -572,3 +576,114 @@ func (c *clickhouse) scanBuckets(ctx context.Context, sb *sqlbuilder.SelectBuild
 	}
 	return out, rows.Err()
 }
+
+// TopMetricsBySeries returns the metrics with the most raw series in the window, skipping the
+// excluded ones.
+func (c *clickhouse) TopMetricsBySeries(ctx context.Context, excluded []string, startMs, endMs int64, limit int) ([]volumeRow, error) {
+	ctx = c.withThreads(ctx)
+	startMs = floorToTimeSeriesBucket(startMs)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("metric_name", "uniq(fingerprint) AS series")
+	sb.From(bufferSeriesTable)
+	conds := []string{
+		sb.GE("unix_milli", startMs),
+		sb.LT("unix_milli", endMs),
+		sb.E("is_reduced", false),
+		"NOT startsWith(metric_name, 'signoz')",
+	}
+	if len(excluded) > 0 {
+		conds = append(conds, sb.NotIn("metric_name", sqlbuilder.List(excluded)))
+	}
+	sb.Where(conds...)
+	sb.GroupBy("metric_name")
+	sb.OrderBy("series DESC")
+	sb.Limit(limit)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := c.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to rank metrics by series")
+	}
+	defer rows.Close()
+
+	out := make([]volumeRow, 0, limit)
+	for rows.Next() {
+		var row volumeRow
+		if err := rows.Scan(&row.MetricName, &row.Ingested); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to scan metric series")
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// LabelDistinctValues returns the number of distinct values of each label of the metric.
+func (c *clickhouse) LabelDistinctValues(ctx context.Context, metricName string, startMs, endMs int64) (map[string]uint64, error) {
+	ctx = c.withThreads(ctx)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("attr_name", "uniq(attr_string_value)")
+	sb.From(metadataTable)
+	sb.Where(
+		sb.E("metric_name", metricName),
+		"NOT startsWith(attr_name, '__')",
+		sb.GE("last_reported_unix_milli", startMs),
+		sb.LE("first_reported_unix_milli", endMs),
+	)
+	sb.GroupBy("attr_name")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := c.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to fetch label distinct values")
+	}
+	defer rows.Close()
+
+	out := make(map[string]uint64)
+	for rows.Next() {
+		var (
+			key    string
+			values uint64
+		)
+		if err := rows.Scan(&key, &values); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to scan label distinct values")
+		}
+		out[key] = values
+	}
+	return out, rows.Err()
+}
+
+// QueriedLabels returns the labels recent queries on the metric read, taken from the SQL the
+// querier sent ClickHouse. Label keys are bound client side, so they appear in the query text.
+func (c *clickhouse) QueriedLabels(ctx context.Context, metricName string, startMs, endMs int64) ([]string, error) {
+	ctx = c.withThreads(ctx)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("DISTINCT arrayJoin(extractAll(query, " + sb.Var(queriedLabelPattern) + ")) AS label")
+	sb.From(queryLogTable)
+	sb.Where(
+		sb.GE("event_time", startMs/1000),
+		sb.LT("event_time", endMs/1000),
+		"type = 'QueryFinish'",
+		"is_initial_query",
+		"position(query, "+sb.Var("'"+metricName+"'")+") > 0",
+	)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := c.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to fetch queried labels")
+	}
+	defer rows.Close()
+
+	labels := make([]string, 0)
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to scan queried label")
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
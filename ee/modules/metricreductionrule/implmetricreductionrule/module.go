@@ -47,6 +47,7 @@ type module struct {
 	ch            *clickhouse
 	dashboard     dashboard.Module
 	ruleStore     ruletypes.RuleStore
+	queryParser   queryparser.QueryParser
 	licensing     licensing.Licensing
 	flagger       flagger.Flagger
 	metadataStore telemetrytypes.MetadataStore
@@ -60,6 +61,7 @@ func NewModule(sqlStore sqlstore.SQLStore, telemetryStore telemetrystore.Telemet
 		ch:            newClickhouse(telemetryStore, threads),
 		dashboard:     dashboardModule,
 		ruleStore:     sqlrulestore.NewRuleStore(sqlStore, queryParser, providerSettings),
+		queryParser:   queryParser,
 		licensing:     licensing,
 		flagger:       flagger,
 		metadataStore: metadataStore,
@@ -0,0 +1,288 @@
+package implmetricreductionrule
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	"github.com/SigNoz/signoz/pkg/types/metricreductionruletypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// labelUsage is the set of labels of a metric read by dashboards, alerts and recent queries.
+// Unresolved is set when a dashboard or alert reads the metric through a query whose labels cannot
+// be told apart, in which case no label of the metric is safe to drop.
+type labelUsage struct {
+	labels     map[string]struct{}
+	unresolved bool
+}
+
+func (u *labelUsage) add(labels ...string) {
+	for _, label := range labels {
+		if label != "" {
+			u.labels[label] = struct{}{}
+		}
+	}
+}
+
+func (m *module) Recommendations(ctx context.Context, orgID valuer.UUID, params *metricreductionruletypes.ListReductionRuleRecommendationsParams) (*metricreductionruletypes.GettableReductionRuleRecommendations, error) {
+	if err := m.checkAccess(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startMs := now.Add(-defaultPreviewLookback).UnixMilli()
+	endMs := now.UnixMilli()
+
+	rules, _, err := m.store.List(ctx, orgID, &metricreductionruletypes.ListReductionRulesParams{})
+	if err != nil {
+		return nil, err
+	}
+	ruled := make([]string, len(rules))
+	for i, rule := range rules {
+		ruled[i] = rule.MetricName
+	}
+
+	candidates, err := m.ch.TopMetricsBySeries(ctx, ruled, startMs, endMs, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := make([]metricreductionruletypes.GettableReductionRuleRecommendation, 0)
+	if len(candidates) == 0 {
+		return &metricreductionruletypes.GettableReductionRuleRecommendations{Recommendations: recommendations}, nil
+	}
+
+	metricNames := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		metricNames[i] = candidate.MetricName
+	}
+	_, metricTypes, _, err := m.metadataStore.FetchTemporalityAndTypeMulti(ctx, orgID, uint64(startMs), uint64(endMs), metricNames...)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := m.labelUsage(ctx, orgID, metricNames, startMs, endMs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, metricName := range metricNames {
+		if metricTypes[metricName] == metrictypes.ExpHistogramType {
+			continue
+		}
+		recommendation, err := m.recommend(ctx, metricName, usage[metricName], startMs, endMs)
+		if err != nil {
+			return nil, err
+		}
+		if recommendation != nil {
+			recommendations = append(recommendations, *recommendation)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].EstimatedSeriesSavings > recommendations[j].EstimatedSeriesSavings
+	})
+	return &metricreductionruletypes.GettableReductionRuleRecommendations{Recommendations: recommendations}, nil
+}
+
+func (m *module) ApplyRecommendation(ctx context.Context, orgID valuer.UUID, userEmail string, req *metricreductionruletypes.PostableReductionRuleRecommendation) (*metricreductionruletypes.GettableReductionRule, error) {
+	if err := m.checkAccess(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := m.validateMetricForReduction(ctx, orgID, req.MetricName); err != nil {
+		return nil, err
+	}
+
+	// The recommendation is recomputed rather than taken from the client so labels that came into
+	// use since it was listed are never dropped.
+	now := time.Now()
+	startMs := now.Add(-defaultPreviewLookback).UnixMilli()
+	endMs := now.UnixMilli()
+	usage, err := m.labelUsage(ctx, orgID, []string{req.MetricName}, startMs, endMs)
+	if err != nil {
+		return nil, err
+	}
+	recommendation, err := m.recommend(ctx, req.MetricName, usage[req.MetricName], startMs, endMs)
+	if err != nil {
+		return nil, err
+	}
+	if recommendation == nil {
+		return nil, errors.Newf(errors.TypeNotFound, metricreductionruletypes.ErrCodeMetricReductionRuleNoRecommendation, "no reduction rule is recommended for metric %q", req.MetricName)
+	}
+
+	return m.Create(ctx, orgID, userEmail, &recommendation.Rule)
+}
+
+// recommend returns the drop rule for the unused high cardinality labels of the metric, or nil when
+// it would not reduce the metric's series by at least MinRecommendedReductionPercent.
+func (m *module) recommend(ctx context.Context, metricName string, usage *labelUsage, startMs, endMs int64) (*metricreductionruletypes.GettableReductionRuleRecommendation, error) {
+	if usage.unresolved {
+		return nil, nil
+	}
+
+	distinctValues, err := m.ch.LabelDistinctValues(ctx, metricName, startMs, endMs)
+	if err != nil {
+		return nil, err
+	}
+	dropped := metricreductionruletypes.NewRecommendedLabels(distinctValues, usage.labels)
+	if len(dropped) == 0 {
+		return nil, nil
+	}
+	droppedNames := make([]string, len(dropped))
+	for i, label := range dropped {
+		droppedNames[i] = label.Name
+	}
+
+	current, reduced, reductionPercent, _, err := m.estimateVolume(ctx, metricName, metricreductionruletypes.MatchTypeDrop, droppedNames, startMs, endMs)
+	if err != nil {
+		return nil, err
+	}
+	if reductionPercent < metricreductionruletypes.MinRecommendedReductionPercent {
+		return nil, nil
+	}
+
+	inUse := make([]string, 0)
+	for label := range distinctValues {
+		if _, ok := usage.labels[label]; ok {
+			inUse = append(inUse, label)
+		}
+	}
+	sort.Strings(inUse)
+
+	return &metricreductionruletypes.GettableReductionRuleRecommendation{
+		Rule: metricreductionruletypes.PostableReductionRule{
+			MetricName: metricName,
+			UpdatableReductionRule: metricreductionruletypes.UpdatableReductionRule{
+				MatchType: metricreductionruletypes.MatchTypeDrop,
+				Labels:    droppedNames,
+			},
+		},
+		DroppedLabels:          dropped,
+		InUseLabels:            inUse,
+		IngestedSeries:         current,
+		RetainedSeries:         reduced,
+		EstimatedSeriesSavings: current - reduced,
+		ReductionPercent:       reductionPercent,
+	}, nil
+}
+
+// labelUsage collects the labels of each metric that dashboards, alerts and recent queries read.
+// Dashboards and alerts must be readable for a recommendation to be made; the query log is best
+// effort since it may be disabled or not readable by the telemetry store user.
+func (m *module) labelUsage(ctx context.Context, orgID valuer.UUID, metricNames []string, startMs, endMs int64) (map[string]*labelUsage, error) {
+	usage := make(map[string]*labelUsage, len(metricNames))
+	for _, metricName := range metricNames {
+		usage[metricName] = &labelUsage{labels: make(map[string]struct{})}
+	}
+
+	v1, err := m.dashboard.GetByMetricNames(ctx, orgID, metricNames)
+	if err != nil {
+		return nil, err
+	}
+	v2, err := m.dashboard.GetByMetricNamesV2(ctx, orgID, metricNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, dashboards := range []map[string][]dashboardtypes.DashboardPanelRef{v1, v2} {
+		for metricName, panels := range dashboards {
+			u, ok := usage[metricName]
+			if !ok {
+				continue
+			}
+			for _, panel := range panels {
+				// Only the builder queries of a panel resolve to labels; PromQL and ClickHouse SQL
+				// panels, and those of v2 dashboards, are only known to reference the metric.
+				if len(panel.GroupBy) == 0 && len(panel.FilterBy) == 0 {
+					u.unresolved = true
+					continue
+				}
+				u.add(panel.GroupBy...)
+				u.add(panel.FilterBy...)
+			}
+		}
+	}
+
+	if err := m.alertLabelUsage(ctx, orgID, usage); err != nil {
+		return nil, err
+	}
+
+	for _, metricName := range metricNames {
+		labels, err := m.ch.QueriedLabels(ctx, metricName, startMs, endMs)
+		if err != nil {
+			m.logger.WarnContext(ctx, "failed to fetch queried labels for reduction recommendation", slog.String("metric_name", metricName), errors.Attr(err))
+			continue
+		}
+		usage[metricName].add(labels...)
+	}
+
+	return usage, nil
+}
+
+func (m *module) alertLabelUsage(ctx context.Context, orgID valuer.UUID, usage map[string]*labelUsage) error {
+	storedRules, err := m.ruleStore.GetStoredRules(ctx, orgID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, storedRule := range storedRules {
+		var rule ruletypes.PostableRule
+		if err := json.Unmarshal([]byte(storedRule.Data), &rule); err != nil {
+			m.logger.WarnContext(ctx, "failed to unmarshal rule data for reduction recommendation", slog.String("rule.id", storedRule.ID.StringValue()), errors.Attr(err))
+			continue
+		}
+		if rule.AlertType != ruletypes.AlertTypeMetric || rule.RuleCondition == nil || rule.RuleCondition.CompositeQuery == nil {
+			continue
+		}
+
+		for _, envelope := range rule.RuleCondition.CompositeQuery.Queries {
+			switch spec := envelope.Spec.(type) {
+			case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+				labels := make([]string, 0, len(spec.GroupBy))
+				for _, groupBy := range spec.GroupBy {
+					labels = append(labels, groupBy.Name)
+				}
+				if spec.Filter != nil {
+					for _, key := range querybuilder.QueryStringToKeysSelectors(spec.Filter.Expression) {
+						labels = append(labels, key.Name)
+					}
+				}
+				for _, aggregation := range spec.Aggregations {
+					if u, ok := usage[aggregation.MetricName]; ok {
+						u.add(labels...)
+					}
+				}
+			case qbtypes.PromQuery:
+				m.markUnresolved(ctx, usage, qbtypes.QueryTypePromQL, spec.Query)
+			case qbtypes.ClickHouseQuery:
+				m.markUnresolved(ctx, usage, qbtypes.QueryTypeClickHouseSQL, spec.Query)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *module) markUnresolved(ctx context.Context, usage map[string]*labelUsage, queryType qbtypes.QueryType, query string) {
+	result, err := m.queryParser.AnalyzeQueryFilter(ctx, queryType, query)
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to parse alert query for reduction recommendation", slog.String("query", query), errors.Attr(err))
+		return
+	}
+	for _, metricName := range result.MetricNames {
+		if u, ok := usage[metricName]; ok {
+			u.unresolved = true
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package implmetricreductionrule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	cmock "github.com/SigNoz/clickhouse-go-mock"
+	"github.com/SigNoz/signoz/ee/modules/metricreductionrule/implmetricreductionrule"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+	"github.com/SigNoz/signoz/pkg/modules/metricreductionrule"
+	"github.com/SigNoz/signoz/pkg/queryparser"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	"github.com/SigNoz/signoz/pkg/types/licensetypes"
+	"github.com/SigNoz/signoz/pkg/types/metricreductionruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	builderAlertRule = `{
+		"alert": "route latency",
+		"alertType": "METRIC_BASED_ALERT",
+		"ruleType": "threshold_rule",
+		"version": "v5",
+		"condition": {
+			"compositeQuery": {
+				"queryType": "builder",
+				"queries": [{
+					"type": "builder_query",
+					"spec": {
+						"name": "A",
+						"signal": "metrics",
+						"aggregations": [{"metricName": "http_server_duration", "spaceAggregation": "p99"}],
+						"filter": {"expression": "http.route = '/checkout'"}
+					}
+				}]
+			},
+			"target": 1.0,
+			"matchType": "1",
+			"op": "1",
+			"selectedQuery": "A"
+		}
+	}`
+	promQLAlertRule = `{
+		"alert": "queue depth",
+		"alertType": "METRIC_BASED_ALERT",
+		"ruleType": "promql_rule",
+		"version": "v5",
+		"condition": {
+			"compositeQuery": {
+				"queryType": "promql",
+				"queries": [{"type": "promql", "spec": {"name": "A", "query": "sum(queue_depth{queue=\"orders\"})"}}]
+			},
+			"target": 1.0,
+			"matchType": "1",
+			"op": "1",
+			"selectedQuery": "A"
+		}
+	}`
+)
+
+var (
+	testOrgID = valuer.GenerateUUID()
+
+	seriesColumns   = []cmock.ColumnType{{Name: "metric_name", Type: "String"}, {Name: "series", Type: "UInt64"}}
+	labelColumns    = []cmock.ColumnType{{Name: "label", Type: "String"}}
+	distinctColumns = []cmock.ColumnType{{Name: "attr_name", Type: "String"}, {Name: "uniq(attr_string_value)", Type: "UInt64"}}
+	attrColumns     = []cmock.ColumnType{{Name: "attr_name", Type: "String"}}
+	estimateColumns = []cmock.ColumnType{{Name: "uniq(fingerprint)", Type: "UInt64"}, {Name: "reduced", Type: "UInt64"}}
+)
+
+type testDashboard struct {
+	dashboard.Module
+	v1 map[string][]dashboardtypes.DashboardPanelRef
+	v2 map[string][]dashboardtypes.DashboardPanelRef
+}
+
+func (d *testDashboard) GetByMetricNames(context.Context, valuer.UUID, []string) (map[string][]dashboardtypes.DashboardPanelRef, error) {
+	return d.v1, nil
+}
+
+func (d *testDashboard) GetByMetricNamesV2(context.Context, valuer.UUID, []string) (map[string][]dashboardtypes.DashboardPanelRef, error) {
+	return d.v2, nil
+}
+
+type testLicensing struct {
+	licensing.Licensing
+}
+
+func (testLicensing) GetActive(context.Context, valuer.UUID) (*licensetypes.License, error) {
+	return &licensetypes.License{}, nil
+}
+
+type testMetadataStore struct {
+	*telemetrytypestest.MockMetadataStore
+	lastSeen map[string]int64
+}
+
+func (s *testMetadataStore) FetchLastSeenInfoMulti(context.Context, valuer.UUID, ...string) (map[string]int64, error) {
+	return s.lastSeen, nil
+}
+
+func newTestModule(t *testing.T, dashboards *testDashboard) (metricreductionrule.Module, sqlmock.Sqlmock, cmock.ClickConnMockCommon) {
+	t.Helper()
+
+	sqlStore := sqlstoretest.New(sqlstore.Config{Provider: "sqlite"}, sqlmock.QueryMatcherRegexp)
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, sqlmock.QueryMatcherRegexp)
+	metadataStore := &testMetadataStore{
+		MockMetadataStore: telemetrytypestest.NewMockMetadataStore(),
+		lastSeen:          map[string]int64{"http_server_duration": time.Now().UnixMilli()},
+	}
+	settings := instrumentationtest.New().ToProviderSettings()
+	flags := flaggertest.WithBooleanFlags(t, map[string]bool{flagger.FeatureEnableMetricsReduction.String(): true})
+
+	module := implmetricreductionrule.NewModule(sqlStore, telemetryStore, dashboards, queryparser.New(settings), testLicensing{}, flags, metadataStore, settings, 1)
+	return module, sqlStore.Mock(), telemetryStore.Mock()
+}
+
+func anyArgs(n int) []any {
+	return make([]any, n)
+}
+
+func expectStoredRules(mock sqlmock.Sqlmock, data ...string) {
+	rows := sqlmock.NewRows([]string{"id", "data", "org_id"})
+	for _, d := range data {
+		rows.AddRow(valuer.GenerateUUID().StringValue(), d, testOrgID.StringValue())
+	}
+	mock.ExpectQuery(`FROM "rule"`).WillReturnRows(rows)
+}
+
+func expectQueriedLabels(mock cmock.ClickConnMockCommon, labels ...string) {
+	rows := make([][]any, len(labels))
+	for i, label := range labels {
+		rows[i] = []any{label}
+	}
+	mock.ExpectQuery(`FROM system\.query_log`).WithArgs(anyArgs(4)...).WillReturnRows(cmock.NewRows(labelColumns, rows))
+}
+
+// expectRecommendation expects the queries that size the drop rule of http_server_duration, whose
+// pod and host labels are the only unused high cardinality ones.
+func expectRecommendation(mock cmock.ClickConnMockCommon, reduced uint64) {
+	mock.ExpectQuery(`SELECT attr_name, uniq\(attr_string_value\)`).WithArgs(anyArgs(3)...).WillReturnRows(cmock.NewRows(distinctColumns, [][]any{
+		{"service.name", uint64(12)},
+		{"http.route", uint64(50)},
+		{"status_code", uint64(20)},
+		{"pod", uint64(500)},
+		{"host", uint64(40)},
+	}))
+	mock.ExpectQuery(`SELECT DISTINCT attr_name`).WithArgs(anyArgs(3)...).WillReturnRows(cmock.NewRows(attrColumns, [][]any{
+		{"service.name"}, {"http.route"}, {"status_code"}, {"pod"}, {"host"},
+	}))
+	mock.ExpectQueryRow(`SELECT uniq\(fingerprint\), uniq\(\(`).WillReturnRow(cmock.NewRow(estimateColumns, []any{uint64(1000), reduced}))
+}
+
+func TestRecommendations(t *testing.T) {
+	dashboards := &testDashboard{
+		v1: map[string][]dashboardtypes.DashboardPanelRef{
+			"http_server_duration": {{DashboardID: "d1", PanelID: "p1", GroupBy: []string{"service.name"}}},
+		},
+		v2: map[string][]dashboardtypes.DashboardPanelRef{
+			"cache_hits": {{DashboardID: "d2", PanelID: "p2"}},
+		},
+	}
+	module, sqlMock, chMock := newTestModule(t, dashboards)
+
+	sqlMock.ExpectQuery(`FROM "metric_reduction_rule"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	expectStoredRules(sqlMock, builderAlertRule, promQLAlertRule)
+
+	chMock.ExpectQuery(`SELECT metric_name, uniq\(fingerprint\) AS series`).WithArgs(anyArgs(4)...).WillReturnRows(cmock.NewRows(seriesColumns, [][]any{
+		{"http_server_duration", uint64(1000)},
+		{"queue_depth", uint64(800)},
+		{"cache_hits", uint64(600)},
+	}))
+	expectQueriedLabels(chMock, "status_code")
+	expectQueriedLabels(chMock)
+	expectQueriedLabels(chMock)
+	expectRecommendation(chMock, 100)
+
+	resp, err := module.Recommendations(context.Background(), testOrgID, &metricreductionruletypes.ListReductionRuleRecommendationsParams{Limit: 10})
+	require.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, chMock.ExpectationsWereMet())
+
+	// queue_depth is read by a PromQL alert and cache_hits by a v2 dashboard panel, neither of
+	// which resolve to labels, so only http_server_duration is recommended.
+	require.Len(t, resp.Recommendations, 1)
+	recommendation := resp.Recommendations[0]
+	assert.Equal(t, "http_server_duration", recommendation.Rule.MetricName)
+	assert.Equal(t, metricreductionruletypes.MatchTypeDrop, recommendation.Rule.MatchType)
+	assert.Equal(t, []string{"pod", "host"}, recommendation.Rule.Labels)
+	assert.Equal(t, []string{"http.route", "service.name", "status_code"}, recommendation.InUseLabels)
+	assert.Equal(t, uint64(900), recommendation.EstimatedSeriesSavings)
+	assert.InDelta(t, 90.0, recommendation.ReductionPercent, 0.001)
+}
+
+func TestApplyRecommendation(t *testing.T) {
+	dashboards := &testDashboard{
+		v1: map[string][]dashboardtypes.DashboardPanelRef{
+			"http_server_duration": {{DashboardID: "d1", PanelID: "p1", GroupBy: []string{"service.name"}}},
+		},
+	}
+
+	t.Run("RecomputesBeforeCreate", func(t *testing.T) {
+		module, sqlMock, chMock := newTestModule(t, dashboards)
+
+		expectStoredRules(sqlMock, builderAlertRule)
+		// pod came into use since the recommendation was listed, so only host is dropped.
+		expectQueriedLabels(chMock, "status_code", "pod")
+		chMock.ExpectQuery(`SELECT attr_name, uniq\(attr_string_value\)`).WithArgs(anyArgs(3)...).WillReturnRows(cmock.NewRows(distinctColumns, [][]any{
+			{"service.name", uint64(12)},
+			{"pod", uint64(500)},
+			{"host", uint64(40)},
+		}))
+		chMock.ExpectQuery(`SELECT DISTINCT attr_name`).WithArgs(anyArgs(3)...).WillReturnRows(cmock.NewRows(attrColumns, [][]any{
+			{"service.name"}, {"pod"}, {"host"},
+		}))
+		chMock.ExpectQueryRow(`SELECT uniq\(fingerprint\), uniq\(\(`).WillReturnRow(cmock.NewRow(estimateColumns, []any{uint64(1000), uint64(500)}))
+		sqlMock.ExpectExec(`INSERT INTO "metric_reduction_rule"`).WillReturnResult(sqlmock.NewResult(1, 1))
+		chMock.ExpectExec(`INSERT INTO signoz_metrics\.`).WithArgs("http_server_duration", []string{"host"}, "drop", nil, false, nil)
+
+		rule, err := module.ApplyRecommendation(context.Background(), testOrgID, "user@signoz.io", &metricreductionruletypes.PostableReductionRuleRecommendation{MetricName: "http_server_duration"})
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+		assert.NoError(t, chMock.ExpectationsWereMet())
+		assert.Equal(t, "http_server_duration", rule.MetricName)
+		assert.Equal(t, []string{"host"}, []string(rule.Labels))
+	})
+
+	t.Run("NoLongerRecommended", func(t *testing.T) {
+		module, sqlMock, chMock := newTestModule(t, dashboards)
+
+		expectStoredRules(sqlMock, builderAlertRule)
+		expectQueriedLabels(chMock, "status_code")
+		// Dropping pod and host no longer reduces the series by enough to recommend a rule.
+		expectRecommendation(chMock, 950)
+
+		_, err := module.ApplyRecommendation(context.Background(), testOrgID, "user@signoz.io", &metricreductionruletypes.PostableReductionRuleRecommendation{MetricName: "http_server_duration"})
+		require.Error(t, err)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+		assert.NoError(t, chMock.ExpectationsWereMet())
+	})
+}
@@ -113,6 +113,10 @@ func (h *handler) ReplaceVariables(rw http.ResponseWriter, req *http.Request) {
 	h.community.ReplaceVariables(rw, req)
 }
 
+func (h *handler) ValidatePipeQuery(rw http.ResponseWriter, req *http.Request) {
+	h.community.ValidatePipeQuery(rw, req)
+}
+
 func extractSeasonality(anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]) anomalyV2.Seasonality {
 	for _, fn := range anomalyQuery.Functions {
 		if fn.Name == qbtypes.FunctionNameAnomaly {
@@ -0,0 +1,149 @@
+grammar PipeQuery;
+
+/*
+ * A pipe query selects a signal, optionally filters it with a search expression and
+ * transforms the result with a chain of commands, e.g.
+ *
+ *   source=logs service.name="api" severity_text=ERROR | stats count() by k8s.pod.name | sort -count | head 10
+ *
+ * Search and where expressions follow FilterQuery.g4. A where command following stats
+ * filters the aggregated rows and follows HavingExpression.g4.
+ */
+
+/*
+ * Parser Rules
+ */
+
+query
+    : source search? ( PIPE command )* EOF
+    ;
+
+source
+    : SOURCE EQUALS ( LOGS | TRACES )
+    ;
+
+// Everything up to the first pipe outside of quotes, see FilterQuery.g4.
+search
+    : expressionText
+    ;
+
+command
+    : whereCommand
+    | statsCommand
+    | sortCommand
+    | headCommand
+    | fieldsCommand
+    ;
+
+// where service.name = 'api' AND has_error = true
+whereCommand
+    : WHERE expressionText
+    ;
+
+// stats count() as total, p99(duration_nano) by service.name, http.route
+statsCommand
+    : STATS aggregation ( COMMA aggregation )* ( BY field ( COMMA field )* )?
+    ;
+
+// count(), avg(duration_nano), countIf(has_error = true)
+aggregation
+    : functionCall ( AS field )?
+    ;
+
+functionCall
+    : IDENTIFIER LPAREN argumentText? RPAREN
+    ;
+
+// sort -count, service.name
+sortCommand
+    : SORT sortKey ( COMMA sortKey )*
+    ;
+
+sortKey
+    : ( PLUS | MINUS )? field
+    ;
+
+// head 10
+headCommand
+    : HEAD NUMBER?
+    ;
+
+// fields service.name, body
+fieldsCommand
+    : FIELDS field ( COMMA field )*
+    ;
+
+// Keywords are not reserved, they are field names where a field is expected.
+field
+    : IDENTIFIER
+    | QUOTED_TEXT
+    | keyword
+    ;
+
+expressionText
+    : ( ~PIPE )+
+    ;
+
+// Balanced parentheses, with any token but a pipe in between.
+argumentText
+    : ( ~( LPAREN | RPAREN | PIPE ) | LPAREN argumentText? RPAREN )+
+    ;
+
+keyword
+    : SOURCE
+    | LOGS
+    | TRACES
+    | WHERE
+    | STATS
+    | BY
+    | AS
+    | SORT
+    | HEAD
+    | FIELDS
+    ;
+
+/*
+ * Lexer Rules
+ */
+
+PIPE   : '|' ;
+COMMA  : ',' ;
+EQUALS : '=' ;
+LPAREN : '(' ;
+RPAREN : ')' ;
+PLUS   : '+' ;
+MINUS  : '-' ;
+
+SOURCE : [Ss][Oo][Uu][Rr][Cc][Ee] ;
+LOGS   : [Ll][Oo][Gg][Ss] ;
+TRACES : [Tt][Rr][Aa][Cc][Ee][Ss] ;
+WHERE  : [Ww][Hh][Ee][Rr][Ee] ;
+STATS  : [Ss][Tt][Aa][Tt][Ss] ;
+BY     : [Bb][Yy] ;
+AS     : [Aa][Ss] ;
+SORT   : [Ss][Oo][Rr][Tt] ;
+HEAD   : [Hh][Ee][Aa][Dd] ;
+FIELDS : [Ff][Ii][Ee][Ll][Dd][Ss] ;
+
+NUMBER
+    : [0-9]+
+    ;
+
+QUOTED_TEXT
+    : '"' ( ~["\\] | '\\' . )* '"'
+    | '\'' ( ~['\\] | '\\' . )* '\''
+    ;
+
+// Field names as in FilterQuery.g4, e.g. service.name, resource.k8s.pod.name, http.status_code:number
+IDENTIFIER
+    : [a-zA-Z_$@] [a-zA-Z0-9_$@.:\-[\]]*
+    ;
+
+WS
+    : [ \t\r\n]+ -> skip
+    ;
+
+// Any other character, only found in expression and argument texts
+OTHER
+    : .
+    ;
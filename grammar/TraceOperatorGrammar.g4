@@ -13,9 +13,19 @@ expression
     | atom                                               // Simple expression (atom)
     ;
 
-// Atom definition: atoms are identifiers (letters and optional numbers)
+// Atom definition: atoms are identifiers (letters and optional numbers) or count conditions
 atom
     : IDENTIFIER                                         // General atom (combination of letters and numbers)
+    | count                                              // Count condition over an expression
+    ;
+
+// Count condition: traces where the number of spans matching the expression satisfies the comparison
+count
+    : 'COUNT' '(' expression ')' comparator NUMBER       // e.g. COUNT(A) >= 3
+    ;
+
+comparator
+    : '>=' | '>' | '<=' | '<' | '=' | '!='
     ;
 
 // Operator definition
@@ -25,6 +35,14 @@ operator
     | '||'                                               // OR
     | 'NOT'                                              // NOT
     | '->'                                               // Implication
+    | '>>' window?                                       // Followed by, optionally within a time window
+    | '!>>' window?                                      // Not followed by, optionally within a time window
+    | '~'                                                // Sibling
+    ;
+
+// Time window of the sequence operators, e.g. A >>[500ms] B
+window
+    : '[' DURATION ']'
     ;
 
 // Lexer rules
@@ -34,6 +52,16 @@ IDENTIFIER
     : [a-zA-Z]+[0-9]*                                    // Letters followed by optional numbers (e.g., A1, B123, C99)
     ;
 
+// DURATION is a Go style duration (e.g., 500ms, 2s, 1m30s)
+DURATION
+    : ([0-9]+ ('.' [0-9]+)? ('ns' | 'us' | 'µs' | 'ms' | 's' | 'm' | 'h'))+
+    ;
+
+// NUMBER is a non-negative integer
+NUMBER
+    : [0-9]+
+    ;
+
 // Whitespace (to be skipped)
 WS 
     : [ \t\r\n]+ -> skip;                                 // Skip whitespace
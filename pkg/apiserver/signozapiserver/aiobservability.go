@@ -47,5 +47,40 @@ func (provider *provider) addAIObservabilityRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v1/ai_observability/conversation", handler.New(provider.authzMiddleware.ViewAccess(provider.aiObservabilityHandler.GetConversation), handler.OpenAPIDef{
+		ID:                  "GetAIObservabilityConversation",
+		Tags:                []string{"ai_observability"},
+		Summary:             "Get AI observability conversation",
+		Description:         "This endpoint reconstructs the multi-turn conversation or agent run of a trace, or of the traces sharing a session id attribute (gen_ai.conversation.id unless sessionKey is set), from its gen_ai spans. The LLM calls are the turns in time order, each with its messages, token usage, cost and error, and the tool calls run after it. The message bodies are redacted for the roles below admin as configured",
+		Request:             nil,
+		RequestQuery:        new(aiobservabilitytypes.GetConversationParams),
+		RequestContentType:  "",
+		Response:            new(aiobservabilitytypes.Conversation),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/ai_observability/conversations/search", handler.New(provider.authzMiddleware.ViewAccess(provider.aiObservabilityHandler.SearchConversations), handler.OpenAPIDef{
+		ID:                  "SearchAIObservabilityConversations",
+		Tags:                []string{"ai_observability"},
+		Summary:             "Search AI observability conversations",
+		Description:         "This endpoint returns the traces, latest first, whose gen_ai.input.messages or gen_ai.output.messages contain a text, with their session id and a snippet of the match. For the roles below admin, the message bodies are redacted before being matched, so only the text left visible to them is found",
+		Request:             new(aiobservabilitytypes.PostableConversationSearch),
+		RequestContentType:  "application/json",
+		Response:            new(aiobservabilitytypes.GettableConversationSearch),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		Deprecated:          false,
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	return nil
 }
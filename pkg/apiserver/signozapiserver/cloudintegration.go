@@ -6,6 +6,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/http/handler"
 	"github.com/SigNoz/signoz/pkg/types"
 	citypes "github.com/SigNoz/signoz/pkg/types/cloudintegrationtypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/gorilla/mux"
 )
 
@@ -130,6 +131,46 @@ func (provider *provider) addCloudIntegrationRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v1/cloud_integrations/{cloud_provider}/accounts/{id}/health", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.cloudIntegrationHandler.GetAccountHealth),
+		handler.OpenAPIDef{
+			ID:                  "GetAccountHealth",
+			Tags:                []string{"cloudintegration"},
+			Summary:             "Get account health",
+			Description:         "This endpoint reports whether the agent of an account still checks in and runs the latest config, and whether the metrics of its enabled services still arrive from the account. Logs are checked for the whole org",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(citypes.AccountHealth),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/cloud_integrations/{cloud_provider}/accounts/{id}/health/alert_rules", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.cloudIntegrationHandler.GetAccountHealthAlertRules),
+		handler.OpenAPIDef{
+			ID:                  "GetAccountHealthAlertRules",
+			Tags:                []string{"cloudintegration"},
+			Summary:             "Get account health alert rules",
+			Description:         "This endpoint returns alert rules, ready to be created, firing when the metrics of an enabled service of the account stop arriving",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            make([]*ruletypes.PostableRule, 0),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v1/cloud_integrations/{cloud_provider}/services", handler.New(
 		provider.authzMiddleware.AdminAccess(provider.cloudIntegrationHandler.ListServicesMetadata),
 		handler.OpenAPIDef{
@@ -6,6 +6,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/http/handler"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/gorilla/mux"
 )
 
@@ -219,13 +220,125 @@ func (provider *provider) addInfraMonitoringRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v2/infra_monitoring/ecs_clusters", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.ListECSClusters),
+		handler.OpenAPIDef{
+			ID:                  "ListECSClusters",
+			Tags:                []string{"inframonitoring"},
+			Summary:             "List ECS Clusters for Infra Monitoring",
+			Description:         "Returns a paginated list of Amazon ECS clusters with key metrics aggregated from the task-level metrics of the awsecscontainermetrics receiver: average task CPU utilization (ecs.task.cpu.utilized), reserved CPU (ecs.task.cpu.reserved, vCPU), utilized memory (ecs.task.memory.utilized, MiB) and reserved memory (ecs.task.memory.reserved, MiB) summed across tasks, plus the distinct count of tasks under counts.tasks. Each row includes metadata attributes (aws.ecs.cluster.name, cloud.account.id, cloud.region). Supports filtering via a filter expression, custom groupBy to aggregate clusters by any attribute, ordering by cpu / cpu_reserved / memory / memory_reserved or by aws.ecs.cluster.name (only when groupBy is empty), and pagination via offset/limit. The response type is 'list' for the default aws.ecs.cluster.name grouping or 'grouped_list' for custom groupBy keys. Also reports whether the requested time range falls before the data retention boundary. Numeric metric fields (cpuUtilization, cpuReserved, memoryUtilized, memoryReserved) return -1 as a sentinel when no data is available for that field.",
+			Request:             new(inframonitoringtypes.PostableECSClusters),
+			RequestContentType:  "application/json",
+			Response:            new(inframonitoringtypes.ECSClusters),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/infra_monitoring/ecs_services", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.ListECSServices),
+		handler.OpenAPIDef{
+			ID:                  "ListECSServices",
+			Tags:                []string{"inframonitoring"},
+			Summary:             "List ECS Services for Infra Monitoring",
+			Description:         "Returns a paginated list of Amazon ECS services with key metrics aggregated from the task-level metrics of the awsecscontainermetrics receiver: average task CPU utilization (ecs.task.cpu.utilized), reserved CPU (ecs.task.cpu.reserved, vCPU), utilized memory (ecs.task.memory.utilized, MiB) and reserved memory (ecs.task.memory.reserved, MiB) summed across the service's tasks, plus the distinct count of tasks under counts.tasks. The receiver reports aws.ecs.service.name as 'undefined', so tasks are only attributed to a service once the attribute is set in the collector pipeline; tasks without a service are left out. Each row includes metadata attributes (aws.ecs.service.name, aws.ecs.cluster.name, aws.ecs.launchtype, cloud.account.id, cloud.region). Supports filtering via a filter expression, custom groupBy, ordering by cpu / cpu_reserved / memory / memory_reserved or by aws.ecs.service.name (only when groupBy is empty), and pagination via offset/limit. The response type is 'list' for the default (aws.ecs.service.name, aws.ecs.cluster.name) grouping or 'grouped_list' for custom groupBy keys. Also reports whether the requested time range falls before the data retention boundary. Numeric metric fields (cpuUtilization, cpuReserved, memoryUtilized, memoryReserved) return -1 as a sentinel when no data is available for that field.",
+			Request:             new(inframonitoringtypes.PostableECSServices),
+			RequestContentType:  "application/json",
+			Response:            new(inframonitoringtypes.ECSServices),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/infra_monitoring/ecs_tasks", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.ListECSTasks),
+		handler.OpenAPIDef{
+			ID:                  "ListECSTasks",
+			Tags:                []string{"inframonitoring"},
+			Summary:             "List ECS Tasks for Infra Monitoring",
+			Description:         "Returns a paginated list of Amazon ECS tasks with the task-level metrics of the awsecscontainermetrics receiver: CPU utilization (ecs.task.cpu.utilized), reserved CPU (ecs.task.cpu.reserved, vCPU), utilized and reserved memory (ecs.task.memory.utilized, ecs.task.memory.reserved, MiB) and network receive/transmit rates (ecs.task.network.rate.rx, ecs.task.network.rate.tx, bytes/s). Each task includes metadata attributes (aws.ecs.task.id, aws.ecs.task.arn, aws.ecs.task.family, aws.ecs.task.revision, aws.ecs.task.known_status, aws.ecs.launchtype, aws.ecs.service.name, aws.ecs.cluster.name, cloud.availability_zone, cloud.region). Supports filtering via a filter expression, custom groupBy, ordering by cpu / cpu_reserved / memory / memory_reserved / network_rx / network_tx or by aws.ecs.task.id (only when groupBy is empty), and pagination via offset/limit. The response type is 'list' for the default (aws.ecs.task.id, aws.ecs.cluster.name) grouping or 'grouped_list' for custom groupBy keys, where CPU utilization is averaged and the other metrics are summed across the tasks in the group. Also reports whether the requested time range falls before the data retention boundary. Numeric metric fields (cpuUtilization, cpuReserved, memoryUtilized, memoryReserved, networkRxRate, networkTxRate) return -1 as a sentinel when no data is available for that field.",
+			Request:             new(inframonitoringtypes.PostableECSTasks),
+			RequestContentType:  "application/json",
+			Response:            new(inframonitoringtypes.ECSTasks),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/infra_monitoring/docker_containers", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.ListDockerContainers),
+		handler.OpenAPIDef{
+			ID:                  "ListDockerContainers",
+			Tags:                []string{"inframonitoring"},
+			Summary:             "List Docker Containers for Infra Monitoring",
+			Description:         "Returns a paginated list of Docker containers with the metrics of the docker_stats receiver: CPU utilization (container.cpu.utilization, %), memory usage and limit (container.memory.usage.total, container.memory.usage.limit, bytes), memory usage as a percentage of the limit (container.memory.percent), and network receive/transmit rates (rate of container.network.io.usage.rx_bytes and tx_bytes summed across interfaces, bytes/s). Each container includes metadata attributes (container.name, container.id, container.image.name, container.runtime, container.hostname, host.name). Containers are identified by (container.name, host.name), which is stable across container restarts unlike container.id; host.name requires the resourcedetection processor. Supports filtering via a filter expression, custom groupBy, ordering by cpu / memory / memory_limit / memory_percent / network_rx / network_tx or by container.name (only when groupBy is empty), and pagination via offset/limit. The response type is 'list' for the default grouping or 'grouped_list' for custom groupBy keys, where the utilization percentages are averaged and the other metrics are summed across the containers in the group. Also reports whether the requested time range falls before the data retention boundary. Numeric metric fields (cpuUtilization, memoryUsage, memoryLimit, memoryPercent, networkRxRate, networkTxRate) return -1 as a sentinel when no data is available for that field.",
+			Request:             new(inframonitoringtypes.PostableDockerContainers),
+			RequestContentType:  "application/json",
+			Response:            new(inframonitoringtypes.DockerContainers),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/infra_monitoring/entity_graph", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.GetEntityGraph),
+		handler.OpenAPIDef{
+			ID:                  "GetEntityGraph",
+			Tags:                []string{"inframonitoring"},
+			Summary:             "Get Kubernetes Entity Graph for Infra Monitoring",
+			Description:         "Returns the relationships of a Kubernetes entity (cluster, node, namespace, deployment, replicaset, statefulset, daemonset, cronjob, job, pod, container or volume) identified by its type and k8s.* resource attributes: its parents (e.g. the replicaset owning a pod and the node it runs on), its children (e.g. the containers of a pod and the PVCs it mounts) and its siblings (the entities of the same type sharing its primary parent). Relationships are derived from the k8s.* resource attributes of the series reported in the time range, so entities which churned during it are included. Each entity carries its health metrics over the time range, keyed like the orderBy keys of the list API of its type; replicasets and cronjobs carry none. Pods, nodes and workloads also carry eventCounts, the total and Warning counts of the Kubernetes events about them (collected by the k8sobjectsreceiver and stored as logs), and events lists the 50 most recent events about the entity itself. Each relation is capped to 500 entities, with a warning when truncated. Returns 404 when no entity matches and 400 when the attributes match several entities.",
+			Request:             new(inframonitoringtypes.PostableEntityGraph),
+			RequestContentType:  "application/json",
+			Response:            new(inframonitoringtypes.EntityGraph),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/infra_monitoring/alert_templates/k8s_warning_events", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.GetK8sWarningEventsAlertTemplate),
+		handler.OpenAPIDef{
+			ID:                  "GetK8sWarningEventsAlertTemplate",
+			Tags:                []string{"inframonitoring"},
+			Summary:             "Get Kubernetes Warning Events Alert Template",
+			Description:         "Returns a built-in logs-based alert rule firing when the Kubernetes Warning events collected by the k8sobjectsreceiver (stored as logs with k8s.resource.name = 'events') spike, counted per k8s.cluster.name over a rolling 5 minute window. The rule is a template: it is not created, and is meant to be adjusted and posted to the rules API.",
+			Response:            new(ruletypes.PostableRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusUnauthorized},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v2/infra_monitoring/checks", handler.New(
 		provider.authzMiddleware.ViewAccess(provider.infraMonitoringHandler.GetChecks),
 		handler.OpenAPIDef{
 			ID:                  "GetChecks",
 			Tags:                []string{"inframonitoring"},
 			Summary:             "Run Infra Monitoring Setup Checks",
-			Description:         "Checks whether the metrics and attributes required to power the infra-monitoring section selected by the 'type' query parameter (hosts, processes, pods, nodes, deployments, daemonsets, statefulsets, jobs, namespaces, clusters, volumes) are being received. For each collector receiver or processor that contributes required metrics or attributes, lists what is present and what is missing, with a prebuilt user-facing message and a docs link per missing component. Default-enabled metrics are those expected as soon as the receiver is configured; optional metrics require 'enabled: true' in receiver config. 'ready' is true only when every missing list is empty.",
+			Description:         "Checks whether the metrics and attributes required to power the infra-monitoring section selected by the 'type' query parameter (hosts, processes, pods, nodes, deployments, daemonsets, statefulsets, jobs, namespaces, clusters, volumes, kube_containers, ecs_clusters, ecs_services, ecs_tasks, docker_containers) are being received; for k8s_events, whether the k8sobjectsreceiver is logging Kubernetes events, from the log attributes it sets. For each collector receiver or processor that contributes required metrics or attributes, lists what is present and what is missing, with a prebuilt user-facing message and a docs link per missing component. Default-enabled metrics are those expected as soon as the receiver is configured; optional metrics require 'enabled: true' in receiver config. 'ready' is true only when every missing list is empty.",
 			RequestQuery:        new(inframonitoringtypes.PostableChecks),
 			Response:            new(inframonitoringtypes.Checks),
 			ResponseContentType: "application/json",
@@ -0,0 +1,155 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addLLMCostRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/llm_cost/breakdown", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.llmCostHandler.GetBreakdown),
+		handler.OpenAPIDef{
+			ID:                  "GetLLMCostBreakdown",
+			Tags:                []string{"llmcost"},
+			Summary:             "Get LLM cost breakdown",
+			Description:         "Returns the spend of the gen_ai spans over the window, from the _signoz.gen_ai.*_cost attributes the LLM pricing processor attaches to them, grouped by any attributes such as gen_ai.request.model, gen_ai.provider.name, gen_ai.agent.name, gen_ai.tool.name or a custom one. Each group has its total, input, output and cache costs and its spend over time at the step. Costs are on the priced LLM spans, so grouping by an attribute only splits the spend when it is set on them.",
+			Request:             new(llmcosttypes.PostableCostBreakdown),
+			RequestContentType:  "application/json",
+			Response:            new(llmcosttypes.GettableCostBreakdown),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_cost/budgets", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.llmCostHandler.ListBudgets),
+		handler.OpenAPIDef{
+			ID:                  "ListLLMBudgets",
+			Tags:                []string{"llmcost"},
+			Summary:             "List LLM budgets",
+			Description:         "Returns the monthly LLM budgets of the authenticated org, each with its month-to-date spend and the spend forecast for the end of the month.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(llmcosttypes.GettableBudgets),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_cost/budgets", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.llmCostHandler.CreateBudget),
+		handler.OpenAPIDef{
+			ID:                  "CreateLLMBudget",
+			Tags:                []string{"llmcost"},
+			Summary:             "Create an LLM budget",
+			Description:         "Creates a monthly budget for the spend of the gen_ai spans carrying an attribute value, e.g. team = search. Months are calendar months in UTC.",
+			Request:             new(llmcosttypes.PostableBudget),
+			RequestContentType:  "application/json",
+			Response:            new(llmcosttypes.Budget),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_cost/budgets/{id}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.llmCostHandler.GetBudget),
+		handler.OpenAPIDef{
+			ID:                  "GetLLMBudget",
+			Tags:                []string{"llmcost"},
+			Summary:             "Get an LLM budget",
+			Description:         "Returns an LLM budget by ID with its month-to-date spend and the spend forecast for the end of the month.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(llmcosttypes.GettableBudget),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_cost/budgets/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.llmCostHandler.UpdateBudget),
+		handler.OpenAPIDef{
+			ID:                  "UpdateLLMBudget",
+			Tags:                []string{"llmcost"},
+			Summary:             "Update an LLM budget",
+			Description:         "Replaces the name, attribute and monthly limit of an LLM budget. The alert rule of the budget is updated to its new name, filter and thresholds, keeping its channels.",
+			Request:             new(llmcosttypes.PostableBudget),
+			RequestContentType:  "application/json",
+			Response:            new(llmcosttypes.Budget),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPut).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_cost/budgets/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.llmCostHandler.DeleteBudget),
+		handler.OpenAPIDef{
+			ID:                  "DeleteLLMBudget",
+			Tags:                []string{"llmcost"},
+			Summary:             "Delete an LLM budget",
+			Description:         "Deletes an LLM budget and its alert rule.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            nil,
+			ResponseContentType: "",
+			SuccessStatusCode:   http.StatusNoContent,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodDelete).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_cost/budgets/{id}/alert_rule", handler.New(
+		provider.authzMiddleware.EditAccess(provider.llmCostHandler.CreateBudgetAlertRule),
+		handler.OpenAPIDef{
+			ID:                  "CreateLLMBudgetAlertRule",
+			Tags:                []string{"llmcost"},
+			Summary:             "Create an LLM budget alert rule",
+			Description:         "Creates a threshold alert rule on the month-to-date spend of the budget, labelled llm_budget_id. Its builder_ai_query sums _signoz.gen_ai.total_cost hourly over a cumulative window restarting on the 1st of every month (UTC), firing warning at 80% of the monthly limit and critical at the limit. A budget has at most one alert rule, which follows the updates of the budget and is deleted with it.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(ruletypes.Rule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleEditor),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -69,6 +69,86 @@ func (provider *provider) addLLMPricingRuleRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v1/llm_pricing_rules/catalog", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.llmPricingRuleHandler.GetCatalog),
+		handler.OpenAPIDef{
+			ID:                  "GetLLMPricingCatalog",
+			Tags:                []string{"llmpricingrules"},
+			Summary:             "Get the pricing catalog",
+			Description:         "Returns the pricing catalog the rules are synced from: the imported catalog when it is newer than the one bundled with the release, the bundled one otherwise.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(llmpricingruletypes.GettableLLMPricingCatalog),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_pricing_rules/catalog", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.llmPricingRuleHandler.ImportCatalog),
+		handler.OpenAPIDef{
+			ID:                  "ImportLLMPricingCatalog",
+			Tags:                []string{"llmpricingrules"},
+			Summary:             "Import a pricing catalog",
+			Description:         "Imports a pricing catalog file. Its version must be newer than the current catalog. The rules are not changed until the catalog is synced.",
+			Request:             new(llmpricingruletypes.LLMPricingCatalog),
+			RequestContentType:  "application/json",
+			Response:            new(llmpricingruletypes.GettableLLMPricingCatalog),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPut).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_pricing_rules/catalog/diff", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.llmPricingRuleHandler.GetCatalogDiff),
+		handler.OpenAPIDef{
+			ID:                  "GetLLMPricingCatalogDiff",
+			Tags:                []string{"llmpricingrules"},
+			Summary:             "Get the pricing catalog diff",
+			Description:         "Proposes the pricing rules to create or update from the catalog, with the changed fields. Changed rules with isOverride set are proposed as keep_override and are not changed by a sync.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(llmpricingruletypes.GettableLLMPricingCatalogDiff),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/llm_pricing_rules/catalog/sync", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.llmPricingRuleHandler.SyncCatalog),
+		handler.OpenAPIDef{
+			ID:                  "SyncLLMPricingCatalog",
+			Tags:                []string{"llmpricingrules"},
+			Summary:             "Sync the pricing catalog",
+			Description:         "Applies the proposals of the catalog diff, all of them or the ones of the given sourceIds, and pushes the updated rules to the collectors. Returns the applied proposals.",
+			Request:             new(llmpricingruletypes.PostableLLMPricingCatalogSync),
+			RequestContentType:  "application/json",
+			Response:            new(llmpricingruletypes.GettableLLMPricingCatalogDiff),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v1/llm_pricing_rules/{id}", handler.New(
 		provider.authzMiddleware.ViewAccess(provider.llmPricingRuleHandler.Get),
 		handler.OpenAPIDef{
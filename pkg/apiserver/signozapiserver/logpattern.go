@@ -0,0 +1,33 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/logpatterntypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addLogPatternRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/logs/patterns", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.logPatternHandler.GetPatterns),
+		handler.OpenAPIDef{
+			ID:                  "GetLogPatterns",
+			Tags:                []string{"logs"},
+			Summary:             "Get log patterns",
+			Description:         "Clusters the bodies of the logs matching the filter into templates in which the varying tokens are replaced by <*>. Every pattern carries its count, a sample log ID and its trend over time. Counts are estimated from a sample when too many logs match. Optionally flags the patterns not seen over the previous period as new.",
+			Request:             new(logpatterntypes.PostableLogPatterns),
+			RequestContentType:  "application/json",
+			Response:            new(logpatterntypes.GettableLogPatterns),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
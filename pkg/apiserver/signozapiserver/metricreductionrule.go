@@ -101,6 +101,43 @@ func (provider *provider) addMetricReductionRuleRoutes(router *mux.Router) error
 		return err
 	}
 
+	if err := router.Handle("/api/v2/metric_reduction_rules/recommendations", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.metricReductionRuleHandler.Recommendations),
+		handler.OpenAPIDef{
+			ID:                  "ListMetricReductionRuleRecommendations",
+			Tags:                []string{"metrics"},
+			Summary:             "List metric reduction rule recommendations",
+			Description:         "Recommends drop rules for the metrics with the most series, dropping high cardinality labels that no dashboard, alert or recent query reads, with the estimated series savings.",
+			RequestQuery:        new(metricreductionruletypes.ListReductionRuleRecommendationsParams),
+			Response:            new(metricreductionruletypes.GettableReductionRuleRecommendations),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotImplemented, http.StatusUnavailableForLegalReasons, http.StatusInternalServerError},
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v2/metric_reduction_rules/recommendations", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.metricReductionRuleHandler.ApplyRecommendation),
+		handler.OpenAPIDef{
+			ID:                  "ApplyMetricReductionRuleRecommendation",
+			Tags:                []string{"metrics"},
+			Summary:             "Apply a metric reduction rule recommendation",
+			Description:         "Recomputes the recommendation for a metric and creates its rule; fails if no rule is recommended for the metric anymore or it already has a rule.",
+			Request:             new(metricreductionruletypes.PostableReductionRuleRecommendation),
+			RequestContentType:  "application/json",
+			Response:            new(metricreductionruletypes.GettableReductionRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusNotImplemented, http.StatusUnavailableForLegalReasons, http.StatusInternalServerError},
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v2/metric_reduction_rules/{id}", handler.New(
 		provider.authzMiddleware.ViewAccess(provider.metricReductionRuleHandler.GetByID),
 		handler.OpenAPIDef{
@@ -68,6 +68,25 @@ func (provider *provider) addMetricsExplorerRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v2/metrics/cardinality", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.metricsExplorerHandler.GetCardinality),
+		handler.OpenAPIDef{
+			ID:                  "GetMetricsCardinality",
+			Tags:                []string{"metrics"},
+			Summary:             "Get metrics cardinality",
+			Description:         "This endpoint ranks the label keys of a metric, or of all metrics, by distinct values and contribution to the series count, with their growth over time, recently seen new values and a suggested reduction rule preview",
+			Request:             new(metricsexplorertypes.CardinalityRequest),
+			RequestContentType:  "application/json",
+			Response:            new(metricsexplorertypes.CardinalityResponse),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	if err := router.Handle("/api/v2/metrics/attributes", handler.New(
 		provider.authzMiddleware.ViewAccess(provider.metricsExplorerHandler.GetMetricAttributes),
 		handler.OpenAPIDef{
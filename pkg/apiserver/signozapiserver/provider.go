@@ -18,18 +18,23 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/dashboard"
 	"github.com/SigNoz/signoz/pkg/modules/fields"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule"
+	"github.com/SigNoz/signoz/pkg/modules/logpattern"
 	"github.com/SigNoz/signoz/pkg/modules/metricreductionrule"
 	"github.com/SigNoz/signoz/pkg/modules/metricsexplorer"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/modules/preference"
 	"github.com/SigNoz/signoz/pkg/modules/promote"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/modules/rollup"
 	"github.com/SigNoz/signoz/pkg/modules/rulestatehistory"
 	"github.com/SigNoz/signoz/pkg/modules/savedview"
 	"github.com/SigNoz/signoz/pkg/modules/serviceaccount"
+	"github.com/SigNoz/signoz/pkg/modules/servicetopology"
 	"github.com/SigNoz/signoz/pkg/modules/session"
 	"github.com/SigNoz/signoz/pkg/modules/spanmapper"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule"
 	"github.com/SigNoz/signoz/pkg/modules/tracedetail"
 	"github.com/SigNoz/signoz/pkg/modules/user"
 	"github.com/SigNoz/signoz/pkg/querier"
@@ -76,10 +81,15 @@ type provider struct {
 	spanMapperHandler          spanmapper.Handler
 	alertmanagerHandler        alertmanager.Handler
 	traceDetailHandler         tracedetail.Handler
+	serviceTopologyHandler     servicetopology.Handler
+	spanMetricsRuleHandler     spanmetricsrule.Handler
+	logPatternHandler          logpattern.Handler
 	rulerHandler               ruler.Handler
 	llmPricingRuleHandler      llmpricingrule.Handler
 	statsHandler               statsreporter.Handler
 	savedViewHandler           savedview.Handler
+	rollupHandler              rollup.Handler
+	llmCostHandler             llmcost.Handler
 }
 
 func NewFactory(
@@ -115,9 +125,14 @@ func NewFactory(
 	alertmanagerHandler alertmanager.Handler,
 	llmPricingRuleHandler llmpricingrule.Handler,
 	traceDetailHandler tracedetail.Handler,
+	serviceTopologyHandler servicetopology.Handler,
+	spanMetricsRuleHandler spanmetricsrule.Handler,
+	logPatternHandler logpattern.Handler,
 	rulerHandler ruler.Handler,
 	statsHandler statsreporter.Handler,
 	savedViewHandler savedview.Handler,
+	rollupHandler rollup.Handler,
+	llmCostHandler llmcost.Handler,
 ) factory.ProviderFactory[apiserver.APIServer, apiserver.Config] {
 	return factory.NewProviderFactory(factory.MustNewName("signoz"), func(ctx context.Context, providerSettings factory.ProviderSettings, config apiserver.Config) (apiserver.APIServer, error) {
 		return newProvider(
@@ -156,9 +171,14 @@ func NewFactory(
 			alertmanagerHandler,
 			llmPricingRuleHandler,
 			traceDetailHandler,
+			serviceTopologyHandler,
+			spanMetricsRuleHandler,
+			logPatternHandler,
 			rulerHandler,
 			statsHandler,
 			savedViewHandler,
+			rollupHandler,
+			llmCostHandler,
 		)
 	})
 }
@@ -199,9 +219,14 @@ func newProvider(
 	alertmanagerHandler alertmanager.Handler,
 	llmPricingRuleHandler llmpricingrule.Handler,
 	traceDetailHandler tracedetail.Handler,
+	serviceTopologyHandler servicetopology.Handler,
+	spanMetricsRuleHandler spanmetricsrule.Handler,
+	logPatternHandler logpattern.Handler,
 	rulerHandler ruler.Handler,
 	statsHandler statsreporter.Handler,
 	savedViewHandler savedview.Handler,
+	rollupHandler rollup.Handler,
+	llmCostHandler llmcost.Handler,
 ) (apiserver.APIServer, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/apiserver/signozapiserver")
 	router := mux.NewRouter().UseEncodedPath()
@@ -240,10 +265,15 @@ func newProvider(
 		spanMapperHandler:          spanMapperHandler,
 		alertmanagerHandler:        alertmanagerHandler,
 		traceDetailHandler:         traceDetailHandler,
+		serviceTopologyHandler:     serviceTopologyHandler,
+		spanMetricsRuleHandler:     spanMetricsRuleHandler,
+		logPatternHandler:          logPatternHandler,
 		rulerHandler:               rulerHandler,
 		llmPricingRuleHandler:      llmPricingRuleHandler,
 		statsHandler:               statsHandler,
 		savedViewHandler:           savedViewHandler,
+		rollupHandler:              rollupHandler,
+		llmCostHandler:             llmCostHandler,
 	}
 
 	provider.authzMiddleware = middleware.NewAuthZ(settings.Logger(), orgGetter, authzService)
@@ -372,6 +402,18 @@ func (provider *provider) AddToRouter(router *mux.Router) error {
 		return err
 	}
 
+	if err := provider.addServiceTopologyRoutes(router); err != nil {
+		return err
+	}
+
+	if err := provider.addSpanMetricsRuleRoutes(router); err != nil {
+		return err
+	}
+
+	if err := provider.addLogPatternRoutes(router); err != nil {
+		return err
+	}
+
 	if err := provider.addRulerRoutes(router); err != nil {
 		return err
 	}
@@ -384,6 +426,14 @@ func (provider *provider) AddToRouter(router *mux.Router) error {
 		return err
 	}
 
+	if err := provider.addRollupRoutes(router); err != nil {
+		return err
+	}
+
+	if err := provider.addLLMCostRoutes(router); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -27,8 +27,9 @@ func (provider *provider) addQuerierRoutes(router *mux.Router) error {
 		ID:                 "QueryRangeV5",
 		Tags:               []string{"querier"},
 		Summary:            "Query range",
-		Description:        "Execute a composite query over a time range. Supports builder queries (traces, logs, metrics), formulas, trace operators, PromQL, and ClickHouse SQL.",
+		Description:        "Execute a composite query over a time range. Supports builder queries (traces, logs, metrics), formulas, trace operators, PromQL, and ClickHouse SQL. Pass ?profile=true to also get, for each query, the ClickHouse statements it ran with their query_id, rows and bytes read and granule index analysis, the ranges the bucket cache served and missed, and the time spent in ClickHouse versus post-processing.",
 		Request:            new(qbtypes.QueryRangeRequest),
+		RequestQuery:       new(qbtypes.QueryRangeParams),
 		RequestContentType: "application/json",
 		RequestExamples: []handler.OpenAPIExample{
 			{
@@ -507,5 +508,30 @@ func (provider *provider) addQuerierRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v5/pipe_query/validate", handler.New(provider.authzMiddleware.ViewAccess(provider.querierHandler.ValidatePipeQuery), handler.OpenAPIDef{
+		ID:                 "ValidatePipeQuery",
+		Tags:               []string{"querier"},
+		Summary:            "Validate pipe query",
+		Description:        "Validate a pipe query and compile it into a builder query. Syntax errors are returned with their line and column in the pipe query. Valid queries come with the builder query and the request type to run it with on the query range endpoint.",
+		Request:            new(qbtypes.PostablePipeQuery),
+		RequestContentType: "application/json",
+		RequestExamples: []handler.OpenAPIExample{
+			{
+				Name:    "logs_stats",
+				Summary: "Count error logs per pod",
+				Value: map[string]any{
+					"query": `source=logs service.name="api" severity_text="ERROR" | stats count() by k8s.pod.name | sort -count | head 10`,
+				},
+			},
+		},
+		Response:            new(qbtypes.GettablePipeQuery),
+		ResponseContentType: "application/json",
+		SuccessStatusCode:   http.StatusOK,
+		ErrorStatusCodes:    []int{http.StatusBadRequest},
+		SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+	})).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,134 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addRollupRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/rollups", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.rollupHandler.List),
+		handler.OpenAPIDef{
+			ID:                  "ListRollups",
+			Tags:                []string{"rollup"},
+			Summary:             "List rollups",
+			Description:         "Returns all rollups of the authenticated org, the materialized views pre-aggregating logs and traces for a query shape.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(rolluptypes.GettableRollups),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/rollups", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.rollupHandler.Create),
+		handler.OpenAPIDef{
+			ID:                  "CreateRollup",
+			Tags:                []string{"rollup"},
+			Summary:             "Create a rollup",
+			Description:         "Creates the materialized view pre-aggregating the rows matching the filter by interval and group by keys. Builder queries whose filter, group by keys, aggregations and step match the rollup are served from it from the second interval after it is created. Rows ingested before are not backfilled, earlier windows are always read from the raw rows.",
+			Request:             new(rolluptypes.PostableRollup),
+			RequestContentType:  "application/json",
+			Response:            new(rolluptypes.GettableRollup),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/rollups/candidates", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.rollupHandler.ListCandidates),
+		handler.OpenAPIDef{
+			ID:                  "ListRollupCandidates",
+			Tags:                []string{"rollup"},
+			Summary:             "List rollup candidates",
+			Description:         "Returns the logs and traces aggregation query shapes run the most by the org over the last day, with the rollup serving each if any.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(rolluptypes.GettableCandidates),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/rollups/{id}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.rollupHandler.Get),
+		handler.OpenAPIDef{
+			ID:                  "GetRollup",
+			Tags:                []string{"rollup"},
+			Summary:             "Get a rollup",
+			Description:         "Returns a rollup by ID.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(rolluptypes.GettableRollup),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/rollups/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.rollupHandler.Delete),
+		handler.OpenAPIDef{
+			ID:                  "DeleteRollup",
+			Tags:                []string{"rollup"},
+			Summary:             "Delete a rollup",
+			Description:         "Stops serving queries from the rollup, drops its view and tables and deletes it.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            nil,
+			ResponseContentType: "",
+			SuccessStatusCode:   http.StatusNoContent,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodDelete).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/rollups/{id}/verify", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.rollupHandler.Verify),
+		handler.OpenAPIDef{
+			ID:                  "VerifyRollup",
+			Tags:                []string{"rollup"},
+			Summary:             "Verify a rollup",
+			Description:         "Compares the time series of the rollup with the ones computed from the raw rows over the whole intervals of the window after the rollup became active, and returns the mismatches.",
+			Request:             new(rolluptypes.PostableVerification),
+			RequestContentType:  "application/json",
+			Response:            new(rolluptypes.GettableVerification),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
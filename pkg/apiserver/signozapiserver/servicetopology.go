@@ -0,0 +1,33 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/servicetopologytypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addServiceTopologyRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/service_topology", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.serviceTopologyHandler.GetTopology),
+		handler.OpenAPIDef{
+			ID:                  "GetServiceTopology",
+			Tags:                []string{"servicetopology"},
+			Summary:             "Get service topology",
+			Description:         "Derives the service dependency graph from spans of the traces matching the filter. Edges are built from parent and child spans of different services and from database, messaging and peer service client spans. Every node and edge carries its request rate, error rate and latency percentiles, and optionally its change against the previous period. Each query reads at most 100 million spans; busier periods are rejected and have to be narrowed or filtered.",
+			Request:             new(servicetopologytypes.PostableTopology),
+			RequestContentType:  "application/json",
+			Response:            new(servicetopologytypes.GettableTopology),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package signozapiserver
+
+import (
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/handler"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/spanmetricsruletypes"
+	"github.com/gorilla/mux"
+)
+
+func (provider *provider) addSpanMetricsRuleRoutes(router *mux.Router) error {
+	if err := router.Handle("/api/v1/span_metrics_rules", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.spanMetricsRuleHandler.List),
+		handler.OpenAPIDef{
+			ID:                  "ListSpanMetricsRules",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "List span metrics rules",
+			Description:         "Returns all span metrics rules of the authenticated org.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(spanmetricsruletypes.GettableSpanMetricsRules),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/span_metrics_rules", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.spanMetricsRuleHandler.Create),
+		handler.OpenAPIDef{
+			ID:                  "CreateSpanMetricsRule",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "Create a span metrics rule",
+			Description:         "Creates a rule generating RED metrics from spans. Dimensions whose cardinality over the last day is too high are rejected.",
+			Request:             new(spanmetricsruletypes.PostableSpanMetricsRule),
+			RequestContentType:  "application/json",
+			Response:            new(spanmetricsruletypes.GettableSpanMetricsRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusCreated,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/span_metrics_rules/deploy", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.spanMetricsRuleHandler.Deploy),
+		handler.OpenAPIDef{
+			ID:                  "DeploySpanMetricsRules",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "Deploy span metrics rules",
+			Description:         "Pushes the enabled span metrics rules of the org to its collectors and starts a new rollout.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(spanmetricsruletypes.GettableRollout),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusAccepted,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusInternalServerError},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/span_metrics_rules/rollout", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.spanMetricsRuleHandler.GetRollout),
+		handler.OpenAPIDef{
+			ID:                  "GetSpanMetricsRulesRollout",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "Get the span metrics rules rollout",
+			Description:         "Returns the per-agent status of the latest deployment of the span metrics rules.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(spanmetricsruletypes.GettableRollout),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/span_metrics_rules/{id}", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.spanMetricsRuleHandler.Get),
+		handler.OpenAPIDef{
+			ID:                  "GetSpanMetricsRule",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "Get a span metrics rule",
+			Description:         "Returns a span metrics rule by ID.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(spanmetricsruletypes.GettableSpanMetricsRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/span_metrics_rules/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.spanMetricsRuleHandler.Update),
+		handler.OpenAPIDef{
+			ID:                  "UpdateSpanMetricsRule",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "Update a span metrics rule",
+			Description:         "Replaces the definition of a span metrics rule. The change reaches the collectors on the next deployment.",
+			Request:             new(spanmetricsruletypes.PostableSpanMetricsRule),
+			RequestContentType:  "application/json",
+			Response:            new(spanmetricsruletypes.GettableSpanMetricsRule),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodPut).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/span_metrics_rules/{id}", handler.New(
+		provider.authzMiddleware.AdminAccess(provider.spanMetricsRuleHandler.Delete),
+		handler.OpenAPIDef{
+			ID:                  "DeleteSpanMetricsRule",
+			Tags:                []string{"spanmetricsrule"},
+			Summary:             "Delete a span metrics rule",
+			Description:         "Deletes a span metrics rule. The change reaches the collectors on the next deployment.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            nil,
+			ResponseContentType: "",
+			SuccessStatusCode:   http.StatusNoContent,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			Deprecated:          false,
+			SecuritySchemes:     newSecuritySchemes(types.RoleAdmin),
+		},
+	)).Methods(http.MethodDelete).GetError(); err != nil {
+		return err
+	}
+
+	return nil
+}
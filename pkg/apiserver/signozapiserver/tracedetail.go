@@ -67,5 +67,62 @@ func (provider *provider) addTraceDetailRoutes(router *mux.Router) error {
 		return err
 	}
 
+	if err := router.Handle("/api/v1/traces/compare", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.traceDetailHandler.CompareTraces),
+		handler.OpenAPIDef{
+			ID:                  "CompareTraces",
+			Tags:                []string{"tracedetail"},
+			Summary:             "Compare two traces",
+			Description:         "Aligns the spans of two traces by service and operation path and returns a merged tree with added, missing and repeated spans along with self-time and total-time deltas.",
+			Request:             new(spantypes.PostableTraceComparison),
+			RequestContentType:  "application/json",
+			Response:            new(spantypes.GettableTraceComparison),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/traces/{traceID}/critical_path", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.traceDetailHandler.GetCriticalPath),
+		handler.OpenAPIDef{
+			ID:                  "GetCriticalPath",
+			Tags:                []string{"tracedetail"},
+			Summary:             "Get critical path for a trace",
+			Description:         "Returns the chain of spans, or parts of spans, on the longest blocking path of a trace along with each service's and operation's contribution to it.",
+			Request:             nil,
+			RequestContentType:  "",
+			Response:            new(spantypes.GettableTraceCriticalPath),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest, http.StatusNotFound},
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodGet).GetError(); err != nil {
+		return err
+	}
+
+	if err := router.Handle("/api/v1/traces/critical_path", handler.New(
+		provider.authzMiddleware.ViewAccess(provider.traceDetailHandler.GetAggregatedCriticalPath),
+		handler.OpenAPIDef{
+			ID:                  "GetAggregatedCriticalPath",
+			Tags:                []string{"tracedetail"},
+			Summary:             "Get aggregated critical path for traces",
+			Description:         "Aggregates each service's and operation's contribution to the critical path over the slowest traces matching a filter.",
+			Request:             new(spantypes.PostableCriticalPathAggregation),
+			RequestContentType:  "application/json",
+			Response:            new(spantypes.GettableCriticalPathAggregation),
+			ResponseContentType: "application/json",
+			SuccessStatusCode:   http.StatusOK,
+			ErrorStatusCodes:    []int{http.StatusBadRequest},
+			SecuritySchemes:     newSecuritySchemes(types.RoleViewer),
+		},
+	)).Methods(http.MethodPost).GetError(); err != nil {
+		return err
+	}
+
 	return nil
 }
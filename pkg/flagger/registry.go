@@ -14,6 +14,7 @@ var (
 	FeatureEnableMetricsReduction    = featuretypes.MustNewName("enable_metrics_reduction")
 	FeatureUsePrometheusClickhouseV2 = featuretypes.MustNewName("use_prometheus_clickhouse_v2")
 	FeatureResolveSemconvFamilies    = featuretypes.MustNewName("resolve_semconv_families")
+	FeatureUseNativeExpHistograms    = featuretypes.MustNewName("use_native_exp_histograms")
 )
 
 func MustNewRegistry() featuretypes.Registry {
@@ -106,6 +107,14 @@ func MustNewRegistry() featuretypes.Registry {
 			DefaultVariant: featuretypes.MustNewName("disabled"),
 			Variants:       featuretypes.NewBooleanVariants(),
 		},
+		&featuretypes.Feature{
+			Name:           FeatureUseNativeExpHistograms,
+			Kind:           featuretypes.KindBoolean,
+			Stage:          featuretypes.StageExperimental,
+			Description:    "Controls whether percentiles of exponential histogram metrics merge their DDSketches per temporality instead of merging every point in the step",
+			DefaultVariant: featuretypes.MustNewName("disabled"),
+			Variants:       featuretypes.NewBooleanVariants(),
+		},
 	)
 	if err != nil {
 		panic(err)
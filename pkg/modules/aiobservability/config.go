@@ -0,0 +1,38 @@
+package aiobservability
+
+import (
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/aiobservabilitytypes"
+)
+
+type Config struct {
+	// Conversations configures the conversations reconstructed from the gen_ai spans.
+	Conversations ConversationsConfig `mapstructure:"conversations"`
+}
+
+type ConversationsConfig struct {
+	// Redaction is how the message bodies are redacted for the roles below admin: none,
+	// full or patterns. Searching the messages is forbidden to them unless it is none.
+	Redaction string `mapstructure:"redaction"`
+
+	// Patterns are the regular expressions replaced in the message bodies by the patterns
+	// redaction.
+	Patterns []string `mapstructure:"patterns"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("ai_observability"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return &Config{
+		Conversations: ConversationsConfig{
+			Redaction: aiobservabilitytypes.RedactionNone.StringValue(),
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	_, err := aiobservabilitytypes.NewRedactor(c.Conversations.Redaction, c.Conversations.Patterns)
+	return err
+}
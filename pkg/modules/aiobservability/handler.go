@@ -8,4 +8,10 @@ type Handler interface {
 
 	// Gets the values the AI observability explorer can filter a field key on
 	GetFieldsValues(http.ResponseWriter, *http.Request)
+
+	// Gets the conversation reconstructed from the gen_ai spans of a trace or a session
+	GetConversation(http.ResponseWriter, *http.Request)
+
+	// Searches the conversations by the content of their messages
+	SearchConversations(http.ResponseWriter, *http.Request)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/authz"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/binding"
 	"github.com/SigNoz/signoz/pkg/http/render"
@@ -12,17 +13,22 @@ import (
 	"github.com/SigNoz/signoz/pkg/telemetryschema/aitelemetryschema"
 	"github.com/SigNoz/signoz/pkg/types/aiobservabilitytypes"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/coretypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type handler struct {
 	telemetryMetadataStore telemetrytypes.MetadataStore
+	module                 aiobservability.Module
+	authz                  authz.AuthZ
 }
 
-func NewHandler(telemetryMetadataStore telemetrytypes.MetadataStore) aiobservability.Handler {
+func NewHandler(telemetryMetadataStore telemetrytypes.MetadataStore, module aiobservability.Module, authz authz.AuthZ) aiobservability.Handler {
 	return &handler{
 		telemetryMetadataStore: telemetryMetadataStore,
+		module:                 module,
+		authz:                  authz,
 	}
 }
 
@@ -103,3 +109,73 @@ func (handler *handler) GetFieldsValues(rw http.ResponseWriter, req *http.Reques
 		Complete: complete,
 	})
 }
+
+func (handler *handler) GetConversation(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	var params aiobservabilitytypes.GetConversationParams
+	if err := binding.Query.BindQuery(req.URL.Query(), &params); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	conversation, err := handler.module.GetConversation(ctx, orgID, handler.isAdmin(ctx, claims, orgID), &params)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, conversation)
+}
+
+func (handler *handler) SearchConversations(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	orgID := valuer.MustNewUUID(claims.OrgID)
+
+	postable := new(aiobservabilitytypes.PostableConversationSearch)
+	if err := binding.JSON.BindBody(req.Body, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	matches, err := handler.module.SearchConversations(ctx, orgID, handler.isAdmin(ctx, claims, orgID), postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, matches)
+}
+
+// isAdmin reports whether the caller has the admin role, which the message bodies are
+// never redacted for.
+func (handler *handler) isAdmin(ctx context.Context, claims authtypes.Claims, orgID valuer.UUID) bool {
+	selectors := []coretypes.Selector{
+		coretypes.TypeRole.MustSelector(authtypes.SigNozAdminRoleName),
+	}
+	err := handler.authz.CheckWithTupleCreation(
+		ctx,
+		claims,
+		orgID,
+		authtypes.Relation{Verb: coretypes.VerbAssignee},
+		coretypes.NewResourceRole(),
+		selectors,
+		selectors,
+	)
+	return err == nil
+}
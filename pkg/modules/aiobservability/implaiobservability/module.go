@@ -0,0 +1,249 @@
+package implaiobservability
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/aiobservability"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/aitelemetryschema"
+	"github.com/SigNoz/signoz/pkg/types/aiobservabilitytypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const conversationQueryName = "conversation"
+
+// spanFields are the span columns a conversation is reconstructed from, besides the
+// timestamp, trace_id and span_id every span list selects.
+var spanFields = []string{"parent_span_id", "name", "duration_nano", "has_error", "status_message"}
+
+// genAIFields are the gen_ai attributes a conversation is reconstructed from.
+var genAIFields = []string{
+	aiobservabilitytypes.GenAIOperationName,
+	aiobservabilitytypes.GenAIRequestModel,
+	aiobservabilitytypes.GenAIProviderName,
+	aiobservabilitytypes.GenAIAgentName,
+	aiobservabilitytypes.GenAIToolName,
+	aiobservabilitytypes.GenAIInputMessages,
+	aiobservabilitytypes.GenAIOutputMessages,
+	aiobservabilitytypes.GenAIUsageInputTokens,
+	aiobservabilitytypes.GenAIUsageOutputTokens,
+	aiobservabilitytypes.SignozGenAITotalCost,
+}
+
+type module struct {
+	querier  querier.Querier
+	redactor *aiobservabilitytypes.Redactor
+}
+
+func NewModule(querier querier.Querier, config aiobservability.Config) aiobservability.Module {
+	return &module{
+		querier:  querier,
+		redactor: aiobservabilitytypes.MustNewRedactor(config.Conversations.Redaction, config.Conversations.Patterns),
+	}
+}
+
+func (m *module) GetConversation(ctx context.Context, orgID valuer.UUID, isAdmin bool, params *aiobservabilitytypes.GetConversationParams) (*aiobservabilitytypes.Conversation, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	// one more span than kept tells whether the conversation is truncated
+	spans, err := m.getSpans(ctx, orgID, uint64(params.StartUnixMilli), uint64(params.EndUnixMilli), params.FilterExpression(), params.SessionKey, qbtypes.OrderDirectionAsc, aiobservabilitytypes.MaxConversationSpans+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(spans) == 0 {
+		if params.TraceID != "" {
+			return nil, errors.Newf(errors.TypeNotFound, aiobservabilitytypes.ErrCodeConversationNotFound, "no gen_ai spans found for trace %s", params.TraceID)
+		}
+		return nil, errors.Newf(errors.TypeNotFound, aiobservabilitytypes.ErrCodeConversationNotFound, "no gen_ai spans found for %s %s", params.SessionKey, params.SessionID)
+	}
+
+	truncated := len(spans) > aiobservabilitytypes.MaxConversationSpans
+	if truncated {
+		spans = spans[:aiobservabilitytypes.MaxConversationSpans]
+	}
+
+	conversation := aiobservabilitytypes.NewConversation(spans)
+	conversation.Truncated = truncated
+	if params.SessionID != "" {
+		conversation.SessionID = params.SessionID
+	}
+	if !isAdmin {
+		m.redactor.Redact(conversation)
+	}
+	return conversation, nil
+}
+
+func (m *module) SearchConversations(ctx context.Context, orgID valuer.UUID, isAdmin bool, req *aiobservabilitytypes.PostableConversationSearch) (*aiobservabilitytypes.GettableConversationSearch, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	spans, err := m.getSpans(ctx, orgID, req.Start, req.End, req.FilterExpression(), req.SessionKey, qbtypes.OrderDirectionDesc, aiobservabilitytypes.MaxSearchSpans+1)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated := len(spans) > aiobservabilitytypes.MaxSearchSpans
+	if truncated {
+		spans = spans[:aiobservabilitytypes.MaxSearchSpans]
+	}
+
+	// match after redacting, for neither the snippets nor the matches to disclose the
+	// messages the redaction hides
+	redacted := !isAdmin && m.redactor.Enabled()
+	if redacted {
+		m.redactor.RedactSpans(spans)
+	}
+
+	return &aiobservabilitytypes.GettableConversationSearch{
+		Items:     aiobservabilitytypes.NewConversationMatches(spans, req.Text, req.Limit),
+		Truncated: truncated,
+		Redacted:  redacted,
+	}, nil
+}
+
+// getSpans lists the gen_ai spans matching the filter in timestamp order, reading the
+// session id from sessionKey.
+func (m *module) getSpans(ctx context.Context, orgID valuer.UUID, startMs, endMs uint64, filter, sessionKey string, direction qbtypes.OrderDirection, limit int) ([]*aiobservabilitytypes.ConversationSpan, error) {
+	resp, err := m.querier.QueryRange(ctx, orgID, &qbtypes.QueryRangeRequest{
+		Start:       startMs,
+		End:         endMs,
+		RequestType: qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{newSpansQuery(filter, sessionKey, direction, limit)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spans := make([]*aiobservabilitytypes.ConversationSpan, 0)
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.RawData)
+		if !ok || data == nil {
+			continue
+		}
+		for _, row := range data.Rows {
+			spans = append(spans, newConversationSpan(row, sessionKey))
+		}
+	}
+	return spans, nil
+}
+
+// newSpansQuery returns the AI builder query listing the gen_ai spans, which the AI
+// statement builder gates to the spans of LLM, tool and agent calls.
+func newSpansQuery(filter, sessionKey string, direction qbtypes.OrderDirection, limit int) qbtypes.QueryEnvelope {
+	selectFields := make([]telemetrytypes.TelemetryFieldKey, 0, len(spanFields)+len(genAIFields)+1)
+	for _, name := range spanFields {
+		selectFields = append(selectFields, telemetrytypes.TelemetryFieldKey{Name: name, Signal: telemetrytypes.SignalTraces, FieldContext: telemetrytypes.FieldContextSpan})
+	}
+	for _, name := range genAIFields {
+		selectFields = append(selectFields, aitelemetryschema.GenAIFields[name])
+	}
+	selectFields = append(selectFields, telemetrytypes.GetFieldKeyFromKeyText(sessionKey))
+
+	return qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeBuilderAI,
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Name:         conversationQueryName,
+			Signal:       telemetrytypes.SignalTraces,
+			Filter:       &qbtypes.Filter{Expression: filter},
+			SelectFields: selectFields,
+			Order: []qbtypes.OrderBy{
+				{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "timestamp", Signal: telemetrytypes.SignalTraces, FieldContext: telemetrytypes.FieldContextSpan}}, Direction: direction},
+			},
+			Limit: limit,
+		},
+	}
+}
+
+// newConversationSpan reads a span of the raw result of newSpansQuery.
+func newConversationSpan(row *qbtypes.RawRow, sessionKey string) *aiobservabilitytypes.ConversationSpan {
+	sessionName := telemetrytypes.GetFieldKeyFromKeyText(sessionKey).Name
+	return &aiobservabilitytypes.ConversationSpan{
+		TraceID:        stringValue(row.Data["trace_id"]),
+		SpanID:         stringValue(row.Data["span_id"]),
+		ParentSpanID:   stringValue(row.Data["parent_span_id"]),
+		Name:           stringValue(row.Data["name"]),
+		Timestamp:      row.Timestamp,
+		DurationNano:   uint64(floatValue(row.Data["duration_nano"])),
+		SessionID:      stringValue(row.Data[sessionName]),
+		Operation:      stringValue(row.Data[aiobservabilitytypes.GenAIOperationName]),
+		Model:          stringValue(row.Data[aiobservabilitytypes.GenAIRequestModel]),
+		Provider:       stringValue(row.Data[aiobservabilitytypes.GenAIProviderName]),
+		Agent:          stringValue(row.Data[aiobservabilitytypes.GenAIAgentName]),
+		Tool:           stringValue(row.Data[aiobservabilitytypes.GenAIToolName]),
+		InputMessages:  stringValue(row.Data[aiobservabilitytypes.GenAIInputMessages]),
+		OutputMessages: stringValue(row.Data[aiobservabilitytypes.GenAIOutputMessages]),
+		InputTokens:    floatValue(row.Data[aiobservabilitytypes.GenAIUsageInputTokens]),
+		OutputTokens:   floatValue(row.Data[aiobservabilitytypes.GenAIUsageOutputTokens]),
+		Cost:           floatValue(row.Data[aiobservabilitytypes.SignozGenAITotalCost]),
+		HasError:       boolValue(row.Data["has_error"]),
+		StatusMessage:  stringValue(row.Data["status_message"]),
+	}
+}
+
+// deref follows the pointers the driver scans nullable columns into.
+func deref(value any) any {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func stringValue(value any) string {
+	switch v := deref(value).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func floatValue(value any) float64 {
+	switch v := deref(value).(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case int:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+func boolValue(value any) bool {
+	switch v := deref(value).(type) {
+	case bool:
+		return v
+	case uint8:
+		return v != 0
+	default:
+		return false
+	}
+}
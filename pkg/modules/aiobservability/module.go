@@ -0,0 +1,18 @@
+package aiobservability
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/types/aiobservabilitytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Module interface {
+	// GetConversation reconstructs the conversation of a trace or a session from its
+	// gen_ai spans, redacting the message bodies unless isAdmin.
+	GetConversation(ctx context.Context, orgID valuer.UUID, isAdmin bool, params *aiobservabilitytypes.GetConversationParams) (*aiobservabilitytypes.Conversation, error)
+
+	// SearchConversations returns the conversations whose messages contain a text, matching
+	// the redacted message bodies unless isAdmin.
+	SearchConversations(ctx context.Context, orgID valuer.UUID, isAdmin bool, req *aiobservabilitytypes.PostableConversationSearch) (*aiobservabilitytypes.GettableConversationSearch, error)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/statsreporter"
 	citypes "github.com/SigNoz/signoz/pkg/types/cloudintegrationtypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
@@ -50,6 +51,13 @@ type Module interface {
 	// AgentCheckIn is called by agent to send heartbeat and get latest config in response.
 	AgentCheckIn(ctx context.Context, orgID valuer.UUID, provider citypes.CloudProviderType, req *citypes.AgentCheckInRequest) (*citypes.AgentCheckInResponse, error)
 
+	// GetAccountHealth returns the check-in, config drift and per service telemetry health of a connected account.
+	GetAccountHealth(ctx context.Context, orgID, accountID valuer.UUID, provider citypes.CloudProviderType) (*citypes.AccountHealth, error)
+
+	// GetAccountHealthAlertRules returns alert rules firing when the metrics of the enabled services of
+	// a connected account stop arriving. They are not created, the caller creates the ones it wants.
+	GetAccountHealthAlertRules(ctx context.Context, orgID, accountID valuer.UUID, provider citypes.CloudProviderType) ([]*ruletypes.PostableRule, error)
+
 	statsreporter.StatsCollector
 }
 
@@ -80,4 +88,6 @@ type Handler interface {
 	GetAccountService(http.ResponseWriter, *http.Request)
 	UpdateService(http.ResponseWriter, *http.Request)
 	AgentCheckIn(http.ResponseWriter, *http.Request)
+	GetAccountHealth(http.ResponseWriter, *http.Request)
+	GetAccountHealthAlertRules(http.ResponseWriter, *http.Request)
 }
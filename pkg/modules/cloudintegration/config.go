@@ -1,18 +1,32 @@
 package cloudintegration
 
 import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
 type Config struct {
 	// Agent config for cloud integration
 	Agent AgentConfig `mapstructure:"agent"`
+
+	// Health config for the health of connected accounts
+	Health HealthConfig `mapstructure:"health"`
 }
 
 type AgentConfig struct {
 	Version string `mapstructure:"version"`
 }
 
+type HealthConfig struct {
+	// CheckInStaleAfter is how long after the last check-in the agent of an account is considered gone.
+	CheckInStaleAfter time.Duration `mapstructure:"check_in_stale_after"`
+
+	// TelemetryStaleAfter is how long after it was last seen the telemetry of a service is considered stopped.
+	TelemetryStaleAfter time.Duration `mapstructure:"telemetry_stale_after"`
+}
+
 func NewConfigFactory() factory.ConfigFactory {
 	return factory.NewConfigFactory(factory.MustNewName("cloudintegration"), newConfig)
 }
@@ -24,9 +38,21 @@ func newConfig() factory.Config {
 			// till we automate it externally or figure out a way to validate it.
 			Version: "v0.0.13",
 		},
+		Health: HealthConfig{
+			CheckInStaleAfter:   30 * time.Minute,
+			TelemetryStaleAfter: 2 * time.Hour,
+		},
 	}
 }
 
 func (c Config) Validate() error {
+	if c.Health.CheckInStaleAfter <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "cloudintegration::health::check_in_stale_after must be greater than 0")
+	}
+
+	if c.Health.TelemetryStaleAfter <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "cloudintegration::health::telemetry_stale_after must be greater than 0")
+	}
+
 	return nil
 }
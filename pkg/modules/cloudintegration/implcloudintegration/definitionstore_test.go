@@ -16,6 +16,8 @@ func TestServiceDefinitionsAreValid(t *testing.T) {
 		citypes.CloudProviderTypeAWS,
 		citypes.CloudProviderTypeAzure,
 		citypes.CloudProviderTypeGCP,
+		citypes.CloudProviderTypeOCI,
+		citypes.CloudProviderTypeDigitalOcean,
 	} {
 		t.Run(provider.StringValue(), func(t *testing.T) {
 			defs, err := store.List(context.Background(), provider)
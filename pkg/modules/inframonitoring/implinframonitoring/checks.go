@@ -2,6 +2,7 @@ package implinframonitoring
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -10,6 +11,7 @@ import (
 
 // splitBucket partitions one component bucket's metric and attribute lists
 // against the module-wide missing sets into up to six response entries.
+// missingAttrs covers both the metric and the log attributes of the bucket.
 // Empty partitions are left nil so callers can skip them.
 func splitBucket(b checkComponentBucket, missingMetrics, missingAttrs map[string]bool) bucketSplit {
 	var s bucketSplit
@@ -49,7 +51,7 @@ func splitBucket(b checkComponentBucket, missingMetrics, missingAttrs map[string
 		}
 	}
 
-	presentA, missA := partitionList(b.RequiredAttrs, missingAttrs)
+	presentA, missA := partitionList(slices.Concat(b.RequiredAttrs, b.RequiredLogAttrs), missingAttrs)
 	if len(presentA) > 0 {
 		s.PresentAttrs = &inframonitoringtypes.AttributesComponentEntry{
 			Attributes:          presentA,
@@ -2,25 +2,31 @@ package implinframonitoring
 
 import "github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
 
-// Component names — the 5 OTel collector receivers/processors that produce
-// metrics and resource attributes consumed by infra-monitoring tabs. Bare
+// Component names — the 8 OTel collector receivers/processors that produce
+// the metrics, logs and attributes consumed by infra-monitoring tabs. Bare
 // strings on purpose (not wrapped enums) — the list is open-ended enough that
 // an enum adds more friction than value.
 const (
-	componentNameHostMetricsReceiver        = "hostmetricsreceiver"
-	componentNameKubeletStatsReceiver       = "kubeletstatsreceiver"
-	componentNameK8sClusterReceiver         = "k8sclusterreceiver"
-	componentNameResourceDetectionProcessor = "resourcedetectionprocessor"
-	componentNameK8sAttributesProcessor     = "k8sattributesprocessor"
+	componentNameHostMetricsReceiver            = "hostmetricsreceiver"
+	componentNameKubeletStatsReceiver           = "kubeletstatsreceiver"
+	componentNameK8sClusterReceiver             = "k8sclusterreceiver"
+	componentNameResourceDetectionProcessor     = "resourcedetectionprocessor"
+	componentNameK8sAttributesProcessor         = "k8sattributesprocessor"
+	componentNameK8sObjectsReceiver             = "k8sobjectsreceiver"
+	componentNameAWSECSContainerMetricsReceiver = "awsecscontainermetricsreceiver"
+	componentNameDockerStatsReceiver            = "dockerstatsreceiver"
 )
 
 // Documentation links — one per component. User-facing; emitted on missing-entries.
 const (
-	docLinkHostMetricsReceiver        = "https://signoz.io/docs/infrastructure-monitoring/hostmetrics/#configure-the-hostmetrics-receiver"
-	docLinkKubeletStatsReceiver       = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/#2-configure-the-kubelet-stats-receiver"
-	docLinkK8sClusterReceiver         = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/#1-configure-the-k8s-cluster-receiver"
-	docLinkResourceDetectionProcessor = "https://signoz.io/docs/infrastructure-monitoring/hostmetrics/#configure-the-processors"
-	docLinkK8sAttributesProcessor     = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/#3-enable-kubernetes-metadata"
+	docLinkHostMetricsReceiver            = "https://signoz.io/docs/infrastructure-monitoring/hostmetrics/#configure-the-hostmetrics-receiver"
+	docLinkKubeletStatsReceiver           = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/#2-configure-the-kubelet-stats-receiver"
+	docLinkK8sClusterReceiver             = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/#1-configure-the-k8s-cluster-receiver"
+	docLinkResourceDetectionProcessor     = "https://signoz.io/docs/infrastructure-monitoring/hostmetrics/#configure-the-processors"
+	docLinkK8sAttributesProcessor         = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/#3-enable-kubernetes-metadata"
+	docLinkK8sObjectsReceiver             = "https://signoz.io/docs/infrastructure-monitoring/k8s-metrics/"
+	docLinkAWSECSContainerMetricsReceiver = "https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/receiver/awsecscontainermetricsreceiver"
+	docLinkDockerStatsReceiver            = "https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/receiver/dockerstatsreceiver"
 )
 
 var (
@@ -44,23 +50,40 @@ var (
 		Type: inframonitoringtypes.CheckComponentTypeProcessor,
 		Name: componentNameK8sAttributesProcessor,
 	}
+	componentK8sObjectsReceiver = inframonitoringtypes.AssociatedComponent{
+		Type: inframonitoringtypes.CheckComponentTypeReceiver,
+		Name: componentNameK8sObjectsReceiver,
+	}
+	componentAWSECSContainerMetricsReceiver = inframonitoringtypes.AssociatedComponent{
+		Type: inframonitoringtypes.CheckComponentTypeReceiver,
+		Name: componentNameAWSECSContainerMetricsReceiver,
+	}
+	componentDockerStatsReceiver = inframonitoringtypes.AssociatedComponent{
+		Type: inframonitoringtypes.CheckComponentTypeReceiver,
+		Name: componentNameDockerStatsReceiver,
+	}
 )
 
 // checkSpecs is the single lookup table the module consults for a type's
 // readiness contract. Every CheckType value must have an entry here.
 var checkSpecs = map[inframonitoringtypes.CheckType]checkSpec{
-	inframonitoringtypes.CheckTypeHosts:          hostsSpec,
-	inframonitoringtypes.CheckTypeProcesses:      processesSpec,
-	inframonitoringtypes.CheckTypePods:           podsSpec,
-	inframonitoringtypes.CheckTypeNodes:          nodesSpec,
-	inframonitoringtypes.CheckTypeDeployments:    deploymentsSpec,
-	inframonitoringtypes.CheckTypeDaemonsets:     daemonsetsSpec,
-	inframonitoringtypes.CheckTypeStatefulsets:   statefulsetsSpec,
-	inframonitoringtypes.CheckTypeJobs:           jobsSpec,
-	inframonitoringtypes.CheckTypeNamespaces:     namespacesSpec,
-	inframonitoringtypes.CheckTypeClusters:       clustersSpec,
-	inframonitoringtypes.CheckTypeVolumes:        volumesSpec,
-	inframonitoringtypes.CheckTypeKubeContainers: kubeContainersSpec,
+	inframonitoringtypes.CheckTypeHosts:            hostsSpec,
+	inframonitoringtypes.CheckTypeProcesses:        processesSpec,
+	inframonitoringtypes.CheckTypePods:             podsSpec,
+	inframonitoringtypes.CheckTypeNodes:            nodesSpec,
+	inframonitoringtypes.CheckTypeDeployments:      deploymentsSpec,
+	inframonitoringtypes.CheckTypeDaemonsets:       daemonsetsSpec,
+	inframonitoringtypes.CheckTypeStatefulsets:     statefulsetsSpec,
+	inframonitoringtypes.CheckTypeJobs:             jobsSpec,
+	inframonitoringtypes.CheckTypeNamespaces:       namespacesSpec,
+	inframonitoringtypes.CheckTypeClusters:         clustersSpec,
+	inframonitoringtypes.CheckTypeVolumes:          volumesSpec,
+	inframonitoringtypes.CheckTypeKubeContainers:   kubeContainersSpec,
+	inframonitoringtypes.CheckTypeK8sEvents:        k8sEventsSpec,
+	inframonitoringtypes.CheckTypeECSClusters:      ecsClustersSpec,
+	inframonitoringtypes.CheckTypeECSServices:      ecsServicesSpec,
+	inframonitoringtypes.CheckTypeECSTasks:         ecsTasksSpec,
+	inframonitoringtypes.CheckTypeDockerContainers: dockerContainersSpec,
 }
 
 // Per-type specs. Every metric and attribute is spelled out in its own spec
@@ -470,3 +493,91 @@ var volumesSpec = checkSpec{
 		},
 	},
 }
+
+// k8sEventsSpec checks the k8sobjectsreceiver watches events: the attributes it
+// sets on the logs of watched objects (event.domain and event.name are only set
+// in watch mode, which events need).
+var k8sEventsSpec = checkSpec{
+	Buckets: []checkComponentBucket{
+		{
+			Component:         componentK8sObjectsReceiver,
+			RequiredLogAttrs:  []string{"k8s.resource.name", "event.domain", "event.name"},
+			DocumentationLink: docLinkK8sObjectsReceiver,
+		},
+	},
+}
+
+var ecsClustersSpec = checkSpec{
+	Buckets: []checkComponentBucket{
+		{
+			Component: componentAWSECSContainerMetricsReceiver,
+			DefaultMetrics: []string{
+				"ecs.task.cpu.utilized",
+				"ecs.task.cpu.reserved",
+				"ecs.task.memory.utilized",
+				"ecs.task.memory.reserved",
+			},
+			RequiredAttrs:     []string{"aws.ecs.cluster.name", "aws.ecs.task.id"},
+			DocumentationLink: docLinkAWSECSContainerMetricsReceiver,
+		},
+	},
+}
+
+// ecsServicesSpec — the receiver always sets aws.ecs.service.name (to 'undefined'
+// when unknown), so the attribute check passes even when services can't be listed.
+var ecsServicesSpec = checkSpec{
+	Buckets: []checkComponentBucket{
+		{
+			Component: componentAWSECSContainerMetricsReceiver,
+			DefaultMetrics: []string{
+				"ecs.task.cpu.utilized",
+				"ecs.task.cpu.reserved",
+				"ecs.task.memory.utilized",
+				"ecs.task.memory.reserved",
+			},
+			RequiredAttrs:     []string{"aws.ecs.service.name", "aws.ecs.cluster.name", "aws.ecs.task.id"},
+			DocumentationLink: docLinkAWSECSContainerMetricsReceiver,
+		},
+	},
+}
+
+var ecsTasksSpec = checkSpec{
+	Buckets: []checkComponentBucket{
+		{
+			Component: componentAWSECSContainerMetricsReceiver,
+			DefaultMetrics: []string{
+				"ecs.task.cpu.utilized",
+				"ecs.task.cpu.reserved",
+				"ecs.task.memory.utilized",
+				"ecs.task.memory.reserved",
+				"ecs.task.network.rate.rx",
+				"ecs.task.network.rate.tx",
+			},
+			RequiredAttrs:     []string{"aws.ecs.task.id", "aws.ecs.cluster.name"},
+			DocumentationLink: docLinkAWSECSContainerMetricsReceiver,
+		},
+	},
+}
+
+var dockerContainersSpec = checkSpec{
+	Buckets: []checkComponentBucket{
+		{
+			Component: componentDockerStatsReceiver,
+			DefaultMetrics: []string{
+				"container.cpu.utilization",
+				"container.memory.usage.total",
+				"container.memory.usage.limit",
+				"container.memory.percent",
+				"container.network.io.usage.rx_bytes",
+				"container.network.io.usage.tx_bytes",
+			},
+			RequiredAttrs:     []string{"container.name"},
+			DocumentationLink: docLinkDockerStatsReceiver,
+		},
+		{
+			Component:         componentResourceDetectionProcessor,
+			RequiredAttrs:     []string{"host.name"},
+			DocumentationLink: docLinkResourceDetectionProcessor,
+		},
+	},
+}
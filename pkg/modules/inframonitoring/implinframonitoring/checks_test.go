@@ -139,6 +139,21 @@ func TestSplitBucket(t *testing.T) {
 				missingAttrs:    []string{"a2"},
 			},
 		},
+		{
+			name: "log attrs are checked alongside metric attrs",
+			bucket: checkComponentBucket{
+				Component:         testComponent,
+				RequiredAttrs:     []string{"a1"},
+				RequiredLogAttrs:  []string{"l1", "l2"},
+				DocumentationLink: testDocLink,
+			},
+			missingMetrics: map[string]bool{},
+			missingAttrs:   map[string]bool{"l2": true},
+			want: want{
+				presentAttrs: []string{"a1", "l1"},
+				missingAttrs: []string{"l2"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
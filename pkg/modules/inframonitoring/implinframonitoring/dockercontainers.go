@@ -0,0 +1,157 @@
+package implinframonitoring
+
+import (
+	"context"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"golang.org/x/sync/errgroup"
+)
+
+// buildDockerContainerRecords assembles the page records. No per-row sub-counts.
+func buildDockerContainerRecords(
+	resp *qbtypes.QueryRangeResponse,
+	pageGroups []map[string]string,
+	groupBy []qbtypes.GroupByKey,
+	metadataMap map[string]map[string]string,
+) []inframonitoringtypes.DockerContainerRecord {
+	metricsMap := parseFullQueryResponse(resp, groupBy)
+
+	records := make([]inframonitoringtypes.DockerContainerRecord, 0, len(pageGroups))
+	for _, labels := range pageGroups {
+		compositeKey := compositeKeyFromLabels(labels, groupBy)
+		containerName := labels[inframonitoringtypes.DockerContainerNameAttrKey]
+
+		record := inframonitoringtypes.DockerContainerRecord{ // initialize with default values
+			ContainerName:  containerName,
+			CPUUtilization: -1,
+			MemoryUsage:    -1,
+			MemoryLimit:    -1,
+			MemoryPercent:  -1,
+			NetworkRxRate:  -1,
+			NetworkTxRate:  -1,
+			Meta:           map[string]string{},
+		}
+
+		if metrics, ok := metricsMap[compositeKey]; ok {
+			if v, exists := metrics["A"]; exists {
+				record.CPUUtilization = v
+			}
+			if v, exists := metrics["B"]; exists {
+				record.MemoryUsage = v
+			}
+			if v, exists := metrics["C"]; exists {
+				record.MemoryLimit = v
+			}
+			if v, exists := metrics["D"]; exists {
+				record.MemoryPercent = v
+			}
+			if v, exists := metrics["E"]; exists {
+				record.NetworkRxRate = v
+			}
+			if v, exists := metrics["F"]; exists {
+				record.NetworkTxRate = v
+			}
+		}
+
+		if attrs, ok := metadataMap[compositeKey]; ok {
+			for k, v := range attrs {
+				record.Meta[k] = v
+			}
+		}
+
+		records = append(records, record)
+	}
+	return records
+}
+
+func (m *module) getTopDockerContainerGroupsAndMetadata(
+	ctx context.Context,
+	orgID valuer.UUID,
+	req *inframonitoringtypes.PostableDockerContainers,
+) ([]map[string]string, map[string]map[string]string, error) {
+
+	var (
+		orderByKey      string
+		metadataMap     map[string]map[string]string
+		allMetricGroups []rankedGroup
+	)
+
+	orderByKey = req.OrderBy.Key.Name
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		metadataMap, err = m.getDockerContainersTableMetadata(gCtx, orgID, req)
+		return err
+	})
+
+	if orderByKey == inframonitoringtypes.DockerContainerNameAttrKey {
+		if err := g.Wait(); err != nil {
+			return nil, nil, err
+		}
+		pageGroups := inframonitoringtypes.PaginateMetadataByName(metadataMap, req.GroupBy, req.OrderBy.Direction, req.Offset, req.Limit, inframonitoringtypes.DockerContainerNameAttrKey)
+		return pageGroups, metadataMap, nil
+	}
+
+	queryNamesForOrderBy := orderByToDockerContainersQueryNames[orderByKey]
+	rankingQueryName := queryNamesForOrderBy[len(queryNamesForOrderBy)-1]
+
+	topReq := &qbtypes.QueryRangeRequest{
+		Start:       uint64(req.Start),
+		End:         uint64(req.End),
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: make([]qbtypes.QueryEnvelope, 0, len(queryNamesForOrderBy)),
+		},
+	}
+
+	for _, envelope := range m.newDockerContainersTableListQuery().CompositeQuery.Queries {
+		if !slices.Contains(queryNamesForOrderBy, envelope.GetQueryName()) {
+			continue
+		}
+		copied := envelope
+		if copied.Type == qbtypes.QueryTypeBuilder {
+			existingExpr := ""
+			if f := copied.GetFilter(); f != nil {
+				existingExpr = f.Expression
+			}
+			reqFilterExpr := ""
+			if req.Filter != nil {
+				reqFilterExpr = req.Filter.Expression
+			}
+			merged := mergeFilterExpressions(existingExpr, reqFilterExpr)
+			copied.SetFilter(&qbtypes.Filter{Expression: merged})
+			copied.SetGroupBy(req.GroupBy)
+		}
+		topReq.CompositeQuery.Queries = append(topReq.CompositeQuery.Queries, copied)
+	}
+
+	g.Go(func() error {
+		resp, err := m.querier.QueryRange(gCtx, orgID, topReq)
+		if err != nil {
+			return err
+		}
+		allMetricGroups = parseAndSortGroups(resp, rankingQueryName, req.GroupBy, req.OrderBy.Direction)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return paginateWithBackfill(allMetricGroups, metadataMap, req.GroupBy, req.Offset, req.Limit), metadataMap, nil
+}
+
+func (m *module) getDockerContainersTableMetadata(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableDockerContainers) (map[string]map[string]string, error) {
+	var nonGroupByAttrs []string
+	for _, key := range dockerContainerAttrKeysForMetadata {
+		if !isKeyInGroupByAttrs(req.GroupBy, key) {
+			nonGroupByAttrs = append(nonGroupByAttrs, key)
+		}
+	}
+	return m.getMetadata(ctx, orgID, dockerContainersTableMetricNamesList, req.GroupBy, nonGroupByAttrs, req.Filter, req.Start, req.End)
+}
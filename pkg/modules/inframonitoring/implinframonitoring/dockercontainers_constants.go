@@ -0,0 +1,187 @@
+package implinframonitoring
+
+import (
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const dockerContainersBaseFilterExpr = "container.name != ''"
+
+var dockerContainerNameGroupByKey = qbtypes.GroupByKey{
+	TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+		Name:          inframonitoringtypes.DockerContainerNameAttrKey,
+		FieldContext:  telemetrytypes.FieldContextResource,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	},
+}
+
+// dockerContainersTableMetricNamesList drives the existence/retention check.
+var dockerContainersTableMetricNamesList = []string{
+	"container.cpu.utilization",
+	"container.memory.usage.total",
+	"container.memory.usage.limit",
+	"container.memory.percent",
+	"container.network.io.usage.rx_bytes",
+	"container.network.io.usage.tx_bytes",
+}
+
+var dockerContainerAttrKeysForMetadata = []string{
+	"container.name",
+	"container.id",
+	"container.image.name",
+	"container.runtime",
+	"container.hostname",
+	"host.name",
+}
+
+var orderByToDockerContainersQueryNames = map[string][]string{
+	inframonitoringtypes.DockerContainersOrderByCPU:           {"A"},
+	inframonitoringtypes.DockerContainersOrderByMemory:        {"B"},
+	inframonitoringtypes.DockerContainersOrderByMemoryLimit:   {"C"},
+	inframonitoringtypes.DockerContainersOrderByMemoryPercent: {"D"},
+	inframonitoringtypes.DockerContainersOrderByNetworkRx:     {"E"},
+	inframonitoringtypes.DockerContainersOrderByNetworkTx:     {"F"},
+}
+
+// newDockerContainersTableListQuery builds the composite QB v5 request for the Docker containers list.
+// Every builder query carries a base filter `container.name != ”`: other receivers
+// (kubeletstats) emit container.* metrics keyed by container.id without a name.
+func (m *module) newDockerContainersTableListQuery() *qbtypes.QueryRangeRequest {
+	queries := []qbtypes.QueryEnvelope{
+		// Query A: container.cpu.utilization — container CPU utilization (%).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "container.cpu.utilization",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationAvg,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: dockerContainersBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{dockerContainerNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query B: container.memory.usage.total — container memory usage (bytes).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "B",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "container.memory.usage.total",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: dockerContainersBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{dockerContainerNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query C: container.memory.usage.limit — container memory limit (bytes).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "C",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "container.memory.usage.limit",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: dockerContainersBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{dockerContainerNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query D: container.memory.percent — container memory usage of its limit (%).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "D",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "container.memory.percent",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationAvg,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: dockerContainersBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{dockerContainerNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query E: container.network.io.usage.rx_bytes — network receive rate (bytes/s), summed across interfaces.
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "E",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "container.network.io.usage.rx_bytes",
+						TimeAggregation:  metrictypes.TimeAggregationRate,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: dockerContainersBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{dockerContainerNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query F: container.network.io.usage.tx_bytes — network transmit rate (bytes/s), summed across interfaces.
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "F",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "container.network.io.usage.tx_bytes",
+						TimeAggregation:  metrictypes.TimeAggregationRate,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: dockerContainersBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{dockerContainerNameGroupByKey},
+				Disabled: false,
+			},
+		},
+	}
+
+	return &qbtypes.QueryRangeRequest{
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: queries,
+		},
+	}
+}
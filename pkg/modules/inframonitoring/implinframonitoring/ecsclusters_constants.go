@@ -0,0 +1,130 @@
+package implinframonitoring
+
+import (
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+var ecsClusterNameGroupByKey = qbtypes.GroupByKey{
+	TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+		Name:          inframonitoringtypes.ECSClusterNameAttrKey,
+		FieldContext:  telemetrytypes.FieldContextResource,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	},
+}
+
+// ecsClustersTableMetricNamesList drives the existence/retention check.
+var ecsClustersTableMetricNamesList = []string{
+	"ecs.task.cpu.utilized",
+	"ecs.task.cpu.reserved",
+	"ecs.task.memory.utilized",
+	"ecs.task.memory.reserved",
+}
+
+var ecsClusterAttrKeysForMetadata = []string{
+	"aws.ecs.cluster.name",
+	"cloud.account.id",
+	"cloud.region",
+}
+
+// ecsClusterCountAttrKeys are the resource attributes whose distinct values
+// are counted per group, read from the task metric universe.
+var ecsClusterCountAttrKeys = []string{
+	inframonitoringtypes.ECSTaskIDAttrKey,
+}
+
+var orderByToECSClustersQueryNames = map[string][]string{
+	inframonitoringtypes.ECSClustersOrderByCPU:            {"A"},
+	inframonitoringtypes.ECSClustersOrderByCPUReserved:    {"B"},
+	inframonitoringtypes.ECSClustersOrderByMemory:         {"C"},
+	inframonitoringtypes.ECSClustersOrderByMemoryReserved: {"D"},
+}
+
+// newECSClustersTableListQuery builds the composite QB v5 request for the ECS clusters list.
+// Every query reads the task-level gauges of the awsecscontainermetrics receiver, so
+// a cluster row aggregates the tasks running in it.
+func (m *module) newECSClustersTableListQuery() *qbtypes.QueryRangeRequest {
+	queries := []qbtypes.QueryEnvelope{
+		// Query A: ecs.task.cpu.utilized — task CPU utilization (%).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.cpu.utilized",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationAvg,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsClusterNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query B: ecs.task.cpu.reserved — task CPU reserved (vCPU).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "B",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.cpu.reserved",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsClusterNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query C: ecs.task.memory.utilized — task memory utilized (MiB).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "C",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.memory.utilized",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsClusterNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query D: ecs.task.memory.reserved — task memory reserved (MiB).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "D",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.memory.reserved",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsClusterNameGroupByKey},
+				Disabled: false,
+			},
+		},
+	}
+
+	return &qbtypes.QueryRangeRequest{
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: queries,
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package implinframonitoring
+
+import (
+	"context"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"golang.org/x/sync/errgroup"
+)
+
+// buildECSServiceRecords assembles the page records. Task counts come from
+// resourceCounts in both modes.
+func buildECSServiceRecords(
+	resp *qbtypes.QueryRangeResponse,
+	pageGroups []map[string]string,
+	groupBy []qbtypes.GroupByKey,
+	metadataMap map[string]map[string]string,
+	resourceCounts map[string]map[string]int64,
+) []inframonitoringtypes.ECSServiceRecord {
+	metricsMap := parseFullQueryResponse(resp, groupBy)
+
+	records := make([]inframonitoringtypes.ECSServiceRecord, 0, len(pageGroups))
+	for _, labels := range pageGroups {
+		compositeKey := compositeKeyFromLabels(labels, groupBy)
+		serviceName := labels[inframonitoringtypes.ECSServiceNameAttrKey]
+
+		record := inframonitoringtypes.ECSServiceRecord{ // initialize with default values
+			ServiceName:    serviceName,
+			CPUUtilization: -1,
+			CPUReserved:    -1,
+			MemoryUtilized: -1,
+			MemoryReserved: -1,
+			Meta:           map[string]string{},
+		}
+
+		if metrics, ok := metricsMap[compositeKey]; ok {
+			if v, exists := metrics["A"]; exists {
+				record.CPUUtilization = v
+			}
+			if v, exists := metrics["B"]; exists {
+				record.CPUReserved = v
+			}
+			if v, exists := metrics["C"]; exists {
+				record.MemoryUtilized = v
+			}
+			if v, exists := metrics["D"]; exists {
+				record.MemoryReserved = v
+			}
+		}
+
+		if counts, ok := resourceCounts[compositeKey]; ok {
+			record.Counts.Tasks = counts[inframonitoringtypes.ECSTaskIDAttrKey]
+		}
+
+		if attrs, ok := metadataMap[compositeKey]; ok {
+			for k, v := range attrs {
+				record.Meta[k] = v
+			}
+		}
+
+		records = append(records, record)
+	}
+	return records
+}
+
+func (m *module) getTopECSServiceGroupsAndMetadata(
+	ctx context.Context,
+	orgID valuer.UUID,
+	req *inframonitoringtypes.PostableECSServices,
+) ([]map[string]string, map[string]map[string]string, error) {
+
+	var (
+		orderByKey      string
+		metadataMap     map[string]map[string]string
+		allMetricGroups []rankedGroup
+	)
+
+	orderByKey = req.OrderBy.Key.Name
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		metadataMap, err = m.getECSServicesTableMetadata(gCtx, orgID, req)
+		return err
+	})
+
+	if orderByKey == inframonitoringtypes.ECSServiceNameAttrKey {
+		if err := g.Wait(); err != nil {
+			return nil, nil, err
+		}
+		pageGroups := inframonitoringtypes.PaginateMetadataByName(metadataMap, req.GroupBy, req.OrderBy.Direction, req.Offset, req.Limit, inframonitoringtypes.ECSServiceNameAttrKey)
+		return pageGroups, metadataMap, nil
+	}
+
+	queryNamesForOrderBy := orderByToECSServicesQueryNames[orderByKey]
+	rankingQueryName := queryNamesForOrderBy[len(queryNamesForOrderBy)-1]
+
+	topReq := &qbtypes.QueryRangeRequest{
+		Start:       uint64(req.Start),
+		End:         uint64(req.End),
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: make([]qbtypes.QueryEnvelope, 0, len(queryNamesForOrderBy)),
+		},
+	}
+
+	for _, envelope := range m.newECSServicesTableListQuery().CompositeQuery.Queries {
+		if !slices.Contains(queryNamesForOrderBy, envelope.GetQueryName()) {
+			continue
+		}
+		copied := envelope
+		if copied.Type == qbtypes.QueryTypeBuilder {
+			existingExpr := ""
+			if f := copied.GetFilter(); f != nil {
+				existingExpr = f.Expression
+			}
+			reqFilterExpr := ""
+			if req.Filter != nil {
+				reqFilterExpr = req.Filter.Expression
+			}
+			merged := mergeFilterExpressions(existingExpr, reqFilterExpr)
+			copied.SetFilter(&qbtypes.Filter{Expression: merged})
+			copied.SetGroupBy(req.GroupBy)
+		}
+		topReq.CompositeQuery.Queries = append(topReq.CompositeQuery.Queries, copied)
+	}
+
+	g.Go(func() error {
+		resp, err := m.querier.QueryRange(gCtx, orgID, topReq)
+		if err != nil {
+			return err
+		}
+		allMetricGroups = parseAndSortGroups(resp, rankingQueryName, req.GroupBy, req.OrderBy.Direction)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return paginateWithBackfill(allMetricGroups, metadataMap, req.GroupBy, req.Offset, req.Limit), metadataMap, nil
+}
+
+func (m *module) getECSServicesTableMetadata(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSServices) (map[string]map[string]string, error) {
+	var nonGroupByAttrs []string
+	for _, key := range ecsServiceAttrKeysForMetadata {
+		if !isKeyInGroupByAttrs(req.GroupBy, key) {
+			nonGroupByAttrs = append(nonGroupByAttrs, key)
+		}
+	}
+	return m.getMetadata(ctx, orgID, ecsServicesTableMetricNamesList, req.GroupBy, nonGroupByAttrs, req.Filter, req.Start, req.End)
+}
@@ -0,0 +1,148 @@
+package implinframonitoring
+
+import (
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const ecsServicesBaseFilterExpr = "aws.ecs.service.name != '' AND aws.ecs.service.name != 'undefined'"
+
+var ecsServiceNameGroupByKey = qbtypes.GroupByKey{
+	TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+		Name:          inframonitoringtypes.ECSServiceNameAttrKey,
+		FieldContext:  telemetrytypes.FieldContextResource,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	},
+}
+
+// ecsServicesTableMetricNamesList drives the existence/retention check.
+var ecsServicesTableMetricNamesList = []string{
+	"ecs.task.cpu.utilized",
+	"ecs.task.cpu.reserved",
+	"ecs.task.memory.utilized",
+	"ecs.task.memory.reserved",
+}
+
+var ecsServiceAttrKeysForMetadata = []string{
+	"aws.ecs.service.name",
+	"aws.ecs.cluster.name",
+	"aws.ecs.launchtype",
+	"cloud.account.id",
+	"cloud.region",
+}
+
+// ecsServiceCountAttrKeys are the resource attributes whose distinct values
+// are counted per group, read from the task metric universe.
+var ecsServiceCountAttrKeys = []string{
+	inframonitoringtypes.ECSTaskIDAttrKey,
+}
+
+var orderByToECSServicesQueryNames = map[string][]string{
+	inframonitoringtypes.ECSServicesOrderByCPU:            {"A"},
+	inframonitoringtypes.ECSServicesOrderByCPUReserved:    {"B"},
+	inframonitoringtypes.ECSServicesOrderByMemory:         {"C"},
+	inframonitoringtypes.ECSServicesOrderByMemoryReserved: {"D"},
+}
+
+// newECSServicesTableListQuery builds the composite QB v5 request for the ECS services list.
+// Every builder query carries a base filter dropping tasks without a service: the
+// awsecscontainermetrics receiver sets aws.ecs.service.name to 'undefined' since the
+// task metadata endpoint does not report it, so the attribute has to be set upstream
+// (e.g. with a resource or transform processor) for services to be listed.
+func (m *module) newECSServicesTableListQuery() *qbtypes.QueryRangeRequest {
+	queries := []qbtypes.QueryEnvelope{
+		// Query A: ecs.task.cpu.utilized — task CPU utilization (%).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.cpu.utilized",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationAvg,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: ecsServicesBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsServiceNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query B: ecs.task.cpu.reserved — task CPU reserved (vCPU).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "B",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.cpu.reserved",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: ecsServicesBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsServiceNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query C: ecs.task.memory.utilized — task memory utilized (MiB).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "C",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.memory.utilized",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: ecsServicesBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsServiceNameGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query D: ecs.task.memory.reserved — task memory reserved (MiB).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "D",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.memory.reserved",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				Filter: &qbtypes.Filter{
+					Expression: ecsServicesBaseFilterExpr,
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsServiceNameGroupByKey},
+				Disabled: false,
+			},
+		},
+	}
+
+	return &qbtypes.QueryRangeRequest{
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: queries,
+		},
+	}
+}
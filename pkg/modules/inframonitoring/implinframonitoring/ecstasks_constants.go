@@ -0,0 +1,171 @@
+package implinframonitoring
+
+import (
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+var ecsTaskIDGroupByKey = qbtypes.GroupByKey{
+	TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+		Name:          inframonitoringtypes.ECSTaskIDAttrKey,
+		FieldContext:  telemetrytypes.FieldContextResource,
+		FieldDataType: telemetrytypes.FieldDataTypeString,
+	},
+}
+
+// ecsTasksTableMetricNamesList drives the existence/retention check.
+var ecsTasksTableMetricNamesList = []string{
+	"ecs.task.cpu.utilized",
+	"ecs.task.cpu.reserved",
+	"ecs.task.memory.utilized",
+	"ecs.task.memory.reserved",
+	"ecs.task.network.rate.rx",
+	"ecs.task.network.rate.tx",
+}
+
+var ecsTaskAttrKeysForMetadata = []string{
+	"aws.ecs.task.id",
+	"aws.ecs.task.arn",
+	"aws.ecs.task.family",
+	"aws.ecs.task.revision",
+	"aws.ecs.task.known_status",
+	"aws.ecs.launchtype",
+	"aws.ecs.service.name",
+	"aws.ecs.cluster.name",
+	"cloud.availability_zone",
+	"cloud.region",
+}
+
+var orderByToECSTasksQueryNames = map[string][]string{
+	inframonitoringtypes.ECSTasksOrderByCPU:            {"A"},
+	inframonitoringtypes.ECSTasksOrderByCPUReserved:    {"B"},
+	inframonitoringtypes.ECSTasksOrderByMemory:         {"C"},
+	inframonitoringtypes.ECSTasksOrderByMemoryReserved: {"D"},
+	inframonitoringtypes.ECSTasksOrderByNetworkRx:      {"E"},
+	inframonitoringtypes.ECSTasksOrderByNetworkTx:      {"F"},
+}
+
+// newECSTasksTableListQuery builds the composite QB v5 request for the ECS tasks list.
+// Queries read the task-level gauges of the awsecscontainermetrics receiver; in list
+// mode every row is one task, so the space aggregation only matters for groups.
+func (m *module) newECSTasksTableListQuery() *qbtypes.QueryRangeRequest {
+	queries := []qbtypes.QueryEnvelope{
+		// Query A: ecs.task.cpu.utilized — task CPU utilization (%).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.cpu.utilized",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationAvg,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsTaskIDGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query B: ecs.task.cpu.reserved — task CPU reserved (vCPU).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "B",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.cpu.reserved",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsTaskIDGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query C: ecs.task.memory.utilized — task memory utilized (MiB).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "C",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.memory.utilized",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsTaskIDGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query D: ecs.task.memory.reserved — task memory reserved (MiB).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "D",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.memory.reserved",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsTaskIDGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query E: ecs.task.network.rate.rx — task network receive rate (bytes/s).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "E",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.network.rate.rx",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsTaskIDGroupByKey},
+				Disabled: false,
+			},
+		},
+		// Query F: ecs.task.network.rate.tx — task network transmit rate (bytes/s).
+		{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   "F",
+				Signal: telemetrytypes.SignalMetrics,
+				Aggregations: []qbtypes.MetricAggregation{
+					{
+						MetricName:       "ecs.task.network.rate.tx",
+						TimeAggregation:  metrictypes.TimeAggregationAvg,
+						SpaceAggregation: metrictypes.SpaceAggregationSum,
+						ReduceTo:         qbtypes.ReduceToAvg,
+					},
+				},
+				GroupBy:  []qbtypes.GroupByKey{ecsTaskIDGroupByKey},
+				Disabled: false,
+			},
+		},
+	}
+
+	return &qbtypes.QueryRangeRequest{
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: queries,
+		},
+	}
+}
@@ -0,0 +1,343 @@
+package implinframonitoring
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"golang.org/x/sync/errgroup"
+)
+
+// entityFromRow returns the entity of type t a metadata row belongs to, nil when the row
+// does not carry the name attribute of the type.
+func entityFromRow(t inframonitoringtypes.EntityType, row map[string]string) *inframonitoringtypes.Entity {
+	name := row[t.NameAttrKey()]
+	if name == "" {
+		return nil
+	}
+
+	attributes := make(map[string]string)
+	for _, key := range inframonitoringtypes.EntityIdentityAttrKeys[t] {
+		if v := row[key]; v != "" {
+			attributes[key] = v
+		}
+	}
+	return &inframonitoringtypes.Entity{
+		Type:       t,
+		Name:       name,
+		Attributes: attributes,
+		Metrics:    map[string]float64{},
+	}
+}
+
+// entityKey identifies an entity across rows by its type and identifying attributes.
+func entityKey(e *inframonitoringtypes.Entity) string {
+	keys := inframonitoringtypes.EntityIdentityAttrKeys[e.Type]
+	parts := make([]string, 0, len(keys)+1)
+	parts = append(parts, e.Type.StringValue())
+	for _, key := range keys {
+		parts = append(parts, e.Attributes[key])
+	}
+	return compositeKeyFromList(parts)
+}
+
+// firstEntityFromRow returns the entity of the first of types the row carries.
+func firstEntityFromRow(row map[string]string, types ...inframonitoringtypes.EntityType) *inframonitoringtypes.Entity {
+	for _, t := range types {
+		if e := entityFromRow(t, row); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// entityParentsFromRow returns the owners of the entity of type t in the row, its
+// primary owner first. Pods are owned by their closest workload (falling back to their
+// namespace) and run on their node; replicasets and jobs are owned by the deployment and
+// cronjob managing them; volumes (PVCs) belong to the pods mounting them.
+func entityParentsFromRow(t inframonitoringtypes.EntityType, row map[string]string) []*inframonitoringtypes.Entity {
+	var parents []*inframonitoringtypes.Entity
+	switch t {
+	case inframonitoringtypes.EntityTypeNode, inframonitoringtypes.EntityTypeNamespace:
+		parents = append(parents, entityFromRow(inframonitoringtypes.EntityTypeCluster, row))
+	case inframonitoringtypes.EntityTypeDeployment, inframonitoringtypes.EntityTypeStatefulSet, inframonitoringtypes.EntityTypeDaemonSet, inframonitoringtypes.EntityTypeCronJob:
+		parents = append(parents, entityFromRow(inframonitoringtypes.EntityTypeNamespace, row))
+	case inframonitoringtypes.EntityTypeReplicaSet:
+		parents = append(parents, firstEntityFromRow(row, inframonitoringtypes.EntityTypeDeployment, inframonitoringtypes.EntityTypeNamespace))
+	case inframonitoringtypes.EntityTypeJob:
+		parents = append(parents, firstEntityFromRow(row, inframonitoringtypes.EntityTypeCronJob, inframonitoringtypes.EntityTypeNamespace))
+	case inframonitoringtypes.EntityTypePod:
+		parents = append(parents,
+			firstEntityFromRow(row,
+				inframonitoringtypes.EntityTypeReplicaSet,
+				inframonitoringtypes.EntityTypeStatefulSet,
+				inframonitoringtypes.EntityTypeDaemonSet,
+				inframonitoringtypes.EntityTypeJob,
+				inframonitoringtypes.EntityTypeDeployment,
+				inframonitoringtypes.EntityTypeCronJob,
+				inframonitoringtypes.EntityTypeNamespace,
+			),
+			entityFromRow(inframonitoringtypes.EntityTypeNode, row),
+		)
+	case inframonitoringtypes.EntityTypeContainer, inframonitoringtypes.EntityTypeVolume:
+		parents = append(parents, entityFromRow(inframonitoringtypes.EntityTypePod, row))
+	}
+	return slices.DeleteFunc(parents, func(e *inframonitoringtypes.Entity) bool { return e == nil })
+}
+
+// entityRefMatchesRow reports whether the row carries an entity matching the reference.
+func entityRefMatchesRow(ref inframonitoringtypes.EntityRef, row map[string]string) bool {
+	for key, value := range ref.Attributes {
+		if row[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// entityFilterExpr builds the filter expression restricting series to the ones carrying
+// the attributes.
+func entityFilterExpr(attributes map[string]string) string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	clauses := make([]string, 0, len(keys))
+	for _, key := range keys {
+		clauses = append(clauses, fmt.Sprintf("%s = %s", key, querybuilder.ClickHouseStringLiteral(attributes[key])))
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// sortedRows returns the metadata rows ordered by their composite key, so that the graph
+// built from them does not depend on map iteration order.
+func sortedRows(rows map[string]map[string]string) []map[string]string {
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	sorted := make([]map[string]string, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, rows[key])
+	}
+	return sorted
+}
+
+// entitySet collects distinct entities in the order they are added.
+type entitySet struct {
+	keys     map[string]struct{}
+	entities []*inframonitoringtypes.Entity
+}
+
+func newEntitySet() *entitySet {
+	return &entitySet{keys: make(map[string]struct{}), entities: make([]*inframonitoringtypes.Entity, 0)}
+}
+
+func (s *entitySet) add(e *inframonitoringtypes.Entity) {
+	key := entityKey(e)
+	if _, ok := s.keys[key]; ok {
+		return
+	}
+	s.keys[key] = struct{}{}
+	s.entities = append(s.entities, e)
+}
+
+// list returns the entities ordered by type and name, capped to maxEntityGraphRelations,
+// and whether the cap truncated them.
+func (s *entitySet) list() ([]*inframonitoringtypes.Entity, bool) {
+	order := make(map[inframonitoringtypes.EntityType]int, len(inframonitoringtypes.ValidEntityTypes))
+	for i, t := range inframonitoringtypes.ValidEntityTypes {
+		order[t] = i
+	}
+
+	entities := slices.Clone(s.entities)
+	slices.SortStableFunc(entities, func(a, b *inframonitoringtypes.Entity) int {
+		return cmp.Or(
+			cmp.Compare(order[a.Type], order[b.Type]),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(entityKey(a), entityKey(b)),
+		)
+	})
+	if len(entities) > maxEntityGraphRelations {
+		return entities[:maxEntityGraphRelations], true
+	}
+	return entities, false
+}
+
+// buildEntityRelations resolves the entity of the reference in the metadata rows and
+// returns it with its parents, its children and its primary parent, the one its siblings
+// share. The rows must include every row carrying the entity.
+func buildEntityRelations(ref inframonitoringtypes.EntityRef, rows map[string]map[string]string) (
+	*inframonitoringtypes.Entity, // entity
+	*entitySet, // parents
+	*entitySet, // children
+	*inframonitoringtypes.Entity, // primary parent
+	error,
+) {
+	var (
+		entity        *inframonitoringtypes.Entity
+		primaryParent *inframonitoringtypes.Entity
+		entityRows    []map[string]string
+		matches       = newEntitySet()
+	)
+	for _, row := range sortedRows(rows) {
+		e := entityFromRow(ref.Type, row)
+		if e == nil || !entityRefMatchesRow(ref, row) {
+			continue
+		}
+		matches.add(e)
+		entity = cmp.Or(entity, e)
+		entityRows = append(entityRows, row)
+	}
+
+	switch len(matches.entities) {
+	case 0:
+		return nil, nil, nil, nil, errors.NewNotFoundf(errors.CodeNotFound, "no %s matching %v reported in the time range", ref.Type, ref.Attributes)
+	case 1:
+	default:
+		return nil, nil, nil, nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "%d entities of type %s match %v, specify %v to pick one", len(matches.entities), ref.Type, ref.Attributes, inframonitoringtypes.EntityIdentityAttrKeys[ref.Type])
+	}
+
+	key := entityKey(entity)
+	parents, children := newEntitySet(), newEntitySet()
+	for _, row := range entityRows {
+		for i, parent := range entityParentsFromRow(ref.Type, row) {
+			if i == 0 && primaryParent == nil {
+				primaryParent = parent
+			}
+			parents.add(parent)
+		}
+
+		for _, t := range inframonitoringtypes.ValidEntityTypes {
+			child := entityFromRow(t, row)
+			if child == nil {
+				continue
+			}
+			for _, parent := range entityParentsFromRow(t, row) {
+				if entityKey(parent) == key {
+					children.add(child)
+					break
+				}
+			}
+		}
+	}
+
+	return entity, parents, children, primaryParent, nil
+}
+
+// buildEntitySiblings returns the other entities of the type of entity whose primary
+// parent is the given one.
+func buildEntitySiblings(entity, primaryParent *inframonitoringtypes.Entity, rows map[string]map[string]string) *entitySet {
+	siblings := newEntitySet()
+	key, parentKey := entityKey(entity), entityKey(primaryParent)
+	for _, row := range sortedRows(rows) {
+		sibling := entityFromRow(entity.Type, row)
+		if sibling == nil || entityKey(sibling) == key {
+			continue
+		}
+		parents := entityParentsFromRow(entity.Type, row)
+		if len(parents) > 0 && entityKey(parents[0]) == parentKey {
+			siblings.add(sibling)
+		}
+	}
+	return siblings
+}
+
+// getEntityGraphRows returns the distinct combinations of the k8s.* attributes of the
+// series matching the filter reported in the time range.
+func (m *module) getEntityGraphRows(ctx context.Context, orgID valuer.UUID, filterExpr string, start, end int64) (map[string]map[string]string, error) {
+	return m.getMetadata(ctx, orgID, entityGraphMetricNamesList, entityGraphGroupBy, nil, &qbtypes.Filter{Expression: filterExpr}, start, end)
+}
+
+// fillEntityMetrics sets the health metrics of the entities, querying those of each
+// type at once with the table list query of the type.
+func (m *module) fillEntityMetrics(ctx context.Context, orgID valuer.UUID, start, end int64, entities []*inframonitoringtypes.Entity) (*qbtypes.QueryWarnData, error) {
+	byType := make(map[inframonitoringtypes.EntityType][]*inframonitoringtypes.Entity)
+	for _, e := range entities {
+		if _, ok := entityMetricsQueries[e.Type]; ok {
+			byType[e.Type] = append(byType[e.Type], e)
+		}
+	}
+
+	types := make([]inframonitoringtypes.EntityType, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	warnings := make([]*qbtypes.QueryWarnData, len(types))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for i, t := range types {
+		g.Go(func() error {
+			var err error
+			warnings[i], err = m.fillEntityMetricsOfType(gCtx, orgID, start, end, entityMetricsQueries[t], byType[t])
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return mergeQueryWarnings(warnings...), nil
+}
+
+func (m *module) fillEntityMetricsOfType(
+	ctx context.Context,
+	orgID valuer.UUID,
+	start, end int64,
+	query entityMetricsQuery,
+	entities []*inframonitoringtypes.Entity,
+) (*qbtypes.QueryWarnData, error) {
+	// Group by the identifying attributes all the entities carry; a cluster name which is
+	// not set could not be matched by the IN filter.
+	var groupBy []qbtypes.GroupByKey
+	for _, key := range inframonitoringtypes.EntityIdentityAttrKeys[entities[0].Type] {
+		if slices.ContainsFunc(entities, func(e *inframonitoringtypes.Entity) bool { return e.Attributes[key] == "" }) {
+			continue
+		}
+		groupBy = append(groupBy, qbtypes.GroupByKey{
+			TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+				Name:          key,
+				FieldContext:  telemetrytypes.FieldContextResource,
+				FieldDataType: telemetrytypes.FieldDataTypeString,
+			},
+		})
+	}
+
+	pageGroups := make([]map[string]string, 0, len(entities))
+	for _, e := range entities {
+		labels := make(map[string]string, len(groupBy))
+		for _, key := range groupBy {
+			labels[key.Name] = e.Attributes[key.Name]
+		}
+		pageGroups = append(pageGroups, labels)
+	}
+
+	resp, err := m.querier.QueryRange(ctx, orgID, buildFullQueryRequest(start, end, "", groupBy, pageGroups, query.newQuery(m)))
+	if err != nil {
+		return nil, err
+	}
+
+	metricKeys := query.metricKeys()
+	metricsMap := parseFullQueryResponse(resp, groupBy)
+	for i, e := range entities {
+		for queryName, value := range metricsMap[compositeKeyFromLabels(pageGroups[i], groupBy)] {
+			if key, ok := metricKeys[queryName]; ok {
+				e.Metrics[key] = value
+			}
+		}
+	}
+
+	return resp.Warning, nil
+}
@@ -0,0 +1,100 @@
+package implinframonitoring
+
+import (
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// maxEntityGraphRelations caps each of the parents, children and siblings of an entity graph.
+const maxEntityGraphRelations = 500
+
+// entityGraphAttrKeys are the k8s.* resource attributes the relationships of the entity
+// graph are derived from. A series carries the attributes of the pod, workload, node and
+// namespace it belongs to, so each distinct combination in the time range is an edge set.
+var entityGraphAttrKeys = []string{
+	inframonitoringtypes.ClusterNameAttrKey,
+	inframonitoringtypes.NodeNameAttrKey,
+	inframonitoringtypes.NamespaceNameAttrKey,
+	inframonitoringtypes.DeploymentNameAttrKey,
+	inframonitoringtypes.ReplicaSetNameAttrKey,
+	inframonitoringtypes.StatefulSetNameAttrKey,
+	inframonitoringtypes.DaemonSetNameAttrKey,
+	inframonitoringtypes.CronJobNameAttrKey,
+	inframonitoringtypes.JobNameAttrKey,
+	inframonitoringtypes.PodNameAttrKey,
+	inframonitoringtypes.ContainerNameAttrKey,
+	inframonitoringtypes.PersistentVolumeClaimNameAttrKey,
+}
+
+var entityGraphGroupBy = func() []qbtypes.GroupByKey {
+	groupBy := make([]qbtypes.GroupByKey, 0, len(entityGraphAttrKeys))
+	for _, key := range entityGraphAttrKeys {
+		groupBy = append(groupBy, qbtypes.GroupByKey{
+			TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+				Name:          key,
+				FieldContext:  telemetrytypes.FieldContextResource,
+				FieldDataType: telemetrytypes.FieldDataTypeString,
+			},
+		})
+	}
+	return groupBy
+}()
+
+// entityGraphMetricNamesList is the union of the metrics of the k8s list APIs, the
+// universe the membership of the entity graph is read from.
+var entityGraphMetricNamesList = func() []string {
+	seen := make(map[string]struct{})
+	names := make([]string, 0)
+	for _, list := range [][]string{
+		clustersTableMetricNamesList,
+		nodesTableMetricNamesList,
+		namespacesTableMetricNamesList,
+		deploymentsTableMetricNamesList,
+		statefulSetsTableMetricNamesList,
+		daemonSetsTableMetricNamesList,
+		jobsTableMetricNamesList,
+		podsTableMetricNamesList,
+		containersTableMetricNamesList,
+		volumesTableMetricNamesList,
+	} {
+		for _, name := range list {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names
+}()
+
+// entityMetricsQuery is how the health metrics of entities of a type are queried: the
+// table list query of the type, with the metric key of each query named in its order by
+// map (the last query name of each order by key is its ranking column).
+type entityMetricsQuery struct {
+	newQuery func(*module) *qbtypes.QueryRangeRequest
+	orderBy  map[string][]string
+}
+
+var entityMetricsQueries = map[inframonitoringtypes.EntityType]entityMetricsQuery{
+	inframonitoringtypes.EntityTypeCluster:     {(*module).newClustersTableListQuery, orderByToClustersQueryNames},
+	inframonitoringtypes.EntityTypeNode:        {(*module).newNodesTableListQuery, orderByToNodesQueryNames},
+	inframonitoringtypes.EntityTypeNamespace:   {(*module).newNamespacesTableListQuery, orderByToNamespacesQueryNames},
+	inframonitoringtypes.EntityTypeDeployment:  {(*module).newDeploymentsTableListQuery, orderByToDeploymentsQueryNames},
+	inframonitoringtypes.EntityTypeStatefulSet: {(*module).newStatefulSetsTableListQuery, orderByToStatefulSetsQueryNames},
+	inframonitoringtypes.EntityTypeDaemonSet:   {(*module).newDaemonSetsTableListQuery, orderByToDaemonSetsQueryNames},
+	inframonitoringtypes.EntityTypeJob:         {(*module).newJobsTableListQuery, orderByToJobsQueryNames},
+	inframonitoringtypes.EntityTypePod:         {(*module).newPodsTableListQuery, orderByToPodsQueryNames},
+	inframonitoringtypes.EntityTypeContainer:   {(*module).newContainersTableListQuery, orderByToContainersQueryNames},
+	inframonitoringtypes.EntityTypeVolume:      {(*module).newVolumesTableListQuery, orderByToVolumesQueryNames},
+}
+
+// metricKeys maps the ranking query name of each order by key to the key.
+func (q entityMetricsQuery) metricKeys() map[string]string {
+	keys := make(map[string]string, len(q.orderBy))
+	for key, queryNames := range q.orderBy {
+		keys[queryNames[len(queryNames)-1]] = key
+	}
+	return keys
+}
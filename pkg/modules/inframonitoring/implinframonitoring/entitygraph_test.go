@@ -0,0 +1,163 @@
+package implinframonitoring
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// entityGraphRows keys the metadata rows like getMetadata does.
+func entityGraphRows(rows ...map[string]string) map[string]map[string]string {
+	out := make(map[string]map[string]string, len(rows))
+	for _, row := range rows {
+		values := make([]string, 0, len(entityGraphAttrKeys))
+		for _, key := range entityGraphAttrKeys {
+			values = append(values, row[key])
+		}
+		out[compositeKeyFromList(values)] = row
+	}
+	return out
+}
+
+func entityNames(entities []*inframonitoringtypes.Entity) []string {
+	names := make([]string, 0, len(entities))
+	for _, e := range entities {
+		names = append(names, e.Type.StringValue()+"/"+e.Name)
+	}
+	return names
+}
+
+func podRow(pod, replicaSet, node string, extra map[string]string) map[string]string {
+	row := map[string]string{
+		inframonitoringtypes.ClusterNameAttrKey:    "prod",
+		inframonitoringtypes.NamespaceNameAttrKey:  "default",
+		inframonitoringtypes.DeploymentNameAttrKey: "api",
+		inframonitoringtypes.ReplicaSetNameAttrKey: replicaSet,
+		inframonitoringtypes.NodeNameAttrKey:       node,
+		inframonitoringtypes.PodNameAttrKey:        pod,
+	}
+	for k, v := range extra {
+		row[k] = v
+	}
+	return row
+}
+
+func TestBuildEntityRelations(t *testing.T) {
+	rows := entityGraphRows(
+		podRow("api-1", "api-7d9f", "node-1", nil),
+		podRow("api-1", "api-7d9f", "node-1", map[string]string{inframonitoringtypes.ContainerNameAttrKey: "api"}),
+		podRow("api-1", "api-7d9f", "node-1", map[string]string{inframonitoringtypes.ContainerNameAttrKey: "istio-proxy"}),
+		podRow("api-1", "api-7d9f", "node-1", map[string]string{inframonitoringtypes.PersistentVolumeClaimNameAttrKey: "data-api-1"}),
+		// api-2 churned to another replicaset during the range.
+		podRow("api-2", "api-6c4b", "node-2", nil),
+		podRow("api-3", "api-7d9f", "node-2", nil),
+	)
+
+	testCases := []struct {
+		name          string
+		ref           inframonitoringtypes.EntityRef
+		parents       []string
+		children      []string
+		primaryParent string
+	}{
+		{
+			name:          "Pod",
+			ref:           inframonitoringtypes.EntityRef{Type: inframonitoringtypes.EntityTypePod, Attributes: map[string]string{inframonitoringtypes.PodNameAttrKey: "api-1"}},
+			parents:       []string{"replicaset/api-7d9f", "node/node-1"},
+			children:      []string{"container/api", "container/istio-proxy", "volume/data-api-1"},
+			primaryParent: "replicaset/api-7d9f",
+		},
+		{
+			name:          "Deployment",
+			ref:           inframonitoringtypes.EntityRef{Type: inframonitoringtypes.EntityTypeDeployment, Attributes: map[string]string{inframonitoringtypes.DeploymentNameAttrKey: "api"}},
+			parents:       []string{"namespace/default"},
+			children:      []string{"replicaset/api-6c4b", "replicaset/api-7d9f"},
+			primaryParent: "namespace/default",
+		},
+		{
+			name:          "Node",
+			ref:           inframonitoringtypes.EntityRef{Type: inframonitoringtypes.EntityTypeNode, Attributes: map[string]string{inframonitoringtypes.NodeNameAttrKey: "node-2"}},
+			parents:       []string{"cluster/prod"},
+			children:      []string{"pod/api-2", "pod/api-3"},
+			primaryParent: "cluster/prod",
+		},
+		{
+			name:          "Volume",
+			ref:           inframonitoringtypes.EntityRef{Type: inframonitoringtypes.EntityTypeVolume, Attributes: map[string]string{inframonitoringtypes.PersistentVolumeClaimNameAttrKey: "data-api-1"}},
+			parents:       []string{"pod/api-1"},
+			children:      []string{},
+			primaryParent: "pod/api-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entity, parents, children, primaryParent, err := buildEntityRelations(tc.ref, rows)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ref.Type, entity.Type)
+
+			parentList, _ := parents.list()
+			childList, _ := children.list()
+			assert.ElementsMatch(t, tc.parents, entityNames(parentList))
+			assert.Equal(t, tc.children, entityNames(childList))
+			assert.Equal(t, tc.primaryParent, primaryParent.Type.StringValue()+"/"+primaryParent.Name)
+		})
+	}
+}
+
+func TestBuildEntityRelationsErrors(t *testing.T) {
+	rows := entityGraphRows(
+		podRow("api-1", "api-7d9f", "node-1", nil),
+		podRow("api-1", "api-7d9f", "node-1", map[string]string{inframonitoringtypes.NamespaceNameAttrKey: "staging"}),
+	)
+
+	_, _, _, _, err := buildEntityRelations(inframonitoringtypes.EntityRef{
+		Type:       inframonitoringtypes.EntityTypePod,
+		Attributes: map[string]string{inframonitoringtypes.PodNameAttrKey: "api-9"},
+	}, rows)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	_, _, _, _, err = buildEntityRelations(inframonitoringtypes.EntityRef{
+		Type:       inframonitoringtypes.EntityTypePod,
+		Attributes: map[string]string{inframonitoringtypes.PodNameAttrKey: "api-1"},
+	}, rows)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+
+	_, _, _, _, err = buildEntityRelations(inframonitoringtypes.EntityRef{
+		Type:       inframonitoringtypes.EntityTypePod,
+		Attributes: map[string]string{inframonitoringtypes.PodNameAttrKey: "api-1", inframonitoringtypes.NamespaceNameAttrKey: "staging"},
+	}, rows)
+	assert.NoError(t, err)
+}
+
+func TestBuildEntitySiblings(t *testing.T) {
+	rows := entityGraphRows(
+		podRow("api-1", "api-7d9f", "node-1", nil),
+		podRow("api-2", "api-6c4b", "node-2", nil),
+		podRow("api-3", "api-7d9f", "node-2", nil),
+		podRow("api-3", "api-7d9f", "node-2", map[string]string{inframonitoringtypes.ContainerNameAttrKey: "api"}),
+	)
+
+	entity, _, _, primaryParent, err := buildEntityRelations(inframonitoringtypes.EntityRef{
+		Type:       inframonitoringtypes.EntityTypePod,
+		Attributes: map[string]string{inframonitoringtypes.PodNameAttrKey: "api-1"},
+	}, rows)
+	require.NoError(t, err)
+
+	siblings, truncated := buildEntitySiblings(entity, primaryParent, rows).list()
+	assert.False(t, truncated)
+	assert.Equal(t, []string{"pod/api-3"}, entityNames(siblings))
+}
+
+func TestEntityFilterExpr(t *testing.T) {
+	assert.Equal(t,
+		"k8s.namespace.name = 'default' AND k8s.pod.name = 'it\\'s'",
+		entityFilterExpr(map[string]string{
+			inframonitoringtypes.PodNameAttrKey:       "it's",
+			inframonitoringtypes.NamespaceNameAttrKey: "default",
+		}),
+	)
+}
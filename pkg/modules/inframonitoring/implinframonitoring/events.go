@@ -0,0 +1,222 @@
+package implinframonitoring
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/logstelemetryschema"
+	"github.com/SigNoz/signoz/pkg/types/featuretypes"
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// k8sEventObject is the involved object the events about an entity carry. Empty
+// namespace and cluster match any.
+type k8sEventObject struct {
+	kind      string
+	name      string
+	namespace string
+	cluster   string
+}
+
+// k8sEventObjectOf returns the involved object of the events about the entity, false
+// when events are not attached to entities of its type.
+func k8sEventObjectOf(e *inframonitoringtypes.Entity) (k8sEventObject, bool) {
+	kind := e.Type.EventKind()
+	if kind == "" {
+		return k8sEventObject{}, false
+	}
+
+	object := k8sEventObject{
+		kind:    kind,
+		name:    e.Name,
+		cluster: e.Attributes[inframonitoringtypes.ClusterNameAttrKey],
+	}
+	if kind != k8sNodeEventKind {
+		object.namespace = e.Attributes[inframonitoringtypes.NamespaceNameAttrKey]
+	}
+	return object, true
+}
+
+// matches reports whether an event about the given object is about o. Events logged
+// without a cluster name match entities of any cluster.
+func (o k8sEventObject) matches(kind, name, namespace, cluster string) bool {
+	return kind == o.kind &&
+		name == o.name &&
+		(o.namespace == "" || namespace == o.namespace) &&
+		(o.cluster == "" || cluster == "" || cluster == o.cluster)
+}
+
+// newK8sEventsSource selects the fields of the events logged by the k8sobjectsreceiver
+// in the time range. In watch mode the receiver logs {"type": ..., "object": <event>}.
+func (m *module) newK8sEventsSource(ctx context.Context, orgID valuer.UUID, startMs, endMs int64) *sqlbuilder.SelectBuilder {
+	body := logstelemetryschema.LogsV2BodyColumn
+	if m.fl.BooleanOrEmpty(ctx, flagger.FeatureUseJSONBody, featuretypes.NewFlaggerEvaluationContext(orgID)) {
+		body = fmt.Sprintf("toString(%s)", logstelemetryschema.LogsV2BodyV2Column)
+	}
+	field := func(path string) string {
+		return fmt.Sprintf("JSONExtractString(%s, 'object', %s)", body, path)
+	}
+
+	startNs, endNs := querybuilder.ToNanoSecs(uint64(startMs)), querybuilder.ToNanoSecs(uint64(endMs))
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		logstelemetryschema.LogsV2TimestampColumn,
+		fmt.Sprintf("%s AS event_type", field("'type'")),
+		fmt.Sprintf("%s AS reason", field("'reason'")),
+		fmt.Sprintf("%s AS message", field("'message'")),
+		fmt.Sprintf("JSONExtractInt(%s, 'object', 'count') AS event_count", body),
+		fmt.Sprintf("if(%s != '', %s, %s) AS source", field("'reportingComponent'"), field("'reportingComponent'"), field("'source', 'component'")),
+		fmt.Sprintf("%s AS involved_kind", field("'involvedObject', 'kind'")),
+		fmt.Sprintf("%s AS involved_name", field("'involvedObject', 'name'")),
+		fmt.Sprintf("%s AS involved_namespace", field("'involvedObject', 'namespace'")),
+		fmt.Sprintf("%s[%s] AS cluster", logstelemetryschema.LogsV2ResourcesStringColumn, querybuilder.ClickHouseStringLiteral(inframonitoringtypes.ClusterNameAttrKey)),
+	)
+	sb.From(fmt.Sprintf("%s.%s", logstelemetryschema.DBName, logstelemetryschema.LogsV2TableName))
+	sb.Where(
+		sb.GE(logstelemetryschema.LogsV2TimestampColumn, startNs),
+		sb.L(logstelemetryschema.LogsV2TimestampColumn, endNs),
+		sb.GE(logstelemetryschema.LogsV2TimestampBucketStartColumn, startNs/querybuilder.NsToSeconds-querybuilder.BucketAdjustment),
+		sb.LE(logstelemetryschema.LogsV2TimestampBucketStartColumn, endNs/querybuilder.NsToSeconds),
+		sb.E(fmt.Sprintf("%s[%s]", logstelemetryschema.LogsV2AttributesStringColumn, querybuilder.ClickHouseStringLiteral(k8sResourceNameAttrKey)), k8sEventsResourceName),
+	)
+	return sb
+}
+
+// getRecentK8sEvents returns the latest events about the entity in the time range, none
+// when events are not attached to entities of its type.
+func (m *module) getRecentK8sEvents(ctx context.Context, orgID valuer.UUID, startMs, endMs int64, entity *inframonitoringtypes.Entity) ([]inframonitoringtypes.K8sEvent, error) {
+	events := []inframonitoringtypes.K8sEvent{}
+	object, ok := k8sEventObjectOf(entity)
+	if !ok {
+		return events, nil
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		logstelemetryschema.LogsV2TimestampColumn,
+		"event_type",
+		"reason",
+		"message",
+		"event_count",
+		"source",
+		"involved_kind",
+		"involved_name",
+		"involved_namespace",
+	)
+	sb.From(sb.BuilderAs(m.newK8sEventsSource(ctx, orgID, startMs, endMs), "events"))
+	sb.Where(
+		sb.E("involved_kind", object.kind),
+		sb.E("involved_name", object.name),
+	)
+	if object.namespace != "" {
+		sb.Where(sb.E("involved_namespace", object.namespace))
+	}
+	if object.cluster != "" {
+		sb.Where(sb.In("cluster", object.cluster, ""))
+	}
+	sb.OrderBy(logstelemetryschema.LogsV2TimestampColumn).Desc()
+	sb.Limit(maxRecentK8sEvents)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := m.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			timestampNs uint64
+			event       inframonitoringtypes.K8sEvent
+		)
+		if err := rows.Scan(
+			&timestampNs,
+			&event.Type,
+			&event.Reason,
+			&event.Message,
+			&event.Count,
+			&event.Source,
+			&event.InvolvedObject.Kind,
+			&event.InvolvedObject.Name,
+			&event.InvolvedObject.Namespace,
+		); err != nil {
+			return nil, err
+		}
+		event.Timestamp = int64(timestampNs / 1_000_000)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// fillEntityEventCounts sets the counts of the events about the entities in the time
+// range, for the types events are attached to.
+func (m *module) fillEntityEventCounts(ctx context.Context, orgID valuer.UUID, startMs, endMs int64, entities []*inframonitoringtypes.Entity) error {
+	objects := make([]k8sEventObject, len(entities))
+	kinds, names := make(map[string]struct{}), make(map[string]struct{})
+	for i, e := range entities {
+		object, ok := k8sEventObjectOf(e)
+		if !ok {
+			continue
+		}
+		objects[i] = object
+		e.EventCounts = &inframonitoringtypes.K8sEventCounts{}
+		kinds[object.kind] = struct{}{}
+		names[object.name] = struct{}{}
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"involved_kind",
+		"involved_name",
+		"involved_namespace",
+		"cluster",
+		"count() AS total",
+		fmt.Sprintf("countIf(event_type = %s) AS warning", querybuilder.ClickHouseStringLiteral(inframonitoringtypes.K8sEventTypeWarning)),
+	)
+	sb.From(sb.BuilderAs(m.newK8sEventsSource(ctx, orgID, startMs, endMs), "events"))
+	sb.Where(
+		sb.In("involved_kind", sqlbuilder.List(slices.Collect(maps.Keys(kinds)))),
+		sb.In("involved_name", sqlbuilder.List(slices.Collect(maps.Keys(names)))),
+	)
+	sb.GroupBy("involved_kind", "involved_name", "involved_namespace", "cluster")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := m.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			kind, name, namespace, cluster string
+			total, warning                 uint64
+		)
+		if err := rows.Scan(&kind, &name, &namespace, &cluster, &total, &warning); err != nil {
+			return err
+		}
+		for i, e := range entities {
+			if e.EventCounts == nil || !objects[i].matches(kind, name, namespace, cluster) {
+				continue
+			}
+			e.EventCounts.Total += int64(total)
+			e.EventCounts.Warning += int64(warning)
+		}
+	}
+
+	return rows.Err()
+}
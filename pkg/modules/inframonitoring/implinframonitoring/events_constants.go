@@ -0,0 +1,23 @@
+package implinframonitoring
+
+import _ "embed"
+
+const (
+	// k8sResourceNameAttrKey is the log attribute the k8sobjectsreceiver sets to the
+	// resource of the watched object; events are logged with k8sEventsResourceName.
+	k8sResourceNameAttrKey = "k8s.resource.name"
+	k8sEventsResourceName  = "events"
+
+	// k8sNodeEventKind is the only cluster-scoped kind events are attached to; its
+	// events are matched regardless of their namespace.
+	k8sNodeEventKind = "Node"
+
+	// maxRecentK8sEvents is the number of events listed for an entity.
+	maxRecentK8sEvents = 50
+)
+
+// k8sWarningEventsAlertTemplate is the built-in alert rule firing on spikes of
+// Warning events, grouped by cluster.
+//
+//go:embed alerttemplates/k8s_warning_events.json
+var k8sWarningEventsAlertTemplate []byte
@@ -0,0 +1,80 @@
+package implinframonitoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestK8sEventObjectOf(t *testing.T) {
+	pod := &inframonitoringtypes.Entity{
+		Type: inframonitoringtypes.EntityTypePod,
+		Name: "api-7d9f-abc",
+		Attributes: map[string]string{
+			inframonitoringtypes.ClusterNameAttrKey:   "prod",
+			inframonitoringtypes.NamespaceNameAttrKey: "default",
+		},
+	}
+	object, ok := k8sEventObjectOf(pod)
+	require.True(t, ok)
+	assert.Equal(t, k8sEventObject{kind: "Pod", name: "api-7d9f-abc", namespace: "default", cluster: "prod"}, object)
+
+	// Node events are logged in the default namespace; the node has none.
+	node := &inframonitoringtypes.Entity{
+		Type: inframonitoringtypes.EntityTypeNode,
+		Name: "node-1",
+		Attributes: map[string]string{
+			inframonitoringtypes.ClusterNameAttrKey: "prod",
+		},
+	}
+	object, ok = k8sEventObjectOf(node)
+	require.True(t, ok)
+	assert.Equal(t, k8sEventObject{kind: "Node", name: "node-1", cluster: "prod"}, object)
+
+	for _, typ := range []inframonitoringtypes.EntityType{
+		inframonitoringtypes.EntityTypeCluster,
+		inframonitoringtypes.EntityTypeNamespace,
+		inframonitoringtypes.EntityTypeContainer,
+		inframonitoringtypes.EntityTypeVolume,
+	} {
+		_, ok := k8sEventObjectOf(&inframonitoringtypes.Entity{Type: typ, Name: "x"})
+		assert.False(t, ok, typ.StringValue())
+	}
+}
+
+func TestK8sEventObjectMatches(t *testing.T) {
+	pod := k8sEventObject{kind: "Pod", name: "api", namespace: "default", cluster: "prod"}
+	node := k8sEventObject{kind: "Node", name: "node-1", cluster: "prod"}
+
+	tests := []struct {
+		name                            string
+		object                          k8sEventObject
+		kind, eName, namespace, cluster string
+		want                            bool
+	}{
+		{name: "exact", object: pod, kind: "Pod", eName: "api", namespace: "default", cluster: "prod", want: true},
+		{name: "event without cluster", object: pod, kind: "Pod", eName: "api", namespace: "default", want: true},
+		{name: "other cluster", object: pod, kind: "Pod", eName: "api", namespace: "default", cluster: "staging"},
+		{name: "other namespace", object: pod, kind: "Pod", eName: "api", namespace: "kube-system", cluster: "prod"},
+		{name: "other kind", object: pod, kind: "Deployment", eName: "api", namespace: "default", cluster: "prod"},
+		{name: "other name", object: pod, kind: "Pod", eName: "web", namespace: "default", cluster: "prod"},
+		{name: "node in any namespace", object: node, kind: "Node", eName: "node-1", namespace: "default", cluster: "prod", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.object.matches(tt.kind, tt.eName, tt.namespace, tt.cluster))
+		})
+	}
+}
+
+func TestGetK8sWarningEventsAlertTemplate(t *testing.T) {
+	rule, err := (&module{}).GetK8sWarningEventsAlertTemplate(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, rule.Validate())
+	assert.Equal(t, ruletypes.AlertTypeLogs, rule.AlertType)
+}
@@ -608,6 +608,40 @@ func (m *module) getAttributesExistence(ctx context.Context, metricNames, attrNa
 	return present, nil
 }
 
+// getLogAttributesExistence returns, for each requested attrName, whether it has ever
+// been reported as an attribute of a log record.
+func (m *module) getLogAttributesExistence(ctx context.Context, orgID valuer.UUID, attrNames []string) (map[string]bool, error) {
+	present := make(map[string]bool, len(attrNames))
+	for _, a := range attrNames {
+		present[a] = false
+	}
+	if len(attrNames) == 0 {
+		return present, nil
+	}
+
+	selectors := make([]*telemetrytypes.FieldKeySelector, 0, len(attrNames))
+	for _, a := range attrNames {
+		selectors = append(selectors, &telemetrytypes.FieldKeySelector{
+			Name:              a,
+			Signal:            telemetrytypes.SignalLogs,
+			FieldContext:      telemetrytypes.FieldContextAttribute,
+			SelectorMatchType: telemetrytypes.FieldSelectorMatchTypeExact,
+		})
+	}
+
+	keys, _, err := m.telemetryMetadataStore.GetKeysMulti(ctx, orgID, selectors)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range attrNames {
+		if len(keys[a]) > 0 {
+			present[a] = true
+		}
+	}
+
+	return present, nil
+}
+
 // getMetadata fetches the latest values of additionalCols for each unique combination of groupBy keys,
 // within the given time range and metric names. It uses argMax(tuple(...), unix_milli) to ensure
 // we always pick attribute values from the latest timestamp for each group.
@@ -92,12 +92,15 @@ type bucketSplit struct {
 // checkComponentBucket is a single collector component's contribution
 // toward a single infra-monitoring tab's readiness. Any of the three dimension
 // slices (DefaultMetrics, OptionalMetrics, RequiredAttrs) may be empty — the
-// bucketizer in Phase 4 skips empty dimensions.
+// bucketizer in Phase 4 skips empty dimensions. RequiredLogAttrs are log
+// attributes, for components shipping logs rather than metrics (k8s events);
+// they are reported alongside RequiredAttrs.
 type checkComponentBucket struct {
 	Component         inframonitoringtypes.AssociatedComponent
 	DefaultMetrics    []string
 	OptionalMetrics   []string
 	RequiredAttrs     []string
+	RequiredLogAttrs  []string
 	DocumentationLink string
 }
 
@@ -124,6 +127,14 @@ func (s checkSpec) getAllAttrs() []string {
 	return out
 }
 
+func (s checkSpec) getAllLogAttrs() []string {
+	var out []string
+	for _, b := range s.Buckets {
+		out = append(out, b.RequiredLogAttrs...)
+	}
+	return out
+}
+
 // containerStatusCounts holds per-group container counts bucketed by latest
 // kubectl-style display status in window. Mirrors inframonitoringtypes.ContainerCountsByStatus.
 type containerStatusCounts struct {
@@ -2,7 +2,10 @@ package implinframonitoring
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/factory"
@@ -15,6 +18,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
 	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"golang.org/x/sync/errgroup"
@@ -93,6 +97,17 @@ func (m *module) GetChecks(ctx context.Context, orgID valuer.UUID, req *inframon
 		}
 	}
 
+	allLogAttrs := spec.getAllLogAttrs()
+	presentLogAttrs, err := m.getLogAttributesExistence(ctx, orgID, allLogAttrs)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range allLogAttrs {
+		if !presentLogAttrs[name] {
+			missingAttrsMap[name] = true
+		}
+	}
+
 	resp := &inframonitoringtypes.Checks{
 		Type:                         req.Type,
 		PresentDefaultEnabledMetrics: []inframonitoringtypes.MetricsComponentEntry{},
@@ -1328,6 +1343,431 @@ func (m *module) ListDaemonSets(ctx context.Context, orgID valuer.UUID, req *inf
 	return resp, nil
 }
 
+func (m *module) ListECSClusters(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSClusters) (*inframonitoringtypes.ECSClusters, error) {
+	ctx = m.withInfraMonitoringContext(ctx, "ListECSClusters")
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &inframonitoringtypes.ECSClusters{}
+
+	if req.OrderBy == nil {
+		req.OrderBy = &qbtypes.OrderBy{
+			Key: qbtypes.OrderByKey{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+					Name: inframonitoringtypes.ECSClustersOrderByCPU,
+				},
+			},
+			Direction: qbtypes.OrderDirectionDesc,
+		}
+	}
+
+	if len(req.GroupBy) == 0 {
+		req.GroupBy = []qbtypes.GroupByKey{ecsClusterNameGroupByKey}
+		resp.Type = inframonitoringtypes.ResponseTypeList
+	} else {
+		resp.Type = inframonitoringtypes.ResponseTypeGroupedList
+	}
+
+	minFirstReportedUnixMilli, err := m.getEarliestMetricTime(ctx, ecsClustersTableMetricNamesList)
+	if err != nil {
+		return nil, err
+	}
+	if req.End < int64(minFirstReportedUnixMilli) {
+		resp.EndTimeBeforeRetention = true
+		resp.Records = []inframonitoringtypes.ECSClusterRecord{}
+		resp.Total = 0
+		return resp, nil
+	}
+
+	pageGroups, metadataMap, err := m.getTopECSClusterGroupsAndMetadata(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Total = len(metadataMap)
+
+	if len(pageGroups) == 0 {
+		resp.Records = []inframonitoringtypes.ECSClusterRecord{}
+		return resp, nil
+	}
+
+	filterExpr := ""
+	if req.Filter != nil {
+		filterExpr = req.Filter.Expression
+	}
+
+	fullQueryReq := buildFullQueryRequest(req.Start, req.End, filterExpr, req.GroupBy, pageGroups, m.newECSClustersTableListQuery())
+
+	var (
+		queryResp      *qbtypes.QueryRangeResponse
+		resourceCounts map[string]map[string]int64
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		queryResp, err = m.querier.QueryRange(gCtx, orgID, fullQueryReq)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		resourceCounts, err = m.getPerGroupDistinctCounts(gCtx, orgID, req.Start, req.End, req.Filter, req.GroupBy, pageGroups, ecsClusterCountAttrKeys, ecsClustersTableMetricNamesList)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	resp.Records = buildECSClusterRecords(queryResp, pageGroups, req.GroupBy, metadataMap, resourceCounts)
+	resp.Warning = queryResp.Warning
+
+	return resp, nil
+}
+
+func (m *module) ListECSServices(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSServices) (*inframonitoringtypes.ECSServices, error) {
+	ctx = m.withInfraMonitoringContext(ctx, "ListECSServices")
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &inframonitoringtypes.ECSServices{}
+
+	if req.OrderBy == nil {
+		req.OrderBy = &qbtypes.OrderBy{
+			Key: qbtypes.OrderByKey{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+					Name: inframonitoringtypes.ECSServicesOrderByCPU,
+				},
+			},
+			Direction: qbtypes.OrderDirectionDesc,
+		}
+	}
+
+	if len(req.GroupBy) == 0 {
+		req.GroupBy = []qbtypes.GroupByKey{ecsServiceNameGroupByKey, ecsClusterNameGroupByKey}
+		resp.Type = inframonitoringtypes.ResponseTypeList
+	} else {
+		resp.Type = inframonitoringtypes.ResponseTypeGroupedList
+	}
+
+	// Bake the ECS services base filter into req.Filter so all downstream helpers pick it up.
+	if req.Filter == nil {
+		req.Filter = &qbtypes.Filter{}
+	}
+	req.Filter.Expression = mergeFilterExpressions(ecsServicesBaseFilterExpr, req.Filter.Expression)
+
+	minFirstReportedUnixMilli, err := m.getEarliestMetricTime(ctx, ecsServicesTableMetricNamesList)
+	if err != nil {
+		return nil, err
+	}
+	if req.End < int64(minFirstReportedUnixMilli) {
+		resp.EndTimeBeforeRetention = true
+		resp.Records = []inframonitoringtypes.ECSServiceRecord{}
+		resp.Total = 0
+		return resp, nil
+	}
+
+	pageGroups, metadataMap, err := m.getTopECSServiceGroupsAndMetadata(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Total = len(metadataMap)
+
+	if len(pageGroups) == 0 {
+		resp.Records = []inframonitoringtypes.ECSServiceRecord{}
+		return resp, nil
+	}
+
+	filterExpr := ""
+	if req.Filter != nil {
+		filterExpr = req.Filter.Expression
+	}
+
+	fullQueryReq := buildFullQueryRequest(req.Start, req.End, filterExpr, req.GroupBy, pageGroups, m.newECSServicesTableListQuery())
+
+	var (
+		queryResp      *qbtypes.QueryRangeResponse
+		resourceCounts map[string]map[string]int64
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		queryResp, err = m.querier.QueryRange(gCtx, orgID, fullQueryReq)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		resourceCounts, err = m.getPerGroupDistinctCounts(gCtx, orgID, req.Start, req.End, req.Filter, req.GroupBy, pageGroups, ecsServiceCountAttrKeys, ecsServicesTableMetricNamesList)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	resp.Records = buildECSServiceRecords(queryResp, pageGroups, req.GroupBy, metadataMap, resourceCounts)
+	resp.Warning = queryResp.Warning
+
+	return resp, nil
+}
+
+func (m *module) ListECSTasks(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSTasks) (*inframonitoringtypes.ECSTasks, error) {
+	ctx = m.withInfraMonitoringContext(ctx, "ListECSTasks")
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &inframonitoringtypes.ECSTasks{}
+
+	if req.OrderBy == nil {
+		req.OrderBy = &qbtypes.OrderBy{
+			Key: qbtypes.OrderByKey{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+					Name: inframonitoringtypes.ECSTasksOrderByCPU,
+				},
+			},
+			Direction: qbtypes.OrderDirectionDesc,
+		}
+	}
+
+	if len(req.GroupBy) == 0 {
+		req.GroupBy = []qbtypes.GroupByKey{ecsTaskIDGroupByKey, ecsClusterNameGroupByKey}
+		resp.Type = inframonitoringtypes.ResponseTypeList
+	} else {
+		resp.Type = inframonitoringtypes.ResponseTypeGroupedList
+	}
+
+	minFirstReportedUnixMilli, err := m.getEarliestMetricTime(ctx, ecsTasksTableMetricNamesList)
+	if err != nil {
+		return nil, err
+	}
+	if req.End < int64(minFirstReportedUnixMilli) {
+		resp.EndTimeBeforeRetention = true
+		resp.Records = []inframonitoringtypes.ECSTaskRecord{}
+		resp.Total = 0
+		return resp, nil
+	}
+
+	pageGroups, metadataMap, err := m.getTopECSTaskGroupsAndMetadata(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Total = len(metadataMap)
+
+	if len(pageGroups) == 0 {
+		resp.Records = []inframonitoringtypes.ECSTaskRecord{}
+		return resp, nil
+	}
+
+	filterExpr := ""
+	if req.Filter != nil {
+		filterExpr = req.Filter.Expression
+	}
+
+	fullQueryReq := buildFullQueryRequest(req.Start, req.End, filterExpr, req.GroupBy, pageGroups, m.newECSTasksTableListQuery())
+	queryResp, err := m.querier.QueryRange(ctx, orgID, fullQueryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Records = buildECSTaskRecords(queryResp, pageGroups, req.GroupBy, metadataMap)
+	resp.Warning = queryResp.Warning
+
+	return resp, nil
+}
+
+func (m *module) ListDockerContainers(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableDockerContainers) (*inframonitoringtypes.DockerContainers, error) {
+	ctx = m.withInfraMonitoringContext(ctx, "ListDockerContainers")
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &inframonitoringtypes.DockerContainers{}
+
+	if req.OrderBy == nil {
+		req.OrderBy = &qbtypes.OrderBy{
+			Key: qbtypes.OrderByKey{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+					Name: inframonitoringtypes.DockerContainersOrderByCPU,
+				},
+			},
+			Direction: qbtypes.OrderDirectionDesc,
+		}
+	}
+
+	if len(req.GroupBy) == 0 {
+		req.GroupBy = []qbtypes.GroupByKey{dockerContainerNameGroupByKey, hostNameGroupByKey}
+		resp.Type = inframonitoringtypes.ResponseTypeList
+	} else {
+		resp.Type = inframonitoringtypes.ResponseTypeGroupedList
+	}
+
+	// Bake the Docker containers base filter into req.Filter so all downstream helpers pick it up.
+	if req.Filter == nil {
+		req.Filter = &qbtypes.Filter{}
+	}
+	req.Filter.Expression = mergeFilterExpressions(dockerContainersBaseFilterExpr, req.Filter.Expression)
+
+	minFirstReportedUnixMilli, err := m.getEarliestMetricTime(ctx, dockerContainersTableMetricNamesList)
+	if err != nil {
+		return nil, err
+	}
+	if req.End < int64(minFirstReportedUnixMilli) {
+		resp.EndTimeBeforeRetention = true
+		resp.Records = []inframonitoringtypes.DockerContainerRecord{}
+		resp.Total = 0
+		return resp, nil
+	}
+
+	pageGroups, metadataMap, err := m.getTopDockerContainerGroupsAndMetadata(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Total = len(metadataMap)
+
+	if len(pageGroups) == 0 {
+		resp.Records = []inframonitoringtypes.DockerContainerRecord{}
+		return resp, nil
+	}
+
+	filterExpr := ""
+	if req.Filter != nil {
+		filterExpr = req.Filter.Expression
+	}
+
+	fullQueryReq := buildFullQueryRequest(req.Start, req.End, filterExpr, req.GroupBy, pageGroups, m.newDockerContainersTableListQuery())
+	queryResp, err := m.querier.QueryRange(ctx, orgID, fullQueryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Records = buildDockerContainerRecords(queryResp, pageGroups, req.GroupBy, metadataMap)
+	resp.Warning = queryResp.Warning
+
+	return resp, nil
+}
+
+func (m *module) GetEntityGraph(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableEntityGraph) (*inframonitoringtypes.EntityGraph, error) {
+	ctx = m.withInfraMonitoringContext(ctx, "GetEntityGraph")
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &inframonitoringtypes.EntityGraph{
+		Entity: &inframonitoringtypes.Entity{
+			Type:       req.Entity.Type,
+			Name:       req.Entity.Attributes[req.Entity.Type.NameAttrKey()],
+			Attributes: req.Entity.Attributes,
+			Metrics:    map[string]float64{},
+		},
+		Parents:  []*inframonitoringtypes.Entity{},
+		Children: []*inframonitoringtypes.Entity{},
+		Siblings: []*inframonitoringtypes.Entity{},
+		Events:   []inframonitoringtypes.K8sEvent{},
+	}
+
+	minFirstReportedUnixMilli, err := m.getEarliestMetricTime(ctx, entityGraphMetricNamesList)
+	if err != nil {
+		return nil, err
+	}
+	if req.End < int64(minFirstReportedUnixMilli) {
+		resp.EndTimeBeforeRetention = true
+		return resp, nil
+	}
+
+	rows, err := m.getEntityGraphRows(ctx, orgID, entityFilterExpr(req.Entity.Attributes), req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, parents, children, primaryParent, err := buildEntityRelations(req.Entity, rows)
+	if err != nil {
+		return nil, err
+	}
+	resp.Entity = entity
+
+	siblings := newEntitySet()
+	if primaryParent != nil {
+		// Siblings are the entities of the same type sharing the primary parent.
+		filterExpr := mergeFilterExpressions(entityFilterExpr(primaryParent.Attributes), fmt.Sprintf("%s != ''", entity.Type.NameAttrKey()))
+		siblingRows, err := m.getEntityGraphRows(ctx, orgID, filterExpr, req.Start, req.End)
+		if err != nil {
+			return nil, err
+		}
+		siblings = buildEntitySiblings(entity, primaryParent, siblingRows)
+	}
+
+	var truncated []string
+	for _, relation := range []struct {
+		name   string
+		set    *entitySet
+		target *[]*inframonitoringtypes.Entity
+	}{
+		{"parents", parents, &resp.Parents},
+		{"children", children, &resp.Children},
+		{"siblings", siblings, &resp.Siblings},
+	} {
+		var isTruncated bool
+		*relation.target, isTruncated = relation.set.list()
+		if isTruncated {
+			truncated = append(truncated, relation.name)
+		}
+	}
+
+	entities := make([]*inframonitoringtypes.Entity, 0, 1+len(resp.Parents)+len(resp.Children)+len(resp.Siblings))
+	entities = append(entities, resp.Entity)
+	entities = append(entities, resp.Parents...)
+	entities = append(entities, resp.Children...)
+	entities = append(entities, resp.Siblings...)
+
+	metricsWarning, err := m.fillEntityMetrics(ctx, orgID, req.Start, req.End, entities)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.fillEntityEventCounts(ctx, orgID, req.Start, req.End, entities); err != nil {
+		return nil, err
+	}
+
+	resp.Events, err = m.getRecentK8sEvents(ctx, orgID, req.Start, req.End, resp.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var truncatedWarning *qbtypes.QueryWarnData
+	if len(truncated) > 0 {
+		truncatedWarning = &qbtypes.QueryWarnData{
+			Message: fmt.Sprintf("%s truncated to %d entities", strings.Join(truncated, ", "), maxEntityGraphRelations),
+		}
+	}
+	resp.Warning = mergeQueryWarnings(truncatedWarning, metricsWarning)
+
+	return resp, nil
+}
+
+// GetK8sWarningEventsAlertTemplate returns the built-in alert rule firing on spikes of
+// Kubernetes Warning events, to be created through the rules API.
+func (m *module) GetK8sWarningEventsAlertTemplate(_ context.Context) (*ruletypes.PostableRule, error) {
+	rule := new(ruletypes.PostableRule)
+	if err := json.Unmarshal(k8sWarningEventsAlertTemplate, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
 func (m *module) withInfraMonitoringContext(ctx context.Context, functionName string) context.Context {
 	comments := map[string]string{
 		instrumentationtypes.TelemetrySignal:  telemetrytypes.SignalMetrics.StringValue(),
@@ -6,6 +6,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/statsreporter"
 	"github.com/SigNoz/signoz/pkg/types/inframonitoringtypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
@@ -21,7 +22,13 @@ type Handler interface {
 	ListStatefulSets(http.ResponseWriter, *http.Request)
 	ListJobs(http.ResponseWriter, *http.Request)
 	ListDaemonSets(http.ResponseWriter, *http.Request)
+	ListECSClusters(http.ResponseWriter, *http.Request)
+	ListECSServices(http.ResponseWriter, *http.Request)
+	ListECSTasks(http.ResponseWriter, *http.Request)
+	ListDockerContainers(http.ResponseWriter, *http.Request)
 	GetChecks(http.ResponseWriter, *http.Request)
+	GetEntityGraph(http.ResponseWriter, *http.Request)
+	GetK8sWarningEventsAlertTemplate(http.ResponseWriter, *http.Request)
 }
 
 type Module interface {
@@ -37,5 +44,11 @@ type Module interface {
 	ListStatefulSets(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableStatefulSets) (*inframonitoringtypes.StatefulSets, error)
 	ListJobs(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableJobs) (*inframonitoringtypes.Jobs, error)
 	ListDaemonSets(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableDaemonSets) (*inframonitoringtypes.DaemonSets, error)
+	ListECSClusters(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSClusters) (*inframonitoringtypes.ECSClusters, error)
+	ListECSServices(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSServices) (*inframonitoringtypes.ECSServices, error)
+	ListECSTasks(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableECSTasks) (*inframonitoringtypes.ECSTasks, error)
+	ListDockerContainers(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableDockerContainers) (*inframonitoringtypes.DockerContainers, error)
 	GetChecks(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableChecks) (*inframonitoringtypes.Checks, error)
+	GetEntityGraph(ctx context.Context, orgID valuer.UUID, req *inframonitoringtypes.PostableEntityGraph) (*inframonitoringtypes.EntityGraph, error)
+	GetK8sWarningEventsAlertTemplate(ctx context.Context) (*ruletypes.PostableRule, error)
 }
@@ -0,0 +1,131 @@
+package impllmcost
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// budgetWarningPercent is the share of the monthly limit the month-to-date spend
+	// warns at; the critical threshold is the limit itself.
+	budgetWarningPercent = 80
+
+	// budgetIDLabel labels the alerts of a budget with its id.
+	budgetIDLabel = "llm_budget_id"
+)
+
+// newBudgetAlertRule returns the threshold rule on the month-to-date spend of the budget:
+// a cumulative evaluation restarting on the 1st of every month (UTC) sums the hourly
+// spend since then, firing warning at budgetWarningPercent of the limit and critical at
+// the limit.
+func newBudgetAlertRule(budget *llmcosttypes.Budget) *ruletypes.PostableRule {
+	warning := budget.MonthlyLimit * budgetWarningPercent / 100
+	critical := budget.MonthlyLimit
+	day, hour, minute := 1, 0, 0
+	limit := strconv.FormatFloat(budget.MonthlyLimit, 'f', -1, 64)
+
+	return &ruletypes.PostableRule{
+		AlertName:     fmt.Sprintf("LLM budget %s", budget.Name),
+		AlertType:     ruletypes.AlertTypeTraces,
+		Description:   fmt.Sprintf("Month-to-date LLM spend of budget %s is burning through its monthly limit of %s", budget.Name, limit),
+		RuleType:      ruletypes.RuleTypeThreshold,
+		Version:       "v5",
+		SchemaVersion: ruletypes.SchemaVersionV2Alpha1,
+		RuleCondition: &ruletypes.RuleCondition{
+			CompositeQuery: &ruletypes.AlertCompositeQuery{
+				QueryType: ruletypes.QueryTypeBuilder,
+				PanelType: ruletypes.PanelTypeGraph,
+				Queries: []qbtypes.QueryEnvelope{
+					{
+						Type: qbtypes.QueryTypeBuilderAI,
+						Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+							Name:         "A",
+							Signal:       telemetrytypes.SignalTraces,
+							StepInterval: qbtypes.Step{Duration: time.Hour},
+							Aggregations: costAggregations[:1],
+							Filter:       &qbtypes.Filter{Expression: budget.FilterExpression()},
+						},
+					},
+				},
+			},
+			SelectedQuery: "A",
+			Thresholds: &ruletypes.RuleThresholdData{
+				Kind: ruletypes.BasicThresholdKind,
+				Spec: ruletypes.BasicRuleThresholds{
+					{Name: "critical", TargetValue: &critical, MatchType: ruletypes.InTotalLiteral, CompareOperator: ruletypes.ValueIsAboveLiteral},
+					{Name: "warning", TargetValue: &warning, MatchType: ruletypes.InTotalLiteral, CompareOperator: ruletypes.ValueIsAboveLiteral},
+				},
+			},
+		},
+		Evaluation: &ruletypes.EvaluationEnvelope{
+			Kind: ruletypes.CumulativeEvaluation,
+			Spec: ruletypes.CumulativeWindow{
+				Schedule: ruletypes.CumulativeSchedule{
+					Type:   ruletypes.ScheduleTypeMonthly,
+					Day:    &day,
+					Hour:   &hour,
+					Minute: &minute,
+				},
+				Frequency: valuer.MustParseTextDuration("1h"),
+				Timezone:  time.UTC.String(),
+			},
+		},
+		NotificationSettings: &ruletypes.NotificationSettings{
+			Renotify: &ruletypes.Renotify{
+				Enabled:          true,
+				ReNotifyInterval: valuer.MustParseTextDuration("24h"),
+				AlertStates:      []ruletypes.AlertState{ruletypes.StateFiring},
+			},
+		},
+		Labels: map[string]string{
+			budgetIDLabel: budget.ID.StringValue(),
+		},
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("LLM budget %s is burning", budget.Name),
+			"description": fmt.Sprintf("The month-to-date LLM spend of budget %s is {{$value}}, above the {{$threshold}} threshold of its monthly limit of %s.", budget.Name, limit),
+		},
+	}
+}
+
+// budgetAlertRuleUpdate is the part of the alert rule of a budget derived from the budget,
+// patched onto the rule when the budget changes. The rest of the rule, e.g. its
+// notification settings, is left as the users set it.
+type budgetAlertRuleUpdate struct {
+	AlertName     string                   `json:"alert"`
+	Description   string                   `json:"description"`
+	RuleCondition *ruletypes.RuleCondition `json:"condition"`
+	Annotations   map[string]string        `json:"annotations"`
+}
+
+// newBudgetAlertRuleUpdate returns the update of the current alert rule of the budget,
+// keeping the channels its thresholds notify.
+func newBudgetAlertRuleUpdate(budget *llmcosttypes.Budget, current *ruletypes.PostableRule) *budgetAlertRuleUpdate {
+	rule := newBudgetAlertRule(budget)
+
+	if current.RuleCondition != nil && current.RuleCondition.Thresholds != nil {
+		if currentThresholds, ok := current.RuleCondition.Thresholds.Spec.(ruletypes.BasicRuleThresholds); ok {
+			channels := make(map[string][]string, len(currentThresholds))
+			for _, threshold := range currentThresholds {
+				channels[threshold.Name] = threshold.Channels
+			}
+			thresholds := rule.RuleCondition.Thresholds.Spec.(ruletypes.BasicRuleThresholds)
+			for i := range thresholds {
+				thresholds[i].Channels = channels[thresholds[i].Name]
+			}
+		}
+	}
+
+	return &budgetAlertRuleUpdate{
+		AlertName:     rule.AlertName,
+		Description:   rule.Description,
+		RuleCondition: rule.RuleCondition,
+		Annotations:   rule.Annotations,
+	}
+}
@@ -0,0 +1,98 @@
+package impllmcost
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBudgetAlertRule(t *testing.T) {
+	budget := llmcosttypes.NewBudget(valuer.GenerateUUID(), "admin@example.com", &llmcosttypes.PostableBudget{
+		Name:           "search",
+		AttributeKey:   "team",
+		AttributeValue: "search",
+		MonthlyLimit:   250,
+	})
+
+	// the rule reaches the rules manager as JSON
+	data, err := json.Marshal(newBudgetAlertRule(budget))
+	require.NoError(t, err)
+
+	rule := new(ruletypes.PostableRule)
+	require.NoError(t, json.Unmarshal(data, rule))
+	require.NoError(t, rule.Validate())
+
+	require.Len(t, rule.RuleCondition.CompositeQuery.Queries, 1)
+	query := rule.RuleCondition.CompositeQuery.Queries[0]
+	assert.Equal(t, qbtypes.QueryTypeBuilderAI, query.Type)
+	spec, ok := query.Spec.(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
+	require.True(t, ok)
+	assert.Equal(t, "team = 'search'", spec.Filter.Expression)
+	assert.Equal(t, "sum(_signoz.gen_ai.total_cost)", spec.Aggregations[0].Expression)
+
+	thresholds, ok := rule.RuleCondition.Thresholds.Spec.(ruletypes.BasicRuleThresholds)
+	require.True(t, ok)
+	require.Len(t, thresholds, 2)
+	assert.Equal(t, 250.0, *thresholds[0].TargetValue)
+	assert.Equal(t, 200.0, *thresholds[1].TargetValue)
+
+	// the window restarts at the start of every month
+	evaluation, err := rule.Evaluation.GetEvaluation()
+	require.NoError(t, err)
+	start, _ := evaluation.NextWindowFor(time.Date(2026, time.June, 11, 5, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, budget.ID.StringValue(), rule.Labels[budgetIDLabel])
+}
+
+func TestNewBudgetAlertRuleUpdate(t *testing.T) {
+	budget := llmcosttypes.NewBudget(valuer.GenerateUUID(), "admin@example.com", &llmcosttypes.PostableBudget{
+		Name:           "search",
+		AttributeKey:   "team",
+		AttributeValue: "search",
+		MonthlyLimit:   250,
+	})
+
+	// the rule as created, its critical threshold notifying a channel since
+	data, err := json.Marshal(newBudgetAlertRule(budget))
+	require.NoError(t, err)
+	current := new(ruletypes.PostableRule)
+	require.NoError(t, json.Unmarshal(data, current))
+	current.RuleCondition.Thresholds.Spec.(ruletypes.BasicRuleThresholds)[0].Channels = []string{"oncall"}
+
+	budget.Update("admin@example.com", &llmcosttypes.PostableBudget{
+		Name:           "ranking",
+		AttributeKey:   "resource.team",
+		AttributeValue: "ranking",
+		MonthlyLimit:   1000,
+	})
+
+	// the rules manager patches the update onto the rule
+	patch, err := json.Marshal(newBudgetAlertRuleUpdate(budget, current))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(patch, current))
+	require.NoError(t, current.Validate())
+
+	assert.Equal(t, "LLM budget ranking", current.AlertName)
+	spec, ok := current.RuleCondition.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
+	require.True(t, ok)
+	assert.Equal(t, "resource.team = 'ranking'", spec.Filter.Expression)
+
+	thresholds, ok := current.RuleCondition.Thresholds.Spec.(ruletypes.BasicRuleThresholds)
+	require.True(t, ok)
+	require.Len(t, thresholds, 2)
+	assert.Equal(t, 1000.0, *thresholds[0].TargetValue)
+	assert.Equal(t, []string{"oncall"}, thresholds[0].Channels)
+	assert.Equal(t, 800.0, *thresholds[1].TargetValue)
+	assert.Empty(t, thresholds[1].Channels)
+
+	// the rest of the rule is left as it was
+	assert.True(t, current.NotificationSettings.Renotify.Enabled)
+	assert.Equal(t, budget.ID.StringValue(), current.Labels[budgetIDLabel])
+}
@@ -0,0 +1,280 @@
+package impllmcost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost"
+	"github.com/SigNoz/signoz/pkg/ruler"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	module llmcost.Module
+	ruler  ruler.Ruler
+}
+
+// NewHandler returns the LLM cost handler. The budget alert rules are created with the
+// ruler, which is built after the modules.
+func NewHandler(module llmcost.Module, ruler ruler.Ruler) llmcost.Handler {
+	return &handler{module: module, ruler: ruler}
+}
+
+// GetBreakdown handles POST /api/v1/llm_cost/breakdown.
+func (h *handler) GetBreakdown(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	req := new(llmcosttypes.PostableCostBreakdown)
+	if err := binding.JSON.BindBody(r.Body, req); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	breakdown, err := h.module.GetBreakdown(ctx, valuer.MustNewUUID(claims.OrgID), req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, breakdown)
+}
+
+// ListBudgets handles GET /api/v1/llm_cost/budgets.
+func (h *handler) ListBudgets(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	budgets, err := h.module.ListBudgets(ctx, valuer.MustNewUUID(claims.OrgID))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, &llmcosttypes.GettableBudgets{Items: budgets})
+}
+
+// GetBudget handles GET /api/v1/llm_cost/budgets/{id}.
+func (h *handler) GetBudget(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := budgetIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	budget, err := h.module.GetBudget(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, budget)
+}
+
+// CreateBudget handles POST /api/v1/llm_cost/budgets.
+func (h *handler) CreateBudget(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	req := new(llmcosttypes.PostableBudget)
+	if err := binding.JSON.BindBody(r.Body, req); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	budget, err := h.module.CreateBudget(ctx, valuer.MustNewUUID(claims.OrgID), claims.Email, req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, budget)
+}
+
+// UpdateBudget handles PUT /api/v1/llm_cost/budgets/{id}.
+func (h *handler) UpdateBudget(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := budgetIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	req := new(llmcosttypes.PostableBudget)
+	if err := binding.JSON.BindBody(r.Body, req); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+	budget, err := h.module.UpdateBudget(ctx, orgID, id, claims.Email, req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := h.updateBudgetAlertRule(ctx, orgID, budget); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, budget)
+}
+
+// DeleteBudget handles DELETE /api/v1/llm_cost/budgets/{id}.
+func (h *handler) DeleteBudget(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := budgetIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	budget, err := h.module.DeleteBudget(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if budget.AlertRuleID != nil {
+		if err := h.ruler.DeleteRule(ctx, budget.AlertRuleID.StringValue()); err != nil && !errors.Ast(err, errors.TypeNotFound) {
+			render.Error(rw, err)
+			return
+		}
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// CreateBudgetAlertRule handles POST /api/v1/llm_cost/budgets/{id}/alert_rule.
+func (h *handler) CreateBudgetAlertRule(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := budgetIDFromPath(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID := valuer.MustNewUUID(claims.OrgID)
+	postableRule, err := h.module.GetBudgetAlertRule(ctx, orgID, id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	data, err := json.Marshal(postableRule)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	rule, err := h.ruler.CreateRule(ctx, string(data))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	// a rule created concurrently for the budget may have been recorded first
+	if err := h.module.SetBudgetAlertRule(ctx, orgID, id, valuer.MustNewUUID(rule.Id)); err != nil {
+		_ = h.ruler.DeleteRule(ctx, rule.Id)
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, ruletypes.NewRule(rule))
+}
+
+// updateBudgetAlertRule patches the alert rule of the budget, if it has one, to its new
+// name, filter and limit. A rule deleted from the alerts is forgotten.
+func (h *handler) updateBudgetAlertRule(ctx context.Context, orgID valuer.UUID, budget *llmcosttypes.Budget) error {
+	if budget.AlertRuleID == nil {
+		return nil
+	}
+	ruleID := *budget.AlertRuleID
+
+	current, err := h.ruler.GetRule(ctx, ruleID)
+	if err != nil {
+		if !errors.Ast(err, errors.TypeNotFound) {
+			return err
+		}
+		budget.AlertRuleID = nil
+		return h.module.UnsetBudgetAlertRule(ctx, orgID, budget.ID, ruleID)
+	}
+
+	data, err := json.Marshal(newBudgetAlertRuleUpdate(budget, &current.PostableRule))
+	if err != nil {
+		return err
+	}
+
+	_, err = h.ruler.PatchRule(ctx, string(data), ruleID)
+	return err
+}
+
+// budgetIDFromPath extracts and validates the {id} path variable.
+func budgetIDFromPath(r *http.Request) (valuer.UUID, error) {
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		return valuer.UUID{}, errors.Wrapf(err, errors.TypeInvalidInput, llmcosttypes.ErrCodeLLMCostInvalidInput, "id is not a valid uuid")
+	}
+	return id, nil
+}
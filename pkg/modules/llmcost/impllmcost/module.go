@@ -0,0 +1,376 @@
+package impllmcost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/types/aiobservabilitytypes"
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	groupsQueryName = "groups"
+	totalQueryName  = "total"
+	seriesQueryName = "series"
+
+	totalCostAlias = "totalCost"
+)
+
+// costAggregations are the costs of a breakdown group, totalCost first.
+var costAggregations = []qbtypes.TraceAggregation{
+	{Expression: sumOf(aiobservabilitytypes.SignozGenAITotalCost), Alias: totalCostAlias},
+	{Expression: sumOf(aiobservabilitytypes.SignozGenAICostInput), Alias: "inputCost"},
+	{Expression: sumOf(aiobservabilitytypes.SignozGenAICostOutput), Alias: "outputCost"},
+	{Expression: sumOf(aiobservabilitytypes.SignozGenAICostCacheRead), Alias: "cacheReadCost"},
+	{Expression: sumOf(aiobservabilitytypes.SignozGenAICostCacheWrite), Alias: "cacheWriteCost"},
+}
+
+type module struct {
+	store   llmcosttypes.Store
+	querier querier.Querier
+}
+
+func NewModule(store llmcosttypes.Store, querier querier.Querier) llmcost.Module {
+	return &module{store: store, querier: querier}
+}
+
+func (m *module) GetBreakdown(ctx context.Context, orgID valuer.UUID, req *llmcosttypes.PostableCostBreakdown) (*llmcosttypes.GettableCostBreakdown, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	scalarResp, err := m.querier.QueryRange(ctx, orgID, &qbtypes.QueryRangeRequest{
+		Start:       req.Start,
+		End:         req.End,
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{
+				newCostQuery(groupsQueryName, req.Filter, req.GroupBy, costAggregations, qbtypes.Step{}, req.Limit),
+				newCostQuery(totalQueryName, req.Filter, nil, costAggregations[:1], qbtypes.Step{}, 0),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seriesResp, err := m.querier.QueryRange(ctx, orgID, &qbtypes.QueryRangeRequest{
+		Start:       req.Start,
+		End:         req.End,
+		RequestType: qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{
+				newCostQuery(seriesQueryName, req.Filter, req.GroupBy, costAggregations[:1], req.Step, req.Limit),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &llmcosttypes.GettableCostBreakdown{Groups: []*llmcosttypes.CostGroup{}}
+	groupsByKey := make(map[string]*llmcosttypes.CostGroup)
+	for _, result := range scalarResp.Data.Results {
+		data, ok := result.(*qbtypes.ScalarData)
+		if !ok || data == nil {
+			continue
+		}
+		switch data.QueryName {
+		case totalQueryName:
+			for _, row := range data.Data {
+				for i, column := range data.Columns {
+					if column.Type == qbtypes.ColumnTypeAggregation && i < len(row) {
+						breakdown.TotalCost += toFloat64(row[i])
+					}
+				}
+			}
+		case groupsQueryName:
+			for _, group := range costGroupsFromScalar(data) {
+				groupsByKey[groupKey(group.Labels, req.GroupBy)] = group
+				breakdown.Groups = append(breakdown.Groups, group)
+			}
+		}
+	}
+
+	for _, result := range seriesResp.Data.Results {
+		data, ok := result.(*qbtypes.TimeSeriesData)
+		if !ok || data == nil {
+			continue
+		}
+		for _, bucket := range data.Aggregations {
+			for _, series := range bucket.Series {
+				labels := make(map[string]string, len(series.Labels))
+				for _, label := range series.Labels {
+					labels[label.Key.Name] = labelValue(label.Value)
+				}
+				group, ok := groupsByKey[groupKey(labels, req.GroupBy)]
+				if !ok {
+					continue
+				}
+				for _, value := range series.Values {
+					group.Series = append(group.Series, &llmcosttypes.CostPoint{Timestamp: value.Timestamp, Cost: value.Value})
+				}
+			}
+		}
+	}
+
+	return breakdown, nil
+}
+
+func (m *module) ListBudgets(ctx context.Context, orgID valuer.UUID) ([]*llmcosttypes.GettableBudget, error) {
+	budgets, err := m.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return m.withStatus(ctx, orgID, budgets)
+}
+
+func (m *module) GetBudget(ctx context.Context, orgID, id valuer.UUID) (*llmcosttypes.GettableBudget, error) {
+	budget, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	gettables, err := m.withStatus(ctx, orgID, []*llmcosttypes.Budget{budget})
+	if err != nil {
+		return nil, err
+	}
+	return gettables[0], nil
+}
+
+func (m *module) CreateBudget(ctx context.Context, orgID valuer.UUID, createdBy string, postable *llmcosttypes.PostableBudget) (*llmcosttypes.Budget, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	budget := llmcosttypes.NewBudget(orgID, createdBy, postable)
+	if err := m.store.Create(ctx, budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (m *module) UpdateBudget(ctx context.Context, orgID, id valuer.UUID, updatedBy string, postable *llmcosttypes.PostableBudget) (*llmcosttypes.Budget, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	budget, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	budget.Update(updatedBy, postable)
+	if err := m.store.Update(ctx, budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (m *module) DeleteBudget(ctx context.Context, orgID, id valuer.UUID) (*llmcosttypes.Budget, error) {
+	budget, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.store.Delete(ctx, orgID, id); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (m *module) GetBudgetAlertRule(ctx context.Context, orgID, id valuer.UUID) (*ruletypes.PostableRule, error) {
+	budget, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if budget.AlertRuleID != nil {
+		return nil, errors.Newf(errors.TypeAlreadyExists, llmcosttypes.ErrCodeLLMBudgetAlertRuleExists, "budget %s already has the alert rule %s", id, budget.AlertRuleID.StringValue())
+	}
+	return newBudgetAlertRule(budget), nil
+}
+
+func (m *module) SetBudgetAlertRule(ctx context.Context, orgID, id, ruleID valuer.UUID) error {
+	return m.store.SetAlertRuleID(ctx, orgID, id, ruleID)
+}
+
+func (m *module) UnsetBudgetAlertRule(ctx context.Context, orgID, id, ruleID valuer.UUID) error {
+	return m.store.UnsetAlertRuleID(ctx, orgID, id, ruleID)
+}
+
+// withStatus adds to the budgets their status for the current month, running one query
+// per attribute key the budgets apply to.
+func (m *module) withStatus(ctx context.Context, orgID valuer.UUID, budgets []*llmcosttypes.Budget) ([]*llmcosttypes.GettableBudget, error) {
+	now := time.Now()
+	monthStart, _ := llmcosttypes.MonthOf(now)
+
+	byKey := make(map[string][]*llmcosttypes.Budget)
+	keys := make([]string, 0)
+	for _, budget := range budgets {
+		if _, ok := byKey[budget.AttributeKey]; !ok {
+			keys = append(keys, budget.AttributeKey)
+		}
+		byKey[budget.AttributeKey] = append(byKey[budget.AttributeKey], budget)
+	}
+
+	spends := make(map[valuer.UUID]float64, len(budgets))
+	if now.After(monthStart) {
+		for _, key := range keys {
+			spendByValue, err := m.getMonthToDateSpend(ctx, orgID, key, byKey[key], uint64(monthStart.UnixMilli()), uint64(now.UnixMilli()))
+			if err != nil {
+				return nil, err
+			}
+			for _, budget := range byKey[key] {
+				spends[budget.ID] = spendByValue[budget.AttributeValue]
+			}
+		}
+	}
+
+	gettables := make([]*llmcosttypes.GettableBudget, 0, len(budgets))
+	for _, budget := range budgets {
+		gettables = append(gettables, &llmcosttypes.GettableBudget{
+			Budget: *budget,
+			Status: llmcosttypes.NewBudgetStatus(budget.MonthlyLimit, spends[budget.ID], now),
+		})
+	}
+	return gettables, nil
+}
+
+// getMonthToDateSpend returns the spend over [startMs, endMs) of the values of the
+// attribute key the budgets apply to.
+func (m *module) getMonthToDateSpend(ctx context.Context, orgID valuer.UUID, key string, budgets []*llmcosttypes.Budget, startMs, endMs uint64) (map[string]float64, error) {
+	exprs := make([]string, 0, len(budgets))
+	for _, budget := range budgets {
+		exprs = append(exprs, "("+budget.FilterExpression()+")")
+	}
+	groupBy := []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.GetFieldKeyFromKeyText(key)}}
+
+	resp, err := m.querier.QueryRange(ctx, orgID, &qbtypes.QueryRangeRequest{
+		Start:       startMs,
+		End:         endMs,
+		RequestType: qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{
+				newCostQuery(totalQueryName, &qbtypes.Filter{Expression: strings.Join(exprs, " OR ")}, groupBy, costAggregations[:1], qbtypes.Step{}, len(budgets)),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spends := make(map[string]float64)
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.ScalarData)
+		if !ok || data == nil {
+			continue
+		}
+		for _, group := range costGroupsFromScalar(data) {
+			spends[group.Labels[groupBy[0].Name]] += group.TotalCost
+		}
+	}
+	return spends, nil
+}
+
+// newCostQuery returns the AI builder query summing the costs of the gen_ai spans, which
+// the AI statement builder gates to the spans of LLM, tool and agent calls.
+func newCostQuery(name string, filter *qbtypes.Filter, groupBy []qbtypes.GroupByKey, aggregations []qbtypes.TraceAggregation, step qbtypes.Step, limit int) qbtypes.QueryEnvelope {
+	spec := qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+		Name:         name,
+		Signal:       telemetrytypes.SignalTraces,
+		StepInterval: step,
+		Filter:       filter,
+		GroupBy:      groupBy,
+		Aggregations: aggregations,
+		Limit:        limit,
+	}
+	if len(groupBy) > 0 {
+		spec.Order = []qbtypes.OrderBy{
+			{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: totalCostAlias}}, Direction: qbtypes.OrderDirectionDesc},
+		}
+	}
+	return qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilderAI, Spec: spec}
+}
+
+// costGroupsFromScalar reads a group per row of a scalar result of costAggregations or a
+// prefix of them.
+func costGroupsFromScalar(data *qbtypes.ScalarData) []*llmcosttypes.CostGroup {
+	groups := make([]*llmcosttypes.CostGroup, 0, len(data.Data))
+	for _, row := range data.Data {
+		group := &llmcosttypes.CostGroup{Labels: map[string]string{}, Series: []*llmcosttypes.CostPoint{}}
+		for i, column := range data.Columns {
+			if i >= len(row) {
+				break
+			}
+			switch column.Type {
+			case qbtypes.ColumnTypeGroup:
+				group.Labels[column.Name] = labelValue(row[i])
+			case qbtypes.ColumnTypeAggregation:
+				value := toFloat64(row[i])
+				switch column.AggregationIndex {
+				case 0:
+					group.TotalCost = value
+				case 1:
+					group.InputCost = value
+				case 2:
+					group.OutputCost = value
+				case 3:
+					group.CacheReadCost = value
+				case 4:
+					group.CacheWriteCost = value
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// groupKey joins the values of the group by keys, in their order.
+func groupKey(labels map[string]string, groupBy []qbtypes.GroupByKey) string {
+	parts := make([]string, len(groupBy))
+	for i, key := range groupBy {
+		parts[i] = labels[key.Name]
+	}
+	return strings.Join(parts, "\x00")
+}
+
+func labelValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func toFloat64(value any) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case int:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+func sumOf(key string) string {
+	return "sum(" + key + ")"
+}
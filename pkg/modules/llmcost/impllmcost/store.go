@@ -0,0 +1,143 @@
+package impllmcost
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) llmcosttypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (s *store) List(ctx context.Context, orgID valuer.UUID) ([]*llmcosttypes.Budget, error) {
+	budgets := make([]*llmcosttypes.Budget, 0)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&budgets).
+		Where("org_id = ?", orgID).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+func (s *store) Get(ctx context.Context, orgID, id valuer.UUID) (*llmcosttypes.Budget, error) {
+	budget := new(llmcosttypes.Budget)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(budget).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, s.sqlstore.WrapNotFoundErrf(err, llmcosttypes.ErrCodeLLMBudgetNotFound, "budget %s not found", id)
+	}
+	return budget, nil
+}
+
+func (s *store) Create(ctx context.Context, budget *llmcosttypes.Budget) error {
+	_, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(budget).
+		Exec(ctx)
+	if err != nil {
+		return s.sqlstore.WrapAlreadyExistsErrf(err, llmcosttypes.ErrCodeLLMBudgetExists, "budget %q already exists", budget.Name)
+	}
+	return nil
+}
+
+func (s *store) Update(ctx context.Context, budget *llmcosttypes.Budget) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(budget).
+		Where("org_id = ?", budget.OrgID).
+		Where("id = ?", budget.ID).
+		Exec(ctx)
+	if err != nil {
+		return s.sqlstore.WrapAlreadyExistsErrf(err, llmcosttypes.ErrCodeLLMBudgetExists, "budget %q already exists", budget.Name)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, llmcosttypes.ErrCodeLLMBudgetNotFound, "budget %s not found", budget.ID)
+	}
+	return nil
+}
+
+func (s *store) Delete(ctx context.Context, orgID, id valuer.UUID) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model((*llmcosttypes.Budget)(nil)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, llmcosttypes.ErrCodeLLMBudgetNotFound, "budget %s not found", id)
+	}
+	return nil
+}
+
+func (s *store) SetAlertRuleID(ctx context.Context, orgID, id, ruleID valuer.UUID) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model((*llmcosttypes.Budget)(nil)).
+		Set("alert_rule_id = ?", ruleID).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Where("alert_rule_id IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeAlreadyExists, llmcosttypes.ErrCodeLLMBudgetAlertRuleExists, "budget %s already has an alert rule", id)
+	}
+	return nil
+}
+
+func (s *store) UnsetAlertRuleID(ctx context.Context, orgID, id, ruleID valuer.UUID) error {
+	_, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model((*llmcosttypes.Budget)(nil)).
+		Set("alert_rule_id = NULL").
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Where("alert_rule_id = ?", ruleID).
+		Exec(ctx)
+	return err
+}
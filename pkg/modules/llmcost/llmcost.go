@@ -0,0 +1,50 @@
+package llmcost
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/llmcosttypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module defines the business logic for the spend of the AI/LLM workloads, from the
+// costs the LLM pricing processor attaches to the gen_ai spans.
+type Module interface {
+	// GetBreakdown returns the spend over the window, grouped by the requested attributes.
+	GetBreakdown(ctx context.Context, orgID valuer.UUID, req *llmcosttypes.PostableCostBreakdown) (*llmcosttypes.GettableCostBreakdown, error)
+
+	// ListBudgets returns the budgets of the org with their status for the current month.
+	ListBudgets(ctx context.Context, orgID valuer.UUID) ([]*llmcosttypes.GettableBudget, error)
+	GetBudget(ctx context.Context, orgID, id valuer.UUID) (*llmcosttypes.GettableBudget, error)
+	CreateBudget(ctx context.Context, orgID valuer.UUID, createdBy string, budget *llmcosttypes.PostableBudget) (*llmcosttypes.Budget, error)
+	UpdateBudget(ctx context.Context, orgID, id valuer.UUID, updatedBy string, budget *llmcosttypes.PostableBudget) (*llmcosttypes.Budget, error)
+
+	// DeleteBudget deletes the budget and returns it, for its alert rule to be deleted
+	// with it.
+	DeleteBudget(ctx context.Context, orgID, id valuer.UUID) (*llmcosttypes.Budget, error)
+
+	// GetBudgetAlertRule returns the alert rule firing when the month-to-date spend of the
+	// budget burns through its warning and critical thresholds, for the rules manager. A
+	// budget has at most one alert rule.
+	GetBudgetAlertRule(ctx context.Context, orgID, id valuer.UUID) (*ruletypes.PostableRule, error)
+
+	// SetBudgetAlertRule records the alert rule created for the budget, which then
+	// follows its updates.
+	SetBudgetAlertRule(ctx context.Context, orgID, id, ruleID valuer.UUID) error
+
+	// UnsetBudgetAlertRule forgets the alert rule of the budget, e.g. once it was deleted.
+	UnsetBudgetAlertRule(ctx context.Context, orgID, id, ruleID valuer.UUID) error
+}
+
+// Handler defines the HTTP handler interface for LLM cost endpoints.
+type Handler interface {
+	GetBreakdown(rw http.ResponseWriter, r *http.Request)
+	ListBudgets(rw http.ResponseWriter, r *http.Request)
+	GetBudget(rw http.ResponseWriter, r *http.Request)
+	CreateBudget(rw http.ResponseWriter, r *http.Request)
+	UpdateBudget(rw http.ResponseWriter, r *http.Request)
+	DeleteBudget(rw http.ResponseWriter, r *http.Request)
+	CreateBudgetAlertRule(rw http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,117 @@
+package impllmpricingrule
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	_ "embed"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/llmpricingruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// bundledCatalog is the pricing catalog shipped with this release. Bump its version
+// whenever a price changes so the orgs get the change proposed on their next sync.
+//
+//go:embed catalog/llm_pricing_catalog.json
+var bundledCatalog []byte
+
+// GetCatalog returns the catalog the rules of the org are synced from: the one an admin
+// imported when it is newer than the bundled one, the bundled one otherwise.
+func (module *module) GetCatalog(ctx context.Context, orgID valuer.UUID) (*llmpricingruletypes.GettableLLMPricingCatalog, error) {
+	bundled, err := llmpricingruletypes.NewLLMPricingCatalog(bundledCatalog)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "bundled pricing catalog is invalid")
+	}
+
+	storable, err := module.store.GetCatalog(ctx, orgID)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return nil, err
+	}
+	if storable != nil && storable.Version > bundled.Version {
+		imported, err := llmpricingruletypes.NewLLMPricingCatalog([]byte(storable.Data))
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "imported pricing catalog is invalid")
+		}
+		return &llmpricingruletypes.GettableLLMPricingCatalog{LLMPricingCatalog: *imported, Source: llmpricingruletypes.LLMPricingCatalogSourceImported}, nil
+	}
+
+	return &llmpricingruletypes.GettableLLMPricingCatalog{LLMPricingCatalog: *bundled, Source: llmpricingruletypes.LLMPricingCatalogSourceBundled}, nil
+}
+
+// ImportCatalog stores a catalog newer than the one the rules of the org are synced
+// from. It does not change the rules; they are synced with it by SyncCatalog.
+func (module *module) ImportCatalog(ctx context.Context, orgID valuer.UUID, userEmail string, catalog *llmpricingruletypes.LLMPricingCatalog) (*llmpricingruletypes.GettableLLMPricingCatalog, error) {
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+
+	current, err := module.GetCatalog(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if catalog.Version <= current.Version {
+		return nil, errors.Newf(errors.TypeInvalidInput, llmpricingruletypes.ErrCodePricingCatalogOutdated, "pricing catalog version %d is not newer than the %s version %d", catalog.Version, current.Source.StringValue(), current.Version)
+	}
+
+	data, err := json.Marshal(catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	storable, err := module.store.GetCatalog(ctx, orgID)
+	switch {
+	case err == nil:
+		storable.Update(userEmail, data, catalog, now)
+		err = module.store.UpdateCatalog(ctx, storable)
+	case errors.Ast(err, errors.TypeNotFound):
+		err = module.store.CreateCatalog(ctx, llmpricingruletypes.NewStorableLLMPricingCatalog(orgID, userEmail, data, catalog, now))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &llmpricingruletypes.GettableLLMPricingCatalog{LLMPricingCatalog: *catalog, Source: llmpricingruletypes.LLMPricingCatalogSourceImported}, nil
+}
+
+// GetCatalogDiff proposes the changes syncing the rules of the org with its catalog makes.
+func (module *module) GetCatalogDiff(ctx context.Context, orgID valuer.UUID) (*llmpricingruletypes.GettableLLMPricingCatalogDiff, error) {
+	catalog, err := module.GetCatalog(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := module.listAllRules(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return llmpricingruletypes.NewLLMPricingCatalogDiff(catalog, rules), nil
+}
+
+// SyncCatalog applies the selected proposals through CreateOrUpdate, which keeps the user
+// overrides and pushes the new rules to the collectors.
+func (module *module) SyncCatalog(ctx context.Context, orgID valuer.UUID, userEmail string, req *llmpricingruletypes.PostableLLMPricingCatalogSync) (*llmpricingruletypes.GettableLLMPricingCatalogDiff, error) {
+	diff, err := module.GetCatalogDiff(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Select(req.SourceIDs)
+	if len(diff.Proposals) == 0 {
+		return diff, nil
+	}
+
+	rules := make([]*llmpricingruletypes.UpdatableLLMPricingRule, 0, len(diff.Proposals))
+	for _, proposal := range diff.Proposals {
+		rules = append(rules, proposal.Rule)
+	}
+	if err := module.CreateOrUpdate(ctx, orgID, userEmail, rules); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,15 @@
+package impllmpricingrule
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/llmpricingruletypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundledCatalog(t *testing.T) {
+	catalog, err := llmpricingruletypes.NewLLMPricingCatalog(bundledCatalog)
+	require.NoError(t, err)
+	assert.Positive(t, catalog.Version)
+}
@@ -140,6 +140,46 @@ func (store *store) Delete(ctx context.Context, orgID, id valuer.UUID) error {
 	return nil
 }
 
+func (store *store) GetCatalog(ctx context.Context, orgID valuer.UUID) (*llmpricingruletypes.StorableLLMPricingCatalog, error) {
+	catalog := new(llmpricingruletypes.StorableLLMPricingCatalog)
+
+	err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(catalog).
+		Where("org_id = ?", orgID).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, llmpricingruletypes.ErrCodePricingCatalogNotFound, "no pricing catalog imported in the org")
+	}
+
+	return catalog, nil
+}
+
+func (store *store) CreateCatalog(ctx context.Context, catalog *llmpricingruletypes.StorableLLMPricingCatalog) error {
+	_, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(catalog).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, llmpricingruletypes.ErrCodePricingCatalogOutdated, "a pricing catalog is already imported in the org")
+	}
+	return nil
+}
+
+func (store *store) UpdateCatalog(ctx context.Context, catalog *llmpricingruletypes.StorableLLMPricingCatalog) error {
+	_, err := store.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(catalog).
+		Where("org_id = ?", catalog.OrgID).
+		Where("id = ?", catalog.ID).
+		ExcludeColumn("id", "org_id", "created_at", "created_by").
+		Exec(ctx)
+	return err
+}
+
 func (store *store) RunInTx(ctx context.Context, cb func(ctx context.Context) error) error {
 	return store.sqlstore.RunInTxCtx(ctx, nil, cb)
 }
@@ -18,6 +18,15 @@ type Module interface {
 	CreateOrUpdate(ctx context.Context, orgID valuer.UUID, userEmail string, rules []*llmpricingruletypes.UpdatableLLMPricingRule) (err error)
 	Delete(ctx context.Context, orgID, id valuer.UUID) error
 	ListUnmappedModels(ctx context.Context, orgID valuer.UUID) ([]*llmpricingruletypes.UnmappedModel, error)
+
+	// GetCatalog returns the pricing catalog the rules are synced from, bundled or imported.
+	GetCatalog(ctx context.Context, orgID valuer.UUID) (*llmpricingruletypes.GettableLLMPricingCatalog, error)
+	// ImportCatalog imports a pricing catalog newer than the current one.
+	ImportCatalog(ctx context.Context, orgID valuer.UUID, userEmail string, catalog *llmpricingruletypes.LLMPricingCatalog) (*llmpricingruletypes.GettableLLMPricingCatalog, error)
+	// GetCatalogDiff proposes the rules to create or update from the catalog.
+	GetCatalogDiff(ctx context.Context, orgID valuer.UUID) (*llmpricingruletypes.GettableLLMPricingCatalogDiff, error)
+	// SyncCatalog applies the proposals of the catalog diff and returns the applied ones.
+	SyncCatalog(ctx context.Context, orgID valuer.UUID, userEmail string, req *llmpricingruletypes.PostableLLMPricingCatalogSync) (*llmpricingruletypes.GettableLLMPricingCatalogDiff, error)
 }
 
 // Handler defines the HTTP handler interface for pricing rule endpoints.
@@ -27,4 +36,8 @@ type Handler interface {
 	CreateOrUpdate(rw http.ResponseWriter, r *http.Request)
 	Delete(rw http.ResponseWriter, r *http.Request)
 	ListUnmappedModels(rw http.ResponseWriter, r *http.Request)
+	GetCatalog(rw http.ResponseWriter, r *http.Request)
+	ImportCatalog(rw http.ResponseWriter, r *http.Request)
+	GetCatalogDiff(rw http.ResponseWriter, r *http.Request)
+	SyncCatalog(rw http.ResponseWriter, r *http.Request)
 }
@@ -0,0 +1,172 @@
+package impllogpattern
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// wildcard stands for the tokens that vary between the logs of a pattern.
+const wildcard = "<*>"
+
+const (
+	// drainDepth is the number of leading tokens routing a log through the tree. Routing on
+	// more tokens is faster but splits the patterns varying early, such as "user alice
+	// logged in" and "user bob logged in".
+	drainDepth = 1
+	// drainSimilarityThreshold is the share of tokens a log must have in common with the
+	// template of a cluster to join it.
+	drainSimilarityThreshold = 0.4
+	// drainMaxChildren is the number of children of a tree node beyond which the logs are
+	// routed to its wildcard child.
+	drainMaxChildren = 100
+	// drainMaxTokens is the number of tokens of a log body taken into account, the
+	// remaining ones are folded into a trailing wildcard.
+	drainMaxTokens = 128
+)
+
+// cluster is a group of logs sharing a template. Buckets holds the number of logs of the
+// cluster per trend bucket, keyed by the bucket start in epoch milliseconds.
+type cluster struct {
+	tokens   []string
+	count    uint64
+	sampleID string
+	buckets  map[uint64]uint64
+}
+
+func (c *cluster) template() string {
+	return strings.Join(c.tokens, " ")
+}
+
+type drainNode struct {
+	children map[string]*drainNode
+	clusters []*cluster
+}
+
+func newDrainNode() *drainNode {
+	return &drainNode{children: make(map[string]*drainNode)}
+}
+
+// drain clusters logs with the Drain algorithm (He et al., "Drain: An Online Log Parsing
+// Approach with Fixed Depth Tree"). A log is routed through a tree by its number of tokens
+// and its leading tokens to a leaf, where it joins the most similar cluster or starts a new
+// one. Joining a cluster replaces the tokens of its template that differ from the log with
+// a wildcard.
+type drain struct {
+	root     map[int]*drainNode
+	clusters []*cluster
+}
+
+func newDrain() *drain {
+	return &drain{root: make(map[int]*drainNode)}
+}
+
+// add adds the tokens of a log and returns the cluster it joined.
+func (d *drain) add(tokens []string) *cluster {
+	leaf := d.leaf(tokens)
+
+	var best *cluster
+	bestSimilarity := 0.0
+	for _, c := range leaf.clusters {
+		if s := similarity(c.tokens, tokens); s >= drainSimilarityThreshold && s > bestSimilarity {
+			best, bestSimilarity = c, s
+		}
+	}
+
+	if best == nil {
+		best = &cluster{tokens: slices.Clone(tokens), buckets: make(map[uint64]uint64)}
+		leaf.clusters = append(leaf.clusters, best)
+		d.clusters = append(d.clusters, best)
+		return best
+	}
+
+	for i, token := range tokens {
+		if best.tokens[i] != token {
+			best.tokens[i] = wildcard
+		}
+	}
+	return best
+}
+
+// match returns the cluster whose template is the most similar to the given one, or nil
+// when none is similar enough for the logs of both to have been clustered together.
+func (d *drain) match(tokens []string) *cluster {
+	var best *cluster
+	bestSimilarity := 0.0
+	for _, c := range d.clusters {
+		if len(c.tokens) != len(tokens) {
+			continue
+		}
+		if s := similarity(c.tokens, tokens); s >= drainSimilarityThreshold && s > bestSimilarity {
+			best, bestSimilarity = c, s
+		}
+	}
+	return best
+}
+
+func (d *drain) leaf(tokens []string) *drainNode {
+	node, ok := d.root[len(tokens)]
+	if !ok {
+		node = newDrainNode()
+		d.root[len(tokens)] = node
+	}
+
+	for _, token := range tokens[:min(len(tokens), drainDepth)] {
+		child, ok := node.children[token]
+		if !ok {
+			if len(node.children) >= drainMaxChildren {
+				token = wildcard
+				child = node.children[token]
+			}
+			if child == nil {
+				child = newDrainNode()
+				node.children[token] = child
+			}
+		}
+		node = child
+	}
+	return node
+}
+
+// similarity returns the share of positions at which the two token sequences, of the same
+// length, hold the same token or a wildcard.
+func similarity(template, tokens []string) float64 {
+	if len(tokens) == 0 {
+		return 1
+	}
+	same := 0
+	for i, token := range tokens {
+		if template[i] == token || template[i] == wildcard || token == wildcard {
+			same++
+		}
+	}
+	return float64(same) / float64(len(tokens))
+}
+
+// tokenize splits a log body on whitespace and masks the tokens holding a digit, which are
+// most likely values such as IDs, durations or timestamps, with a wildcard. In key=value
+// and key:value tokens only the value is masked.
+func tokenize(body string) []string {
+	tokens := strings.Fields(body)
+	if len(tokens) > drainMaxTokens {
+		tokens = append(tokens[:drainMaxTokens-1], wildcard)
+	}
+	for i, token := range tokens {
+		tokens[i] = maskToken(token)
+	}
+	return tokens
+}
+
+func maskToken(token string) string {
+	if i := strings.IndexAny(token, "=:"); i > 0 && i < len(token)-1 && !hasDigit(token[:i]) {
+		return token[:i+1] + maskToken(token[i+1:])
+	}
+	if hasDigit(token) {
+		return wildcard
+	}
+	return token
+}
+
+func hasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
@@ -0,0 +1,72 @@
+package impllogpattern
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected []string
+	}{
+		{
+			name:     "values_with_digits_are_masked",
+			body:     "connection to 10.0.0.12 timed out after 30s",
+			expected: []string{"connection", "to", wildcard, "timed", "out", "after", wildcard},
+		},
+		{
+			name:     "only_the_value_of_key_value_tokens_is_masked",
+			body:     "user_id=42 status:failed retry=3",
+			expected: []string{"user_id=" + wildcard, "status:failed", "retry=" + wildcard},
+		},
+		{
+			name:     "empty_body",
+			body:     "   ",
+			expected: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, tokenize(c.body))
+		})
+	}
+}
+
+func TestTokenize_LongBody(t *testing.T) {
+	body := ""
+	for range drainMaxTokens + 10 {
+		body += "word "
+	}
+
+	tokens := tokenize(body)
+	require.Len(t, tokens, drainMaxTokens)
+	assert.Equal(t, wildcard, tokens[drainMaxTokens-1])
+}
+
+func TestDrain(t *testing.T) {
+	d := newDrain()
+
+	first := d.add(tokenize("user alice logged in from web"))
+	second := d.add(tokenize("user bob logged in from web"))
+	third := d.add(tokenize("user carol logged in from mobile"))
+	other := d.add(tokenize("payment declined for order 1234"))
+
+	assert.Same(t, first, second)
+	assert.Same(t, first, third)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, "user <*> logged in from <*>", first.template())
+	assert.Equal(t, "payment declined for order <*>", other.template())
+	require.Len(t, d.clusters, 2)
+
+	t.Run("match", func(t *testing.T) {
+		assert.Same(t, first, d.match(tokenize("user dave logged in from web")))
+		assert.Same(t, other, d.match([]string{"payment", "declined", "for", wildcard, wildcard}))
+		assert.Nil(t, d.match(tokenize("disk quota exceeded on volume data")))
+		assert.Nil(t, d.match(tokenize("user logged in")))
+	})
+}
@@ -0,0 +1,197 @@
+package impllogpattern
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/modules/logpattern"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	"github.com/SigNoz/signoz/pkg/types/logpatterntypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	telemetryStore telemetrystore.TelemetryStore
+	stmtBuilder    *statementBuilder
+}
+
+func NewModule(
+	telemetryStore telemetrystore.TelemetryStore,
+	telemetryMetadataStore telemetrytypes.MetadataStore,
+	fl flagger.Flagger,
+	providerSettings factory.ProviderSettings,
+) logpattern.Module {
+	return &module{
+		telemetryStore: telemetryStore,
+		stmtBuilder:    newStatementBuilder(telemetryMetadataStore, fl, providerSettings.Logger),
+	}
+}
+
+type logRow struct {
+	ID        string `ch:"id"`
+	Timestamp uint64 `ch:"timestamp"`
+	Body      string `ch:"log_body"`
+}
+
+// period holds the patterns of the logs seen over one period. Scale is the number of logs
+// each clustered log stands for.
+type period struct {
+	drain   *drain
+	total   uint64
+	sampled uint64
+	scale   float64
+}
+
+func (m *module) GetPatterns(ctx context.Context, orgID valuer.UUID, req *logpatterntypes.PostableLogPatterns) (*logpatterntypes.GettableLogPatterns, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "logpattern",
+		instrumentationtypes.CodeFunctionName: "GetPatterns",
+	})
+
+	step := req.StepInterval
+	if step == 0 {
+		step = querybuilder.RecommendedStepInterval(req.Start, req.End)
+	} else if minStep := querybuilder.MinAllowedStepInterval(req.Start, req.End); step < minStep {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "step interval must be at least %d seconds for the given time range", minStep)
+	}
+	stepMillis := step * 1000
+
+	current, err := m.getPeriod(ctx, orgID, req, req.Start, req.End, stepMillis)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *period
+	if req.CompareWithPrevious {
+		previousStart, previousEnd := req.PreviousPeriod()
+		previous, err = m.getPeriod(ctx, orgID, req, previousStart, previousEnd, stepMillis)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newGettableLogPatterns(current, previous, req.Start, req.End, stepMillis, req.PatternLimit()), nil
+}
+
+func (m *module) getPeriod(ctx context.Context, orgID valuer.UUID, req *logpatterntypes.PostableLogPatterns, start, end, stepMillis uint64) (*period, error) {
+	stmts, err := m.stmtBuilder.Build(ctx, orgID, start, end, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	countStmt := stmts.Count()
+	var total uint64
+	if err := m.telemetryStore.ClickhouseDB().QueryRow(ctx, countStmt.Query, countStmt.Args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	var rows []logRow
+	if total > 0 {
+		samplesStmt := stmts.Samples(total, req.SampleLimit())
+		if err := m.telemetryStore.ClickhouseDB().Select(ctx, &rows, samplesStmt.Query, samplesStmt.Args...); err != nil {
+			return nil, err
+		}
+	}
+
+	return newPeriod(rows, total, stepMillis), nil
+}
+
+// newPeriod clusters the bodies of the given logs, counting them per trend bucket.
+func newPeriod(rows []logRow, total, stepMillis uint64) *period {
+	result := &period{drain: newDrain(), total: total, scale: 1}
+	for _, row := range rows {
+		tokens := tokenize(row.Body)
+		if len(tokens) == 0 {
+			continue
+		}
+		c := result.drain.add(tokens)
+		c.count++
+		if c.sampleID == "" {
+			c.sampleID = row.ID
+		}
+		timestampMillis := row.Timestamp / 1e6
+		c.buckets[timestampMillis-timestampMillis%stepMillis]++
+	}
+
+	result.sampled = uint64(len(rows))
+	if result.sampled > 0 && total > result.sampled {
+		result.scale = float64(total) / float64(result.sampled)
+	}
+	return result
+}
+
+func (p *period) estimate(count uint64) uint64 {
+	return uint64(math.Round(float64(count) * p.scale))
+}
+
+// newGettableLogPatterns builds the response from the patterns of the current period,
+// merging those that ended up with the same template, and, when set, compares them with
+// the patterns of the previous period.
+func newGettableLogPatterns(current, previous *period, start, end, stepMillis uint64, limit int) *logpatterntypes.GettableLogPatterns {
+	merged := make(map[string]*cluster)
+	order := make([]*cluster, 0, len(current.drain.clusters))
+	for _, c := range current.drain.clusters {
+		template := c.template()
+		existing, ok := merged[template]
+		if !ok {
+			merged[template] = c
+			order = append(order, c)
+			continue
+		}
+		existing.count += c.count
+		for bucket, count := range c.buckets {
+			existing.buckets[bucket] += count
+		}
+	}
+
+	slices.SortStableFunc(order, func(a, b *cluster) int {
+		if a.count != b.count {
+			if a.count > b.count {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.template(), b.template())
+	})
+	if len(order) > limit {
+		order = order[:limit]
+	}
+
+	result := &logpatterntypes.GettableLogPatterns{
+		Patterns:     make([]*logpatterntypes.Pattern, 0, len(order)),
+		TotalCount:   current.total,
+		SampledCount: current.sampled,
+		Sampled:      current.total > current.sampled,
+	}
+
+	firstBucket := start - start%stepMillis
+	for _, c := range order {
+		pattern := &logpatterntypes.Pattern{
+			Template:    c.template(),
+			Count:       current.estimate(c.count),
+			SampleLogID: c.sampleID,
+			Trend:       make([]*logpatterntypes.TrendPoint, 0, (end-firstBucket)/stepMillis+1),
+		}
+		for bucket := firstBucket; bucket < end; bucket += stepMillis {
+			pattern.Trend = append(pattern.Trend, &logpatterntypes.TrendPoint{Timestamp: bucket, Count: current.estimate(c.buckets[bucket])})
+		}
+		if previous != nil {
+			if match := previous.drain.match(c.tokens); match != nil {
+				pattern.PreviousCount = previous.estimate(match.count)
+			} else {
+				pattern.IsNew = true
+			}
+		}
+		result.Patterns = append(result.Patterns, pattern)
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package impllogpattern
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/logpatterntypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGettableLogPatterns(t *testing.T) {
+	start, end, step := uint64(1_700_000_040_000), uint64(1_700_000_220_000), uint64(60_000)
+	ns := func(ms uint64) uint64 { return ms * 1_000_000 }
+
+	current := newPeriod([]logRow{
+		{ID: "1", Timestamp: ns(start + 1_000), Body: "payment declined for order 1234"},
+		{ID: "2", Timestamp: ns(start + 2_000), Body: "user alice logged in"},
+		{ID: "3", Timestamp: ns(start + 61_000), Body: "payment declined for order 5678"},
+		{ID: "4", Timestamp: ns(start + 125_000), Body: "payment declined for order 9012"},
+		{ID: "5", Timestamp: ns(start + 130_000), Body: ""},
+	}, 10, step)
+	previous := newPeriod([]logRow{
+		{ID: "6", Timestamp: ns(start - 60_000), Body: "user bob logged in"},
+	}, 1, step)
+
+	t.Run("current_only", func(t *testing.T) {
+		got := newGettableLogPatterns(current, nil, start, end, step, 50)
+
+		assert.Equal(t, uint64(10), got.TotalCount)
+		assert.Equal(t, uint64(5), got.SampledCount)
+		assert.True(t, got.Sampled)
+		require.Len(t, got.Patterns, 2)
+
+		payment := got.Patterns[0]
+		assert.Equal(t, "payment declined for order <*>", payment.Template)
+		assert.Equal(t, uint64(6), payment.Count)
+		assert.Equal(t, "1", payment.SampleLogID)
+		assert.Equal(t, []*logpatterntypes.TrendPoint{
+			{Timestamp: start, Count: 2},
+			{Timestamp: start + step, Count: 2},
+			{Timestamp: start + 2*step, Count: 2},
+		}, payment.Trend)
+		assert.False(t, payment.IsNew)
+
+		assert.Equal(t, "user alice logged in", got.Patterns[1].Template)
+		assert.Equal(t, uint64(2), got.Patterns[1].Count)
+	})
+
+	t.Run("compare_with_previous", func(t *testing.T) {
+		got := newGettableLogPatterns(current, previous, start, end, step, 50)
+
+		require.Len(t, got.Patterns, 2)
+		assert.True(t, got.Patterns[0].IsNew)
+		assert.Zero(t, got.Patterns[0].PreviousCount)
+		assert.False(t, got.Patterns[1].IsNew)
+		assert.Equal(t, uint64(1), got.Patterns[1].PreviousCount)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		got := newGettableLogPatterns(current, nil, start, end, step, 1)
+
+		require.Len(t, got.Patterns, 1)
+		assert.Equal(t, "payment declined for order <*>", got.Patterns[0].Template)
+	})
+}
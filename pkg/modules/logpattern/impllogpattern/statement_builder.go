@@ -0,0 +1,145 @@
+package impllogpattern
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/logstelemetryschema"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// sampleBuckets is the number of buckets the log IDs are hashed into when sampling; the
+// logs of the first buckets make the sample.
+const sampleBuckets = 10000
+
+// statementBuilder builds the queries reading the logs to cluster.
+type statementBuilder struct {
+	metadataStore telemetrytypes.MetadataStore
+	fieldMapper   qbtypes.FieldMapper
+	condBuilder   qbtypes.ConditionBuilder
+	fl            flagger.Flagger
+	logger        *slog.Logger
+}
+
+func newStatementBuilder(metadataStore telemetrytypes.MetadataStore, fl flagger.Flagger, logger *slog.Logger) *statementBuilder {
+	fieldMapper := logstelemetryschema.NewFieldMapper(fl)
+	return &statementBuilder{
+		metadataStore: metadataStore,
+		fieldMapper:   fieldMapper,
+		condBuilder:   logstelemetryschema.NewConditionBuilder(fieldMapper, fl),
+		fl:            fl,
+		logger:        logger,
+	}
+}
+
+// statements builds the queries over the logs of one period matching the filter.
+type statements struct {
+	startNs uint64
+	endNs   uint64
+	body    string
+	where   *sqlbuilder.WhereClause
+}
+
+// Build prepares the statements for the given period in epoch milliseconds.
+func (b *statementBuilder) Build(ctx context.Context, orgID valuer.UUID, start, end uint64, filter *qbtypes.Filter) (*statements, error) {
+	startNs, endNs := querybuilder.ToNanoSecs(start), querybuilder.ToNanoSecs(end)
+
+	body, err := b.fieldMapper.FieldFor(ctx, orgID, startNs, endNs, logstelemetryschema.DefaultFullTextColumn)
+	if err != nil {
+		return nil, err
+	}
+	result := &statements{startNs: startNs, endNs: endNs, body: body}
+
+	expression := ""
+	if filter != nil {
+		expression = strings.TrimSpace(filter.Expression)
+	}
+	if expression == "" {
+		return result, nil
+	}
+
+	selectors := querybuilder.QueryStringToKeysSelectors(expression)
+	for idx := range selectors {
+		selectors[idx].Signal = telemetrytypes.SignalLogs
+		selectors[idx].SelectorMatchType = telemetrytypes.FieldSelectorMatchTypeExact
+	}
+
+	keys, _, err := b.metadataStore.GetKeysMulti(ctx, orgID, selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	whereClause, err := querybuilder.PrepareWhereClause(expression, querybuilder.FilterExprVisitorOpts{
+		Context:          ctx,
+		OrgID:            orgID,
+		Flagger:          b.fl,
+		Logger:           b.logger,
+		FieldMapper:      b.fieldMapper,
+		ConditionBuilder: b.condBuilder,
+		FieldKeys:        keys,
+		FullTextColumn:   logstelemetryschema.DefaultFullTextColumn,
+		StartNs:          startNs,
+		EndNs:            endNs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !whereClause.IsEmpty() {
+		result.where = whereClause.WhereClause
+	}
+	return result, nil
+}
+
+// Count returns the statement counting the logs.
+func (s *statements) Count() *qbtypes.Statement {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("count() AS total")
+	s.from(sb)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: query, Args: args}
+}
+
+// Samples returns the statement selecting the ID, timestamp and body of at most limit
+// logs. When total, the number of logs, is above the limit the logs are sampled by the
+// hash of their ID, which keeps the sample stable across requests.
+func (s *statements) Samples(total uint64, limit int) *qbtypes.Statement {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		logstelemetryschema.LogsV2IDColumn,
+		logstelemetryschema.LogsV2TimestampColumn,
+		fmt.Sprintf("toString(%s) AS log_body", s.body),
+	)
+	s.from(sb)
+	if total > uint64(limit) {
+		threshold := uint64(math.Ceil(float64(limit) * sampleBuckets / float64(total)))
+		sb.Where(sb.L(fmt.Sprintf("cityHash64(%s) %% %d", logstelemetryschema.LogsV2IDColumn, sampleBuckets), threshold))
+	}
+	sb.Limit(limit)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: query, Args: args}
+}
+
+func (s *statements) from(sb *sqlbuilder.SelectBuilder) {
+	sb.From(fmt.Sprintf("%s.%s", logstelemetryschema.DBName, logstelemetryschema.LogsV2TableName))
+	if s.where != nil {
+		sb.AddWhereClause(s.where)
+	}
+	startBucket := s.startNs/querybuilder.NsToSeconds - querybuilder.BucketAdjustment
+	endBucket := s.endNs / querybuilder.NsToSeconds
+	sb.Where(
+		sb.GE(logstelemetryschema.LogsV2TimestampColumn, fmt.Sprintf("%d", s.startNs)),
+		sb.L(logstelemetryschema.LogsV2TimestampColumn, fmt.Sprintf("%d", s.endNs)),
+		sb.GE(logstelemetryschema.LogsV2TimestampBucketStartColumn, startBucket),
+		sb.LE(logstelemetryschema.LogsV2TimestampBucketStartColumn, endBucket),
+	)
+}
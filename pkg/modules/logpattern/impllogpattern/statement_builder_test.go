@@ -0,0 +1,83 @@
+package impllogpattern
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/logstelemetryschema"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStatementBuilder(t *testing.T) *statementBuilder {
+	t.Helper()
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = logstelemetryschema.BuildCompleteFieldKeyMap(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	return newStatementBuilder(mockMetadataStore, flaggertest.New(t), instrumentationtest.New().Logger())
+}
+
+func TestStatementBuilder_Build(t *testing.T) {
+	start, end := uint64(1747947419000), uint64(1747983448000)
+	timeCondition := "timestamp >= ? AND timestamp < ? AND ts_bucket_start >= ? AND ts_bucket_start <= ?"
+	timeArgs := []any{"1747947419000000000", "1747983448000000000", uint64(1747945619), uint64(1747983448)}
+	samplesSelect := "SELECT id, timestamp, toString(body) AS log_body FROM signoz_logs.distributed_logs_v2 WHERE "
+
+	cases := []struct {
+		name          string
+		filter        *qbtypes.Filter
+		total         uint64
+		expectedWhere string
+		expectedArgs  []any
+		expectedCount string
+		expectedQuery string
+		expectedSArgs []any
+	}{
+		{
+			name:          "no_filter_below_limit",
+			filter:        nil,
+			total:         500,
+			expectedCount: "SELECT count() AS total FROM signoz_logs.distributed_logs_v2 WHERE " + timeCondition,
+			expectedArgs:  timeArgs,
+			expectedQuery: samplesSelect + timeCondition + " LIMIT ?",
+			expectedSArgs: append(append([]any{}, timeArgs...), 1000),
+		},
+		{
+			name:          "filter_above_limit_is_sampled",
+			filter:        &qbtypes.Filter{Expression: "severity_text = 'ERROR'"},
+			total:         3000,
+			expectedCount: "SELECT count() AS total FROM signoz_logs.distributed_logs_v2 WHERE severity_text = ? AND " + timeCondition,
+			expectedArgs:  append([]any{"ERROR"}, timeArgs...),
+			expectedQuery: samplesSelect + "severity_text = ? AND " + timeCondition + " AND cityHash64(id) % 10000 < ? LIMIT ?",
+			expectedSArgs: append(append([]any{"ERROR"}, timeArgs...), uint64(3334), 1000),
+		},
+	}
+
+	statementBuilder := newTestStatementBuilder(t)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmts, err := statementBuilder.Build(context.Background(), valuer.GenerateUUID(), start, end, c.filter)
+			require.NoError(t, err)
+
+			count := stmts.Count()
+			assert.Equal(t, c.expectedCount, count.Query)
+			assert.Equal(t, c.expectedArgs, count.Args)
+
+			samples := stmts.Samples(c.total, 1000)
+			assert.Equal(t, c.expectedQuery, samples.Query)
+			assert.Equal(t, c.expectedSArgs, samples.Args)
+		})
+	}
+}
+
+func TestStatementBuilder_InvalidFilter(t *testing.T) {
+	statementBuilder := newTestStatementBuilder(t)
+
+	_, err := statementBuilder.Build(context.Background(), valuer.GenerateUUID(), 1747947419000, 1747983448000, &qbtypes.Filter{Expression: "severity_text = "})
+	require.Error(t, err)
+}
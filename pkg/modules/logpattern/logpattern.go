@@ -0,0 +1,19 @@
+package logpattern
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/logpatterntypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module clusters log bodies into patterns.
+type Module interface {
+	GetPatterns(ctx context.Context, orgID valuer.UUID, req *logpatterntypes.PostableLogPatterns) (*logpatterntypes.GettableLogPatterns, error)
+}
+
+// Handler exposes the log pattern module over HTTP.
+type Handler interface {
+	GetPatterns(http.ResponseWriter, *http.Request)
+}
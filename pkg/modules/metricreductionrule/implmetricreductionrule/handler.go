@@ -196,3 +196,46 @@ func (h *handler) DeleteByID(rw http.ResponseWriter, r *http.Request) {
 
 	render.Success(rw, http.StatusNoContent, nil)
 }
+
+func (h *handler) Recommendations(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var params metricreductionruletypes.ListReductionRuleRecommendationsParams
+	if err := binding.Query.BindQuery(r.URL.Query(), &params); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	out, err := h.module.Recommendations(r.Context(), valuer.MustNewUUID(claims.OrgID), &params)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, out)
+}
+
+func (h *handler) ApplyRecommendation(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var in metricreductionruletypes.PostableReductionRuleRecommendation
+	if err := binding.JSON.BindBody(r.Body, &in); err != nil {
+		render.Error(rw, err)
+		return
+	}
+	out, err := h.module.ApplyRecommendation(r.Context(), valuer.MustNewUUID(claims.OrgID), claims.Email, &in)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, out)
+}
@@ -50,3 +50,11 @@ func (m *module) Stats(_ context.Context, _ valuer.UUID) (*metricreductionrulety
 func (m *module) Timeseries(_ context.Context, _ valuer.UUID) (*querybuildertypesv5.QueryRangeResponse, error) {
 	return nil, errUnsupported
 }
+
+func (m *module) Recommendations(_ context.Context, _ valuer.UUID, _ *metricreductionruletypes.ListReductionRuleRecommendationsParams) (*metricreductionruletypes.GettableReductionRuleRecommendations, error) {
+	return nil, errUnsupported
+}
+
+func (m *module) ApplyRecommendation(_ context.Context, _ valuer.UUID, _ string, _ *metricreductionruletypes.PostableReductionRuleRecommendation) (*metricreductionruletypes.GettableReductionRule, error) {
+	return nil, errUnsupported
+}
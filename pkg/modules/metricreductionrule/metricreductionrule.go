@@ -18,6 +18,8 @@ type Module interface {
 	Preview(ctx context.Context, orgID valuer.UUID, req *metricreductionruletypes.PostableReductionRulePreview) (*metricreductionruletypes.GettableReductionRulePreview, error)
 	Stats(ctx context.Context, orgID valuer.UUID) (*metricreductionruletypes.GettableReductionRuleStats, error)
 	Timeseries(ctx context.Context, orgID valuer.UUID) (*querybuildertypesv5.QueryRangeResponse, error)
+	Recommendations(ctx context.Context, orgID valuer.UUID, params *metricreductionruletypes.ListReductionRuleRecommendationsParams) (*metricreductionruletypes.GettableReductionRuleRecommendations, error)
+	ApplyRecommendation(ctx context.Context, orgID valuer.UUID, userEmail string, req *metricreductionruletypes.PostableReductionRuleRecommendation) (*metricreductionruletypes.GettableReductionRule, error)
 }
 
 type Handler interface {
@@ -29,4 +31,6 @@ type Handler interface {
 	Preview(rw http.ResponseWriter, r *http.Request)
 	Stats(rw http.ResponseWriter, r *http.Request)
 	Timeseries(rw http.ResponseWriter, r *http.Request)
+	Recommendations(rw http.ResponseWriter, r *http.Request)
+	ApplyRecommendation(rw http.ResponseWriter, r *http.Request)
 }
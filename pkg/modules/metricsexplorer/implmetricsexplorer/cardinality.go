@@ -0,0 +1,306 @@
+package implmetricsexplorer
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	sqlbuilder "github.com/huandu/go-sqlbuilder"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/metricstelemetryschema"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/metricsexplorertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// maxNewLabelValues caps the new values listed per label key.
+const maxNewLabelValues = 10
+
+func (m *module) GetCardinality(ctx context.Context, orgID valuer.UUID, req *metricsexplorertypes.CardinalityRequest) (*metricsexplorertypes.CardinalityResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	labels, err := m.fetchLabelDistinctValues(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &metricsexplorertypes.CardinalityResponse{
+		MetricName: req.MetricName,
+		Labels:     labels,
+		Trend:      []metricsexplorertypes.CardinalityTrendPoint{},
+	}
+	if len(labels) == 0 {
+		return resp, nil
+	}
+
+	keys := make([]string, len(labels))
+	for i := range labels {
+		keys[i] = labels[i].Key
+	}
+
+	if err := m.fetchLabelNewValues(ctx, req, keys, labels); err != nil {
+		return nil, err
+	}
+
+	resp.TotalSeries, err = m.fetchLabelSeriesContribution(ctx, req, keys, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Trend, err = m.fetchCardinalityTrend(ctx, req, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range labels {
+		if req.MetricName != "" && labels[i].SeriesIfDropped < resp.TotalSeries {
+			labels[i].SuggestedReduction = metricsexplorertypes.NewSuggestedReduction(req.MetricName, labels[i].Key, req.Start, req.End)
+		}
+	}
+
+	if req.OrderBy == metricsexplorertypes.CardinalityOrderBySeriesContribution {
+		slices.SortStableFunc(labels, func(a, b metricsexplorertypes.LabelCardinality) int {
+			return cmp.Compare(b.Contribution, a.Contribution)
+		})
+	}
+
+	return resp, nil
+}
+
+// whereCardinalityMetric scopes a query to the requested metric, or to all but the SigNoz internal metrics.
+func whereCardinalityMetric(sb *sqlbuilder.SelectBuilder, metricName string) {
+	if metricName != "" {
+		sb.Where(sb.E("metric_name", metricName))
+		return
+	}
+
+	sb.Where("NOT startsWith(metric_name, 'signoz')")
+}
+
+// fetchLabelDistinctValues returns the label keys with the most distinct values in the range. Only these
+// keys are analysed further, the series queries need an aggregate per key.
+func (m *module) fetchLabelDistinctValues(ctx context.Context, req *metricsexplorertypes.CardinalityRequest) ([]metricsexplorertypes.LabelCardinality, error) {
+	ctx = m.withMetricsExplorerContext(ctx, "fetchLabelDistinctValues")
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"attr_name AS key",
+		"uniq(attr_string_value) AS distinct_values",
+	)
+	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, metricstelemetryschema.AttributesMetadataTableName))
+	whereCardinalityMetric(sb, req.MetricName)
+	sb.Where("NOT startsWith(attr_name, '__')")
+	sb.Where(sb.GE("last_reported_unix_milli", req.Start))
+	sb.Where(sb.LE("first_reported_unix_milli", req.End))
+	sb.GroupBy("attr_name")
+	sb.OrderBy("distinct_values DESC")
+	sb.Limit(req.Limit)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	valueCtx := ctxtypes.SetClickhouseMaxThreads(ctx, m.config.TelemetryStore.Threads)
+	db := m.telemetryStore.ClickhouseDB()
+	rows, err := db.Query(valueCtx, query, args...)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to fetch label distinct values")
+	}
+	defer rows.Close()
+
+	labels := make([]metricsexplorertypes.LabelCardinality, 0)
+	for rows.Next() {
+		label := metricsexplorertypes.LabelCardinality{NewValues: []metricsexplorertypes.NewLabelValue{}}
+		if err := rows.Scan(&label.Key, &label.DistinctValues); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to scan label distinct values row")
+		}
+		labels = append(labels, label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "error iterating label distinct values rows")
+	}
+
+	return labels, nil
+}
+
+// fetchLabelNewValues fills in the values of the label keys first seen within the new values window,
+// the most recent first.
+func (m *module) fetchLabelNewValues(ctx context.Context, req *metricsexplorertypes.CardinalityRequest, keys []string, labels []metricsexplorertypes.LabelCardinality) error {
+	ctx = m.withMetricsExplorerContext(ctx, "fetchLabelNewValues")
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"attr_name",
+		"attr_string_value",
+		"min(first_reported_unix_milli) AS first_seen",
+		"count() OVER (PARTITION BY attr_name) AS new_values",
+	)
+	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, metricstelemetryschema.AttributesMetadataTableName))
+	whereCardinalityMetric(sb, req.MetricName)
+	sb.Where(sb.In("attr_name", sqlbuilder.List(keys)))
+	sb.Where(sb.LE("first_reported_unix_milli", req.End))
+	sb.GroupBy("attr_name", "attr_string_value")
+	sb.Having(sb.GE("first_seen", req.End-req.NewValuesWindowMs))
+	sb.OrderBy("first_seen DESC")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	query += fmt.Sprintf(" LIMIT %d BY attr_name", maxNewLabelValues)
+
+	valueCtx := ctxtypes.SetClickhouseMaxThreads(ctx, m.config.TelemetryStore.Threads)
+	db := m.telemetryStore.ClickhouseDB()
+	rows, err := db.Query(valueCtx, query, args...)
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to fetch label new values")
+	}
+	defer rows.Close()
+
+	indexByKey := make(map[string]int, len(labels))
+	for i := range labels {
+		indexByKey[labels[i].Key] = i
+	}
+
+	for rows.Next() {
+		var key string
+		var value metricsexplorertypes.NewLabelValue
+		var newValues uint64
+		if err := rows.Scan(&key, &value.Value, &value.FirstSeenAt, &newValues); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to scan label new values row")
+		}
+
+		i, ok := indexByKey[key]
+		if !ok {
+			continue
+		}
+		labels[i].NewValueCount = newValues
+		labels[i].NewValues = append(labels[i].NewValues, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "error iterating label new values rows")
+	}
+
+	return nil
+}
+
+// fetchLabelSeriesContribution fills in the series carrying each label key and the series left when
+// it is dropped, and returns the total series in the range.
+func (m *module) fetchLabelSeriesContribution(ctx context.Context, req *metricsexplorertypes.CardinalityRequest, keys []string, labels []metricsexplorertypes.LabelCardinality) (uint64, error) {
+	ctx = m.withMetricsExplorerContext(ctx, "fetchLabelSeriesContribution")
+
+	start, end, distributedTsTable, _ := metricstelemetryschema.WhichTSTableToUse(uint64(req.Start), uint64(req.End), false, nil)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	columns := []string{"uniq(fingerprint) AS total_series"}
+	for _, key := range keys {
+		columns = append(columns,
+			fmt.Sprintf("uniqIf(fingerprint, JSONHas(labels, %s))", sb.Var(key)),
+			fmt.Sprintf("uniq(metric_name, arrayFilter(kv -> kv.1 != %s, JSONExtractKeysAndValues(labels, 'String')))", sb.Var(key)),
+		)
+	}
+	sb.Select(columns...)
+	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, distributedTsTable))
+	sb.Where(sb.Between("unix_milli", start, end))
+	whereCardinalityMetric(sb, req.MetricName)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	var totalSeries uint64
+	series := make([]uint64, len(keys))
+	seriesIfDropped := make([]uint64, len(keys))
+	dest := []any{&totalSeries}
+	for i := range keys {
+		dest = append(dest, &series[i], &seriesIfDropped[i])
+	}
+
+	valueCtx := ctxtypes.SetClickhouseMaxThreads(ctx, m.config.TelemetryStore.Threads)
+	db := m.telemetryStore.ClickhouseDB()
+	if err := db.QueryRow(valueCtx, query, args...).Scan(dest...); err != nil {
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to fetch label series contribution")
+	}
+
+	for i := range labels {
+		labels[i].Series = series[i]
+		labels[i].SeriesIfDropped = seriesIfDropped[i]
+		if totalSeries > 0 && seriesIfDropped[i] < totalSeries {
+			labels[i].Contribution = float64(totalSeries-seriesIfDropped[i]) * 100.0 / float64(totalSeries)
+		}
+	}
+
+	return totalSeries, nil
+}
+
+// cardinalityTrendTable returns the time series table and bucket of the trend, a step finer than the
+// table the range would use elsewhere so short ranges still show growth.
+func cardinalityTrendTable(start, end int64) (string, time.Duration) {
+	switch rangeMs := end - start; {
+	case rangeMs < (24 * time.Hour).Milliseconds():
+		return metricstelemetryschema.TimeseriesV4TableName, time.Hour
+	case rangeMs < (7 * 24 * time.Hour).Milliseconds():
+		return metricstelemetryschema.TimeseriesV46hrsTableName, 6 * time.Hour
+	default:
+		return metricstelemetryschema.TimeseriesV41dayTableName, 24 * time.Hour
+	}
+}
+
+// fetchCardinalityTrend returns the series and distinct values of the label keys per bucket.
+func (m *module) fetchCardinalityTrend(ctx context.Context, req *metricsexplorertypes.CardinalityRequest, keys []string) ([]metricsexplorertypes.CardinalityTrendPoint, error) {
+	ctx = m.withMetricsExplorerContext(ctx, "fetchCardinalityTrend")
+
+	table, step := cardinalityTrendTable(req.Start, req.End)
+	start := req.Start - req.Start%step.Milliseconds()
+
+	sb := sqlbuilder.NewSelectBuilder()
+	columns := []string{
+		fmt.Sprintf("toInt64(toUnixTimestamp(toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(%d)))) * 1000 AS ts", int64(step.Seconds())),
+		"uniq(fingerprint) AS series",
+	}
+	for _, key := range keys {
+		columns = append(columns, fmt.Sprintf("uniqIf(JSONExtractString(labels, %s), JSONHas(labels, %s))", sb.Var(key), sb.Var(key)))
+	}
+	sb.Select(columns...)
+	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, table))
+	sb.Where(sb.Between("unix_milli", start, req.End))
+	whereCardinalityMetric(sb, req.MetricName)
+	sb.GroupBy("ts")
+	sb.OrderBy("ts ASC")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	valueCtx := ctxtypes.SetClickhouseMaxThreads(ctx, m.config.TelemetryStore.Threads)
+	db := m.telemetryStore.ClickhouseDB()
+	rows, err := db.Query(valueCtx, query, args...)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to fetch cardinality trend")
+	}
+	defer rows.Close()
+
+	trend := make([]metricsexplorertypes.CardinalityTrendPoint, 0)
+	for rows.Next() {
+		var point metricsexplorertypes.CardinalityTrendPoint
+		distinctValues := make([]uint64, len(keys))
+		dest := []any{&point.Timestamp, &point.Series}
+		for i := range keys {
+			dest = append(dest, &distinctValues[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to scan cardinality trend row")
+		}
+
+		point.DistinctValues = make(map[string]uint64, len(keys))
+		for i, key := range keys {
+			point.DistinctValues[key] = distinctValues[i]
+		}
+		trend = append(trend, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "error iterating cardinality trend rows")
+	}
+
+	return trend, nil
+}
@@ -308,3 +308,54 @@ func TestGetTreemap(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCardinality(t *testing.T) {
+	mod, mock, _ := newTestModule(t, sqlmock.QueryMatcherRegexp, false)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT attr_name AS key, uniq(attr_string_value) AS distinct_values FROM signoz_metrics.distributed_metadata WHERE metric_name = ?")).
+		WithArgs(anyArgs(4)...).
+		WillReturnRows(cmock.NewRows(
+			[]cmock.ColumnType{{Name: "key", Type: "String"}, {Name: "distinct_values", Type: "UInt64"}},
+			[][]any{{"path", uint64(500)}, {"pod", uint64(20)}},
+		))
+	mock.ExpectQuery(regexp.QuoteMeta("HAVING first_seen >= ? ORDER BY first_seen DESC LIMIT 10 BY attr_name")).
+		WithArgs(anyArgs(5)...).
+		WillReturnRows(cmock.NewRows(
+			[]cmock.ColumnType{{Name: "attr_name", Type: "String"}, {Name: "attr_string_value", Type: "String"}, {Name: "first_seen", Type: "Int64"}, {Name: "new_values", Type: "UInt64"}},
+			[][]any{{"pod", "pod-7", int64(1700003000000), uint64(1)}},
+		))
+	mock.ExpectQueryRow(regexp.QuoteMeta("SELECT uniq(fingerprint) AS total_series, uniqIf(fingerprint, JSONHas(labels, ?))")).
+		WillReturnRow(cmock.NewRow(
+			[]cmock.ColumnType{{Name: "total_series", Type: "UInt64"}, {Name: "s0", Type: "UInt64"}, {Name: "d0", Type: "UInt64"}, {Name: "s1", Type: "UInt64"}, {Name: "d1", Type: "UInt64"}},
+			[]any{uint64(1000), uint64(900), uint64(950), uint64(1000), uint64(100)},
+		))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM signoz_metrics.distributed_time_series_v4 WHERE unix_milli BETWEEN ? AND ? AND metric_name = ? GROUP BY ts ORDER BY ts ASC")).
+		WithArgs(anyArgs(7)...).
+		WillReturnRows(cmock.NewRows(
+			[]cmock.ColumnType{{Name: "ts", Type: "Int64"}, {Name: "series", Type: "UInt64"}, {Name: "v0", Type: "UInt64"}, {Name: "v1", Type: "UInt64"}},
+			[][]any{{int64(1699999200000), uint64(1000), uint64(500), uint64(20)}},
+		))
+
+	resp, err := mod.GetCardinality(context.Background(), testOrgID, &metricsexplorertypes.CardinalityRequest{
+		MetricName: "http_requests",
+		Start:      testStartMillis,
+		End:        testEndMillis,
+		Limit:      10,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, uint64(1000), resp.TotalSeries)
+	// dropping pod collapses most series, so it ranks above path despite fewer values
+	if assert.Len(t, resp.Labels, 2) {
+		assert.Equal(t, "pod", resp.Labels[0].Key)
+		assert.Equal(t, 90.0, resp.Labels[0].Contribution)
+		assert.Equal(t, []metricsexplorertypes.NewLabelValue{{Value: "pod-7", FirstSeenAt: 1700003000000}}, resp.Labels[0].NewValues)
+		assert.Equal(t, []string{"pod"}, resp.Labels[0].SuggestedReduction.Labels)
+		assert.Equal(t, "path", resp.Labels[1].Key)
+		assert.Equal(t, 5.0, resp.Labels[1].Contribution)
+	}
+	if assert.Len(t, resp.Trend, 1) {
+		assert.Equal(t, map[string]uint64{"path": 500, "pod": 20}, resp.Trend[0].DistinctValues)
+	}
+}
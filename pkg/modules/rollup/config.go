@@ -0,0 +1,56 @@
+package rollup
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+)
+
+type Config struct {
+	// Detection creates rollups for the query shapes run the most.
+	Detection DetectionConfig `mapstructure:"detection"`
+}
+
+type DetectionConfig struct {
+	// Enabled creates the rollups of the candidates run more than MinQueriesPerHour.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinQueriesPerHour is how often a query shape must run to get a rollup.
+	MinQueriesPerHour float64 `mapstructure:"min_queries_per_hour"`
+
+	// Interval is how often the candidates are checked.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// RollupInterval is the granularity of the rollups created.
+	RollupInterval time.Duration `mapstructure:"rollup_interval"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("rollup"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return &Config{
+		Detection: DetectionConfig{
+			Enabled:           false,
+			MinQueriesPerHour: 120,
+			Interval:          time.Hour,
+			RollupInterval:    time.Minute,
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Detection.MinQueriesPerHour <= 0 {
+		return errors.New(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "rollup::detection::min_queries_per_hour must be positive")
+	}
+	if c.Detection.Interval <= 0 {
+		return errors.New(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "rollup::detection::interval must be positive")
+	}
+	if c.Detection.RollupInterval < rolluptypes.MinInterval || c.Detection.RollupInterval > rolluptypes.MaxInterval || c.Detection.RollupInterval%time.Second != 0 {
+		return errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "rollup::detection::rollup_interval must be a whole number of seconds between %v and %v", rolluptypes.MinInterval, rolluptypes.MaxInterval)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package implrollup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/modules/rollup"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// getterTTL is how long the rollups of an org are cached, as every builder query of the
+// org looks them up.
+const getterTTL = 30 * time.Second
+
+type cachedRollups struct {
+	rollups []*rolluptypes.Rollup
+	at      time.Time
+}
+
+type getter struct {
+	store rolluptypes.Store
+
+	mu   sync.Mutex
+	orgs map[valuer.UUID]cachedRollups
+}
+
+func NewGetter(store rolluptypes.Store) rollup.Getter {
+	return &getter{store: store, orgs: map[valuer.UUID]cachedRollups{}}
+}
+
+func (g *getter) ListActive(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal) ([]*rolluptypes.Rollup, error) {
+	rollups, err := g.list(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*rolluptypes.Rollup, 0, len(rollups))
+	for _, rollup := range rollups {
+		if rollup.Status == rolluptypes.StatusActive && rollup.Signal == signal {
+			active = append(active, rollup)
+		}
+	}
+	return active, nil
+}
+
+func (g *getter) Invalidate(orgID valuer.UUID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.orgs, orgID)
+}
+
+func (g *getter) list(ctx context.Context, orgID valuer.UUID) ([]*rolluptypes.Rollup, error) {
+	g.mu.Lock()
+	cached, ok := g.orgs[orgID]
+	g.mu.Unlock()
+	if ok && time.Since(cached.at) < getterTTL {
+		return cached.rollups, nil
+	}
+
+	rollups, err := g.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	g.orgs[orgID] = cachedRollups{rollups: rollups, at: time.Now()}
+	g.mu.Unlock()
+	return rollups, nil
+}
@@ -0,0 +1,242 @@
+package implrollup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/rollup"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystorehook"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// maxCandidates is the number of query shapes listed as candidates.
+const maxCandidates = 50
+
+type module struct {
+	store             rolluptypes.Store
+	getter            rollup.Getter
+	statementBuilders map[telemetrytypes.Signal]rolluptypes.StatementBuilder
+	telemetryStore    telemetrystore.TelemetryStore
+	queryShapes       *telemetrystorehook.QueryShapes
+	logger            *slog.Logger
+}
+
+func NewModule(
+	store rolluptypes.Store,
+	getter rollup.Getter,
+	statementBuilders map[telemetrytypes.Signal]rolluptypes.StatementBuilder,
+	telemetryStore telemetrystore.TelemetryStore,
+	queryShapes *telemetrystorehook.QueryShapes,
+	providerSettings factory.ProviderSettings,
+) rollup.Module {
+	return &module{
+		store:             store,
+		getter:            getter,
+		statementBuilders: statementBuilders,
+		telemetryStore:    telemetryStore,
+		queryShapes:       queryShapes,
+		logger:            factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/rollup/implrollup").Logger(),
+	}
+}
+
+func (m *module) List(ctx context.Context, orgID valuer.UUID) ([]*rolluptypes.Rollup, error) {
+	return m.store.List(ctx, orgID)
+}
+
+func (m *module) Get(ctx context.Context, orgID, id valuer.UUID) (*rolluptypes.Rollup, error) {
+	return m.store.Get(ctx, orgID, id)
+}
+
+func (m *module) Create(ctx context.Context, orgID valuer.UUID, createdBy string, source rolluptypes.Source, postable *rolluptypes.PostableRollup) (*rolluptypes.Rollup, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	stmtBuilder, err := m.statementBuilder(postable.Signal)
+	if err != nil {
+		return nil, err
+	}
+
+	rollup := rolluptypes.NewRollup(orgID, createdBy, source, postable)
+
+	existing, err := m.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Shape().Key() == rollup.Shape().Key() && other.IntervalSeconds == rollup.IntervalSeconds {
+			return nil, errors.Newf(errors.TypeAlreadyExists, rolluptypes.ErrCodeRollupAlreadyExists, "rollup %q already pre-aggregates this shape at this interval", other.Name)
+		}
+	}
+
+	ctx = m.withComment(ctx, "Create")
+	stmts, err := stmtBuilder.BuildCreate(ctx, orgID, m.telemetryStore.Cluster(), rollup)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.store.Create(ctx, rollup); err != nil {
+		return nil, err
+	}
+
+	for _, stmt := range stmts {
+		if err := m.telemetryStore.ClickhouseDB().Exec(ctx, stmt); err != nil {
+			m.drop(ctx, stmtBuilder, rollup)
+			rollup.Fail(err)
+			if err := m.store.Update(ctx, rollup); err != nil {
+				m.logger.ErrorContext(ctx, "failed to record the failure of the rollup", slog.String("rollup.id", rollup.ID.StringValue()), slog.Any("error", err))
+			}
+			return nil, errors.WrapInternalf(err, rolluptypes.ErrCodeRollupCreateFailed, "failed to create rollup %q in ClickHouse", rollup.Name)
+		}
+	}
+
+	rollup.Activate(time.Now())
+	if err := m.store.Update(ctx, rollup); err != nil {
+		return nil, err
+	}
+
+	m.getter.Invalidate(orgID)
+	return rollup, nil
+}
+
+func (m *module) Delete(ctx context.Context, orgID, id valuer.UUID) error {
+	rollup, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	stmtBuilder, err := m.statementBuilder(rollup.Signal)
+	if err != nil {
+		return err
+	}
+
+	// Stop serving queries from the rollup before dropping it.
+	rollup.Status = rolluptypes.StatusPending
+	rollup.UpdatedAt = time.Now()
+	if err := m.store.Update(ctx, rollup); err != nil {
+		return err
+	}
+	m.getter.Invalidate(orgID)
+
+	ctx = m.withComment(ctx, "Delete")
+	for _, stmt := range stmtBuilder.BuildDrop(m.telemetryStore.Cluster(), rollup) {
+		if err := m.telemetryStore.ClickhouseDB().Exec(ctx, stmt); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to drop rollup %q from ClickHouse", rollup.Name)
+		}
+	}
+
+	return m.store.Delete(ctx, orgID, id)
+}
+
+func (m *module) ListCandidates(ctx context.Context, orgID valuer.UUID) ([]*rolluptypes.Candidate, error) {
+	rollups, err := m.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*rolluptypes.Candidate, 0)
+	for _, count := range m.queryShapes.Top(orgID.StringValue(), maxCandidates) {
+		shape, err := rolluptypes.ParseShapeKey(count.Key)
+		if err != nil || shape.Validate() != nil {
+			continue
+		}
+
+		candidate := &rolluptypes.Candidate{Shape: shape, QueriesPerHour: count.QueriesPerHour}
+		for _, rollup := range rollups {
+			if _, _, ok := rollup.Covers(shape); ok {
+				candidate.RollupID = &rollup.ID
+				break
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func (m *module) Verify(ctx context.Context, orgID, id valuer.UUID, verification *rolluptypes.PostableVerification) (*rolluptypes.GettableVerification, error) {
+	if err := verification.Validate(); err != nil {
+		return nil, err
+	}
+
+	rollup, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if rollup.Status != rolluptypes.StatusActive {
+		return nil, errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupNotActive, "rollup %q is %s", rollup.Name, rollup.Status.StringValue())
+	}
+
+	stmtBuilder, err := m.statementBuilder(rollup.Signal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the whole intervals after the rollup became active are in it.
+	intervalMs := uint64(rollup.IntervalSeconds) * 1000
+	start := max(verification.Start, uint64(rollup.ActiveFrom.UnixMilli()))
+	start = (start + intervalMs - 1) / intervalMs * intervalMs
+	end := verification.End / intervalMs * intervalMs
+	if end <= start {
+		return nil, errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "the window must contain a whole interval of %ds after the rollup became active at %s", rollup.IntervalSeconds, rollup.ActiveFrom.UTC().Format(time.RFC3339))
+	}
+
+	rollupStmt, rawStmt, err := stmtBuilder.BuildVerification(ctx, orgID, rollup, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = m.withComment(ctx, "Verify")
+	rollupRows, err := m.query(ctx, rollupStmt.Query, rollupStmt.Args)
+	if err != nil {
+		return nil, err
+	}
+	rawRows, err := m.query(ctx, rawStmt.Query, rawStmt.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := compare(rollup, rawRows, rollupRows)
+	result.Start, result.End = start, end
+	return result, nil
+}
+
+func (m *module) query(ctx context.Context, query string, args []any) ([]*row, error) {
+	rows, err := m.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to query the rows to verify")
+	}
+	defer rows.Close()
+
+	return readRows(rows)
+}
+
+func (m *module) drop(ctx context.Context, stmtBuilder rolluptypes.StatementBuilder, rollup *rolluptypes.Rollup) {
+	for _, stmt := range stmtBuilder.BuildDrop(m.telemetryStore.Cluster(), rollup) {
+		if err := m.telemetryStore.ClickhouseDB().Exec(ctx, stmt); err != nil {
+			m.logger.ErrorContext(ctx, "failed to drop rollup", slog.String("rollup.id", rollup.ID.StringValue()), slog.Any("error", err))
+		}
+	}
+}
+
+func (m *module) statementBuilder(signal telemetrytypes.Signal) (rolluptypes.StatementBuilder, error) {
+	stmtBuilder, ok := m.statementBuilders[signal]
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "rollups are not supported for %q", signal.StringValue())
+	}
+	return stmtBuilder, nil
+}
+
+func (m *module) withComment(ctx context.Context, functionName string) context.Context {
+	return ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "rollup",
+		instrumentationtypes.CodeFunctionName: functionName,
+	})
+}
@@ -0,0 +1,114 @@
+package implrollup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/organization"
+	"github.com/SigNoz/signoz/pkg/modules/rollup"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// detectedBy is recorded as the creator of the rollups created by detection.
+const detectedBy = "rollup-detection"
+
+type service struct {
+	settings  factory.ScopedProviderSettings
+	module    rollup.Module
+	orgGetter organization.Getter
+	config    rollup.DetectionConfig
+	// failed are the shapes whose rollup could not be created, not retried until restart.
+	failed map[string]struct{}
+	stopC  chan struct{}
+}
+
+func NewService(providerSettings factory.ProviderSettings, module rollup.Module, orgGetter organization.Getter, config rollup.Config) rollup.Service {
+	return &service{
+		settings:  factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/rollup/implrollup"),
+		module:    module,
+		orgGetter: orgGetter,
+		config:    config.Detection,
+		failed:    map[string]struct{}{},
+		stopC:     make(chan struct{}),
+	}
+}
+
+func (s *service) Start(ctx context.Context) error {
+	if !s.config.Enabled {
+		<-s.stopC
+		return nil
+	}
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopC:
+			return nil
+		case <-ticker.C:
+			s.detect(ctx)
+		}
+	}
+}
+
+func (s *service) Stop(ctx context.Context) error {
+	close(s.stopC)
+	return nil
+}
+
+func (s *service) detect(ctx context.Context) {
+	orgs, err := s.orgGetter.ListByOwnedKeyRange(ctx)
+	if err != nil {
+		s.settings.Logger().ErrorContext(ctx, "failed to list orgs to detect rollups for", errors.Attr(err))
+		return
+	}
+
+	for _, org := range orgs {
+		if err := s.detectOrg(ctx, org.ID); err != nil {
+			s.settings.Logger().ErrorContext(ctx, "failed to detect rollups", slog.String("org.id", org.ID.StringValue()), errors.Attr(err))
+		}
+	}
+}
+
+func (s *service) detectOrg(ctx context.Context, orgID valuer.UUID) error {
+	candidates, err := s.module.ListCandidates(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range candidates {
+		if candidate.RollupID != nil || candidate.QueriesPerHour < s.config.MinQueriesPerHour {
+			continue
+		}
+
+		key := orgID.StringValue() + candidate.Key()
+		if _, ok := s.failed[key]; ok {
+			continue
+		}
+
+		sum := sha256.Sum256([]byte(candidate.Key()))
+		created, err := s.module.Create(ctx, orgID, detectedBy, rolluptypes.SourceDetected, &rolluptypes.PostableRollup{
+			Name:            "detected-" + hex.EncodeToString(sum[:6]),
+			Signal:          candidate.Signal,
+			Filter:          candidate.Filter,
+			GroupBy:         candidate.GroupBy,
+			Aggregations:    candidate.Aggregations,
+			IntervalSeconds: int64(s.config.RollupInterval / time.Second),
+		})
+		if err != nil {
+			s.failed[key] = struct{}{}
+			s.settings.Logger().WarnContext(ctx, "failed to create detected rollup", slog.String("org.id", orgID.StringValue()), slog.String("rollup.shape", candidate.Key()), errors.Attr(err))
+			continue
+		}
+
+		s.settings.Logger().InfoContext(ctx, "created detected rollup", slog.String("org.id", orgID.StringValue()), slog.String("rollup.id", created.ID.StringValue()), slog.Float64("queries_per_hour", candidate.QueriesPerHour))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package implrollup
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) rolluptypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (s *store) List(ctx context.Context, orgID valuer.UUID) ([]*rolluptypes.Rollup, error) {
+	rollups := make([]*rolluptypes.Rollup, 0)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&rollups).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+func (s *store) Get(ctx context.Context, orgID, id valuer.UUID) (*rolluptypes.Rollup, error) {
+	rollup := new(rolluptypes.Rollup)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(rollup).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, s.sqlstore.WrapNotFoundErrf(err, rolluptypes.ErrCodeRollupNotFound, "rollup %s not found", id)
+	}
+	return rollup, nil
+}
+
+func (s *store) Create(ctx context.Context, rollup *rolluptypes.Rollup) error {
+	_, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(rollup).
+		Exec(ctx)
+	if err != nil {
+		return s.sqlstore.WrapAlreadyExistsErrf(err, rolluptypes.ErrCodeRollupAlreadyExists, "rollup %q already exists", rollup.Name)
+	}
+	return nil
+}
+
+func (s *store) Update(ctx context.Context, rollup *rolluptypes.Rollup) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(rollup).
+		Where("org_id = ?", rollup.OrgID).
+		Where("id = ?", rollup.ID).
+		Exec(ctx)
+	if err != nil {
+		return s.sqlstore.WrapAlreadyExistsErrf(err, rolluptypes.ErrCodeRollupAlreadyExists, "rollup %q already exists", rollup.Name)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, rolluptypes.ErrCodeRollupNotFound, "rollup %s not found", rollup.ID)
+	}
+	return nil
+}
+
+func (s *store) Delete(ctx context.Context, orgID, id valuer.UUID) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model((*rolluptypes.Rollup)(nil)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, rolluptypes.ErrCodeRollupNotFound, "rollup %s not found", id)
+	}
+	return nil
+}
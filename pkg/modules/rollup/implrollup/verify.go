@@ -0,0 +1,187 @@
+package implrollup
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+)
+
+const (
+	// maxMismatches is the number of mismatches listed in a verification.
+	maxMismatches = 100
+	// tolerance is the relative difference of the values of the rollup and of the raw rows
+	// put down to the order floats are summed in.
+	tolerance = 1e-9
+)
+
+var (
+	groupByAliasRegex = regexp.MustCompile(`^__GROUP_BY_KEY_(\d+)_`)
+	resultAliasRegex  = regexp.MustCompile(`^__result_(\d+)$`)
+)
+
+// row is a row of the time series of a rollup, by group by key and aggregation index.
+type row struct {
+	ts     time.Time
+	group  []string
+	values []*float64
+}
+
+func (r *row) key() string {
+	return strconv.FormatInt(r.ts.UnixMilli(), 10) + "\x00" + strings.Join(r.group, "\x00")
+}
+
+// readRows reads the ts, __GROUP_BY_KEY_<i>_<name> and __result_<i> columns of the rows.
+func readRows(rows driver.Rows) ([]*row, error) {
+	colTypes := rows.ColumnTypes()
+	colNames := rows.Columns()
+
+	slots := make([]any, len(colTypes))
+	groups, results := 0, 0
+	for i, ct := range colTypes {
+		slots[i] = reflect.New(ct.ScanType()).Interface()
+		if groupByAliasRegex.MatchString(colNames[i]) {
+			groups++
+		}
+		if resultAliasRegex.MatchString(colNames[i]) {
+			results++
+		}
+	}
+
+	out := make([]*row, 0)
+	for rows.Next() {
+		if err := rows.Scan(slots...); err != nil {
+			return nil, err
+		}
+
+		r := &row{group: make([]string, groups), values: make([]*float64, results)}
+		for i, slot := range slots {
+			value := reflect.ValueOf(slot).Elem()
+			for value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					break
+				}
+				value = value.Elem()
+			}
+
+			if m := groupByAliasRegex.FindStringSubmatch(colNames[i]); m != nil {
+				idx, _ := strconv.Atoi(m[1])
+				r.group[idx] = fmt.Sprint(value.Interface())
+				continue
+			}
+			if m := resultAliasRegex.FindStringSubmatch(colNames[i]); m != nil {
+				idx, _ := strconv.Atoi(m[1])
+				r.values[idx] = asFloat(value)
+				continue
+			}
+			if ts, ok := value.Interface().(time.Time); ok {
+				r.ts = ts
+			}
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+func asFloat(value reflect.Value) *float64 {
+	var f float64
+	switch {
+	case value.CanFloat():
+		f = value.Float()
+	case value.CanInt():
+		f = float64(value.Int())
+	case value.CanUint():
+		f = float64(value.Uint())
+	default:
+		return nil
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return &f
+}
+
+// compare compares the time series of the rollup with the ones of the raw rows. A series
+// missing on one side mismatches the values of the other.
+func compare(rollup *rolluptypes.Rollup, rawRows, rollupRows []*row) *rolluptypes.GettableVerification {
+	result := &rolluptypes.GettableVerification{
+		RawRows:    len(rawRows),
+		RollupRows: len(rollupRows),
+		Mismatches: make([]*rolluptypes.Mismatch, 0),
+	}
+
+	raw := make(map[string]*row, len(rawRows))
+	for _, r := range rawRows {
+		raw[r.key()] = r
+	}
+	rolled := make(map[string]*row, len(rollupRows))
+	for _, r := range rollupRows {
+		rolled[r.key()] = r
+	}
+
+	keys := make([]string, 0, len(raw)+len(rolled))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	for key := range rolled {
+		if _, ok := raw[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		rawRow, rollupRow := raw[key], rolled[key]
+		r := cmp.Or(rawRow, rollupRow)
+		for i, aggregation := range rollup.Aggregations {
+			rawValue, rollupValue := valueAt(rawRow, i), valueAt(rollupRow, i)
+			if equal(rawValue, rollupValue) {
+				continue
+			}
+
+			result.MismatchCount++
+			if len(result.Mismatches) == maxMismatches {
+				continue
+			}
+
+			group := make(map[string]string, len(rollup.GroupBy))
+			for j, name := range rollup.GroupBy {
+				if j < len(r.group) {
+					group[name] = r.group[j]
+				}
+			}
+			result.Mismatches = append(result.Mismatches, &rolluptypes.Mismatch{
+				Timestamp:   r.ts,
+				Group:       group,
+				Aggregation: aggregation,
+				Raw:         rawValue,
+				Rollup:      rollupValue,
+			})
+		}
+	}
+
+	result.Match = result.MismatchCount == 0
+	return result
+}
+
+func valueAt(r *row, i int) *float64 {
+	if r == nil || i >= len(r.values) {
+		return nil
+	}
+	return r.values[i]
+}
+
+func equal(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return math.Abs(*a-*b) <= tolerance*max(1, math.Abs(*a), math.Abs(*b))
+}
@@ -0,0 +1,65 @@
+package implrollup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	value := func(f float64) *float64 { return &f }
+
+	rollup := &rolluptypes.Rollup{
+		GroupBy:      rolluptypes.Strings{"service.name"},
+		Aggregations: rolluptypes.Strings{"count()", "avg(duration)"},
+	}
+
+	raw := []*row{
+		{ts: ts, group: []string{"frontend"}, values: []*float64{value(10), value(1.0000000001)}},
+		{ts: ts, group: []string{"cart"}, values: []*float64{value(4), nil}},
+		{ts: ts.Add(time.Minute), group: []string{"cart"}, values: []*float64{value(7), value(2)}},
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		rolled := []*row{
+			{ts: ts.Add(time.Minute), group: []string{"cart"}, values: []*float64{value(7), value(2)}},
+			{ts: ts, group: []string{"cart"}, values: []*float64{value(4), nil}},
+			{ts: ts, group: []string{"frontend"}, values: []*float64{value(10), value(1)}},
+		}
+
+		result := compare(rollup, raw, rolled)
+		assert.True(t, result.Match)
+		assert.Equal(t, 0, result.MismatchCount)
+		assert.Equal(t, 3, result.RawRows)
+		assert.Equal(t, 3, result.RollupRows)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		rolled := []*row{
+			{ts: ts, group: []string{"cart"}, values: []*float64{value(3), nil}},
+			{ts: ts, group: []string{"frontend"}, values: []*float64{value(10), value(1)}},
+		}
+
+		result := compare(rollup, raw, rolled)
+		assert.False(t, result.Match)
+		assert.Equal(t, 3, result.MismatchCount)
+		require.Len(t, result.Mismatches, 3)
+
+		assert.Equal(t, &rolluptypes.Mismatch{
+			Timestamp:   ts,
+			Group:       map[string]string{"service.name": "cart"},
+			Aggregation: "count()",
+			Raw:         value(4),
+			Rollup:      value(3),
+		}, result.Mismatches[0])
+
+		// The series missing from the rollup mismatches every aggregation.
+		assert.Equal(t, ts.Add(time.Minute), result.Mismatches[1].Timestamp)
+		assert.Nil(t, result.Mismatches[1].Rollup)
+		assert.Equal(t, "avg(duration)", result.Mismatches[2].Aggregation)
+	})
+}
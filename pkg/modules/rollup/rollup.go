@@ -0,0 +1,44 @@
+package rollup
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module defines the business logic for rollups, the ClickHouse materialized views
+// pre-aggregating the logs and traces of the query shapes run the most.
+type Module interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*rolluptypes.Rollup, error)
+	Get(ctx context.Context, orgID, id valuer.UUID) (*rolluptypes.Rollup, error)
+	// Create stores the rollup and creates its tables and view in ClickHouse. It serves the
+	// queries matching it from the interval after the view is created.
+	Create(ctx context.Context, orgID valuer.UUID, createdBy string, source rolluptypes.Source, rollup *rolluptypes.PostableRollup) (*rolluptypes.Rollup, error)
+	// Delete drops the view and tables of the rollup and deletes it.
+	Delete(ctx context.Context, orgID, id valuer.UUID) error
+
+	// ListCandidates returns the query shapes of the org run the most, the ones worth a rollup.
+	ListCandidates(ctx context.Context, orgID valuer.UUID) ([]*rolluptypes.Candidate, error)
+	// Verify compares the time series of the rollup with the ones of the raw rows over the window.
+	Verify(ctx context.Context, orgID, id valuer.UUID, verification *rolluptypes.PostableVerification) (*rolluptypes.GettableVerification, error)
+}
+
+// Getter returns the active rollups of an org to the statement builders, caching them.
+type Getter interface {
+	rolluptypes.Getter
+
+	// Invalidate drops the cached rollups of the org, after they changed.
+	Invalidate(orgID valuer.UUID)
+}
+
+// Handler defines the HTTP handler interface for rollup endpoints.
+type Handler interface {
+	List(rw http.ResponseWriter, r *http.Request)
+	Get(rw http.ResponseWriter, r *http.Request)
+	Create(rw http.ResponseWriter, r *http.Request)
+	Delete(rw http.ResponseWriter, r *http.Request)
+	ListCandidates(rw http.ResponseWriter, r *http.Request)
+	Verify(rw http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,8 @@
+package rollup
+
+import "github.com/SigNoz/signoz/pkg/factory"
+
+// Service creates the rollups of the query shapes run the most, when detection is enabled.
+type Service interface {
+	factory.Service
+}
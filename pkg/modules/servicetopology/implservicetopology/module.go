@@ -0,0 +1,237 @@
+package implservicetopology
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	chproto "github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/modules/servicetopology"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	"github.com/SigNoz/signoz/pkg/types/servicetopologytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	telemetryStore telemetrystore.TelemetryStore
+	stmtBuilder    *statementBuilder
+}
+
+func NewModule(
+	telemetryStore telemetrystore.TelemetryStore,
+	telemetryMetadataStore telemetrytypes.MetadataStore,
+	fl flagger.Flagger,
+	providerSettings factory.ProviderSettings,
+) servicetopology.Module {
+	return &module{
+		telemetryStore: telemetryStore,
+		stmtBuilder:    newStatementBuilder(telemetryMetadataStore, fl, providerSettings.Logger),
+	}
+}
+
+type nodeRow struct {
+	Name       string    `ch:"name"`
+	Type       string    `ch:"type"`
+	CallCount  uint64    `ch:"call_count"`
+	ErrorCount uint64    `ch:"error_count"`
+	Quantiles  []float64 `ch:"quantiles"`
+}
+
+type edgeRow struct {
+	Source     string    `ch:"source"`
+	SourceType string    `ch:"source_type"`
+	Target     string    `ch:"target"`
+	TargetType string    `ch:"target_type"`
+	CallCount  uint64    `ch:"call_count"`
+	ErrorCount uint64    `ch:"error_count"`
+	Quantiles  []float64 `ch:"quantiles"`
+}
+
+type nodeKey struct {
+	name     string
+	nodeType string
+}
+
+type edgeKey struct {
+	source nodeKey
+	target nodeKey
+}
+
+// period holds the stats of the nodes and edges seen over one period.
+type period struct {
+	nodes map[nodeKey]servicetopologytypes.Stats
+	edges map[edgeKey]servicetopologytypes.Stats
+}
+
+func (m *module) GetTopology(ctx context.Context, orgID valuer.UUID, req *servicetopologytypes.PostableTopology) (*servicetopologytypes.GettableTopology, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "servicetopology",
+		instrumentationtypes.CodeFunctionName: "GetTopology",
+	})
+
+	current, err := m.getPeriod(ctx, orgID, req, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *period
+	if req.CompareWithPrevious {
+		previousStart, previousEnd := req.PreviousPeriod()
+		previous, err = m.getPeriod(ctx, orgID, req, previousStart, previousEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newGettableTopology(current, previous), nil
+}
+
+func (m *module) getPeriod(ctx context.Context, orgID valuer.UUID, req *servicetopologytypes.PostableTopology, start, end uint64) (*period, error) {
+	nodesStmt, edgesStmt, err := m.stmtBuilder.Build(ctx, orgID, start, end, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []nodeRow
+	if err := m.telemetryStore.ClickhouseDB().Select(ctx, &nodes, nodesStmt.Query, nodesStmt.Args...); err != nil {
+		return nil, wrapQueryError(err)
+	}
+
+	var edges []edgeRow
+	if err := m.telemetryStore.ClickhouseDB().Select(ctx, &edges, edgesStmt.Query, edgesStmt.Args...); err != nil {
+		return nil, wrapQueryError(err)
+	}
+
+	return newPeriod(nodes, edges, end-start), nil
+}
+
+// wrapQueryError turns a query stopped at maxSpansToRead into an invalid input error, the
+// period or the filter having to be narrowed for the topology to be computed.
+func wrapQueryError(err error) error {
+	var exception *clickhouse.Exception
+	if errors.As(err, &exception) && chproto.Error(exception.Code) == chproto.ErrTooManyRows {
+		return errors.WrapInvalidInputf(err, servicetopologytypes.ErrCodeTopologyTooManySpans, "the period holds more than %d spans, narrow it or add a filter", maxSpansToRead)
+	}
+	return err
+}
+
+func newPeriod(nodes []nodeRow, edges []edgeRow, periodMillis uint64) *period {
+	result := &period{
+		nodes: make(map[nodeKey]servicetopologytypes.Stats, len(nodes)),
+		edges: make(map[edgeKey]servicetopologytypes.Stats, len(edges)),
+	}
+	for _, row := range nodes {
+		if row.Name == "" {
+			continue
+		}
+		result.nodes[nodeKey{name: row.Name, nodeType: row.Type}] = servicetopologytypes.NewStats(row.CallCount, row.ErrorCount, row.Quantiles, periodMillis)
+	}
+	for _, row := range edges {
+		if row.Source == "" || row.Target == "" {
+			continue
+		}
+		key := edgeKey{
+			source: nodeKey{name: row.Source, nodeType: row.SourceType},
+			target: nodeKey{name: row.Target, nodeType: row.TargetType},
+		}
+		result.edges[key] = servicetopologytypes.NewStats(row.CallCount, row.ErrorCount, row.Quantiles, periodMillis)
+		// Nodes only seen as callers, such as services without entry spans, are added without calls.
+		for _, node := range []nodeKey{key.source, key.target} {
+			if _, ok := result.nodes[node]; !ok {
+				result.nodes[node] = servicetopologytypes.Stats{}
+			}
+		}
+	}
+	return result
+}
+
+// newGettableTopology builds the response from the current period and, when set, attaches
+// to every node and edge of either period its change against the previous one.
+func newGettableTopology(current, previous *period) *servicetopologytypes.GettableTopology {
+	result := &servicetopologytypes.GettableTopology{
+		Nodes: make([]*servicetopologytypes.Node, 0, len(current.nodes)),
+		Edges: make([]*servicetopologytypes.Edge, 0, len(current.edges)),
+	}
+
+	for key, stats := range current.nodes {
+		node := &servicetopologytypes.Node{Name: key.name, Type: servicetopologytypes.NodeType{String: valuer.NewString(key.nodeType)}, Stats: stats}
+		if previous != nil {
+			var previousStats *servicetopologytypes.Stats
+			if value, ok := previous.nodes[key]; ok {
+				previousStats = &value
+			}
+			node.Change = servicetopologytypes.NewChange(&stats, previousStats)
+		}
+		result.Nodes = append(result.Nodes, node)
+	}
+
+	for key, stats := range current.edges {
+		edge := newEdge(key, stats)
+		if previous != nil {
+			var previousStats *servicetopologytypes.Stats
+			if value, ok := previous.edges[key]; ok {
+				previousStats = &value
+			}
+			edge.Change = servicetopologytypes.NewChange(&stats, previousStats)
+		}
+		result.Edges = append(result.Edges, edge)
+	}
+
+	if previous != nil {
+		for key, stats := range previous.nodes {
+			if _, ok := current.nodes[key]; ok {
+				continue
+			}
+			result.Nodes = append(result.Nodes, &servicetopologytypes.Node{
+				Name:   key.name,
+				Type:   servicetopologytypes.NodeType{String: valuer.NewString(key.nodeType)},
+				Change: servicetopologytypes.NewChange(nil, &stats),
+			})
+		}
+		for key, stats := range previous.edges {
+			if _, ok := current.edges[key]; ok {
+				continue
+			}
+			edge := newEdge(key, servicetopologytypes.Stats{})
+			edge.Change = servicetopologytypes.NewChange(nil, &stats)
+			result.Edges = append(result.Edges, edge)
+		}
+	}
+
+	slices.SortFunc(result.Nodes, func(a, b *servicetopologytypes.Node) int {
+		if c := strings.Compare(a.Type.StringValue(), b.Type.StringValue()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(result.Edges, func(a, b *servicetopologytypes.Edge) int {
+		if c := strings.Compare(a.Source, b.Source); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Target, b.Target); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.SourceType.StringValue(), b.SourceType.StringValue()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.TargetType.StringValue(), b.TargetType.StringValue())
+	})
+	return result
+}
+
+func newEdge(key edgeKey, stats servicetopologytypes.Stats) *servicetopologytypes.Edge {
+	return &servicetopologytypes.Edge{
+		Source:     key.source.name,
+		SourceType: servicetopologytypes.NodeType{String: valuer.NewString(key.source.nodeType)},
+		Target:     key.target.name,
+		TargetType: servicetopologytypes.NodeType{String: valuer.NewString(key.target.nodeType)},
+		Stats:      stats,
+	}
+}
@@ -0,0 +1,134 @@
+package implservicetopology
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/servicetopologytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPeriod(t *testing.T) {
+	nodes := []nodeRow{
+		{Name: "frontend", Type: "service", CallCount: 120, ErrorCount: 6, Quantiles: []float64{10, 20, 30}},
+		{Name: "", Type: "service", CallCount: 5},
+	}
+	edges := []edgeRow{
+		{Source: "frontend", SourceType: "service", Target: "cart", TargetType: "service", CallCount: 60, ErrorCount: 3, Quantiles: []float64{1, 2, 3}},
+		{Source: "cart", SourceType: "service", Target: "redis", TargetType: "database", CallCount: 30},
+		{Source: "cart", SourceType: "service", Target: "", TargetType: "", CallCount: 30},
+	}
+
+	got := newPeriod(nodes, edges, 60_000)
+
+	require.Len(t, got.nodes, 3)
+	frontend := got.nodes[nodeKey{name: "frontend", nodeType: "service"}]
+	assert.Equal(t, uint64(120), frontend.CallCount)
+	assert.InDelta(t, 2.0, frontend.RequestRate, 1e-9)
+	assert.InDelta(t, 5.0, frontend.ErrorRate, 1e-9)
+	assert.Equal(t, 30.0, frontend.P99Nano)
+
+	// Nodes only seen on edges are added without calls.
+	assert.Equal(t, servicetopologytypes.Stats{}, got.nodes[nodeKey{name: "cart", nodeType: "service"}])
+	assert.Contains(t, got.nodes, nodeKey{name: "redis", nodeType: "database"})
+
+	require.Len(t, got.edges, 2)
+	edge := got.edges[edgeKey{source: nodeKey{name: "frontend", nodeType: "service"}, target: nodeKey{name: "cart", nodeType: "service"}}]
+	assert.InDelta(t, 1.0, edge.RequestRate, 1e-9)
+	assert.InDelta(t, 5.0, edge.ErrorRate, 1e-9)
+}
+
+func TestNewGettableTopology(t *testing.T) {
+	current := newPeriod(
+		[]nodeRow{
+			{Name: "frontend", Type: "service", CallCount: 100, Quantiles: []float64{10, 20, 30}},
+			{Name: "cart", Type: "service", CallCount: 50, Quantiles: []float64{5, 6, 7}},
+		},
+		[]edgeRow{
+			{Source: "frontend", SourceType: "service", Target: "cart", TargetType: "service", CallCount: 50, ErrorCount: 5, Quantiles: []float64{5, 6, 7}},
+			{Source: "cart", SourceType: "service", Target: "orders", TargetType: "messaging", CallCount: 10},
+		},
+		10_000,
+	)
+	previous := newPeriod(
+		[]nodeRow{
+			{Name: "frontend", Type: "service", CallCount: 50, Quantiles: []float64{8, 16, 24}},
+			{Name: "cart", Type: "service", CallCount: 50, Quantiles: []float64{5, 6, 7}},
+		},
+		[]edgeRow{
+			{Source: "frontend", SourceType: "service", Target: "cart", TargetType: "service", CallCount: 50, Quantiles: []float64{5, 6, 7}},
+			{Source: "cart", SourceType: "service", Target: "postgresql", TargetType: "database", CallCount: 10},
+		},
+		10_000,
+	)
+
+	t.Run("current_only", func(t *testing.T) {
+		got := newGettableTopology(current, nil)
+
+		require.Len(t, got.Nodes, 3)
+		assert.Equal(t, "orders", got.Nodes[0].Name)
+		assert.Equal(t, servicetopologytypes.NodeTypeMessaging, got.Nodes[0].Type)
+		assert.Equal(t, "cart", got.Nodes[1].Name)
+		assert.Equal(t, "frontend", got.Nodes[2].Name)
+
+		require.Len(t, got.Edges, 2)
+		assert.Equal(t, "cart", got.Edges[0].Source)
+		assert.Equal(t, "frontend", got.Edges[1].Source)
+		for _, node := range got.Nodes {
+			assert.Nil(t, node.Change)
+		}
+		for _, edge := range got.Edges {
+			assert.Nil(t, edge.Change)
+		}
+	})
+
+	t.Run("compare_with_previous", func(t *testing.T) {
+		got := newGettableTopology(current, previous)
+
+		nodes := make(map[string]*servicetopologytypes.Node)
+		for _, node := range got.Nodes {
+			nodes[node.Name] = node
+		}
+		require.Len(t, nodes, 4)
+
+		frontend := nodes["frontend"]
+		require.NotNil(t, frontend.Change)
+		assert.Equal(t, servicetopologytypes.ChangeStatusExisting, frontend.Change.Status)
+		assert.InDelta(t, 5.0, frontend.Change.RequestRateDelta, 1e-9)
+		assert.Equal(t, 6.0, frontend.Change.P99DeltaNano)
+
+		assert.Equal(t, servicetopologytypes.ChangeStatusAdded, nodes["orders"].Change.Status)
+
+		postgresql := nodes["postgresql"]
+		assert.Equal(t, servicetopologytypes.ChangeStatusRemoved, postgresql.Change.Status)
+		assert.Equal(t, servicetopologytypes.Stats{}, postgresql.Stats)
+
+		edges := make(map[string]*servicetopologytypes.Edge)
+		for _, edge := range got.Edges {
+			edges[edge.Source+"->"+edge.Target] = edge
+		}
+		require.Len(t, edges, 3)
+
+		frontendCart := edges["frontend->cart"]
+		assert.Equal(t, servicetopologytypes.ChangeStatusExisting, frontendCart.Change.Status)
+		assert.InDelta(t, 10.0, frontendCart.Change.ErrorRateDelta, 1e-9)
+		assert.Equal(t, 0.0, frontendCart.Change.P50DeltaNano)
+
+		assert.Equal(t, servicetopologytypes.ChangeStatusAdded, edges["cart->orders"].Change.Status)
+		removed := edges["cart->postgresql"]
+		assert.Equal(t, servicetopologytypes.ChangeStatusRemoved, removed.Change.Status)
+		assert.Equal(t, uint64(10), removed.Change.Previous.CallCount)
+		assert.InDelta(t, -1.0, removed.Change.RequestRateDelta, 1e-9)
+	})
+}
+
+func TestWrapQueryError(t *testing.T) {
+	err := wrapQueryError(&clickhouse.Exception{Code: 158, Message: "Limit for rows exceeded"})
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+	assert.True(t, errors.Asc(err, servicetopologytypes.ErrCodeTopologyTooManySpans))
+
+	other := &clickhouse.Exception{Code: 241, Message: "Memory limit exceeded"}
+	assert.Equal(t, error(other), wrapQueryError(other))
+}
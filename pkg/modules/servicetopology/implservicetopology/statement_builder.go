@@ -0,0 +1,277 @@
+package implservicetopology
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/semconv"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/tracestelemetryschema"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/servicetopologytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	spanKindServer   = 2
+	spanKindClient   = 3
+	spanKindProducer = 4
+	spanKindConsumer = 5
+
+	serviceNameColumn = "resource_string_service$$$$name" // $ gets escaped so $$$$ converts to $$.
+
+	// maxSpansToRead caps the spans a node or edge query reads. Past it the query fails
+	// rather than return stats, and rates, covering only part of the period.
+	maxSpansToRead = 100_000_000
+)
+
+// querySettings bounds the reads and the memory of the node and edge queries, the latter
+// like the other trace aggregations.
+var querySettings = fmt.Sprintf(" SETTINGS max_rows_to_read = %d, read_overflow_mode = 'throw', max_memory_usage = 10000000000", maxSpansToRead)
+
+// Attributes naming the peer of a client, producer or consumer span. Each list holds the
+// current name first followed by the names used by older semantic conventions; families
+// known to pkg/semconv are expanded with their members.
+var (
+	dbSystemAttributes             = []string{"db.system.name", "db.system"}
+	messagingSystemAttributes      = []string{"messaging.system"}
+	messagingDestinationAttributes = []string{"messaging.destination.name", "messaging.destination"}
+	peerServiceAttributes          = []string{"service.peer.name", "peer.service"}
+)
+
+// statementBuilder builds the node and edge queries of the topology. Both read a common
+// __spans CTE holding the spans of the matching traces along with the peer, if any, they
+// point at, and a __span_families CTE holding every span with its children. A CTE is read
+// again wherever it is used, so each query uses __span_families once and reads the spans of
+// the period a single time.
+type statementBuilder struct {
+	metadataStore telemetrytypes.MetadataStore
+	fieldMapper   qbtypes.FieldMapper
+	condBuilder   qbtypes.ConditionBuilder
+	fl            flagger.Flagger
+	logger        *slog.Logger
+}
+
+func newStatementBuilder(metadataStore telemetrytypes.MetadataStore, fl flagger.Flagger, logger *slog.Logger) *statementBuilder {
+	fieldMapper := tracestelemetryschema.NewFieldMapper(fl)
+	return &statementBuilder{
+		metadataStore: metadataStore,
+		fieldMapper:   fieldMapper,
+		condBuilder:   tracestelemetryschema.NewConditionBuilder(fieldMapper, fl),
+		fl:            fl,
+		logger:        logger,
+	}
+}
+
+// Build returns the node and edge statements for the given period in epoch milliseconds.
+func (b *statementBuilder) Build(ctx context.Context, orgID valuer.UUID, start, end uint64, filter *qbtypes.Filter) (*qbtypes.Statement, *qbtypes.Statement, error) {
+	startNs, endNs := querybuilder.ToNanoSecs(start), querybuilder.ToNanoSecs(end)
+
+	spansSQL, spansArgs, err := b.buildSpansCTE(ctx, orgID, startNs, endNs, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctes := []string{
+		fmt.Sprintf("__spans AS (%s)", spansSQL),
+		fmt.Sprintf("__span_families AS (%s)", buildSpanFamiliesCTE()),
+	}
+
+	nodes := &qbtypes.Statement{Query: querybuilder.CombineCTEs(ctes) + buildNodesQuery() + querySettings, Args: spansArgs}
+	edges := &qbtypes.Statement{Query: querybuilder.CombineCTEs(ctes) + buildEdgesQuery() + querySettings, Args: spansArgs}
+	return nodes, edges, nil
+}
+
+func (b *statementBuilder) buildSpansCTE(ctx context.Context, orgID valuer.UUID, startNs, endNs uint64, filter *qbtypes.Filter) (string, []any, error) {
+	dbSystem := attributeExpr(dbSystemAttributes)
+	messagingSystem := attributeExpr(messagingSystemAttributes)
+	messagingDestination := attributeExpr(messagingDestinationAttributes)
+	peerService := attributeExpr(peerServiceAttributes)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		tracestelemetryschema.SpanTraceIDColumn,
+		tracestelemetryschema.SpanSpanIDColumn,
+		tracestelemetryschema.SpanParentSpanIDColumn,
+		tracestelemetryschema.SpanKindColumn,
+		fmt.Sprintf("%s AS service_name", serviceNameColumn),
+		tracestelemetryschema.SpanDurationNanoColumn,
+		tracestelemetryschema.SpanHasErrorColumn,
+		fmt.Sprintf(
+			"multiIf(%s != '', %s, %s != '', if(%s != '', %s, %s), %s) AS peer_name",
+			dbSystem, dbSystem, messagingSystem, messagingDestination, messagingDestination, messagingSystem, peerService,
+		),
+		fmt.Sprintf(
+			"multiIf(%s != '', '%s', %s != '', '%s', %s != '', '%s', '') AS peer_type",
+			dbSystem, servicetopologytypes.NodeTypeDatabase.StringValue(),
+			messagingSystem, servicetopologytypes.NodeTypeMessaging.StringValue(),
+			peerService, servicetopologytypes.NodeTypeExternal.StringValue(),
+		),
+	)
+	sb.From(fmt.Sprintf("%s.%s", tracestelemetryschema.DBName, tracestelemetryschema.SpanIndexV3TableName))
+	addTimeFilter(sb, startNs, endNs)
+
+	traceFilter, err := b.buildTraceFilter(ctx, orgID, startNs, endNs, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	if traceFilter != nil {
+		sb.Where(fmt.Sprintf("%s GLOBAL IN (%s)", tracestelemetryschema.SpanTraceIDColumn, sb.Var(traceFilter)))
+	}
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return sql, args, nil
+}
+
+// buildTraceFilter returns the subquery selecting the traces with at least one span
+// matching the filter, or nil when there is no filter.
+func (b *statementBuilder) buildTraceFilter(ctx context.Context, orgID valuer.UUID, startNs, endNs uint64, filter *qbtypes.Filter) (*sqlbuilder.SelectBuilder, error) {
+	expression := ""
+	if filter != nil {
+		expression = strings.TrimSpace(filter.Expression)
+	}
+	if expression == "" {
+		return nil, nil
+	}
+
+	selectors := querybuilder.QueryStringToKeysSelectors(expression)
+	for idx := range selectors {
+		selectors[idx].Signal = telemetrytypes.SignalTraces
+		selectors[idx].SelectorMatchType = telemetrytypes.FieldSelectorMatchTypeExact
+	}
+
+	keys, _, err := b.metadataStore.GetKeysMulti(ctx, orgID, selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	whereClause, err := querybuilder.PrepareWhereClause(expression, querybuilder.FilterExprVisitorOpts{
+		Context:          ctx,
+		OrgID:            orgID,
+		Flagger:          b.fl,
+		Logger:           b.logger,
+		FieldMapper:      b.fieldMapper,
+		ConditionBuilder: b.condBuilder,
+		FieldKeys:        keys,
+		StartNs:          startNs,
+		EndNs:            endNs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if whereClause.IsEmpty() {
+		return nil, nil
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(fmt.Sprintf("DISTINCT %s", tracestelemetryschema.SpanTraceIDColumn))
+	sb.From(fmt.Sprintf("%s.%s", tracestelemetryschema.DBName, tracestelemetryschema.SpanIndexV3TableName))
+	sb.AddWhereClause(whereClause.WhereClause)
+	addTimeFilter(sb, startNs, endNs)
+	return sb, nil
+}
+
+// buildSpanFamiliesCTE groups every span with its children in a single read of __spans
+// instead of joining it to itself: each span is keyed by its own id, where it brings its
+// columns, and by its parent's, where it joins the children of the parent. A group whose
+// span is outside the period has children but no span.
+func buildSpanFamiliesCTE() string {
+	return "SELECT trace_id, if(role = 0, span_id, parent_span_id) AS id, countIf(role = 0) > 0 AS has_span, " +
+		"anyIf(parent_span_id, role = 0) AS span_parent_span_id, anyIf(kind, role = 0) AS span_kind, anyIf(service_name, role = 0) AS span_service_name, " +
+		"anyIf(duration_nano, role = 0) AS span_duration_nano, anyIf(has_error, role = 0) AS span_has_error, " +
+		"anyIf(peer_name, role = 0) AS span_peer_name, anyIf(peer_type, role = 0) AS span_peer_type, " +
+		"groupArrayIf((service_name, duration_nano, has_error), role = 1) AS children " +
+		"FROM __spans ARRAY JOIN [0, 1] AS role WHERE role = 0 OR parent_span_id != '' GROUP BY trace_id, id"
+}
+
+// peerCallCondition matches the spans calling an uninstrumented peer: client spans without
+// children, whose callee is an instrumented service otherwise, and producer spans writing
+// to a messaging destination.
+func peerCallCondition() string {
+	return fmt.Sprintf(
+		"(span_kind = %d AND span_peer_type != '' AND empty(children)) OR (span_kind = %d AND span_peer_type = '%s')",
+		spanKindClient, spanKindProducer, servicetopologytypes.NodeTypeMessaging.StringValue(),
+	)
+}
+
+// buildNodesQuery aggregates the entry spans of every service, and the spans calling
+// every uninstrumented peer.
+func buildNodesQuery() string {
+	return fmt.Sprintf(
+		"SELECT name, type, count() AS call_count, countIf(has_error) AS error_count, quantiles(0.5, 0.95, 0.99)(duration_nano) AS quantiles FROM ("+
+			"SELECT call.1 AS name, call.2 AS type, call.3 AS duration_nano, call.4 AS has_error FROM __span_families ARRAY JOIN arrayConcat("+
+			"if(span_kind IN (%d, %d) OR span_parent_span_id = '', [(span_service_name, '%s', span_duration_nano, span_has_error)], []), "+
+			"if(%s, [(span_peer_name, span_peer_type, span_duration_nano, span_has_error)], [])"+
+			") AS call WHERE has_span"+
+			") GROUP BY name, type",
+		spanKindServer, spanKindConsumer, servicetopologytypes.NodeTypeService.StringValue(),
+		peerCallCondition(),
+	)
+}
+
+// buildEdgesQuery aggregates the calls between services, from a span to a child span of
+// another service, and the calls to and from uninstrumented peers. For calls between
+// services the latency and the error are taken from the client span when the parent is
+// one. Calls through a messaging destination are split into a producer and a consumer edge.
+func buildEdgesQuery() string {
+	service := servicetopologytypes.NodeTypeService.StringValue()
+	messaging := servicetopologytypes.NodeTypeMessaging.StringValue()
+	return fmt.Sprintf(
+		"SELECT source, source_type, target, target_type, count() AS call_count, countIf(has_error) AS error_count, quantiles(0.5, 0.95, 0.99)(duration_nano) AS quantiles FROM ("+
+			"SELECT call.1 AS source, call.2 AS source_type, call.3 AS target, call.4 AS target_type, call.5 AS duration_nano, call.6 AS has_error FROM __span_families ARRAY JOIN arrayConcat("+
+			"if(span_kind = %d AND span_peer_type = '%s', [], "+
+			"arrayMap(c -> (span_service_name, '%s', c.1, '%s', if(span_kind = %d, span_duration_nano, c.2), if(span_kind = %d, span_has_error, c.3)), arrayFilter(c -> c.1 != span_service_name, children))), "+
+			"if(%s, [(span_service_name, '%s', span_peer_name, span_peer_type, span_duration_nano, span_has_error)], []), "+
+			"if(span_kind = %d AND span_peer_type = '%s', [(span_peer_name, span_peer_type, span_service_name, '%s', span_duration_nano, span_has_error)], [])"+
+			") AS call WHERE has_span"+
+			") GROUP BY source, source_type, target, target_type",
+		spanKindProducer, messaging,
+		service, service, spanKindClient, spanKindClient,
+		peerCallCondition(), service,
+		spanKindConsumer, messaging, service,
+	)
+}
+
+func addTimeFilter(sb *sqlbuilder.SelectBuilder, startNs, endNs uint64) {
+	startBucket := startNs/querybuilder.NsToSeconds - querybuilder.BucketAdjustment
+	endBucket := endNs / querybuilder.NsToSeconds
+	sb.Where(
+		sb.GE(tracestelemetryschema.SpanTimestampColumn, fmt.Sprintf("%d", startNs)),
+		sb.L(tracestelemetryschema.SpanTimestampColumn, fmt.Sprintf("%d", endNs)),
+		sb.GE(tracestelemetryschema.SpanTimestampBucketStartColumn, startBucket),
+		sb.LE(tracestelemetryschema.SpanTimestampBucketStartColumn, endBucket),
+	)
+}
+
+// attributeExpr returns the value of the first non-empty span attribute among names and
+// the members of their semconv families.
+func attributeExpr(names []string) string {
+	var members []string
+	for _, name := range names {
+		for _, member := range semconv.Members(semconv.KindAttribute, telemetrytypes.FieldKeySelector{
+			Name:         name,
+			Signal:       telemetrytypes.SignalTraces,
+			FieldContext: telemetrytypes.FieldContextAttribute,
+		}) {
+			if !slices.Contains(members, member) {
+				members = append(members, member)
+			}
+		}
+	}
+
+	if len(members) == 1 {
+		return fmt.Sprintf("%s['%s']", tracestelemetryschema.SpanAttributesStringColumn, members[0])
+	}
+	values := make([]string, 0, len(members)+1)
+	for _, member := range members {
+		values = append(values, fmt.Sprintf("nullIf(%s['%s'], '')", tracestelemetryschema.SpanAttributesStringColumn, member))
+	}
+	values = append(values, "''")
+	return fmt.Sprintf("coalesce(%s)", strings.Join(values, ", "))
+}
@@ -0,0 +1,116 @@
+package implservicetopology
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/tracestelemetryschema"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	spansSelect     = `SELECT trace_id, span_id, parent_span_id, kind, resource_string_service$$name AS service_name, duration_nano, has_error, multiIf(coalesce(nullIf(attributes_string['db.system.name'], ''), nullIf(attributes_string['db.system'], ''), '') != '', coalesce(nullIf(attributes_string['db.system.name'], ''), nullIf(attributes_string['db.system'], ''), ''), attributes_string['messaging.system'] != '', if(coalesce(nullIf(attributes_string['messaging.destination.name'], ''), nullIf(attributes_string['messaging.destination'], ''), '') != '', coalesce(nullIf(attributes_string['messaging.destination.name'], ''), nullIf(attributes_string['messaging.destination'], ''), ''), attributes_string['messaging.system']), coalesce(nullIf(attributes_string['service.peer.name'], ''), nullIf(attributes_string['peer.service'], ''), '')) AS peer_name, multiIf(coalesce(nullIf(attributes_string['db.system.name'], ''), nullIf(attributes_string['db.system'], ''), '') != '', 'database', attributes_string['messaging.system'] != '', 'messaging', coalesce(nullIf(attributes_string['service.peer.name'], ''), nullIf(attributes_string['peer.service'], ''), '') != '', 'external', '') AS peer_type FROM signoz_traces.distributed_signoz_index_v3`
+	spanFamiliesCTE = `__span_families AS (SELECT trace_id, if(role = 0, span_id, parent_span_id) AS id, countIf(role = 0) > 0 AS has_span, anyIf(parent_span_id, role = 0) AS span_parent_span_id, anyIf(kind, role = 0) AS span_kind, anyIf(service_name, role = 0) AS span_service_name, anyIf(duration_nano, role = 0) AS span_duration_nano, anyIf(has_error, role = 0) AS span_has_error, anyIf(peer_name, role = 0) AS span_peer_name, anyIf(peer_type, role = 0) AS span_peer_type, groupArrayIf((service_name, duration_nano, has_error), role = 1) AS children FROM __spans ARRAY JOIN [0, 1] AS role WHERE role = 0 OR parent_span_id != '' GROUP BY trace_id, id)`
+	peerCall        = `(span_kind = 3 AND span_peer_type != '' AND empty(children)) OR (span_kind = 4 AND span_peer_type = 'messaging')`
+	nodesQuery      = `SELECT name, type, count() AS call_count, countIf(has_error) AS error_count, quantiles(0.5, 0.95, 0.99)(duration_nano) AS quantiles FROM (SELECT call.1 AS name, call.2 AS type, call.3 AS duration_nano, call.4 AS has_error FROM __span_families ARRAY JOIN arrayConcat(if(span_kind IN (2, 5) OR span_parent_span_id = '', [(span_service_name, 'service', span_duration_nano, span_has_error)], []), if(` + peerCall + `, [(span_peer_name, span_peer_type, span_duration_nano, span_has_error)], [])) AS call WHERE has_span) GROUP BY name, type` + settings
+	edgesQuery      = `SELECT source, source_type, target, target_type, count() AS call_count, countIf(has_error) AS error_count, quantiles(0.5, 0.95, 0.99)(duration_nano) AS quantiles FROM (SELECT call.1 AS source, call.2 AS source_type, call.3 AS target, call.4 AS target_type, call.5 AS duration_nano, call.6 AS has_error FROM __span_families ARRAY JOIN arrayConcat(if(span_kind = 4 AND span_peer_type = 'messaging', [], arrayMap(c -> (span_service_name, 'service', c.1, 'service', if(span_kind = 3, span_duration_nano, c.2), if(span_kind = 3, span_has_error, c.3)), arrayFilter(c -> c.1 != span_service_name, children))), if(` + peerCall + `, [(span_service_name, 'service', span_peer_name, span_peer_type, span_duration_nano, span_has_error)], []), if(span_kind = 5 AND span_peer_type = 'messaging', [(span_peer_name, span_peer_type, span_service_name, 'service', span_duration_nano, span_has_error)], [])) AS call WHERE has_span) GROUP BY source, source_type, target, target_type` + settings
+	settings        = ` SETTINGS max_rows_to_read = 100000000, read_overflow_mode = 'throw', max_memory_usage = 10000000000`
+	timeCondition   = "timestamp >= ? AND timestamp < ? AND ts_bucket_start >= ? AND ts_bucket_start <= ?"
+)
+
+func TestStatementBuilder_Build(t *testing.T) {
+	start, end := uint64(1747947419000), uint64(1747983448000)
+	timeArgs := []any{"1747947419000000000", "1747983448000000000", uint64(1747945619), uint64(1747983448)}
+
+	cases := []struct {
+		name         string
+		filter       *qbtypes.Filter
+		expectedCTEs string
+		expectedArgs []any
+	}{
+		{
+			name:         "no_filter",
+			filter:       nil,
+			expectedCTEs: "WITH __spans AS (" + spansSelect + " WHERE " + timeCondition + "), " + spanFamiliesCTE + " ",
+			expectedArgs: timeArgs,
+		},
+		{
+			name:         "empty_filter",
+			filter:       &qbtypes.Filter{Expression: "  "},
+			expectedCTEs: "WITH __spans AS (" + spansSelect + " WHERE " + timeCondition + "), " + spanFamiliesCTE + " ",
+			expectedArgs: timeArgs,
+		},
+		{
+			name:   "filter_selects_traces",
+			filter: &qbtypes.Filter{Expression: "service.name = 'frontend'"},
+			expectedCTEs: "WITH __spans AS (" + spansSelect + " WHERE " + timeCondition +
+				" AND trace_id GLOBAL IN (SELECT DISTINCT trace_id FROM signoz_traces.distributed_signoz_index_v3 WHERE (resource.`service.name`::String = ? AND resource.`service.name` IS NOT NULL) AND " + timeCondition + ")), " +
+				spanFamiliesCTE + " ",
+			expectedArgs: []any{"1747947419000000000", "1747983448000000000", uint64(1747945619), uint64(1747983448), "frontend", "1747947419000000000", "1747983448000000000", uint64(1747945619), uint64(1747983448)},
+		},
+	}
+
+	fl := flaggertest.New(t)
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = tracestelemetryschema.BuildCompleteFieldKeyMap(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	statementBuilder := newStatementBuilder(mockMetadataStore, fl, instrumentationtest.New().Logger())
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			nodes, edges, err := statementBuilder.Build(context.Background(), valuer.GenerateUUID(), start, end, c.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.expectedCTEs+nodesQuery, nodes.Query)
+			assert.Equal(t, c.expectedArgs, nodes.Args)
+			assert.Equal(t, c.expectedCTEs+edgesQuery, edges.Query)
+			assert.Equal(t, c.expectedArgs, edges.Args)
+		})
+	}
+}
+
+func TestStatementBuilder_BoundsSpansRead(t *testing.T) {
+	fl := flaggertest.New(t)
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = tracestelemetryschema.BuildCompleteFieldKeyMap(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	statementBuilder := newStatementBuilder(mockMetadataStore, fl, instrumentationtest.New().Logger())
+
+	// a day long period, whose spans every query reads once
+	end := uint64(1747983448000)
+	start := end - uint64(24*time.Hour/time.Millisecond)
+	nodes, edges, err := statementBuilder.Build(context.Background(), valuer.GenerateUUID(), start, end, nil)
+	require.NoError(t, err)
+
+	for _, stmt := range []*qbtypes.Statement{nodes, edges} {
+		assert.Contains(t, stmt.Query, "max_rows_to_read = 100000000")
+		assert.Contains(t, stmt.Query, "read_overflow_mode = 'throw'")
+		assert.Equal(t, 1, strings.Count(stmt.Query, "FROM __spans "))
+		assert.Equal(t, 1, strings.Count(stmt.Query, "FROM __span_families "))
+		assert.NotContains(t, stmt.Query, "JOIN __spans")
+	}
+}
+
+func TestStatementBuilder_InvalidFilter(t *testing.T) {
+	fl := flaggertest.New(t)
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = tracestelemetryschema.BuildCompleteFieldKeyMap(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	statementBuilder := newStatementBuilder(mockMetadataStore, fl, instrumentationtest.New().Logger())
+
+	_, _, err := statementBuilder.Build(context.Background(), valuer.GenerateUUID(), 1747947419000, 1747983448000, &qbtypes.Filter{Expression: "service.name = "})
+	require.Error(t, err)
+}
+
+func TestAttributeExpr(t *testing.T) {
+	assert.Equal(t, "attributes_string['messaging.system']", attributeExpr([]string{"messaging.system"}))
+	assert.Equal(t,
+		"coalesce(nullIf(attributes_string['db.system.name'], ''), nullIf(attributes_string['db.system'], ''), '')",
+		attributeExpr([]string{"db.system.name", "db.system"}),
+	)
+}
@@ -0,0 +1,19 @@
+package servicetopology
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/servicetopologytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module builds the service dependency graph from spans.
+type Module interface {
+	GetTopology(ctx context.Context, orgID valuer.UUID, req *servicetopologytypes.PostableTopology) (*servicetopologytypes.GettableTopology, error)
+}
+
+// Handler exposes the service topology module over HTTP.
+type Handler interface {
+	GetTopology(http.ResponseWriter, *http.Request)
+}
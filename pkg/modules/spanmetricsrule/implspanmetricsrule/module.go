@@ -0,0 +1,176 @@
+package implspanmetricsrule
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule"
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp"
+	"github.com/SigNoz/signoz/pkg/types/spanmetricsruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store                  spanmetricsruletypes.Store
+	telemetryMetadataStore telemetrytypes.MetadataStore
+	logger                 *slog.Logger
+
+	// mu serializes deployments, and keeps agent acknowledgements of a deployment from
+	// being handled before its rollout is recorded.
+	mu sync.Mutex
+}
+
+func NewModule(store spanmetricsruletypes.Store, telemetryMetadataStore telemetrytypes.MetadataStore, providerSettings factory.ProviderSettings) spanmetricsrule.Module {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/spanmetricsrule/implspanmetricsrule")
+	return &module{
+		store:                  store,
+		telemetryMetadataStore: telemetryMetadataStore,
+		logger:                 settings.Logger(),
+	}
+}
+
+func (module *module) List(ctx context.Context, orgID valuer.UUID) ([]*spanmetricsruletypes.SpanMetricsRule, error) {
+	return module.store.List(ctx, orgID)
+}
+
+func (module *module) Get(ctx context.Context, orgID, id valuer.UUID) (*spanmetricsruletypes.SpanMetricsRule, error) {
+	return module.store.Get(ctx, orgID, id)
+}
+
+func (module *module) Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *spanmetricsruletypes.PostableSpanMetricsRule) (*spanmetricsruletypes.SpanMetricsRule, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule := spanmetricsruletypes.NewSpanMetricsRule(orgID, createdBy, postable)
+	if err := module.checkCardinality(ctx, orgID, rule); err != nil {
+		return nil, err
+	}
+
+	if err := module.store.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (module *module) Update(ctx context.Context, orgID, id valuer.UUID, updatedBy string, postable *spanmetricsruletypes.PostableSpanMetricsRule) (*spanmetricsruletypes.SpanMetricsRule, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Update(postable, updatedBy)
+	if err := module.checkCardinality(ctx, orgID, rule); err != nil {
+		return nil, err
+	}
+
+	if err := module.store.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (module *module) Delete(ctx context.Context, orgID, id valuer.UUID) error {
+	return module.store.Delete(ctx, orgID, id)
+}
+
+func (module *module) Deploy(ctx context.Context, orgID valuer.UUID) (*spanmetricsruletypes.GettableRollout, error) {
+	module.mu.Lock()
+	defer module.mu.Unlock()
+
+	rules, err := module.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cardinality of the dimensions may have grown since the rules were saved.
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		if err := module.checkCardinality(ctx, orgID, rule); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, spanmetricsruletypes.ErrCodeSpanMetricsRuleCardinality, "span metrics rule %q cannot be deployed", rule.Name)
+		}
+	}
+
+	latest, err := module.store.ListLatestRollouts(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	version := spanmetricsruletypes.NewGettableRollout(latest).Version + 1
+
+	config := spanmetricsruletypes.NewProcessorConfig(rules)
+	updateTracePipelineSpec(orgID, config)
+	processors := map[string]interface{}{
+		opamp.SpanMetricsProcessorName: config,
+	}
+
+	deployments, err := opamp.UpsertControlProcessorsForOrg(ctx, orgID, "traces", processors, module.onConfigUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts := make([]*spanmetricsruletypes.AgentRollout, 0, len(deployments))
+	for _, deployment := range deployments {
+		rollouts = append(rollouts, spanmetricsruletypes.NewAgentRollout(orgID, version, deployment.AgentID, deployment.ConfigHash, deployment.Err))
+	}
+
+	if err := module.store.CreateRollouts(ctx, rollouts); err != nil {
+		return nil, err
+	}
+	return spanmetricsruletypes.NewGettableRollout(rollouts), nil
+}
+
+func (module *module) GetRollout(ctx context.Context, orgID valuer.UUID) (*spanmetricsruletypes.GettableRollout, error) {
+	rollouts, err := module.store.ListLatestRollouts(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return spanmetricsruletypes.NewGettableRollout(rollouts), nil
+}
+
+// onConfigUpdate records the outcome reported by an agent for a deployed config.
+func (module *module) onConfigUpdate(orgID valuer.UUID, agentID string, hash string, err error) {
+	module.mu.Lock()
+	defer module.mu.Unlock()
+
+	status, message := spanmetricsruletypes.RolloutStatusDeployed, "Deployment was successful"
+	if err != nil {
+		status, message = spanmetricsruletypes.RolloutStatusFailed, err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := module.store.UpdateRolloutStatus(ctx, orgID, agentID, spanmetricsruletypes.EncodeConfigHash(hash), status, message); err != nil {
+		module.logger.ErrorContext(ctx, "failed to update span metrics rule rollout status", "org_id", orgID, "agent_id", agentID, errors.Attr(err))
+	}
+}
+
+func (module *module) checkCardinality(ctx context.Context, orgID valuer.UUID, rule *spanmetricsruletypes.SpanMetricsRule) error {
+	start := time.Now().Add(-spanmetricsruletypes.CardinalityLookback).UnixMilli()
+	cardinalities, err := module.telemetryMetadataStore.FetchSpanFieldCardinalityMulti(ctx, orgID, start, rule.CardinalityKeys()...)
+	if err != nil {
+		return err
+	}
+	return spanmetricsruletypes.CheckCardinality(rule.Dimensions, cardinalities)
+}
+
+// updateTracePipelineSpec adds the span metrics processor to the traces pipeline of the agents of
+// the org when the config has rules to apply, and removes it when none are left.
+func updateTracePipelineSpec(orgID valuer.UUID, config *spanmetricsruletypes.ProcessorConfig) {
+	if len(config.Rules) == 0 {
+		opamp.RemoveFromTracePipelineSpecForOrg(orgID, opamp.SpanMetricsProcessorName)
+		return
+	}
+	opamp.AddToTracePipelineSpecForOrg(orgID, opamp.SpanMetricsProcessorName)
+}
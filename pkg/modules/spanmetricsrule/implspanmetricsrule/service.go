@@ -0,0 +1,58 @@
+package implspanmetricsrule
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule"
+	"github.com/SigNoz/signoz/pkg/types/spanmetricsruletypes"
+)
+
+type service struct {
+	settings factory.ScopedProviderSettings
+	store    spanmetricsruletypes.Store
+	stopC    chan struct{}
+}
+
+func NewService(providerSettings factory.ProviderSettings, store spanmetricsruletypes.Store) spanmetricsrule.Service {
+	return &service{
+		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/spanmetricsrule/implspanmetricsrule"),
+		store:    store,
+		stopC:    make(chan struct{}),
+	}
+}
+
+func (s *service) Start(ctx context.Context) error {
+	// The pipeline spec lives in memory; without this a restart would drop the processor from
+	// the traces pipeline of an org on its next traces deployment, e.g. of the sampling rules.
+	if err := s.restoreTracePipelineSpec(ctx); err != nil {
+		s.settings.Logger().ErrorContext(ctx, "failed to restore the span metrics processor of deployed rules", errors.Attr(err))
+	}
+
+	<-s.stopC
+	return nil
+}
+
+// restoreTracePipelineSpec adds the span metrics processor to the traces pipeline of every org
+// whose rules were deployed and still have rules to apply.
+func (s *service) restoreTracePipelineSpec(ctx context.Context) error {
+	orgIDs, err := s.store.ListRolloutOrgIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, orgID := range orgIDs {
+		rules, err := s.store.List(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		updateTracePipelineSpec(orgID, spanmetricsruletypes.NewProcessorConfig(rules))
+	}
+	return nil
+}
+
+func (s *service) Stop(ctx context.Context) error {
+	close(s.stopC)
+	return nil
+}
@@ -0,0 +1,175 @@
+package implspanmetricsrule
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/spanmetricsruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) spanmetricsruletypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (s *store) List(ctx context.Context, orgID valuer.UUID) ([]*spanmetricsruletypes.SpanMetricsRule, error) {
+	rules := make([]*spanmetricsruletypes.SpanMetricsRule, 0)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&rules).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *store) Get(ctx context.Context, orgID, id valuer.UUID) (*spanmetricsruletypes.SpanMetricsRule, error) {
+	rule := new(spanmetricsruletypes.SpanMetricsRule)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(rule).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, s.sqlstore.WrapNotFoundErrf(err, spanmetricsruletypes.ErrCodeSpanMetricsRuleNotFound, "span metrics rule %s not found", id)
+	}
+	return rule, nil
+}
+
+func (s *store) Create(ctx context.Context, rule *spanmetricsruletypes.SpanMetricsRule) error {
+	_, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(rule).
+		Exec(ctx)
+	if err != nil {
+		return s.sqlstore.WrapAlreadyExistsErrf(err, spanmetricsruletypes.ErrCodeSpanMetricsRuleAlreadyExists, "span metrics rule %q already exists", rule.Name)
+	}
+	return nil
+}
+
+func (s *store) Update(ctx context.Context, rule *spanmetricsruletypes.SpanMetricsRule) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(rule).
+		Where("org_id = ?", rule.OrgID).
+		Where("id = ?", rule.ID).
+		Exec(ctx)
+	if err != nil {
+		return s.sqlstore.WrapAlreadyExistsErrf(err, spanmetricsruletypes.ErrCodeSpanMetricsRuleAlreadyExists, "span metrics rule %q already exists", rule.Name)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, spanmetricsruletypes.ErrCodeSpanMetricsRuleNotFound, "span metrics rule %s not found", rule.ID)
+	}
+	return nil
+}
+
+func (s *store) Delete(ctx context.Context, orgID, id valuer.UUID) error {
+	res, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model((*spanmetricsruletypes.SpanMetricsRule)(nil)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.Newf(errors.TypeNotFound, spanmetricsruletypes.ErrCodeSpanMetricsRuleNotFound, "span metrics rule %s not found", id)
+	}
+	return nil
+}
+
+func (s *store) CreateRollouts(ctx context.Context, rollouts []*spanmetricsruletypes.AgentRollout) error {
+	if len(rollouts) == 0 {
+		return nil
+	}
+
+	_, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(&rollouts).
+		Exec(ctx)
+	return err
+}
+
+func (s *store) ListLatestRollouts(ctx context.Context, orgID valuer.UUID) ([]*spanmetricsruletypes.AgentRollout, error) {
+	rollouts := make([]*spanmetricsruletypes.AgentRollout, 0)
+
+	latest := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model((*spanmetricsruletypes.AgentRollout)(nil)).
+		ColumnExpr("MAX(version)").
+		Where("org_id = ?", orgID)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&rollouts).
+		Where("org_id = ?", orgID).
+		Where("version = (?)", latest).
+		Order("agent_id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rollouts, nil
+}
+
+func (s *store) UpdateRolloutStatus(ctx context.Context, orgID valuer.UUID, agentID, configHash string, status spanmetricsruletypes.RolloutStatus, message string) error {
+	_, err := s.sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model((*spanmetricsruletypes.AgentRollout)(nil)).
+		Set("status = ?", status).
+		Set("message = ?", message).
+		Set("updated_at = ?", time.Now()).
+		Where("org_id = ?", orgID).
+		Where("agent_id = ?", agentID).
+		Where("config_hash = ?", configHash).
+		Where("status = ?", spanmetricsruletypes.RolloutStatusInProgress).
+		Exec(ctx)
+	return err
+}
+
+func (s *store) ListRolloutOrgIDs(ctx context.Context) ([]valuer.UUID, error) {
+	orgIDs := make([]valuer.UUID, 0)
+
+	err := s.sqlstore.
+		BunDB().
+		NewSelect().
+		Model((*spanmetricsruletypes.AgentRollout)(nil)).
+		ColumnExpr("DISTINCT org_id").
+		Scan(ctx, &orgIDs)
+	if err != nil {
+		return nil, err
+	}
+	return orgIDs, nil
+}
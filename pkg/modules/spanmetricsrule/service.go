@@ -0,0 +1,9 @@
+package spanmetricsrule
+
+import "github.com/SigNoz/signoz/pkg/factory"
+
+// Service restores the span metrics processor in the traces pipeline of every org whose rules were
+// deployed on startup, so that other traces deployments keep it in the collector config.
+type Service interface {
+	factory.Service
+}
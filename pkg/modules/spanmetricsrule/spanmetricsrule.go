@@ -0,0 +1,36 @@
+package spanmetricsrule
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/spanmetricsruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module defines the business logic for span metrics rules, which configure the RED metrics
+// the collectors generate from spans.
+type Module interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*spanmetricsruletypes.SpanMetricsRule, error)
+	Get(ctx context.Context, orgID, id valuer.UUID) (*spanmetricsruletypes.SpanMetricsRule, error)
+	Create(ctx context.Context, orgID valuer.UUID, createdBy string, rule *spanmetricsruletypes.PostableSpanMetricsRule) (*spanmetricsruletypes.SpanMetricsRule, error)
+	Update(ctx context.Context, orgID, id valuer.UUID, updatedBy string, rule *spanmetricsruletypes.PostableSpanMetricsRule) (*spanmetricsruletypes.SpanMetricsRule, error)
+	Delete(ctx context.Context, orgID, id valuer.UUID) error
+
+	// Deploy pushes the enabled rules of the org to its collectors and starts tracking the
+	// rollout on every agent.
+	Deploy(ctx context.Context, orgID valuer.UUID) (*spanmetricsruletypes.GettableRollout, error)
+	// GetRollout returns the per-agent status of the latest deployment.
+	GetRollout(ctx context.Context, orgID valuer.UUID) (*spanmetricsruletypes.GettableRollout, error)
+}
+
+// Handler defines the HTTP handler interface for span metrics rule endpoints.
+type Handler interface {
+	List(rw http.ResponseWriter, r *http.Request)
+	Get(rw http.ResponseWriter, r *http.Request)
+	Create(rw http.ResponseWriter, r *http.Request)
+	Update(rw http.ResponseWriter, r *http.Request)
+	Delete(rw http.ResponseWriter, r *http.Request)
+	Deploy(rw http.ResponseWriter, r *http.Request)
+	GetRollout(rw http.ResponseWriter, r *http.Request)
+}
@@ -1,13 +1,17 @@
 package tracedetail
 
 import (
+	"time"
+
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
 type Config struct {
-	Waterfall  WaterfallConfig  `mapstructure:"waterfall"`
-	Flamegraph FlamegraphConfig `mapstructure:"flamegraph"`
+	Waterfall    WaterfallConfig    `mapstructure:"waterfall"`
+	Flamegraph   FlamegraphConfig   `mapstructure:"flamegraph"`
+	Comparison   ComparisonConfig   `mapstructure:"comparison"`
+	CriticalPath CriticalPathConfig `mapstructure:"critical_path"`
 }
 
 type FlamegraphConfig struct {
@@ -27,6 +31,20 @@ type WaterfallConfig struct {
 	MaxLimitToSelectAllSpans uint `mapstructure:"max_limit_to_select_all_spans"`
 }
 
+type ComparisonConfig struct {
+	// MaxSpansPerTrace is the maximum number of spans a trace can have to be compared.
+	MaxSpansPerTrace uint `mapstructure:"max_spans_per_trace"`
+}
+
+type CriticalPathConfig struct {
+	// TimeBudget is the maximum time spent computing critical paths for a request.
+	TimeBudget time.Duration `mapstructure:"time_budget"`
+	// MaxSpansPerTrace is the maximum number of spans a trace can have to be analysed.
+	MaxSpansPerTrace uint `mapstructure:"max_spans_per_trace"`
+	// DefaultTraces is the number of traces aggregated when the request does not set a limit.
+	DefaultTraces int `mapstructure:"default_traces"`
+}
+
 func NewConfigFactory() factory.ConfigFactory {
 	return factory.NewConfigFactory(factory.MustNewName("traces"), newConfig)
 }
@@ -45,6 +63,14 @@ func newConfig() factory.Config {
 			SamplingBucketCount:          50,
 			SelectAllSpansLimit:          100_000,
 		},
+		Comparison: ComparisonConfig{
+			MaxSpansPerTrace: 100_000,
+		},
+		CriticalPath: CriticalPathConfig{
+			TimeBudget:       10 * time.Second,
+			MaxSpansPerTrace: 100_000,
+			DefaultTraces:    100,
+		},
 	}
 }
 
@@ -73,5 +99,17 @@ func (c Config) Validate() error {
 	if c.Flamegraph.SelectAllSpansLimit == 0 {
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "traces.flamegraph.select_all_spans_limit must be positive")
 	}
+	if c.Comparison.MaxSpansPerTrace == 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "traces.comparison.max_spans_per_trace must be positive")
+	}
+	if c.CriticalPath.TimeBudget <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "traces.critical_path.time_budget must be positive, got %v", c.CriticalPath.TimeBudget)
+	}
+	if c.CriticalPath.MaxSpansPerTrace == 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "traces.critical_path.max_spans_per_trace must be positive")
+	}
+	if c.CriticalPath.DefaultTraces <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "traces.critical_path.default_traces must be positive, got %d", c.CriticalPath.DefaultTraces)
+	}
 	return nil
 }
@@ -0,0 +1,323 @@
+// Package impltracedetail tests — trace comparison
+//
+// # Background
+//
+// Comparing a slow trace with a normal one needs the two span trees to be
+// aligned even though span IDs never match across traces. Spans are aligned by
+// their path of (service name, operation name) from the root; siblings sharing
+// the same service and operation collapse into a single node of the merged tree.
+//
+// # Key concepts
+//
+// status
+//
+//	matched  — the node appears the same number of times in both traces.
+//	added    — the node only appears in the compared trace.
+//	missing  — the node only appears in the base trace.
+//	repeated — the node appears in both traces a different number of times.
+//
+// self time
+//
+//	The part of a span's duration not covered by any of its children. Overlapping
+//	children are merged so parallel work is not subtracted twice.
+
+package impltracedetail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/spantypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+var comparisonEpoch = time.Unix(1_700_000_000, 0)
+
+// mkMinimalSpan builds a span starting startMs after the epoch and lasting durationMs.
+func mkMinimalSpan(id, parentID, service, name string, startMs, durationMs int64) spantypes.MinimalSpan {
+	return spantypes.MinimalSpan{
+		SpanID:       id,
+		ParentSpanID: parentID,
+		ServiceName:  service,
+		Name:         name,
+		StartTime:    comparisonEpoch.Add(time.Duration(startMs) * time.Millisecond),
+		DurationNano: uint64(durationMs * int64(time.Millisecond)),
+	}
+}
+
+func ms(v int64) uint64 {
+	return uint64(v * int64(time.Millisecond))
+}
+
+// flattenComparison returns "service:name" → node for every node of the merged tree.
+// Paths are unique in the fixtures below so the flat map is unambiguous.
+func flattenComparison(nodes []*spantypes.TraceComparisonNode) map[string]*spantypes.TraceComparisonNode {
+	result := map[string]*spantypes.TraceComparisonNode{}
+	var walk func([]*spantypes.TraceComparisonNode)
+	walk = func(nodes []*spantypes.TraceComparisonNode) {
+		for _, n := range nodes {
+			result[n.ServiceName+":"+n.Name] = n
+			walk(n.Children)
+		}
+	}
+	walk(nodes)
+	return result
+}
+
+func comparisonChildNames(node *spantypes.TraceComparisonNode) []string {
+	names := make([]string, len(node.Children))
+	for i, c := range node.Children {
+		names[i] = c.ServiceName + ":" + c.Name
+	}
+	return names
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NewGettableTraceComparison — structure
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCompareTraces_Structure(t *testing.T) {
+	// base                              compare
+	//	frontend:GET /checkout            frontend:GET /checkout
+	//	  ├─ cart:GetCart                   ├─ cart:GetCart
+	//	  └─ payment:Charge                 ├─ cart:GetCart        (repeated)
+	//	       └─ fraud:Check  (missing)    ├─ payment:Charge
+	//	                                    │    └─ bank:Authorize (added)
+	//	                                    └─ ...
+	base := []spantypes.MinimalSpan{
+		mkMinimalSpan("b-root", "", "frontend", "GET /checkout", 0, 100),
+		mkMinimalSpan("b-cart", "b-root", "cart", "GetCart", 10, 20),
+		mkMinimalSpan("b-pay", "b-root", "payment", "Charge", 40, 50),
+		mkMinimalSpan("b-fraud", "b-pay", "fraud", "Check", 45, 10),
+	}
+	compare := []spantypes.MinimalSpan{
+		mkMinimalSpan("c-root", "", "frontend", "GET /checkout", 0, 300),
+		mkMinimalSpan("c-cart1", "c-root", "cart", "GetCart", 10, 20),
+		mkMinimalSpan("c-cart2", "c-root", "cart", "GetCart", 40, 20),
+		mkMinimalSpan("c-pay", "c-root", "payment", "Charge", 70, 200),
+		mkMinimalSpan("c-bank", "c-pay", "bank", "Authorize", 80, 150),
+	}
+
+	result := spantypes.NewGettableTraceComparison("base", base, "compare", compare)
+
+	require.Len(t, result.Roots, 1)
+	root := result.Roots[0]
+	assert.Equal(t, spantypes.ComparisonNodeStatusMatched, root.Status)
+	assert.Equal(t, []string{"cart:GetCart", "payment:Charge"}, comparisonChildNames(root))
+
+	nodes := flattenComparison(result.Roots)
+	assert.Equal(t, spantypes.ComparisonNodeStatusRepeated, nodes["cart:GetCart"].Status)
+	assert.Equal(t, uint64(1), nodes["cart:GetCart"].BaseCount)
+	assert.Equal(t, uint64(2), nodes["cart:GetCart"].CompareCount)
+	assert.Equal(t, spantypes.ComparisonNodeStatusMatched, nodes["payment:Charge"].Status)
+	assert.Equal(t, spantypes.ComparisonNodeStatusMissing, nodes["fraud:Check"].Status)
+	assert.Equal(t, spantypes.ComparisonNodeStatusAdded, nodes["bank:Authorize"].Status)
+
+	assert.Equal(t, uint64(1), result.AddedCount)
+	assert.Equal(t, uint64(1), result.MissingCount)
+	assert.Equal(t, uint64(1), result.RepeatedCount)
+	assert.Equal(t, uint64(4), result.BaseSpansCount)
+	assert.Equal(t, uint64(5), result.CompareSpansCount)
+}
+
+func TestCompareTraces_Levels(t *testing.T) {
+	base := []spantypes.MinimalSpan{
+		mkMinimalSpan("r", "", "svc", "root", 0, 30),
+		mkMinimalSpan("c", "r", "svc", "child", 0, 20),
+		mkMinimalSpan("g", "c", "svc", "grandchild", 0, 10),
+	}
+
+	result := spantypes.NewGettableTraceComparison("base", base, "compare", base)
+
+	nodes := flattenComparison(result.Roots)
+	assert.Equal(t, uint64(0), nodes["svc:root"].Level)
+	assert.Equal(t, uint64(1), nodes["svc:child"].Level)
+	assert.Equal(t, uint64(2), nodes["svc:grandchild"].Level)
+	for _, n := range nodes {
+		assert.Equal(t, spantypes.ComparisonNodeStatusMatched, n.Status)
+		assert.Equal(t, int64(0), n.TotalTimeDeltaNano)
+		assert.Equal(t, int64(0), n.SelfTimeDeltaNano)
+	}
+	assert.Zero(t, result.AddedCount+result.MissingCount+result.RepeatedCount)
+}
+
+func TestCompareTraces_SameOperationUnderDifferentParents(t *testing.T) {
+	// The same (service, operation) under different parents are different paths.
+	//
+	//	root
+	//	  ├─ a → db:query
+	//	  └─ b → db:query
+	spans := []spantypes.MinimalSpan{
+		mkMinimalSpan("r", "", "svc", "root", 0, 100),
+		mkMinimalSpan("a", "r", "svc", "a", 0, 40),
+		mkMinimalSpan("aq", "a", "db", "query", 0, 10),
+		mkMinimalSpan("b", "r", "svc", "b", 50, 40),
+		mkMinimalSpan("bq", "b", "db", "query", 50, 10),
+	}
+	compare := spans[:4] // drop db:query under b
+
+	result := spantypes.NewGettableTraceComparison("base", spans, "compare", compare)
+
+	require.Len(t, result.Roots, 1)
+	require.Len(t, result.Roots[0].Children, 2)
+	a, b := result.Roots[0].Children[0], result.Roots[0].Children[1]
+	require.Len(t, a.Children, 1)
+	require.Len(t, b.Children, 1)
+	assert.Equal(t, spantypes.ComparisonNodeStatusMatched, a.Children[0].Status)
+	assert.Equal(t, spantypes.ComparisonNodeStatusMissing, b.Children[0].Status)
+	assert.Equal(t, uint64(1), result.MissingCount)
+}
+
+func TestCompareTraces_MissingParentBecomesRoot(t *testing.T) {
+	// Spans whose parent was not recorded are compared as roots.
+	base := []spantypes.MinimalSpan{
+		mkMinimalSpan("orphan", "not-recorded", "svc", "op", 0, 10),
+	}
+	compare := []spantypes.MinimalSpan{
+		mkMinimalSpan("orphan", "also-not-recorded", "svc", "op", 0, 15),
+	}
+
+	result := spantypes.NewGettableTraceComparison("base", base, "compare", compare)
+
+	require.Len(t, result.Roots, 1)
+	assert.Equal(t, spantypes.ComparisonNodeStatusMatched, result.Roots[0].Status)
+	assert.Equal(t, int64(ms(5)), result.Roots[0].TotalTimeDeltaNano)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NewGettableTraceComparison — latency
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCompareTraces_SelfAndTotalTime(t *testing.T) {
+	tests := []struct {
+		name            string
+		spans           []spantypes.MinimalSpan
+		wantRootSelf    uint64
+		wantRootTotal   uint64
+		wantTraceLength uint64
+	}{
+		{
+			// root 0–100, child 10–30: self = 100 - 20.
+			name: "single_child",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("r", "", "svc", "root", 0, 100),
+				mkMinimalSpan("c", "r", "svc", "child", 10, 20),
+			},
+			wantRootSelf:    ms(80),
+			wantRootTotal:   ms(100),
+			wantTraceLength: ms(100),
+		},
+		{
+			// Parallel children 10–50 and 30–70 overlap; covered = 10–70.
+			name: "overlapping_children",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("r", "", "svc", "root", 0, 100),
+				mkMinimalSpan("c1", "r", "svc", "child1", 10, 40),
+				mkMinimalSpan("c2", "r", "svc", "child2", 30, 40),
+			},
+			wantRootSelf:    ms(40),
+			wantRootTotal:   ms(100),
+			wantTraceLength: ms(100),
+		},
+		{
+			// An async child outliving its parent only counts up to the parent's end.
+			name: "child_outlives_parent",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("r", "", "svc", "root", 0, 100),
+				mkMinimalSpan("c", "r", "svc", "child", 80, 100),
+			},
+			wantRootSelf:    ms(80),
+			wantRootTotal:   ms(100),
+			wantTraceLength: ms(180),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := spantypes.NewGettableTraceComparison("base", tt.spans, "compare", nil)
+
+			require.Len(t, result.Roots, 1)
+			root := result.Roots[0]
+			assert.Equal(t, spantypes.ComparisonNodeStatusMissing, root.Status)
+			assert.Equal(t, tt.wantRootSelf, root.BaseSelfTimeNano)
+			assert.Equal(t, tt.wantRootTotal, root.BaseTotalTimeNano)
+			assert.Equal(t, -int64(tt.wantRootSelf), root.SelfTimeDeltaNano)
+			assert.Equal(t, tt.wantTraceLength, result.BaseDurationNano)
+		})
+	}
+}
+
+func TestCompareTraces_Deltas(t *testing.T) {
+	// The slow trace spends its extra time in the payment service itself,
+	// not in its downstream call, which self-time delta makes visible.
+	base := []spantypes.MinimalSpan{
+		mkMinimalSpan("r", "", "frontend", "checkout", 0, 100),
+		mkMinimalSpan("p", "r", "payment", "Charge", 10, 50),
+		mkMinimalSpan("b", "p", "bank", "Authorize", 20, 30),
+	}
+	compare := []spantypes.MinimalSpan{
+		mkMinimalSpan("r", "", "frontend", "checkout", 0, 400),
+		mkMinimalSpan("p", "r", "payment", "Charge", 10, 350),
+		mkMinimalSpan("b", "p", "bank", "Authorize", 20, 30),
+	}
+
+	result := spantypes.NewGettableTraceComparison("base", base, "compare", compare)
+
+	assert.Equal(t, int64(ms(300)), result.DurationDeltaNano)
+
+	nodes := flattenComparison(result.Roots)
+	payment := nodes["payment:Charge"]
+	assert.Equal(t, int64(ms(300)), payment.TotalTimeDeltaNano)
+	assert.Equal(t, ms(20), payment.BaseSelfTimeNano)
+	assert.Equal(t, ms(320), payment.CompareSelfTimeNano)
+	assert.Equal(t, int64(ms(300)), payment.SelfTimeDeltaNano)
+
+	bank := nodes["bank:Authorize"]
+	assert.Equal(t, int64(0), bank.TotalTimeDeltaNano)
+	assert.Equal(t, int64(0), bank.SelfTimeDeltaNano)
+
+	frontend := nodes["frontend:checkout"]
+	assert.Equal(t, int64(0), frontend.SelfTimeDeltaNano)
+}
+
+func TestCompareTraces_RepeatedAggregatesDurations(t *testing.T) {
+	// N+1 queries: the same query issued three times instead of once.
+	base := []spantypes.MinimalSpan{
+		mkMinimalSpan("r", "", "api", "list", 0, 50),
+		mkMinimalSpan("q", "r", "db", "SELECT", 10, 10),
+	}
+	compare := []spantypes.MinimalSpan{
+		mkMinimalSpan("r", "", "api", "list", 0, 50),
+		mkMinimalSpan("q1", "r", "db", "SELECT", 5, 10),
+		mkMinimalSpan("q2", "r", "db", "SELECT", 20, 10),
+		mkMinimalSpan("q3", "r", "db", "SELECT", 35, 10),
+	}
+
+	result := spantypes.NewGettableTraceComparison("base", base, "compare", compare)
+
+	query := flattenComparison(result.Roots)["db:SELECT"]
+	assert.Equal(t, spantypes.ComparisonNodeStatusRepeated, query.Status)
+	assert.Equal(t, ms(10), query.BaseTotalTimeNano)
+	assert.Equal(t, ms(30), query.CompareTotalTimeNano)
+	assert.Equal(t, int64(ms(20)), query.TotalTimeDeltaNano)
+
+	root := flattenComparison(result.Roots)["api:list"]
+	assert.Equal(t, -int64(ms(20)), root.SelfTimeDeltaNano)
+}
+
+func TestCompareTraces_ErrorCounts(t *testing.T) {
+	base := []spantypes.MinimalSpan{mkMinimalSpan("r", "", "svc", "op", 0, 10)}
+	compare := []spantypes.MinimalSpan{mkMinimalSpan("r", "", "svc", "op", 0, 10)}
+	compare[0].HasError = true
+
+	result := spantypes.NewGettableTraceComparison("base", base, "compare", compare)
+
+	require.Len(t, result.Roots, 1)
+	assert.Equal(t, uint64(0), result.Roots[0].BaseErrorCount)
+	assert.Equal(t, uint64(1), result.Roots[0].CompareErrorCount)
+}
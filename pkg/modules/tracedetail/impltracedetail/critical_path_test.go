@@ -0,0 +1,231 @@
+// Package impltracedetail tests — critical path
+//
+// # Background
+//
+// The critical path is the chain of spans, or parts of spans, that determined
+// the end-to-end latency of a trace. Walking back from the end of the root span,
+// a span is blocked on the child that finished last; time not covered by such a
+// child is attributed to the span itself. Children running in parallel with the
+// blocking child are not on the critical path.
+
+package impltracedetail
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/spantypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// criticalPathSegment renders a segment as "spanID:startMs-endMs" relative to the epoch.
+func criticalPathSegment(s *spantypes.CriticalPathSegment) string {
+	start := (s.StartTimeUnixNano - uint64(comparisonEpoch.UnixNano())) / uint64(time.Millisecond)
+	return fmt.Sprintf("%s:%d-%d", s.SpanID, start, start+s.DurationNano/uint64(time.Millisecond))
+}
+
+func criticalPathSegments(path *spantypes.GettableTraceCriticalPath) []string {
+	segments := make([]string, len(path.Segments))
+	for i, s := range path.Segments {
+		segments[i] = criticalPathSegment(s)
+	}
+	return segments
+}
+
+func newTestBudget() *spantypes.CriticalPathBudget {
+	return spantypes.NewCriticalPathBudget(time.Minute)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NewGettableTraceCriticalPath — segments
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCriticalPath_Segments(t *testing.T) {
+	tests := []struct {
+		name         string
+		spans        []spantypes.MinimalSpan
+		wantSegments []string
+	}{
+		{
+			// Sequential children are all on the path, with the gaps attributed to the parent.
+			//
+			//	root  0────────────────────100
+			//	A       10────40
+			//	B                 50────90
+			name: "sequential_children",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("root", "", "svc", "root", 0, 100),
+				mkMinimalSpan("A", "root", "svc", "a", 10, 30),
+				mkMinimalSpan("B", "root", "svc", "b", 50, 40),
+			},
+			wantSegments: []string{"root:0-10", "A:10-40", "root:40-50", "B:50-90", "root:90-100"},
+		},
+		{
+			// Of two parallel children only the one finishing last is on the path.
+			//
+			//	root  0────────────────────100
+			//	A       10──────60
+			//	B       10──────────────90
+			name: "parallel_children",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("root", "", "svc", "root", 0, 100),
+				mkMinimalSpan("A", "root", "svc", "a", 10, 50),
+				mkMinimalSpan("B", "root", "svc", "b", 10, 80),
+			},
+			wantSegments: []string{"root:0-10", "B:10-90", "root:90-100"},
+		},
+		{
+			// A child overlapping the blocking one contributes the part before it.
+			//
+			//	root  0────────────────────100
+			//	A       10──────────80
+			//	C          20────────70
+			//	B                50──────90
+			name: "overlapping_children",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("root", "", "svc", "root", 0, 100),
+				mkMinimalSpan("A", "root", "svc", "a", 10, 70),
+				mkMinimalSpan("C", "A", "svc", "c", 20, 50),
+				mkMinimalSpan("B", "root", "svc", "b", 50, 40),
+			},
+			wantSegments: []string{"root:0-10", "A:10-20", "C:20-50", "B:50-90", "root:90-100"},
+		},
+		{
+			// An async child outliving its parent is clipped to the parent's end.
+			name: "child_outlives_parent",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("root", "", "svc", "root", 0, 100),
+				mkMinimalSpan("A", "root", "svc", "a", 80, 100),
+			},
+			wantSegments: []string{"root:0-80", "A:80-100"},
+		},
+		{
+			// With several roots the one ending last is walked.
+			name: "multiple_roots",
+			spans: []spantypes.MinimalSpan{
+				mkMinimalSpan("early", "", "svc", "early", 0, 50),
+				mkMinimalSpan("late", "missing-parent", "svc", "late", 10, 90),
+			},
+			wantSegments: []string{"late:10-100"},
+		},
+		{
+			name:         "single_span",
+			spans:        []spantypes.MinimalSpan{mkMinimalSpan("root", "", "svc", "root", 0, 100)},
+			wantSegments: []string{"root:0-100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := spantypes.NewGettableTraceCriticalPath("trace", tt.spans, newTestBudget())
+			assert.Equal(t, tt.wantSegments, criticalPathSegments(path))
+			assert.False(t, path.Truncated)
+		})
+	}
+}
+
+func TestCriticalPath_EmptyTrace(t *testing.T) {
+	path := spantypes.NewGettableTraceCriticalPath("trace", nil, newTestBudget())
+	assert.Empty(t, path.Segments)
+	assert.Empty(t, path.Services)
+	assert.Empty(t, path.Operations)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NewGettableTraceCriticalPath — contributions
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCriticalPath_Contributions(t *testing.T) {
+	//	frontend:checkout  0────────────────────100
+	//	cart:GetCart         10────40
+	//	payment:Charge                50────90
+	//	payment:Validate              50─60 (parallel with Charge)
+	spans := []spantypes.MinimalSpan{
+		mkMinimalSpan("root", "", "frontend", "checkout", 0, 100),
+		mkMinimalSpan("cart", "root", "cart", "GetCart", 10, 30),
+		mkMinimalSpan("charge", "root", "payment", "Charge", 50, 40),
+		mkMinimalSpan("validate", "root", "payment", "Validate", 50, 10),
+	}
+
+	path := spantypes.NewGettableTraceCriticalPath("trace", spans, newTestBudget())
+
+	assert.Equal(t, ms(100), path.DurationNano)
+	assert.Equal(t, ms(100), path.CriticalPathDurationNano)
+
+	require.Len(t, path.Services, 3)
+	assert.Equal(t, "payment", path.Services[0].ServiceName)
+	assert.Equal(t, ms(40), path.Services[0].DurationNano)
+	assert.InDelta(t, 40.0, path.Services[0].Percentage, 0.001)
+	assert.Equal(t, "cart", path.Services[1].ServiceName)
+	assert.Equal(t, "frontend", path.Services[2].ServiceName)
+	assert.Equal(t, ms(30), path.Services[2].DurationNano)
+	assert.Equal(t, uint64(1), path.Services[2].TraceCount)
+
+	require.Len(t, path.Operations, 3)
+	for _, op := range path.Operations {
+		assert.NotEqual(t, "Validate", op.Name)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NewGettableCriticalPathAggregation
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCriticalPath_Aggregation(t *testing.T) {
+	first := spantypes.NewGettableTraceCriticalPath("t1", []spantypes.MinimalSpan{
+		mkMinimalSpan("root", "", "frontend", "checkout", 0, 100),
+		mkMinimalSpan("db", "root", "db", "SELECT", 0, 75),
+	}, newTestBudget())
+	second := spantypes.NewGettableTraceCriticalPath("t2", []spantypes.MinimalSpan{
+		mkMinimalSpan("root", "", "frontend", "checkout", 0, 300),
+	}, newTestBudget())
+
+	aggregation := spantypes.NewGettableCriticalPathAggregation([]*spantypes.GettableTraceCriticalPath{first, second}, 1, false)
+
+	assert.Equal(t, uint64(2), aggregation.TraceCount)
+	assert.Equal(t, uint64(1), aggregation.SkippedTraceCount)
+	assert.Equal(t, ms(400), aggregation.CriticalPathDurationNano)
+	assert.False(t, aggregation.Truncated)
+
+	require.Len(t, aggregation.Services, 2)
+	assert.Equal(t, "frontend", aggregation.Services[0].ServiceName)
+	assert.Equal(t, ms(325), aggregation.Services[0].DurationNano)
+	assert.Equal(t, uint64(2), aggregation.Services[0].TraceCount)
+	assert.InDelta(t, 81.25, aggregation.Services[0].Percentage, 0.001)
+	assert.Equal(t, "db", aggregation.Services[1].ServiceName)
+	assert.Equal(t, uint64(1), aggregation.Services[1].TraceCount)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Time budget
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCriticalPath_BudgetExceeded(t *testing.T) {
+	// A chain long enough for the budget to be checked during the walk.
+	n := 4096
+	spans := make([]spantypes.MinimalSpan, n)
+	for i := range n {
+		parent := ""
+		if i > 0 {
+			parent = fmt.Sprintf("span%d", i-1)
+		}
+		spans[i] = mkMinimalSpan(fmt.Sprintf("span%d", i), parent, "svc", "op", int64(i), int64(2*n-2*i))
+	}
+
+	path := spantypes.NewGettableTraceCriticalPath("trace", spans, spantypes.NewCriticalPathBudget(-time.Second))
+	assert.True(t, path.Truncated)
+	assert.NotEmpty(t, path.Segments)
+
+	aggregation := spantypes.NewGettableCriticalPathAggregation([]*spantypes.GettableTraceCriticalPath{path}, 0, false)
+	assert.True(t, aggregation.Truncated)
+
+	complete := spantypes.NewGettableTraceCriticalPath("trace", spans, newTestBudget())
+	assert.False(t, complete.Truncated)
+	assert.Equal(t, complete.DurationNano, complete.CriticalPathDurationNano)
+}
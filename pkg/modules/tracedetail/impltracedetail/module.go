@@ -4,21 +4,28 @@ import (
 	"context"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/tracedetail"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/spantypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"go.opentelemetry.io/otel/metric"
 )
 
 type module struct {
 	store    spantypes.TraceStore
+	querier  querier.Querier
 	settings factory.ScopedProviderSettings
 	config   tracedetail.Config
 	metrics  *moduleMetrics
 }
 
-func NewModule(traceStore spantypes.TraceStore, providerSettings factory.ProviderSettings, cfg tracedetail.Config) *module {
+func NewModule(traceStore spantypes.TraceStore, querier querier.Querier, providerSettings factory.ProviderSettings, cfg tracedetail.Config) *module {
 	scopedProviderSettings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/tracedetail/impltracedetail")
 
 	metrics, err := newModuleMetrics(scopedProviderSettings.Meter())
@@ -29,6 +36,7 @@ func NewModule(traceStore spantypes.TraceStore, providerSettings factory.Provide
 	m := &module{
 		config:   cfg,
 		store:    traceStore,
+		querier:  querier,
 		settings: scopedProviderSettings,
 		metrics:  metrics,
 	}
@@ -132,6 +140,184 @@ func (m *module) GetFlamegraph(ctx context.Context, traceID string, selectedSpan
 	return m.getWindowedFlamegraph(ctx, traceID, selectedSpanID, summary, selectFields)
 }
 
+// CompareTraces aligns the spans of two traces by service and operation path and
+// returns the merged tree with per-node count, self-time and total-time deltas.
+func (m *module) CompareTraces(ctx context.Context, baseTraceID string, compareTraceID string) (*spantypes.GettableTraceComparison, error) {
+	baseSpans, err := m.getComparisonSpans(ctx, baseTraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	compareSpans, err := m.getComparisonSpans(ctx, compareTraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return spantypes.NewGettableTraceComparison(baseTraceID, baseSpans, compareTraceID, compareSpans), nil
+}
+
+func (m *module) getComparisonSpans(ctx context.Context, traceID string) ([]spantypes.MinimalSpan, error) {
+	summary, err := m.store.GetTraceSummary(ctx, traceID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.NumSpans > uint64(m.config.Comparison.MaxSpansPerTrace) {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "trace %s has %d spans which exceeds the comparison limit of %d", traceID, summary.NumSpans, m.config.Comparison.MaxSpansPerTrace)
+	}
+
+	spans, err := m.store.GetNamedSpans(ctx, traceID, summary.Start, summary.End)
+	if err != nil {
+		return nil, err
+	}
+	if len(spans) == 0 {
+		return nil, spantypes.ErrTraceNotFound
+	}
+	return spans, nil
+}
+
+// GetCriticalPath returns the critical path of a trace, within the configured time budget.
+func (m *module) GetCriticalPath(ctx context.Context, traceID string) (*spantypes.GettableTraceCriticalPath, error) {
+	budget := spantypes.NewCriticalPathBudget(m.config.CriticalPath.TimeBudget)
+	return m.getCriticalPath(ctx, traceID, budget)
+}
+
+// GetAggregatedCriticalPath sums the critical path contributions of the slowest traces matching the filter.
+// Traces left once the time budget runs out, fetching or walking them, are skipped and the result is marked
+// as truncated.
+func (m *module) GetAggregatedCriticalPath(ctx context.Context, orgID valuer.UUID, req *spantypes.PostableCriticalPathAggregation) (*spantypes.GettableCriticalPathAggregation, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.CodeNamespace:    "tracedetail",
+		instrumentationtypes.CodeFunctionName: "GetAggregatedCriticalPath",
+	})
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = m.config.CriticalPath.DefaultTraces
+	}
+
+	budget := spantypes.NewCriticalPathBudget(m.config.CriticalPath.TimeBudget)
+	traceIDs, err := m.getSlowestTraceIDs(ctx, orgID, req, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// the fetches of the traces stop with the budget, not only their walks.
+	budgetCtx, cancel := context.WithDeadline(ctx, budget.Deadline())
+	defer cancel()
+
+	paths := make([]*spantypes.GettableTraceCriticalPath, 0, len(traceIDs))
+	truncated, skipped := false, uint64(0)
+	for _, traceID := range traceIDs {
+		if budget.Exceeded() {
+			truncated = true
+			break
+		}
+		path, err := m.getCriticalPath(budgetCtx, traceID, budget)
+		if err != nil {
+			if budgetCtx.Err() != nil && ctx.Err() == nil {
+				truncated = true
+				break
+			}
+			// the trace is too large or its spans are gone, leave it out of the aggregate.
+			if errors.Ast(err, errors.TypeNotFound) || errors.Ast(err, errors.TypeInvalidInput) {
+				skipped++
+				continue
+			}
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return spantypes.NewGettableCriticalPathAggregation(paths, skipped, truncated), nil
+}
+
+func (m *module) getCriticalPath(ctx context.Context, traceID string, budget *spantypes.CriticalPathBudget) (*spantypes.GettableTraceCriticalPath, error) {
+	summary, err := m.store.GetTraceSummary(ctx, traceID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.NumSpans > uint64(m.config.CriticalPath.MaxSpansPerTrace) {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "trace %s has %d spans which exceeds the critical path limit of %d", traceID, summary.NumSpans, m.config.CriticalPath.MaxSpansPerTrace)
+	}
+
+	spans, err := m.store.GetNamedSpans(ctx, traceID, summary.Start, summary.End)
+	if err != nil {
+		return nil, err
+	}
+	if len(spans) == 0 {
+		return nil, spantypes.ErrTraceNotFound
+	}
+
+	return spantypes.NewGettableTraceCriticalPath(traceID, spans, budget), nil
+}
+
+// getSlowestTraceIDs returns the IDs of the traces matching the filter which contain the longest spans.
+func (m *module) getSlowestTraceIDs(ctx context.Context, orgID valuer.UUID, req *spantypes.PostableCriticalPathAggregation, limit int) ([]string, error) {
+	query := qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+		Name:   "A",
+		Signal: telemetrytypes.SignalTraces,
+		Filter: req.Filter,
+		GroupBy: []qbtypes.GroupByKey{
+			{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+				Name:          "trace_id",
+				FieldContext:  telemetrytypes.FieldContextSpan,
+				FieldDataType: telemetrytypes.FieldDataTypeString,
+			}},
+		},
+		Aggregations: []qbtypes.TraceAggregation{
+			{Expression: "max(duration_nano)", Alias: "duration"},
+		},
+		Order: []qbtypes.OrderBy{
+			{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "duration"}}, Direction: qbtypes.OrderDirectionDesc},
+		},
+		Limit: limit,
+	}
+
+	queryRangeRequest := &qbtypes.QueryRangeRequest{
+		SchemaVersion: "v5",
+		Start:         req.Start,
+		End:           req.End,
+		RequestType:   qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{{Type: qbtypes.QueryTypeBuilder, Spec: query}},
+		},
+	}
+	if err := queryRangeRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := m.querier.QueryRange(ctx, orgID, queryRangeRequest)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Data.Results) == 0 {
+		return []string{}, nil
+	}
+
+	scalarData, ok := resp.Data.Results[0].(*qbtypes.ScalarData)
+	if !ok || scalarData == nil {
+		return nil, errors.NewInternalf(errors.CodeInternal, "unexpected result type %T", resp.Data.Results[0])
+	}
+
+	traceIDIdx := -1
+	for i, column := range scalarData.Columns {
+		if column.Type == qbtypes.ColumnTypeGroup && column.Name == "trace_id" {
+			traceIDIdx = i
+		}
+	}
+	if traceIDIdx == -1 {
+		return []string{}, nil
+	}
+
+	traceIDs := make([]string, 0, len(scalarData.Data))
+	for _, row := range scalarData.Data {
+		if traceID, ok := row[traceIDIdx].(string); ok && traceID != "" {
+			traceIDs = append(traceIDs, traceID)
+		}
+	}
+	return traceIDs, nil
+}
+
 // getWindowedWaterfall builds the waterfall tree with minimal data and then returns only a window of full spans.
 func (m *module) getWindowedWaterfall(ctx context.Context, traceID, selectedSpanID string, uncollapsedSpans []string, start, end time.Time) (*spantypes.GettableWaterfallTrace, error) {
 	// Step 1: minimal fetch → build full tree → select visible window
@@ -94,12 +94,22 @@ func (s *traceStore) GetTraceSpans(ctx context.Context, traceID string, summary
 }
 
 func (s *traceStore) GetMinimalSpans(ctx context.Context, traceID string, start, end time.Time) ([]spantypes.MinimalSpan, error) {
+	return s.getMinimalSpans(ctx, traceID, start, end)
+}
+
+func (s *traceStore) GetNamedSpans(ctx context.Context, traceID string, start, end time.Time) ([]spantypes.MinimalSpan, error) {
+	return s.getMinimalSpans(ctx, traceID, start, end, "name")
+}
+
+// getMinimalSpans reads the minimal spans of a trace, with the extra columns the caller needs.
+func (s *traceStore) getMinimalSpans(ctx context.Context, traceID string, start, end time.Time, extraColumns ...string) ([]spantypes.MinimalSpan, error) {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select(
 		"DISTINCT ON (span_id) span_id",
 		"parent_span_id", "timestamp", "duration_nano", "has_error",
 		colServiceName,
 	)
+	sb.SelectMore(extraColumns...)
 	sb.From(fmt.Sprintf("%s.%s", spantypes.TraceDB, spantypes.TraceTable))
 	sb.Where(
 		sb.E("trace_id", traceID),
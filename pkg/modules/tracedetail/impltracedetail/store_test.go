@@ -66,6 +66,18 @@ func TestGetMinimalSpans(t *testing.T) {
 	})
 }
 
+func TestGetNamedSpans(t *testing.T) {
+	expectedSQL := "SELECT DISTINCT ON (span_id) span_id, parent_span_id, timestamp, duration_nano, has_error, resource_string_service$$name, name FROM signoz_traces.distributed_signoz_index_v3 WHERE trace_id = ? AND ts_bucket_start >= ? AND ts_bucket_start <= ? ORDER BY timestamp ASC, name ASC"
+
+	t.Run("ValidRange_GeneratesExpectedSQL", func(t *testing.T) {
+		s := newTestStore(sqlmock.QueryMatcherRegexp)
+		s.Mock().ExpectSelect(regexp.QuoteMeta(expectedSQL)).
+			WillReturnRows(cmock.NewRows(nil, nil))
+		_, _ = s.Store().GetNamedSpans(context.Background(), testTraceID, testStart, testEnd)
+		assert.NoError(t, s.Mock().ExpectationsWereMet())
+	})
+}
+
 func TestGetSpanCountByField(t *testing.T) {
 	expectedSQL := "SELECT resource.`service.name`::String AS field_value, count(DISTINCT span_id) AS count FROM signoz_traces.distributed_signoz_index_v3 WHERE trace_id = ? AND ts_bucket_start >= ? AND ts_bucket_start <= ? AND notEmpty(resource.`service.name`::String) GROUP BY field_value"
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/types/spantypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 // Handler exposes HTTP handlers for trace detail APIs.
@@ -13,6 +14,9 @@ type Handler interface {
 	GetWaterfallV4(http.ResponseWriter, *http.Request)
 	GetTraceAggregations(http.ResponseWriter, *http.Request)
 	GetFlamegraph(http.ResponseWriter, *http.Request)
+	CompareTraces(http.ResponseWriter, *http.Request)
+	GetCriticalPath(http.ResponseWriter, *http.Request)
+	GetAggregatedCriticalPath(http.ResponseWriter, *http.Request)
 }
 
 // Module defines the business logic for trace detail operations.
@@ -20,4 +24,7 @@ type Module interface {
 	GetWaterfallV4(ctx context.Context, traceID string, selectedSpanID string, uncollapsedSpans []string) (*spantypes.GettableWaterfallTrace, error)
 	GetTraceAggregations(ctx context.Context, traceID string, req *spantypes.PostableTraceAggregations) (*spantypes.GettableTraceAggregations, error)
 	GetFlamegraph(ctx context.Context, traceID string, selectedSpanID string, selectFields []telemetrytypes.TelemetryFieldKey) (*spantypes.GettableFlamegraphTrace, error)
+	CompareTraces(ctx context.Context, baseTraceID string, compareTraceID string) (*spantypes.GettableTraceComparison, error)
+	GetCriticalPath(ctx context.Context, traceID string) (*spantypes.GettableTraceCriticalPath, error)
+	GetAggregatedCriticalPath(ctx context.Context, orgID valuer.UUID, req *spantypes.PostableCriticalPathAggregation) (*spantypes.GettableCriticalPathAggregation, error)
 }
@@ -12,23 +12,27 @@ import (
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
 	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/prometheus/prometheus/model/labels"
 	promValue "github.com/prometheus/prometheus/model/value"
 )
 
 // seriesLookup holds a series lookup's result: matched fingerprints with
-// their labels, and the distinct metric names seen on them.
+// their labels, and the distinct metric names seen on them, all of them and
+// those of exponential histograms.
 type seriesLookup struct {
-	fingerprints map[uint64]labels.Labels
-	metricNames  []string
+	fingerprints      map[uint64]labels.Labels
+	metricNames       []string
+	expHistogramNames []string
 }
 
 // client executes the series, samples and raw queries against ClickHouse.
 type client struct {
-	settings       factory.ScopedProviderSettings
-	telemetryStore telemetrystore.TelemetryStore
-	lookbackMs     int64
+	settings         factory.ScopedProviderSettings
+	telemetryStore   telemetrystore.TelemetryStore
+	lookbackMs       int64
+	nativeHistograms bool
 }
 
 func newClient(settings factory.ScopedProviderSettings, telemetryStore telemetrystore.TelemetryStore, cfg prometheus.Config) *client {
@@ -38,9 +42,10 @@ func newClient(settings factory.ScopedProviderSettings, telemetryStore telemetry
 		lookback = defaultLookbackDelta
 	}
 	return &client{
-		settings:       settings,
-		telemetryStore: telemetryStore,
-		lookbackMs:     lookback.Milliseconds(),
+		settings:         settings,
+		telemetryStore:   telemetryStore,
+		lookbackMs:       lookback.Milliseconds(),
+		nativeHistograms: cfg.NativeHistograms,
 	}
 }
 
@@ -62,11 +67,13 @@ func (c *client) selectSeries(ctx context.Context, query string, args []any) (*s
 
 	lookup := &seriesLookup{fingerprints: make(map[uint64]labels.Labels)}
 	names := make(map[string]struct{})
+	expHistogramNames := make(map[string]struct{})
 
 	var fingerprint uint64
 	var labelsJSON string
+	var metricType metrictypes.Type
 	for rows.Next() {
-		if err := rows.Scan(&fingerprint, &labelsJSON); err != nil {
+		if err := rows.Scan(&fingerprint, &labelsJSON, &metricType); err != nil {
 			return nil, err
 		}
 		lset, err := unmarshalLabels(labelsJSON)
@@ -76,20 +83,30 @@ func (c *client) selectSeries(ctx context.Context, query string, args []any) (*s
 		lookup.fingerprints[fingerprint] = lset
 		if name := lset.Get(metricNameLabel); name != "" {
 			names[name] = struct{}{}
+			if metricType == metrictypes.ExpHistogramType {
+				expHistogramNames[name] = struct{}{}
+			}
 		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	for name := range names {
-		lookup.metricNames = append(lookup.metricNames, name)
-	}
-	slices.Sort(lookup.metricNames)
+	lookup.metricNames = sortedKeys(names)
+	lookup.expHistogramNames = sortedKeys(expHistogramNames)
 
 	return lookup, nil
 }
 
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 // unmarshalLabels parses the labels JSON column, dropping empty-valued
 // labels: empty means "absent" in Prometheus, but stored attribute JSON can
 // carry them.
@@ -178,3 +195,64 @@ func (c *client) selectSamples(ctx context.Context, query string, args []any, lo
 
 	return result, nil
 }
+
+// selectExpHistogramSamples assembles per-series native histogram samples
+// from an exponential histogram samples query, whose rows arrive ordered by
+// (fingerprint, unix_milli). Fingerprints missing from the lookup are skipped
+// as in selectSamples.
+func (c *client) selectExpHistogramSamples(ctx context.Context, query string, args []any, lookup *seriesLookup) ([]*series, error) {
+	ctx = c.withContext(ctx, "selectExpHistogramSamples")
+	rows, err := c.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		result       []*series
+		current      *series
+		fingerprint  uint64
+		prevFp       uint64
+		timestampMs  int64
+		point        expHistogramPoint
+		first        = true
+		haveCurrent  bool
+		unknownCount int
+	)
+
+	for rows.Next() {
+		if err := rows.Scan(&fingerprint, &timestampMs, &point.count, &point.sum, &point.quantiles); err != nil {
+			return nil, err
+		}
+
+		if first || fingerprint != prevFp {
+			first = false
+			prevFp = fingerprint
+			lset, ok := lookup.fingerprints[fingerprint]
+			if !ok {
+				unknownCount++
+				haveCurrent = false
+				continue
+			}
+			current = &series{lset: lset}
+			result = append(result, current)
+			haveCurrent = true
+		}
+		if !haveCurrent {
+			continue
+		}
+
+		current.ts = append(current.ts, timestampMs)
+		current.hs = append(current.hs, point.floatHistogram())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if unknownCount > 0 {
+		c.settings.Logger().DebugContext(ctx, "skipped exponential histogram points of fingerprints missing from series lookup",
+			slog.Int("unknown_fingerprints", unknownCount))
+	}
+
+	return result, nil
+}
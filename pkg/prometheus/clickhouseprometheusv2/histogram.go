@@ -0,0 +1,118 @@
+package clickhouseprometheusv2
+
+import (
+	"math"
+	"slices"
+
+	"github.com/prometheus/prometheus/model/histogram"
+)
+
+const (
+	// expHistogramZeroThreshold is the zero bucket width the OTLP translator
+	// of Prometheus gives exponential histograms: the points carry no
+	// threshold.
+	expHistogramZeroThreshold = 1e-128
+
+	// expHistogramSchema is the schema of the native histograms built from
+	// the sketches. Its buckets grow by 2^(1/32) ≈ 2.2%, about the bucket
+	// width of a sketch of 1% relative accuracy, so a finer schema would
+	// only spread the quantiles thinner.
+	expHistogramSchema = 5
+
+	// expHistogramQuantiles is the number of quantiles read off the sketch
+	// of every point; each of them stands for the same share of the count.
+	expHistogramQuantiles = 100
+)
+
+// expHistogramRanks returns the ranks the quantiles of a point are read at:
+// the midpoints of expHistogramQuantiles equal shares of the values.
+func expHistogramRanks() []float64 {
+	ranks := make([]float64, expHistogramQuantiles)
+	for i := range ranks {
+		ranks[i] = (float64(i) + 0.5) / expHistogramQuantiles
+	}
+	return ranks
+}
+
+// expHistogramPoint is one row of the exponential histogram samples query:
+// the count and sum of the point and the quantiles of its sketch at
+// expHistogramRanks.
+type expHistogramPoint struct {
+	count     uint64
+	sum       float64
+	quantiles []float64
+}
+
+// floatHistogram converts the point to a native histogram sample. The
+// exp_hist table keeps a DDSketch per point instead of its OTLP buckets, so
+// the buckets are rebuilt from the quantiles: every quantile adds its share
+// of the count to the bucket of expHistogramSchema it falls in. Percentiles
+// of the result are as precise as the quantiles read, 1/expHistogramQuantiles
+// of the rank.
+func (p *expHistogramPoint) floatHistogram() *histogram.FloatHistogram {
+	h := &histogram.FloatHistogram{
+		CounterResetHint: histogram.UnknownCounterReset,
+		Schema:           expHistogramSchema,
+		ZeroThreshold:    expHistogramZeroThreshold,
+		Count:            float64(p.count),
+		Sum:              p.sum,
+	}
+
+	values := slices.DeleteFunc(slices.Clone(p.quantiles), math.IsNaN)
+	if p.count == 0 || len(values) == 0 {
+		return h
+	}
+
+	share := float64(p.count) / float64(len(values))
+	positive := make(map[int32]float64)
+	negative := make(map[int32]float64)
+	for _, v := range values {
+		switch {
+		case math.Abs(v) <= expHistogramZeroThreshold:
+			h.ZeroCount += share
+		case v > 0:
+			positive[nativeHistogramBucketIndex(v)] += share
+		default:
+			negative[nativeHistogramBucketIndex(-v)] += share
+		}
+	}
+	h.PositiveSpans, h.PositiveBuckets = nativeHistogramBuckets(positive)
+	h.NegativeSpans, h.NegativeBuckets = nativeHistogramBuckets(negative)
+	return h
+}
+
+// nativeHistogramBucketIndex returns the index of the bucket of
+// expHistogramSchema holding the positive value v: bucket i holds
+// (base^(i-1), base^i] with base = 2^(2^-schema).
+func nativeHistogramBucketIndex(v float64) int32 {
+	return int32(math.Ceil(math.Log2(v) * (1 << expHistogramSchema)))
+}
+
+// nativeHistogramBuckets turns the counts per bucket index into the spans
+// and absolute counts of a float histogram, one span per run of adjacent
+// buckets.
+func nativeHistogramBuckets(counts map[int32]float64) ([]histogram.Span, []float64) {
+	if len(counts) == 0 {
+		return nil, nil
+	}
+	indexes := make([]int32, 0, len(counts))
+	for i := range counts {
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+
+	var spans []histogram.Span
+	buckets := make([]float64, 0, len(indexes))
+	for n, i := range indexes {
+		switch {
+		case n == 0:
+			spans = append(spans, histogram.Span{Offset: i, Length: 1})
+		case i == indexes[n-1]+1:
+			spans[len(spans)-1].Length++
+		default:
+			spans = append(spans, histogram.Span{Offset: i - indexes[n-1] - 1, Length: 1})
+		}
+		buckets = append(buckets, counts[i])
+	}
+	return spans, buckets
+}
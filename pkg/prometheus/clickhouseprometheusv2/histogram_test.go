@@ -0,0 +1,64 @@
+package clickhouseprometheusv2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpHistogramRanks(t *testing.T) {
+	ranks := expHistogramRanks()
+	require.Len(t, ranks, expHistogramQuantiles)
+	assert.InDelta(t, 0.005, ranks[0], 1e-12)
+	assert.InDelta(t, 0.995, ranks[len(ranks)-1], 1e-12)
+}
+
+func TestExpHistogramPointFloatHistogram(t *testing.T) {
+	t.Run("quantiles fill the buckets they fall in", func(t *testing.T) {
+		// 1 is the upper bound of bucket 0, 2 of bucket 32; 2.01 falls in 33.
+		quantiles := make([]float64, 0, 100)
+		for range 50 {
+			quantiles = append(quantiles, 1)
+		}
+		for range 25 {
+			quantiles = append(quantiles, 2)
+		}
+		for range 25 {
+			quantiles = append(quantiles, 2.01)
+		}
+		p := &expHistogramPoint{count: 200, sum: 301, quantiles: quantiles}
+
+		h := p.floatHistogram()
+		assert.Equal(t, int32(expHistogramSchema), h.Schema)
+		assert.Equal(t, float64(200), h.Count)
+		assert.Equal(t, float64(301), h.Sum)
+		assert.Equal(t, []histogram.Span{{Offset: 0, Length: 1}, {Offset: 31, Length: 2}}, h.PositiveSpans)
+		assert.Equal(t, []float64{100, 50, 50}, h.PositiveBuckets)
+		assert.Empty(t, h.NegativeBuckets)
+		require.NoError(t, h.Validate())
+	})
+
+	t.Run("negative and zero values", func(t *testing.T) {
+		p := &expHistogramPoint{count: 4, sum: -2, quantiles: []float64{-1, -1, 0, 0}}
+
+		h := p.floatHistogram()
+		assert.Equal(t, float64(2), h.ZeroCount)
+		assert.Equal(t, []histogram.Span{{Offset: 0, Length: 1}}, h.NegativeSpans)
+		assert.Equal(t, []float64{2}, h.NegativeBuckets)
+		assert.Empty(t, h.PositiveBuckets)
+		require.NoError(t, h.Validate())
+	})
+
+	t.Run("empty sketch has no buckets", func(t *testing.T) {
+		p := &expHistogramPoint{quantiles: []float64{math.NaN(), math.NaN()}}
+
+		h := p.floatHistogram()
+		assert.Equal(t, float64(0), h.Count)
+		assert.Empty(t, h.PositiveSpans)
+		assert.Empty(t, h.NegativeSpans)
+		require.NoError(t, h.Validate())
+	})
+}
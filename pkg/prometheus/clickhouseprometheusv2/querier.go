@@ -47,6 +47,13 @@ func (q *querier) Select(ctx context.Context, sortSeries bool, hints *storage.Se
 	if err != nil {
 		return storage.ErrSeriesSet(err)
 	}
+	if q.client.nativeHistograms && len(lookup.expHistogramNames) > 0 {
+		histograms, err := q.fetchExpHistogramSamples(ctx, start, end, matchers, lookup)
+		if err != nil {
+			return storage.ErrSeriesSet(err)
+		}
+		list = append(list, histograms...)
+	}
 
 	// The engine assumes storages never emit duplicate label sets.
 	list = sortAndMerge(list)
@@ -148,6 +155,16 @@ func (q *querier) fetchSamples(ctx context.Context, start, end int64, matchers [
 	return q.client.selectSamples(ctx, query, args, lookup)
 }
 
+// fetchExpHistogramSamples runs the exponential histogram samples query for
+// the matched series (see buildExpHistogramSamplesQuery).
+func (q *querier) fetchExpHistogramSamples(ctx context.Context, start, end int64, matchers []*labels.Matcher, lookup *seriesLookup) ([]*series, error) {
+	query, args, err := buildExpHistogramSamplesQuery(start, end, lookup.expHistogramNames, matchers)
+	if err != nil {
+		return nil, err
+	}
+	return q.client.selectExpHistogramSamples(ctx, query, args, lookup)
+}
+
 func (q *querier) selectStrings(ctx context.Context, fn, query string, args []any) ([]string, error) {
 	ctx = q.client.withContext(ctx, fn)
 	rows, err := q.client.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
@@ -11,13 +11,15 @@ import (
 )
 
 // series is one time series with samples as parallel slices ordered by
-// timestamp. Deliberately not storage.NewListSeries: that boxes every sample
-// as an interface value, a per-sample allocation this fetch path exists to
-// avoid.
+// timestamp: float samples in vs, or native histogram samples in hs for an
+// exponential histogram series. Deliberately not storage.NewListSeries: that
+// boxes every sample as an interface value, a per-sample allocation this
+// fetch path exists to avoid.
 type series struct {
 	lset labels.Labels
 	ts   []int64
 	vs   []float64
+	hs   []*histogram.FloatHistogram
 }
 
 var _ storage.Series = (*series)(nil)
@@ -27,37 +29,45 @@ func (s *series) Labels() labels.Labels {
 }
 
 func (s *series) Iterator(it chunkenc.Iterator) chunkenc.Iterator {
-	if fit, ok := it.(*floatIterator); ok {
-		fit.reset(s)
-		return fit
+	if sit, ok := it.(*sampleIterator); ok {
+		sit.reset(s)
+		return sit
 	}
-	fit := &floatIterator{}
-	fit.reset(s)
-	return fit
+	sit := &sampleIterator{}
+	sit.reset(s)
+	return sit
 }
 
-// floatIterator implements chunkenc.Iterator over a series' sample slices.
-type floatIterator struct {
+// sampleIterator implements chunkenc.Iterator over a series' sample slices.
+type sampleIterator struct {
 	s *series
 	i int
 }
 
-var _ chunkenc.Iterator = (*floatIterator)(nil)
+var _ chunkenc.Iterator = (*sampleIterator)(nil)
 
-func (it *floatIterator) reset(s *series) {
+func (it *sampleIterator) reset(s *series) {
 	it.s = s
 	it.i = -1
 }
 
-func (it *floatIterator) Next() chunkenc.ValueType {
+// valueType is the type of every sample of the series.
+func (it *sampleIterator) valueType() chunkenc.ValueType {
+	if it.s.hs != nil {
+		return chunkenc.ValFloatHistogram
+	}
+	return chunkenc.ValFloat
+}
+
+func (it *sampleIterator) Next() chunkenc.ValueType {
 	it.i++
 	if it.i >= len(it.s.ts) {
 		return chunkenc.ValNone
 	}
-	return chunkenc.ValFloat
+	return it.valueType()
 }
 
-func (it *floatIterator) Seek(t int64) chunkenc.ValueType { //nolint:govet // stdmethods flags io.Seeker; this is chunkenc.Iterator's Seek
+func (it *sampleIterator) Seek(t int64) chunkenc.ValueType { //nolint:govet // stdmethods flags io.Seeker; this is chunkenc.Iterator's Seek
 	if it.i < 0 {
 		it.i = 0
 	}
@@ -66,7 +76,7 @@ func (it *floatIterator) Seek(t int64) chunkenc.ValueType { //nolint:govet // st
 	}
 	// The current position, once valid, must not move backwards.
 	if it.s.ts[it.i] >= t {
-		return chunkenc.ValFloat
+		return it.valueType()
 	}
 	it.i += sort.Search(len(it.s.ts)-it.i, func(j int) bool {
 		return it.s.ts[it.i+j] >= t
@@ -74,31 +84,37 @@ func (it *floatIterator) Seek(t int64) chunkenc.ValueType { //nolint:govet // st
 	if it.i >= len(it.s.ts) {
 		return chunkenc.ValNone
 	}
-	return chunkenc.ValFloat
+	return it.valueType()
 }
 
-func (it *floatIterator) At() (int64, float64) {
+func (it *sampleIterator) At() (int64, float64) {
 	return it.s.ts[it.i], it.s.vs[it.i]
 }
 
-func (it *floatIterator) AtHistogram(*histogram.Histogram) (int64, *histogram.Histogram) {
+func (it *sampleIterator) AtHistogram(*histogram.Histogram) (int64, *histogram.Histogram) {
 	return 0, nil
 }
 
-func (it *floatIterator) AtFloatHistogram(*histogram.FloatHistogram) (int64, *histogram.FloatHistogram) {
-	return 0, nil
+// AtFloatHistogram copies the current histogram sample into fh, or into a
+// new histogram when fh is nil: the caller may modify the result.
+func (it *sampleIterator) AtFloatHistogram(fh *histogram.FloatHistogram) (int64, *histogram.FloatHistogram) {
+	if fh == nil {
+		return it.s.ts[it.i], it.s.hs[it.i].Copy()
+	}
+	it.s.hs[it.i].CopyTo(fh)
+	return it.s.ts[it.i], fh
 }
 
-func (it *floatIterator) AtT() int64 {
+func (it *sampleIterator) AtT() int64 {
 	return it.s.ts[it.i]
 }
 
 // AtST returns the current start timestamp; not tracked by this storage.
-func (it *floatIterator) AtST() int64 {
+func (it *sampleIterator) AtST() int64 {
 	return 0
 }
 
-func (it *floatIterator) Err() error {
+func (it *sampleIterator) Err() error {
 	return nil
 }
 
@@ -155,30 +171,50 @@ func sortAndMerge(list []*series) []*series {
 	return out
 }
 
+// mergeSamples merges two series of the same label set. A float and a
+// histogram series cannot share one sample slice; the histogram series wins,
+// mirroring how a native histogram replaces its float representation.
 func mergeSamples(a, b *series) *series {
+	if (a.hs != nil) != (b.hs != nil) {
+		if a.hs != nil {
+			return a
+		}
+		return b
+	}
 	ts := make([]int64, 0, len(a.ts)+len(b.ts))
-	vs := make([]float64, 0, len(a.ts)+len(b.ts))
+	vs := make([]float64, 0, len(a.vs)+len(b.vs))
+	hs := make([]*histogram.FloatHistogram, 0, len(a.hs)+len(b.hs))
+	appendSample := func(s *series, k int) {
+		ts = append(ts, s.ts[k])
+		if s.hs != nil {
+			hs = append(hs, s.hs[k])
+		} else {
+			vs = append(vs, s.vs[k])
+		}
+	}
 	i, j := 0, 0
 	for i < len(a.ts) && j < len(b.ts) {
 		switch {
 		case a.ts[i] < b.ts[j]:
-			ts = append(ts, a.ts[i])
-			vs = append(vs, a.vs[i])
+			appendSample(a, i)
 			i++
 		case a.ts[i] > b.ts[j]:
-			ts = append(ts, b.ts[j])
-			vs = append(vs, b.vs[j])
+			appendSample(b, j)
 			j++
 		default:
-			ts = append(ts, a.ts[i])
-			vs = append(vs, a.vs[i])
+			appendSample(a, i)
 			i++
 			j++
 		}
 	}
-	ts = append(ts, a.ts[i:]...)
-	vs = append(vs, a.vs[i:]...)
-	ts = append(ts, b.ts[j:]...)
-	vs = append(vs, b.vs[j:]...)
+	for ; i < len(a.ts); i++ {
+		appendSample(a, i)
+	}
+	for ; j < len(b.ts); j++ {
+		appendSample(b, j)
+	}
+	if a.hs != nil {
+		return &series{lset: a.lset, ts: ts, hs: hs}
+	}
 	return &series{lset: a.lset, ts: ts, vs: vs}
 }
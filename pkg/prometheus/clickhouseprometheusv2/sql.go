@@ -13,7 +13,7 @@ import (
 const metricNameLabel = "__name__"
 
 // buildSeriesQuery renders the series lookup: one row per matched fingerprint
-// with its labels.
+// with its labels and metric type.
 func buildSeriesQuery(start, end int64, matchers []*labels.Matcher) (string, []any, error) {
 	// The series tables hold one row per (fingerprint, bucket) at 1h/6h/1d/1w
 	// granularities; the schema package picks the table whose bucket fits the
@@ -21,7 +21,7 @@ func buildSeriesQuery(start, end int64, matchers []*labels.Matcher) (string, []a
 	// beginning mid-bucket still matches the bucket's row.
 	adjustedStart, _, table, _ := metricstelemetryschema.WhichTSTableToUse(uint64(start), uint64(end), false, nil)
 	sb := sqlbuilder.NewSelectBuilder()
-	sb.Select("fingerprint", "any(labels)")
+	sb.Select("fingerprint", "any(labels)", "any(type)")
 	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, table))
 	if err := applySeriesConditions(sb, int64(adjustedStart), end, matchers); err != nil {
 		return "", nil, err
@@ -51,7 +51,51 @@ func buildSamplesQuery(start, end int64, metricNames []string, matchers []*label
 		sb.Select("fingerprint", "unix_milli", "value", "flags")
 	}
 	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, metricstelemetryschema.SamplesV4TableName))
+	if err := applySamplesConditions(sb, start, end, metricNames, matchers); err != nil {
+		return "", nil, err
+	}
+
+	if lastPerStep != nil {
+		sb.GroupBy("fingerprint")
+		if expr := lastPerStep.bucketExpr(); expr != "" {
+			sb.GroupBy(expr)
+		}
+		sb.OrderBy("fingerprint", "ts")
+	} else {
+		sb.OrderBy("fingerprint", "unix_milli")
+	}
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return query, args, nil
+}
+
+// buildExpHistogramSamplesQuery renders the points fetch of the matched
+// exponential histogram series, in the same order as buildSamplesQuery, with
+// the quantiles of every point's sketch at expHistogramRanks. metricNames
+// are the exponential histogram metrics observed on the matched series.
+// Histogram selectors always fetch every point: lastSamplePerStep only
+// reduces float samples.
+func buildExpHistogramSamplesQuery(start, end int64, metricNames []string, matchers []*labels.Matcher) (string, []any, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"fingerprint", "unix_milli", "any(count)", "any(sum)",
+		metricstelemetryschema.ExpHistogramQuantilesExpr(metricstelemetryschema.ExpHistogramSketchColumn, expHistogramRanks()),
+	)
+	sb.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, metricstelemetryschema.ExpHistogramTableName))
+	if err := applySamplesConditions(sb, start, end, metricNames, matchers); err != nil {
+		return "", nil, err
+	}
+	sb.GroupBy("fingerprint", "unix_milli")
+	sb.OrderBy("fingerprint", "unix_milli")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return query, args, nil
+}
 
+// applySamplesConditions adds the WHERE conditions of a samples table scan
+// (see buildSamplesQuery): the metric names, the semi-join on the matched
+// series and the window.
+func applySamplesConditions(sb *sqlbuilder.SelectBuilder, start, end int64, metricNames []string, matchers []*labels.Matcher) error {
 	switch len(metricNames) {
 	case 0:
 		// No name constraint derivable; the primary-key prefix goes unused.
@@ -68,23 +112,11 @@ func buildSamplesQuery(start, end int64, metricNames []string, matchers []*label
 	adjustedStart, _, _, localTable := metricstelemetryschema.WhichTSTableToUse(uint64(start), uint64(end), false, nil)
 	sub.From(fmt.Sprintf("%s.%s", metricstelemetryschema.DBName, localTable))
 	if err := applySeriesConditions(sub, int64(adjustedStart), end, matchers); err != nil {
-		return "", nil, err
+		return err
 	}
 	sb.Where(sb.In("fingerprint", sub))
 	sb.Where(sb.GTE("unix_milli", start), sb.LTE("unix_milli", end))
-
-	if lastPerStep != nil {
-		sb.GroupBy("fingerprint")
-		if expr := lastPerStep.bucketExpr(); expr != "" {
-			sb.GroupBy(expr)
-		}
-		sb.OrderBy("fingerprint", "ts")
-	} else {
-		sb.OrderBy("fingerprint", "unix_milli")
-	}
-
-	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
-	return query, args, nil
+	return nil
 }
 
 // applySeriesConditions adds the WHERE conditions of a series table scan:
@@ -29,7 +29,7 @@ func TestBuildSeriesQuery(t *testing.T) {
 		})
 		require.NoError(t, err)
 		assert.Equal(t,
-			"SELECT fingerprint, any(labels) FROM signoz_metrics.distributed_time_series_v4 WHERE metric_name = ? AND temporality IN ['Cumulative', 'Unspecified'] AND unix_milli >= ? AND unix_milli <= ? AND JSONExtractString(labels, ?) = ? GROUP BY fingerprint",
+			"SELECT fingerprint, any(labels), any(type) FROM signoz_metrics.distributed_time_series_v4 WHERE metric_name = ? AND temporality IN ['Cumulative', 'Unspecified'] AND unix_milli >= ? AND unix_milli <= ? AND JSONExtractString(labels, ?) = ? GROUP BY fingerprint",
 			query,
 		)
 		assert.Equal(t, []any{"http_requests_total", adjustedStart, end, "job", "api"}, args)
@@ -113,3 +113,21 @@ func TestBuildSamplesQuery(t *testing.T) {
 		assert.Equal(t, []any{"node_cpu", "node_memory", "up", adjustedStart, end, "job", "api", start, end}, args)
 	})
 }
+
+func TestBuildExpHistogramSamplesQuery(t *testing.T) {
+	start := int64(1_700_000_000_000)
+	end := start + time.Hour.Milliseconds()
+	adjustedStart := start - (start % time.Hour.Milliseconds())
+	matchers := []*labels.Matcher{
+		mustMatcher(t, labels.MatchEqual, "__name__", "http_request_duration"),
+		mustMatcher(t, labels.MatchEqual, "job", "api"),
+	}
+
+	query, args, err := buildExpHistogramSamplesQuery(start, end, []string{"http_request_duration"}, matchers)
+	require.NoError(t, err)
+	assert.Contains(t, query, "SELECT fingerprint, unix_milli, any(count), any(sum), quantilesDDMerge(0.01, 0.005000, 0.015000, ")
+	assert.Contains(t, query, ", 0.995000)(sketch) FROM signoz_metrics.distributed_exp_hist")
+	assert.Contains(t, query, "fingerprint IN (SELECT fingerprint FROM signoz_metrics.time_series_v4 WHERE ")
+	assert.Contains(t, query, "GROUP BY fingerprint, unix_milli ORDER BY fingerprint, unix_milli")
+	assert.Equal(t, []any{"http_request_duration", "http_request_duration", adjustedStart, end, "job", "api", start, end}, args)
+}
@@ -17,6 +17,11 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// errNativeHistogramSeries reports a unit matching exponential histogram
+// series served as native histograms: the grid SQL reads float samples only,
+// so the query falls back to the engine path.
+var errNativeHistogramSeries = errors.NewInternalf(errors.CodeInternal, "selector matches native histogram series")
+
 type executor struct {
 	client *client
 	engine *prometheus.Engine
@@ -102,6 +107,9 @@ func (e *executor) TryExecuteRange(ctx context.Context, qs string, start, end ti
 		})
 	}
 	if err := eg.Wait(); err != nil {
+		if errors.Is(err, errNativeHistogramSeries) {
+			return nil, false, nil
+		}
 		return nil, true, err
 	}
 
@@ -153,6 +161,9 @@ func (e *executor) executeUnit(ctx context.Context, unit *coreUnit, grid gridCon
 	if len(lookup.fingerprints) == 0 {
 		return nil, nil
 	}
+	if e.client.nativeHistograms && len(lookup.expHistogramNames) > 0 {
+		return nil, errNativeHistogramSeries
+	}
 
 	query, args, err := buildUnitSQL(unit, lookup.metricNames, dataStart, dataEnd, startMs, endMs, stepMs, e.client.lookbackMs)
 	if err != nil {
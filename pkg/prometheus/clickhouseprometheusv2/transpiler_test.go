@@ -30,6 +30,7 @@ func newTestClient(t *testing.T) (*client, *telemetrystoretest.Provider) {
 var seriesCols = []cmock.ColumnType{
 	{Name: "fingerprint", Type: "UInt64"},
 	{Name: "labels", Type: "String"},
+	{Name: "type", Type: "String"},
 }
 
 func parse(t *testing.T, q string) parser.Expr {
@@ -28,6 +28,13 @@ type Config struct {
 	// ProviderName selects the storage provider: "clickhouse" (default) or
 	// "clickhousev2".
 	ProviderName string `mapstructure:"provider"`
+
+	// NativeHistograms makes the clickhousev2 provider serve exponential
+	// histogram series as native histograms, so that histogram_quantile and
+	// the other histogram functions apply to them. The buckets are rebuilt
+	// from the quantiles of every point's DDSketch, which bounds the
+	// precision of their percentiles to a hundredth of the rank.
+	NativeHistograms bool `mapstructure:"native_histograms"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -0,0 +1,466 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// AdmissionActionReject rejects the queries over budget.
+	AdmissionActionReject = "reject"
+	// AdmissionActionDownsample runs the queries over budget on a sample of the data: the
+	// reads stop once the budget is spent and the results are computed from the rows
+	// read so far.
+	AdmissionActionDownsample = "downsample"
+	// AdmissionActionOverride rejects the queries over budget unless the request
+	// explicitly overrides the budget, see qbtypes.QueryRangeRequest.CostOverride.
+	AdmissionActionOverride = "override"
+)
+
+var (
+	ErrCodeQueryCostExceeded = errors.MustNewCode("query_cost_exceeded")
+)
+
+const (
+	// rowWidthTTL is how long the average row width of a table is cached for.
+	rowWidthTTL = time.Hour
+	// maxReportedScans is the number of table scans named in the errors of the queries
+	// over budget.
+	maxReportedScans = 3
+)
+
+// QueryBudget bounds the rows and bytes the queries of a request may scan. Zero means
+// unlimited.
+type QueryBudget struct {
+	MaxRows  uint64 `yaml:"max_rows" mapstructure:"max_rows"`
+	MaxBytes uint64 `yaml:"max_bytes" mapstructure:"max_bytes"`
+}
+
+// AdmissionConfig is the configuration of the admission control of queries. Before a
+// request runs, the rows and bytes its builder and ClickHouse SQL queries would scan over
+// the ranges missing from the bucket cache are estimated with EXPLAIN ESTIMATE and
+// compared with the budget of the caller, which is the tighter of the budget of its org
+// and the one of its roles.
+type AdmissionConfig struct {
+	// Enabled turns the admission control on.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Action is what happens to the queries over budget: reject, downsample or override.
+	Action string `yaml:"action" mapstructure:"action"`
+	// Default is the budget of the orgs without one in Orgs.
+	Default QueryBudget `yaml:"default" mapstructure:"default"`
+	// Orgs are the budgets by org id.
+	Orgs map[string]QueryBudget `yaml:"orgs" mapstructure:"orgs"`
+	// Roles are the budgets by role name, e.g. signoz-viewer. A user with several roles
+	// gets the most permissive of their budgets.
+	Roles map[string]QueryBudget `yaml:"roles" mapstructure:"roles"`
+}
+
+func (c AdmissionConfig) Validate() error {
+	if !slices.Contains([]string{AdmissionActionReject, AdmissionActionDownsample, AdmissionActionOverride}, c.Action) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "admission.action must be one of %s, %s or %s, got %q", AdmissionActionReject, AdmissionActionDownsample, AdmissionActionOverride, c.Action)
+	}
+	for orgID := range c.Orgs {
+		if _, err := valuer.NewUUID(orgID); err != nil {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "admission.orgs must be keyed by org id, got %q", orgID)
+		}
+	}
+	return nil
+}
+
+func (b QueryBudget) unlimited() bool {
+	return b.MaxRows == 0 && b.MaxBytes == 0
+}
+
+// tighter returns the tighter of two budgets, limit by limit.
+func (b QueryBudget) tighter(other QueryBudget) QueryBudget {
+	return QueryBudget{MaxRows: minLimit(b.MaxRows, other.MaxRows), MaxBytes: minLimit(b.MaxBytes, other.MaxBytes)}
+}
+
+// looser returns the looser of two budgets, limit by limit.
+func (b QueryBudget) looser(other QueryBudget) QueryBudget {
+	return QueryBudget{MaxRows: maxLimit(b.MaxRows, other.MaxRows), MaxBytes: maxLimit(b.MaxBytes, other.MaxBytes)}
+}
+
+// exceeded returns the limit of the budget the scan goes over, rows or bytes, or an
+// empty string when it fits.
+func (b QueryBudget) exceeded(scan tableScan) string {
+	if b.MaxRows != 0 && scan.rows > b.MaxRows {
+		return "rows"
+	}
+	if b.MaxBytes != 0 && scan.bytes > b.MaxBytes {
+		return "bytes"
+	}
+	return ""
+}
+
+func (b QueryBudget) String() string {
+	var parts []string
+	if b.MaxRows != 0 {
+		parts = append(parts, humanize.Comma(int64(b.MaxRows))+" rows")
+	}
+	if b.MaxBytes != 0 {
+		parts = append(parts, humanize.Bytes(b.MaxBytes))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func minLimit(a, b uint64) uint64 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+func maxLimit(a, b uint64) uint64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return max(a, b)
+}
+
+// tableScan is the estimated scan of a table by the queries of a request. Bytes is an
+// upper bound computed from the average uncompressed width of the rows of the table, as
+// if every column was read.
+type tableScan struct {
+	table string
+	rows  uint64
+	bytes uint64
+}
+
+func (s tableScan) String() string {
+	return fmt.Sprintf("%s: ~%s rows, ~%s", s.table, humanize.Comma(int64(s.rows)), humanize.Bytes(s.bytes))
+}
+
+type rowWidth struct {
+	bytes     float64
+	expiresAt time.Time
+}
+
+type admissionMetrics struct {
+	rejected    metric.Int64Counter
+	downsampled metric.Int64Counter
+	overridden  metric.Int64Counter
+}
+
+func newAdmissionMetrics(meter metric.Meter) (*admissionMetrics, error) {
+	var errs error
+
+	rejected, err := meter.Int64Counter(
+		"signoz.querier.admission.rejected.count",
+		metric.WithDescription("Total number of query range requests rejected for going over their scan budget, by limit and action."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	downsampled, err := meter.Int64Counter(
+		"signoz.querier.admission.downsampled.count",
+		metric.WithDescription("Total number of query range requests run on a sample of the data for going over their scan budget, by limit."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	overridden, err := meter.Int64Counter(
+		"signoz.querier.admission.overridden.count",
+		metric.WithDescription("Total number of query range requests run over their scan budget with an explicit override, by limit."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	return &admissionMetrics{
+		rejected:    rejected,
+		downsampled: downsampled,
+		overridden:  overridden,
+	}, errs
+}
+
+const (
+	attrLimit  = attribute.Key("limit")
+	attrAction = attribute.Key("action")
+)
+
+type admission struct {
+	config         AdmissionConfig
+	logger         *slog.Logger
+	telemetryStore telemetrystore.TelemetryStore
+	userRoleStore  authtypes.UserRoleStore
+	metrics        *admissionMetrics
+
+	mu        sync.Mutex
+	rowWidths map[string]rowWidth
+}
+
+func newAdmission(config AdmissionConfig, logger *slog.Logger, meter metric.Meter, telemetryStore telemetrystore.TelemetryStore, userRoleStore authtypes.UserRoleStore) *admission {
+	metrics, err := newAdmissionMetrics(meter)
+	if err != nil {
+		// The noop instruments never fail, admit records to them instead.
+		logger.Error("failed to create admission metrics, not recording them", errors.Attr(err))
+		metrics, _ = newAdmissionMetrics(noop.NewMeterProvider().Meter(""))
+	}
+
+	return &admission{
+		config:         config,
+		logger:         logger,
+		telemetryStore: telemetryStore,
+		userRoleStore:  userRoleStore,
+		metrics:        metrics,
+		rowWidths:      make(map[string]rowWidth),
+	}
+}
+
+// admit estimates the rows and bytes the queries of a request would scan and compares
+// them with the budget of the caller, rejecting, downsampling or requiring an override
+// for the requests over budget. The queries are the ones the request runs, i.e. for the
+// ranges missing from the bucket cache, and are estimated in the slots of the ticket.
+// The returned context carries the settings of the downsampled requests. Requests
+// without claims, such as the ones of the rule manager, are always admitted, and so are
+// the requests whose cost cannot be estimated.
+func (a *admission) admit(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, queries []qbtypes.Query, ticket *schedulerTicket) (context.Context, []string, error) {
+	if a == nil || !a.config.Enabled {
+		return ctx, nil, nil
+	}
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return ctx, nil, nil
+	}
+
+	budget := a.budget(ctx, orgID, claims)
+	if budget.unlimited() {
+		return ctx, nil, nil
+	}
+
+	scans, statements, err := a.estimate(ctx, queries, ticket)
+	if err != nil {
+		// A request out of time waiting for a slot would only wait again to run.
+		if errors.Asc(err, ErrCodeQueryQueueTimeout) || ctx.Err() != nil {
+			return ctx, nil, err
+		}
+		a.logger.WarnContext(ctx, "failed to estimate the cost of the queries, admitting them", errors.Attr(err))
+		return ctx, nil, nil
+	}
+
+	total := tableScan{table: "total"}
+	for _, scan := range scans {
+		total.rows += scan.rows
+		total.bytes += scan.bytes
+	}
+
+	limit := budget.exceeded(total)
+	if limit == "" {
+		return ctx, nil, nil
+	}
+
+	switch a.config.Action {
+	case AdmissionActionDownsample:
+		maxRows := budget.MaxRows
+		if budget.MaxBytes != 0 && total.bytes > 0 {
+			// Bytes are estimated from rows, so the bytes budget becomes a rows budget.
+			maxRowsForBytes := uint64(float64(budget.MaxBytes) * float64(total.rows) / float64(total.bytes))
+			maxRows = minLimit(maxRows, max(maxRowsForBytes, 1))
+		}
+		// The rows budget is split between the statements, each reading at most its share.
+		if statements > 1 {
+			maxRows /= uint64(statements)
+		}
+		maxRows = max(maxRows, 1)
+
+		// Partial results must not be cached in place of the full ones.
+		req.NoCache = true
+		a.metrics.downsampled.Add(ctx, 1, metric.WithAttributes(attrLimit.String(limit)))
+		return ctxtypes.SetClickhouseMaxRowsToRead(ctx, maxRows), []string{fmt.Sprintf(
+			"The queries would scan ~%s rows (~%s), over the budget of %s; they were run on a sample of the data and their results are partial.",
+			humanize.Comma(int64(total.rows)), humanize.Bytes(total.bytes), budget,
+		)}, nil
+	case AdmissionActionOverride:
+		if req.CostOverride {
+			a.metrics.overridden.Add(ctx, 1, metric.WithAttributes(attrLimit.String(limit)))
+			return ctx, nil, nil
+		}
+	}
+
+	a.metrics.rejected.Add(ctx, 1, metric.WithAttributes(attrLimit.String(limit), attrAction.String(a.config.Action)))
+	return ctx, nil, newQueryCostError(a.config.Action, budget, total, scans)
+}
+
+func newQueryCostError(action string, budget QueryBudget, total tableScan, scans []tableScan) error {
+	additionals := make([]string, 0, maxReportedScans)
+	for i, scan := range scans {
+		if i == maxReportedScans {
+			break
+		}
+		additionals = append(additionals, scan.String())
+	}
+
+	err := errors.Newf(
+		errors.TypeForbidden,
+		ErrCodeQueryCostExceeded,
+		"The queries would scan ~%s rows (~%s), over the budget of %s. The biggest table scans are listed below.",
+		humanize.Comma(int64(total.rows)), humanize.Bytes(total.bytes), budget,
+	).WithAdditional(additionals...)
+
+	if action == AdmissionActionOverride {
+		return err.WithSuggestions(
+			"Narrow the time range or add filters to scan less data.",
+			"Resend the request with the X-SigNoz-Query-Cost-Override: true header to run it anyway.",
+		)
+	}
+	return err.WithSuggestions("Narrow the time range or add filters to scan less data.")
+}
+
+// budget returns the tighter of the budget of the org and the one of the roles of the
+// user. Without a role budget configured, only the org budget applies.
+func (a *admission) budget(ctx context.Context, orgID valuer.UUID, claims authtypes.Claims) QueryBudget {
+	budget, ok := a.config.Orgs[orgID.StringValue()]
+	if !ok {
+		budget = a.config.Default
+	}
+
+	if len(a.config.Roles) == 0 || a.userRoleStore == nil || claims.UserID == "" {
+		return budget
+	}
+
+	userID, err := valuer.NewUUID(claims.UserID)
+	if err != nil {
+		return budget
+	}
+
+	userRoles, err := a.userRoleStore.GetUserRolesByUserID(ctx, userID)
+	if err != nil {
+		a.logger.WarnContext(ctx, "failed to get the roles of the user, applying the org budget", errors.Attr(err))
+		return budget
+	}
+
+	var roleBudget *QueryBudget
+	for _, userRole := range userRoles {
+		if userRole.Role == nil {
+			continue
+		}
+		b, ok := a.config.Roles[userRole.Role.Name]
+		if !ok {
+			continue
+		}
+		if roleBudget == nil {
+			roleBudget = &b
+			continue
+		}
+		looser := roleBudget.looser(b)
+		roleBudget = &looser
+	}
+
+	if roleBudget == nil {
+		return budget
+	}
+	return budget.tighter(*roleBudget)
+}
+
+// estimate returns the estimated scans of the queries but PromQL ones by table, the biggest first, and the number of statements estimated.
+func (a *admission) estimate(ctx context.Context, queries []qbtypes.Query, ticket *schedulerTicket) ([]tableScan, int, error) {
+	rowsByTable := make(map[string]uint64)
+	statements := 0
+
+	for _, query := range queries {
+		// PromQL queries read the samples series by series, EXPLAIN ESTIMATE does not apply.
+		if _, ok := query.(*promqlQuery); ok {
+			continue
+		}
+		stmtProvider, ok := query.(qbtypes.StatementProvider)
+		if !ok {
+			continue
+		}
+
+		stmt, err := stmtProvider.Statement(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if err := ticket.acquire(ctx); err != nil {
+			return nil, 0, err
+		}
+		entries, err := a.telemetryStore.Estimate(ctx, stmt.Query, stmt.Args...)
+		ticket.release()
+		if err != nil {
+			return nil, 0, err
+		}
+		statements++
+
+		for _, entry := range entries {
+			if entry.Rows > 0 {
+				rowsByTable[entry.Database+"."+entry.Table] += uint64(entry.Rows)
+			}
+		}
+	}
+
+	scans := make([]tableScan, 0, len(rowsByTable))
+	for table, rows := range rowsByTable {
+		width, err := a.rowWidth(ctx, table)
+		if err != nil {
+			return nil, 0, err
+		}
+		scans = append(scans, tableScan{table: table, rows: rows, bytes: uint64(float64(rows) * width)})
+	}
+
+	slices.SortFunc(scans, func(x, y tableScan) int {
+		if x.bytes != y.bytes {
+			if x.bytes > y.bytes {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(x.table, y.table)
+	})
+	return scans, statements, nil
+}
+
+// rowWidth returns the average uncompressed width of the rows of a table, in bytes.
+func (a *admission) rowWidth(ctx context.Context, table string) (float64, error) {
+	a.mu.Lock()
+	cached, ok := a.rowWidths[table]
+	a.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.bytes, nil
+	}
+
+	database, name, _ := strings.Cut(table, ".")
+
+	var bytes, rows uint64
+	if err := a.telemetryStore.ClickhouseDB().QueryRow(
+		ctx,
+		"SELECT sum(data_uncompressed_bytes), sum(rows) FROM system.parts WHERE active AND database = ? AND table = ?",
+		database, name,
+	).Scan(&bytes, &rows); err != nil {
+		return 0, err
+	}
+
+	width := 0.0
+	if rows > 0 {
+		width = float64(bytes) / float64(rows)
+	}
+
+	a.mu.Lock()
+	a.rowWidths[table] = rowWidth{bytes: width, expiresAt: time.Now().Add(rowWidthTTL)}
+	a.mu.Unlock()
+	return width, nil
+}
@@ -0,0 +1,240 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrystoretypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+type estimateTelemetryStore struct {
+	telemetrystore.TelemetryStore
+	entries map[string][]telemetrystoretypes.EstimateEntry
+}
+
+func (s *estimateTelemetryStore) Estimate(_ context.Context, stmt string, _ ...any) ([]telemetrystoretypes.EstimateEntry, error) {
+	return s.entries[stmt], nil
+}
+
+type statementQuery struct {
+	qbtypes.Query
+	query string
+}
+
+func (q *statementQuery) Statement(_ context.Context) (*qbtypes.Statement, error) {
+	return &qbtypes.Statement{Query: q.query}, nil
+}
+
+// failingMeter fails to create counters.
+type failingMeter struct {
+	noop.Meter
+}
+
+func (failingMeter) Int64Counter(string, ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return nil, errors.New(errors.TypeInternal, errors.CodeInternal, "failed to create counter")
+}
+
+type rolesUserRoleStore struct {
+	authtypes.UserRoleStore
+	roles []string
+}
+
+func (s *rolesUserRoleStore) GetUserRolesByUserID(_ context.Context, _ valuer.UUID) ([]*authtypes.UserRole, error) {
+	userRoles := make([]*authtypes.UserRole, 0, len(s.roles))
+	for _, role := range s.roles {
+		userRoles = append(userRoles, &authtypes.UserRole{Role: &authtypes.Role{Name: role}})
+	}
+	return userRoles, nil
+}
+
+func newTestAdmission(config AdmissionConfig, roles ...string) *admission {
+	settings := instrumentationtest.New().ToProviderSettings()
+	a := newAdmission(
+		config,
+		settings.Logger,
+		settings.MeterProvider.Meter("test"),
+		&estimateTelemetryStore{entries: map[string][]telemetrystoretypes.EstimateEntry{
+			"SELECT logs": {
+				{Database: "signoz_logs", Table: "logs_v2", Rows: 3_000_000},
+				{Database: "signoz_logs", Table: "logs_v2_resource", Rows: 1_000},
+			},
+			"SELECT traces": {
+				{Database: "signoz_traces", Table: "signoz_index_v3", Rows: 1_000_000},
+			},
+		}},
+		&rolesUserRoleStore{roles: roles},
+	)
+	// Cached widths keep the tests off system.parts.
+	expiresAt := time.Now().Add(time.Hour)
+	a.rowWidths["signoz_logs.logs_v2"] = rowWidth{bytes: 1000, expiresAt: expiresAt}
+	a.rowWidths["signoz_logs.logs_v2_resource"] = rowWidth{bytes: 100, expiresAt: expiresAt}
+	a.rowWidths["signoz_traces.signoz_index_v3"] = rowWidth{bytes: 500, expiresAt: expiresAt}
+	return a
+}
+
+func testQueries() []qbtypes.Query {
+	return []qbtypes.Query{
+		&statementQuery{query: "SELECT logs"},
+		&statementQuery{query: "SELECT traces"},
+	}
+}
+
+func TestAdmissionBudget(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	userID := valuer.GenerateUUID()
+	claims := authtypes.Claims{UserID: userID.StringValue(), OrgID: orgID.StringValue()}
+
+	cases := []struct {
+		name     string
+		config   AdmissionConfig
+		roles    []string
+		expected QueryBudget
+	}{
+		{
+			name:     "default org budget",
+			config:   AdmissionConfig{Default: QueryBudget{MaxRows: 100}},
+			expected: QueryBudget{MaxRows: 100},
+		},
+		{
+			name: "org budget",
+			config: AdmissionConfig{
+				Default: QueryBudget{MaxRows: 100},
+				Orgs:    map[string]QueryBudget{orgID.StringValue(): {MaxRows: 1000, MaxBytes: 5000}},
+			},
+			expected: QueryBudget{MaxRows: 1000, MaxBytes: 5000},
+		},
+		{
+			name: "tighter of org and role budgets",
+			config: AdmissionConfig{
+				Default: QueryBudget{MaxRows: 1000},
+				Roles:   map[string]QueryBudget{authtypes.SigNozViewerRoleName: {MaxRows: 2000, MaxBytes: 300}},
+			},
+			roles:    []string{authtypes.SigNozViewerRoleName},
+			expected: QueryBudget{MaxRows: 1000, MaxBytes: 300},
+		},
+		{
+			name: "looser of role budgets",
+			config: AdmissionConfig{
+				Roles: map[string]QueryBudget{
+					authtypes.SigNozViewerRoleName: {MaxRows: 100, MaxBytes: 300},
+					authtypes.SigNozEditorRoleName: {MaxRows: 200},
+				},
+			},
+			roles:    []string{authtypes.SigNozViewerRoleName, authtypes.SigNozEditorRoleName},
+			expected: QueryBudget{MaxRows: 200},
+		},
+		{
+			name: "role without budget",
+			config: AdmissionConfig{
+				Default: QueryBudget{MaxRows: 100},
+				Roles:   map[string]QueryBudget{authtypes.SigNozViewerRoleName: {MaxRows: 10}},
+			},
+			roles:    []string{authtypes.SigNozAdminRoleName},
+			expected: QueryBudget{MaxRows: 100},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := newTestAdmission(c.config, c.roles...)
+			assert.Equal(t, c.expected, a.budget(context.Background(), orgID, claims))
+		})
+	}
+}
+
+func TestAdmissionAdmit(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	ctx := authtypes.NewContextWithClaims(context.Background(), authtypes.Claims{UserID: valuer.GenerateUUID().StringValue(), OrgID: orgID.StringValue()})
+
+	t.Run("under budget", func(t *testing.T) {
+		a := newTestAdmission(AdmissionConfig{Enabled: true, Action: AdmissionActionReject, Default: QueryBudget{MaxRows: 5_000_000}})
+		_, warnings, err := a.admit(ctx, orgID, &qbtypes.QueryRangeRequest{}, testQueries(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		a := newTestAdmission(AdmissionConfig{Enabled: true, Action: AdmissionActionReject, Default: QueryBudget{MaxBytes: 1_000_000_000}})
+		_, _, err := a.admit(ctx, orgID, &qbtypes.QueryRangeRequest{}, testQueries(), nil)
+		require.Error(t, err)
+		assert.True(t, errors.Asc(err, ErrCodeQueryCostExceeded))
+		assert.True(t, errors.Ast(err, errors.TypeForbidden))
+
+		j := errors.AsJSON(err)
+		assert.Equal(t, "The queries would scan ~4,001,000 rows (~3.5 GB), over the budget of 1.0 GB. The biggest table scans are listed below.", j.Message)
+		messages := make([]string, 0, len(j.Errors))
+		for _, additional := range j.Errors {
+			messages = append(messages, additional.Message)
+		}
+		assert.Equal(t, []string{
+			"signoz_logs.logs_v2: ~3,000,000 rows, ~3.0 GB",
+			"signoz_traces.signoz_index_v3: ~1,000,000 rows, ~500 MB",
+			"signoz_logs.logs_v2_resource: ~1,000 rows, ~100 kB",
+		}, messages)
+	})
+
+	t.Run("without claims", func(t *testing.T) {
+		a := newTestAdmission(AdmissionConfig{Enabled: true, Action: AdmissionActionReject, Default: QueryBudget{MaxRows: 1}})
+		_, _, err := a.admit(context.Background(), orgID, &qbtypes.QueryRangeRequest{}, testQueries(), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		a := newTestAdmission(AdmissionConfig{Enabled: true, Action: AdmissionActionOverride, Default: QueryBudget{MaxRows: 1_000_000}})
+
+		_, _, err := a.admit(ctx, orgID, &qbtypes.QueryRangeRequest{}, testQueries(), nil)
+		require.Error(t, err)
+		assert.Contains(t, errors.AsJSON(err).Suggestions, "Resend the request with the X-SigNoz-Query-Cost-Override: true header to run it anyway.")
+
+		_, _, err = a.admit(ctx, orgID, &qbtypes.QueryRangeRequest{CostOverride: true}, testQueries(), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("downsample", func(t *testing.T) {
+		a := newTestAdmission(AdmissionConfig{Enabled: true, Action: AdmissionActionDownsample, Default: QueryBudget{MaxRows: 1_000_000}})
+
+		req := &qbtypes.QueryRangeRequest{}
+		downsampledCtx, warnings, err := a.admit(ctx, orgID, req, testQueries(), nil)
+		require.NoError(t, err)
+		assert.Len(t, warnings, 1)
+		assert.True(t, req.NoCache)
+
+		maxRowsToRead, ok := ctxtypes.ClickhouseMaxRowsToReadFromContext(downsampledCtx)
+		require.True(t, ok)
+		assert.Equal(t, uint64(500_000), maxRowsToRead)
+	})
+}
+
+func TestAdmissionMetricsFallback(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	ctx := authtypes.NewContextWithClaims(context.Background(), authtypes.Claims{UserID: valuer.GenerateUUID().StringValue(), OrgID: orgID.StringValue()})
+
+	settings := instrumentationtest.New().ToProviderSettings()
+	a := newAdmission(
+		AdmissionConfig{Enabled: true, Action: AdmissionActionReject, Default: QueryBudget{MaxRows: 1}},
+		settings.Logger,
+		failingMeter{},
+		&estimateTelemetryStore{entries: map[string][]telemetrystoretypes.EstimateEntry{
+			"SELECT traces": {{Database: "signoz_traces", Table: "signoz_index_v3", Rows: 1_000}},
+		}},
+		nil,
+	)
+	a.rowWidths["signoz_traces.signoz_index_v3"] = rowWidth{bytes: 500, expiresAt: time.Now().Add(time.Hour)}
+
+	// the rejection is recorded to noop counters
+	require.NotNil(t, a.metrics.rejected)
+	_, _, err := a.admit(ctx, orgID, &qbtypes.QueryRangeRequest{}, []qbtypes.Query{&statementQuery{query: "SELECT traces"}}, nil)
+	assert.True(t, errors.Asc(err, ErrCodeQueryCostExceeded))
+}
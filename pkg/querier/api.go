@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/SigNoz/signoz/pkg/analytics"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/http/binding"
 	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/querybuilder/pipequery"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
 	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
@@ -27,6 +29,7 @@ type Handler interface {
 	QueryRangePreview(rw http.ResponseWriter, req *http.Request)
 	QueryRawStream(rw http.ResponseWriter, req *http.Request)
 	ReplaceVariables(rw http.ResponseWriter, req *http.Request)
+	ValidatePipeQuery(rw http.ResponseWriter, req *http.Request)
 }
 
 type handler struct {
@@ -58,6 +61,14 @@ func (handler *handler) QueryRange(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 	queryRangeRequest.PromQLProvider = req.Header.Get("X-SigNoz-PromQL-Provider")
+	queryRangeRequest.CostOverride, _ = strconv.ParseBool(req.Header.Get("X-SigNoz-Query-Cost-Override"))
+
+	queryRangeParams := qbtypes.QueryRangeParams{Profile: req.URL.Query().Get("profile")}
+	queryRangeRequest.Profile, err = queryRangeParams.Validate()
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
 
 	// Validate the query request
 	if err := queryRangeRequest.Validate(); err != nil {
@@ -291,6 +302,47 @@ func (handler *handler) ReplaceVariables(rw http.ResponseWriter, req *http.Reque
 	render.Success(rw, http.StatusOK, queryRangeRequest)
 }
 
+// ValidatePipeQuery compiles a pipe query into a builder query. Syntax errors are
+// reported in the response, positioned in the query, rather than failing the request.
+func (handler *handler) ValidatePipeQuery(rw http.ResponseWriter, req *http.Request) {
+	var postable qbtypes.PostablePipeQuery
+	if err := binding.JSON.BindBody(req.Body, &postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := postable.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	compiled, syntaxErrs := pipequery.Compile(postable.Query)
+	if len(syntaxErrs) > 0 {
+		gettable := &qbtypes.GettablePipeQuery{Errors: make([]qbtypes.PipeQueryError, 0, len(syntaxErrs))}
+		for _, syntaxErr := range syntaxErrs {
+			message := syntaxErr.Msg
+			if len(syntaxErr.Expected) > 0 {
+				message = "expecting one of {" + strings.Join(syntaxErr.Expected, ", ") + "} but got " + syntaxErr.TokenTxt
+			}
+			gettable.Errors = append(gettable.Errors, qbtypes.PipeQueryError{
+				Line:     syntaxErr.Line,
+				Column:   syntaxErr.Col,
+				Message:  message,
+				Expected: syntaxErr.Expected,
+			})
+		}
+		render.Success(rw, http.StatusOK, gettable)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, &qbtypes.GettablePipeQuery{
+		Valid:       true,
+		Errors:      []qbtypes.PipeQueryError{},
+		RequestType: compiled.RequestType,
+		Query:       &compiled.Query,
+	})
+}
+
 func (handler *handler) logEvent(ctx context.Context, referrer string, event *qbtypes.QBEvent) {
 	claims, err := authtypes.ClaimsFromContext(ctx)
 	if err != nil {
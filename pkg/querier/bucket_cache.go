@@ -453,6 +453,8 @@ func (bc *bucketCache) mergeBuckets(ctx context.Context, buckets []*qbtypes.Cach
 	switch resultType {
 	case qbtypes.RequestTypeTimeSeries:
 		mergedValue = bc.mergeTimeSeriesValues(ctx, buckets)
+	case qbtypes.RequestTypeDistribution:
+		mergedValue = bc.mergeDistributionValues(ctx, buckets)
 		// Raw and Scalar types are not cached, so no merge needed
 	}
 
@@ -565,6 +567,20 @@ func (bc *bucketCache) mergeTimeSeriesValues(ctx context.Context, buckets []*qbt
 	return result
 }
 
+// mergeDistributionValues merges distribution data from multiple buckets.
+func (bc *bucketCache) mergeDistributionValues(ctx context.Context, buckets []*qbtypes.CachedBucket) *qbtypes.DistributionData {
+	values := make([]*qbtypes.DistributionData, 0, len(buckets))
+	for _, bucket := range buckets {
+		var data *qbtypes.DistributionData
+		if err := json.Unmarshal(bucket.Value, &data); err != nil {
+			bc.logger.ErrorContext(ctx, "failed to unmarshal distribution data", errors.Attr(err))
+			continue
+		}
+		values = append(values, data)
+	}
+	return mergeDistributionData(values...)
+}
+
 // isEmptyResult checks if a result is truly empty (no data exists) vs filtered empty (data was filtered out).
 func (bc *bucketCache) isEmptyResult(result *qbtypes.Result) (isEmpty bool, isFiltered bool) {
 	if result.Value == nil {
@@ -607,6 +623,20 @@ func (bc *bucketCache) isEmptyResult(result *qbtypes.Result) (isEmpty bool, isFi
 			return !hasValues, !hasValues && totalSeries > 0
 		}
 
+	case qbtypes.RequestTypeDistribution:
+		if data, ok := result.Value.(*qbtypes.DistributionData); ok {
+			if len(data.Series) == 0 {
+				return true, false
+			}
+			for _, series := range data.Series {
+				if len(series.Values) > 0 {
+					return false, false
+				}
+			}
+			// series without values - data was filtered out
+			return true, true
+		}
+
 	case qbtypes.RequestTypeRaw, qbtypes.RequestTypeScalar, qbtypes.RequestTypeTrace:
 		// Raw and scalar data are not cached
 		return true, false
@@ -737,6 +767,14 @@ func (bc *bucketCache) trimResultToFluxBoundary(result *qbtypes.Result, fluxBoun
 			trimmedResult.Value = trimmedData
 		}
 
+	case qbtypes.RequestTypeDistribution:
+		if data, ok := result.Value.(*qbtypes.DistributionData); ok && data != nil {
+			// partial values cannot be cached
+			trimmedResult.Value = filterDistributionValues(data, func(value *qbtypes.DistributionValue) bool {
+				return !value.Partial && uint64(value.Timestamp) <= fluxBoundary
+			})
+		}
+
 	case qbtypes.RequestTypeRaw, qbtypes.RequestTypeScalar, qbtypes.RequestTypeTrace:
 		// Don't cache raw or scalar data
 		return nil
@@ -812,6 +850,19 @@ func (bc *bucketCache) filterResultToTimeRange(result *qbtypes.Result, startMs,
 				Warnings: result.Warnings,
 			}
 		}
+
+	case qbtypes.RequestTypeDistribution:
+		if data, ok := result.Value.(*qbtypes.DistributionData); ok {
+			return &qbtypes.Result{
+				Type: result.Type,
+				Value: filterDistributionValues(data, func(value *qbtypes.DistributionValue) bool {
+					timestampMs := uint64(value.Timestamp)
+					return timestampMs >= startMs && timestampMs < endMs
+				}),
+				Stats:    result.Stats,
+				Warnings: result.Warnings,
+			}
+		}
 	}
 
 	// For non-time series data, return as is
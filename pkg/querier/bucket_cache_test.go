@@ -3,6 +3,7 @@ package querier
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -1401,3 +1402,50 @@ func TestBucketCache_NoCache(t *testing.T) {
 	// The actual NoCache logic is implemented in querier.run(), not in bucket cache
 	// This test verifies that the cache works normally and NoCache bypasses it at a higher level
 }
+
+func TestBucketCache_DistributionData(t *testing.T) {
+	bc := createTestBucketCache(t)
+	ctx := context.Background()
+	orgID := valuer.UUID{}
+
+	query := &mockQuery{
+		fingerprint: "test-distribution-query",
+		startMs:     1000,
+		endMs:       5000,
+	}
+
+	labels := []*qbtypes.Label{{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Value: "frontend"}}
+	result := &qbtypes.Result{
+		Type: qbtypes.RequestTypeDistribution,
+		Value: &qbtypes.DistributionData{
+			QueryName: "A",
+			Series: []*qbtypes.DistributionSeries{{
+				Labels: labels,
+				Values: []*qbtypes.DistributionValue{
+					{Timestamp: 1000, Buckets: []*qbtypes.DistributionBucket{{Lower: 0, Upper: 1, Count: 1}}, Partial: true},
+					{Timestamp: 2000, Buckets: []*qbtypes.DistributionBucket{{Lower: 1, Upper: 2, Count: 3}, {Lower: 2, Upper: math.Inf(1), Count: 1}}},
+					{Timestamp: 3000, Buckets: []*qbtypes.DistributionBucket{{Lower: 1, Upper: 2, Count: 5}}},
+				},
+			}},
+		},
+	}
+
+	bc.Put(ctx, orgID, query, qbtypes.Step{Duration: 1000 * time.Millisecond}, result)
+	time.Sleep(10 * time.Millisecond)
+
+	cached, _ := bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: 1000 * time.Millisecond})
+	require.NotNil(t, cached)
+	assert.Equal(t, qbtypes.RequestTypeDistribution, cached.Type)
+
+	data, ok := cached.Value.(*qbtypes.DistributionData)
+	require.True(t, ok)
+	require.Len(t, data.Series, 1)
+	assert.Equal(t, "frontend", data.Series[0].Labels[0].Value)
+
+	// the partial value is not cached, the infinite bound survives the round trip
+	values := data.Series[0].Values
+	require.Len(t, values, 2)
+	assert.Equal(t, int64(2000), values[0].Timestamp)
+	assert.Equal(t, []*qbtypes.DistributionBucket{{Lower: 1, Upper: 2, Count: 3}, {Lower: 2, Upper: math.Inf(1), Count: 1}}, values[0].Buckets)
+	assert.Equal(t, int64(3000), values[1].Timestamp)
+}
@@ -11,11 +11,13 @@ import (
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
 	"github.com/SigNoz/signoz/pkg/telemetryschema/tracestelemetryschema"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
 	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -76,8 +78,13 @@ func newBuilderQuery[T any](
 
 func (q *builderQuery[T]) Fingerprint() string {
 
+	if len(qbtypes.SubQueriesIn(q.spec.Filter, q.variables)) > 0 {
+		// The values of a sub-query span the whole window, they cannot be cached by bucket
+		return ""
+	}
+
 	if (q.spec.Signal == telemetrytypes.SignalTraces ||
-		q.spec.Signal == telemetrytypes.SignalLogs) && q.kind != qbtypes.RequestTypeTimeSeries {
+		q.spec.Signal == telemetrytypes.SignalLogs) && q.kind != qbtypes.RequestTypeTimeSeries && q.kind != qbtypes.RequestTypeDistribution {
 		// No caching for non-timeseries queries
 		return ""
 	}
@@ -176,6 +183,11 @@ func (q *builderQuery[T]) Fingerprint() string {
 		parts = append(parts, fmt.Sprintf("shiftby=%d", q.spec.ShiftBy))
 	}
 
+	// distributions are cached apart from the time series of the same query
+	if q.kind == qbtypes.RequestTypeDistribution {
+		parts = append(parts, fmt.Sprintf("kind=%s", q.kind.StringValue()))
+	}
+
 	return strings.Join(parts, "&")
 }
 
@@ -223,7 +235,43 @@ func (q *builderQuery[T]) isWindowList() bool {
 
 // Statement renders the SQL without executing it, for the preview path.
 func (q *builderQuery[T]) Statement(ctx context.Context) (*qbtypes.Statement, error) {
-	return q.stmtBuilder.Build(ctx, q.orgID, q.fromMS, q.toMS, q.kind, q.spec, q.variables)
+	return q.buildStatement(ctx, q.fromMS, q.toMS)
+}
+
+// buildStatement builds the statement of the window, with the statements of the sub-queries
+// its filter reads as CTEs.
+func (q *builderQuery[T]) buildStatement(ctx context.Context, fromMS, toMS uint64) (*qbtypes.Statement, error) {
+	stmt, err := q.stmtBuilder.Build(ctx, q.orgID, fromMS, toMS, q.kind, q.spec, q.variables)
+	if err != nil {
+		return nil, err
+	}
+
+	subQueries := qbtypes.SubQueriesIn(q.spec.Filter, q.variables)
+	if len(subQueries) == 0 {
+		return stmt, nil
+	}
+
+	ctes := make([]string, 0, len(subQueries))
+	cteArgs := make([][]any, 0, len(subQueries))
+	for _, subQuery := range subQueries {
+		subStmt, err := subQuery.Statement.Statement(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ctes = append(ctes, fmt.Sprintf("%s AS (%s)", subQuery.CTEName(), subStmt.Query))
+		cteArgs = append(cteArgs, subStmt.Args)
+		stmt.Warnings = append(stmt.Warnings, subStmt.Warnings...)
+	}
+
+	// the statement may already start with its own CTEs, which can read the sub-queries
+	query, found := strings.CutPrefix(stmt.Query, "WITH ")
+	if found {
+		stmt.Query = "WITH " + strings.Join(ctes, ", ") + ", " + query
+	} else {
+		stmt.Query = querybuilder.CombineCTEs(ctes) + stmt.Query
+	}
+	stmt.Args = querybuilder.PrependArgs(cteArgs, stmt.Args)
+	return stmt, nil
 }
 
 func (q *builderQuery[T]) Execute(ctx context.Context) (*qbtypes.Result, error) {
@@ -247,7 +295,7 @@ func (q *builderQuery[T]) Execute(ctx context.Context) (*qbtypes.Result, error)
 		}
 	}
 
-	stmt, err := q.stmtBuilder.Build(ctx, q.orgID, fromMS, toMS, q.kind, q.spec, q.variables)
+	stmt, err := q.buildStatement(ctx, fromMS, toMS)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +304,14 @@ func (q *builderQuery[T]) Execute(ctx context.Context) (*qbtypes.Result, error)
 		return nil, err
 	}
 
+	// Let the telemetry store count the aggregation queries of every shape, for rollups
+	// to be suggested for the most frequent ones.
+	if q.kind == qbtypes.RequestTypeTimeSeries || q.kind == qbtypes.RequestTypeScalar {
+		if shape, ok := rolluptypes.ShapeOf(q.spec); ok {
+			ctx = ctxtypes.NewContextWithQueryShape(ctx, ctxtypes.QueryShape{OrgID: q.orgID.StringValue(), Key: shape.Key()})
+		}
+	}
+
 	// Execute the query with proper context for partial value detection
 	result, err := q.executeWithContext(ctx, stmt.Query, stmt.Args)
 	if err != nil {
@@ -410,6 +466,8 @@ func emptyResultFor(kind qbtypes.RequestType, queryName string) *qbtypes.Result
 		value = &qbtypes.TimeSeriesData{QueryName: queryName}
 	case qbtypes.RequestTypeScalar:
 		value = &qbtypes.ScalarData{QueryName: queryName}
+	case qbtypes.RequestTypeDistribution:
+		value = &qbtypes.DistributionData{QueryName: queryName}
 	default:
 		value = &qbtypes.RawData{QueryName: queryName}
 	}
@@ -435,6 +493,7 @@ func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string,
 		totalBytes += p.Bytes
 		elapsed += p.Elapsed
 	}))
+	ctx, recordStatement := queryProfileFromContext(ctx).statement(ctx, query, args)
 
 	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
 	if err != nil {
@@ -460,7 +519,7 @@ func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string,
 
 	kind := q.kind
 	// all metric queries are time series then reduced if required
-	if q.spec.Signal == telemetrytypes.SignalMetrics {
+	if q.spec.Signal == telemetrytypes.SignalMetrics && kind != qbtypes.RequestTypeDistribution {
 		kind = qbtypes.RequestTypeTimeSeries
 	}
 
@@ -468,6 +527,7 @@ func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string,
 	if err != nil {
 		return nil, err
 	}
+	recordStatement(totalRows, totalBytes)
 
 	// TODO: This should move to readAsRaw function in consume.go but for now we are keeping it here since it's only relevant for traces
 	if q.spec.Signal == telemetrytypes.SignalTraces {
@@ -567,7 +627,7 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 		q.spec.Offset = 0
 		q.spec.Limit = need
 
-		stmt, err := q.stmtBuilder.Build(ctx, q.orgID, r.fromNS/1e6, r.toNS/1e6, q.kind, q.spec, q.variables)
+		stmt, err := q.buildStatement(ctx, r.fromNS/1e6, r.toNS/1e6)
 		if err != nil {
 			return nil, err
 		}
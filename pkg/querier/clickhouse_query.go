@@ -139,6 +139,7 @@ func (q *chSQLQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	ctx, recordStatement := queryProfileFromContext(ctx).statement(ctx, query, q.args)
 
 	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, q.args...)
 	if err != nil {
@@ -150,6 +151,7 @@ func (q *chSQLQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	recordStatement(totalRows, totalBytes)
 
 	return &qbtypes.Result{
 		Type:  q.kind,
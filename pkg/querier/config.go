@@ -20,6 +20,10 @@ type Config struct {
 	MaxConcurrentQueries int `yaml:"max_concurrent_queries" mapstructure:"max_concurrent_queries"`
 	// LogTraceIDWindowPadding is the padding added to narrowed down timerange from trace summary to logs with trace_id filter.
 	LogTraceIDWindowPadding time.Duration `yaml:"log_trace_id_window_padding" mapstructure:"log_trace_id_window_padding"`
+	// Admission is the admission control of queries based on their estimated cost.
+	Admission AdmissionConfig `yaml:"admission" mapstructure:"admission"`
+	// Scheduler shares the querier between orgs and users, with quotas.
+	Scheduler SchedulerConfig `yaml:"scheduler" mapstructure:"scheduler"`
 
 	// Keys sit under querier.skip_resource_fingerprint.
 	statementbuilder.Config `mapstructure:",squash" yaml:",squash"`
@@ -38,6 +42,16 @@ func newConfig() factory.Config {
 		MaxConcurrentQueries:    DefaultMaxConcurrentQueries,
 		LogTraceIDWindowPadding: 5 * time.Minute,
 		Config:                  statementbuilder.NewConfig(),
+		Admission: AdmissionConfig{
+			Enabled: false,
+			Action:  AdmissionActionReject,
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:              false,
+			MaxConcurrentQueries: 32,
+			MaxQueueDuration:     30 * time.Second,
+			Org:                  TenantLimits{Weight: 1},
+		},
 	}
 }
 
@@ -55,6 +69,12 @@ func (c Config) Validate() error {
 	if c.LogTraceIDWindowPadding < 0 {
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "log_trace_id_window_padding must not be negative, got %v", c.LogTraceIDWindowPadding)
 	}
+	if err := c.Admission.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.Validate(); err != nil {
+		return err
+	}
 	// Embedded Validate is shadowed by this one; call it explicitly.
 	if err := c.Config.Validate(); err != nil {
 		return err
@@ -60,6 +60,8 @@ func consume(rows driver.Rows, kind qbtypes.RequestType, queryWindow *qbtypes.Ti
 		payload, err = readAsScalar(rows, queryName)
 	case qbtypes.RequestTypeRaw, qbtypes.RequestTypeTrace, qbtypes.RequestTypeRawStream:
 		payload, err = readAsRaw(rows, queryName)
+	case qbtypes.RequestTypeDistribution:
+		payload, err = readAsDistribution(rows, queryWindow, step, queryName)
 		// TODO: add support for other request types
 	}
 
@@ -85,36 +87,7 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 	}
 	seriesMap := map[sKey]*qbtypes.TimeSeries{}
 
-	stepMs := uint64(step.Milliseconds())
-
-	// Helper function to check if a timestamp represents a partial value
-	isPartialValue := func(timestamp int64) bool {
-		if stepMs == 0 || queryWindow == nil {
-			return false
-		}
-
-		timestampMs := uint64(timestamp)
-
-		// For the first interval, check if query start is misaligned
-		// The first complete interval starts at the first timestamp >= queryWindow.From that is aligned to step
-		firstCompleteInterval := queryWindow.From
-		if queryWindow.From%stepMs != 0 {
-			// Round up to next step boundary
-			firstCompleteInterval = ((queryWindow.From / stepMs) + 1) * stepMs
-		}
-
-		// If timestamp is before the first complete interval, it's partial
-		if timestampMs < firstCompleteInterval {
-			return true
-		}
-
-		// For the last interval, check if it would extend beyond query end
-		if timestampMs+stepMs > queryWindow.To {
-			return queryWindow.To%stepMs != 0
-		}
-
-		return false
-	}
+	isPartialValue := partialStepChecker(queryWindow, step)
 
 	// Pre-allocate for labels based on column count
 	lblValsCapacity := len(colNames) - 1 // -1 for timestamp
@@ -282,6 +255,145 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 	}, nil
 }
 
+// readAsDistribution reads one row per bucket, step and group: the group-by labels, the
+// bounds of the bucket and its count as __result_0. Rows of the same bucket are summed.
+func readAsDistribution(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbtypes.Step, queryName string) (*qbtypes.DistributionData, error) {
+	colTypes := rows.ColumnTypes()
+	colNames := rows.Columns()
+
+	slots := make([]any, len(colTypes))
+	for i, ct := range colTypes {
+		slots[i] = reflect.New(ct.ScanType()).Interface()
+	}
+
+	type bucketKey struct{ lower, upper float64 }
+	type series struct {
+		labels []*qbtypes.Label
+		values map[int64]map[bucketKey]float64
+	}
+	seriesMap := map[string]*series{}
+	var seriesKeys []string
+
+	isPartialValue := partialStepChecker(queryWindow, step)
+
+	for rows.Next() {
+		if err := rows.Scan(slots...); err != nil {
+			return nil, err
+		}
+
+		var (
+			ts                  int64
+			lower, upper, count = math.NaN(), math.NaN(), math.NaN()
+			lblVals             []string
+			lblObjs             []*qbtypes.Label
+		)
+
+		for idx, ptr := range slots {
+			name := stripKeyAlias(colNames[idx])
+
+			switch v := ptr.(type) {
+			case *time.Time:
+				ts = v.UnixMilli()
+				continue
+			case *string:
+				lblVals = append(lblVals, *v)
+				lblObjs = append(lblObjs, &qbtypes.Label{Key: telemetrytypes.TelemetryFieldKey{Name: name}, Value: *v})
+				continue
+			case **string:
+				val := ""
+				if *v != nil {
+					val = **v
+				}
+				lblVals = append(lblVals, val)
+				lblObjs = append(lblObjs, &qbtypes.Label{Key: telemetrytypes.TelemetryFieldKey{Name: name}, Value: val})
+				continue
+			}
+
+			val := numericAsFloat(derefValue(reflect.ValueOf(ptr).Elem().Interface()))
+			switch name {
+			case qbtypes.DistributionBucketLowerColumn:
+				lower = val
+			case qbtypes.DistributionBucketUpperColumn:
+				upper = val
+			case "__result_0":
+				count = val
+			}
+		}
+
+		if ts == 0 || math.IsNaN(lower) || math.IsNaN(upper) || math.IsNaN(count) || count <= 0 {
+			continue
+		}
+
+		sort.Strings(lblVals)
+		labelsKey := strings.Join(lblVals, ",")
+		s, ok := seriesMap[labelsKey]
+		if !ok {
+			s = &series{labels: lblObjs, values: map[int64]map[bucketKey]float64{}}
+			seriesMap[labelsKey] = s
+			seriesKeys = append(seriesKeys, labelsKey)
+		}
+		if s.values[ts] == nil {
+			s.values[ts] = map[bucketKey]float64{}
+		}
+		s.values[ts][bucketKey{lower: lower, upper: upper}] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	data := &qbtypes.DistributionData{QueryName: queryName}
+	for _, key := range seriesKeys {
+		s := seriesMap[key]
+		out := &qbtypes.DistributionSeries{Labels: s.labels}
+		for ts, buckets := range s.values {
+			value := &qbtypes.DistributionValue{Timestamp: ts, Partial: isPartialValue(ts)}
+			for b, count := range buckets {
+				value.Buckets = append(value.Buckets, &qbtypes.DistributionBucket{Lower: b.lower, Upper: b.upper, Count: count})
+			}
+			sort.Slice(value.Buckets, func(i, j int) bool { return value.Buckets[i].Lower < value.Buckets[j].Lower })
+			out.Values = append(out.Values, value)
+		}
+		sort.Slice(out.Values, func(i, j int) bool { return out.Values[i].Timestamp < out.Values[j].Timestamp })
+		data.Series = append(data.Series, out)
+	}
+
+	return data, nil
+}
+
+// partialStepChecker returns a function reporting whether the step starting at the
+// timestamp (in milliseconds) is only partially covered by the query window.
+func partialStepChecker(queryWindow *qbtypes.TimeRange, step qbtypes.Step) func(int64) bool {
+	stepMs := uint64(step.Milliseconds())
+
+	return func(timestamp int64) bool {
+		if stepMs == 0 || queryWindow == nil {
+			return false
+		}
+
+		timestampMs := uint64(timestamp)
+
+		// For the first interval, check if query start is misaligned
+		// The first complete interval starts at the first timestamp >= queryWindow.From that is aligned to step
+		firstCompleteInterval := queryWindow.From
+		if queryWindow.From%stepMs != 0 {
+			// Round up to next step boundary
+			firstCompleteInterval = ((queryWindow.From / stepMs) + 1) * stepMs
+		}
+
+		// If timestamp is before the first complete interval, it's partial
+		if timestampMs < firstCompleteInterval {
+			return true
+		}
+
+		// For the last interval, check if it would extend beyond query end
+		if timestampMs+stepMs > queryWindow.To {
+			return queryWindow.To%stepMs != 0
+		}
+
+		return false
+	}
+}
+
 func isNumericKind(t reflect.Type) bool {
 	if t == nil {
 		return false
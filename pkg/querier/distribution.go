@@ -0,0 +1,121 @@
+package querier
+
+import (
+	"slices"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+// mergeDistributionData merges the series of the distributions by their labels. When
+// more than one distribution has a value at the same timestamp, the first one is kept.
+func mergeDistributionData(values ...*qbtypes.DistributionData) *qbtypes.DistributionData {
+	merged := &qbtypes.DistributionData{}
+	seriesMap := make(map[string]*qbtypes.DistributionSeries)
+	seen := make(map[string]map[int64]bool)
+
+	for _, data := range values {
+		if data == nil {
+			continue
+		}
+		if merged.QueryName == "" {
+			merged.QueryName = data.QueryName
+		}
+		for _, series := range data.Series {
+			key := qbtypes.GetUniqueSeriesKey(series.Labels)
+			existing, ok := seriesMap[key]
+			if !ok {
+				existing = &qbtypes.DistributionSeries{Labels: series.Labels}
+				seriesMap[key] = existing
+				seen[key] = make(map[int64]bool)
+				merged.Series = append(merged.Series, existing)
+			}
+			for _, value := range series.Values {
+				if seen[key][value.Timestamp] {
+					continue
+				}
+				seen[key][value.Timestamp] = true
+				existing.Values = append(existing.Values, value)
+			}
+		}
+	}
+
+	for _, series := range merged.Series {
+		slices.SortFunc(series.Values, func(a, b *qbtypes.DistributionValue) int {
+			if a.Timestamp < b.Timestamp {
+				return -1
+			}
+			if a.Timestamp > b.Timestamp {
+				return 1
+			}
+			return 0
+		})
+	}
+
+	return merged
+}
+
+// mergeDistributionResults merges the fresh distributions into the cached one.
+func mergeDistributionResults(cachedValue *qbtypes.DistributionData, freshResults []*qbtypes.Result) *qbtypes.DistributionData {
+	values := []*qbtypes.DistributionData{cachedValue}
+	for _, result := range freshResults {
+		if fresh, ok := result.Value.(*qbtypes.DistributionData); ok {
+			values = append(values, fresh)
+		}
+	}
+	return mergeDistributionData(values...)
+}
+
+// filterDistributionValues returns a copy of the distribution with only the values keep
+// accepts. Series left without values are kept, so filtered empty results stay cached.
+func filterDistributionValues(data *qbtypes.DistributionData, keep func(*qbtypes.DistributionValue) bool) *qbtypes.DistributionData {
+	filtered := &qbtypes.DistributionData{
+		QueryName: data.QueryName,
+		Series:    make([]*qbtypes.DistributionSeries, 0, len(data.Series)),
+	}
+	for _, series := range data.Series {
+		filteredSeries := &qbtypes.DistributionSeries{
+			Labels: series.Labels,
+			Values: make([]*qbtypes.DistributionValue, 0, len(series.Values)),
+		}
+		for _, value := range series.Values {
+			if keep(value) {
+				filteredSeries.Values = append(filteredSeries.Values, value)
+			}
+		}
+		filtered.Series = append(filtered.Series, filteredSeries)
+	}
+	return filtered
+}
+
+// limitDistributionSeries keeps the limit series with the most values, in their order.
+func limitDistributionSeries(series []*qbtypes.DistributionSeries, limit int) []*qbtypes.DistributionSeries {
+	if limit <= 0 || len(series) <= limit {
+		return series
+	}
+
+	totals := make(map[*qbtypes.DistributionSeries]float64, len(series))
+	for _, s := range series {
+		for _, value := range s.Values {
+			for _, bucket := range value.Buckets {
+				totals[s] += bucket.Count
+			}
+		}
+	}
+
+	ranked := slices.Clone(series)
+	slices.SortStableFunc(ranked, func(a, b *qbtypes.DistributionSeries) int {
+		if totals[a] > totals[b] {
+			return -1
+		}
+		if totals[a] < totals[b] {
+			return 1
+		}
+		return 0
+	})
+	kept := make(map[*qbtypes.DistributionSeries]bool, limit)
+	for _, s := range ranked[:limit] {
+		kept[s] = true
+	}
+
+	return slices.DeleteFunc(slices.Clone(series), func(s *qbtypes.DistributionSeries) bool { return !kept[s] })
+}
@@ -0,0 +1,101 @@
+package querier
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	cmock "github.com/SigNoz/clickhouse-go-mock"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderQueryDistribution(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{})
+	rows := cmock.NewRows([]cmock.ColumnType{
+		{Name: "ts", Type: "DateTime"},
+		{Name: "__GROUP_BY_KEY_0_service.name", Type: "String"},
+		{Name: "__bucket_lower", Type: "Float64"},
+		{Name: "__bucket_upper", Type: "Float64"},
+		{Name: "__result_0", Type: "Float64"},
+	}, [][]any{
+		{time.UnixMilli(120000), "frontend", float64(2), float64(4), float64(3)},
+		{time.UnixMilli(120000), "frontend", float64(0), float64(2), float64(1)},
+		// the same bucket from the reduced tables
+		{time.UnixMilli(120000), "frontend", float64(2), float64(4), float64(2)},
+		{time.UnixMilli(120000), "frontend", float64(4), float64(8), float64(0)},
+		{time.UnixMilli(60000), "cart", float64(0), float64(2), float64(7)},
+	})
+	telemetryStore.Mock().ExpectQuery("SELECT distribution").WillReturnRows(rows)
+
+	// metric queries are read as distributions, not time series
+	query := newBuilderQuery(slog.Default(), telemetryStore, valuer.GenerateUUID(),
+		&mockJoinStmtBuilder[qbtypes.MetricAggregation]{query: "SELECT distribution"},
+		qbtypes.QueryTypeBuilder, qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+			Name:         "A",
+			Signal:       telemetrytypes.SignalMetrics,
+			StepInterval: qbtypes.Step{Duration: time.Minute},
+		},
+		qbtypes.TimeRange{From: 60000, To: 150000}, qbtypes.RequestTypeDistribution, nil, builderConfig{})
+
+	result, err := query.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, qbtypes.RequestTypeDistribution, result.Type)
+
+	data, ok := result.Value.(*qbtypes.DistributionData)
+	require.True(t, ok)
+	assert.Equal(t, "A", data.QueryName)
+	require.Len(t, data.Series, 2)
+
+	frontend := data.Series[0]
+	assert.Equal(t, "service.name", frontend.Labels[0].Key.Name)
+	assert.Equal(t, "frontend", frontend.Labels[0].Value)
+	require.Len(t, frontend.Values, 1)
+	assert.Equal(t, int64(120000), frontend.Values[0].Timestamp)
+	assert.True(t, frontend.Values[0].Partial)
+	assert.Equal(t, []*qbtypes.DistributionBucket{
+		{Lower: 0, Upper: 2, Count: 1},
+		{Lower: 2, Upper: 4, Count: 5},
+	}, frontend.Values[0].Buckets)
+
+	cart := data.Series[1]
+	require.Len(t, cart.Values, 1)
+	assert.False(t, cart.Values[0].Partial)
+	assert.Equal(t, []*qbtypes.DistributionBucket{{Lower: 0, Upper: 2, Count: 7}}, cart.Values[0].Buckets)
+}
+
+func TestMergeDistributionData(t *testing.T) {
+	label := func(v string) []*qbtypes.Label {
+		return []*qbtypes.Label{{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Value: v}}
+	}
+	value := func(ts int64, count float64) *qbtypes.DistributionValue {
+		return &qbtypes.DistributionValue{Timestamp: ts, Buckets: []*qbtypes.DistributionBucket{{Lower: 0, Upper: 1, Count: count}}}
+	}
+
+	cached := &qbtypes.DistributionData{QueryName: "A", Series: []*qbtypes.DistributionSeries{
+		{Labels: label("frontend"), Values: []*qbtypes.DistributionValue{value(2000, 2), value(1000, 1)}},
+	}}
+	fresh := []*qbtypes.Result{{Value: &qbtypes.DistributionData{QueryName: "A", Series: []*qbtypes.DistributionSeries{
+		{Labels: label("frontend"), Values: []*qbtypes.DistributionValue{value(2000, 20), value(3000, 3)}},
+		{Labels: label("cart"), Values: []*qbtypes.DistributionValue{value(1000, 10)}},
+	}}}}
+
+	merged := mergeDistributionResults(cached, fresh)
+	assert.Equal(t, "A", merged.QueryName)
+	require.Len(t, merged.Series, 2)
+	assert.Equal(t, []*qbtypes.DistributionValue{value(1000, 1), value(2000, 2), value(3000, 3)}, merged.Series[0].Values)
+	assert.Equal(t, []*qbtypes.DistributionValue{value(1000, 10)}, merged.Series[1].Values)
+	// the cached series are not modified
+	assert.Len(t, cached.Series[0].Values, 2)
+
+	limited := limitDistributionSeries(merged.Series, 1)
+	require.Len(t, limited, 1)
+	assert.Equal(t, "cart", limited[0].Labels[0].Value)
+	assert.Len(t, limitDistributionSeries(merged.Series, 0), 2)
+}
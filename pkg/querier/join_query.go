@@ -0,0 +1,377 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	joinLeftCTE  = "__join_left"
+	joinRightCTE = "__join_right"
+)
+
+// joinSide is a builder query read by a join. Its statement is compiled by the statement
+// builder of its signal and becomes a CTE of the join.
+type joinSide struct {
+	name         string
+	stmt         qbtypes.StatementProvider
+	groupBy      []qbtypes.GroupByKey
+	aggregations int
+	// metric statements expose their single aggregation as `value` instead of __result_0.
+	metric bool
+	step   qbtypes.Step
+}
+
+// groupByColumn returns the column of the i-th group by key, aliased the way every
+// statement builder aliases its group by keys.
+func (s joinSide) groupByColumn(alias string, i int) string {
+	return fmt.Sprintf("%s.`__GROUP_BY_KEY_%d_%s`", alias, i, s.groupBy[i].Name)
+}
+
+func (s joinSide) aggregationColumn(alias string, i int) string {
+	if s.metric {
+		return alias + ".value"
+	}
+	return fmt.Sprintf("%s.__result_%d", alias, i)
+}
+
+func (s joinSide) groupByIndex(key string) int {
+	return slices.IndexFunc(s.groupBy, func(g qbtypes.GroupByKey) bool { return g.Name == key })
+}
+
+type joinQuery struct {
+	telemetryStore telemetrystore.TelemetryStore
+	spec           qbtypes.QueryBuilderJoin
+	conditions     []qbtypes.JoinCondition
+	left           joinSide
+	right          joinSide
+	fromMS         uint64
+	toMS           uint64
+	kind           qbtypes.RequestType
+}
+
+var _ qbtypes.Query = (*joinQuery)(nil)
+var _ qbtypes.StatementProvider = (*joinQuery)(nil)
+
+// newJoinQuery resolves the queries referenced by the join and checks that they can be
+// joined: both must be builder queries and the On keys must be among their group by keys.
+func (q *querier) newJoinQuery(orgID valuer.UUID, spec qbtypes.QueryBuilderJoin, req *qbtypes.QueryRangeRequest, tmplVars map[string]qbtypes.VariableItem) (*joinQuery, error) {
+	if req.RequestType != qbtypes.RequestTypeScalar && req.RequestType != qbtypes.RequestTypeTimeSeries {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q supports only %s and %s requests", spec.Name, qbtypes.RequestTypeScalar.StringValue(), qbtypes.RequestTypeTimeSeries.StringValue())
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	conditions, err := spec.Conditions()
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := q.newJoinSide(orgID, spec.Name, spec.Left.Name, req, tmplVars)
+	if err != nil {
+		return nil, err
+	}
+	right, err := q.newJoinSide(orgID, spec.Name, spec.Right.Name, req, tmplVars)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, condition := range conditions {
+		if left.groupByIndex(condition.LeftKey) < 0 {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q key %q is not a group by key of query %q", spec.Name, condition.LeftKey, left.name)
+		}
+		if right.groupByIndex(condition.RightKey) < 0 {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q key %q is not a group by key of query %q", spec.Name, condition.RightKey, right.name)
+		}
+	}
+
+	if req.RequestType == qbtypes.RequestTypeTimeSeries && left.step.Duration != right.step.Duration {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q requires queries %q and %q to have the same step interval, got %s and %s", spec.Name, left.name, right.name, left.step.String(), right.step.String())
+	}
+
+	return &joinQuery{
+		telemetryStore: q.telemetryStore,
+		spec:           spec,
+		conditions:     conditions,
+		left:           left,
+		right:          right,
+		fromMS:         req.Start,
+		toMS:           req.End,
+		kind:           req.RequestType,
+	}, nil
+}
+
+func (q *querier) newJoinSide(orgID valuer.UUID, joinName string, name string, req *qbtypes.QueryRangeRequest, tmplVars map[string]qbtypes.VariableItem) (joinSide, error) {
+	idx := slices.IndexFunc(req.CompositeQuery.Queries, func(e qbtypes.QueryEnvelope) bool { return e.GetQueryName() == name })
+	if idx < 0 {
+		return joinSide{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q references unknown query %q", joinName, name)
+	}
+	envelope := req.CompositeQuery.Queries[idx]
+	if envelope.Type != qbtypes.QueryTypeBuilder {
+		return joinSide{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q can only reference builder queries, %q is a %s query", joinName, name, envelope.Type.StringValue())
+	}
+
+	queryRange := qbtypes.TimeRange{From: req.Start, To: req.End}
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		spec.ShiftBy = extractShiftFromBuilderQuery(spec)
+		timeRange := adjustTimeRangeForShift(spec, queryRange, req.RequestType)
+		return joinSide{
+			name:         name,
+			stmt:         newBuilderQuery(q.logger, q.telemetryStore, orgID, q.traceStmtBuilder, envelope.Type, spec, timeRange, req.RequestType, tmplVars, builderConfig{}),
+			groupBy:      spec.GroupBy,
+			aggregations: len(spec.Aggregations),
+			step:         spec.StepInterval,
+		}, nil
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		spec.ShiftBy = extractShiftFromBuilderQuery(spec)
+		timeRange := adjustTimeRangeForShift(spec, queryRange, req.RequestType)
+		stmtBuilder := q.logStmtBuilder
+		if spec.Source == telemetrytypes.SourceAudit {
+			stmtBuilder = q.auditStmtBuilder
+		}
+		return joinSide{
+			name:         name,
+			stmt:         newBuilderQuery(q.logger, q.telemetryStore, orgID, stmtBuilder, envelope.Type, spec, timeRange, req.RequestType, tmplVars, q.builderConfig),
+			groupBy:      spec.GroupBy,
+			aggregations: len(spec.Aggregations),
+			step:         spec.StepInterval,
+		}, nil
+	case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+		// metric queries are reduced to scalars after they are read, which a join cannot do in SQL
+		if req.RequestType != qbtypes.RequestTypeTimeSeries {
+			return joinSide{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q supports metric query %q only in %s requests", joinName, name, qbtypes.RequestTypeTimeSeries.StringValue())
+		}
+		spec.ShiftBy = extractShiftFromBuilderQuery(spec)
+		timeRange := adjustTimeRangeForShift(spec, queryRange, req.RequestType)
+		stmtBuilder := q.metricStmtBuilder
+		if spec.Source == telemetrytypes.SourceMeter {
+			stmtBuilder = q.meterStmtBuilder
+		}
+		return joinSide{
+			name:         name,
+			stmt:         newBuilderQuery(q.logger, q.telemetryStore, orgID, stmtBuilder, envelope.Type, spec, timeRange, req.RequestType, tmplVars, builderConfig{}),
+			groupBy:      spec.GroupBy,
+			aggregations: 1,
+			metric:       true,
+			step:         spec.StepInterval,
+		}, nil
+	default:
+		return joinSide{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q references query %q with unsupported spec type %T", joinName, name, envelope.Spec)
+	}
+}
+
+func (q *joinQuery) Fingerprint() string {
+	return ""
+}
+
+func (q *joinQuery) Window() (uint64, uint64) {
+	return q.fromMS, q.toMS
+}
+
+// Statement renders the SQL without executing it, for the preview path.
+func (q *joinQuery) Statement(ctx context.Context) (*qbtypes.Statement, error) {
+	leftStmt, err := q.left.stmt.Statement(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rightStmt, err := q.right.stmt.Statement(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildJoinStatement(q.spec, q.conditions, q.kind, q.left, q.right, leftStmt, rightStmt)
+}
+
+func (q *joinQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
+	stmt, err := q.Statement(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.QueryDuration: instrumentationtypes.DurationBucket(q.fromMS, q.toMS),
+	})
+
+	totalRows := uint64(0)
+	totalBytes := uint64(0)
+	elapsed := time.Duration(0)
+
+	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
+		totalRows += p.Rows
+		totalBytes += p.Bytes
+		elapsed += p.Elapsed
+	}))
+	ctx, recordStatement := queryProfileFromContext(ctx).statement(ctx, stmt.Query, stmt.Args)
+
+	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, stmt.Query, stmt.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	queryWindow := &qbtypes.TimeRange{From: q.fromMS, To: q.toMS}
+	payload, err := consume(rows, q.kind, queryWindow, q.left.step, q.spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	recordStatement(totalRows, totalBytes)
+
+	return &qbtypes.Result{
+		Type:     q.kind,
+		Value:    payload,
+		Warnings: stmt.Warnings,
+		Stats: qbtypes.ExecStats{
+			RowsScanned:  totalRows,
+			BytesScanned: totalBytes,
+			DurationMS:   uint64(elapsed.Milliseconds()),
+		},
+	}, nil
+}
+
+// buildJoinStatement joins the statements of the two sides. Time series are also joined
+// on their timestamp, so a cross join of time series pairs every series of both sides at
+// each step. The On keys are output once, under the name of the left key; the other group
+// by keys of the right side are prefixed with its query name when they clash with a left
+// one. Aggregations keep their order, the ones of the right side following the left ones.
+func buildJoinStatement(
+	spec qbtypes.QueryBuilderJoin,
+	conditions []qbtypes.JoinCondition,
+	kind qbtypes.RequestType,
+	left, right joinSide,
+	leftStmt, rightStmt *qbtypes.Statement,
+) (*qbtypes.Statement, error) {
+	// pick resolves an output column present on both sides: the side preserved by the
+	// join provides it, and a full join takes whichever side matched.
+	pick := func(leftColumn, rightColumn string) string {
+		switch spec.Type {
+		case qbtypes.JoinTypeRight:
+			return rightColumn
+		case qbtypes.JoinTypeFull:
+			return fmt.Sprintf("assumeNotNull(coalesce(%s, %s))", leftColumn, rightColumn)
+		default:
+			return leftColumn
+		}
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	on := []string{}
+	if kind == qbtypes.RequestTypeTimeSeries {
+		sb.SelectMore(pick("l.ts", "r.ts") + " AS ts")
+		on = append(on, "l.ts = r.ts")
+	}
+
+	labels := []string{}
+	joinedLeft := map[int]bool{}
+	joinedRight := map[int]bool{}
+	for _, condition := range conditions {
+		li, ri := left.groupByIndex(condition.LeftKey), right.groupByIndex(condition.RightKey)
+		leftColumn, rightColumn := left.groupByColumn("l", li), right.groupByColumn("r", ri)
+		on = append(on, fmt.Sprintf("%s = %s", leftColumn, rightColumn))
+		if joinedLeft[li] {
+			continue
+		}
+		joinedLeft[li], joinedRight[ri] = true, true
+		sb.SelectMore(fmt.Sprintf("%s AS `%s`", pick(leftColumn, rightColumn), condition.LeftKey))
+		labels = append(labels, condition.LeftKey)
+	}
+	for i, key := range left.groupBy {
+		if joinedLeft[i] {
+			continue
+		}
+		sb.SelectMore(fmt.Sprintf("%s AS `%s`", left.groupByColumn("l", i), key.Name))
+		labels = append(labels, key.Name)
+	}
+	for i, key := range right.groupBy {
+		if joinedRight[i] {
+			continue
+		}
+		label := key.Name
+		if slices.Contains(labels, label) {
+			label = right.name + "." + key.Name
+		}
+		sb.SelectMore(fmt.Sprintf("%s AS `%s`", right.groupByColumn("r", i), label))
+		labels = append(labels, label)
+	}
+
+	for i := range left.aggregations {
+		sb.SelectMore(fmt.Sprintf("%s AS __result_%d", left.aggregationColumn("l", i), i))
+	}
+	for i := range right.aggregations {
+		sb.SelectMore(fmt.Sprintf("%s AS __result_%d", right.aggregationColumn("r", i), left.aggregations+i))
+	}
+
+	sb.From(joinLeftCTE + " AS l")
+	switch {
+	case spec.Type == qbtypes.JoinTypeCross && len(on) == 0:
+		sb.JoinWithOption(sqlbuilder.JoinOption("CROSS"), joinRightCTE+" AS r")
+	case spec.Type == qbtypes.JoinTypeLeft:
+		sb.JoinWithOption(sqlbuilder.LeftJoin, joinRightCTE+" AS r", on...)
+	case spec.Type == qbtypes.JoinTypeRight:
+		sb.JoinWithOption(sqlbuilder.RightJoin, joinRightCTE+" AS r", on...)
+	case spec.Type == qbtypes.JoinTypeFull:
+		sb.JoinWithOption(sqlbuilder.FullOuterJoin, joinRightCTE+" AS r", on...)
+	default:
+		sb.JoinWithOption(sqlbuilder.InnerJoin, joinRightCTE+" AS r", on...)
+	}
+
+	// time series are limited after they are read, see postProcessJoin
+	if kind == qbtypes.RequestTypeScalar {
+		numAggregations := left.aggregations + right.aggregations
+		if len(spec.Order) == 0 {
+			sb.OrderBy("__result_0 DESC")
+		}
+		for _, order := range spec.Order {
+			column, err := joinOrderColumn(spec.Name, order.Key.Name, labels, numAggregations)
+			if err != nil {
+				return nil, err
+			}
+			sb.OrderBy(column + " " + order.Direction.StringValue())
+		}
+		if spec.Limit > 0 {
+			sb.Limit(spec.Limit)
+		}
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	ctes := []string{
+		fmt.Sprintf("%s AS (%s)", joinLeftCTE, leftStmt.Query),
+		fmt.Sprintf("%s AS (%s)", joinRightCTE, rightStmt.Query),
+	}
+
+	return &qbtypes.Statement{
+		Query:    querybuilder.CombineCTEs(ctes) + mainSQL + " SETTINGS join_use_nulls = 1",
+		Args:     querybuilder.PrependArgs([][]any{leftStmt.Args, rightStmt.Args}, mainArgs),
+		Warnings: append(slices.Clone(leftStmt.Warnings), rightStmt.Warnings...),
+	}, nil
+}
+
+// joinOrderColumn resolves an order by key of a scalar join: an output label, an
+// aggregation (__result_N) or the first aggregation (__result).
+func joinOrderColumn(joinName string, key string, labels []string, numAggregations int) (string, error) {
+	if key == qbtypes.DefaultOrderByKey {
+		return "__result_0", nil
+	}
+	if m := aggRe.FindStringSubmatch(key); m != nil {
+		if idx, _ := strconv.Atoi(m[1]); idx < numAggregations {
+			return key, nil
+		}
+	}
+	if slices.Contains(labels, key) {
+		return "`" + key + "`", nil
+	}
+	return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q cannot order by %q, it is neither a key nor an aggregation of the join", joinName, key)
+}
@@ -0,0 +1,296 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmock "github.com/SigNoz/clickhouse-go-mock"
+
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockJoinStmtBuilder returns a fixed statement for every query it builds.
+type mockJoinStmtBuilder[T any] struct {
+	query string
+	args  []any
+}
+
+func (m *mockJoinStmtBuilder[T]) Build(_ context.Context, _ valuer.UUID, _, _ uint64, _ qbtypes.RequestType, _ qbtypes.QueryBuilderQuery[T], _ map[string]qbtypes.VariableItem) (*qbtypes.Statement, error) {
+	return &qbtypes.Statement{Query: m.query, Args: m.args}, nil
+}
+
+func joinGroupBy(names ...string) []qbtypes.GroupByKey {
+	keys := make([]qbtypes.GroupByKey, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: name}})
+	}
+	return keys
+}
+
+func TestBuildJoinStatement(t *testing.T) {
+	leftStmt := &qbtypes.Statement{Query: "SELECT left", Args: []any{"a"}, Warnings: []string{"left warning"}}
+	rightStmt := &qbtypes.Statement{Query: "SELECT right", Args: []any{"b"}}
+
+	cases := []struct {
+		name     string
+		spec     qbtypes.QueryBuilderJoin
+		kind     qbtypes.RequestType
+		left     joinSide
+		right    joinSide
+		expected string
+		args     []any
+	}{
+		{
+			name:  "inner time series",
+			spec:  qbtypes.QueryBuilderJoin{Name: "C", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "B"}, Type: qbtypes.JoinTypeInner, On: "service.name"},
+			kind:  qbtypes.RequestTypeTimeSeries,
+			left:  joinSide{name: "A", groupBy: joinGroupBy("service.name"), aggregations: 1},
+			right: joinSide{name: "B", groupBy: joinGroupBy("service.name", "host.name"), aggregations: 1},
+			expected: "WITH __join_left AS (SELECT left), __join_right AS (SELECT right) " +
+				"SELECT l.ts AS ts, l.`__GROUP_BY_KEY_0_service.name` AS `service.name`, r.`__GROUP_BY_KEY_1_host.name` AS `host.name`, l.__result_0 AS __result_0, r.__result_0 AS __result_1 " +
+				"FROM __join_left AS l INNER JOIN __join_right AS r ON l.ts = r.ts AND l.`__GROUP_BY_KEY_0_service.name` = r.`__GROUP_BY_KEY_0_service.name` " +
+				"SETTINGS join_use_nulls = 1",
+			args: []any{"a", "b"},
+		},
+		{
+			name:  "full time series with metric side",
+			spec:  qbtypes.QueryBuilderJoin{Name: "C", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "B"}, Type: qbtypes.JoinTypeFull, On: "A.service.name = B.service"},
+			kind:  qbtypes.RequestTypeTimeSeries,
+			left:  joinSide{name: "A", groupBy: joinGroupBy("service.name", "env"), aggregations: 2},
+			right: joinSide{name: "B", groupBy: joinGroupBy("service", "env"), aggregations: 1, metric: true},
+			expected: "WITH __join_left AS (SELECT left), __join_right AS (SELECT right) " +
+				"SELECT assumeNotNull(coalesce(l.ts, r.ts)) AS ts, assumeNotNull(coalesce(l.`__GROUP_BY_KEY_0_service.name`, r.`__GROUP_BY_KEY_0_service`)) AS `service.name`, l.`__GROUP_BY_KEY_1_env` AS `env`, r.`__GROUP_BY_KEY_1_env` AS `B.env`, l.__result_0 AS __result_0, l.__result_1 AS __result_1, r.value AS __result_2 " +
+				"FROM __join_left AS l FULL OUTER JOIN __join_right AS r ON l.ts = r.ts AND l.`__GROUP_BY_KEY_0_service.name` = r.`__GROUP_BY_KEY_0_service` " +
+				"SETTINGS join_use_nulls = 1",
+			args: []any{"a", "b"},
+		},
+		{
+			name: "left scalar with order and limit",
+			spec: qbtypes.QueryBuilderJoin{
+				Name: "C", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "B"}, Type: qbtypes.JoinTypeLeft, On: "service.name",
+				Order: []qbtypes.OrderBy{
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "__result_1"}}, Direction: qbtypes.OrderDirectionAsc},
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}, Direction: qbtypes.OrderDirectionDesc},
+				},
+				Limit: 10,
+			},
+			kind:  qbtypes.RequestTypeScalar,
+			left:  joinSide{name: "A", groupBy: joinGroupBy("service.name"), aggregations: 1},
+			right: joinSide{name: "B", groupBy: joinGroupBy("service.name"), aggregations: 1},
+			expected: "WITH __join_left AS (SELECT left), __join_right AS (SELECT right) " +
+				"SELECT l.`__GROUP_BY_KEY_0_service.name` AS `service.name`, l.__result_0 AS __result_0, r.__result_0 AS __result_1 " +
+				"FROM __join_left AS l LEFT JOIN __join_right AS r ON l.`__GROUP_BY_KEY_0_service.name` = r.`__GROUP_BY_KEY_0_service.name` " +
+				"ORDER BY __result_1 asc, `service.name` desc LIMIT ? " +
+				"SETTINGS join_use_nulls = 1",
+			args: []any{"a", "b", 10},
+		},
+		{
+			name:  "cross scalar",
+			spec:  qbtypes.QueryBuilderJoin{Name: "C", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "B"}, Type: qbtypes.JoinTypeCross},
+			kind:  qbtypes.RequestTypeScalar,
+			left:  joinSide{name: "A", aggregations: 1},
+			right: joinSide{name: "B", aggregations: 1},
+			expected: "WITH __join_left AS (SELECT left), __join_right AS (SELECT right) " +
+				"SELECT l.__result_0 AS __result_0, r.__result_0 AS __result_1 " +
+				"FROM __join_left AS l CROSS JOIN __join_right AS r " +
+				"ORDER BY __result_0 DESC " +
+				"SETTINGS join_use_nulls = 1",
+			args: []any{"a", "b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conditions, err := c.spec.Conditions()
+			require.NoError(t, err)
+
+			stmt, err := buildJoinStatement(c.spec, conditions, c.kind, c.left, c.right, leftStmt, rightStmt)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, stmt.Query)
+			assert.Equal(t, c.args, stmt.Args)
+			assert.Equal(t, []string{"left warning"}, stmt.Warnings)
+		})
+	}
+}
+
+func TestBuildJoinStatementUnknownOrderKey(t *testing.T) {
+	spec := qbtypes.QueryBuilderJoin{
+		Name: "C", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "B"}, Type: qbtypes.JoinTypeInner, On: "service.name",
+		Order: []qbtypes.OrderBy{{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "__result_2"}}}},
+	}
+	conditions, err := spec.Conditions()
+	require.NoError(t, err)
+
+	side := joinSide{groupBy: joinGroupBy("service.name"), aggregations: 1}
+	_, err = buildJoinStatement(spec, conditions, qbtypes.RequestTypeScalar, side, side, &qbtypes.Statement{}, &qbtypes.Statement{})
+	require.ErrorContains(t, err, `cannot order by "__result_2"`)
+}
+
+func newJoinTestQuerier(t *testing.T, telemetryStore telemetrystore.TelemetryStore) *querier {
+	return New(
+		instrumentationtest.New().ToProviderSettings(),
+		telemetryStore,
+		telemetrytypestest.NewMockMetadataStore(),
+		nil, // prometheus
+		nil, // promV2
+		&mockJoinStmtBuilder[qbtypes.TraceAggregation]{query: "SELECT traces", args: []any{"traces"}},
+		nil, // aiTraceStmtBuilder
+		&mockJoinStmtBuilder[qbtypes.LogAggregation]{query: "SELECT logs", args: []any{"logs"}},
+		nil,                // auditStmtBuilder
+		nil,                // metricStmtBuilder
+		nil,                // meterStmtBuilder
+		nil,                // traceOperatorStmtBuilder
+		nil,                // bucketCache
+		flaggertest.New(t), // flagger
+		0,                  // logTraceIDWindowPadding
+		0,                  // maxConcurrentQueries
+		AdmissionConfig{},  // admissionConfig
+		SchedulerConfig{},  // schedulerConfig
+		nil,                // userRoleStore
+	)
+}
+
+func joinTestRequest(requestType qbtypes.RequestType, join qbtypes.QueryBuilderJoin, logsGroupBy []string, logsStep time.Duration) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       uint64(time.Now().Add(-5 * time.Minute).UnixMilli()),
+		End:         uint64(time.Now().UnixMilli()),
+		RequestType: requestType,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{
+				{
+					Type: qbtypes.QueryTypeBuilder,
+					Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+						Name:         "A",
+						Signal:       telemetrytypes.SignalTraces,
+						StepInterval: qbtypes.Step{Duration: time.Minute},
+						Aggregations: []qbtypes.TraceAggregation{{Expression: "count()"}},
+						GroupBy:      joinGroupBy("service.name"),
+					},
+				},
+				{
+					Type: qbtypes.QueryTypeBuilder,
+					Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+						Name:         "B",
+						Signal:       telemetrytypes.SignalLogs,
+						StepInterval: qbtypes.Step{Duration: logsStep},
+						Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+						GroupBy:      joinGroupBy(logsGroupBy...),
+					},
+				},
+				{
+					Type: qbtypes.QueryTypeJoin,
+					Spec: join,
+				},
+			},
+		},
+	}
+}
+
+func TestQueryRange_JoinScalar(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{})
+	rows := cmock.NewRows([]cmock.ColumnType{
+		{Name: "service.name", Type: "String"},
+		{Name: "__result_0", Type: "UInt64"},
+		{Name: "__result_1", Type: "UInt64"},
+	}, [][]any{
+		{"frontend", uint64(10), uint64(4)},
+		{"cart", uint64(7), uint64(2)},
+	})
+	telemetryStore.Mock().
+		ExpectQuery("WITH __join_left AS (SELECT traces), __join_right AS (SELECT logs)").
+		WithArgs("traces", "logs").
+		WillReturnRows(rows)
+
+	q := newJoinTestQuerier(t, telemetryStore)
+	req := joinTestRequest(qbtypes.RequestTypeScalar, qbtypes.QueryBuilderJoin{
+		Name:  "C",
+		Left:  qbtypes.QueryRef{Name: "A"},
+		Right: qbtypes.QueryRef{Name: "B"},
+		Type:  qbtypes.JoinTypeInner,
+		On:    "service.name",
+	}, []string{"service.name"}, time.Minute)
+
+	resp, err := q.QueryRange(context.Background(), valuer.GenerateUUID(), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Data.Results, 1)
+
+	scalar, ok := resp.Data.Results[0].(*qbtypes.ScalarData)
+	require.True(t, ok)
+	assert.Equal(t, "C", scalar.QueryName)
+	require.Len(t, scalar.Columns, 3)
+	assert.Equal(t, "service.name", scalar.Columns[0].Name)
+	assert.Equal(t, qbtypes.ColumnTypeAggregation, scalar.Columns[1].Type)
+	assert.Equal(t, int64(1), scalar.Columns[2].AggregationIndex)
+	assert.Equal(t, [][]any{{"frontend", uint64(10), uint64(4)}, {"cart", uint64(7), uint64(2)}}, scalar.Data)
+}
+
+func TestNewJoinQueryErrors(t *testing.T) {
+	join := qbtypes.QueryBuilderJoin{
+		Name:  "C",
+		Left:  qbtypes.QueryRef{Name: "A"},
+		Right: qbtypes.QueryRef{Name: "B"},
+		Type:  qbtypes.JoinTypeInner,
+		On:    "service.name",
+	}
+
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		join        qbtypes.QueryBuilderJoin
+		logsGroupBy []string
+		logsStep    time.Duration
+		expected    string
+	}{
+		{
+			name:        "raw request",
+			requestType: qbtypes.RequestTypeRaw,
+			join:        join,
+			logsGroupBy: []string{"service.name"},
+			logsStep:    time.Minute,
+			expected:    "supports only scalar and time_series requests",
+		},
+		{
+			name:        "key not grouped by",
+			requestType: qbtypes.RequestTypeScalar,
+			join:        join,
+			logsGroupBy: []string{"host.name"},
+			logsStep:    time.Minute,
+			expected:    `key "service.name" is not a group by key of query "B"`,
+		},
+		{
+			name:        "different steps",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			join:        join,
+			logsGroupBy: []string{"service.name"},
+			logsStep:    2 * time.Minute,
+			expected:    "to have the same step interval",
+		},
+		{
+			name:        "unknown query",
+			requestType: qbtypes.RequestTypeScalar,
+			join:        qbtypes.QueryBuilderJoin{Name: "C", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "D"}, Type: qbtypes.JoinTypeCross},
+			logsGroupBy: []string{"service.name"},
+			logsStep:    time.Minute,
+			expected:    `references unknown query "D"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := newJoinTestQuerier(t, nil)
+			req := joinTestRequest(c.requestType, c.join, c.logsGroupBy, c.logsStep)
+			_, err := q.newJoinQuery(valuer.GenerateUUID(), c.join, req, nil)
+			require.ErrorContains(t, err, c.expected)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/SigNoz/govaluate"
 
@@ -38,6 +39,8 @@ func getqueryInfo(spec any) queryInfo {
 		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.StepInterval}
 	case qbtypes.QueryBuilderTraceOperator:
 		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.StepInterval}
+	case qbtypes.QueryBuilderJoin:
+		return queryInfo{Name: s.Name, Disabled: s.Disabled}
 	case qbtypes.QueryBuilderFormula:
 		return queryInfo{Name: s.Name, Disabled: s.Disabled}
 	case qbtypes.PromQuery:
@@ -66,23 +69,28 @@ func (q *querier) postProcessResults(ctx context.Context, orgID valuer.UUID, res
 		switch spec := query.Spec.(type) {
 		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
 			if result, ok := typedResults[spec.Name]; ok {
-				result = postProcessBuilderQuery(q, result, spec, req)
+				result = postProcessBuilderQuery(ctx, q, result, spec, req)
 				typedResults[spec.Name] = result
 			}
 		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
 			if result, ok := typedResults[spec.Name]; ok {
-				result = postProcessBuilderQuery(q, result, spec, req)
+				result = postProcessBuilderQuery(ctx, q, result, spec, req)
 				result = q.postProcessLogBody(ctx, orgID, result, req)
 				typedResults[spec.Name] = result
 			}
 		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
 			if result, ok := typedResults[spec.Name]; ok {
-				result = postProcessMetricQuery(q, result, spec, req)
+				result = postProcessMetricQuery(ctx, q, result, spec, req)
 				typedResults[spec.Name] = result
 			}
 		case qbtypes.QueryBuilderTraceOperator:
 			if result, ok := typedResults[spec.Name]; ok {
-				result = postProcessTraceOperator(q, result, spec, req)
+				result = postProcessTraceOperator(ctx, q, result, spec, req)
+				typedResults[spec.Name] = result
+			}
+		case qbtypes.QueryBuilderJoin:
+			if result, ok := typedResults[spec.Name]; ok {
+				result = postProcessJoin(ctx, q, result, spec, req)
 				typedResults[spec.Name] = result
 			}
 		}
@@ -172,6 +180,7 @@ func (q *querier) postProcessResults(ctx context.Context, orgID valuer.UUID, res
 
 // postProcessBuilderQuery applies postprocessing to a single builder query result.
 func postProcessBuilderQuery[T any](
+	ctx context.Context,
 	q *querier,
 	result *qbtypes.Result,
 	query qbtypes.QueryBuilderQuery[T],
@@ -182,9 +191,11 @@ func postProcessBuilderQuery[T any](
 
 	// Apply functions
 	if len(query.Functions) > 0 {
+		start := time.Now()
 		step := query.StepInterval.Milliseconds()
 		functions := q.prepareFillZeroArgsWithStep(query.Functions, req, step)
 		result = q.applyFunctions(result, functions)
+		requestProfileFromContext(ctx).stage(query.Name, profileStageFunctions, start)
 	}
 
 	return result
@@ -192,6 +203,7 @@ func postProcessBuilderQuery[T any](
 
 // postProcessMetricQuery applies postprocessing to a metric query result.
 func postProcessMetricQuery(
+	ctx context.Context,
 	q *querier,
 	result *qbtypes.Result,
 	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
@@ -214,15 +226,19 @@ func postProcessMetricQuery(
 	result = q.applySeriesLimit(result, query.Limit, query.Order)
 
 	if len(query.Functions) > 0 {
+		start := time.Now()
 		step := query.StepInterval.Milliseconds()
 		functions := q.prepareFillZeroArgsWithStep(query.Functions, req, step)
 		result = q.applyFunctions(result, functions)
+		requestProfileFromContext(ctx).stage(query.Name, profileStageFunctions, start)
 	}
 
 	// Apply reduce to for scalar request type
 	if req.RequestType == qbtypes.RequestTypeScalar {
 		if len(query.Aggregations) > 0 && query.Aggregations[0].ReduceTo != qbtypes.ReduceToUnknown {
+			start := time.Now()
 			result = q.applyMetricReduceTo(result, query.Aggregations[0].ReduceTo)
+			requestProfileFromContext(ctx).stage(query.Name, profileStageReduce, start)
 		}
 	}
 
@@ -231,6 +247,7 @@ func postProcessMetricQuery(
 
 // postProcessTraceOperator applies postprocessing to a trace operator query result.
 func postProcessTraceOperator(
+	ctx context.Context,
 	q *querier,
 	result *qbtypes.Result,
 	query qbtypes.QueryBuilderTraceOperator,
@@ -241,9 +258,37 @@ func postProcessTraceOperator(
 
 	// Apply functions if any
 	if len(query.Functions) > 0 {
+		start := time.Now()
 		step := query.StepInterval.Milliseconds()
 		functions := q.prepareFillZeroArgsWithStep(query.Functions, req, step)
 		result = q.applyFunctions(result, functions)
+		requestProfileFromContext(ctx).stage(query.Name, profileStageFunctions, start)
+	}
+
+	return result
+}
+
+// postProcessJoin applies postprocessing to a join query result. Scalar joins are
+// ordered and limited in SQL.
+func postProcessJoin(
+	ctx context.Context,
+	q *querier,
+	result *qbtypes.Result,
+	query qbtypes.QueryBuilderJoin,
+	req *qbtypes.QueryRangeRequest,
+) *qbtypes.Result {
+
+	result = q.applySeriesLimit(result, query.Limit, query.Order)
+
+	if len(query.Functions) > 0 {
+		step, err := req.StepIntervalForQuery(query.Name)
+		if err != nil {
+			return result
+		}
+		start := time.Now()
+		functions := q.prepareFillZeroArgsWithStep(query.Functions, req, step)
+		result = q.applyFunctions(result, functions)
+		requestProfileFromContext(ctx).stage(query.Name, profileStageFunctions, start)
 	}
 
 	return result
@@ -274,6 +319,13 @@ func (q *querier) applyMetricReduceTo(result *qbtypes.Result, reduceOp qbtypes.R
 
 // applySeriesLimit limits the number of series in the result.
 func (q *querier) applySeriesLimit(result *qbtypes.Result, limit int, orderBy []qbtypes.OrderBy) *qbtypes.Result {
+	if data, ok := result.Value.(*qbtypes.DistributionData); ok {
+		if data != nil {
+			data.Series = limitDistributionSeries(data.Series, limit)
+		}
+		return result
+	}
+
 	tsData, ok := result.Value.(*qbtypes.TimeSeriesData)
 	if !ok {
 		return result
@@ -322,6 +374,7 @@ func (q *querier) applyFormulas(ctx context.Context, results map[string]*qbtypes
 
 	// Process each formula
 	for name, formula := range formulaQueries {
+		start := time.Now()
 
 		for idx := range formula.Order {
 			if formula.Order[idx].Key.Name == formula.Name || formula.Order[idx].Key.Name == formula.Expression {
@@ -342,6 +395,7 @@ func (q *querier) applyFormulas(ctx context.Context, results map[string]*qbtypes
 			// For scalar results, apply limit by processScalarFormula itself since it needs to be applied before converting back to scalar format
 			results[name] = result
 		}
+		requestProfileFromContext(ctx).stage(name, profileStageFormula, start)
 	}
 
 	return results
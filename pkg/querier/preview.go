@@ -226,9 +226,9 @@ func (q *querier) buildPreviewProviders(
 			deps = dependencyQueries
 		case dependencyQueries[name]:
 			sub.RequestType = qbtypes.RequestTypeRaw
-			sub.CompositeQuery = qbtypes.CompositeQuery{Queries: []qbtypes.QueryEnvelope{query}}
+			sub.CompositeQuery = qbtypes.CompositeQuery{Queries: withSubQueryEnvelopes(req, query)}
 		default:
-			sub.CompositeQuery = qbtypes.CompositeQuery{Queries: []qbtypes.QueryEnvelope{query}}
+			sub.CompositeQuery = qbtypes.CompositeQuery{Queries: withSubQueryEnvelopes(req, query)}
 		}
 
 		built, _, bErr := q.buildQueries(orgID, &sub, deps, missingMetricQuerySet, event, promqlOptions{})
@@ -244,6 +244,21 @@ func (q *querier) buildPreviewProviders(
 	return providers, errs
 }
 
+// withSubQueryEnvelopes returns the query along with the sub-queries of the request, which
+// its filter may read.
+func withSubQueryEnvelopes(req *qbtypes.QueryRangeRequest, query qbtypes.QueryEnvelope) []qbtypes.QueryEnvelope {
+	queries := []qbtypes.QueryEnvelope{}
+	for _, envelope := range req.CompositeQuery.Queries {
+		if envelope.Type == qbtypes.QueryTypeSubQuery {
+			queries = append(queries, envelope)
+		}
+	}
+	if query.Type == qbtypes.QueryTypeSubQuery {
+		return queries
+	}
+	return append(queries, query)
+}
+
 // rendersStandaloneStatement reports whether a query type renders its own
 // statement. Formula/join/sub-query don't — they reference other queries.
 func rendersStandaloneStatement(t qbtypes.QueryType) bool {
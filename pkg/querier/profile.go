@@ -0,0 +1,245 @@
+package querier
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type requestProfileCtxKey struct{}
+
+type queryProfileCtxKey struct{}
+
+// profileStage is a post-processing stage timed for a query.
+type profileStage int
+
+const (
+	profileStageFunctions profileStage = iota
+	profileStageReduce
+	profileStageFormula
+)
+
+// requestProfile collects the profile of a request run in profile mode. A nil
+// *requestProfile records nothing, so callers use it unconditionally.
+type requestProfile struct {
+	mu      sync.Mutex
+	queries map[string]*qbtypes.QueryProfile
+}
+
+func newRequestProfile() *requestProfile {
+	return &requestProfile{queries: make(map[string]*qbtypes.QueryProfile)}
+}
+
+func newContextWithRequestProfile(ctx context.Context, profile *requestProfile) context.Context {
+	return context.WithValue(ctx, requestProfileCtxKey{}, profile)
+}
+
+func requestProfileFromContext(ctx context.Context) *requestProfile {
+	profile, _ := ctx.Value(requestProfileCtxKey{}).(*requestProfile)
+	return profile
+}
+
+// query returns the profile of the named query, creating it. It must be called with mu held.
+func (p *requestProfile) query(name string) *qbtypes.QueryProfile {
+	profile, ok := p.queries[name]
+	if !ok {
+		profile = qbtypes.NewQueryProfile()
+		p.queries[name] = profile
+	}
+	return profile
+}
+
+// contextForQuery returns the context the named query runs with, for its executor to
+// record the statements it runs into the query's profile.
+func (p *requestProfile) contextForQuery(ctx context.Context, name string) context.Context {
+	if p == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, queryProfileCtxKey{}, &queryProfile{request: p, name: name})
+}
+
+// executed records the outcome of running the named query.
+func (p *requestProfile) executed(name string, result *qbtypes.Result, elapsed time.Duration) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	profile := p.query(name)
+	profile.ExecutionMS = durationMS(elapsed)
+	if result != nil {
+		profile.RowsRead = result.Stats.RowsScanned
+		profile.BytesRead = result.Stats.BytesScanned
+	}
+}
+
+// stage adds the time since start to the post-processing stage of the named query.
+func (p *requestProfile) stage(name string, stage profileStage, start time.Time) {
+	if p == nil {
+		return
+	}
+
+	elapsed := durationMS(time.Since(start))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	profile := p.query(name)
+	switch stage {
+	case profileStageFunctions:
+		profile.FunctionsMS += elapsed
+	case profileStageReduce:
+		profile.ReduceMS += elapsed
+	case profileStageFormula:
+		profile.FormulaMS += elapsed
+	}
+}
+
+// explain attaches the granule-skip breakdown of every statement recorded. It runs after
+// the queries, so that the EXPLAIN round trips do not count in their timings.
+func (p *requestProfile) explain(ctx context.Context, telemetryStore telemetrystore.TelemetryStore) {
+	if p == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, profile := range p.queries {
+		for i := range profile.Statements {
+			wg.Add(1)
+			go func(stmt *qbtypes.ProfileStatement) {
+				defer wg.Done()
+				granules, ok, err := telemetryStore.Indexes(ctx, stmt.Query, stmt.Args...)
+				p.mu.Lock()
+				defer p.mu.Unlock()
+				if err != nil {
+					profile.Warnings = append(profile.Warnings, "could not compute granule stats: "+err.Error())
+					return
+				}
+				if ok {
+					stmt.Granules = &granules
+				}
+			}(&profile.Statements[i])
+		}
+	}
+	wg.Wait()
+}
+
+// build returns the profile of the request.
+func (p *requestProfile) build(execution, postProcessing time.Duration) *qbtypes.QueryRangeProfile {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, profile := range p.queries {
+		for _, stmt := range profile.Statements {
+			profile.ClickHouseMS += stmt.DurationMS
+		}
+	}
+	return &qbtypes.QueryRangeProfile{
+		Queries:          p.queries,
+		ExecutionMS:      durationMS(execution),
+		PostProcessingMS: durationMS(postProcessing),
+	}
+}
+
+// queryProfile records into the profile of one query of a request. A nil *queryProfile
+// records nothing, so executors use it unconditionally.
+type queryProfile struct {
+	request *requestProfile
+	name    string
+}
+
+func queryProfileFromContext(ctx context.Context) *queryProfile {
+	profile, _ := ctx.Value(queryProfileCtxKey{}).(*queryProfile)
+	return profile
+}
+
+// statement starts profiling a ClickHouse statement. It returns the context to run the
+// statement with, carrying its query_id, and the func recording the statement with what it
+// read once its last row was read.
+func (p *queryProfile) statement(ctx context.Context, query string, args []any) (context.Context, func(rows, bytes uint64)) {
+	if p == nil {
+		return ctx, func(uint64, uint64) {}
+	}
+
+	queryID := valuer.GenerateUUID().StringValue()
+	ctx = clickhouse.Context(ctx, clickhouse.WithQueryID(queryID))
+	start := time.Now()
+
+	return ctx, func(rows, bytes uint64) {
+		elapsed := time.Since(start)
+		p.request.mu.Lock()
+		defer p.request.mu.Unlock()
+		profile := p.request.query(p.name)
+		profile.Statements = append(profile.Statements, qbtypes.ProfileStatement{
+			Query:      query,
+			Args:       orEmpty(args),
+			QueryID:    queryID,
+			RowsRead:   rows,
+			BytesRead:  bytes,
+			DurationMS: durationMS(elapsed),
+		})
+	}
+}
+
+// cache records the ranges of the window of the query the bucket cache served and the
+// ones it missed.
+func (p *queryProfile) cache(startMS, endMS uint64, cached bool, missing []*qbtypes.TimeRange) {
+	if p == nil {
+		return
+	}
+
+	misses := make([]qbtypes.ProfileTimeRange, 0, len(missing))
+	for _, tr := range missing {
+		misses = append(misses, qbtypes.ProfileTimeRange{From: tr.From, To: tr.To})
+	}
+	slices.SortFunc(misses, func(a, b qbtypes.ProfileTimeRange) int {
+		return cmp.Compare(a.From, b.From)
+	})
+
+	// Whatever of the window is not missing was served from the cache.
+	hits := make([]qbtypes.ProfileTimeRange, 0)
+	if cached {
+		cursor := startMS
+		for _, miss := range misses {
+			if miss.From > cursor {
+				hits = append(hits, qbtypes.ProfileTimeRange{From: cursor, To: miss.From})
+			}
+			cursor = max(cursor, miss.To)
+		}
+		if cursor < endMS {
+			hits = append(hits, qbtypes.ProfileTimeRange{From: cursor, To: endMS})
+		}
+	}
+
+	p.request.mu.Lock()
+	defer p.request.mu.Unlock()
+	profile := p.request.query(p.name)
+	profile.Cached = true
+	profile.CacheHits = hits
+	profile.CacheMisses = misses
+}
+
+// warn adds a warning to the profile of the query.
+func (p *queryProfile) warn(warning string) {
+	if p == nil {
+		return
+	}
+
+	p.request.mu.Lock()
+	defer p.request.mu.Unlock()
+	profile := p.request.query(p.name)
+	profile.Warnings = append(profile.Warnings, warning)
+}
+
+func durationMS(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,120 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryProfileCache(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cached  bool
+		missing []*qbtypes.TimeRange
+		hits    []qbtypes.ProfileTimeRange
+		misses  []qbtypes.ProfileTimeRange
+	}{
+		{
+			name:   "AllCached",
+			cached: true,
+			hits:   []qbtypes.ProfileTimeRange{{From: 1000, To: 5000}},
+			misses: []qbtypes.ProfileTimeRange{},
+		},
+		{
+			name:    "NothingCached",
+			missing: []*qbtypes.TimeRange{{From: 1000, To: 5000}},
+			hits:    []qbtypes.ProfileTimeRange{},
+			misses:  []qbtypes.ProfileTimeRange{{From: 1000, To: 5000}},
+		},
+		{
+			name:    "GapsAndTail",
+			cached:  true,
+			missing: []*qbtypes.TimeRange{{From: 4000, To: 5000}, {From: 2000, To: 3000}},
+			hits:    []qbtypes.ProfileTimeRange{{From: 1000, To: 2000}, {From: 3000, To: 4000}},
+			misses:  []qbtypes.ProfileTimeRange{{From: 2000, To: 3000}, {From: 4000, To: 5000}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := newRequestProfile()
+			ctx := profile.contextForQuery(context.Background(), "A")
+			queryProfileFromContext(ctx).cache(1000, 5000, tc.cached, tc.missing)
+
+			built := profile.build(0, 0)
+			require.Contains(t, built.Queries, "A")
+			assert.True(t, built.Queries["A"].Cached)
+			assert.Equal(t, tc.hits, built.Queries["A"].CacheHits)
+			assert.Equal(t, tc.misses, built.Queries["A"].CacheMisses)
+		})
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{})
+	telemetryStore.Mock().ExpectQuery("EXPLAIN").WillReturnError(errors.NewInternalf(errors.CodeInternal, "explain failed"))
+
+	q := &querier{
+		logger:               instrumentationtest.New().Logger(),
+		telemetryStore:       telemetryStore,
+		maxConcurrentQueries: 1,
+	}
+
+	qs := map[string]qbtypes.Query{
+		"A": &fakeQuery{execute: func(ctx context.Context) (*qbtypes.Result, error) {
+			_, recordStatement := queryProfileFromContext(ctx).statement(ctx, "SELECT 1", nil)
+			time.Sleep(5 * time.Millisecond)
+			recordStatement(10, 20)
+			return &qbtypes.Result{
+				Type:  qbtypes.RequestTypeScalar,
+				Value: &qbtypes.ScalarData{QueryName: "A"},
+				Stats: qbtypes.ExecStats{RowsScanned: 10, BytesScanned: 20},
+			}, nil
+		}},
+	}
+
+	req := &qbtypes.QueryRangeRequest{
+		RequestType:    qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{Queries: chQueryEnvelopes([]string{"A"})},
+		NoCache:        true,
+	}
+
+	resp, err := q.run(context.Background(), valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, resp.Profile, "profile is only set in profile mode")
+
+	ctx := newContextWithRequestProfile(context.Background(), newRequestProfile())
+	resp, err = q.run(ctx, valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Profile)
+	require.Contains(t, resp.Profile.Queries, "A")
+
+	profile := resp.Profile.Queries["A"]
+	require.Len(t, profile.Statements, 1)
+	stmt := profile.Statements[0]
+	assert.Equal(t, "SELECT 1", stmt.Query)
+	assert.Equal(t, []any{}, stmt.Args)
+	assert.NotEmpty(t, stmt.QueryID)
+	assert.Equal(t, uint64(10), stmt.RowsRead)
+	assert.Equal(t, uint64(20), stmt.BytesRead)
+	assert.GreaterOrEqual(t, stmt.DurationMS, float64(5))
+	assert.Nil(t, stmt.Granules)
+
+	assert.False(t, profile.Cached)
+	assert.Equal(t, uint64(10), profile.RowsRead)
+	assert.Equal(t, uint64(20), profile.BytesRead)
+	assert.Equal(t, stmt.DurationMS, profile.ClickHouseMS)
+	assert.GreaterOrEqual(t, profile.ExecutionMS, profile.ClickHouseMS)
+	assert.GreaterOrEqual(t, resp.Profile.ExecutionMS, profile.ExecutionMS)
+	require.Len(t, profile.Warnings, 1)
+	assert.Contains(t, profile.Warnings[0], "could not compute granule stats")
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/querybuilder"
 	"github.com/SigNoz/signoz/pkg/statsreporter"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
 	"github.com/SigNoz/signoz/pkg/types/featuretypes"
 	"github.com/SigNoz/signoz/pkg/types/instrumentationtypes"
@@ -74,6 +75,8 @@ type querier struct {
 	// shadowSlots bounds concurrent shadow comparisons per process; shadows
 	// detach from their requests, so nothing else limits how many pile up.
 	shadowSlots chan struct{}
+	admission   *admission
+	scheduler   *scheduler
 }
 
 // maxConcurrentShadows is deliberately small: a shadow is a full extra
@@ -100,6 +103,9 @@ func New(
 	flagger flagger.Flagger,
 	logTraceIDWindowPadding time.Duration,
 	maxConcurrentQueries int,
+	admissionConfig AdmissionConfig,
+	schedulerConfig SchedulerConfig,
+	userRoleStore authtypes.UserRoleStore,
 ) *querier {
 	querierSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier")
 	if maxConcurrentQueries <= 0 {
@@ -126,6 +132,8 @@ func New(
 		},
 		maxConcurrentQueries: maxConcurrentQueries,
 		shadowSlots:          make(chan struct{}, maxConcurrentShadows),
+		admission:            newAdmission(admissionConfig, querierSettings.Logger(), querierSettings.Meter(), telemetryStore, userRoleStore),
+		scheduler:            newScheduler(schedulerConfig, querierSettings.Logger(), querierSettings.Meter()),
 	}
 }
 
@@ -137,6 +145,10 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 	req.Start = querybuilder.ToMilliSecs(req.Start)
 	req.End = querybuilder.ToMilliSecs(req.End)
 
+	if req.Profile {
+		ctx = newContextWithRequestProfile(ctx, newRequestProfile())
+	}
+
 	event := &qbtypes.QBEvent{
 		Version:         "v5",
 		NumberOfQueries: len(req.CompositeQuery.Queries),
@@ -151,6 +163,8 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 	if err != nil {
 		return nil, err
 	}
+	// Joins read the queries they join as CTEs, the same way.
+	addJoinDependencies(dependencyQueries, req.CompositeQuery.Queries)
 
 	// Step interval is the aggregation parameter for timeseries requests.
 	// We need to set if it is unspecified or adjust it if value is not within recommended range
@@ -174,6 +188,18 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 		return nil, err
 	}
 
+	ticket, err := q.scheduler.admit(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cost of a request is the one of the ranges missing from the cache.
+	plans := q.planCache(ctx, orgID, queries, req, steps)
+	ctx, admissionWarnings, err := q.admission.admit(ctx, orgID, req, q.missingQueries(orgID, queries, plans), ticket)
+	if err != nil {
+		return nil, err
+	}
+
 	preseededResults := make(map[string]any)
 	for _, name := range missingMetricQueries {
 		switch req.RequestType {
@@ -183,9 +209,11 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 			preseededResults[name] = &qbtypes.ScalarData{QueryName: name}
 		case qbtypes.RequestTypeRaw:
 			preseededResults[name] = &qbtypes.RawData{QueryName: name}
+		case qbtypes.RequestTypeDistribution:
+			preseededResults[name] = &qbtypes.DistributionData{QueryName: name}
 		}
 	}
-	qbResp, qbErr := q.run(ctx, orgID, queries, req, steps, event, preseededResults)
+	qbResp, qbErr := q.run(ctx, orgID, queries, req, steps, event, preseededResults, plans, ticket)
 	if qbResp != nil {
 		qbResp.QBEvent = event
 		if len(intervalWarnings) != 0 && req.RequestType == qbtypes.RequestTypeTimeSeries {
@@ -198,11 +226,11 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 				}
 			}
 		}
-		if len(metricWarnings) > 0 {
+		if len(metricWarnings) > 0 || len(admissionWarnings) > 0 {
 			if qbResp.Warning == nil {
 				qbResp.Warning = &qbtypes.QueryWarnData{}
 			}
-			for _, w := range metricWarnings {
+			for _, w := range append(metricWarnings, admissionWarnings...) {
 				qbResp.Warning.Warnings = append(qbResp.Warning.Warnings, qbtypes.QueryWarnDataAdditional{
 					Message: w,
 				})
@@ -253,6 +281,10 @@ func (q *querier) buildQueries(
 	if tmplVars == nil {
 		tmplVars = make(map[string]qbtypes.VariableItem)
 	}
+	tmplVars, err := q.addSubQueryVariables(orgID, req, tmplVars)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	queries := make(map[string]qbtypes.Query)
 	steps := make(map[string]qbtypes.Step)
@@ -298,6 +330,17 @@ func (q *querier) buildQueries(
 			}
 			queries[traceOpQuery.Name] = toq
 			steps[traceOpQuery.Name] = traceOpQuery.StepInterval
+		case qbtypes.QueryTypeJoin:
+			joinSpec, ok := query.Spec.(qbtypes.QueryBuilderJoin)
+			if !ok {
+				return nil, nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid join query spec %T", query.Spec)
+			}
+			jq, err := q.newJoinQuery(orgID, joinSpec, req, tmplVars)
+			if err != nil {
+				return nil, nil, err
+			}
+			queries[joinSpec.Name] = jq
+			steps[joinSpec.Name] = jq.left.step
 		case qbtypes.QueryTypeBuilderAI:
 			spec, ok := query.Spec.(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
 			if !ok {
@@ -585,6 +628,14 @@ func (q *querier) QueryRawStream(ctx context.Context, orgID valuer.UUID, req *qb
 		spec.Filter.Expression = fmt.Sprintf("%s and id > $id", spec.Filter.Expression)
 	}
 
+	// The stream is one request: its queries share a ticket, queuing for the slots of the
+	// querier and counting against the bytes read quota like the ones of QueryRange.
+	ticket, err := q.scheduler.admit(ctx, orgID)
+	if err != nil {
+		client.Error <- err
+		return
+	}
+
 	tsStart := req.Start
 	if tsStart == 0 {
 		tsStart = uint64(time.Now().UnixNano())
@@ -625,7 +676,7 @@ func (q *querier) QueryRawStream(ctx context.Context, orgID valuer.UUID, req *qb
 			}, q.builderConfig)
 			queries[spec.Name] = bq
 
-			qbResp, qbErr := q.run(ctx, orgID, queries, req, nil, event, nil)
+			qbResp, qbErr := q.run(ctx, orgID, queries, req, nil, event, nil, nil, ticket)
 			if qbErr != nil {
 				client.Error <- qbErr
 				return
@@ -655,6 +706,8 @@ func (q *querier) run(
 	steps map[string]qbtypes.Step,
 	qbEvent *qbtypes.QBEvent,
 	preseededResults map[string]any,
+	plans map[string]*cachePlan,
+	ticket *schedulerTicket,
 ) (*qbtypes.QueryRangeResponse, error) {
 	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
 		instrumentationtypes.PanelType: qbEvent.PanelType,
@@ -693,6 +746,10 @@ func (q *querier) run(
 				}
 				return false
 			}
+		case qbtypes.RequestTypeDistribution:
+			if val, ok := result.Value.(*qbtypes.DistributionData); ok && val != nil {
+				return len(val.Series) != 0
+			}
 		}
 		return false
 	}
@@ -701,16 +758,27 @@ func (q *querier) run(
 	slices.Sort(names)
 	queryResults := make([]*qbtypes.Result, len(names))
 
-	// sem limits how many queries run at once for this request. The same
-	// limit covers the missing-range queries in executeWithCache. sem is held
-	// only while a query is running, never while waiting for other
-	// goroutines, so the two levels cannot deadlock.
-	sem := make(chan struct{}, q.maxConcurrentQueries)
+	// slots limit how many queries run at once for this request, and through
+	// the scheduler ticket for the querier. The same limit covers the
+	// missing-range queries in executeWithCache. A slot is held only while a
+	// query is running, never while waiting for other goroutines, so the two
+	// levels cannot deadlock.
+	slots := newQuerySlots(q.maxConcurrentQueries, ticket)
+
+	profile := requestProfileFromContext(ctx)
+	executionStart := time.Now()
 
 	eg, egCtx := errgroup.WithContext(ctx)
 	for i, name := range names {
 		query := qs[name]
 		eg.Go(func() error {
+			egCtx := profile.contextForQuery(egCtx, name)
+			if _, ok := query.(*promqlQuery); ok {
+				queryProfileFromContext(egCtx).warn("PromQL queries are evaluated by the Prometheus engine; the ClickHouse statements it runs are not profiled")
+			}
+			began := time.Now()
+			defer func() { profile.executed(name, queryResults[i], time.Since(began)) }()
+
 			// Skip cache if NoCache is set, or if cache is not available
 			if req.NoCache || q.bucketCache == nil || query.Fingerprint() == "" {
 				if req.NoCache {
@@ -718,9 +786,11 @@ func (q *querier) run(
 				} else {
 					q.logger.InfoContext(egCtx, "no bucket cache or fingerprint, executing query", slog.String("fingerprint", query.Fingerprint()))
 				}
-				sem <- struct{}{}
+				if err := slots.acquire(egCtx); err != nil {
+					return err
+				}
 				result, err := query.Execute(egCtx)
-				<-sem
+				slots.release()
 				if err != nil {
 					return err
 				}
@@ -728,7 +798,7 @@ func (q *querier) run(
 				return nil
 			}
 
-			result, err := q.executeWithCache(egCtx, orgID, query, steps[name], sem)
+			result, err := q.executeWithCache(egCtx, orgID, query, steps[name], plans[name], slots)
 			if err != nil {
 				return err
 			}
@@ -739,6 +809,8 @@ func (q *querier) run(
 				v.QueryName = name
 			case *qbtypes.RawData:
 				v.QueryName = name
+			case *qbtypes.DistributionData:
+				v.QueryName = name
 			}
 			queryResults[i] = result
 			return nil
@@ -747,6 +819,7 @@ func (q *querier) run(
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	execution := time.Since(executionStart)
 
 	for i, name := range names {
 		result := queryResults[i]
@@ -758,12 +831,17 @@ func (q *querier) run(
 		stats.BytesScanned += result.Stats.BytesScanned
 		stats.DurationMS += result.Stats.DurationMS
 	}
+	// Bytes read count against the quotas of the org and the user.
+	ticket.record(stats.BytesScanned)
+	queued, throttled := ticket.stats()
 
 	gomaps.Copy(results, preseededResults)
+	postProcessingStart := time.Now()
 	processedResults, err := q.postProcessResults(ctx, orgID, results, req)
 	if err != nil {
 		return nil, err
 	}
+	postProcessing := time.Since(postProcessingStart)
 
 	// attach step interval to metadata so client can make informed decisions, ex: width of the bar
 	// or go to related logs/traces from a point in line/bar chart with correct time range
@@ -789,6 +867,8 @@ func (q *querier) run(
 			RowsScanned:   stats.RowsScanned,
 			BytesScanned:  stats.BytesScanned,
 			DurationMS:    stats.DurationMS,
+			QueuedMS:      uint64(queued.Milliseconds()),
+			Throttled:     throttled,
 			StepIntervals: stepIntervals,
 		},
 	}
@@ -813,14 +893,76 @@ func (q *querier) run(
 			Warnings: warns,
 		}
 	}
+
+	profile.explain(ctx, q.telemetryStore)
+	resp.Profile = profile.build(execution, postProcessing)
 	return resp, nil
 }
 
-// executeWithCache executes a query using the bucket cache. sem limits how
-// many queries run at once for the whole request.
-func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query qbtypes.Query, step qbtypes.Step, sem chan struct{}) (*qbtypes.Result, error) {
+// cachePlan is the result of a query found in the bucket cache and the ranges of its
+// window missing from it, which the query runs for.
+type cachePlan struct {
+	cached  *qbtypes.Result
+	missing []*qbtypes.TimeRange
+}
+
+// whole reports whether the whole window of the query is missing from the cache.
+func (p *cachePlan) whole(query qbtypes.Query) bool {
+	if p.cached != nil || len(p.missing) != 1 {
+		return false
+	}
+	startMs, endMs := query.Window()
+	return p.missing[0].From == startMs && p.missing[0].To == endMs
+}
+
+// planCache looks the queries up in the bucket cache. The queries bypassing the cache
+// have no plan.
+func (q *querier) planCache(ctx context.Context, orgID valuer.UUID, qs map[string]qbtypes.Query, req *qbtypes.QueryRangeRequest, steps map[string]qbtypes.Step) map[string]*cachePlan {
+	plans := make(map[string]*cachePlan, len(qs))
+	if req.NoCache || q.bucketCache == nil {
+		return plans
+	}
+	for name, query := range qs {
+		if query.Fingerprint() == "" {
+			continue
+		}
+		cached, missing := q.bucketCache.GetMissRanges(ctx, orgID, query, steps[name])
+		plans[name] = &cachePlan{cached: cached, missing: missing}
+	}
+	return plans
+}
+
+// missingQueries returns the queries the request runs: the queries without a plan over
+// their whole window, and the other ones over the ranges missing from the cache.
+func (q *querier) missingQueries(orgID valuer.UUID, qs map[string]qbtypes.Query, plans map[string]*cachePlan) []qbtypes.Query {
+	missing := make([]qbtypes.Query, 0, len(qs))
+	for name, query := range qs {
+		plan, ok := plans[name]
+		if !ok || plan.whole(query) {
+			missing = append(missing, query)
+			continue
+		}
+		for _, timeRange := range plan.missing {
+			if rangedQuery := q.createRangedQuery(orgID, query, *timeRange); rangedQuery != nil {
+				missing = append(missing, rangedQuery)
+			}
+		}
+	}
+	return missing
+}
+
+// executeWithCache executes a query using the bucket cache, looking it up unless
+// its plan is given. slots limit how many queries run at once for the whole
+// request.
+func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query qbtypes.Query, step qbtypes.Step, plan *cachePlan, slots *querySlots) (*qbtypes.Result, error) {
 	// Get cached data and missing ranges
-	cachedResult, missingRanges := q.bucketCache.GetMissRanges(ctx, orgID, query, step)
+	if plan == nil {
+		cached, missing := q.bucketCache.GetMissRanges(ctx, orgID, query, step)
+		plan = &cachePlan{cached: cached, missing: missing}
+	}
+	cachedResult, missingRanges := plan.cached, plan.missing
+	startMs, endMs := query.Window()
+	queryProfileFromContext(ctx).cache(startMs, endMs, cachedResult != nil, missingRanges)
 
 	// If no missing ranges, return cached result
 	if len(missingRanges) == 0 && cachedResult != nil {
@@ -828,19 +970,18 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 	}
 
 	// If entire range is missing, execute normally
-	if cachedResult == nil && len(missingRanges) == 1 {
-		startMs, endMs := query.Window()
-		if missingRanges[0].From == startMs && missingRanges[0].To == endMs {
-			sem <- struct{}{}
-			result, err := query.Execute(ctx)
-			<-sem
-			if err != nil {
-				return nil, err
-			}
-			// Store in cache for future use
-			q.bucketCache.Put(ctx, orgID, query, step, result)
-			return result, nil
+	if plan.whole(query) {
+		if err := slots.acquire(ctx); err != nil {
+			return nil, err
+		}
+		result, err := query.Execute(ctx)
+		slots.release()
+		if err != nil {
+			return nil, err
 		}
+		// Store in cache for future use
+		q.bucketCache.Put(ctx, orgID, query, step, result)
+		return result, nil
 	}
 
 	// Execute queries for missing ranges with bounded parallelism
@@ -859,8 +1000,11 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 		go func(idx int, tr *qbtypes.TimeRange) {
 			defer wg.Done()
 
-			sem <- struct{}{}
-			defer func() { <-sem }()
+			if err := slots.acquire(ctx); err != nil {
+				errs[idx] = err
+				return
+			}
+			defer slots.release()
 
 			// Create a new query with the missing time range
 			rangedQuery := q.createRangedQuery(orgID, query, *tr)
@@ -886,11 +1030,17 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 	// Check for errors
 	for _, err := range errs {
 		if err != nil {
+			// A query that timed out waiting for a slot would only wait again.
+			if errors.Asc(err, ErrCodeQueryQueueTimeout) {
+				return nil, err
+			}
 			// If any query failed, fall back to full execution
 			q.logger.ErrorContext(ctx, "parallel query execution failed", errors.Attr(err))
-			sem <- struct{}{}
+			if err := slots.acquire(ctx); err != nil {
+				return nil, err
+			}
 			result, err := query.Execute(ctx)
-			<-sem
+			slots.release()
 			if err != nil {
 				return nil, err
 			}
@@ -1003,6 +1153,8 @@ func (q *querier) mergeResults(cached *qbtypes.Result, fresh []*qbtypes.Result)
 		case qbtypes.RequestTypeTimeSeries:
 			// Pass nil as cached value to ensure proper merging of all fresh results
 			merged.Value = q.mergeTimeSeriesResults(nil, fresh)
+		case qbtypes.RequestTypeDistribution:
+			merged.Value = mergeDistributionResults(nil, fresh)
 		}
 
 		return merged
@@ -1025,6 +1177,9 @@ func (q *querier) mergeResults(cached *qbtypes.Result, fresh []*qbtypes.Result)
 	switch merged.Type {
 	case qbtypes.RequestTypeTimeSeries:
 		merged.Value = q.mergeTimeSeriesResults(cached.Value.(*qbtypes.TimeSeriesData), fresh)
+	case qbtypes.RequestTypeDistribution:
+		cachedValue, _ := cached.Value.(*qbtypes.DistributionData)
+		merged.Value = mergeDistributionResults(cachedValue, fresh)
 	}
 
 	if len(fresh) > 0 {
@@ -1267,6 +1422,19 @@ func (q *querier) constructTraceOperatorDependencyMap(queries []qbtypes.QueryEnv
 	return dependencyQueries, nil
 }
 
+// addJoinDependencies marks the queries referenced by joins as dependencies.
+func addJoinDependencies(dependencyQueries map[string]bool, queries []qbtypes.QueryEnvelope) {
+	for _, query := range queries {
+		if query.Type != qbtypes.QueryTypeJoin {
+			continue
+		}
+		if spec, ok := query.Spec.(qbtypes.QueryBuilderJoin); ok {
+			dependencyQueries[spec.Left.Name] = true
+			dependencyQueries[spec.Right.Name] = true
+		}
+	}
+}
+
 // adjustStepInterval normalizes each query's step interval in place and returns
 // any clamp warnings emitted along the way.
 func (q *querier) adjustStepInterval(queries []qbtypes.QueryEnvelope, start, end uint64) []string {
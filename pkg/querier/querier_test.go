@@ -60,6 +60,9 @@ func TestQueryRange_MetricTypeMissing(t *testing.T) {
 		flaggertest.New(t), // flagger
 		0,                  // logTraceIDWindowPadding
 		0,                  // maxConcurrentQueries
+		AdmissionConfig{},  // admissionConfig
+		SchedulerConfig{},  // schedulerConfig
+		nil,                // userRoleStore
 	)
 
 	req := &qbtypes.QueryRangeRequest{
@@ -134,6 +137,9 @@ func TestQueryRange_MetricTypeFromStore(t *testing.T) {
 		flaggertest.New(t), // flagger
 		0,                  // logTraceIDWindowPadding
 		0,                  // maxConcurrentQueries
+		AdmissionConfig{},  // admissionConfig
+		SchedulerConfig{},  // schedulerConfig
+		nil,                // userRoleStore
 	)
 
 	req := &qbtypes.QueryRangeRequest{
@@ -221,7 +227,7 @@ func TestRunExecutesQueriesConcurrently(t *testing.T) {
 		RequestType:    qbtypes.RequestTypeScalar,
 		CompositeQuery: qbtypes.CompositeQuery{Queries: chQueryEnvelopes(names)},
 	}
-	resp, err := q.run(ctx, valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil)
+	resp, err := q.run(ctx, valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	assert.Len(t, resp.Data.Results, numQueries)
@@ -263,7 +269,7 @@ func TestRunRespectsMaxConcurrentQueries(t *testing.T) {
 		RequestType:    qbtypes.RequestTypeScalar,
 		CompositeQuery: qbtypes.CompositeQuery{Queries: chQueryEnvelopes(names)},
 	}
-	resp, err := q.run(context.Background(), valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil)
+	resp, err := q.run(context.Background(), valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	assert.Len(t, resp.Data.Results, len(names))
@@ -305,7 +311,38 @@ func TestRunQueryErrorCancelsSiblings(t *testing.T) {
 		RequestType:    qbtypes.RequestTypeScalar,
 		CompositeQuery: qbtypes.CompositeQuery{Queries: chQueryEnvelopes([]string{"A", "B"})},
 	}
-	_, err := q.run(context.Background(), valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil)
+	_, err := q.run(context.Background(), valuer.GenerateUUID(), qs, req, nil, &qbtypes.QBEvent{}, nil, nil, nil)
 	require.ErrorContains(t, err, "query A failed")
 	assert.True(t, bCanceled.Load(), "query B should be canceled once query A fails")
 }
+
+func TestMissingQueries(t *testing.T) {
+	q := &querier{logger: instrumentationtest.New().Logger()}
+	orgID := valuer.GenerateUUID()
+	newLogsQuery := func(name string) qbtypes.Query {
+		spec := qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: name, Signal: telemetrytypes.SignalLogs}
+		return newBuilderQuery(q.logger, nil, orgID, nil, qbtypes.QueryTypeBuilder, spec, qbtypes.TimeRange{From: 0, To: 3_600_000}, qbtypes.RequestTypeTimeSeries, nil, builderConfig{})
+	}
+	qs := map[string]qbtypes.Query{
+		"A": newLogsQuery("A"),
+		"B": newLogsQuery("B"),
+		"C": newLogsQuery("C"),
+		"D": newLogsQuery("D"),
+	}
+	plans := map[string]*cachePlan{
+		// the last ten minutes are missing
+		"A": {cached: &qbtypes.Result{}, missing: []*qbtypes.TimeRange{{From: 3_000_000, To: 3_600_000}}},
+		// the whole window is missing
+		"B": {missing: []*qbtypes.TimeRange{{From: 0, To: 3_600_000}}},
+		// the whole window is cached
+		"C": {cached: &qbtypes.Result{}},
+		// D bypasses the cache
+	}
+
+	windows := make([][2]uint64, 0)
+	for _, query := range q.missingQueries(orgID, qs, plans) {
+		start, end := query.Window()
+		windows = append(windows, [2]uint64{start, end})
+	}
+	assert.ElementsMatch(t, [][2]uint64{{3_000_000, 3_600_000}, {0, 3_600_000}, {0, 3_600_000}}, windows)
+}
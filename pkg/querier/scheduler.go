@@ -0,0 +1,606 @@
+package querier
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	ErrCodeQueryQuotaExceeded = errors.MustNewCode("query_quota_exceeded")
+	ErrCodeQueryQueueTimeout  = errors.MustNewCode("query_queue_timeout")
+)
+
+const (
+	throttleReasonQueriesPerMinute = "queries_per_minute"
+	throttleReasonBytesReadPerHour = "bytes_read_per_hour"
+	throttleReasonQueueTimeout     = "queue_timeout"
+)
+
+const (
+	attrReason = attribute.Key("reason")
+	attrScope  = attribute.Key("scope")
+)
+
+// TenantLimits are the limits of an org, or of a user within their org. Zero means
+// unlimited.
+type TenantLimits struct {
+	// Weight is the share of the querier slots an org gets when orgs compete for them,
+	// relative to the weights of the other orgs. It does not apply to users.
+	Weight int `yaml:"weight" mapstructure:"weight"`
+	// MaxConcurrentQueries is the number of ClickHouse queries running at once.
+	MaxConcurrentQueries int `yaml:"max_concurrent_queries" mapstructure:"max_concurrent_queries"`
+	// QueriesPerMinute is the number of query range requests per minute.
+	QueriesPerMinute int `yaml:"queries_per_minute" mapstructure:"queries_per_minute"`
+	// BytesReadPerHour is the number of bytes the ClickHouse queries may read per hour.
+	BytesReadPerHour uint64 `yaml:"bytes_read_per_hour" mapstructure:"bytes_read_per_hour"`
+}
+
+// SchedulerConfig is the configuration of the scheduler sharing the querier between
+// orgs. The ClickHouse queries of all requests, live tails included, share
+// MaxConcurrentQueries slots, given to the orgs waiting for one by weighted fair queuing
+// and to the requests of an org in order. Requests over their queries per minute or
+// bytes read per hour quota are throttled. Requests without claims, such as the ones of
+// the rule manager, are scheduled but never throttled.
+type SchedulerConfig struct {
+	// Enabled turns the scheduler on.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// MaxConcurrentQueries is the number of ClickHouse queries running at once across orgs.
+	MaxConcurrentQueries int `yaml:"max_concurrent_queries" mapstructure:"max_concurrent_queries"`
+	// MaxQueueDuration is how long a query waits for a slot before being throttled.
+	MaxQueueDuration time.Duration `yaml:"max_queue_duration" mapstructure:"max_queue_duration"`
+	// Org are the limits of the orgs not listed in Orgs.
+	Org TenantLimits `yaml:"org" mapstructure:"org"`
+	// Orgs are the limits by org id, replacing Org.
+	Orgs map[string]TenantLimits `yaml:"orgs" mapstructure:"orgs"`
+	// User are the limits of every user within their org.
+	User TenantLimits `yaml:"user" mapstructure:"user"`
+}
+
+func (c SchedulerConfig) Validate() error {
+	if c.MaxConcurrentQueries <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "scheduler.max_concurrent_queries must be positive, got %d", c.MaxConcurrentQueries)
+	}
+	if c.MaxQueueDuration <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "scheduler.max_queue_duration must be positive, got %v", c.MaxQueueDuration)
+	}
+	for orgID, limits := range c.Orgs {
+		if _, err := valuer.NewUUID(orgID); err != nil {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "scheduler.orgs must be keyed by org id, got %q", orgID)
+		}
+		if err := limits.validate("scheduler.orgs." + orgID); err != nil {
+			return err
+		}
+	}
+	if err := c.Org.validate("scheduler.org"); err != nil {
+		return err
+	}
+	return c.User.validate("scheduler.user")
+}
+
+func (l TenantLimits) validate(path string) error {
+	if l.Weight < 0 || l.MaxConcurrentQueries < 0 || l.QueriesPerMinute < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s limits must not be negative", path)
+	}
+	return nil
+}
+
+type schedulerMetrics struct {
+	queueDuration metric.Float64Histogram
+	queued        metric.Int64UpDownCounter
+	throttled     metric.Int64Counter
+}
+
+func newSchedulerMetrics(meter metric.Meter) (*schedulerMetrics, error) {
+	var errs error
+
+	queueDuration, err := meter.Float64Histogram(
+		"signoz.querier.scheduler.queue.duration",
+		metric.WithDescription("Time ClickHouse queries waited for a querier slot."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	queued, err := meter.Int64UpDownCounter(
+		"signoz.querier.scheduler.queued",
+		metric.WithDescription("Number of ClickHouse queries waiting for a querier slot."),
+		metric.WithUnit("{query}"),
+	)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	throttled, err := meter.Int64Counter(
+		"signoz.querier.scheduler.throttled.count",
+		metric.WithDescription("Total number of query range requests throttled, by reason and scope (org or user)."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	return &schedulerMetrics{
+		queueDuration: queueDuration,
+		queued:        queued,
+		throttled:     throttled,
+	}, errs
+}
+
+// tenantUsage is the usage of an org or a user: its running queries, the times of its
+// requests in the last minute and the bytes it read in the last hour.
+type tenantUsage struct {
+	running   int
+	requests  []time.Time
+	reads     []bytesRead
+	bytesRead uint64
+}
+
+type bytesRead struct {
+	at    time.Time
+	bytes uint64
+}
+
+// trim drops the requests and reads out of their window.
+func (u *tenantUsage) trim(now time.Time) {
+	i := 0
+	for i < len(u.requests) && now.Sub(u.requests[i]) >= time.Minute {
+		i++
+	}
+	u.requests = u.requests[i:]
+
+	i = 0
+	for i < len(u.reads) && now.Sub(u.reads[i].at) >= time.Hour {
+		u.bytesRead -= u.reads[i].bytes
+		i++
+	}
+	u.reads = u.reads[i:]
+}
+
+// throttle returns the reason the usage is over the limits and when it gets back under
+// them, or an empty reason.
+func (u *tenantUsage) throttle(limits TenantLimits, now time.Time) (string, time.Duration) {
+	if limits.QueriesPerMinute > 0 && len(u.requests) >= limits.QueriesPerMinute {
+		return throttleReasonQueriesPerMinute, u.requests[len(u.requests)-limits.QueriesPerMinute].Add(time.Minute).Sub(now)
+	}
+	if limits.BytesReadPerHour > 0 && u.bytesRead >= limits.BytesReadPerHour {
+		// The reads expire in order, the quota frees up once enough of them have.
+		excess := u.bytesRead - limits.BytesReadPerHour
+		for _, read := range u.reads {
+			if read.bytes > excess {
+				return throttleReasonBytesReadPerHour, read.at.Add(time.Hour).Sub(now)
+			}
+			excess -= read.bytes
+		}
+	}
+	return "", 0
+}
+
+func (u *tenantUsage) idle() bool {
+	return u.running == 0 && len(u.requests) == 0 && len(u.reads) == 0
+}
+
+type orgState struct {
+	tenantUsage
+	limits TenantLimits
+	// finish is the virtual finish time of the last slot given to the org.
+	finish float64
+	queue  []*schedulerWaiter
+}
+
+type schedulerWaiter struct {
+	// seq orders the waiters across orgs, for orgs with the same start tag.
+	seq     uint64
+	ticket  *schedulerTicket
+	ready   chan struct{}
+	granted bool
+}
+
+type scheduler struct {
+	config  SchedulerConfig
+	logger  *slog.Logger
+	metrics *schedulerMetrics
+
+	mu      sync.Mutex
+	running int
+	// vtime is the virtual start time of the last slot given, see grant.
+	vtime float64
+	seq   uint64
+	orgs  map[string]*orgState
+	users map[string]*tenantUsage
+	// swept is when the idle orgs and users were last dropped, see sweep.
+	swept time.Time
+}
+
+func newScheduler(config SchedulerConfig, logger *slog.Logger, meter metric.Meter) *scheduler {
+	if !config.Enabled {
+		return nil
+	}
+
+	metrics, err := newSchedulerMetrics(meter)
+	if err != nil {
+		// The noop instruments never fail, the scheduler records to them instead.
+		logger.Error("failed to create scheduler metrics, not recording them", errors.Attr(err))
+		metrics, _ = newSchedulerMetrics(noop.NewMeterProvider().Meter(""))
+	}
+
+	return &scheduler{
+		config:  config,
+		logger:  logger,
+		metrics: metrics,
+		orgs:    make(map[string]*orgState),
+		users:   make(map[string]*tenantUsage),
+	}
+}
+
+// schedulerTicket schedules the ClickHouse queries of a query range request and
+// accounts for them.
+type schedulerTicket struct {
+	scheduler *scheduler
+	org       string
+	user      string
+
+	mu        sync.Mutex
+	queued    time.Duration
+	throttled bool
+}
+
+// admit throttles the request when its org or its user is over its queries per minute
+// or bytes read per hour quota, and returns the ticket scheduling its queries otherwise.
+func (s *scheduler) admit(ctx context.Context, orgID valuer.UUID) (*schedulerTicket, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	t := &schedulerTicket{scheduler: s, org: orgID.StringValue()}
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return t, nil
+	}
+	if claims.UserID != "" {
+		t.user = t.org + "/" + claims.UserID
+	} else if claims.ServiceAccountID != "" {
+		t.user = t.org + "/" + claims.ServiceAccountID
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.swept) >= time.Minute {
+		s.sweep(now)
+	}
+
+	org := s.org(t.org)
+	org.trim(now)
+	if reason, retryAfter := org.throttle(org.limits, now); reason != "" {
+		s.metrics.throttled.Add(ctx, 1, metric.WithAttributes(attrReason.String(reason), attrScope.String("org")))
+		return nil, newThrottledError(reason, "org", retryAfter)
+	}
+
+	if t.user != "" {
+		user := s.user(t.user)
+		user.trim(now)
+		if reason, retryAfter := user.throttle(s.config.User, now); reason != "" {
+			s.metrics.throttled.Add(ctx, 1, metric.WithAttributes(attrReason.String(reason), attrScope.String("user")))
+			return nil, newThrottledError(reason, "user", retryAfter)
+		}
+		if s.config.User.QueriesPerMinute > 0 {
+			user.requests = append(user.requests, now)
+		}
+	}
+	// Requests are kept for the quota only, so that the state of the orgs and users
+	// without one is dropped as soon as they are idle.
+	if org.limits.QueriesPerMinute > 0 {
+		org.requests = append(org.requests, now)
+	}
+
+	return t, nil
+}
+
+func newThrottledError(reason string, scope string, retryAfter time.Duration) error {
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+	switch reason {
+	case throttleReasonQueriesPerMinute:
+		return errors.Newf(errors.TypeTooManyRequests, ErrCodeQueryQuotaExceeded, "The %s ran out of its queries per minute quota, retry in %v.", scope, retryAfter.Round(time.Second)).WithRetryAfter(retryAfter)
+	default:
+		return errors.Newf(errors.TypeTooManyRequests, ErrCodeQueryQuotaExceeded, "The %s ran out of its bytes read per hour quota, retry in %v.", scope, retryAfter.Round(time.Second)).WithRetryAfter(retryAfter)
+	}
+}
+
+func (s *scheduler) org(key string) *orgState {
+	org, ok := s.orgs[key]
+	if !ok {
+		limits, ok := s.config.Orgs[key]
+		if !ok {
+			limits = s.config.Org
+		}
+		if limits.Weight <= 0 {
+			limits.Weight = 1
+		}
+		org = &orgState{limits: limits, finish: s.vtime}
+		s.orgs[key] = org
+	}
+	return org
+}
+
+func (s *scheduler) user(key string) *tenantUsage {
+	user, ok := s.users[key]
+	if !ok {
+		user = &tenantUsage{}
+		s.users[key] = user
+	}
+	return user
+}
+
+// acquire waits for a slot for a ClickHouse query of the request. Queries waiting longer
+// than the max queue duration are throttled.
+func (t *schedulerTicket) acquire(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	s := t.scheduler
+	start := time.Now()
+
+	s.mu.Lock()
+	org := s.org(t.org)
+	if len(org.queue) == 0 && s.eligible(org, t) && s.running < s.config.MaxConcurrentQueries {
+		s.grant(org, t)
+		s.mu.Unlock()
+		s.metrics.queueDuration.Record(ctx, 0)
+		return nil
+	}
+
+	s.seq++
+	w := &schedulerWaiter{seq: s.seq, ticket: t, ready: make(chan struct{})}
+	org.queue = append(org.queue, w)
+	// The querier has free slots, the org or the user is at its concurrency limit.
+	limited := s.running < s.config.MaxConcurrentQueries
+	s.mu.Unlock()
+	s.metrics.queued.Add(ctx, 1)
+
+	timer := time.NewTimer(s.config.MaxQueueDuration)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timer.C:
+		err = errors.Newf(errors.TypeTooManyRequests, ErrCodeQueryQueueTimeout, "The query waited more than %v for a querier slot, retry later.", s.config.MaxQueueDuration).WithRetryAfter(s.config.MaxQueueDuration)
+	}
+
+	if err != nil {
+		s.mu.Lock()
+		if w.granted {
+			// Granted while giving up, the slot goes to the next waiter.
+			s.releaseLocked(t)
+		} else {
+			s.remove(org, w)
+			s.prune(t)
+		}
+		s.mu.Unlock()
+	}
+
+	waited := time.Since(start)
+	s.metrics.queued.Add(ctx, -1)
+	s.metrics.queueDuration.Record(ctx, waited.Seconds())
+	if err != nil && errors.Asc(err, ErrCodeQueryQueueTimeout) {
+		s.metrics.throttled.Add(ctx, 1, metric.WithAttributes(attrReason.String(throttleReasonQueueTimeout), attrScope.String("org")))
+	}
+
+	t.mu.Lock()
+	t.queued += waited
+	t.throttled = t.throttled || limited
+	t.mu.Unlock()
+	return err
+}
+
+// release frees the slot of a ClickHouse query of the request.
+func (t *schedulerTicket) release() {
+	if t == nil {
+		return
+	}
+	t.scheduler.mu.Lock()
+	defer t.scheduler.mu.Unlock()
+	t.scheduler.releaseLocked(t)
+}
+
+// record accounts for the bytes read by the queries of the request.
+func (t *schedulerTicket) record(bytes uint64) {
+	if t == nil || bytes == 0 {
+		return
+	}
+	s := t.scheduler
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if org := s.org(t.org); org.limits.BytesReadPerHour > 0 {
+		org.reads = append(org.reads, bytesRead{at: now, bytes: bytes})
+		org.bytesRead += bytes
+	}
+	if t.user != "" && s.config.User.BytesReadPerHour > 0 {
+		user := s.user(t.user)
+		user.reads = append(user.reads, bytesRead{at: now, bytes: bytes})
+		user.bytesRead += bytes
+	}
+	s.prune(t)
+}
+
+// stats returns the time the queries of the request waited for a slot, and whether they
+// waited for the concurrency limit of their org or user rather than for the querier.
+func (t *schedulerTicket) stats() (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.queued, t.throttled
+}
+
+// eligible reports whether a query of the ticket may run without going over the
+// concurrency limits of its org and user.
+func (s *scheduler) eligible(org *orgState, t *schedulerTicket) bool {
+	if org.limits.MaxConcurrentQueries > 0 && org.running >= org.limits.MaxConcurrentQueries {
+		return false
+	}
+	if t.user != "" && s.config.User.MaxConcurrentQueries > 0 {
+		if user, ok := s.users[t.user]; ok && user.running >= s.config.User.MaxConcurrentQueries {
+			return false
+		}
+	}
+	return true
+}
+
+// grant gives a slot to a query of the ticket. Slots are given by start-time fair
+// queuing: an org's start tag is the later of the virtual time and the finish tag of its
+// previous slot, and each slot advances its finish tag by the inverse of its weight, so
+// that competing orgs get slots in proportion to their weights.
+func (s *scheduler) grant(org *orgState, t *schedulerTicket) {
+	start := s.start(org)
+	org.finish = start + 1/float64(org.limits.Weight)
+	s.vtime = start
+
+	s.running++
+	org.running++
+	if t.user != "" {
+		s.user(t.user).running++
+	}
+}
+
+// start returns the start tag of the next slot of the org.
+func (s *scheduler) start(org *orgState) float64 {
+	if org.finish > s.vtime {
+		return org.finish
+	}
+	return s.vtime
+}
+
+func (s *scheduler) releaseLocked(t *schedulerTicket) {
+	s.running--
+	s.org(t.org).running--
+	if t.user != "" {
+		s.user(t.user).running--
+	}
+	s.dispatch()
+	s.prune(t)
+}
+
+// prune drops the state of the org and the user of the ticket once they are idle and no
+// query of the org waits, e.g. when its last query finishes or gives up waiting.
+func (s *scheduler) prune(t *schedulerTicket) {
+	if user, ok := s.users[t.user]; ok && user.idle() {
+		delete(s.users, t.user)
+	}
+	if org, ok := s.orgs[t.org]; ok && org.idle() && len(org.queue) == 0 {
+		delete(s.orgs, t.org)
+	}
+}
+
+// sweep drops the state of the orgs and users whose quota windows have passed since
+// their last request, which prune does not see.
+func (s *scheduler) sweep(now time.Time) {
+	for key, org := range s.orgs {
+		org.trim(now)
+		if org.idle() && len(org.queue) == 0 {
+			delete(s.orgs, key)
+		}
+	}
+	for key, user := range s.users {
+		user.trim(now)
+		if user.idle() {
+			delete(s.users, key)
+		}
+	}
+	s.swept = now
+}
+
+// dispatch gives the free slots to the waiting queries, to the org with the earliest
+// start tag first and within an org in order. Orgs with the same start tag are served
+// in the order their queries queued.
+func (s *scheduler) dispatch() {
+	for s.running < s.config.MaxConcurrentQueries {
+		var (
+			next     *orgState
+			nextIdx  int
+			earliest float64
+		)
+		for _, org := range s.orgs {
+			if len(org.queue) == 0 {
+				continue
+			}
+			idx := -1
+			for i, w := range org.queue {
+				if s.eligible(org, w.ticket) {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				continue
+			}
+			if start := s.start(org); next == nil || start < earliest || (start == earliest && org.queue[idx].seq < next.queue[nextIdx].seq) {
+				next, nextIdx, earliest = org, idx, start
+			}
+		}
+		if next == nil {
+			return
+		}
+
+		w := next.queue[nextIdx]
+		next.queue = append(next.queue[:nextIdx], next.queue[nextIdx+1:]...)
+		s.grant(next, w.ticket)
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+func (s *scheduler) remove(org *orgState, w *schedulerWaiter) {
+	for i, queued := range org.queue {
+		if queued == w {
+			org.queue = append(org.queue[:i], org.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// querySlots bound the ClickHouse queries of a request: to maxConcurrentQueries for the
+// request, and through the scheduler ticket to the slots of the querier.
+type querySlots struct {
+	sem    chan struct{}
+	ticket *schedulerTicket
+}
+
+func newQuerySlots(maxConcurrentQueries int, ticket *schedulerTicket) *querySlots {
+	return &querySlots{sem: make(chan struct{}, maxConcurrentQueries), ticket: ticket}
+}
+
+func (s *querySlots) acquire(ctx context.Context) error {
+	s.sem <- struct{}{}
+	if err := s.ticket.acquire(ctx); err != nil {
+		<-s.sem
+		return err
+	}
+	return nil
+}
+
+func (s *querySlots) release() {
+	s.ticket.release()
+	<-s.sem
+}
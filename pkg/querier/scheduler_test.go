@@ -0,0 +1,251 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheduler(config SchedulerConfig) *scheduler {
+	settings := instrumentationtest.New().ToProviderSettings()
+	config.Enabled = true
+	if config.MaxConcurrentQueries == 0 {
+		config.MaxConcurrentQueries = 1
+	}
+	if config.MaxQueueDuration == 0 {
+		config.MaxQueueDuration = 5 * time.Second
+	}
+	return newScheduler(config, settings.Logger, settings.MeterProvider.Meter("test"))
+}
+
+func contextWithUser(orgID valuer.UUID) context.Context {
+	return authtypes.NewContextWithClaims(context.Background(), authtypes.Claims{UserID: valuer.GenerateUUID().StringValue(), OrgID: orgID.StringValue()})
+}
+
+// waitQueued waits for n queries to be waiting for a slot.
+func waitQueued(t *testing.T, s *scheduler, n int) {
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		queued := 0
+		for _, org := range s.orgs {
+			queued += len(org.queue)
+		}
+		return queued == n
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestSchedulerWeightedFairQueuing(t *testing.T) {
+	orgA, orgB := valuer.GenerateUUID(), valuer.GenerateUUID()
+	s := newTestScheduler(SchedulerConfig{
+		Org:  TenantLimits{Weight: 1},
+		Orgs: map[string]TenantLimits{orgA.StringValue(): {Weight: 2}},
+	})
+
+	first, err := s.admit(context.Background(), orgA)
+	require.NoError(t, err)
+	require.NoError(t, first.acquire(context.Background()))
+
+	granted := make(chan *schedulerTicket)
+	queued := 0
+	queue := func(orgID valuer.UUID, n int) {
+		for i := 0; i < n; i++ {
+			ticket, err := s.admit(context.Background(), orgID)
+			require.NoError(t, err)
+			go func() {
+				assert.NoError(t, ticket.acquire(context.Background()))
+				granted <- ticket
+			}()
+			// Queue one at a time, for the order of the queries to be known.
+			queued++
+			waitQueued(t, s, queued)
+		}
+	}
+	queue(orgA, 4)
+	queue(orgB, 4)
+
+	order := make([]string, 0, 6)
+	first.release()
+	for i := 0; i < 6; i++ {
+		ticket := <-granted
+		if ticket.org == orgA.StringValue() {
+			order = append(order, "A")
+		} else {
+			order = append(order, "B")
+		}
+		ticket.release()
+	}
+	// A has twice the weight of B, and was served once already.
+	assert.Equal(t, []string{"B", "A", "A", "B", "A", "A"}, order)
+
+	for i := 0; i < 2; i++ {
+		(<-granted).release()
+	}
+}
+
+func TestSchedulerUserConcurrency(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	s := newTestScheduler(SchedulerConfig{
+		MaxConcurrentQueries: 4,
+		User:                 TenantLimits{MaxConcurrentQueries: 1},
+	})
+
+	ctx := contextWithUser(orgID)
+	ticket, err := s.admit(ctx, orgID)
+	require.NoError(t, err)
+	require.NoError(t, ticket.acquire(ctx))
+
+	// Another user of the org is not limited by the first one.
+	other, err := s.admit(contextWithUser(orgID), orgID)
+	require.NoError(t, err)
+	require.NoError(t, other.acquire(context.Background()))
+	other.release()
+
+	done := make(chan error)
+	go func() { done <- ticket.acquire(ctx) }()
+	waitQueued(t, s, 1)
+	ticket.release()
+	require.NoError(t, <-done)
+	ticket.release()
+
+	_, throttled := ticket.stats()
+	assert.True(t, throttled)
+	_, throttled = other.stats()
+	assert.False(t, throttled)
+}
+
+func TestSchedulerQueriesPerMinute(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	s := newTestScheduler(SchedulerConfig{
+		Org:  TenantLimits{Weight: 1, QueriesPerMinute: 3},
+		User: TenantLimits{QueriesPerMinute: 2},
+	})
+
+	ctx := contextWithUser(orgID)
+	for i := 0; i < 2; i++ {
+		_, err := s.admit(ctx, orgID)
+		require.NoError(t, err)
+	}
+	_, err := s.admit(ctx, orgID)
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, ErrCodeQueryQuotaExceeded))
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+	assert.Contains(t, errors.AsJSON(err).Message, "The user ran out of its queries per minute quota")
+
+	_, err = s.admit(contextWithUser(orgID), orgID)
+	require.NoError(t, err)
+	_, err = s.admit(contextWithUser(orgID), orgID)
+	require.Error(t, err)
+	assert.Contains(t, errors.AsJSON(err).Message, "The org ran out of its queries per minute quota")
+
+	// Requests without claims, such as the ones of the rule manager, are never throttled.
+	_, err = s.admit(context.Background(), orgID)
+	require.NoError(t, err)
+}
+
+func TestSchedulerBytesReadPerHour(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	s := newTestScheduler(SchedulerConfig{
+		Org: TenantLimits{Weight: 1, BytesReadPerHour: 1000},
+	})
+
+	ctx := contextWithUser(orgID)
+	ticket, err := s.admit(ctx, orgID)
+	require.NoError(t, err)
+	ticket.record(600)
+
+	ticket, err = s.admit(ctx, orgID)
+	require.NoError(t, err)
+	ticket.record(600)
+
+	_, err = s.admit(ctx, orgID)
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, ErrCodeQueryQuotaExceeded))
+	assert.Contains(t, errors.AsJSON(err).Message, "The org ran out of its bytes read per hour quota")
+
+	// The reads expire after an hour.
+	org := s.orgs[orgID.StringValue()]
+	org.reads[0].at = org.reads[0].at.Add(-time.Hour)
+	_, err = s.admit(ctx, orgID)
+	require.NoError(t, err)
+}
+
+func TestSchedulerQueueTimeout(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	s := newTestScheduler(SchedulerConfig{MaxQueueDuration: 10 * time.Millisecond})
+
+	running, err := s.admit(context.Background(), orgID)
+	require.NoError(t, err)
+	require.NoError(t, running.acquire(context.Background()))
+
+	ticket, err := s.admit(context.Background(), orgID)
+	require.NoError(t, err)
+	err = ticket.acquire(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, ErrCodeQueryQueueTimeout))
+
+	queued, throttled := ticket.stats()
+	assert.GreaterOrEqual(t, queued, 10*time.Millisecond)
+	// The query waited for the querier, not for a limit of its org.
+	assert.False(t, throttled)
+
+	running.release()
+	require.NoError(t, ticket.acquire(context.Background()))
+	ticket.release()
+}
+
+func TestSchedulerPrunesOrgs(t *testing.T) {
+	orgA, orgB := valuer.GenerateUUID(), valuer.GenerateUUID()
+	s := newTestScheduler(SchedulerConfig{
+		MaxQueueDuration: 10 * time.Millisecond,
+		Orgs:             map[string]TenantLimits{orgB.StringValue(): {QueriesPerMinute: 10}},
+	})
+
+	running, err := s.admit(context.Background(), orgA)
+	require.NoError(t, err)
+	require.NoError(t, running.acquire(context.Background()))
+
+	// the waiters of orgB time out, its state stays for its quota only
+	for range 2 {
+		ctx := contextWithUser(orgB)
+		ticket, err := s.admit(ctx, orgB)
+		require.NoError(t, err)
+		require.Error(t, ticket.acquire(ctx))
+	}
+	s.mu.Lock()
+	assert.Contains(t, s.orgs, orgB.StringValue())
+	assert.Empty(t, s.users)
+	s.mu.Unlock()
+
+	running.release()
+	s.mu.Lock()
+	assert.NotContains(t, s.orgs, orgA.StringValue())
+
+	// once its quota window has passed, the next request drops it
+	s.swept = time.Time{}
+	s.orgs[orgB.StringValue()].requests = []time.Time{time.Now().Add(-time.Hour)}
+	s.mu.Unlock()
+
+	_, err = s.admit(contextWithUser(orgA), orgA)
+	require.NoError(t, err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.NotContains(t, s.orgs, orgB.StringValue())
+}
+
+func TestSchedulerMetricsFallback(t *testing.T) {
+	settings := instrumentationtest.New().ToProviderSettings()
+	s := newScheduler(SchedulerConfig{Enabled: true, MaxConcurrentQueries: 1, MaxQueueDuration: time.Second}, settings.Logger, failingMeter{})
+
+	ticket, err := s.admit(context.Background(), valuer.GenerateUUID())
+	require.NoError(t, err)
+	require.NoError(t, ticket.acquire(context.Background()))
+	ticket.release()
+}
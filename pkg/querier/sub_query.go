@@ -0,0 +1,118 @@
+package querier
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// addSubQueryVariables compiles the builder_sub_query queries of the request and returns the
+// variables with one more per sub-query, named after its reference (`@A`). Sub-queries are
+// compiled in order, so a sub-query can filter on the values of the ones before it.
+func (q *querier) addSubQueryVariables(orgID valuer.UUID, req *qbtypes.QueryRangeRequest, tmplVars map[string]qbtypes.VariableItem) (map[string]qbtypes.VariableItem, error) {
+	if !slices.ContainsFunc(req.CompositeQuery.Queries, func(e qbtypes.QueryEnvelope) bool { return e.Type == qbtypes.QueryTypeSubQuery }) {
+		return tmplVars, nil
+	}
+
+	vars := maps.Clone(tmplVars)
+	for _, envelope := range req.CompositeQuery.Queries {
+		if envelope.Type != qbtypes.QueryTypeSubQuery {
+			continue
+		}
+		// each sub-query sees only the ones before it, which rules out cycles
+		subQuery, err := q.newSubQuery(orgID, envelope, req, maps.Clone(vars))
+		if err != nil {
+			return nil, err
+		}
+		vars[qbtypes.SubQueryRef(subQuery.Name)] = qbtypes.VariableItem{Value: subQuery}
+	}
+
+	// trace operators compile the queries they reference themselves, without the sub-queries
+	traceOperatorDependencies, err := q.constructTraceOperatorDependencyMap(req.CompositeQuery.Queries)
+	if err != nil {
+		return nil, err
+	}
+	for _, envelope := range req.CompositeQuery.Queries {
+		subQueries := qbtypes.SubQueriesIn(envelope.GetFilter(), vars)
+		if len(subQueries) == 0 {
+			continue
+		}
+		if !supportsSubQueries(envelope) || traceOperatorDependencies[envelope.GetQueryName()] {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "query %q cannot filter on sub-query %q, only logs and traces builder queries can", envelope.GetQueryName(), subQueries[0].Name)
+		}
+	}
+
+	return vars, nil
+}
+
+// newSubQuery compiles a sub-query to the distinct values of its single group by key, as a
+// scalar query ranking them by its aggregations.
+func (q *querier) newSubQuery(orgID valuer.UUID, envelope qbtypes.QueryEnvelope, req *qbtypes.QueryRangeRequest, vars map[string]qbtypes.VariableItem) (*qbtypes.SubQuery, error) {
+	timeRange := qbtypes.TimeRange{From: req.Start, To: req.End}
+
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		if err := prepareSubQuerySpec(&spec, qbtypes.TraceAggregation{Expression: "count()"}); err != nil {
+			return nil, err
+		}
+		return &qbtypes.SubQuery{
+			Name:      spec.Name,
+			Column:    groupByKeyColumn(spec.GroupBy[0]),
+			Statement: newBuilderQuery(q.logger, q.telemetryStore, orgID, q.traceStmtBuilder, envelope.Type, spec, timeRange, qbtypes.RequestTypeScalar, vars, builderConfig{}),
+		}, nil
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		if spec.Source == telemetrytypes.SourceAudit {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "sub-query %q cannot read the %s source, only logs and traces", spec.Name, spec.Source.StringValue())
+		}
+		if err := prepareSubQuerySpec(&spec, qbtypes.LogAggregation{Expression: "count()"}); err != nil {
+			return nil, err
+		}
+		return &qbtypes.SubQuery{
+			Name:      spec.Name,
+			Column:    groupByKeyColumn(spec.GroupBy[0]),
+			Statement: newBuilderQuery(q.logger, q.telemetryStore, orgID, q.logStmtBuilder, envelope.Type, spec, timeRange, qbtypes.RequestTypeScalar, vars, q.builderConfig),
+		}, nil
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "sub-query %q must be a logs or traces builder query", envelope.GetQueryName())
+	}
+}
+
+// prepareSubQuerySpec checks that the sub-query selects a single key and bounds the number of
+// values it returns. Without aggregations, the values are ranked by their number of records.
+func prepareSubQuerySpec[T any](spec *qbtypes.QueryBuilderQuery[T], defaultAggregation T) error {
+	if len(spec.GroupBy) != 1 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "sub-query %q must group by exactly one key, the values it returns", spec.Name)
+	}
+	if spec.Limit > qbtypes.MaxSubQueryLimit {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "sub-query %q limit %d exceeds the maximum of %d", spec.Name, spec.Limit, qbtypes.MaxSubQueryLimit)
+	}
+	if spec.Limit <= 0 {
+		spec.Limit = qbtypes.DefaultSubQueryLimit
+	}
+	if len(spec.Aggregations) == 0 {
+		spec.Aggregations = []T{defaultAggregation}
+	}
+	return nil
+}
+
+// groupByKeyColumn returns the column the statement builders output the group by key as.
+func groupByKeyColumn(key qbtypes.GroupByKey) string {
+	return "__GROUP_BY_KEY_0_" + key.Name
+}
+
+// supportsSubQueries reports whether the filter of the query can read sub-queries: the
+// conditions of only the logs and traces statement builders do.
+func supportsSubQueries(envelope qbtypes.QueryEnvelope) bool {
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return envelope.Type == qbtypes.QueryTypeBuilder || envelope.Type == qbtypes.QueryTypeSubQuery
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return spec.Source != telemetrytypes.SourceAudit
+	default:
+		return false
+	}
+}
@@ -0,0 +1,149 @@
+package querier
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderQueryStatementWithSubQuery(t *testing.T) {
+	subQuery := &qbtypes.SubQuery{
+		Name:   "A",
+		Column: "__GROUP_BY_KEY_0_trace_id",
+		Statement: newBuilderQuery(slog.Default(), nil, valuer.GenerateUUID(),
+			&mockJoinStmtBuilder[qbtypes.TraceAggregation]{query: "SELECT trace_id", args: []any{"sub"}},
+			qbtypes.QueryTypeSubQuery, qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{Name: "A"},
+			qbtypes.TimeRange{From: 1, To: 2}, qbtypes.RequestTypeScalar, nil, builderConfig{}),
+	}
+	variables := map[string]qbtypes.VariableItem{"@A": {Value: subQuery}}
+
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "plain statement",
+			query:    "SELECT logs",
+			expected: "WITH __sub_query_A AS (SELECT trace_id) SELECT logs",
+		},
+		{
+			name:     "statement with ctes",
+			query:    "WITH __resource_filter AS (SELECT fingerprint) SELECT logs",
+			expected: "WITH __sub_query_A AS (SELECT trace_id), __resource_filter AS (SELECT fingerprint) SELECT logs",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			query := newBuilderQuery(slog.Default(), nil, valuer.GenerateUUID(),
+				&mockJoinStmtBuilder[qbtypes.LogAggregation]{query: c.query, args: []any{"logs"}},
+				qbtypes.QueryTypeBuilder, qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+					Name:   "B",
+					Signal: telemetrytypes.SignalLogs,
+					Filter: &qbtypes.Filter{Expression: "trace_id IN @A"},
+				},
+				qbtypes.TimeRange{From: 1, To: 2}, qbtypes.RequestTypeRaw, variables, builderConfig{})
+
+			stmt, err := query.Statement(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, stmt.Query)
+			assert.Equal(t, []any{"sub", "logs"}, stmt.Args)
+			assert.Empty(t, query.Fingerprint())
+		})
+	}
+}
+
+func subQueryTestRequest(queries ...qbtypes.QueryEnvelope) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:          uint64(time.Now().Add(-5 * time.Minute).UnixMilli()),
+		End:            uint64(time.Now().UnixMilli()),
+		RequestType:    qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{Queries: queries},
+	}
+}
+
+func TestAddSubQueryVariables(t *testing.T) {
+	q := newJoinTestQuerier(t, telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{}))
+
+	subQuery := qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeSubQuery,
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Name:    "A",
+			Signal:  telemetrytypes.SignalTraces,
+			GroupBy: joinGroupBy("trace_id"),
+		},
+	}
+	logs := qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeBuilder,
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+			Name:   "B",
+			Signal: telemetrytypes.SignalLogs,
+			Filter: &qbtypes.Filter{Expression: "trace_id IN @A"},
+		},
+	}
+
+	vars, err := q.addSubQueryVariables(valuer.GenerateUUID(), subQueryTestRequest(subQuery, logs), map[string]qbtypes.VariableItem{})
+	require.NoError(t, err)
+	require.Contains(t, vars, "@A")
+	compiled, ok := vars["@A"].Value.(*qbtypes.SubQuery)
+	require.True(t, ok)
+	assert.Equal(t, "A", compiled.Name)
+	assert.Equal(t, "__GROUP_BY_KEY_0_trace_id", compiled.Column)
+
+	cases := []struct {
+		name     string
+		queries  []qbtypes.QueryEnvelope
+		expected string
+	}{
+		{
+			name: "no group by",
+			queries: []qbtypes.QueryEnvelope{{
+				Type: qbtypes.QueryTypeSubQuery,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{Name: "A", Signal: telemetrytypes.SignalTraces},
+			}},
+			expected: "must group by exactly one key",
+		},
+		{
+			name: "limit above maximum",
+			queries: []qbtypes.QueryEnvelope{{
+				Type: qbtypes.QueryTypeSubQuery,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{Name: "A", Signal: telemetrytypes.SignalTraces, GroupBy: joinGroupBy("trace_id"), Limit: qbtypes.MaxSubQueryLimit + 1},
+			}},
+			expected: "exceeds the maximum",
+		},
+		{
+			name: "metrics sub-query",
+			queries: []qbtypes.QueryEnvelope{{
+				Type: qbtypes.QueryTypeSubQuery,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{Name: "A", Signal: telemetrytypes.SignalMetrics, GroupBy: joinGroupBy("host.name")},
+			}},
+			expected: "must be a logs or traces builder query",
+		},
+		{
+			name: "metrics query referencing a sub-query",
+			queries: []qbtypes.QueryEnvelope{subQuery, {
+				Type: qbtypes.QueryTypeBuilder,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{Name: "B", Signal: telemetrytypes.SignalMetrics, Filter: &qbtypes.Filter{Expression: "host.name IN @A"}},
+			}},
+			expected: `query "B" cannot filter on sub-query "A"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := q.addSubQueryVariables(valuer.GenerateUUID(), subQueryTestRequest(c.queries...), map[string]qbtypes.VariableItem{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), c.expected)
+		})
+	}
+}
@@ -78,6 +78,7 @@ func (q *traceOperatorQuery) executeWithContext(ctx context.Context, query strin
 		totalBytes += p.Bytes
 		elapsed += p.Elapsed
 	}))
+	ctx, recordStatement := queryProfileFromContext(ctx).statement(ctx, query, args)
 
 	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
 	if err != nil {
@@ -93,6 +94,7 @@ func (q *traceOperatorQuery) executeWithContext(ctx context.Context, query strin
 	if err != nil {
 		return nil, err
 	}
+	recordStatement(totalRows, totalBytes)
 
 	// TODO: This should move to readAsRaw function in consume.go but for now we can keep it here since it's only relevant for traces
 	if raw, ok := payload.(*qbtypes.RawData); ok {
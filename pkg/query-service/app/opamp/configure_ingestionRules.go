@@ -12,8 +12,14 @@ import (
 	"github.com/SigNoz/signoz/pkg/errors"
 	model "github.com/SigNoz/signoz/pkg/query-service/app/opamp/model"
 	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig"
+	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+// SpanMetricsProcessorName is the traces processor generating RED metrics from span metrics rules.
+// It is an instance of the signozspanmetrics processor the collector registers, named apart from
+// the instances of the collector config emitting the APM metrics so that it does not replace them.
+const SpanMetricsProcessorName = "signozspanmetrics/rules"
+
 var (
 	CodeNoAgentsAvailable          = errors.MustNewCode("no_agents_available")
 	CodeOpAmpServerDown            = errors.MustNewCode("opamp_server_down")
@@ -69,6 +75,58 @@ func UpsertControlProcessors(ctx context.Context, signal string,
 	return hash, nil
 }
 
+// AgentDeployment is the outcome of pushing ingestion control processors to one agent.
+type AgentDeployment struct {
+	AgentID string
+	// ConfigHash identifies the config sent to the agent, empty when it could not be sent.
+	ConfigHash string
+	Err        error
+}
+
+// UpsertControlProcessorsForOrg inserts or updates ingestion control processors on every
+// agent of the org and reports the outcome per agent. Unlike UpsertControlProcessors it
+// supports several agents on the traces pipeline, so it must not be used for processors
+// that need to see whole traces, such as tail sampling. The callback is subscribed for
+// every agent the config was sent to.
+func UpsertControlProcessorsForOrg(ctx context.Context, orgID valuer.UUID, signal string,
+	processors map[string]interface{}, callback model.OnChangeCallback,
+) ([]AgentDeployment, error) {
+	slog.DebugContext(ctx, "initiating ingestion rules deployment config for org", "org_id", orgID, "signal", signal, "processors", processors)
+
+	if signal != string(Metrics) && signal != string(Traces) {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "signal not supported in ingestion rules: %s", signal)
+	}
+
+	if opAmpServer == nil {
+		return nil, errors.NewInternalf(CodeOpAmpServerDown, "opamp server is down, unable to push config to agent at this moment")
+	}
+
+	deployments := []AgentDeployment{}
+	for _, agent := range opAmpServer.agents.GetAllAgents() {
+		if agent.OrgID != orgID {
+			continue
+		}
+
+		agentHash, err := addIngestionControlToAgent(agent, signal, processors, false)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to push ingestion rules config to agent", "agent_id", agent.AgentID, errors.Attr(err))
+			deployments = append(deployments, AgentDeployment{AgentID: agent.AgentID, Err: err})
+			continue
+		}
+
+		if agentHash != "" {
+			model.ListenToConfigUpdate(agent.OrgID, agent.AgentID, agentHash, callback)
+		}
+		deployments = append(deployments, AgentDeployment{AgentID: agent.AgentID, ConfigHash: agentHash})
+	}
+
+	if len(deployments) == 0 {
+		return nil, errors.NewInternalf(CodeNoAgentsAvailable, "no agents available at the moment")
+	}
+
+	return deployments, nil
+}
+
 // addIngestionControlToAgent adds ingestion contorl rules to agent config
 func addIngestionControlToAgent(agent *model.Agent, signal string, processors map[string]interface{}, withLB bool) (string, error) {
 	confHash := ""
@@ -81,7 +139,7 @@ func addIngestionControlToAgent(agent *model.Agent, signal string, processors ma
 	agentConf := confmap.NewFromStringMap(c)
 
 	// add ingestion control spec
-	err = makeIngestionControlSpec(agentConf, Signal(signal), processors)
+	err = makeIngestionControlSpec(agentConf, agent.OrgID, Signal(signal), processors)
 	if err != nil {
 		slog.Error("failed to prepare ingestion control processors for agent", "agent_id", agent.AgentID, errors.Attr(err))
 		return confHash, err
@@ -124,7 +182,7 @@ func addIngestionControlToAgent(agent *model.Agent, signal string, processors ma
 }
 
 // prepare spec to introduce ingestion control in agent conf
-func makeIngestionControlSpec(agentConf *confmap.Conf, signal Signal, processors map[string]interface{}) error {
+func makeIngestionControlSpec(agentConf *confmap.Conf, orgID valuer.UUID, signal Signal, processors map[string]interface{}) error {
 	configParser := otelconfig.NewConfigParser(agentConf)
 	configParser.UpdateProcessors(processors)
 
@@ -132,7 +190,7 @@ func makeIngestionControlSpec(agentConf *confmap.Conf, signal Signal, processors
 	currentPipeline := configParser.PipelineProcessors(string(signal))
 
 	// merge tracesPipelinePlan with current pipeline
-	mergedPipeline, err := buildPipeline(signal, currentPipeline)
+	mergedPipeline, err := buildPipeline(signal, orgID, currentPipeline)
 	if err != nil {
 		slog.Error("failed to build pipeline", "signal", string(signal), errors.Attr(err))
 		return err
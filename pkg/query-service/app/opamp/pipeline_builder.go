@@ -3,7 +3,10 @@ package opamp
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 var lockTracesPipelineSpec sync.RWMutex
@@ -15,16 +18,25 @@ type pipelineStatus struct {
 }
 
 var tracesPipelineSpec = map[int]pipelineStatus{
+	// span metrics are generated before sampling so that they account for every span
 	0: {
-		Name:    "signoz_tail_sampling",
+		Name:    SpanMetricsProcessorName,
 		Enabled: false,
 	},
 	1: {
+		Name:    "signoz_tail_sampling",
+		Enabled: false,
+	},
+	2: {
 		Name:    "batch",
 		Enabled: true,
 	},
 }
 
+// tracesPipelineOrgSpec holds, per org, the processors of tracesPipelineSpec enabled for the
+// agents of that org only, on top of those enabled for every org.
+var tracesPipelineOrgSpec = map[valuer.UUID]map[string]bool{}
+
 var metricsPipelineSpec = map[int]pipelineStatus{
 	0: {
 		Name:    "filter",
@@ -76,6 +88,29 @@ func RemoveFromTracePipelineSpec(name string) {
 	updatePipelineSpec("traces", name, false)
 }
 
+// AddToTracePipelineSpecForOrg enables the processor in the traces pipeline of the agents of the org.
+func AddToTracePipelineSpecForOrg(orgID valuer.UUID, processor string) {
+	lockTracesPipelineSpec.Lock()
+	defer lockTracesPipelineSpec.Unlock()
+
+	if tracesPipelineOrgSpec[orgID] == nil {
+		tracesPipelineOrgSpec[orgID] = map[string]bool{}
+	}
+	tracesPipelineOrgSpec[orgID][processor] = true
+}
+
+// RemoveFromTracePipelineSpecForOrg removes a processor enabled with AddToTracePipelineSpecForOrg
+// from the traces pipeline of the agents of the org.
+func RemoveFromTracePipelineSpecForOrg(orgID valuer.UUID, name string) {
+	lockTracesPipelineSpec.Lock()
+	defer lockTracesPipelineSpec.Unlock()
+
+	delete(tracesPipelineOrgSpec[orgID], name)
+	if len(tracesPipelineOrgSpec[orgID]) == 0 {
+		delete(tracesPipelineOrgSpec, orgID)
+	}
+}
+
 // AddToMetricsPipeline to enable processor in traces pipeline
 func AddToMetricsPipelineSpec(processor string) {
 	updatePipelineSpec("metrics", processor, true)
@@ -100,8 +135,11 @@ func checkDuplicates(pipeline []interface{}) bool {
 	return false
 }
 
-func buildPipeline(signal Signal, current []interface{}) ([]interface{}, error) {
+// buildPipeline merges the pipeline spec of the signal, with the processors enabled for the org,
+// into the current processors of the pipeline of an agent of the org.
+func buildPipeline(signal Signal, orgID valuer.UUID, current []interface{}) ([]interface{}, error) {
 	var spec map[int]pipelineStatus
+	var orgSpec map[string]bool
 
 	switch signal {
 	case Metrics:
@@ -112,11 +150,13 @@ func buildPipeline(signal Signal, current []interface{}) ([]interface{}, error)
 		spec = tracesPipelineSpec
 		lockTracesPipelineSpec.Lock()
 		defer lockTracesPipelineSpec.Unlock()
+		orgSpec = tracesPipelineOrgSpec[orgID]
 	default:
 		return nil, fmt.Errorf("invalid signal")
 	}
 
-	pipeline := current
+	// the pipeline is edited in place, which must not change the caller's config
+	pipeline := slices.Clone(current)
 	// create a reverse map of existing config processors and their position
 	existing := map[string]int{}
 	for i, p := range current {
@@ -141,6 +181,7 @@ func buildPipeline(signal Signal, current []interface{}) ([]interface{}, error)
 	// go through plan again in the increasing order
 	for i := 0; i < len(spec); i++ {
 		m := spec[i]
+		m.Enabled = m.Enabled || orgSpec[m.Name]
 
 		if loc, ok := specVsExistingMap[i]; ok {
 			// element from plan already exists in current effective config.
@@ -148,12 +189,11 @@ func buildPipeline(signal Signal, current []interface{}) ([]interface{}, error)
 			currentPos := loc + inserts
 			// if disabled then remove from the pipeline
 			if !m.Enabled {
-				slog.Debug("build_pipeline: found a disabled item, removing from pipeline at position", "position", currentPos-1, "processor", m.Name)
-				if currentPos-1 <= 0 {
-					pipeline = pipeline[currentPos+1:]
-				} else {
-					pipeline = append(pipeline[:currentPos-1], pipeline[currentPos+1:]...)
-				}
+				slog.Debug("build_pipeline: found a disabled item, removing from pipeline at position", "position", currentPos, "processor", m.Name)
+				pipeline = slices.Delete(pipeline, currentPos, currentPos+1)
+				// the removal shifts the elements after it back
+				inserts--
+				continue
 			}
 
 			// capture last position where match was found,  this will be used
@@ -168,19 +208,10 @@ func buildPipeline(signal Signal, current []interface{}) ([]interface{}, error)
 				// we use last matched to insert new item.  This means, we keep inserting missing processors
 				// right after last matched processsor (e.g. insert filters after tail_sampling for existing list of [batch, tail_sampling])
 
-				if lastMatched <= 0 {
-					slog.Debug("build_pipeline: found a new item to be inserted, inserting at position 0", "processor", m.Name)
-					pipeline = append([]interface{}{m.Name}, pipeline[lastMatched+1:]...)
-				} else {
-					slog.Debug("build_pipeline: found a new item to be inserted, inserting at position", "position", lastMatched, "processor", m.Name)
-					prior := make([]interface{}, len(pipeline[:lastMatched]))
-					next := make([]interface{}, len(pipeline[lastMatched:]))
-					copy(prior, pipeline[:lastMatched])
-					copy(next, pipeline[lastMatched:])
-
-					pipeline = append(prior, m.Name)
-					pipeline = append(pipeline, next...)
-				}
+				position := lastMatched + 1
+				slog.Debug("build_pipeline: found a new item to be inserted, inserting at position", "position", position, "processor", m.Name)
+				pipeline = slices.Insert(pipeline, position, interface{}(m.Name))
+				lastMatched = position
 			}
 		}
 	}
@@ -0,0 +1,115 @@
+package opamp
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/SigNoz/signoz/pkg/query-service/app/opamp/otelconfig"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func TestBuildPipeline(t *testing.T) {
+	defer func() {
+		RemoveFromTracePipelineSpec(SpanMetricsProcessorName)
+		RemoveFromTracePipelineSpec("signoz_tail_sampling")
+	}()
+
+	testCases := []struct {
+		name     string
+		enabled  []string
+		current  []interface{}
+		expected []interface{}
+	}{
+		{
+			name:     "nothing_enabled",
+			current:  []interface{}{"batch"},
+			expected: []interface{}{"batch"},
+		},
+		{
+			name:     "insert_before_batch",
+			enabled:  []string{SpanMetricsProcessorName},
+			current:  []interface{}{"batch"},
+			expected: []interface{}{SpanMetricsProcessorName, "batch"},
+		},
+		{
+			name:     "insert_after_matched_processor",
+			enabled:  []string{SpanMetricsProcessorName, "signoz_tail_sampling"},
+			current:  []interface{}{SpanMetricsProcessorName, "batch"},
+			expected: []interface{}{SpanMetricsProcessorName, "signoz_tail_sampling", "batch"},
+		},
+		{
+			name:     "remove_disabled_processor",
+			enabled:  []string{SpanMetricsProcessorName},
+			current:  []interface{}{SpanMetricsProcessorName, "signoz_tail_sampling", "batch"},
+			expected: []interface{}{SpanMetricsProcessorName, "batch"},
+		},
+		{
+			name:     "keep_unmanaged_processors",
+			enabled:  []string{SpanMetricsProcessorName},
+			current:  []interface{}{"memory_limiter", "batch"},
+			expected: []interface{}{SpanMetricsProcessorName, "memory_limiter", "batch"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			RemoveFromTracePipelineSpec(SpanMetricsProcessorName)
+			RemoveFromTracePipelineSpec("signoz_tail_sampling")
+			for _, name := range tc.enabled {
+				AddToTracePipelineSpec(name)
+			}
+
+			current := slices.Clone(tc.current)
+			got, err := buildPipeline(Traces, valuer.GenerateUUID(), current)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.current, current)
+		})
+	}
+}
+
+func TestBuildPipelineForOrg(t *testing.T) {
+	orgID, otherOrgID := valuer.GenerateUUID(), valuer.GenerateUUID()
+	AddToTracePipelineSpecForOrg(orgID, SpanMetricsProcessorName)
+	defer RemoveFromTracePipelineSpecForOrg(orgID, SpanMetricsProcessorName)
+
+	got, err := buildPipeline(Traces, orgID, []interface{}{"batch"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{SpanMetricsProcessorName, "batch"}, got)
+
+	got, err = buildPipeline(Traces, otherOrgID, []interface{}{SpanMetricsProcessorName, "batch"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"batch"}, got)
+
+	RemoveFromTracePipelineSpecForOrg(orgID, SpanMetricsProcessorName)
+	got, err = buildPipeline(Traces, orgID, []interface{}{SpanMetricsProcessorName, "batch"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"batch"}, got)
+}
+
+func TestSpanMetricsProcessorName(t *testing.T) {
+	// the processor type is the part of the name before the slash, and must be one the
+	// collector registers, i.e. one its config uses
+	b, err := os.ReadFile("../../../../.devenv/docker/signoz-otel-collector/otel-collector-config.yaml")
+	require.NoError(t, err)
+	c, err := yaml.Parser().Unmarshal(b)
+	require.NoError(t, err)
+	configParser := otelconfig.NewConfigParser(confmap.NewFromStringMap(c))
+
+	types := map[string]bool{}
+	for name := range configParser.Processors() {
+		processorType, _, _ := strings.Cut(name, "/")
+		types[processorType] = true
+	}
+
+	processorType, _, _ := strings.Cut(SpanMetricsProcessorName, "/")
+	assert.True(t, types[processorType], "processor type %q is not in the collector config", processorType)
+	assert.NotContains(t, configParser.Processors(), SpanMetricsProcessorName, "the rules processor must not replace a processor of the collector config")
+}
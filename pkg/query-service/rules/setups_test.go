@@ -51,7 +51,10 @@ func prepareQuerierForMetrics(t *testing.T, telemetryStore telemetrystore.Teleme
 		nil, // bucketCache
 		fl,
 		0,
-		0, // maxConcurrentQueries (0 means default)
+		0,                         // maxConcurrentQueries (0 means default)
+		querier.AdmissionConfig{}, // admissionConfig
+		querier.SchedulerConfig{}, // schedulerConfig
+		nil,                       // userRoleStore
 	), metadataStore
 }
 
@@ -86,8 +89,11 @@ func prepareQuerierForLogs(t *testing.T, telemetryStore telemetrystore.Telemetry
 		nil, // traceOperatorStmtBuilder
 		nil, // bucketCache
 		fl,
-		5*time.Minute, // logTraceIDWindowPadding
-		0,             // maxConcurrentQueries (0 means default)
+		5*time.Minute,             // logTraceIDWindowPadding
+		0,                         // maxConcurrentQueries (0 means default)
+		querier.AdmissionConfig{}, // admissionConfig
+		querier.SchedulerConfig{}, // schedulerConfig
+		nil,                       // userRoleStore
 	)
 }
 
@@ -124,6 +130,9 @@ func prepareQuerierForTraces(t *testing.T, telemetryStore telemetrystore.Telemet
 		nil, // bucketCache
 		fl,
 		0,
-		0, // maxConcurrentQueries (0 means default)
+		0,                         // maxConcurrentQueries (0 means default)
+		querier.AdmissionConfig{}, // admissionConfig
+		querier.SchedulerConfig{}, // schedulerConfig
+		nil,                       // userRoleStore
 	)
 }
@@ -0,0 +1,34 @@
+package querybuilder
+
+import (
+	"fmt"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	distributionBucketSign  = "__bucket_sign"
+	distributionBucketIndex = "__bucket_index"
+)
+
+// AddDistributionBuckets counts the rows of sb in the distribution bucket of the value
+// expression. Bucket i of positive values holds (2^((i-1)/n), 2^(i/n)], with n buckets per
+// power of two, and the buckets of negative values mirror them; zero has its own bucket.
+// Rows without a finite value are not counted. sb is grouped by the bucket, its bounds are
+// selected as qbtypes.DistributionBucketLowerColumn and qbtypes.DistributionBucketUpperColumn.
+func AddDistributionBuckets(sb *sqlbuilder.SelectBuilder, valueExpr string) {
+	value := sqlbuilder.Escape(valueExpr)
+	n := 1 << qbtypes.DistributionScale
+
+	sb.SelectMore(
+		fmt.Sprintf("sign(%s) AS %s", value, distributionBucketSign),
+		fmt.Sprintf("if(%s = 0, 0, toInt32(ceil(log2(abs(%s)) * %d))) AS %s", value, value, n, distributionBucketIndex),
+		fmt.Sprintf("multiIf(%[1]s > 0, exp2((%[2]s - 1) / %[3]d), %[1]s < 0, -exp2(%[2]s / %[3]d), 0) AS %[4]s",
+			distributionBucketSign, distributionBucketIndex, n, qbtypes.DistributionBucketLowerColumn),
+		fmt.Sprintf("multiIf(%[1]s > 0, exp2(%[2]s / %[3]d), %[1]s < 0, -exp2((%[2]s - 1) / %[3]d), 0) AS %[4]s",
+			distributionBucketSign, distributionBucketIndex, n, qbtypes.DistributionBucketUpperColumn),
+	)
+	sb.Where(fmt.Sprintf("isFinite(%s)", value))
+	sb.GroupBy(distributionBucketSign, distributionBucketIndex)
+}
@@ -34,6 +34,12 @@ var friendly = map[string]string{
 	"HAS": "has()", "HASANY": "hasAny()", "HASALL": "hasAll()",
 	"HASTOKEN": "hasToken()", "SEARCH": "search()",
 
+	// pipe query
+	"PIPE":   "|",
+	"SOURCE": "source", "LOGS": "logs", "TRACES": "traces",
+	"WHERE": "where", "STATS": "stats", "BY": "by", "AS": "as",
+	"SORT": "sort", "HEAD": "head", "FIELDS": "fields",
+
 	// literals / identifiers
 	"NUMBER":      "number",
 	"STRING":      "string",
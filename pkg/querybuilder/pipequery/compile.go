@@ -0,0 +1,396 @@
+package pipequery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	filtergrammar "github.com/SigNoz/signoz/pkg/parser/filterquery/grammar"
+	grammar "github.com/SigNoz/signoz/pkg/parser/pipequery/grammar"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/antlr4-go/antlr/v4"
+)
+
+const (
+	// queryName is the name of the builder query a pipe query compiles to.
+	queryName = "A"
+
+	// defaultHeadLimit is the number of rows kept by a head command without a count.
+	defaultHeadLimit = 10
+)
+
+// Compiled is a pipe query compiled into a builder query. RequestType is the request
+// type to run it with: scalar when it aggregates, raw otherwise.
+type Compiled struct {
+	RequestType qbtypes.RequestType
+	Query       qbtypes.QueryEnvelope
+}
+
+// Compile parses a pipe query, see grammar/PipeQuery.g4, and compiles it into a builder
+// query:
+//   - the search expression and the where commands before stats make the filter;
+//   - stats makes the aggregations and the group by, and the where commands after it the
+//     having expression;
+//   - sort makes the order, by aggregation when the key is the alias, the expression or the
+//     function name of one, by field otherwise;
+//   - head makes the limit and fields the selected fields.
+//
+// The errors are positioned in the pipe query, including those of the search and where
+// expressions.
+func Compile(text string) (*Compiled, []*querybuilder.SyntaxErr) {
+	input := antlr.NewInputStream(text)
+	lexer := grammar.NewPipeQueryLexer(input)
+	lexerErrorListener := querybuilder.NewErrorListener()
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(lexerErrorListener)
+
+	p := grammar.NewPipeQueryParser(antlr.NewCommonTokenStream(lexer, 0))
+	parserErrorListener := querybuilder.NewErrorListener()
+	p.RemoveErrorListeners()
+	p.AddErrorListener(parserErrorListener)
+	tree := p.Query()
+
+	if errs := append(lexerErrorListener.SyntaxErrors, parserErrorListener.SyntaxErrors...); len(errs) > 0 {
+		return nil, errs
+	}
+
+	c := &compiler{input: input}
+	c.compile(tree)
+	if len(c.errs) > 0 {
+		return nil, c.errs
+	}
+
+	compiled := &Compiled{RequestType: qbtypes.RequestTypeRaw}
+	if c.stats != nil {
+		compiled.RequestType = qbtypes.RequestTypeScalar
+	}
+
+	switch c.signal {
+	case telemetrytypes.SignalLogs:
+		aggregations := make([]qbtypes.LogAggregation, 0, len(c.aggregations))
+		for _, agg := range c.aggregations {
+			aggregations = append(aggregations, qbtypes.LogAggregation{Expression: agg.expression, Alias: agg.alias})
+		}
+		if c.having != nil {
+			if _, err := querybuilder.NewHavingExpressionRewriter().RewriteForLogs(c.having.Expression, aggregations); err != nil {
+				return nil, []*querybuilder.SyntaxErr{havingErr(c.havingTok, err)}
+			}
+		}
+		compiled.Query = qbtypes.QueryEnvelope{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Name:         queryName,
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: aggregations,
+				Filter:       c.filter,
+				GroupBy:      c.groupBy,
+				Having:       c.having,
+				Order:        c.order,
+				SelectFields: c.selectFields,
+				Limit:        c.limit,
+			},
+		}
+	case telemetrytypes.SignalTraces:
+		aggregations := make([]qbtypes.TraceAggregation, 0, len(c.aggregations))
+		for _, agg := range c.aggregations {
+			aggregations = append(aggregations, qbtypes.TraceAggregation{Expression: agg.expression, Alias: agg.alias})
+		}
+		if c.having != nil {
+			if _, err := querybuilder.NewHavingExpressionRewriter().RewriteForTraces(c.having.Expression, aggregations); err != nil {
+				return nil, []*querybuilder.SyntaxErr{havingErr(c.havingTok, err)}
+			}
+		}
+		compiled.Query = qbtypes.QueryEnvelope{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Name:         queryName,
+				Signal:       telemetrytypes.SignalTraces,
+				Aggregations: aggregations,
+				Filter:       c.filter,
+				GroupBy:      c.groupBy,
+				Having:       c.having,
+				Order:        c.order,
+				SelectFields: c.selectFields,
+				Limit:        c.limit,
+			},
+		}
+	}
+
+	return compiled, nil
+}
+
+// aggregation is a function call of a stats command such as count() or p99(duration_nano).
+// Name is the function name and Expression the text of the call.
+type aggregation struct {
+	tok        antlr.Token
+	name       string
+	expression string
+	alias      string
+}
+
+type compiler struct {
+	input antlr.CharStream
+
+	signal       telemetrytypes.Signal
+	filters      []string
+	stats        grammar.IStatsCommandContext
+	aggregations []*aggregation
+	groupBy      []qbtypes.GroupByKey
+	havings      []string
+	havingTok    antlr.Token
+	order        []qbtypes.OrderBy
+	selectFields []telemetrytypes.TelemetryFieldKey
+	limit        int
+
+	filter *qbtypes.Filter
+	having *qbtypes.Having
+	errs   []*querybuilder.SyntaxErr
+}
+
+func (c *compiler) compile(q grammar.IQueryContext) {
+	if q.Source().LOGS() != nil {
+		c.signal = telemetrytypes.SignalLogs
+	} else {
+		c.signal = telemetrytypes.SignalTraces
+	}
+
+	if search := q.Search(); search != nil {
+		c.addFilter(search.ExpressionText())
+	}
+
+	var head grammar.IHeadCommandContext
+	for _, cmd := range q.AllCommand() {
+		if head != nil {
+			c.errorf(cmd.GetStart(), "head must be the last command")
+			return
+		}
+
+		switch {
+		case cmd.WhereCommand() != nil:
+			expr := cmd.WhereCommand().ExpressionText()
+			if c.stats != nil {
+				if len(c.havings) == 0 {
+					c.havingTok = expr.GetStart()
+				}
+				c.havings = append(c.havings, c.text(expr))
+			} else {
+				c.addFilter(expr)
+			}
+		case cmd.StatsCommand() != nil:
+			c.addStats(cmd.StatsCommand())
+		case cmd.SortCommand() != nil:
+			c.addSort(cmd.SortCommand())
+		case cmd.HeadCommand() != nil:
+			head = cmd.HeadCommand()
+			c.addHead(head)
+		case cmd.FieldsCommand() != nil:
+			if c.stats != nil || c.selectFields != nil {
+				c.errorf(cmd.GetStart(), "fields cannot follow stats or another fields command")
+				continue
+			}
+			for _, f := range cmd.FieldsCommand().AllField() {
+				c.selectFields = append(c.selectFields, telemetrytypes.GetFieldKeyFromKeyText(fieldName(f)))
+			}
+		}
+	}
+
+	if len(c.filters) > 0 {
+		c.filter = &qbtypes.Filter{Expression: combine(c.filters)}
+	}
+	if len(c.havings) > 0 {
+		c.having = &qbtypes.Having{Expression: combine(c.havings)}
+	}
+}
+
+// text returns the text of a parse tree node as written in the pipe query, spaces
+// included.
+func (c *compiler) text(ctx antlr.ParserRuleContext) string {
+	return c.input.GetText(ctx.GetStart().GetStart(), ctx.GetStop().GetStop())
+}
+
+// addFilter validates the syntax of a search or where expression against FilterQuery.g4,
+// reporting the errors at their position in the pipe query.
+func (c *compiler) addFilter(expr grammar.IExpressionTextContext) {
+	text := c.text(expr)
+	start := expr.GetStart()
+
+	lexer := filtergrammar.NewFilterQueryLexer(antlr.NewInputStream(text))
+	lexerErrorListener := querybuilder.NewErrorListener()
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(lexerErrorListener)
+
+	p := filtergrammar.NewFilterQueryParser(antlr.NewCommonTokenStream(lexer, 0))
+	parserErrorListener := querybuilder.NewErrorListener()
+	p.RemoveErrorListeners()
+	p.AddErrorListener(parserErrorListener)
+	p.Query()
+
+	for _, err := range append(lexerErrorListener.SyntaxErrors, parserErrorListener.SyntaxErrors...) {
+		// Positions are relative to the expression, which starts at its first token.
+		if err.Line == 1 {
+			err.Col += start.GetColumn()
+		}
+		err.Line += start.GetLine() - 1
+		c.errs = append(c.errs, err)
+	}
+	c.filters = append(c.filters, text)
+}
+
+func (c *compiler) addStats(cmd grammar.IStatsCommandContext) {
+	if c.stats != nil {
+		c.errorf(cmd.GetStart(), "only one stats command is supported")
+		return
+	}
+	if c.selectFields != nil {
+		c.errorf(cmd.GetStart(), "stats cannot follow fields")
+		return
+	}
+	if c.order != nil {
+		c.errorf(cmd.GetStart(), "stats cannot follow sort, sort the aggregated rows instead")
+		return
+	}
+	c.stats = cmd
+
+	for _, ctx := range cmd.AllAggregation() {
+		call := ctx.FunctionCall()
+		agg := &aggregation{
+			tok:        call.IDENTIFIER().GetSymbol(),
+			name:       call.IDENTIFIER().GetText(),
+			expression: c.text(call),
+		}
+		if alias := ctx.Field(); alias != nil {
+			agg.alias = fieldName(alias)
+		}
+
+		if _, ok := querybuilder.AggreFuncMap[valuer.NewString(strings.ToLower(agg.name))]; !ok {
+			c.errorf(agg.tok, "unknown aggregation function %q", agg.name)
+			continue
+		}
+		c.aggregations = append(c.aggregations, agg)
+	}
+	for _, f := range cmd.AllField() {
+		c.groupBy = append(c.groupBy, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.GetFieldKeyFromKeyText(fieldName(f))})
+	}
+}
+
+func (c *compiler) addSort(cmd grammar.ISortCommandContext) {
+	for _, key := range cmd.AllSortKey() {
+		direction := qbtypes.OrderDirectionAsc
+		if key.MINUS() != nil {
+			direction = qbtypes.OrderDirectionDesc
+		}
+		field := fieldName(key.Field())
+
+		if c.stats == nil {
+			c.order = append(c.order, qbtypes.OrderBy{
+				Key:       qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.GetFieldKeyFromKeyText(field)},
+				Direction: direction,
+			})
+			continue
+		}
+
+		name, ok := c.sortKeyName(field)
+		if !ok {
+			c.errorf(key.Field().GetStart(), "cannot sort by %q, expected an aggregation or a group by field", field)
+			continue
+		}
+		c.order = append(c.order, qbtypes.OrderBy{
+			Key:       qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: name}},
+			Direction: direction,
+		})
+	}
+}
+
+func (c *compiler) addHead(cmd grammar.IHeadCommandContext) {
+	c.limit = defaultHeadLimit
+	if cmd.NUMBER() == nil {
+		return
+	}
+
+	limit, err := strconv.Atoi(cmd.NUMBER().GetText())
+	if err != nil || limit <= 0 {
+		c.errorf(cmd.NUMBER().GetSymbol(), "head count must be a positive number")
+		return
+	}
+	c.limit = limit
+}
+
+// sortKeyName resolves a sort key following stats to the name the statement builders
+// order by: the alias or the expression of an aggregation, or a group by field.
+func (c *compiler) sortKeyName(name string) (string, bool) {
+	for _, agg := range c.aggregations {
+		if agg.alias != "" && agg.alias == name {
+			return agg.alias, true
+		}
+		if agg.expression == name || strings.EqualFold(agg.name, name) {
+			if agg.alias != "" {
+				return agg.alias, true
+			}
+			return agg.expression, true
+		}
+	}
+	for _, key := range c.groupBy {
+		if key.Name == name || key.Text() == name {
+			return key.Name, true
+		}
+	}
+	return "", false
+}
+
+func (c *compiler) errorf(tok antlr.Token, format string, args ...any) {
+	c.errs = append(c.errs, &querybuilder.SyntaxErr{
+		Line:      tok.GetLine(),
+		Col:       tok.GetColumn(),
+		TokenTxt:  "'" + tok.GetText() + "'",
+		TokenType: tok.GetTokenType(),
+		Msg:       fmt.Sprintf(format, args...),
+	})
+}
+
+func havingErr(tok antlr.Token, err error) *querybuilder.SyntaxErr {
+	inner := errors.AsJSON(err)
+	msg := inner.Message
+	for _, additional := range inner.Errors {
+		msg += "; " + additional.Message
+	}
+	return &querybuilder.SyntaxErr{Line: tok.GetLine(), Col: tok.GetColumn(), TokenTxt: "'" + tok.GetText() + "'", TokenType: tok.GetTokenType(), Msg: msg}
+}
+
+// fieldName returns the name of a field, unquoted.
+func fieldName(f grammar.IFieldContext) string {
+	if f.QUOTED_TEXT() == nil {
+		return f.GetText()
+	}
+	return unquote(f.QUOTED_TEXT().GetText())
+}
+
+func unquote(text string) string {
+	if len(text) < 2 {
+		return text
+	}
+	inner := text[1 : len(text)-1]
+	var b strings.Builder
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String()
+}
+
+// combine joins expressions with AND, parenthesizing them when there are several.
+func combine(expressions []string) string {
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+	parts := make([]string, 0, len(expressions))
+	for _, expression := range expressions {
+		parts = append(parts, "("+expression+")")
+	}
+	return strings.Join(parts, " AND ")
+}
@@ -0,0 +1,188 @@
+package pipequery
+
+import (
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       string
+		requestType qbtypes.RequestType
+		expected    any
+	}{
+		{
+			name:        "stats by with sort and head",
+			query:       `source=logs service.name="api" severity_text=ERROR | stats count() by k8s.pod.name | sort -count | head 10`,
+			requestType: qbtypes.RequestTypeScalar,
+			expected: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Name:         "A",
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+				Filter:       &qbtypes.Filter{Expression: `service.name="api" severity_text=ERROR`},
+				GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "k8s.pod.name"}}},
+				Order: []qbtypes.OrderBy{{
+					Key:       qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "count()"}},
+					Direction: qbtypes.OrderDirectionDesc,
+				}},
+				Limit: 10,
+			},
+		},
+		{
+			name:        "where before and after stats",
+			query:       "source=traces | where service.name = 'api' | where has_error = true | stats p99(duration_nano) as p99, count() by http.route | where p99 > 1000000 | sort -p99, http.route | head",
+			requestType: qbtypes.RequestTypeScalar,
+			expected: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalTraces,
+				Aggregations: []qbtypes.TraceAggregation{
+					{Expression: "p99(duration_nano)", Alias: "p99"},
+					{Expression: "count()"},
+				},
+				Filter:  &qbtypes.Filter{Expression: "(service.name = 'api') AND (has_error = true)"},
+				GroupBy: []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "http.route"}}},
+				Having:  &qbtypes.Having{Expression: "p99 > 1000000"},
+				Order: []qbtypes.OrderBy{
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "p99"}}, Direction: qbtypes.OrderDirectionDesc},
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "http.route"}}, Direction: qbtypes.OrderDirectionAsc},
+				},
+				Limit: 10,
+			},
+		},
+		{
+			name:        "raw with fields",
+			query:       "source=logs body CONTAINS 'timeout' | fields service.name, body | sort -timestamp | head 50",
+			requestType: qbtypes.RequestTypeRaw,
+			expected: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Name:         "A",
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{},
+				Filter:       &qbtypes.Filter{Expression: "body CONTAINS 'timeout'"},
+				SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "service.name"}, {Name: "body"}},
+				Order: []qbtypes.OrderBy{{
+					Key:       qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "timestamp"}},
+					Direction: qbtypes.OrderDirectionDesc,
+				}},
+				Limit: 50,
+			},
+		},
+		{
+			name:        "keywords as field names",
+			query:       "source=logs | fields source, 'stats' | sort -head",
+			requestType: qbtypes.RequestTypeRaw,
+			expected: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Name:         "A",
+				Signal:       telemetrytypes.SignalLogs,
+				Aggregations: []qbtypes.LogAggregation{},
+				SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "source"}, {Name: "stats"}},
+				Order: []qbtypes.OrderBy{{
+					Key:       qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "head"}},
+					Direction: qbtypes.OrderDirectionDesc,
+				}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			compiled, errs := Compile(c.query)
+			require.Empty(t, errs)
+			assert.Equal(t, c.requestType, compiled.RequestType)
+			assert.Equal(t, qbtypes.QueryTypeBuilder, compiled.Query.Type)
+			assert.Equal(t, c.expected, compiled.Query.Spec)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "missing source",
+			query:    "service.name = 'api'",
+			expected: []string{"line 1:0 expecting one of {logs, source} but got 'service.name'"},
+		},
+		{
+			name:     "unknown signal",
+			query:    "source=metrics",
+			expected: []string{"line 1:7 expecting one of {logs, traces, where} but got 'metrics'"},
+		},
+		{
+			name:  "unterminated quoted text",
+			query: "source=logs body = 'timeout",
+			expected: []string{
+				"line 1:19 token recognition error at: ''timeout'",
+				"line 1:27 expecting one of {boolean, number, quoted text} but got EOF",
+			},
+		},
+		{
+			name:  "errors of every command",
+			query: "source=logs | stats count( | sort | head -1",
+			expected: []string{
+				"line 1:27 expecting one of {'+', '-', (, ), ,, =, IDENTIFIER, OTHER, as, by, fields, head, logs, number, quoted text, sort, source, stats, traces, where} but got '|'",
+				"line 1:34 expecting one of {'+', )} but got '|'",
+				"line 1:41 expecting one of {|} but got '-'",
+			},
+		},
+		{
+			name:     "unknown command",
+			query:    "source=logs | dedup body",
+			expected: []string{"line 1:14 expecting one of {by, fields, head, number, sort, stats, where} but got 'dedup'"},
+		},
+		{
+			name:     "unknown aggregation",
+			query:    "source=traces | stats median(duration_nano)",
+			expected: []string{`line 1:22 unknown aggregation function "median"`},
+		},
+		{
+			name:     "head not last",
+			query:    "source=logs | head 5 | where body = 'x'",
+			expected: []string{"line 1:23 head must be the last command"},
+		},
+		{
+			name:     "sort by unknown key after stats",
+			query:    "source=logs | stats count() by service.name | sort -body",
+			expected: []string{`line 1:52 cannot sort by "body", expected an aggregation or a group by field`},
+		},
+		{
+			name:     "fields after stats",
+			query:    "source=logs | stats count() | fields body",
+			expected: []string{"line 1:30 fields cannot follow stats or another fields command"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, errs := Compile(c.query)
+			actual := make([]string, 0, len(errs))
+			for _, err := range errs {
+				actual = append(actual, err.Error())
+			}
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestCompileFilterErrorPosition(t *testing.T) {
+	_, errs := Compile("source=logs service.name = 'api'\n| where severity_text = ")
+	require.Len(t, errs, 1)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, 23, errs[0].Col)
+	assert.Equal(t, "EOF", errs[0].TokenTxt)
+}
+
+func TestCompileHavingError(t *testing.T) {
+	_, errs := Compile("source=logs | stats count() as total | where avg_duration > 5")
+	require.Len(t, errs, 1)
+	assert.Equal(t, 1, errs[0].Line)
+	assert.Equal(t, 45, errs[0].Col)
+	assert.Contains(t, errs[0].Msg, "avg_duration")
+}
@@ -417,6 +417,7 @@ func (v *filterExpressionVisitor) VisitComparison(ctx *grammar.ComparisonContext
 	if ctx.InClause() != nil || ctx.NotInClause() != nil {
 
 		var values []any
+		var subQuery *qbtypes.SubQuery
 		var retValue any
 		if ctx.InClause() != nil {
 			retValue = v.Visit(ctx.InClause())
@@ -441,6 +442,11 @@ func (v *filterExpressionVisitor) VisitComparison(ctx *grammar.ComparisonContext
 					varItem, ok = v.variables[var_[1:]]
 				}
 
+				// a sub-query is not expanded to values, the condition builders read them from its CTE
+				if sq, isSubQuery := varItem.Value.(*qbtypes.SubQuery); ok && isSubQuery {
+					subQuery, ok = sq, false
+				}
+
 				if ok {
 					// we have a variable, now check for dynamic variable
 					if varItem.Type == qbtypes.DynamicVariableType {
@@ -468,7 +474,12 @@ func (v *filterExpressionVisitor) VisitComparison(ctx *grammar.ComparisonContext
 			op = qbtypes.FilterOperatorNotIn
 		}
 
-		conds, ok := v.buildConditions(key, matching, op, values)
+		var value any = values
+		if subQuery != nil {
+			value = subQuery
+		}
+
+		conds, ok := v.buildConditions(key, matching, op, value)
 		if !ok {
 			return ErrorConditionLiteral
 		}
@@ -24,8 +24,12 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/fields/implfields"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring/implinframonitoring"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost/impllmcost"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule/impllmpricingrule"
+	"github.com/SigNoz/signoz/pkg/modules/logpattern"
+	"github.com/SigNoz/signoz/pkg/modules/logpattern/impllogpattern"
 	"github.com/SigNoz/signoz/pkg/modules/metricreductionrule"
 	"github.com/SigNoz/signoz/pkg/modules/metricreductionrule/implmetricreductionrule"
 	"github.com/SigNoz/signoz/pkg/modules/metricsexplorer"
@@ -34,6 +38,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/quickfilter/implquickfilter"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport/implrawdataexport"
+	"github.com/SigNoz/signoz/pkg/modules/rollup"
+	"github.com/SigNoz/signoz/pkg/modules/rollup/implrollup"
 	"github.com/SigNoz/signoz/pkg/modules/rulestatehistory"
 	"github.com/SigNoz/signoz/pkg/modules/rulestatehistory/implrulestatehistory"
 	"github.com/SigNoz/signoz/pkg/modules/savedview"
@@ -42,8 +48,12 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/serviceaccount/implserviceaccount"
 	"github.com/SigNoz/signoz/pkg/modules/services"
 	"github.com/SigNoz/signoz/pkg/modules/services/implservices"
+	"github.com/SigNoz/signoz/pkg/modules/servicetopology"
+	"github.com/SigNoz/signoz/pkg/modules/servicetopology/implservicetopology"
 	"github.com/SigNoz/signoz/pkg/modules/spanmapper"
 	"github.com/SigNoz/signoz/pkg/modules/spanmapper/implspanmapper"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule/implspanmetricsrule"
 	"github.com/SigNoz/signoz/pkg/modules/spanpercentile"
 	"github.com/SigNoz/signoz/pkg/modules/spanpercentile/implspanpercentile"
 	"github.com/SigNoz/signoz/pkg/modules/tracedetail"
@@ -67,6 +77,9 @@ type Handlers struct {
 	RawDataExport           rawdataexport.Handler
 	SpanPercentile          spanpercentile.Handler
 	Services                services.Handler
+	ServiceTopology         servicetopology.Handler
+	SpanMetricsRule         spanmetricsrule.Handler
+	LogPattern              logpattern.Handler
 	MetricsExplorer         metricsexplorer.Handler
 	MetricReductionRule     metricreductionrule.Handler
 	InfraMonitoring         inframonitoring.Handler
@@ -87,7 +100,9 @@ type Handlers struct {
 	TraceDetail             tracedetail.Handler
 	RulerHandler            ruler.Handler
 	LLMPricingRuleHandler   llmpricingrule.Handler
+	LLMCost                 llmcost.Handler
 	StatsHandler            statsreporter.Handler
+	Rollup                  rollup.Handler
 }
 
 func NewHandlers(
@@ -115,6 +130,9 @@ func NewHandlers(
 		TraceFunnel:             impltracefunnel.NewHandler(modules.TraceFunnel),
 		RawDataExport:           implrawdataexport.NewHandler(modules.RawDataExport),
 		Services:                implservices.NewHandler(modules.Services),
+		ServiceTopology:         implservicetopology.NewHandler(modules.ServiceTopology),
+		SpanMetricsRule:         implspanmetricsrule.NewHandler(modules.SpanMetricsRule),
+		LogPattern:              impllogpattern.NewHandler(modules.LogPattern),
 		MetricsExplorer:         implmetricsexplorer.NewHandler(modules.MetricsExplorer),
 		MetricReductionRule:     implmetricreductionrule.NewHandler(modules.MetricReductionRule),
 		InfraMonitoring:         implinframonitoring.NewHandler(modules.InfraMonitoring),
@@ -123,7 +141,7 @@ func NewHandlers(
 		FlaggerHandler:          flagger.NewHandler(flaggerService),
 		GatewayHandler:          gateway.NewHandler(gatewayService),
 		Fields:                  implfields.NewHandler(providerSettings, telemetryMetadataStore),
-		AIObservability:         implaiobservability.NewHandler(telemetryMetadataStore),
+		AIObservability:         implaiobservability.NewHandler(telemetryMetadataStore, modules.AIObservability, authz),
 		AuthzHandler:            signozauthzapi.NewHandler(authz),
 		ZeusHandler:             zeus.NewHandler(zeusService, licensing),
 		QuerierHandler:          querierHandler,
@@ -136,6 +154,8 @@ func NewHandlers(
 		TraceDetail:             impltracedetail.NewHandler(modules.TraceDetail),
 		RulerHandler:            signozruler.NewHandler(rulerService),
 		LLMPricingRuleHandler:   impllmpricingrule.NewHandler(modules.LLMPricingRule),
+		LLMCost:                 impllmcost.NewHandler(modules.LLMCost, rulerService),
 		StatsHandler:            statsreporter.NewHandler(statsAggregator),
+		Rollup:                  implrollup.NewHandler(modules.Rollup),
 	}
 }
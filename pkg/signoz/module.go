@@ -9,6 +9,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/modules/aiobservability"
+	"github.com/SigNoz/signoz/pkg/modules/aiobservability/implaiobservability"
 	"github.com/SigNoz/signoz/pkg/modules/apdex"
 	"github.com/SigNoz/signoz/pkg/modules/apdex/implapdex"
 	"github.com/SigNoz/signoz/pkg/modules/authdomain"
@@ -17,8 +19,12 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/dashboard"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring"
 	"github.com/SigNoz/signoz/pkg/modules/inframonitoring/implinframonitoring"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost"
+	"github.com/SigNoz/signoz/pkg/modules/llmcost/impllmcost"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule"
 	"github.com/SigNoz/signoz/pkg/modules/llmpricingrule/impllmpricingrule"
+	"github.com/SigNoz/signoz/pkg/modules/logpattern"
+	"github.com/SigNoz/signoz/pkg/modules/logpattern/impllogpattern"
 	"github.com/SigNoz/signoz/pkg/modules/logspipeline"
 	"github.com/SigNoz/signoz/pkg/modules/logspipeline/impllogspipeline"
 	"github.com/SigNoz/signoz/pkg/modules/metricreductionrule"
@@ -35,17 +41,22 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport/implrawdataexport"
 	"github.com/SigNoz/signoz/pkg/modules/retention"
+	"github.com/SigNoz/signoz/pkg/modules/rollup"
 	"github.com/SigNoz/signoz/pkg/modules/rulestatehistory"
 	"github.com/SigNoz/signoz/pkg/modules/rulestatehistory/implrulestatehistory"
 	"github.com/SigNoz/signoz/pkg/modules/savedview"
 	"github.com/SigNoz/signoz/pkg/modules/savedview/implsavedview"
 	"github.com/SigNoz/signoz/pkg/modules/serviceaccount"
 	"github.com/SigNoz/signoz/pkg/modules/services"
+	"github.com/SigNoz/signoz/pkg/modules/servicetopology"
+	"github.com/SigNoz/signoz/pkg/modules/servicetopology/implservicetopology"
 	"github.com/SigNoz/signoz/pkg/modules/services/implservices"
 	"github.com/SigNoz/signoz/pkg/modules/session"
 	"github.com/SigNoz/signoz/pkg/modules/session/implsession"
 	"github.com/SigNoz/signoz/pkg/modules/spanmapper"
 	"github.com/SigNoz/signoz/pkg/modules/spanmapper/implspanmapper"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule"
+	"github.com/SigNoz/signoz/pkg/modules/spanmetricsrule/implspanmetricsrule"
 	"github.com/SigNoz/signoz/pkg/modules/spanpercentile"
 	"github.com/SigNoz/signoz/pkg/modules/spanpercentile/implspanpercentile"
 	"github.com/SigNoz/signoz/pkg/modules/tag"
@@ -82,6 +93,9 @@ type Modules struct {
 	AuthDomain          authdomain.Module
 	Session             session.Module
 	Services            services.Module
+	ServiceTopology     servicetopology.Module
+	SpanMetricsRule     spanmetricsrule.Module
+	LogPattern          logpattern.Module
 	SpanPercentile      spanpercentile.Module
 	MetricsExplorer     metricsexplorer.Module
 	MetricReductionRule metricreductionrule.Module
@@ -95,7 +109,10 @@ type Modules struct {
 	TraceDetail         tracedetail.Module
 	SpanMapper          spanmapper.Module
 	LLMPricingRule      llmpricingrule.Module
+	LLMCost             llmcost.Module
 	Tag                 tag.Module
+	Rollup              rollup.Module
+	AIObservability     aiobservability.Module
 }
 
 func NewModules(
@@ -124,6 +141,7 @@ func NewModules(
 	fl flagger.Flagger,
 	tagModule tag.Module,
 	metricReductionRule metricreductionrule.Module,
+	rollup rollup.Module,
 ) Modules {
 	quickfilter := implquickfilter.NewModule(implquickfilter.NewStore(sqlstore))
 	orgSetter := implorganization.NewSetter(implorganization.NewStore(sqlstore), alertmanager, quickfilter)
@@ -152,6 +170,9 @@ func NewModules(
 		Session:             implsession.NewModule(providerSettings, authNs, userSetter, userGetter, authDomainModule, tokenizer, orgGetter, authz, config.Global),
 		SpanPercentile:      implspanpercentile.NewModule(querier, providerSettings),
 		Services:            implservices.NewModule(querier, telemetryStore),
+		ServiceTopology:     implservicetopology.NewModule(telemetryStore, telemetryMetadataStore, fl, providerSettings),
+		SpanMetricsRule:     implspanmetricsrule.NewModule(implspanmetricsrule.NewStore(sqlstore), telemetryMetadataStore, providerSettings),
+		LogPattern:          impllogpattern.NewModule(telemetryStore, telemetryMetadataStore, fl, providerSettings),
 		MetricsExplorer:     implmetricsexplorer.NewModule(telemetryStore, telemetryMetadataStore, cache, ruleStore, dashboard, fl, providerSettings, config.MetricsExplorer),
 		MetricReductionRule: metricReductionRule,
 		InfraMonitoring:     implinframonitoring.NewModule(telemetryStore, telemetryMetadataStore, querier, fl, providerSettings, config.InfraMonitoring),
@@ -161,9 +182,12 @@ func NewModules(
 		LogsPipeline:        impllogspipeline.NewModule(sqlstore),
 		RuleStateHistory:    implrulestatehistory.NewModule(implrulestatehistory.NewStore(telemetryStore, telemetryMetadataStore, providerSettings.Logger), ruleStore),
 		CloudIntegration:    cloudIntegrationModule,
-		TraceDetail:         impltracedetail.NewModule(impltracedetail.NewTraceStore(telemetryStore), providerSettings, config.TraceDetail),
+		TraceDetail:         impltracedetail.NewModule(impltracedetail.NewTraceStore(telemetryStore), querier, providerSettings, config.TraceDetail),
 		SpanMapper:          implspanmapper.NewModule(implspanmapper.NewStore(sqlstore), fl),
 		LLMPricingRule:      impllmpricingrule.NewModule(impllmpricingrule.NewStore(sqlstore), fl, querier),
+		LLMCost:             impllmcost.NewModule(impllmcost.NewStore(sqlstore), querier),
 		Tag:                 tagModule,
+		Rollup:              rollup,
+		AIObservability:     implaiobservability.NewModule(querier, config.AIObservability),
 	}
 }
@@ -691,6 +691,58 @@ LIMIT 10
 `, stmt)
 }
 
+// Span list (raw) searching the messages: the select fields follow the default
+// timestamp, trace_id and span_id, and the gate is ANDed into the CONTAINS filter.
+func TestBuild_SpanList_Raw_MessageSearch(t *testing.T) {
+	b := newTestBuilder(t)
+	stmt, err := b.Build(context.Background(), valuer.UUID{}, testStartMs, testEndMs, qbtypes.RequestTypeRaw,
+		qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Signal: telemetrytypes.SignalTraces,
+			Filter: &qbtypes.Filter{Expression: "(gen_ai.input.messages CONTAINS 'refund' OR gen_ai.output.messages CONTAINS 'refund')"},
+			SelectFields: []telemetrytypes.TelemetryFieldKey{
+				{Name: "parent_span_id", FieldContext: telemetrytypes.FieldContextSpan},
+				aitelemetryschema.GenAIFields["gen_ai.input.messages"],
+			},
+			Order: []qbtypes.OrderBy{
+				{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "timestamp", FieldContext: telemetrytypes.FieldContextSpan}}, Direction: qbtypes.OrderDirectionDesc},
+			},
+			Limit: 1001,
+		}, nil)
+	require.NoError(t, err)
+
+	sql := normalizeSQL(renderSQL(t, stmt))
+	assert.Contains(t, sql, "SELECT timestamp AS __SELECT_KEY_0_timestamp, trace_id AS __SELECT_KEY_1_trace_id, span_id AS __SELECT_KEY_2_span_id, parent_span_id AS __SELECT_KEY_3_parent_span_id,")
+	assert.Contains(t, sql, "AS __SELECT_KEY_4_gen_ai.input.messages")
+	assert.Contains(t, sql, "mapContains(attributes_string, 'gen_ai.tool.name')")
+	assert.Contains(t, sql, "LOWER(attributes_string['gen_ai.output.messages']) LIKE LOWER('%refund%')")
+	assert.Contains(t, sql, "ORDER BY timestamp desc LIMIT 1001")
+}
+
+// Time series (spend by model): delegated like the span list, the gate ANDed into
+// the user filter of both the top-N groups and the series pass.
+func TestBuild_TimeSeries_CostByModel(t *testing.T) {
+	b := newTestBuilder(t)
+	stmt, err := b.Build(context.Background(), valuer.UUID{}, testStartMs, testEndMs, qbtypes.RequestTypeTimeSeries,
+		qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Signal:       telemetrytypes.SignalTraces,
+			StepInterval: qbtypes.Step{Duration: time.Hour},
+			Filter:       &qbtypes.Filter{Expression: "gen_ai.provider.name = 'openai'"},
+			Aggregations: []qbtypes.TraceAggregation{{Expression: "sum(_signoz.gen_ai.total_cost)"}},
+			GroupBy: []qbtypes.GroupByKey{{
+				TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "gen_ai.request.model"},
+			}},
+			Limit: 10,
+		}, nil)
+	require.NoError(t, err)
+
+	sql := renderSQL(t, stmt)
+	gate := "mapContains(attributes_string, 'gen_ai.request.model') OR mapContains(attributes_string, 'gen_ai.tool.name') OR mapContains(attributes_string, 'gen_ai.agent.name')"
+	assert.Equal(t, 2, strings.Count(normalizeSQL(sql), "(("+gate+"))"), "gate in both the top-N and the series pass")
+	assert.Contains(t, sql, "attributes_string['gen_ai.provider.name'] = 'openai'")
+	assert.Contains(t, sql, "sum(multiIf(mapContains(attributes_number, '_signoz.gen_ai.total_cost')")
+	assert.Contains(t, sql, "toStartOfInterval(timestamp, INTERVAL 3600 SECOND)")
+}
+
 // ---------------------------------------------------------------------------
 // Behavior / branch tests not covered by the goldens above
 // ---------------------------------------------------------------------------
@@ -960,7 +1012,7 @@ func TestBuild_TraceList_DefaultLimit(t *testing.T) {
 	assert.Contains(t, stmt.Args, 100)
 }
 
-// Only trace list and span list (raw) are supported; distribution is not.
+// Trace list, span list (raw), time series and scalar are supported; distribution is not.
 func TestBuild_UnsupportedRequestType(t *testing.T) {
 	b := newTestBuilder(t)
 	query := qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
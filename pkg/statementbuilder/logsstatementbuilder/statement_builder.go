@@ -146,6 +146,8 @@ func (b *logQueryStatementBuilder) Build(
 		stmt, err = b.buildTimeSeriesQuery(ctx, orgID, q, query, start, end, keys, variables)
 	case qbtypes.RequestTypeScalar:
 		stmt, err = b.buildScalarQuery(ctx, orgID, q, query, start, end, keys, false, variables)
+	case qbtypes.RequestTypeDistribution:
+		stmt, err = b.buildDistributionQuery(ctx, orgID, q, query, start, end, keys, variables)
 	default:
 		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported request type: %s", requestType)
 	}
@@ -584,6 +586,103 @@ func (b *logQueryStatementBuilder) buildTimeSeriesQuery(
 	return stmt, nil
 }
 
+// buildDistributionQuery builds a query counting the values of the histogram(<key>)
+// aggregation in distribution buckets, at every step of every group.
+func (b *logQueryStatementBuilder) buildDistributionQuery(
+	ctx context.Context,
+	orgID valuer.UUID,
+	sb *sqlbuilder.SelectBuilder,
+	query qbtypes.QueryBuilderQuery[qbtypes.LogAggregation],
+	start, end uint64,
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+	if len(query.Aggregations) != 1 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "distribution queries must have exactly one aggregation, got %d", len(query.Aggregations))
+	}
+	valueKey, err := qbtypes.DistributionKey(query.Aggregations[0].Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		cteFragments []string
+		cteArgs      [][]any
+	)
+
+	frag, args, skipResourceFilter, err := b.maybeAttachResourceFilter(ctx, orgID, sb, query, start, end, variables)
+	if err != nil {
+		return nil, err
+	}
+	if frag != "" {
+		cteFragments = append(cteFragments, frag)
+		cteArgs = append(cteArgs, args)
+	}
+
+	sb.SelectMore(fmt.Sprintf(
+		"toStartOfInterval(fromUnixTimestamp64Nano(timestamp), INTERVAL %d SECOND) AS ts",
+		int64(query.StepInterval.Seconds()),
+	))
+
+	bodyJSONEnabled := b.fl.BooleanOrEmpty(ctx, flagger.FeatureUseJSONBody, featuretypes.NewFlaggerEvaluationContext(orgID))
+	fieldNames := make([]string, 0, len(query.GroupBy))
+	for i, gb := range query.GroupBy {
+		if !bodyJSONEnabled && (strings.Contains(gb.Name, telemetrytypes.ArraySep) || strings.Contains(gb.Name, telemetrytypes.ArrayAnyIndex)) {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "Group by/Aggregation isn't available for the Array Paths: %s", gb.Name)
+		}
+		expr, err := b.fm.ColumnExpressionFor(ctx, orgID, start, end, &gb.TelemetryFieldKey, telemetrytypes.FieldDataTypeString, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldAlias := groupByColumnAlias(i, gb.Name)
+		sb.SelectMore(fmt.Sprintf("toString(%s) AS `%s`", sqlbuilder.Escape(expr), fieldAlias))
+		fieldNames = append(fieldNames, fmt.Sprintf("`%s`", fieldAlias))
+	}
+
+	valueExpr, err := b.fm.ColumnExpressionFor(ctx, orgID, start, end, &valueKey, telemetrytypes.FieldDataTypeFloat64, keys)
+	if err != nil {
+		return nil, err
+	}
+	querybuilder.AddDistributionBuckets(sb, valueExpr)
+	sb.SelectMore("count() AS __result_0")
+
+	sb.From(fmt.Sprintf("%s.%s", logstelemetryschema.DBName, logstelemetryschema.LogsV2TableName))
+
+	preparedWhereClause, err := b.addFilterCondition(ctx, orgID, sb, start, end, query, keys, variables, skipResourceFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit > 0 && len(query.GroupBy) > 0 {
+		// the groups with the most values
+		limitQuery := query.Copy()
+		limitQuery.Aggregations = []qbtypes.LogAggregation{{Expression: "count()"}}
+		cteStmt, err := b.buildScalarQuery(ctx, orgID, sqlbuilder.NewSelectBuilder(), limitQuery, start, end, keys, true, variables)
+		if err != nil {
+			return nil, err
+		}
+		cteFragments = append(cteFragments, fmt.Sprintf("__limit_cte AS (%s)", cteStmt.Query))
+		cteArgs = append(cteArgs, cteStmt.Args)
+
+		tuple := fmt.Sprintf("(%s)", strings.Join(fieldNames, ", "))
+		sb.Where(fmt.Sprintf("%s GLOBAL IN (SELECT %s FROM __limit_cte)", tuple, strings.Join(fieldNames, ", ")))
+	}
+
+	sb.GroupBy("ts")
+	sb.GroupBy(fieldNames...)
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	return &qbtypes.Statement{
+		Query:          querybuilder.CombineCTEs(cteFragments) + mainSQL,
+		Args:           querybuilder.PrependArgs(cteArgs, mainArgs),
+		Warnings:       preparedWhereClause.Warnings,
+		WarningsDocURL: preparedWhereClause.WarningsDocURL,
+		CostGuard:      b.costGuardFor(ctx, orgID, preparedWhereClause.RequiresCostGuard),
+	}, nil
+}
+
 // buildScalarQuery builds a query for scalar panel type.
 func (b *logQueryStatementBuilder) buildScalarQuery(
 	ctx context.Context,
@@ -208,6 +208,24 @@ func TestStatementBuilderTimeSeries(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			startTs:     releaseTimeNano + uint64(24*time.Hour.Nanoseconds()),
+			endTs:       releaseTimeNano + uint64(48*time.Hour.Nanoseconds()),
+			name:        "Distribution of duration",
+			requestType: qbtypes.RequestTypeDistribution,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal:       telemetrytypes.SignalLogs,
+				StepInterval: qbtypes.Step{Duration: 30 * time.Second},
+				Aggregations: []qbtypes.LogAggregation{
+					{Expression: "histogram(duration)"},
+				},
+			},
+			expected: qbtypes.Statement{
+				Query: "SELECT toStartOfInterval(fromUnixTimestamp64Nano(timestamp), INTERVAL 30 SECOND) AS ts, sign(multiIf(mapContains(attributes_number, 'duration'), toFloat64(attributes_number['duration']), NULL)) AS __bucket_sign, if(multiIf(mapContains(attributes_number, 'duration'), toFloat64(attributes_number['duration']), NULL) = 0, 0, toInt32(ceil(log2(abs(multiIf(mapContains(attributes_number, 'duration'), toFloat64(attributes_number['duration']), NULL))) * 4))) AS __bucket_index, multiIf(__bucket_sign > 0, exp2((__bucket_index - 1) / 4), __bucket_sign < 0, -exp2(__bucket_index / 4), 0) AS __bucket_lower, multiIf(__bucket_sign > 0, exp2(__bucket_index / 4), __bucket_sign < 0, -exp2((__bucket_index - 1) / 4), 0) AS __bucket_upper, count() AS __result_0 FROM signoz_logs.distributed_logs_v2 WHERE isFinite(multiIf(mapContains(attributes_number, 'duration'), toFloat64(attributes_number['duration']), NULL)) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? GROUP BY __bucket_sign, __bucket_index, ts",
+				Args:  []any{"1705399200000000000", uint64(1705397400), "1705485600000000000", uint64(1705485600)},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	ctx := context.Background()
@@ -0,0 +1,276 @@
+package metricsstatementbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/metricstelemetryschema"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// expHistogramDistributionBuckets is the number of buckets a distribution of an exponential
+// histogram is split into: the sketch holds no buckets of its own, so the bounds are read at
+// evenly spaced ranks and every bucket holds the same share of the values.
+const expHistogramDistributionBuckets = 20
+
+// isNativeExpHistogramQuery reports whether the query is answered by merging the sketches
+// of an exponential histogram per temporality: percentiles and distributions are, every
+// other aggregation reads the count and sum columns like before.
+func isNativeExpHistogramQuery(requestType qbtypes.RequestType, query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]) bool {
+	if query.Aggregations[0].Type != metrictypes.ExpHistogramType {
+		return false
+	}
+	return requestType == qbtypes.RequestTypeDistribution || query.Aggregations[0].SpaceAggregation.IsPercentile()
+}
+
+// expHistogramDistributionRanks returns the ranks the bounds of the distribution buckets
+// are read at, from 0 to 1.
+func expHistogramDistributionRanks() []float64 {
+	ranks := make([]float64, 0, expHistogramDistributionBuckets+1)
+	for i := 0; i <= expHistogramDistributionBuckets; i++ {
+		ranks = append(ranks, float64(i)/expHistogramDistributionBuckets)
+	}
+	return ranks
+}
+
+// buildExpHistogramStatement builds the query of an exponential histogram from its
+// sketches. The temporal aggregation picks the sketches of every series in each step, the
+// spatial aggregation merges those of the series of a group into the quantiles the final
+// select needs, and the final select either returns the percentile or turns the quantiles
+// into the buckets of a distribution.
+func (b *StatementBuilder) buildExpHistogramStatement(
+	ctx context.Context,
+	orgID valuer.UUID,
+	start, end uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+	agg := query.Aggregations[0]
+
+	samplesTable, _ := metricstelemetryschema.WhichSamplesTableToUse(start, end, agg.Type, agg.TimeAggregation, false, agg.TableHints)
+	tsStart, tsEnd, _, tsTable := metricstelemetryschema.WhichTSTableToUse(start, end, false, agg.TableHints)
+
+	timeSeriesCTE, timeSeriesCTEArgs, filterWarnings, err := b.buildTimeSeriesCTE(ctx, orgID, tsStart, tsEnd, query, keys, variables, tsTable)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := []float64{agg.SpaceAggregation.Percentile()}
+	if requestType == qbtypes.RequestTypeDistribution {
+		ranks = expHistogramDistributionRanks()
+	}
+
+	temporalFrag, temporalArgs := b.buildExpHistogramTemporalAggregationCTE(start, end, query, samplesTable, timeSeriesCTE, timeSeriesCTEArgs)
+	spatialFrag, spatialArgs := b.buildExpHistogramSpatialAggregationCTE(query, ranks)
+	cteFragments := []string{temporalFrag, spatialFrag}
+	cteArgs := [][]any{temporalArgs, spatialArgs}
+
+	var stmt *qbtypes.Statement
+	if requestType == qbtypes.RequestTypeDistribution {
+		stmt = b.buildExpHistogramDistributionSelect(cteFragments, cteArgs, query)
+	} else if stmt, err = b.buildExpHistogramPercentileSelect(cteFragments, cteArgs, query); err != nil {
+		return nil, err
+	}
+	stmt.Warnings = append(stmt.Warnings, filterWarnings...)
+	return stmt, nil
+}
+
+// buildExpHistogramTemporalAggregationCTE returns the sketches of each series in each step,
+// with the count of values they hold, as (ts, groups..., per_series_sketch,
+// per_series_count) rows. Every delta point counts the values of its own interval and is
+// kept as it is. A cumulative point counts every value since the start of its series and
+// a sketch cannot be subtracted from another, so only the last point of the series in the
+// step is kept: its percentiles cover the lifetime of the series, not the step. A metric
+// with both temporalities takes the union of the two.
+func (b *StatementBuilder) buildExpHistogramTemporalAggregationCTE(
+	start, end uint64,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+	samplesTable string,
+	timeSeriesCTE string,
+	timeSeriesCTEArgs []any,
+) (string, []any) {
+	var q string
+	var args []any
+	switch query.Aggregations[0].Temporality {
+	case metrictypes.Delta:
+		q, args = b.buildExpHistogramDelta(start, end, query, samplesTable, timeSeriesCTE, timeSeriesCTEArgs, "")
+	case metrictypes.Multiple:
+		deltaQuery, deltaArgs := b.buildExpHistogramDelta(start, end, query, samplesTable, timeSeriesCTE, timeSeriesCTEArgs, "LOWER(temporality) LIKE LOWER('delta')")
+		cumulativeQuery, cumulativeArgs := b.buildExpHistogramCumulative(start, end, query, samplesTable, timeSeriesCTE, timeSeriesCTEArgs, "LOWER(temporality) NOT LIKE LOWER('delta')")
+		q = fmt.Sprintf("SELECT * FROM (%s) UNION ALL SELECT * FROM (%s)", deltaQuery, cumulativeQuery)
+		args = append(deltaArgs, cumulativeArgs...)
+	default:
+		q, args = b.buildExpHistogramCumulative(start, end, query, samplesTable, timeSeriesCTE, timeSeriesCTEArgs, "")
+	}
+	return fmt.Sprintf("__temporal_aggregation_cte AS (%s)", q), args
+}
+
+// expHistogramPointsSelect selects the points of the metric in [start, end) joined with
+// the filtered time series; temporalityCond, when set, restricts their temporality.
+func expHistogramPointsSelect(
+	sb *sqlbuilder.SelectBuilder,
+	start, end uint64,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+	samplesTable string,
+	timeSeriesCTE string,
+	temporalityCond string,
+) {
+	sb.From(fmt.Sprintf("%s.%s AS points", metricstelemetryschema.DBName, samplesTable))
+	sb.JoinWithOption(sqlbuilder.InnerJoin, timeSeriesCTE, "points.fingerprint = filtered_time_series.fingerprint")
+	sb.Where(
+		sb.In("metric_name", query.Aggregations[0].MetricName),
+		sb.GTE("unix_milli", start),
+		sb.LT("unix_milli", end),
+	)
+	if temporalityCond != "" {
+		sb.Where(temporalityCond)
+	}
+}
+
+func (b *StatementBuilder) buildExpHistogramDelta(
+	start, end uint64,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+	samplesTable string,
+	timeSeriesCTE string,
+	timeSeriesCTEArgs []any,
+	temporalityCond string,
+) (string, []any) {
+	stepSec := int64(query.StepInterval.Seconds())
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(fmt.Sprintf("toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(%d)) AS ts", stepSec))
+	sb.SelectMore(GroupByAliases(query.GroupBy)...)
+	sb.SelectMore(
+		fmt.Sprintf("%s AS per_series_sketch", metricstelemetryschema.ExpHistogramSketchColumn),
+		"count AS per_series_count",
+	)
+	expHistogramPointsSelect(sb, start, end, query, samplesTable, timeSeriesCTE, temporalityCond)
+
+	return sb.BuildWithFlavor(sqlbuilder.ClickHouse, timeSeriesCTEArgs...)
+}
+
+func (b *StatementBuilder) buildExpHistogramCumulative(
+	start, end uint64,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+	samplesTable string,
+	timeSeriesCTE string,
+	timeSeriesCTEArgs []any,
+	temporalityCond string,
+) (string, []any) {
+	stepSec := int64(query.StepInterval.Seconds())
+	groups := GroupByAliases(query.GroupBy)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(fmt.Sprintf("toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(%d)) AS ts", stepSec))
+	sb.SelectMore(groups...)
+	sb.SelectMore(
+		fmt.Sprintf("argMax(%s, unix_milli) AS per_series_sketch", metricstelemetryschema.ExpHistogramSketchColumn),
+		"argMax(count, unix_milli) AS per_series_count",
+	)
+	expHistogramPointsSelect(sb, start, end, query, samplesTable, timeSeriesCTE, temporalityCond)
+	sb.GroupBy("fingerprint", "ts")
+	sb.GroupBy(groups...)
+
+	return sb.BuildWithFlavor(sqlbuilder.ClickHouse, timeSeriesCTEArgs...)
+}
+
+// buildExpHistogramSpatialAggregationCTE merges the sketches of the series of each step and
+// group and reads the quantiles at the given ranks off the merged sketch, next to the count
+// of values it holds.
+func (b *StatementBuilder) buildExpHistogramSpatialAggregationCTE(
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+	ranks []float64,
+) (string, []any) {
+	groups := GroupByAliases(query.GroupBy)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("ts")
+	sb.SelectMore(groups...)
+	sb.SelectMore(
+		fmt.Sprintf("%s AS quantiles", metricstelemetryschema.ExpHistogramQuantilesExpr("per_series_sketch", ranks)),
+		"sum(per_series_count) AS total_count",
+	)
+	sb.From("__temporal_aggregation_cte")
+	sb.GroupBy("ts")
+	sb.GroupBy(groups...)
+
+	q, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return fmt.Sprintf("__spatial_aggregation_cte AS (%s)", q), args
+}
+
+// buildExpHistogramPercentileSelect returns the percentile of each step and group, the only
+// quantile the spatial aggregation read.
+func (b *StatementBuilder) buildExpHistogramPercentileSelect(
+	cteFragments []string,
+	cteArgs [][]any,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+) (*qbtypes.Statement, error) {
+	var args []any
+	for _, a := range cteArgs {
+		args = append(args, a...)
+	}
+
+	groups := GroupByAliases(query.GroupBy)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("ts")
+	sb.SelectMore(groups...)
+	sb.SelectMore("quantiles[1] AS value")
+	sb.From("__spatial_aggregation_cte")
+	sb.Where("total_count > 0")
+	if query.Having != nil && query.Having.Expression != "" {
+		rewriter := querybuilder.NewHavingExpressionRewriter()
+		rewrittenExpr, err := rewriter.RewriteForMetrics(query.Having.Expression, query.Aggregations)
+		if err != nil {
+			return nil, err
+		}
+		sb.Where(rewrittenExpr)
+	}
+	sb.OrderBy(groups...)
+	sb.OrderBy("ts")
+
+	q, a := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: querybuilder.CombineCTEs(cteFragments) + q, Args: append(args, a...)}, nil
+}
+
+// buildExpHistogramDistributionSelect turns the quantiles of each step and group, read at
+// expHistogramDistributionRanks, into the buckets between every two adjacent quantiles,
+// one row per bucket. Each bucket holds the same share of the count of values.
+func (b *StatementBuilder) buildExpHistogramDistributionSelect(
+	cteFragments []string,
+	cteArgs [][]any,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+) *qbtypes.Statement {
+	var args []any
+	for _, a := range cteArgs {
+		args = append(args, a...)
+	}
+
+	groups := GroupByAliases(query.GroupBy)
+
+	buckets := fmt.Sprintf("arrayMap(i -> (quantiles[i], quantiles[i + 1], total_count / %d), range(1, %d))",
+		expHistogramDistributionBuckets, expHistogramDistributionBuckets+1)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("ts")
+	sb.SelectMore(groups...)
+	sb.SelectMore(
+		fmt.Sprintf("bucket.1 AS %s", qbtypes.DistributionBucketLowerColumn),
+		fmt.Sprintf("bucket.2 AS %s", qbtypes.DistributionBucketUpperColumn),
+		"bucket.3 AS __result_0",
+	)
+	sb.From(fmt.Sprintf("__spatial_aggregation_cte ARRAY JOIN %s AS bucket", buckets))
+	sb.Where("total_count > 0")
+	sb.OrderBy(groups...)
+	sb.OrderBy("ts", qbtypes.DistributionBucketLowerColumn)
+
+	q, a := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: querybuilder.CombineCTEs(cteFragments) + q, Args: append(args, a...)}
+}
@@ -0,0 +1,123 @@
+package metricsstatementbuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/metricstelemetryschema"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExpHistogramTestBuilder(t *testing.T, native bool) *StatementBuilder {
+	fm := metricstelemetryschema.NewFieldMapper()
+	cb := metricstelemetryschema.NewConditionBuilder(fm)
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	keys, err := telemetrytypestest.LoadFieldKeysFromJSON("testdata/keys_map.json")
+	require.NoError(t, err)
+	mockMetadataStore.KeysMap = keys
+
+	fl := flaggertest.WithBooleanFlags(t, map[string]bool{flagger.FeatureUseNativeExpHistograms.String(): native})
+	return NewMetricQueryStatementBuilder(instrumentationtest.New().ToProviderSettings(), mockMetadataStore, fm, cb, fl)
+}
+
+func expHistogramQuery(temporality metrictypes.Temporality, spaceAggregation metrictypes.SpaceAggregation, groupBy ...string) qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation] {
+	query := qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+		Signal:       telemetrytypes.SignalMetrics,
+		StepInterval: qbtypes.Step{Duration: 60 * time.Second},
+		Aggregations: []qbtypes.MetricAggregation{
+			{
+				MetricName:       "http.server.duration",
+				Type:             metrictypes.ExpHistogramType,
+				Temporality:      temporality,
+				TimeAggregation:  metrictypes.TimeAggregationRate,
+				SpaceAggregation: spaceAggregation,
+			},
+		},
+	}
+	for _, name := range groupBy {
+		query.GroupBy = append(query.GroupBy, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: name}})
+	}
+	return query
+}
+
+func TestStatementBuilderNativeExpHistogram(t *testing.T) {
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]
+		expected    qbtypes.Statement
+	}{
+		{
+			name:        "cumulative_p90_by_service",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query:       expHistogramQuery(metrictypes.Cumulative, metrictypes.SpaceAggregationPercentile90, "service.name"),
+			expected: qbtypes.Statement{
+				Query: "WITH __temporal_aggregation_cte AS (SELECT toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(60)) AS ts, `__GROUP_BY_KEY_0_service.name`, argMax(sketch, unix_milli) AS per_series_sketch, argMax(count, unix_milli) AS per_series_count FROM signoz_metrics.distributed_exp_hist AS points INNER JOIN (SELECT fingerprint, JSONExtractString(labels, 'service.name') AS `__GROUP_BY_KEY_0_service.name` FROM signoz_metrics.time_series_v4_6hrs WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli <= ? AND LOWER(temporality) LIKE LOWER(?) GROUP BY fingerprint, `__GROUP_BY_KEY_0_service.name`) AS filtered_time_series ON points.fingerprint = filtered_time_series.fingerprint WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli < ? GROUP BY fingerprint, ts, `__GROUP_BY_KEY_0_service.name`), __spatial_aggregation_cte AS (SELECT ts, `__GROUP_BY_KEY_0_service.name`, quantilesDDMerge(0.01, 0.900000)(per_series_sketch) AS quantiles, sum(per_series_count) AS total_count FROM __temporal_aggregation_cte GROUP BY ts, `__GROUP_BY_KEY_0_service.name`) SELECT ts, `__GROUP_BY_KEY_0_service.name`, quantiles[1] AS value FROM __spatial_aggregation_cte WHERE total_count > 0 ORDER BY `__GROUP_BY_KEY_0_service.name`, ts",
+				Args:  []any{"http.server.duration", uint64(1747936800000), uint64(1747983420000), "cumulative", "http.server.duration", uint64(1747947300000), uint64(1747983420000)},
+			},
+		},
+		{
+			name:        "delta_p99",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query:       expHistogramQuery(metrictypes.Delta, metrictypes.SpaceAggregationPercentile99),
+			expected: qbtypes.Statement{
+				Query: "WITH __temporal_aggregation_cte AS (SELECT toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(60)) AS ts, sketch AS per_series_sketch, count AS per_series_count FROM signoz_metrics.distributed_exp_hist AS points INNER JOIN (SELECT fingerprint FROM signoz_metrics.time_series_v4_6hrs WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli <= ? AND LOWER(temporality) LIKE LOWER(?) GROUP BY fingerprint) AS filtered_time_series ON points.fingerprint = filtered_time_series.fingerprint WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli < ?), __spatial_aggregation_cte AS (SELECT ts, quantilesDDMerge(0.01, 0.990000)(per_series_sketch) AS quantiles, sum(per_series_count) AS total_count FROM __temporal_aggregation_cte GROUP BY ts) SELECT ts, quantiles[1] AS value FROM __spatial_aggregation_cte WHERE total_count > 0 ORDER BY ts",
+				Args:  []any{"http.server.duration", uint64(1747936800000), uint64(1747983420000), "delta", "http.server.duration", uint64(1747947360000), uint64(1747983420000)},
+			},
+		},
+		{
+			name:        "multiple_temporalities_p50",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query:       expHistogramQuery(metrictypes.Multiple, metrictypes.SpaceAggregationPercentile50),
+			expected: qbtypes.Statement{
+				Query: "WITH __temporal_aggregation_cte AS (SELECT * FROM (SELECT toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(60)) AS ts, sketch AS per_series_sketch, count AS per_series_count FROM signoz_metrics.distributed_exp_hist AS points INNER JOIN (SELECT fingerprint FROM signoz_metrics.time_series_v4_6hrs WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli <= ? GROUP BY fingerprint) AS filtered_time_series ON points.fingerprint = filtered_time_series.fingerprint WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli < ? AND LOWER(temporality) LIKE LOWER('delta')) UNION ALL SELECT * FROM (SELECT toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(60)) AS ts, argMax(sketch, unix_milli) AS per_series_sketch, argMax(count, unix_milli) AS per_series_count FROM signoz_metrics.distributed_exp_hist AS points INNER JOIN (SELECT fingerprint FROM signoz_metrics.time_series_v4_6hrs WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli <= ? GROUP BY fingerprint) AS filtered_time_series ON points.fingerprint = filtered_time_series.fingerprint WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli < ? AND LOWER(temporality) NOT LIKE LOWER('delta') GROUP BY fingerprint, ts)), __spatial_aggregation_cte AS (SELECT ts, quantilesDDMerge(0.01, 0.500000)(per_series_sketch) AS quantiles, sum(per_series_count) AS total_count FROM __temporal_aggregation_cte GROUP BY ts) SELECT ts, quantiles[1] AS value FROM __spatial_aggregation_cte WHERE total_count > 0 ORDER BY ts",
+				Args:  []any{"http.server.duration", uint64(1747936800000), uint64(1747983420000), "http.server.duration", uint64(1747947300000), uint64(1747983420000), "http.server.duration", uint64(1747936800000), uint64(1747983420000), "http.server.duration", uint64(1747947300000), uint64(1747983420000)},
+			},
+		},
+		{
+			name:        "delta_distribution",
+			requestType: qbtypes.RequestTypeDistribution,
+			query:       expHistogramQuery(metrictypes.Delta, metrictypes.SpaceAggregationSum),
+			expected: qbtypes.Statement{
+				Query: "WITH __temporal_aggregation_cte AS (SELECT toStartOfInterval(toDateTime(intDiv(unix_milli, 1000)), toIntervalSecond(60)) AS ts, sketch AS per_series_sketch, count AS per_series_count FROM signoz_metrics.distributed_exp_hist AS points INNER JOIN (SELECT fingerprint FROM signoz_metrics.time_series_v4_6hrs WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli <= ? AND LOWER(temporality) LIKE LOWER(?) GROUP BY fingerprint) AS filtered_time_series ON points.fingerprint = filtered_time_series.fingerprint WHERE metric_name IN (?) AND unix_milli >= ? AND unix_milli < ?), __spatial_aggregation_cte AS (SELECT ts, quantilesDDMerge(0.01, 0.000000, 0.050000, 0.100000, 0.150000, 0.200000, 0.250000, 0.300000, 0.350000, 0.400000, 0.450000, 0.500000, 0.550000, 0.600000, 0.650000, 0.700000, 0.750000, 0.800000, 0.850000, 0.900000, 0.950000, 1.000000)(per_series_sketch) AS quantiles, sum(per_series_count) AS total_count FROM __temporal_aggregation_cte GROUP BY ts) SELECT ts, bucket.1 AS __bucket_lower, bucket.2 AS __bucket_upper, bucket.3 AS __result_0 FROM __spatial_aggregation_cte ARRAY JOIN arrayMap(i -> (quantiles[i], quantiles[i + 1], total_count / 20), range(1, 21)) AS bucket WHERE total_count > 0 ORDER BY ts, __bucket_lower",
+				Args:  []any{"http.server.duration", uint64(1747936800000), uint64(1747983420000), "delta", "http.server.duration", uint64(1747947360000), uint64(1747983420000)},
+			},
+		},
+	}
+
+	statementBuilder := newExpHistogramTestBuilder(t, true)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(context.Background(), valuer.UUID{}, 1747947419000, 1747983448000, c.requestType, c.query, nil)
+			require.NoError(t, err)
+			require.Equal(t, c.expected.Query, q.Query)
+			require.Equal(t, c.expected.Args, q.Args)
+		})
+	}
+}
+
+func TestStatementBuilderExpHistogramFeatureDisabled(t *testing.T) {
+	statementBuilder := newExpHistogramTestBuilder(t, false)
+
+	q, err := statementBuilder.Build(context.Background(), valuer.UUID{}, 1747947419000, 1747983448000, qbtypes.RequestTypeTimeSeries,
+		expHistogramQuery(metrictypes.Delta, metrictypes.SpaceAggregationPercentile99), nil)
+	require.NoError(t, err)
+	assert.Contains(t, q.Query, "quantilesDDMerge(0.01, 0.990000)(sketch)[1]")
+	assert.NotContains(t, q.Query, "__temporal_aggregation_cte")
+
+	// distributions are always read from the sketches
+	q, err = statementBuilder.Build(context.Background(), valuer.UUID{}, 1747947419000, 1747983448000, qbtypes.RequestTypeDistribution,
+		expHistogramQuery(metrictypes.Delta, metrictypes.SpaceAggregationSum), nil)
+	require.NoError(t, err)
+	assert.Contains(t, q.Query, "quantilesDDMerge(0.01, 0.000000, 0.050000, ")
+}
@@ -7,11 +7,13 @@ import (
 	"slices"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/flagger"
 	"github.com/SigNoz/signoz/pkg/querybuilder"
 	"github.com/SigNoz/signoz/pkg/statementbuilder"
 	"github.com/SigNoz/signoz/pkg/telemetryschema/metricstelemetryschema"
+	"github.com/SigNoz/signoz/pkg/types/featuretypes"
 	"github.com/SigNoz/signoz/pkg/types/metrictypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
@@ -113,10 +115,23 @@ func (b *StatementBuilder) Build(
 	orgID valuer.UUID,
 	start uint64,
 	end uint64,
-	_ qbtypes.RequestType,
+	requestType qbtypes.RequestType,
 	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
 	variables map[string]qbtypes.VariableItem,
 ) (*qbtypes.Statement, error) {
+	nativeExpHistograms := len(query.Aggregations) > 0 &&
+		query.Aggregations[0].Type == metrictypes.ExpHistogramType &&
+		b.flagger.BooleanOrEmpty(ctx, flagger.FeatureUseNativeExpHistograms, featuretypes.NewFlaggerEvaluationContext(orgID))
+
+	finalSelect := b.BuildFinalSelect
+	if requestType == qbtypes.RequestTypeDistribution {
+		var err error
+		if query, err = distributionQuery(query); err != nil {
+			return nil, err
+		}
+		finalSelect = b.buildDistributionSelect
+	}
+
 	keySelectors := GetKeySelectors(query)
 	keys, _, err := b.metadataStore.GetKeysMulti(ctx, orgID, keySelectors)
 	if err != nil {
@@ -125,7 +140,13 @@ func (b *StatementBuilder) Build(
 
 	start, end = querybuilder.AdjustedMetricTimeRange(start, end, uint64(query.StepInterval.Seconds()), query)
 
-	return b.buildPipelineStatement(ctx, orgID, start, end, query, keys, variables)
+	// every install has the sketches a distribution is read from, the feature only changes
+	// how percentiles are merged
+	if (nativeExpHistograms || requestType == qbtypes.RequestTypeDistribution) && isNativeExpHistogramQuery(requestType, query) {
+		return b.buildExpHistogramStatement(ctx, orgID, start, end, requestType, query, keys, variables)
+	}
+
+	return b.buildPipelineStatement(ctx, orgID, start, end, query, keys, variables, finalSelect)
 }
 
 func (b *StatementBuilder) buildPipelineStatement(
@@ -135,6 +156,7 @@ func (b *StatementBuilder) buildPipelineStatement(
 	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
 	keys map[string][]*telemetrytypes.TelemetryFieldKey,
 	variables map[string]qbtypes.VariableItem,
+	finalSelect func([]string, [][]any, qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]) (*qbtypes.Statement, error),
 ) (*qbtypes.Statement, error) {
 	var (
 		cteFragments []string
@@ -216,7 +238,7 @@ func (b *StatementBuilder) buildPipelineStatement(
 		}
 	}
 
-	mainStmt, err := b.BuildFinalSelect(cteFragments, cteArgs, query)
+	mainStmt, err := finalSelect(cteFragments, cteArgs, query)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +246,7 @@ func (b *StatementBuilder) buildPipelineStatement(
 	if reducedFragments == nil {
 		return mainStmt, nil
 	}
-	reducedStmt, err := b.BuildFinalSelect(reducedFragments, reducedArgs, query)
+	reducedStmt, err := finalSelect(reducedFragments, reducedArgs, query)
 	if err != nil {
 		return nil, err
 	}
@@ -428,20 +450,22 @@ func (b *StatementBuilder) buildTemporalAggDeltaFastPath(
 		sb.SelectMore(fmt.Sprintf("`%s`", GroupByColumnAlias(i, g.Name)))
 	}
 
-	aggCol, err := metricstelemetryschema.AggregationColumnForSamplesTable(
-		samplesTable, query.Aggregations[0].Temporality, query.Aggregations[0].TimeAggregation,
-	)
-	if err != nil {
-		return "", nil, err
-	}
-	if query.Aggregations[0].TimeAggregation == metrictypes.TimeAggregationRate {
-		// TODO(srikanthccv): should it be step interval or use [start_time_unix_nano](https://github.com/open-telemetry/opentelemetry-proto/blob/d3fb76d70deb0874692bd0ebe03148580d85f3bb/opentelemetry/proto/metrics/v1/metrics.proto#L400C11-L400C31)?
-		aggCol = fmt.Sprintf("%s/%d", aggCol, stepSec)
-	}
-
+	var aggCol string
 	if query.Aggregations[0].SpaceAggregation.IsPercentile() &&
 		query.Aggregations[0].Type == metrictypes.ExpHistogramType {
-		aggCol = fmt.Sprintf("quantilesDDMerge(0.01, %f)(sketch)[1]", query.Aggregations[0].SpaceAggregation.Percentile())
+		aggCol = metricstelemetryschema.ExpHistogramQuantilesExpr(metricstelemetryschema.ExpHistogramSketchColumn, []float64{query.Aggregations[0].SpaceAggregation.Percentile()}) + "[1]"
+	} else {
+		var err error
+		aggCol, err = metricstelemetryschema.AggregationColumnForSamplesTable(
+			samplesTable, query.Aggregations[0].Temporality, query.Aggregations[0].TimeAggregation,
+		)
+		if err != nil {
+			return "", nil, err
+		}
+		if query.Aggregations[0].TimeAggregation == metrictypes.TimeAggregationRate {
+			// TODO(srikanthccv): should it be step interval or use [start_time_unix_nano](https://github.com/open-telemetry/opentelemetry-proto/blob/d3fb76d70deb0874692bd0ebe03148580d85f3bb/opentelemetry/proto/metrics/v1/metrics.proto#L400C11-L400C31)?
+			aggCol = fmt.Sprintf("%s/%d", aggCol, stepSec)
+		}
 	}
 
 	sb.SelectMore(fmt.Sprintf("%s AS value", aggCol))
@@ -838,6 +862,71 @@ func (b *StatementBuilder) BuildFinalSelect(
 	return &qbtypes.Statement{Query: combined + q, Args: append(args, a...)}, nil
 }
 
+// distributionQuery returns the query for the distribution of a histogram metric: the
+// count space aggregation keeps the cumulative counts of every bucket bound. Exponential
+// histograms are read from their sketches instead, see buildExpHistogramDistributionSelect.
+func distributionQuery(query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]) (qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation], error) {
+	if len(query.Aggregations) != 1 {
+		return query, errors.NewInvalidInputf(errors.CodeInvalidInput, "distribution queries must have exactly one aggregation, got %d", len(query.Aggregations))
+	}
+	switch query.Aggregations[0].Type {
+	case metrictypes.HistogramType, metrictypes.ExpHistogramType:
+	default:
+		return query, errors.NewInvalidInputf(errors.CodeInvalidInput, "distribution requires a histogram metric, %q is a %s metric", query.Aggregations[0].MetricName, query.Aggregations[0].Type.StringValue())
+	}
+
+	query.Aggregations = slices.Clone(query.Aggregations)
+	query.Aggregations[0].SpaceAggregation = metrictypes.SpaceAggregationCount
+	query.Aggregations[0].ComparisonSpaceAggregationParam = nil
+	return query, nil
+}
+
+// buildDistributionSelect turns the cumulative counts of the bucket bounds of each step
+// and group into the count of every bucket, one row per bucket.
+func (b *StatementBuilder) buildDistributionSelect(
+	cteFragments []string,
+	cteArgs [][]any,
+	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
+) (*qbtypes.Statement, error) {
+	var args []any
+	for _, a := range cteArgs {
+		args = append(args, a...)
+	}
+
+	groups := GroupByAliases(query.GroupBy)
+
+	cumulative := sqlbuilder.NewSelectBuilder()
+	cumulative.Select("ts")
+	cumulative.SelectMore(groups...)
+	cumulative.SelectMore("arraySort(x -> x.1, groupArray((toFloat64(le), value))) AS __cumulative")
+	cumulative.From("__spatial_aggregation_cte")
+	cumulative.GroupBy(groups...)
+	cumulative.GroupBy("ts")
+	cumulativeQuery, _ := cumulative.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	// the first bucket starts at zero, unless the histogram has negative bounds
+	buckets := "arrayMap(i -> (" +
+		"if(i = 1, if(__cumulative[1].1 < 0, -inf, 0), __cumulative[i - 1].1), " +
+		"__cumulative[i].1, " +
+		"greatest(__cumulative[i].2 - if(i = 1, 0, __cumulative[i - 1].2), 0)" +
+		"), arrayEnumerate(__cumulative))"
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("ts")
+	sb.SelectMore(groups...)
+	sb.SelectMore(
+		fmt.Sprintf("bucket.1 AS %s", qbtypes.DistributionBucketLowerColumn),
+		fmt.Sprintf("bucket.2 AS %s", qbtypes.DistributionBucketUpperColumn),
+		"bucket.3 AS __result_0",
+	)
+	sb.From(fmt.Sprintf("(%s) ARRAY JOIN %s AS bucket", cumulativeQuery, buckets))
+	sb.OrderBy(groups...)
+	sb.OrderBy("ts")
+
+	q, a := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: querybuilder.CombineCTEs(cteFragments) + q, Args: append(args, a...)}, nil
+}
+
 const histogramBucketKey = "le"
 
 func isHistogramBucket(k qbtypes.GroupByKey) bool { return k.Name == histogramBucketKey }
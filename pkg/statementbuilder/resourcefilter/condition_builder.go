@@ -254,6 +254,13 @@ func (b *defaultConditionBuilder) conditionForLogicalField(
 		return sb.And(sb.NotBetween(fieldName, querybuilder.FormatValueForContains(values[0]), querybuilder.FormatValueForContains(values[1]))), nil
 
 	case qbtypes.FilterOperatorIn:
+		if subQuery, ok := value.(*qbtypes.SubQuery); ok {
+			cond, err := subQuery.Condition(fieldName, telemetrytypes.FieldDataTypeString, op)
+			if err != nil {
+				return "", err
+			}
+			return sb.And(cond, keyIdxFilter), nil
+		}
 		values, ok := value.([]any)
 		if !ok {
 			return "", qbtypes.ErrInValues
@@ -271,6 +278,9 @@ func (b *defaultConditionBuilder) conditionForLogicalField(
 
 		return mainCondition, nil
 	case qbtypes.FilterOperatorNotIn:
+		if subQuery, ok := value.(*qbtypes.SubQuery); ok {
+			return subQuery.Condition(fieldName, telemetrytypes.FieldDataTypeString, op)
+		}
 		values, ok := value.([]any)
 		if !ok {
 			return "", qbtypes.ErrInValues
@@ -0,0 +1,253 @@
+package rollupstatementbuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// rollupFunctions maps the aggregation functions rollups support to the ClickHouse
+// function whose -State and -Merge combinators pre-aggregate them. Rates depend on the
+// window of the query, and the quantiles of merged states are not the ones of the rows.
+var rollupFunctions = map[string]string{
+	"count":           "count",
+	"countif":         "countIf",
+	"countdistinct":   "uniqExact",
+	"countdistinctif": "uniqExactIf",
+	"sum":             "sum",
+	"sumif":           "sumIf",
+	"avg":             "avg",
+	"avgif":           "avgIf",
+	"min":             "min",
+	"minif":           "minIf",
+	"max":             "max",
+	"maxif":           "maxIf",
+}
+
+// BuildCreate builds the statements creating the table of the rollup from the definition
+// of its materialized view, the distributed table over it and the view, in this order. The
+// group by keys are nullable: they are the toString of the same expressions as in the raw
+// queries, which are NULL for absent keys, and toString keeps NULL. The view only sees the
+// rows inserted after it is created, nothing is backfilled.
+func (b *statementBuilder[T]) BuildCreate(ctx context.Context, orgID valuer.UUID, cluster string, rollup *rolluptypes.Rollup) ([]string, error) {
+	definition, err := b.buildDefinition(ctx, orgID, rollup)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy := []string{"bucket_start"}
+	for i := range rollup.GroupBy {
+		orderBy = append(orderBy, groupByColumn(i))
+	}
+
+	localTable := fmt.Sprintf("%s.%s", b.schema.dbName, rollup.LocalTableName())
+	return []string{
+		fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s ON CLUSTER %s ENGINE = AggregatingMergeTree ORDER BY (%s) SETTINGS allow_nullable_key = 1 EMPTY AS %s",
+			localTable, cluster, strings.Join(orderBy, ", "), definition,
+		),
+		fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s.%s ON CLUSTER %s AS %s ENGINE = Distributed('%s', '%s', '%s')",
+			b.schema.dbName, rollup.TableName(), cluster, localTable, cluster, b.schema.dbName, rollup.LocalTableName(),
+		),
+		fmt.Sprintf(
+			"CREATE MATERIALIZED VIEW IF NOT EXISTS %s.%s ON CLUSTER %s TO %s AS %s",
+			b.schema.dbName, rollup.ViewName(), cluster, localTable, definition,
+		),
+	}, nil
+}
+
+// BuildDrop builds the statements dropping the view of the rollup first, for no rows to be
+// inserted into a dropped table.
+func (b *statementBuilder[T]) BuildDrop(cluster string, rollup *rolluptypes.Rollup) []string {
+	return []string{
+		fmt.Sprintf("DROP VIEW IF EXISTS %s.%s ON CLUSTER %s", b.schema.dbName, rollup.ViewName(), cluster),
+		fmt.Sprintf("DROP TABLE IF EXISTS %s.%s ON CLUSTER %s", b.schema.dbName, rollup.TableName(), cluster),
+		fmt.Sprintf("DROP TABLE IF EXISTS %s.%s ON CLUSTER %s", b.schema.dbName, rollup.LocalTableName(), cluster),
+	}
+}
+
+// buildDefinition builds the SELECT aggregating the rows of the local table of the signal
+// matching the filter of the rollup into the states of its aggregations, by interval and
+// group by keys. The statement has no placeholders, as views cannot be parameterized.
+func (b *statementBuilder[T]) buildDefinition(ctx context.Context, orgID valuer.UUID, rollup *rolluptypes.Rollup) (string, error) {
+	if err := rollup.Shape().Validate(); err != nil {
+		return "", err
+	}
+
+	// The keys of the rows inserted from now on, for the columns of evolved keys.
+	end := uint64(time.Now().UnixNano())
+	start := end - uint64(time.Hour.Nanoseconds())
+
+	keys, err := b.keys(ctx, orgID, rollup)
+	if err != nil {
+		return "", err
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(fmt.Sprintf("%s AS bucket_start", fmt.Sprintf(b.schema.bucket, rollup.IntervalSeconds)))
+
+	groupBy := []string{"bucket_start"}
+	for i, text := range rollup.GroupBy {
+		key := telemetrytypes.GetFieldKeyFromKeyText(text)
+		if intrinsic, ok := b.schema.intrinsicField(key.Name); ok {
+			querybuilder.AdjustKey(&key, keys, &intrinsic)
+		} else {
+			querybuilder.AdjustKey(&key, keys, nil)
+		}
+
+		expr, err := b.fm.ColumnExpressionFor(ctx, orgID, start, end, &key, telemetrytypes.FieldDataTypeString, keys)
+		if err != nil {
+			return "", err
+		}
+		// As the raw queries do, for absent keys to be NULL in both rather than ''.
+		sb.SelectMore(fmt.Sprintf("toString(%s) AS %s", sqlbuilder.Escape(expr), groupByColumn(i)))
+		groupBy = append(groupBy, groupByColumn(i))
+	}
+
+	aggArgs := []any{}
+	for i, expression := range rollup.Aggregations {
+		fn, err := rollupFunction(expression)
+		if err != nil {
+			return "", err
+		}
+		rewritten, chArgs, err := b.aggExprRewriter.Rewrite(ctx, orgID, start, end, expression, 0, keys)
+		if err != nil {
+			return "", err
+		}
+		state, err := stateExpression(fn, rewritten)
+		if err != nil {
+			return "", err
+		}
+		aggArgs = append(aggArgs, chArgs...)
+		sb.SelectMore(fmt.Sprintf("%s AS %s", state, aggregationColumn(i)))
+	}
+
+	sb.From(fmt.Sprintf("%s.%s", b.schema.dbName, b.schema.localTableName))
+
+	if rollup.Filter != "" {
+		preparedWhereClause, err := querybuilder.PrepareWhereClause(rollup.Filter, querybuilder.FilterExprVisitorOpts{
+			Context:          ctx,
+			OrgID:            orgID,
+			Flagger:          b.fl,
+			Logger:           b.logger,
+			FieldMapper:      b.fm,
+			ConditionBuilder: b.cb,
+			FieldKeys:        keys,
+			FullTextColumn:   b.schema.fullTextColumn,
+			StartNs:          start,
+			EndNs:            end,
+		})
+		if err != nil {
+			return "", err
+		}
+		if !preparedWhereClause.IsEmpty() {
+			sb.AddWhereClause(preparedWhereClause.WhereClause)
+		}
+	}
+
+	sb.GroupBy(groupBy...)
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse, aggArgs...)
+	definition, err := sqlbuilder.ClickHouse.Interpolate(query, args)
+	if err != nil {
+		return "", errors.WrapInternalf(err, errors.CodeInternal, "failed to interpolate the definition of rollup %s", rollup.ID)
+	}
+	return definition, nil
+}
+
+// keys returns the keys of the filter, group by keys and aggregations of the rollup.
+func (b *statementBuilder[T]) keys(ctx context.Context, orgID valuer.UUID, rollup *rolluptypes.Rollup) (map[string][]*telemetrytypes.TelemetryFieldKey, error) {
+	var selectors []*telemetrytypes.FieldKeySelector
+	for _, expression := range rollup.Aggregations {
+		selectors = append(selectors, querybuilder.QueryStringToKeysSelectors(expression)...)
+	}
+	if rollup.Filter != "" {
+		selectors = append(selectors, querybuilder.QueryStringToKeysSelectors(rollup.Filter)...)
+	}
+	for _, text := range rollup.GroupBy {
+		key := telemetrytypes.GetFieldKeyFromKeyText(text)
+		selectors = append(selectors, &telemetrytypes.FieldKeySelector{
+			Name:          key.Name,
+			FieldContext:  key.FieldContext,
+			FieldDataType: key.FieldDataType,
+		})
+	}
+	for _, selector := range selectors {
+		selector.Signal = b.schema.signal
+		selector.SelectorMatchType = telemetrytypes.FieldSelectorMatchTypeExact
+	}
+
+	keys, _, err := b.metadataStore.GetKeysMulti(ctx, orgID, querybuilder.ExpandKeySelectorsForFamilies(ctx, orgID, b.fl, selectors))
+	if err != nil {
+		return nil, err
+	}
+	b.schema.prepareKeys(keys)
+	return keys, nil
+}
+
+// rollupFunction returns the ClickHouse function pre-aggregating the aggregation, which must
+// be a single call of a function rollups support.
+func rollupFunction(expression string) (string, error) {
+	open := strings.IndexByte(expression, '(')
+	if open <= 0 || !closesAtEnd(expression, open) {
+		return "", errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "aggregation %q must be a single aggregation function call", expression)
+	}
+
+	aggFunc, ok := querybuilder.AggreFuncMap[valuer.NewString(strings.ToLower(strings.TrimSpace(expression[:open])))]
+	if !ok {
+		return "", errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "unknown aggregation function in %q", expression)
+	}
+
+	fn, ok := rollupFunctions[aggFunc.Name.StringValue()]
+	if !ok || aggFunc.Rate {
+		return "", errors.Newf(errors.TypeInvalidInput, rolluptypes.ErrCodeRollupInvalidInput, "aggregation %q cannot be rolled up, only count, countIf, countDistinct, countDistinctIf, sum, sumIf, avg, avgIf, min, minIf, max and maxIf can", expression)
+	}
+	return fn, nil
+}
+
+// stateExpression turns the rewritten aggregation, a call of the aggregation function, into
+// the call of the -State combinator of fn with the same arguments.
+func stateExpression(fn string, rewritten string) (string, error) {
+	open := strings.IndexByte(rewritten, '(')
+	if open <= 0 || !closesAtEnd(rewritten, open) {
+		return "", errors.NewInternalf(errors.CodeInternal, "unexpected rewritten aggregation %q", rewritten)
+	}
+	return fn + "State" + rewritten[open:], nil
+}
+
+// closesAtEnd reports whether the parenthesis opened at open is closed by the last byte of
+// the expression, skipping the ones in quoted strings.
+func closesAtEnd(expression string, open int) bool {
+	depth := 0
+	var quote byte
+	for i := open; i < len(expression); i++ {
+		c := expression[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i == len(expression)-1
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,410 @@
+package rollupstatementbuilder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/flagger"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/logstelemetryschema"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/tracestelemetryschema"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// schema is where the rows of a signal are, and how its keys resolve.
+type schema struct {
+	signal         telemetrytypes.Signal
+	dbName         string
+	localTableName string
+	// bucket truncates the timestamp of a row to the interval in seconds, as a DateTime.
+	bucket         string
+	fullTextColumn *telemetrytypes.TelemetryFieldKey
+	intrinsicField func(name string) (telemetrytypes.TelemetryFieldKey, bool)
+	// prepareKeys adds to the keys of the metadata store the ones the filters of the signal
+	// resolve besides them.
+	prepareKeys func(keys map[string][]*telemetrytypes.TelemetryFieldKey)
+}
+
+// statementBuilder routes the time series and scalar queries an active rollup of the org
+// can answer to the rollup, and builds the others with the statement builder it wraps.
+type statementBuilder[T any] struct {
+	logger          *slog.Logger
+	next            qbtypes.StatementBuilder[T]
+	getter          rolluptypes.Getter
+	metadataStore   telemetrytypes.MetadataStore
+	fm              qbtypes.FieldMapper
+	cb              qbtypes.ConditionBuilder
+	aggExprRewriter qbtypes.AggExprRewriter
+	fl              flagger.Flagger
+	schema          schema
+}
+
+var (
+	_ qbtypes.StatementBuilder[qbtypes.LogAggregation] = (*statementBuilder[qbtypes.LogAggregation])(nil)
+	_ rolluptypes.StatementBuilder                     = (*statementBuilder[qbtypes.LogAggregation])(nil)
+)
+
+// NewLogs wraps the logs statement builder next to route the queries matching a rollup.
+func NewLogs(
+	settings factory.ProviderSettings,
+	next qbtypes.StatementBuilder[qbtypes.LogAggregation],
+	getter rolluptypes.Getter,
+	metadataStore telemetrytypes.MetadataStore,
+	fl flagger.Flagger,
+) *statementBuilder[qbtypes.LogAggregation] {
+	fm := logstelemetryschema.NewFieldMapper(fl)
+	cb := logstelemetryschema.NewConditionBuilder(fm, fl)
+	return &statementBuilder[qbtypes.LogAggregation]{
+		logger:          factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/statementbuilder/rollupstatementbuilder").Logger(),
+		next:            next,
+		getter:          getter,
+		metadataStore:   metadataStore,
+		fm:              fm,
+		cb:              cb,
+		aggExprRewriter: querybuilder.NewAggExprRewriter(settings, logstelemetryschema.DefaultFullTextColumn, fm, cb, fl),
+		fl:              fl,
+		schema: schema{
+			signal:         telemetrytypes.SignalLogs,
+			dbName:         logstelemetryschema.DBName,
+			localTableName: logstelemetryschema.LogsV2LocalTableName,
+			bucket:         "toStartOfInterval(toDateTime(intDiv(timestamp, 1000000000)), INTERVAL %d SECOND)",
+			fullTextColumn: logstelemetryschema.DefaultFullTextColumn,
+			intrinsicField: func(name string) (telemetrytypes.TelemetryFieldKey, bool) {
+				key, ok := logstelemetryschema.IntrinsicFields[name]
+				return key, ok
+			},
+			prepareKeys: func(keys map[string][]*telemetrytypes.TelemetryFieldKey) {},
+		},
+	}
+}
+
+// NewTraces wraps the traces statement builder next to route the queries matching a rollup.
+func NewTraces(
+	settings factory.ProviderSettings,
+	next qbtypes.StatementBuilder[qbtypes.TraceAggregation],
+	getter rolluptypes.Getter,
+	metadataStore telemetrytypes.MetadataStore,
+	fl flagger.Flagger,
+) *statementBuilder[qbtypes.TraceAggregation] {
+	fm := tracestelemetryschema.NewFieldMapper(fl)
+	cb := tracestelemetryschema.NewConditionBuilder(fm, fl)
+	return &statementBuilder[qbtypes.TraceAggregation]{
+		logger:          factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/statementbuilder/rollupstatementbuilder").Logger(),
+		next:            next,
+		getter:          getter,
+		metadataStore:   metadataStore,
+		fm:              fm,
+		cb:              cb,
+		aggExprRewriter: querybuilder.NewAggExprRewriter(settings, nil, fm, cb, fl),
+		fl:              fl,
+		schema: schema{
+			signal:         telemetrytypes.SignalTraces,
+			dbName:         tracestelemetryschema.DBName,
+			localTableName: tracestelemetryschema.SpanIndexV3LocalTableName,
+			bucket:         "toStartOfInterval(toDateTime(timestamp), INTERVAL %d SECOND)",
+			intrinsicField: func(name string) (telemetrytypes.TelemetryFieldKey, bool) {
+				for _, fields := range []map[string]telemetrytypes.TelemetryFieldKey{
+					tracestelemetryschema.IntrinsicFields,
+					tracestelemetryschema.CalculatedFields,
+					tracestelemetryschema.IntrinsicFieldsDeprecated,
+					tracestelemetryschema.CalculatedFieldsDeprecated,
+				} {
+					if key, ok := fields[name]; ok {
+						return key, true
+					}
+				}
+				return telemetrytypes.TelemetryFieldKey{}, false
+			},
+			prepareKeys: func(keys map[string][]*telemetrytypes.TelemetryFieldKey) {
+				for _, fields := range []map[string]telemetrytypes.TelemetryFieldKey{
+					tracestelemetryschema.IntrinsicFieldsDeprecated,
+					tracestelemetryschema.CalculatedFieldsDeprecated,
+				} {
+					for name, key := range fields {
+						keys[name] = append([]*telemetrytypes.TelemetryFieldKey{&key}, keys[name]...)
+					}
+				}
+			},
+		},
+	}
+}
+
+// Build builds the statement reading the rollup the query matches, if any, and the one of
+// the wrapped statement builder otherwise.
+func (b *statementBuilder[T]) Build(
+	ctx context.Context,
+	orgID valuer.UUID,
+	start uint64,
+	end uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.QueryBuilderQuery[T],
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+	if rollup, groupBy, aggregations, ok := b.match(ctx, orgID, start, end, requestType, query); ok {
+		b.logger.DebugContext(ctx, "serving query from rollup", slog.String("rollup.id", rollup.ID.StringValue()), slog.String("query.name", query.Name))
+		startMs, endMs := querybuilder.ToNanoSecs(start)/1e6, querybuilder.ToNanoSecs(end)/1e6
+		return b.buildRollupQuery(rollup, groupBy, aggregations, startMs, endMs, requestType, query)
+	}
+	return b.next.Build(ctx, orgID, start, end, requestType, query, variables)
+}
+
+// match returns the rollup of the org able to answer the query with the fewest rows, and
+// the indexes of the group by keys and aggregations of the query in it.
+func (b *statementBuilder[T]) match(
+	ctx context.Context,
+	orgID valuer.UUID,
+	start, end uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.QueryBuilderQuery[T],
+) (*rolluptypes.Rollup, []int, []int, bool) {
+	var step int64
+	switch requestType {
+	case qbtypes.RequestTypeTimeSeries:
+		step = int64(query.StepInterval.Seconds())
+		if step <= 0 {
+			return nil, nil, nil, false
+		}
+	case qbtypes.RequestTypeScalar:
+	default:
+		return nil, nil, nil, false
+	}
+
+	shape, ok := rolluptypes.ShapeOf(query)
+	if !ok || shape.Signal != b.schema.signal {
+		return nil, nil, nil, false
+	}
+
+	rollups, err := b.getter.ListActive(ctx, orgID, b.schema.signal)
+	if err != nil {
+		b.logger.WarnContext(ctx, "failed to list the rollups, querying the raw rows", slog.Any("error", err))
+		return nil, nil, nil, false
+	}
+
+	startMs, endMs := querybuilder.ToNanoSecs(start)/1e6, querybuilder.ToNanoSecs(end)/1e6
+	var (
+		best                  *rolluptypes.Rollup
+		groupBy, aggregations []int
+	)
+	for _, rollup := range rollups {
+		g, a, ok := rollup.Match(shape, startMs, endMs, step)
+		if !ok {
+			continue
+		}
+		if best == nil || rollup.IntervalSeconds > best.IntervalSeconds {
+			best, groupBy, aggregations = rollup, g, a
+		}
+	}
+	return best, groupBy, aggregations, best != nil
+}
+
+// buildRollupQuery builds the statement merging the states of the rollup into the results
+// of the query, in the columns the wrapped statement builders use.
+func (b *statementBuilder[T]) buildRollupQuery(
+	rollup *rolluptypes.Rollup,
+	groupBy, aggregations []int,
+	startMs, endMs uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.QueryBuilderQuery[T],
+) (*qbtypes.Statement, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	timeSeries := requestType == qbtypes.RequestTypeTimeSeries
+	if timeSeries {
+		sb.SelectMore(fmt.Sprintf("toStartOfInterval(bucket_start, INTERVAL %d SECOND) AS ts", int64(query.StepInterval.Seconds())))
+	}
+
+	fieldNames := make([]string, 0, len(query.GroupBy))
+	for i, gb := range query.GroupBy {
+		alias := groupByColumnAlias(i, gb.Name)
+		sb.SelectMore(fmt.Sprintf("%s AS `%s`", groupByColumn(groupBy[i]), alias))
+		fieldNames = append(fieldNames, fmt.Sprintf("`%s`", alias))
+	}
+
+	for i := range query.Aggregations {
+		idx := aggregations[i]
+		fn, err := rollupFunction(rollup.Aggregations[idx])
+		if err != nil {
+			return nil, err
+		}
+		sb.SelectMore(fmt.Sprintf("%sMerge(%s) AS __result_%d", fn, aggregationColumn(idx), i))
+	}
+
+	sb.From(fmt.Sprintf("%s.%s", b.schema.dbName, rollup.TableName()))
+	sb.Where(
+		fmt.Sprintf("bucket_start >= toDateTime(%d)", startMs/1000),
+		fmt.Sprintf("bucket_start < toDateTime(%d)", endMs/1000),
+	)
+
+	var (
+		cteFragments []string
+		cteArgs      [][]any
+	)
+	if timeSeries && query.Limit > 0 && len(query.GroupBy) > 0 {
+		cteStmt, err := b.buildRollupQuery(rollup, groupBy, aggregations, startMs, endMs, qbtypes.RequestTypeScalar, query)
+		if err != nil {
+			return nil, err
+		}
+		cteFragments = append(cteFragments, fmt.Sprintf("__limit_cte AS (%s)", cteStmt.Query))
+		cteArgs = append(cteArgs, cteStmt.Args)
+
+		tuple := fmt.Sprintf("(%s)", strings.Join(fieldNames, ", "))
+		sb.Where(fmt.Sprintf("%s GLOBAL IN (SELECT %s FROM __limit_cte)", tuple, strings.Join(fieldNames, ", ")))
+	}
+
+	if timeSeries {
+		sb.GroupBy("ts")
+	}
+	sb.GroupBy(fieldNames...)
+
+	if query.Having != nil && query.Having.Expression != "" {
+		having, err := rewriteHaving(query.Having.Expression, query.Aggregations)
+		if err != nil {
+			return nil, err
+		}
+		sb.Having(having)
+	}
+
+	if timeSeries {
+		if len(query.Order) != 0 {
+			for _, orderBy := range query.Order {
+				if _, ok := aggOrderBy(orderBy, query); !ok {
+					sb.OrderBy(fmt.Sprintf("`%s` %s", groupByOrderColumn(orderBy.Key.Name, query.GroupBy), orderBy.Direction.StringValue()))
+				}
+			}
+			sb.OrderBy("ts desc")
+		}
+	} else {
+		for _, orderBy := range query.Order {
+			if idx, ok := aggOrderBy(orderBy, query); ok {
+				sb.OrderBy(fmt.Sprintf("__result_%d %s", idx, orderBy.Direction.StringValue()))
+			} else {
+				sb.OrderBy(fmt.Sprintf("`%s` %s", groupByOrderColumn(orderBy.Key.Name, query.GroupBy), orderBy.Direction.StringValue()))
+			}
+		}
+		if len(query.Order) == 0 {
+			sb.OrderBy("__result_0 DESC")
+		}
+		if query.Limit > 0 {
+			sb.Limit(query.Limit)
+		}
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{
+		Query: querybuilder.CombineCTEs(cteFragments) + mainSQL,
+		Args:  querybuilder.PrependArgs(cteArgs, mainArgs),
+	}, nil
+}
+
+// BuildVerification builds the statements of the time series of every group by key and
+// aggregation of the rollup at its interval, from the rollup and from the raw rows.
+func (b *statementBuilder[T]) BuildVerification(ctx context.Context, orgID valuer.UUID, rollup *rolluptypes.Rollup, startMs, endMs uint64) (*qbtypes.Statement, *qbtypes.Statement, error) {
+	query := qbtypes.QueryBuilderQuery[T]{
+		Name:         "A",
+		Signal:       b.schema.signal,
+		StepInterval: qbtypes.Step{Duration: time.Duration(rollup.IntervalSeconds) * time.Second},
+		Aggregations: make([]T, 0, len(rollup.Aggregations)),
+		GroupBy:      make([]qbtypes.GroupByKey, 0, len(rollup.GroupBy)),
+	}
+	if rollup.Filter != "" {
+		query.Filter = &qbtypes.Filter{Expression: rollup.Filter}
+	}
+
+	groupBy := make([]int, 0, len(rollup.GroupBy))
+	for i, key := range rollup.GroupBy {
+		query.GroupBy = append(query.GroupBy, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.GetFieldKeyFromKeyText(key)})
+		groupBy = append(groupBy, i)
+	}
+
+	aggregations := make([]int, 0, len(rollup.Aggregations))
+	for i, expression := range rollup.Aggregations {
+		query.Aggregations = append(query.Aggregations, newAggregation[T](expression))
+		aggregations = append(aggregations, i)
+	}
+
+	rollupStmt, err := b.buildRollupQuery(rollup, groupBy, aggregations, startMs, endMs, qbtypes.RequestTypeTimeSeries, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawStmt, err := b.next.Build(ctx, orgID, startMs, endMs, qbtypes.RequestTypeTimeSeries, query, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rollupStmt, rawStmt, nil
+}
+
+func groupByColumn(i int) string {
+	return fmt.Sprintf("g_%d", i)
+}
+
+func aggregationColumn(i int) string {
+	return fmt.Sprintf("r_%d", i)
+}
+
+// groupByColumnAlias is the alias the querier strips to recover the name of the key.
+func groupByColumnAlias(i int, name string) string {
+	return fmt.Sprintf("__GROUP_BY_KEY_%d_%s", i, name)
+}
+
+// groupByOrderColumn returns the alias of the group by key to order by, or the key itself.
+func groupByOrderColumn(orderKey string, groupBy []qbtypes.GroupByKey) string {
+	for i := range groupBy {
+		if groupBy[i].Name == orderKey {
+			return groupByColumnAlias(i, groupBy[i].Name)
+		}
+	}
+	return orderKey
+}
+
+func aggOrderBy[T any](k qbtypes.OrderBy, q qbtypes.QueryBuilderQuery[T]) (int, bool) {
+	for i, agg := range q.Aggregations {
+		expression, alias := aggregationOf(agg)
+		if k.Key.Name == alias ||
+			k.Key.Name == expression ||
+			k.Key.Name == fmt.Sprintf("%d", i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func aggregationOf[T any](agg T) (string, string) {
+	switch agg := any(agg).(type) {
+	case qbtypes.LogAggregation:
+		return agg.Expression, agg.Alias
+	case qbtypes.TraceAggregation:
+		return agg.Expression, agg.Alias
+	}
+	return "", ""
+}
+
+func newAggregation[T any](expression string) T {
+	var agg T
+	switch agg := any(&agg).(type) {
+	case *qbtypes.LogAggregation:
+		agg.Expression = expression
+	case *qbtypes.TraceAggregation:
+		agg.Expression = expression
+	}
+	return agg
+}
+
+func rewriteHaving[T any](expression string, aggregations []T) (string, error) {
+	rewriter := querybuilder.NewHavingExpressionRewriter()
+	switch aggregations := any(aggregations).(type) {
+	case []qbtypes.LogAggregation:
+		return rewriter.RewriteForLogs(expression, aggregations)
+	case []qbtypes.TraceAggregation:
+		return rewriter.RewriteForTraces(expression, aggregations)
+	}
+	return expression, nil
+}
@@ -0,0 +1,215 @@
+package rollupstatementbuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/flagger/flaggertest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetryschema/logstelemetryschema"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rolluptypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes/telemetrytypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rawStatement = &qbtypes.Statement{Query: "raw"}
+
+type rawStatementBuilder[T any] struct{}
+
+func (rawStatementBuilder[T]) Build(context.Context, valuer.UUID, uint64, uint64, qbtypes.RequestType, qbtypes.QueryBuilderQuery[T], map[string]qbtypes.VariableItem) (*qbtypes.Statement, error) {
+	return rawStatement, nil
+}
+
+type getter []*rolluptypes.Rollup
+
+func (g getter) ListActive(context.Context, valuer.UUID, telemetrytypes.Signal) ([]*rolluptypes.Rollup, error) {
+	return g, nil
+}
+
+var activeFrom = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newRollup() *rolluptypes.Rollup {
+	rollup := rolluptypes.NewRollup(valuer.GenerateUUID(), "admin@example.com", rolluptypes.SourceManual, &rolluptypes.PostableRollup{
+		Name:            "errors-by-service",
+		Signal:          telemetrytypes.SignalLogs,
+		Filter:          "severity_text = 'ERROR'",
+		GroupBy:         []string{"service.name", "host.name"},
+		Aggregations:    []string{"count()", "max(code)"},
+		IntervalSeconds: 60,
+	})
+	rollup.ID = valuer.MustNewUUID("0195b8a0-0000-7000-8000-000000000001")
+	rollup.Status = rolluptypes.StatusActive
+	rollup.ActiveFrom = activeFrom
+	return rollup
+}
+
+func newLogsStatementBuilder(t *testing.T, rollups ...*rolluptypes.Rollup) *statementBuilder[qbtypes.LogAggregation] {
+	metadataStore := telemetrytypestest.NewMockMetadataStore()
+	metadataStore.KeysMap = logstelemetryschema.BuildCompleteFieldKeyMap(activeFrom.Add(-24 * time.Hour))
+	return NewLogs(instrumentationtest.New().ToProviderSettings(), rawStatementBuilder[qbtypes.LogAggregation]{}, getter(rollups), metadataStore, flaggertest.New(t))
+}
+
+func TestBuildRoutesToRollup(t *testing.T) {
+	start := uint64(activeFrom.Add(time.Hour).UnixMilli())
+	end := uint64(activeFrom.Add(2 * time.Hour).UnixMilli())
+
+	query := func() qbtypes.QueryBuilderQuery[qbtypes.LogAggregation] {
+		return qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+			Name:         "A",
+			Signal:       telemetrytypes.SignalLogs,
+			StepInterval: qbtypes.Step{Duration: 5 * time.Minute},
+			Filter:       &qbtypes.Filter{Expression: "severity_text = 'ERROR'"},
+			GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}},
+			Aggregations: []qbtypes.LogAggregation{{Expression: "count()"}},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		start, end  uint64
+		mutate      func(q *qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+		expected    string
+	}{
+		{
+			name:        "TimeSeries",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			start:       start,
+			end:         end,
+			expected:    "SELECT toStartOfInterval(bucket_start, INTERVAL 300 SECOND) AS ts, g_0 AS `__GROUP_BY_KEY_0_service.name`, countMerge(r_0) AS __result_0 FROM signoz_logs.distributed_rollup_0195b8a0000070008000000000000001 WHERE bucket_start >= toDateTime(1767229200) AND bucket_start < toDateTime(1767232800) GROUP BY ts, `__GROUP_BY_KEY_0_service.name`",
+		},
+		{
+			name:        "TimeSeriesWithLimit",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			start:       start,
+			end:         end,
+			mutate: func(q *qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) {
+				q.Limit = 5
+				q.Aggregations = []qbtypes.LogAggregation{{Expression: "max(code)"}}
+			},
+			expected: "WITH __limit_cte AS (SELECT g_0 AS `__GROUP_BY_KEY_0_service.name`, maxMerge(r_1) AS __result_0 FROM signoz_logs.distributed_rollup_0195b8a0000070008000000000000001 WHERE bucket_start >= toDateTime(1767229200) AND bucket_start < toDateTime(1767232800) GROUP BY `__GROUP_BY_KEY_0_service.name` ORDER BY __result_0 DESC LIMIT ?) SELECT toStartOfInterval(bucket_start, INTERVAL 300 SECOND) AS ts, g_0 AS `__GROUP_BY_KEY_0_service.name`, maxMerge(r_1) AS __result_0 FROM signoz_logs.distributed_rollup_0195b8a0000070008000000000000001 WHERE bucket_start >= toDateTime(1767229200) AND bucket_start < toDateTime(1767232800) AND (`__GROUP_BY_KEY_0_service.name`) GLOBAL IN (SELECT `__GROUP_BY_KEY_0_service.name` FROM __limit_cte) GROUP BY ts, `__GROUP_BY_KEY_0_service.name`",
+		},
+		{
+			name:        "Scalar",
+			requestType: qbtypes.RequestTypeScalar,
+			start:       start,
+			end:         end,
+			mutate: func(q *qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) {
+				q.GroupBy = append(q.GroupBy, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "host.name"}})
+			},
+			expected: "SELECT g_0 AS `__GROUP_BY_KEY_0_service.name`, g_1 AS `__GROUP_BY_KEY_1_host.name`, countMerge(r_0) AS __result_0 FROM signoz_logs.distributed_rollup_0195b8a0000070008000000000000001 WHERE bucket_start >= toDateTime(1767229200) AND bucket_start < toDateTime(1767232800) GROUP BY `__GROUP_BY_KEY_0_service.name`, `__GROUP_BY_KEY_1_host.name` ORDER BY __result_0 DESC",
+		},
+		{name: "WindowNotAligned", requestType: qbtypes.RequestTypeTimeSeries, start: start + 30_000, end: end, expected: "raw"},
+		{name: "WindowBeforeActive", requestType: qbtypes.RequestTypeTimeSeries, start: start - 2*3600_000, end: end, expected: "raw"},
+		{name: "Raw", requestType: qbtypes.RequestTypeRaw, start: start, end: end, expected: "raw"},
+		{
+			name:        "StepNotMultipleOfInterval",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			start:       start,
+			end:         end,
+			mutate: func(q *qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) {
+				q.StepInterval = qbtypes.Step{Duration: 90 * time.Second}
+			},
+			expected: "raw",
+		},
+		{
+			name:        "OtherFilter",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			start:       start,
+			end:         end,
+			mutate:      func(q *qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) { q.Filter = nil },
+			expected:    "raw",
+		},
+		{
+			name:        "AggregationNotInRollup",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			start:       start,
+			end:         end,
+			mutate: func(q *qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) {
+				q.Aggregations = []qbtypes.LogAggregation{{Expression: "count_distinct(host.name)"}}
+			},
+			expected: "raw",
+		},
+	}
+
+	statementBuilder := newLogsStatementBuilder(t, newRollup())
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := query()
+			if tc.mutate != nil {
+				tc.mutate(&q)
+			}
+
+			stmt, err := statementBuilder.Build(context.Background(), valuer.GenerateUUID(), tc.start, tc.end, tc.requestType, q, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, stmt.Query)
+		})
+	}
+}
+
+func TestBuildCreate(t *testing.T) {
+	statementBuilder := newLogsStatementBuilder(t)
+
+	stmts, err := statementBuilder.BuildCreate(context.Background(), valuer.GenerateUUID(), "cluster", newRollup())
+	require.NoError(t, err)
+
+	definition := "SELECT toStartOfInterval(toDateTime(intDiv(timestamp, 1000000000)), INTERVAL 60 SECOND) AS bucket_start, toString(multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, NULL)) AS g_0, toString(multiIf(mapContains(attributes_string, 'host.name'), attributes_string['host.name'], mapContains(attributes_number, 'host.name'), toString(attributes_number['host.name']), mapContains(attributes_bool, 'host.name'), toString(attributes_bool['host.name']), NULL)) AS g_1, countState() AS r_0, maxState(multiIf(mapContains(attributes_number, 'code'), toFloat64(attributes_number['code']), NULL)) AS r_1 FROM signoz_logs.logs_v2 WHERE severity_text = 'ERROR' GROUP BY bucket_start, g_0, g_1"
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS signoz_logs.rollup_0195b8a0000070008000000000000001 ON CLUSTER cluster ENGINE = AggregatingMergeTree ORDER BY (bucket_start, g_0, g_1) SETTINGS allow_nullable_key = 1 EMPTY AS " + definition,
+		"CREATE TABLE IF NOT EXISTS signoz_logs.distributed_rollup_0195b8a0000070008000000000000001 ON CLUSTER cluster AS signoz_logs.rollup_0195b8a0000070008000000000000001 ENGINE = Distributed('cluster', 'signoz_logs', 'rollup_0195b8a0000070008000000000000001')",
+		"CREATE MATERIALIZED VIEW IF NOT EXISTS signoz_logs.rollup_0195b8a0000070008000000000000001_mv ON CLUSTER cluster TO signoz_logs.rollup_0195b8a0000070008000000000000001 AS " + definition,
+	}, stmts)
+
+	rollup := newRollup()
+	rollup.Aggregations = []string{"p99(duration)"}
+	_, err = statementBuilder.BuildCreate(context.Background(), valuer.GenerateUUID(), "cluster", rollup)
+	require.Error(t, err)
+}
+
+func TestRollupFunction(t *testing.T) {
+	testCases := []struct {
+		expression string
+		want       string
+		wantErr    string
+	}{
+		{expression: "count()", want: "count"},
+		{expression: "COUNT()", want: "count"},
+		{expression: "countIf(severity_text = 'ERROR')", want: "countIf"},
+		{expression: "count_distinct(service.name)", want: "uniqExact"},
+		{expression: "sum(bytes)", want: "sum"},
+		{expression: "avg(duration_nano)", want: "avg"},
+		{expression: "max(code)", want: "max"},
+		{expression: "rate()", wantErr: "cannot be rolled up"},
+		{expression: "p99(duration_nano)", wantErr: "cannot be rolled up"},
+		{expression: "count() + 1", wantErr: "single aggregation function call"},
+		{expression: "sum(a) / sum(b)", wantErr: "single aggregation function call"},
+		{expression: "unknown(a)", wantErr: "unknown aggregation function"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			fn, err := rollupFunction(tc.expression)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, fn)
+		})
+	}
+}
+
+func TestStateExpression(t *testing.T) {
+	state, err := stateExpression("countIf", "countIf(body LIKE '%)%')")
+	require.NoError(t, err)
+	assert.Equal(t, "countIfState(body LIKE '%)%')", state)
+
+	_, err = stateExpression("count", "count() + 1")
+	require.Error(t, err)
+}
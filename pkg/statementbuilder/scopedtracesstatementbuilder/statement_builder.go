@@ -115,7 +115,7 @@ func (b *scopedTraceStatementBuilder) Build(
 	switch requestType {
 	case qbtypes.RequestTypeTrace:
 		return b.buildTraceListQuery(ctx, orgID, querybuilder.ToNanoSecs(start), querybuilder.ToNanoSecs(end), query, variables)
-	case qbtypes.RequestTypeRaw:
+	case qbtypes.RequestTypeRaw, qbtypes.RequestTypeTimeSeries, qbtypes.RequestTypeScalar:
 		return b.buildDelegated(ctx, orgID, start, end, requestType, query, variables)
 	default:
 		return nil, ErrUnsupportedRequestType
@@ -123,7 +123,8 @@ func (b *scopedTraceStatementBuilder) Build(
 }
 
 // buildDelegated ANDs the base gate into the user filter and delegates to the
-// standard trace builder (the span-list / raw path).
+// standard trace builder (the span-list / raw path, and span-level aggregations
+// over time or as scalars, e.g. the LLM spend by model).
 func (b *scopedTraceStatementBuilder) buildDelegated(
 	ctx context.Context,
 	orgID valuer.UUID,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/huandu/go-sqlbuilder"
 
@@ -196,7 +197,7 @@ func (b *traceOperatorCTEBuilder) buildExpressionCTEs(ctx context.Context, expr
 		}
 	}
 
-	return b.buildOperatorCTE(ctx, *expr.Operator, leftCTE, rightCTE)
+	return b.buildOperatorCTE(ctx, expr, leftCTE, rightCTE)
 }
 
 func (b *traceOperatorCTEBuilder) buildQueryCTE(ctx context.Context, queryName string) (string, error) {
@@ -323,9 +324,46 @@ func sanitizeForSQL(s string) string {
 	return result
 }
 
-func (b *traceOperatorCTEBuilder) buildOperatorCTE(ctx context.Context, op qbtypes.TraceOperatorType, leftCTE, rightCTE string) (string, error) {
-	sanitizedOp := sanitizeForSQL(op.StringValue())
-	cteName := fmt.Sprintf("%s_%s_%s", leftCTE, sanitizedOp, rightCTE)
+// operatorCTEName names the CTE of an operator after its operands, e.g. A_DIR_DESC_B.
+func operatorCTEName(expr *qbtypes.TraceOperand, leftCTE, rightCTE string) string {
+	switch *expr.Operator {
+	case qbtypes.TraceOperatorFollowedBy, qbtypes.TraceOperatorNotFollowedBy:
+		name := "FOLLOWED_BY"
+		if *expr.Operator == qbtypes.TraceOperatorNotFollowedBy {
+			name = "NOT_FOLLOWED_BY"
+		}
+		if expr.Window > 0 {
+			name = fmt.Sprintf("%s_WITHIN_%d", name, expr.Window.Nanoseconds())
+		}
+		return fmt.Sprintf("%s_%s_%s", leftCTE, name, rightCTE)
+	case qbtypes.TraceOperatorSibling:
+		return fmt.Sprintf("%s_SIBLING_%s", leftCTE, rightCTE)
+	case qbtypes.TraceOperatorCount:
+		return fmt.Sprintf("%s_COUNT_%s_%d", leftCTE, countComparisonNames[expr.Count.Comparison], expr.Count.Value)
+	}
+	return fmt.Sprintf("%s_%s_%s", leftCTE, sanitizeForSQL(expr.Operator.StringValue()), rightCTE)
+}
+
+var countComparisonNames = map[qbtypes.TraceOperatorComparison]string{
+	qbtypes.TraceOperatorComparisonGreaterThanOrEq: "GE",
+	qbtypes.TraceOperatorComparisonGreaterThan:     "GT",
+	qbtypes.TraceOperatorComparisonLessThanOrEq:    "LE",
+	qbtypes.TraceOperatorComparisonLessThan:        "LT",
+	qbtypes.TraceOperatorComparisonEq:              "EQ",
+	qbtypes.TraceOperatorComparisonNotEq:           "NE",
+}
+
+func (b *traceOperatorCTEBuilder) buildOperatorCTE(ctx context.Context, expr *qbtypes.TraceOperand, leftCTE, rightCTE string) (string, error) {
+	op := *expr.Operator
+	if op == qbtypes.TraceOperatorCount {
+		if expr.Count == nil {
+			return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "count operator requires a condition")
+		}
+		if _, ok := countComparisonNames[expr.Count.Comparison]; !ok {
+			return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported count comparison: %s", expr.Count.Comparison.StringValue())
+		}
+	}
+	cteName := operatorCTEName(expr, leftCTE, rightCTE)
 
 	if _, exists := b.cteNameToIndex[cteName]; exists {
 		return cteName, nil
@@ -348,6 +386,14 @@ func (b *traceOperatorCTEBuilder) buildOperatorCTE(ctx context.Context, op qbtyp
 		args = nil
 	case qbtypes.TraceOperatorNot, qbtypes.TraceOperatorExclude:
 		sql, args, dependsOn = b.buildNotCTE(leftCTE, rightCTE)
+	case qbtypes.TraceOperatorFollowedBy:
+		sql, args, dependsOn = b.buildFollowedByCTE(leftCTE, rightCTE, expr.Window)
+	case qbtypes.TraceOperatorNotFollowedBy:
+		sql, args, dependsOn = b.buildNotFollowedByCTE(leftCTE, rightCTE, expr.Window)
+	case qbtypes.TraceOperatorSibling:
+		sql, args, dependsOn = b.buildSiblingCTE(leftCTE, rightCTE)
+	case qbtypes.TraceOperatorCount:
+		sql, args, dependsOn = b.buildCountCTE(leftCTE, expr.Count)
 	default:
 		return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported operator: %s", op.StringValue())
 	}
@@ -428,6 +474,80 @@ func (b *traceOperatorCTEBuilder) buildNotCTE(leftCTE, rightCTE string) (string,
 	return sql, args, []string{leftCTE, rightCTE}
 }
 
+// followedBySelect selects the spans of leftCTE followed by a span of rightCTE starting after
+// they end, within window when it is set.
+func followedBySelect(leftCTE, rightCTE string, window time.Duration, columns ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(columns...)
+	sb.From(fmt.Sprintf("%s AS l", leftCTE))
+	sb.JoinWithOption(
+		sqlbuilder.InnerJoin,
+		fmt.Sprintf("%s AS r", rightCTE),
+		"l.trace_id = r.trace_id",
+	)
+	sb.Where(
+		"l.span_id != r.span_id",
+		"toUnixTimestamp64Nano(r.timestamp) >= toUnixTimestamp64Nano(l.timestamp) + l.duration_nano",
+	)
+	if window > 0 {
+		sb.Where(sb.LE("toUnixTimestamp64Nano(r.timestamp) - toUnixTimestamp64Nano(l.timestamp) - l.duration_nano", window.Nanoseconds()))
+	}
+	return sb
+}
+
+func (b *traceOperatorCTEBuilder) buildFollowedByCTE(leftCTE, rightCTE string, window time.Duration) (string, []any, []string) {
+	sb := followedBySelect(leftCTE, rightCTE, window, "DISTINCT l.*")
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return sql, args, []string{leftCTE, rightCTE}
+}
+
+func (b *traceOperatorCTEBuilder) buildNotFollowedByCTE(leftCTE, rightCTE string, window time.Duration) (string, []any, []string) {
+	followed := followedBySelect(leftCTE, rightCTE, window, "l.trace_id", "l.span_id")
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("s.*")
+	sb.From(fmt.Sprintf("%s AS s", leftCTE))
+	sb.Where(fmt.Sprintf("(s.trace_id, s.span_id) GLOBAL NOT IN (%s)", sb.Var(followed)))
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return sql, args, []string{leftCTE, rightCTE}
+}
+
+func (b *traceOperatorCTEBuilder) buildSiblingCTE(leftCTE, rightCTE string) (string, []any, []string) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("DISTINCT l.*")
+	sb.From(fmt.Sprintf("%s AS l", leftCTE))
+	sb.JoinWithOption(
+		sqlbuilder.InnerJoin,
+		fmt.Sprintf("%s AS r", rightCTE),
+		"l.trace_id = r.trace_id AND l.parent_span_id = r.parent_span_id",
+	)
+	sb.Where(
+		"l.parent_span_id != ''",
+		"l.span_id != r.span_id",
+	)
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return sql, args, []string{leftCTE, rightCTE}
+}
+
+func (b *traceOperatorCTEBuilder) buildCountCTE(operandCTE string, condition *qbtypes.TraceOperatorCountCondition) (string, []any, []string) {
+	counts := sqlbuilder.NewSelectBuilder()
+	counts.Select("trace_id")
+	counts.From(operandCTE)
+	counts.GroupBy("trace_id")
+	counts.Having(fmt.Sprintf("count() %s %s", condition.Comparison.StringValue(), counts.Var(condition.Value)))
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("*")
+	sb.From(operandCTE)
+	sb.Where(fmt.Sprintf("trace_id GLOBAL IN (%s)", sb.Var(counts)))
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return sql, args, []string{operandCTE}
+}
+
 func (b *traceOperatorCTEBuilder) buildFinalQuery(ctx context.Context, selectFromCTE string, requestType qbtypes.RequestType) (*qbtypes.Statement, error) {
 	// Mirror statement_builder.go::Build: for raw queries, empty selectFields
 	// expands to the full intrinsic + calculated set, and the list query also
@@ -2601,13 +2601,23 @@ func (t *telemetryMetaStore) GetFirstSeenFromMetricMetadata(ctx context.Context,
 }
 
 func (t *telemetryMetaStore) FetchLastSeenInfoMulti(ctx context.Context, orgID valuer.UUID, metricNames ...string) (map[string]int64, error) {
-	lastSeenInfo, err := t.fetchLastSeenInfoForTable(ctx, metricstelemetryschema.TimeseriesV4TableName, metricNames)
+	return t.fetchLastSeenInfo(ctx, orgID, "", "", metricNames)
+}
+
+// FetchLastSeenInfoMultiByLabel returns the last time each of the given metrics was seen on a
+// series whose label labelKey is labelValue.
+func (t *telemetryMetaStore) FetchLastSeenInfoMultiByLabel(ctx context.Context, orgID valuer.UUID, labelKey, labelValue string, metricNames ...string) (map[string]int64, error) {
+	return t.fetchLastSeenInfo(ctx, orgID, labelKey, labelValue, metricNames)
+}
+
+func (t *telemetryMetaStore) fetchLastSeenInfo(ctx context.Context, orgID valuer.UUID, labelKey, labelValue string, metricNames []string) (map[string]int64, error) {
+	lastSeenInfo, err := t.fetchLastSeenInfoForTable(ctx, metricstelemetryschema.TimeseriesV4TableName, labelKey, labelValue, metricNames)
 	if err != nil {
 		return nil, err
 	}
 
 	if t.fl.BooleanOrEmpty(ctx, flagger.FeatureEnableMetricsReduction, featuretypes.NewFlaggerEvaluationContext(orgID)) {
-		reducedLastSeen, err := t.fetchLastSeenInfoForTable(ctx, metricstelemetryschema.TimeseriesV4ReducedTableName, metricNames)
+		reducedLastSeen, err := t.fetchLastSeenInfoForTable(ctx, metricstelemetryschema.TimeseriesV4ReducedTableName, labelKey, labelValue, metricNames)
 		if err != nil {
 			return nil, err
 		}
@@ -2621,13 +2631,16 @@ func (t *telemetryMetaStore) FetchLastSeenInfoMulti(ctx context.Context, orgID v
 	return lastSeenInfo, nil
 }
 
-func (t *telemetryMetaStore) fetchLastSeenInfoForTable(ctx context.Context, tableName string, metricNames []string) (map[string]int64, error) {
+func (t *telemetryMetaStore) fetchLastSeenInfoForTable(ctx context.Context, tableName string, labelKey, labelValue string, metricNames []string) (map[string]int64, error) {
 	sb := sqlbuilder.Select(
 		"metric_name",
 		"max(unix_milli)",
 	).
 		From(t.metricsDBName + "." + tableName)
 	sb.Where(sb.In("metric_name", metricNames))
+	if labelKey != "" {
+		sb.Where("JSONExtractString(labels, " + sb.Var(labelKey) + ") = " + sb.Var(labelValue))
+	}
 	sb.GroupBy("metric_name")
 
 	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
@@ -2654,3 +2667,116 @@ func (t *telemetryMetaStore) fetchLastSeenInfoForTable(ctx context.Context, tabl
 	}
 	return lastSeenInfo, nil
 }
+
+// FetchLogFieldLastSeenInfoMulti returns the last time each of the given log attribute or resource
+// fields was seen, from the logs tag attributes table.
+func (t *telemetryMetaStore) FetchLogFieldLastSeenInfoMulti(ctx context.Context, orgID valuer.UUID, keys ...telemetrytypes.FieldCardinalityKey) (map[telemetrytypes.FieldCardinalityKey]int64, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.TelemetrySignal:  telemetrytypes.SignalLogs.StringValue(),
+		instrumentationtypes.CodeNamespace:    "metadata",
+		instrumentationtypes.CodeFunctionName: "FetchLogFieldLastSeenInfoMulti",
+	})
+	result := make(map[telemetrytypes.FieldCardinalityKey]int64)
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	lookupItems := make([]any, 0, len(keys))
+	for _, key := range keys {
+		lookupItems = append(lookupItems, sqlbuilder.Tuple(key.Name, key.FieldContext.TagType()))
+	}
+
+	sb := sqlbuilder.Select(
+		"tag_key",
+		"tag_type",
+		"max(unix_milli) AS last_seen",
+	).From(t.logsDBName + "." + t.logsFieldsTblName)
+	sb.Where(sb.In(sqlbuilder.TupleNames("tag_key", "tag_type"), lookupItems...))
+	sb.GroupBy("tag_key", "tag_type")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	rows, err := t.telemetrystore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to fetch log field last seen info")
+	}
+	defer rows.Close()
+
+	contexts := make(map[string]telemetrytypes.FieldContext, len(keys))
+	for _, key := range keys {
+		contexts[key.FieldContext.TagType()] = key.FieldContext
+	}
+
+	for rows.Next() {
+		var tagKey, tagType string
+		var lastSeen int64
+		if err := rows.Scan(&tagKey, &tagType, &lastSeen); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan log field last seen info")
+		}
+		result[telemetrytypes.FieldCardinalityKey{Name: tagKey, FieldContext: contexts[tagType]}] = lastSeen
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to iterate log field last seen info")
+	}
+
+	return result, nil
+}
+
+// FetchSpanFieldCardinalityMulti estimates the number of distinct values of span attribute and
+// resource fields from the traces tag attributes table.
+func (t *telemetryMetaStore) FetchSpanFieldCardinalityMulti(ctx context.Context, orgID valuer.UUID, startUnixMilli int64, keys ...telemetrytypes.FieldCardinalityKey) (map[telemetrytypes.FieldCardinalityKey]uint64, error) {
+	ctx = ctxtypes.NewContextWithCommentVals(ctx, map[string]string{
+		instrumentationtypes.TelemetrySignal:  telemetrytypes.SignalTraces.StringValue(),
+		instrumentationtypes.CodeNamespace:    "metadata",
+		instrumentationtypes.CodeFunctionName: "FetchSpanFieldCardinalityMulti",
+	})
+	result := make(map[telemetrytypes.FieldCardinalityKey]uint64)
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	lookupItems := make([]any, 0, len(keys))
+	for _, key := range keys {
+		lookupItems = append(lookupItems, sqlbuilder.Tuple(key.Name, key.FieldContext.TagType()))
+	}
+
+	sb := sqlbuilder.Select(
+		"tag_key",
+		"tag_type",
+		"uniq(string_value, number_value) AS cardinality",
+	).From(t.tracesDBName + "." + t.tracesFieldsTblName)
+	sb.Where(
+		sb.GE("unix_milli", startUnixMilli),
+		sb.In(sqlbuilder.TupleNames("tag_key", "tag_type"), lookupItems...),
+	)
+	sb.GroupBy("tag_key", "tag_type")
+
+	query, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	rows, err := t.telemetrystore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to query span field cardinality")
+	}
+	defer rows.Close()
+
+	contexts := make(map[string]telemetrytypes.FieldContext, len(keys))
+	for _, key := range keys {
+		contexts[key.FieldContext.TagType()] = key.FieldContext
+	}
+
+	for rows.Next() {
+		var tagKey, tagType string
+		var cardinality uint64
+		if err := rows.Scan(&tagKey, &tagType, &cardinality); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan span field cardinality")
+		}
+		result[telemetrytypes.FieldCardinalityKey{Name: tagKey, FieldContext: contexts[tagType]}] = cardinality
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to iterate span field cardinality")
+	}
+
+	return result, nil
+}
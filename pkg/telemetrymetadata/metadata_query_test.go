@@ -12,6 +12,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -83,3 +84,115 @@ func TestGetFirstSeenFromMetricMetadata(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestFetchSpanFieldCardinalityMulti(t *testing.T) {
+	mockTelemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &regexMatcher{})
+	mock := mockTelemetryStore.Mock()
+
+	metadata := NewTelemetryMetaStore(
+		instrumentationtest.New().ToProviderSettings(),
+		mockTelemetryStore,
+		flaggertest.New(t),
+	)
+
+	keys := []telemetrytypes.FieldCardinalityKey{
+		{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource},
+		{Name: "http.route", FieldContext: telemetrytypes.FieldContextAttribute},
+		{Name: "user.id", FieldContext: telemetrytypes.FieldContextAttribute},
+	}
+
+	expectedQuery := `SELECT tag_key, tag_type, uniq\(string_value, number_value\) AS cardinality FROM signoz_traces.distributed_tag_attributes_v2 WHERE unix_milli >= \? AND \(tag_key, tag_type\) IN \(\(\?, \?\), \(\?, \?\), \(\?, \?\)\) GROUP BY tag_key, tag_type`
+
+	mock.ExpectQuery(expectedQuery).
+		WithArgs(int64(1000), "service.name", "resource", "http.route", "tag", "user.id", "tag").
+		WillReturnRows(cmock.NewRows([]cmock.ColumnType{
+			{Name: "tag_key", Type: "String"},
+			{Name: "tag_type", Type: "String"},
+			{Name: "cardinality", Type: "UInt64"},
+		}, [][]any{
+			{"service.name", "resource", uint64(12)},
+			{"http.route", "tag", uint64(40)},
+		}))
+
+	result, err := metadata.FetchSpanFieldCardinalityMulti(context.Background(), valuer.GenerateUUID(), 1000, keys...)
+	require.NoError(t, err)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, uint64(12), result[keys[0]])
+	assert.Equal(t, uint64(40), result[keys[1]])
+	assert.NotContains(t, result, keys[2])
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestFetchLogFieldLastSeenInfoMulti(t *testing.T) {
+	mockTelemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &regexMatcher{})
+	mock := mockTelemetryStore.Mock()
+
+	metadata := NewTelemetryMetaStore(
+		instrumentationtest.New().ToProviderSettings(),
+		mockTelemetryStore,
+		flaggertest.New(t),
+	)
+
+	keys := []telemetrytypes.FieldCardinalityKey{
+		{Name: "azure.resource.id", FieldContext: telemetrytypes.FieldContextResource},
+		{Name: "oci.log.category", FieldContext: telemetrytypes.FieldContextAttribute},
+	}
+
+	expectedQuery := `SELECT tag_key, tag_type, max\(unix_milli\) AS last_seen FROM signoz_logs.distributed_tag_attributes_v2 WHERE \(tag_key, tag_type\) IN \(\(\?, \?\), \(\?, \?\)\) GROUP BY tag_key, tag_type`
+
+	mock.ExpectQuery(expectedQuery).
+		WithArgs("azure.resource.id", "resource", "oci.log.category", "tag").
+		WillReturnRows(cmock.NewRows([]cmock.ColumnType{
+			{Name: "tag_key", Type: "String"},
+			{Name: "tag_type", Type: "String"},
+			{Name: "last_seen", Type: "Int64"},
+		}, [][]any{
+			{"azure.resource.id", "resource", int64(1700000000000)},
+		}))
+
+	result, err := metadata.FetchLogFieldLastSeenInfoMulti(context.Background(), valuer.GenerateUUID(), keys...)
+	require.NoError(t, err)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1700000000000), result[keys[0]])
+	assert.NotContains(t, result, keys[1])
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestFetchLastSeenInfoMultiByLabel(t *testing.T) {
+	mockTelemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &regexMatcher{})
+	mock := mockTelemetryStore.Mock()
+
+	metadata := NewTelemetryMetaStore(
+		instrumentationtest.New().ToProviderSettings(),
+		mockTelemetryStore,
+		flaggertest.New(t),
+	)
+
+	expectedQuery := `SELECT metric_name, max\(unix_milli\) FROM signoz_metrics.distributed_time_series_v4 WHERE metric_name IN \(\?\) AND JSONExtractString\(labels, \?\) = \? GROUP BY metric_name`
+
+	mock.ExpectQuery(expectedQuery).
+		WithArgs([]string{"aws_EC2_CPUUtilization_max", "aws_EC2_NetworkIn_max"}, "cloud.account.id", "123456789012").
+		WillReturnRows(cmock.NewRows([]cmock.ColumnType{
+			{Name: "metric_name", Type: "String"},
+			{Name: "max(unix_milli)", Type: "Int64"},
+		}, [][]any{
+			{"aws_EC2_CPUUtilization_max", int64(1700000000000)},
+		}))
+
+	result, err := metadata.FetchLastSeenInfoMultiByLabel(context.Background(), valuer.GenerateUUID(), "cloud.account.id", "123456789012", "aws_EC2_CPUUtilization_max", "aws_EC2_NetworkIn_max")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int64{"aws_EC2_CPUUtilization_max": 1700000000000}, result)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -274,6 +274,17 @@ func (c *conditionBuilder) conditionForResolvedKey(
 		return c.conditionForArrayFunction(ctx, orgID, key, operator, value, columns, sb)
 	}
 
+	if subQuery, ok := value.(*qbtypes.SubQuery); ok {
+		if key.FieldContext == telemetrytypes.FieldContextBody {
+			return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "sub-query %q cannot filter the body key %q", subQuery.Name, key.Name)
+		}
+		fieldExpression, err := c.fm.FieldFor(ctx, orgID, startNs, endNs, key)
+		if err != nil {
+			return "", err
+		}
+		return subQuery.Condition(fieldExpression, key.FieldDataType, operator)
+	}
+
 	// TODO(Piyush): Update this to support multiple JSON columns based on evolutions
 	for _, column := range columns {
 		if column.Type.GetType() == schema.ColumnTypeEnumJSON && isBodyJSONSearch(key, columns) && c.fl.BooleanOrEmpty(ctx, flagger.FeatureUseJSONBody, featuretypes.NewFlaggerEvaluationContext(orgID)) && key.Name != messageSubField {
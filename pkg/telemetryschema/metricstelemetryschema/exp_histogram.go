@@ -0,0 +1,29 @@
+package metricstelemetryschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The exp_hist table keeps every exponential histogram point as a DDSketch of the values it
+// counts, in the sketch column, next to its count and sum. The OTLP buckets themselves are
+// not stored: every distribution of the point is read back from its sketch.
+const (
+	ExpHistogramSketchColumn = "sketch"
+
+	// ExpHistogramSketchRelativeAccuracy is the relative accuracy the sketches are written
+	// with; merging them must use the same one.
+	ExpHistogramSketchRelativeAccuracy = 0.01
+)
+
+// ExpHistogramQuantilesExpr returns the expression of the quantiles, at the given ranks in
+// [0, 1], of the sketches in the sketch expression merged across the rows aggregated. The
+// result is an array with one value per rank, in the order of the ranks.
+func ExpHistogramQuantilesExpr(sketch string, ranks []float64) string {
+	params := make([]string, 0, len(ranks)+1)
+	params = append(params, fmt.Sprintf("%g", ExpHistogramSketchRelativeAccuracy))
+	for _, r := range ranks {
+		params = append(params, fmt.Sprintf("%f", r))
+	}
+	return fmt.Sprintf("quantilesDDMerge(%s)(%s)", strings.Join(params, ", "), sketch)
+}
@@ -51,6 +51,10 @@ func (c *conditionBuilder) conditionFor(
 		return "", err
 	}
 
+	if subQuery, ok := value.(*qbtypes.SubQuery); ok {
+		return subQuery.Condition(fieldExpression, logical.FieldDataType, operator)
+	}
+
 	// TODO(srikanthccv): maybe extend this to every possible attribute
 	if logical.Name == "duration_nano" || logical.Name == "durationNano" { // QoL improvement
 		switch v := value.(type) {
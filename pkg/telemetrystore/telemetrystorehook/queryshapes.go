@@ -0,0 +1,118 @@
+package telemetrystorehook
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+)
+
+// queryShapesRetention is how long the counts of the query shapes are kept.
+const queryShapesRetention = 24 * time.Hour
+
+// QueryShapeCount is how often the queries of a shape ran.
+type QueryShapeCount struct {
+	Key            string
+	QueriesPerHour float64
+}
+
+// QueryShapes counts, by hour, the queries run with every query shape of every org over
+// the last day, see ctxtypes.NewContextWithQueryShape.
+type QueryShapes struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	hours map[int64]map[string]map[string]int
+}
+
+func NewQueryShapes() *QueryShapes {
+	return &QueryShapes{
+		now:   time.Now,
+		hours: map[int64]map[string]map[string]int{},
+	}
+}
+
+func (s *QueryShapes) add(shape ctxtypes.QueryShape) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hour := s.now().Truncate(time.Hour).Unix()
+	for h := range s.hours {
+		if h <= hour-int64(queryShapesRetention.Seconds()) {
+			delete(s.hours, h)
+		}
+	}
+
+	orgs, ok := s.hours[hour]
+	if !ok {
+		orgs = map[string]map[string]int{}
+		s.hours[hour] = orgs
+	}
+	shapes, ok := orgs[shape.OrgID]
+	if !ok {
+		shapes = map[string]int{}
+		orgs[shape.OrgID] = shapes
+	}
+	shapes[shape.Key]++
+}
+
+// Top returns the n shapes of the org queried the most, averaged over the hours the org
+// ran queries in the last day.
+func (s *QueryShapes) Top(orgID string, n int) []QueryShapeCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hour := s.now().Truncate(time.Hour).Unix()
+	totals := map[string]int{}
+	hours := 0
+	for h, orgs := range s.hours {
+		shapes, ok := orgs[orgID]
+		if !ok || h <= hour-int64(queryShapesRetention.Seconds()) {
+			continue
+		}
+		hours++
+		for key, count := range shapes {
+			totals[key] += count
+		}
+	}
+
+	counts := make([]QueryShapeCount, 0, len(totals))
+	for key, total := range totals {
+		counts = append(counts, QueryShapeCount{Key: key, QueriesPerHour: float64(total) / float64(hours)})
+	}
+	slices.SortFunc(counts, func(a, b QueryShapeCount) int {
+		if c := cmp.Compare(b.QueriesPerHour, a.QueriesPerHour); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	if len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+type queryShapesHook struct {
+	shapes *QueryShapes
+}
+
+// NewQueryShapesFactory returns a factory of the hook counting the queries of every shape
+// in shapes.
+func NewQueryShapesFactory(shapes *QueryShapes) factory.ProviderFactory[telemetrystore.TelemetryStoreHook, telemetrystore.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("queryshapes"), func(ctx context.Context, providerSettings factory.ProviderSettings, config telemetrystore.Config) (telemetrystore.TelemetryStoreHook, error) {
+		return &queryShapesHook{shapes: shapes}, nil
+	})
+}
+
+func (hook *queryShapesHook) BeforeQuery(ctx context.Context, _ *telemetrystore.QueryEvent) context.Context {
+	if shape, ok := ctxtypes.QueryShapeFromContext(ctx); ok {
+		hook.shapes.add(shape)
+	}
+	return ctx
+}
+
+func (queryShapesHook) AfterQuery(ctx context.Context, event *telemetrystore.QueryEvent) {}
@@ -72,6 +72,11 @@ func (h *provider) BeforeQuery(ctx context.Context, _ *telemetrystore.QueryEvent
 		settings["result_overflow_mode"] = ctx.Value("result_overflow_mode")
 	}
 
+	if maxRowsToRead, ok := ctxtypes.ClickhouseMaxRowsToReadFromContext(ctx); ok {
+		settings["max_rows_to_read"] = maxRowsToRead
+		settings["read_overflow_mode"] = "break"
+	}
+
 	// TODO(srikanthccv): enable it when the "Cannot read all data" issue is fixed
 	// https://github.com/ClickHouse/ClickHouse/issues/82283
 	settings["secondary_indices_enable_bulk_filtering"] = false
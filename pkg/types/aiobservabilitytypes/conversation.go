@@ -0,0 +1,401 @@
+package aiobservabilitytypes
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+var (
+	ErrCodeConversationInvalidInput = errors.MustNewCode("conversation_invalid_input")
+	ErrCodeConversationNotFound     = errors.MustNewCode("conversation_not_found")
+)
+
+const (
+	// DefaultSessionKey is the attribute the spans of a conversation share when the
+	// request does not name one.
+	DefaultSessionKey = GenAIConversationID
+	// MaxConversationSpans is the largest number of gen_ai spans a conversation is
+	// reconstructed from; longer conversations are truncated to their earliest spans.
+	MaxConversationSpans = 1000
+	// MaxSearchSpans is the largest number of matching spans a search reads.
+	MaxSearchSpans = 1000
+	// DefaultSearchLimit is the number of conversations a search returns when none is
+	// requested.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest number of conversations a search returns.
+	MaxSearchLimit = 100
+	// MaxSearchTextLength is the longest text a search matches the messages on.
+	MaxSearchTextLength = 256
+
+	// snippetRadius is the number of characters kept around the match in a snippet.
+	snippetRadius = 80
+)
+
+// sessionKeyRegex keeps the session key a plain attribute name, as it is written into
+// the filter expression.
+var sessionKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.\-]{0,127}$`)
+
+// GetConversationParams selects the conversation of a trace, or of the traces sharing a
+// session id attribute, over the window in epoch milliseconds.
+type GetConversationParams struct {
+	TraceID        string `query:"traceId"`
+	SessionID      string `query:"sessionId"`
+	SessionKey     string `query:"sessionKey"`
+	StartUnixMilli int64  `query:"startUnixMilli" required:"true"`
+	EndUnixMilli   int64  `query:"endUnixMilli" required:"true"`
+}
+
+func (p *GetConversationParams) Validate() error {
+	if (p.TraceID == "") == (p.SessionID == "") {
+		return errors.New(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "exactly one of traceId and sessionId must be set")
+	}
+	if p.StartUnixMilli <= 0 || p.EndUnixMilli <= p.StartUnixMilli {
+		return errors.New(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "endUnixMilli must be after a positive startUnixMilli")
+	}
+	return validateSessionKey(&p.SessionKey)
+}
+
+// FilterExpression returns the filter selecting the spans of the conversation.
+func (p *GetConversationParams) FilterExpression() string {
+	if p.TraceID != "" {
+		return "trace_id = " + quote(p.TraceID)
+	}
+	return p.SessionKey + " = " + quote(p.SessionID)
+}
+
+// PostableConversationSearch is the request body of a search over the messages of the
+// gen_ai spans: the window in epoch milliseconds, the text the input or output messages
+// contain and an optional filter over the spans.
+type PostableConversationSearch struct {
+	Start uint64 `json:"start" required:"true"`
+	End   uint64 `json:"end" required:"true"`
+	// Text is matched case-insensitively on gen_ai.input.messages and gen_ai.output.messages.
+	Text   string          `json:"text" required:"true"`
+	Filter *qbtypes.Filter `json:"filter,omitempty"`
+	// SessionKey is the attribute the matches report the session id of, gen_ai.conversation.id
+	// when unset.
+	SessionKey string `json:"sessionKey,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+}
+
+func (p *PostableConversationSearch) Validate() error {
+	if p.End <= p.Start {
+		return errors.New(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "end must be after start")
+	}
+	if strings.TrimSpace(p.Text) == "" {
+		return errors.New(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "text must not be empty")
+	}
+	if len(p.Text) > MaxSearchTextLength {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "text must be at most %d characters long", MaxSearchTextLength)
+	}
+	if p.Limit < 0 || p.Limit > MaxSearchLimit {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "limit must be between 0 and %d, got %d", MaxSearchLimit, p.Limit)
+	}
+	if p.Limit == 0 {
+		p.Limit = DefaultSearchLimit
+	}
+	return validateSessionKey(&p.SessionKey)
+}
+
+// FilterExpression returns the filter selecting the spans whose messages contain the
+// text, within the spans of the request filter.
+func (p *PostableConversationSearch) FilterExpression() string {
+	text := quote(p.Text)
+	expr := "(" + GenAIInputMessages + " CONTAINS " + text + " OR " + GenAIOutputMessages + " CONTAINS " + text + ")"
+	if p.Filter != nil && strings.TrimSpace(p.Filter.Expression) != "" {
+		expr = "(" + p.Filter.Expression + ") AND " + expr
+	}
+	return expr
+}
+
+// ConversationSpan is a gen_ai span a conversation is reconstructed from.
+type ConversationSpan struct {
+	TraceID        string
+	SpanID         string
+	ParentSpanID   string
+	Name           string
+	Timestamp      time.Time
+	DurationNano   uint64
+	SessionID      string
+	Operation      string
+	Model          string
+	Provider       string
+	Agent          string
+	Tool           string
+	InputMessages  string
+	OutputMessages string
+	InputTokens    float64
+	OutputTokens   float64
+	Cost           float64
+	HasError       bool
+	StatusMessage  string
+}
+
+// ToolCall is a gen_ai.tool.name span run on behalf of a turn.
+type ToolCall struct {
+	SpanID        string    `json:"spanId" required:"true"`
+	Name          string    `json:"name" required:"true"`
+	Timestamp     time.Time `json:"timestamp" required:"true"`
+	DurationNano  uint64    `json:"durationNano" required:"true"`
+	HasError      bool      `json:"hasError" required:"true"`
+	StatusMessage string    `json:"statusMessage,omitempty"`
+}
+
+// ConversationTurn is an LLM call of a conversation, with the tool calls run after it in
+// its trace until the next LLM call.
+type ConversationTurn struct {
+	TraceID        string      `json:"traceId" required:"true"`
+	SpanID         string      `json:"spanId" required:"true"`
+	Name           string      `json:"name" required:"true"`
+	Timestamp      time.Time   `json:"timestamp" required:"true"`
+	DurationNano   uint64      `json:"durationNano" required:"true"`
+	Operation      string      `json:"operation,omitempty"`
+	Model          string      `json:"model,omitempty"`
+	Provider       string      `json:"provider,omitempty"`
+	Agent          string      `json:"agent,omitempty"`
+	InputMessages  string      `json:"inputMessages" required:"true"`
+	OutputMessages string      `json:"outputMessages" required:"true"`
+	InputTokens    float64     `json:"inputTokens" required:"true"`
+	OutputTokens   float64     `json:"outputTokens" required:"true"`
+	Cost           float64     `json:"cost" required:"true"`
+	HasError       bool        `json:"hasError" required:"true"`
+	StatusMessage  string      `json:"statusMessage,omitempty"`
+	ToolCalls      []*ToolCall `json:"toolCalls" required:"true" nullable:"false"`
+}
+
+// Conversation is the multi-turn conversation or agent run reconstructed from the gen_ai
+// spans of a trace or a session.
+type Conversation struct {
+	SessionID string              `json:"sessionId,omitempty"`
+	TraceIDs  []string            `json:"traceIds" required:"true" nullable:"false"`
+	StartTime time.Time           `json:"startTime" required:"true"`
+	EndTime   time.Time           `json:"endTime" required:"true"`
+	Turns     []*ConversationTurn `json:"turns" required:"true" nullable:"false"`
+	// ToolCalls are the tool calls run before the first turn of their trace.
+	ToolCalls    []*ToolCall `json:"toolCalls" required:"true" nullable:"false"`
+	InputTokens  float64     `json:"inputTokens" required:"true"`
+	OutputTokens float64     `json:"outputTokens" required:"true"`
+	Cost         float64     `json:"cost" required:"true"`
+	// ErrorCount is the number of erroring turns, tool calls and agent spans.
+	ErrorCount int  `json:"errorCount" required:"true"`
+	HasError   bool `json:"hasError" required:"true"`
+	// Redacted is set when the message bodies were redacted for the role of the caller.
+	Redacted bool `json:"redacted" required:"true"`
+	// Truncated is set when the conversation has more than MaxConversationSpans spans.
+	Truncated bool `json:"truncated" required:"true"`
+}
+
+// NewConversation reconstructs the conversation of the spans. The LLM spans, those with a
+// gen_ai.request.model, are the turns in time order; each tool span is attached to the
+// latest turn of its trace started before it. A turn without gen_ai.agent.name takes the
+// one of its closest agent ancestor.
+func NewConversation(spans []*ConversationSpan) *Conversation {
+	spans = slices.Clone(spans)
+	slices.SortStableFunc(spans, func(a, b *ConversationSpan) int {
+		if c := a.Timestamp.Compare(b.Timestamp); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SpanID, b.SpanID)
+	})
+
+	conversation := &Conversation{TraceIDs: []string{}, Turns: []*ConversationTurn{}, ToolCalls: []*ToolCall{}}
+	spansByID := make(map[string]*ConversationSpan, len(spans))
+	for _, span := range spans {
+		spansByID[span.SpanID] = span
+	}
+
+	lastTurnOfTrace := make(map[string]*ConversationTurn)
+	for _, span := range spans {
+		if !slices.Contains(conversation.TraceIDs, span.TraceID) {
+			conversation.TraceIDs = append(conversation.TraceIDs, span.TraceID)
+		}
+		if conversation.SessionID == "" {
+			conversation.SessionID = span.SessionID
+		}
+		if conversation.StartTime.IsZero() {
+			conversation.StartTime = span.Timestamp
+		}
+		if end := span.Timestamp.Add(time.Duration(span.DurationNano)); end.After(conversation.EndTime) {
+			conversation.EndTime = end
+		}
+		if span.HasError {
+			conversation.ErrorCount++
+		}
+
+		switch {
+		case span.Model != "":
+			turn := &ConversationTurn{
+				TraceID:        span.TraceID,
+				SpanID:         span.SpanID,
+				Name:           span.Name,
+				Timestamp:      span.Timestamp,
+				DurationNano:   span.DurationNano,
+				Operation:      span.Operation,
+				Model:          span.Model,
+				Provider:       span.Provider,
+				Agent:          agentOf(span, spansByID),
+				InputMessages:  span.InputMessages,
+				OutputMessages: span.OutputMessages,
+				InputTokens:    span.InputTokens,
+				OutputTokens:   span.OutputTokens,
+				Cost:           span.Cost,
+				HasError:       span.HasError,
+				StatusMessage:  span.StatusMessage,
+				ToolCalls:      []*ToolCall{},
+			}
+			conversation.Turns = append(conversation.Turns, turn)
+			conversation.InputTokens += span.InputTokens
+			conversation.OutputTokens += span.OutputTokens
+			conversation.Cost += span.Cost
+			lastTurnOfTrace[span.TraceID] = turn
+		case span.Tool != "":
+			call := &ToolCall{
+				SpanID:        span.SpanID,
+				Name:          span.Tool,
+				Timestamp:     span.Timestamp,
+				DurationNano:  span.DurationNano,
+				HasError:      span.HasError,
+				StatusMessage: span.StatusMessage,
+			}
+			if turn, ok := lastTurnOfTrace[span.TraceID]; ok {
+				turn.ToolCalls = append(turn.ToolCalls, call)
+			} else {
+				conversation.ToolCalls = append(conversation.ToolCalls, call)
+			}
+			conversation.Cost += span.Cost
+		default:
+			// agent spans only name the agent of the turns under them
+			conversation.Cost += span.Cost
+		}
+	}
+
+	conversation.HasError = conversation.ErrorCount > 0
+	return conversation
+}
+
+// agentOf returns the gen_ai.agent.name of the span or of its closest ancestor among the
+// spans having one.
+func agentOf(span *ConversationSpan, spansByID map[string]*ConversationSpan) string {
+	seen := make(map[string]struct{})
+	for current := span; current != nil; current = spansByID[current.ParentSpanID] {
+		if current.Agent != "" {
+			return current.Agent
+		}
+		if _, ok := seen[current.SpanID]; ok {
+			break
+		}
+		seen[current.SpanID] = struct{}{}
+	}
+	return ""
+}
+
+// ConversationMatch is a conversation whose messages contain the searched text.
+type ConversationMatch struct {
+	TraceID   string    `json:"traceId" required:"true"`
+	SessionID string    `json:"sessionId,omitempty"`
+	StartTime time.Time `json:"startTime" required:"true"`
+	EndTime   time.Time `json:"endTime" required:"true"`
+	// MatchCount is the number of spans of the trace whose messages contain the text.
+	MatchCount int `json:"matchCount" required:"true"`
+	// Snippet is the text around the first match in the latest matching span.
+	Snippet string `json:"snippet" required:"true"`
+}
+
+type GettableConversationSearch struct {
+	Items []*ConversationMatch `json:"items" required:"true" nullable:"false"`
+	// Truncated is set when more than MaxSearchSpans spans matched, so older conversations
+	// may be missing.
+	Truncated bool `json:"truncated" required:"true"`
+	// Redacted is set when the message bodies were redacted for the role of the caller,
+	// before being matched, so only the text left visible is found.
+	Redacted bool `json:"redacted" required:"true"`
+}
+
+// NewConversationMatches groups the matching spans, latest first, by trace, keeping at
+// most limit traces.
+func NewConversationMatches(spans []*ConversationSpan, text string, limit int) []*ConversationMatch {
+	matches := make([]*ConversationMatch, 0)
+	matchesByTrace := make(map[string]*ConversationMatch)
+	for _, span := range spans {
+		// the span matched before its messages were redacted
+		spanSnippet := snippet(span.InputMessages, text)
+		if spanSnippet == "" {
+			spanSnippet = snippet(span.OutputMessages, text)
+		}
+		if spanSnippet == "" {
+			continue
+		}
+
+		match, ok := matchesByTrace[span.TraceID]
+		if !ok {
+			if len(matches) == limit {
+				continue
+			}
+			match = &ConversationMatch{
+				TraceID:   span.TraceID,
+				SessionID: span.SessionID,
+				StartTime: span.Timestamp,
+				EndTime:   span.Timestamp,
+				Snippet:   spanSnippet,
+			}
+			matchesByTrace[span.TraceID] = match
+			matches = append(matches, match)
+		}
+		match.MatchCount++
+		if span.Timestamp.Before(match.StartTime) {
+			match.StartTime = span.Timestamp
+		}
+		if span.Timestamp.After(match.EndTime) {
+			match.EndTime = span.Timestamp
+		}
+		if match.SessionID == "" {
+			match.SessionID = span.SessionID
+		}
+	}
+	return matches
+}
+
+// snippet returns the text around the first case-insensitive match of text in body, or
+// an empty string when it does not match.
+func snippet(body, text string) string {
+	index := strings.Index(strings.ToLower(body), strings.ToLower(text))
+	if index < 0 {
+		return ""
+	}
+	runes := []rune(body)
+	// the index is a byte offset, the radius is in runes
+	start := len([]rune(body[:index]))
+	end := start + len([]rune(text))
+	from, to := max(start-snippetRadius, 0), min(end+snippetRadius, len(runes))
+
+	out := string(runes[from:to])
+	if from > 0 {
+		out = "…" + out
+	}
+	if to < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+// validateSessionKey defaults the session key and checks it is a plain attribute name.
+func validateSessionKey(key *string) error {
+	if *key == "" {
+		*key = DefaultSessionKey
+	}
+	if !sessionKeyRegex.MatchString(*key) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "sessionKey %q is not a valid attribute name", *key)
+	}
+	return nil
+}
+
+func quote(value string) string {
+	escaped := strings.ReplaceAll(value, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
+	return "'" + escaped + "'"
+}
@@ -0,0 +1,143 @@
+package aiobservabilitytypes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConversation(t *testing.T) {
+	at := func(seconds int) time.Time {
+		return time.Date(2026, time.June, 1, 12, 0, seconds, 0, time.UTC)
+	}
+	spans := []*ConversationSpan{
+		// out of order, as the turns are ordered by timestamp
+		{TraceID: "t1", SpanID: "llm2", ParentSpanID: "agent", Timestamp: at(5), DurationNano: uint64(time.Second), Model: "gpt-4o", InputTokens: 30, OutputTokens: 5, Cost: 0.02, HasError: true, StatusMessage: "rate limited"},
+		{TraceID: "t1", SpanID: "agent", Timestamp: at(0), DurationNano: uint64(10 * time.Second), Agent: "planner", SessionID: "s1"},
+		{TraceID: "t1", SpanID: "llm1", ParentSpanID: "agent", Timestamp: at(1), DurationNano: uint64(time.Second), Model: "gpt-4o", InputMessages: `[{"role":"user"}]`, InputTokens: 10, OutputTokens: 20, Cost: 0.01},
+		{TraceID: "t1", SpanID: "tool1", ParentSpanID: "agent", Timestamp: at(3), DurationNano: uint64(time.Second), Tool: "search"},
+		{TraceID: "t2", SpanID: "tool0", Timestamp: at(2), Tool: "fetch", HasError: true},
+	}
+
+	conversation := NewConversation(spans)
+
+	assert.Equal(t, []string{"t1", "t2"}, conversation.TraceIDs)
+	assert.Equal(t, "s1", conversation.SessionID)
+	assert.Equal(t, at(0), conversation.StartTime)
+	assert.Equal(t, at(10), conversation.EndTime)
+
+	require.Len(t, conversation.Turns, 2)
+	first, second := conversation.Turns[0], conversation.Turns[1]
+	assert.Equal(t, "llm1", first.SpanID)
+	assert.Equal(t, "planner", first.Agent)
+	assert.Equal(t, `[{"role":"user"}]`, first.InputMessages)
+	require.Len(t, first.ToolCalls, 1)
+	assert.Equal(t, "search", first.ToolCalls[0].Name)
+
+	assert.Equal(t, "llm2", second.SpanID)
+	assert.True(t, second.HasError)
+	assert.Equal(t, "rate limited", second.StatusMessage)
+	assert.Empty(t, second.ToolCalls)
+
+	// the tool call of a trace without turns stays on the conversation
+	require.Len(t, conversation.ToolCalls, 1)
+	assert.Equal(t, "fetch", conversation.ToolCalls[0].Name)
+
+	assert.Equal(t, 40.0, conversation.InputTokens)
+	assert.Equal(t, 25.0, conversation.OutputTokens)
+	assert.InDelta(t, 0.03, conversation.Cost, 1e-9)
+	assert.Equal(t, 2, conversation.ErrorCount)
+	assert.True(t, conversation.HasError)
+}
+
+func TestNewConversationMatches(t *testing.T) {
+	at := func(seconds int) time.Time {
+		return time.Date(2026, time.June, 1, 12, 0, seconds, 0, time.UTC)
+	}
+	// latest first, as the search lists them
+	spans := []*ConversationSpan{
+		{TraceID: "t2", Timestamp: at(9), OutputMessages: "the Refund was issued", SessionID: "s2"},
+		{TraceID: "t1", Timestamp: at(5), InputMessages: "where is my refund?"},
+		{TraceID: "t2", Timestamp: at(4), InputMessages: "refund please"},
+		{TraceID: "t3", Timestamp: at(1), InputMessages: "refund"},
+	}
+
+	matches := NewConversationMatches(spans, "refund", 2)
+
+	require.Len(t, matches, 2)
+	assert.Equal(t, "t2", matches[0].TraceID)
+	assert.Equal(t, "s2", matches[0].SessionID)
+	assert.Equal(t, 2, matches[0].MatchCount)
+	assert.Equal(t, at(4), matches[0].StartTime)
+	assert.Equal(t, at(9), matches[0].EndTime)
+	assert.Equal(t, "the Refund was issued", matches[0].Snippet)
+	assert.Equal(t, "t1", matches[1].TraceID)
+}
+
+func TestNewConversationMatches_Redacted(t *testing.T) {
+	spans := []*ConversationSpan{
+		{TraceID: "t1", InputMessages: "refund to jane@example.com"},
+		{TraceID: "t2", InputMessages: "mail jane@example.com"},
+	}
+
+	redactor, err := NewRedactor("patterns", []string{`[\w.]+@[\w.]+`})
+	require.NoError(t, err)
+	redactor.RedactSpans(spans)
+
+	matches := NewConversationMatches(spans, "jane", 10)
+	assert.Empty(t, matches)
+
+	matches = NewConversationMatches(spans, "refund", 10)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "t1", matches[0].TraceID)
+	assert.Equal(t, "refund to [REDACTED]", matches[0].Snippet)
+}
+
+func TestSnippet(t *testing.T) {
+	body := "é" + strings.Repeat("a", 100) + "needle" + strings.Repeat("b", 100)
+
+	out := snippet(body, "NEEDLE")
+	assert.Equal(t, "…"+strings.Repeat("a", snippetRadius)+"needle"+strings.Repeat("b", snippetRadius)+"…", out)
+	assert.Empty(t, snippet(body, "missing"))
+}
+
+func TestGetConversationParams(t *testing.T) {
+	params := &GetConversationParams{SessionID: "it's", StartUnixMilli: 1, EndUnixMilli: 2}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, DefaultSessionKey, params.SessionKey)
+	assert.Equal(t, `gen_ai.conversation.id = 'it\'s'`, params.FilterExpression())
+
+	params = &GetConversationParams{TraceID: "abc", StartUnixMilli: 1, EndUnixMilli: 2}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, "trace_id = 'abc'", params.FilterExpression())
+
+	for _, params := range []*GetConversationParams{
+		{StartUnixMilli: 1, EndUnixMilli: 2},
+		{TraceID: "abc", SessionID: "s1", StartUnixMilli: 1, EndUnixMilli: 2},
+		{TraceID: "abc", StartUnixMilli: 2, EndUnixMilli: 1},
+		{SessionID: "s1", SessionKey: "x = 'y' OR z", StartUnixMilli: 1, EndUnixMilli: 2},
+	} {
+		assert.True(t, errors.Ast(params.Validate(), errors.TypeInvalidInput))
+	}
+}
+
+func TestPostableConversationSearch(t *testing.T) {
+	req := &PostableConversationSearch{Start: 1, End: 2, Text: "refund", Filter: &qbtypes.Filter{Expression: "gen_ai.request.model = 'gpt-4o'"}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, DefaultSearchLimit, req.Limit)
+	assert.Equal(t, "(gen_ai.request.model = 'gpt-4o') AND (gen_ai.input.messages CONTAINS 'refund' OR gen_ai.output.messages CONTAINS 'refund')", req.FilterExpression())
+
+	for _, req := range []*PostableConversationSearch{
+		{Start: 1, End: 2, Text: " "},
+		{Start: 2, End: 1, Text: "refund"},
+		{Start: 1, End: 2, Text: "refund", Limit: MaxSearchLimit + 1},
+		{Start: 1, End: 2, Text: strings.Repeat("a", MaxSearchTextLength+1)},
+	} {
+		assert.True(t, errors.Ast(req.Validate(), errors.TypeInvalidInput))
+	}
+}
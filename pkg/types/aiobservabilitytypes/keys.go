@@ -9,6 +9,8 @@ const (
 	GenAIAgentName     = "gen_ai.agent.name"
 	GenAIProviderName  = "gen_ai.provider.name"
 
+	GenAIConversationID = "gen_ai.conversation.id"
+
 	GenAIUsageInputTokens              = "gen_ai.usage.input_tokens"
 	GenAIUsageOutputTokens             = "gen_ai.usage.output_tokens"
 	GenAIUsageCacheReadInputTokens     = "gen_ai.usage.cache_read.input_tokens"
@@ -0,0 +1,107 @@
+package aiobservabilitytypes
+
+import (
+	"regexp"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	// RedactionNone returns the message bodies as they are.
+	RedactionNone = Redaction{valuer.NewString("none")}
+	// RedactionFull drops the message bodies.
+	RedactionFull = Redaction{valuer.NewString("full")}
+	// RedactionPatterns replaces the matches of the configured patterns in the message
+	// bodies.
+	RedactionPatterns = Redaction{valuer.NewString("patterns")}
+)
+
+// redactedText replaces the matches of the patterns.
+const redactedText = "[REDACTED]"
+
+// Redaction is how the message bodies of the gen_ai spans are redacted for the roles
+// below admin.
+type Redaction struct{ valuer.String }
+
+// Redactor redacts the message bodies of the conversations.
+type Redactor struct {
+	redaction Redaction
+	patterns  []*regexp.Regexp
+}
+
+// NewRedactor returns the redactor of the redaction mode, none when unset. The patterns
+// are the regular expressions redacted in the patterns mode, of which there must be at
+// least one.
+func NewRedactor(redaction string, patterns []string) (*Redactor, error) {
+	redactor := &Redactor{}
+	switch redaction {
+	case "", RedactionNone.StringValue():
+		redactor.redaction = RedactionNone
+	case RedactionFull.StringValue():
+		redactor.redaction = RedactionFull
+	case RedactionPatterns.StringValue():
+		redactor.redaction = RedactionPatterns
+		if len(patterns) == 0 {
+			return nil, errors.New(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "at least one pattern is required by the patterns redaction")
+		}
+	default:
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "redaction must be one of none, full or patterns, got %q", redaction)
+	}
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeConversationInvalidInput, "pattern %q is not a valid regular expression", pattern)
+		}
+		redactor.patterns = append(redactor.patterns, re)
+	}
+	return redactor, nil
+}
+
+// MustNewRedactor is NewRedactor for a validated configuration.
+func MustNewRedactor(redaction string, patterns []string) *Redactor {
+	redactor, err := NewRedactor(redaction, patterns)
+	if err != nil {
+		panic(err)
+	}
+	return redactor
+}
+
+// Enabled reports whether the redactor changes the message bodies.
+func (r *Redactor) Enabled() bool {
+	return r.redaction != RedactionNone
+}
+
+// Redact redacts the message bodies of the turns of the conversation.
+func (r *Redactor) Redact(conversation *Conversation) {
+	if !r.Enabled() {
+		return
+	}
+	for _, turn := range conversation.Turns {
+		turn.InputMessages = r.redact(turn.InputMessages)
+		turn.OutputMessages = r.redact(turn.OutputMessages)
+	}
+	conversation.Redacted = true
+}
+
+// RedactSpans redacts the message bodies of the spans.
+func (r *Redactor) RedactSpans(spans []*ConversationSpan) {
+	if !r.Enabled() {
+		return
+	}
+	for _, span := range spans {
+		span.InputMessages = r.redact(span.InputMessages)
+		span.OutputMessages = r.redact(span.OutputMessages)
+	}
+}
+
+func (r *Redactor) redact(body string) string {
+	if r.redaction == RedactionFull {
+		return ""
+	}
+	for _, re := range r.patterns {
+		body = re.ReplaceAllString(body, redactedText)
+	}
+	return body
+}
@@ -0,0 +1,54 @@
+package aiobservabilitytypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor(t *testing.T) {
+	newConversation := func() *Conversation {
+		return &Conversation{Turns: []*ConversationTurn{
+			{InputMessages: "mail me at jane@example.com", OutputMessages: "sure, jane@example.com"},
+		}}
+	}
+
+	none, err := NewRedactor("none", nil)
+	require.NoError(t, err)
+	conversation := newConversation()
+	none.Redact(conversation)
+	assert.False(t, conversation.Redacted)
+	assert.Equal(t, "mail me at jane@example.com", conversation.Turns[0].InputMessages)
+
+	full, err := NewRedactor("full", nil)
+	require.NoError(t, err)
+	conversation = newConversation()
+	full.Redact(conversation)
+	assert.True(t, conversation.Redacted)
+	assert.Empty(t, conversation.Turns[0].InputMessages)
+	assert.Empty(t, conversation.Turns[0].OutputMessages)
+
+	patterns, err := NewRedactor("patterns", []string{`[\w.]+@[\w.]+`})
+	require.NoError(t, err)
+	conversation = newConversation()
+	patterns.Redact(conversation)
+	assert.True(t, conversation.Redacted)
+	assert.Equal(t, "mail me at [REDACTED]", conversation.Turns[0].InputMessages)
+	assert.Equal(t, "sure, [REDACTED]", conversation.Turns[0].OutputMessages)
+}
+
+func TestNewRedactor_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		redaction string
+		patterns  []string
+	}{
+		{redaction: "partial"},
+		{redaction: "patterns"},
+		{redaction: "patterns", patterns: []string{"("}},
+	} {
+		_, err := NewRedactor(tc.redaction, tc.patterns)
+		assert.True(t, errors.Ast(err, errors.TypeInvalidInput), tc.redaction)
+	}
+}
@@ -26,18 +26,24 @@ type Account struct {
 type AgentReport struct {
 	TimestampMillis int64          `json:"timestampMillis" required:"true"`
 	Data            map[string]any `json:"data" required:"true" nullable:"true"`
+	// ConfigVersion is the version of the integration config the agent runs, empty for agents not reporting it.
+	ConfigVersion string `json:"configVersion" required:"true"`
 }
 
 type AccountConfig struct {
-	AWS   *AWSAccountConfig   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure *AzureAccountConfig `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP   *GCPAccountConfig   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *AWSAccountConfig          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *AzureAccountConfig        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *GCPAccountConfig          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *OCIAccountConfig          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *DigitalOceanAccountConfig `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 type UpdatableAccountConfig struct {
-	AWS   *UpdatableAWSAccountConfig   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure *UpdatableAzureAccountConfig `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP   *UpdatableGCPAccountConfig   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *UpdatableAWSAccountConfig          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *UpdatableAzureAccountConfig        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *UpdatableGCPAccountConfig          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *UpdatableOCIAccountConfig          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *UpdatableDigitalOceanAccountConfig `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 type PostableAccount struct {
@@ -48,9 +54,11 @@ type PostableAccount struct {
 type PostableAccountConfig struct {
 	// as agent version is common for all providers, we can keep it at top level of this struct
 	AgentVersion string
-	AWS          *AWSPostableAccountConfig   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure        *AzurePostableAccountConfig `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP          *GCPPostableAccountConfig   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *AWSPostableAccountConfig          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *AzurePostableAccountConfig        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *GCPPostableAccountConfig          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *OCIPostableAccountConfig          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *DigitalOceanPostableAccountConfig `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 type Credentials struct {
@@ -67,9 +75,11 @@ type GettableAccountWithConnectionArtifact struct {
 
 type ConnectionArtifact struct {
 	// required till new providers are added
-	AWS   *AWSConnectionArtifact   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure *AzureConnectionArtifact `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP   *GCPConnectionArtifact   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *AWSConnectionArtifact          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *AzureConnectionArtifact        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *GCPConnectionArtifact          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *OCIConnectionArtifact          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *DigitalOceanConnectionArtifact `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 type GetConnectionArtifactRequest = PostableAccount
@@ -144,12 +154,27 @@ func NewAccountFromStorable(storableAccount *StorableCloudIntegration) (*Account
 			return nil, err
 		}
 		account.Config.GCP = gcpConfig
+	case CloudProviderTypeOCI:
+		ociConfig := new(OCIAccountConfig)
+		err := json.Unmarshal([]byte(storableAccount.Config), ociConfig)
+		if err != nil {
+			return nil, err
+		}
+		account.Config.OCI = ociConfig
+	case CloudProviderTypeDigitalOcean:
+		digitalOceanConfig := new(DigitalOceanAccountConfig)
+		err := json.Unmarshal([]byte(storableAccount.Config), digitalOceanConfig)
+		if err != nil {
+			return nil, err
+		}
+		account.Config.DigitalOcean = digitalOceanConfig
 	}
 
 	if storableAccount.LastAgentReport != nil {
 		account.AgentReport = &AgentReport{
 			TimestampMillis: storableAccount.LastAgentReport.TimestampMillis,
 			Data:            storableAccount.LastAgentReport.Data,
+			ConfigVersion:   storableAccount.LastAgentReport.ConfigVersion,
 		}
 	}
 
@@ -246,6 +271,55 @@ func NewAccountConfigFromPostable(provider CloudProviderType, config *PostableAc
 				DeploymentRegion:    config.GCP.DeploymentRegion,
 			},
 		}, nil
+	case CloudProviderTypeOCI:
+		if config.OCI == nil {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "OCI config can not be nil for OCI provider")
+		}
+
+		if err := validateOCIRegion(config.OCI.DeploymentRegion); err != nil {
+			return nil, err
+		}
+
+		if err := validateOCICompartmentID(config.OCI.DeploymentCompartmentID); err != nil {
+			return nil, err
+		}
+
+		if err := validateOCICompartmentIDs(config.OCI.CompartmentIDs); err != nil {
+			return nil, err
+		}
+
+		return &AccountConfig{
+			OCI: &OCIAccountConfig{
+				DeploymentRegion:        config.OCI.DeploymentRegion,
+				DeploymentCompartmentID: config.OCI.DeploymentCompartmentID,
+				CompartmentIDs:          config.OCI.CompartmentIDs,
+			},
+		}, nil
+	case CloudProviderTypeDigitalOcean:
+		if config.DigitalOcean == nil {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "DigitalOcean config can not be nil for DigitalOcean provider")
+		}
+
+		if err := validateDigitalOceanRegion(config.DigitalOcean.DeploymentRegion); err != nil {
+			return nil, err
+		}
+
+		if len(config.DigitalOcean.Regions) == 0 {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "at least one region is required for DigitalOcean provider")
+		}
+
+		for _, region := range config.DigitalOcean.Regions {
+			if err := validateDigitalOceanRegion(region); err != nil {
+				return nil, err
+			}
+		}
+
+		return &AccountConfig{
+			DigitalOcean: &DigitalOceanAccountConfig{
+				DeploymentRegion: config.DigitalOcean.DeploymentRegion,
+				Regions:          config.DigitalOcean.Regions,
+			},
+		}, nil
 	default:
 		return nil, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
 	}
@@ -303,15 +377,42 @@ func NewAccountConfigFromUpdatable(provider CloudProviderType, config *Updatable
 				DeploymentRegion:    config.Config.GCP.DeploymentRegion,
 			},
 		}, nil
+	case CloudProviderTypeOCI:
+		if config.Config.OCI == nil {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "OCI config can not be nil for OCI provider")
+		}
+
+		if err := validateOCICompartmentIDs(config.Config.OCI.CompartmentIDs); err != nil {
+			return nil, err
+		}
+
+		return &AccountConfig{OCI: &OCIAccountConfig{CompartmentIDs: config.Config.OCI.CompartmentIDs}}, nil
+	case CloudProviderTypeDigitalOcean:
+		if config.Config.DigitalOcean == nil {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "DigitalOcean config can not be nil for DigitalOcean provider")
+		}
+
+		if len(config.Config.DigitalOcean.Regions) == 0 {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "at least one region is required for DigitalOcean provider")
+		}
+
+		for _, region := range config.Config.DigitalOcean.Regions {
+			if err := validateDigitalOceanRegion(region); err != nil {
+				return nil, err
+			}
+		}
+
+		return &AccountConfig{DigitalOcean: &DigitalOceanAccountConfig{Regions: config.Config.DigitalOcean.Regions}}, nil
 	default:
 		return nil, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
 	}
 }
 
-func NewAgentReport(data map[string]any) *AgentReport {
+func NewAgentReport(data map[string]any, configVersion string) *AgentReport {
 	return &AgentReport{
 		TimestampMillis: time.Now().UnixMilli(),
 		Data:            data,
+		ConfigVersion:   configVersion,
 	}
 }
 
@@ -324,9 +425,15 @@ func GetSigNozAPIURLFromDeployment(deployment *zeustypes.GettableDeployment) (st
 }
 
 func (account *Account) Update(provider CloudProviderType, config *AccountConfig) error {
-	// deployment region can not be updated once set for Azure
-	if provider == CloudProviderTypeAzure {
+	// deployment region can not be updated once set for Azure, OCI and DigitalOcean
+	switch provider {
+	case CloudProviderTypeAzure:
 		config.Azure.DeploymentRegion = account.Config.Azure.DeploymentRegion
+	case CloudProviderTypeOCI:
+		config.OCI.DeploymentRegion = account.Config.OCI.DeploymentRegion
+		config.OCI.DeploymentCompartmentID = account.Config.OCI.DeploymentCompartmentID
+	case CloudProviderTypeDigitalOcean:
+		config.DigitalOcean.DeploymentRegion = account.Config.DigitalOcean.DeploymentRegion
 	}
 
 	account.Config = config
@@ -398,6 +505,10 @@ func (config *AccountConfig) ToJSON() ([]byte, error) {
 		return json.Marshal(config.Azure)
 	case config.GCP != nil:
 		return json.Marshal(config.GCP)
+	case config.OCI != nil:
+		return json.Marshal(config.OCI)
+	case config.DigitalOcean != nil:
+		return json.Marshal(config.DigitalOcean)
 	default:
 		return nil, errors.NewInternalf(errors.CodeInternal, "no provider account config found")
 	}
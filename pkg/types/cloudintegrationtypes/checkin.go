@@ -11,6 +11,8 @@ import (
 type AgentCheckInRequest struct {
 	ProviderAccountID  string      `json:"providerAccountId" required:"false"`
 	CloudIntegrationID valuer.UUID `json:"cloudIntegrationId" required:"false"`
+	// ConfigVersion is the version of the integration config the agent runs, as returned on its last check-in.
+	ConfigVersion string `json:"configVersion" required:"false"`
 
 	Data map[string]any `json:"data" required:"true" nullable:"true"`
 }
@@ -27,6 +29,7 @@ type AgentCheckInResponse struct {
 	CloudIntegrationID string                     `json:"cloudIntegrationId" required:"true"`
 	ProviderAccountID  string                     `json:"providerAccountId" required:"true"`
 	IntegrationConfig  *ProviderIntegrationConfig `json:"integrationConfig" required:"true"`
+	ConfigVersion      string                     `json:"configVersion" required:"true"`
 	RemovedAt          *time.Time                 `json:"removedAt" required:"true" nullable:"true"`
 }
 
@@ -48,9 +51,11 @@ type IntegrationConfig struct {
 }
 
 type ProviderIntegrationConfig struct {
-	AWS   *AWSIntegrationConfig   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure *AzureIntegrationConfig `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP   *GCPIntegrationConfig   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *AWSIntegrationConfig          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *AzureIntegrationConfig        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *GCPIntegrationConfig          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *OCIIntegrationConfig          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *DigitalOceanIntegrationConfig `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 // NewGettableAgentCheckIn constructs a backward-compatible response from an AgentCheckInResponse.
@@ -73,15 +78,35 @@ func NewGettableAgentCheckIn(provider CloudProviderType, resp *AgentCheckInRespo
 	return gettable
 }
 
-func NewAgentCheckInResponse(providerAccountID, cloudIntegrationID string, integrationConfig *ProviderIntegrationConfig, removedAt *time.Time) *AgentCheckInResponse {
+func NewAgentCheckInResponse(providerAccountID, cloudIntegrationID string, integrationConfig *ProviderIntegrationConfig, configVersion string, removedAt *time.Time) *AgentCheckInResponse {
 	return &AgentCheckInResponse{
 		CloudIntegrationID: cloudIntegrationID,
 		ProviderAccountID:  providerAccountID,
 		IntegrationConfig:  integrationConfig,
+		ConfigVersion:      configVersion,
 		RemovedAt:          removedAt,
 	}
 }
 
+// Validate validates the provider account id the agent checks in with. OCI agents check in with the
+// tenancy OCID and DigitalOcean agents with the team UUID.
+func (req *AgentCheckInRequest) Validate(provider CloudProviderType) error {
+	if req.ProviderAccountID == "" {
+		return nil
+	}
+
+	switch provider {
+	case CloudProviderTypeOCI:
+		return validateOCIID("tenancy", req.ProviderAccountID)
+	case CloudProviderTypeDigitalOcean:
+		if _, err := valuer.NewUUID(req.ProviderAccountID); err != nil {
+			return errors.NewInvalidInputf(ErrCodeInvalidInput, "invalid DigitalOcean team id: %q", req.ProviderAccountID)
+		}
+	}
+
+	return nil
+}
+
 func (postable *PostableAgentCheckIn) UnmarshalJSON(data []byte) error {
 	type Alias PostableAgentCheckIn
 
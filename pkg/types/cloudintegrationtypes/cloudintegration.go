@@ -45,6 +45,7 @@ type StorableCloudIntegration struct {
 type StorableAgentReport struct {
 	TimestampMillis int64          `json:"timestamp_millis"` // backward compatibility
 	Data            map[string]any `json:"data"`
+	ConfigVersion   string         `json:"config_version,omitempty"`
 }
 
 // StorableCloudIntegrationService is to store service config for a cloud integration, which is a cloud provider specific configuration.
@@ -61,9 +62,11 @@ type StorableCloudIntegrationService struct {
 // Following Service config types are only internally used to store service config in DB and use JSON snake case keys for backward compatibility.
 
 type StorableServiceConfig struct {
-	AWS   *StorableAWSServiceConfig
-	Azure *StorableAzureServiceConfig
-	GCP   *StorableGCPServiceConfig
+	AWS          *StorableAWSServiceConfig
+	Azure        *StorableAzureServiceConfig
+	GCP          *StorableGCPServiceConfig
+	OCI          *StorableOCIServiceConfig
+	DigitalOcean *StorableDigitalOceanServiceConfig
 }
 
 type StorableAWSServiceConfig struct {
@@ -102,6 +105,24 @@ type StorableGCPServiceLogsConfig = GCPServiceLogsConfig
 
 type StorableGCPServiceMetricsConfig = GCPServiceMetricsConfig
 
+type StorableOCIServiceConfig struct {
+	Logs    *StorableOCIServiceLogsConfig    `json:"logs,omitempty"`
+	Metrics *StorableOCIServiceMetricsConfig `json:"metrics,omitempty"`
+}
+
+type StorableOCIServiceLogsConfig = OCIServiceLogsConfig
+
+type StorableOCIServiceMetricsConfig = OCIServiceMetricsConfig
+
+type StorableDigitalOceanServiceConfig struct {
+	Logs    *StorableDigitalOceanServiceLogsConfig    `json:"logs,omitempty"`
+	Metrics *StorableDigitalOceanServiceMetricsConfig `json:"metrics,omitempty"`
+}
+
+type StorableDigitalOceanServiceLogsConfig = DigitalOceanServiceLogsConfig
+
+type StorableDigitalOceanServiceMetricsConfig = DigitalOceanServiceMetricsConfig
+
 // Scan scans value from DB.
 func (r *StorableAgentReport) Scan(src any) error {
 	var data []byte
@@ -148,6 +169,7 @@ func NewStorableCloudIntegration(account *Account) (*StorableCloudIntegration, e
 		storableAccount.LastAgentReport = &StorableAgentReport{
 			TimestampMillis: account.AgentReport.TimestampMillis,
 			Data:            account.AgentReport.Data,
+			ConfigVersion:   account.AgentReport.ConfigVersion,
 		}
 	}
 
@@ -172,6 +194,7 @@ func (account *StorableCloudIntegration) Update(providerAccountID *string, agent
 		account.LastAgentReport = &StorableAgentReport{
 			TimestampMillis: agentReport.TimestampMillis,
 			Data:            agentReport.Data,
+			ConfigVersion:   agentReport.ConfigVersion,
 		}
 	}
 }
@@ -259,6 +282,54 @@ func newStorableServiceConfig(provider CloudProviderType, serviceID ServiceID, s
 		}
 
 		return &StorableServiceConfig{GCP: storableGCPServiceConfig}, nil
+	case CloudProviderTypeOCI:
+		storableOCIServiceConfig := new(StorableOCIServiceConfig)
+
+		if supportedSignals.Logs {
+			if serviceConfig.OCI.Logs == nil {
+				return nil, errors.NewInvalidInputf(ErrCodeCloudIntegrationInvalidConfig, "logs config is required for OCI service: %s", serviceID.StringValue())
+			}
+
+			storableOCIServiceConfig.Logs = &StorableOCIServiceLogsConfig{
+				Enabled: serviceConfig.OCI.Logs.Enabled,
+			}
+		}
+
+		if supportedSignals.Metrics {
+			if serviceConfig.OCI.Metrics == nil {
+				return nil, errors.NewInvalidInputf(ErrCodeCloudIntegrationInvalidConfig, "metrics config is required for OCI service: %s", serviceID.StringValue())
+			}
+
+			storableOCIServiceConfig.Metrics = &StorableOCIServiceMetricsConfig{
+				Enabled: serviceConfig.OCI.Metrics.Enabled,
+			}
+		}
+
+		return &StorableServiceConfig{OCI: storableOCIServiceConfig}, nil
+	case CloudProviderTypeDigitalOcean:
+		storableDigitalOceanServiceConfig := new(StorableDigitalOceanServiceConfig)
+
+		if supportedSignals.Logs {
+			if serviceConfig.DigitalOcean.Logs == nil {
+				return nil, errors.NewInvalidInputf(ErrCodeCloudIntegrationInvalidConfig, "logs config is required for DigitalOcean service: %s", serviceID.StringValue())
+			}
+
+			storableDigitalOceanServiceConfig.Logs = &StorableDigitalOceanServiceLogsConfig{
+				Enabled: serviceConfig.DigitalOcean.Logs.Enabled,
+			}
+		}
+
+		if supportedSignals.Metrics {
+			if serviceConfig.DigitalOcean.Metrics == nil {
+				return nil, errors.NewInvalidInputf(ErrCodeCloudIntegrationInvalidConfig, "metrics config is required for DigitalOcean service: %s", serviceID.StringValue())
+			}
+
+			storableDigitalOceanServiceConfig.Metrics = &StorableDigitalOceanServiceMetricsConfig{
+				Enabled: serviceConfig.DigitalOcean.Metrics.Enabled,
+			}
+		}
+
+		return &StorableServiceConfig{DigitalOcean: storableDigitalOceanServiceConfig}, nil
 	default:
 		return nil, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
 	}
@@ -287,6 +358,20 @@ func newStorableServiceConfigFromJSON(provider CloudProviderType, jsonStr string
 			return nil, errors.WrapInternalf(err, errors.CodeInternal, "couldn't parse GCP service config JSON")
 		}
 		return &StorableServiceConfig{GCP: gcpConfig}, nil
+	case CloudProviderTypeOCI:
+		ociConfig := new(StorableOCIServiceConfig)
+		err := json.Unmarshal([]byte(jsonStr), ociConfig)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "couldn't parse OCI service config JSON")
+		}
+		return &StorableServiceConfig{OCI: ociConfig}, nil
+	case CloudProviderTypeDigitalOcean:
+		digitalOceanConfig := new(StorableDigitalOceanServiceConfig)
+		err := json.Unmarshal([]byte(jsonStr), digitalOceanConfig)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "couldn't parse DigitalOcean service config JSON")
+		}
+		return &StorableServiceConfig{DigitalOcean: digitalOceanConfig}, nil
 	default:
 		return nil, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
 	}
@@ -314,6 +399,20 @@ func (config *StorableServiceConfig) toJSON(provider CloudProviderType) ([]byte,
 			return nil, errors.WrapInternalf(err, errors.CodeInternal, "couldn't serialize GCP service config to JSON")
 		}
 
+		return jsonBytes, nil
+	case CloudProviderTypeOCI:
+		jsonBytes, err := json.Marshal(config.OCI)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "couldn't serialize OCI service config to JSON")
+		}
+
+		return jsonBytes, nil
+	case CloudProviderTypeDigitalOcean:
+		jsonBytes, err := json.Marshal(config.DigitalOcean)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "couldn't serialize DigitalOcean service config to JSON")
+		}
+
 		return jsonBytes, nil
 	default:
 		return nil, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
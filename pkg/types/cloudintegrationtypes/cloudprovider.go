@@ -9,9 +9,11 @@ type CloudProviderType struct{ valuer.String }
 
 var (
 	// cloud providers.
-	CloudProviderTypeAWS   = CloudProviderType{valuer.NewString("aws")}
-	CloudProviderTypeAzure = CloudProviderType{valuer.NewString("azure")}
-	CloudProviderTypeGCP   = CloudProviderType{valuer.NewString("gcp")}
+	CloudProviderTypeAWS          = CloudProviderType{valuer.NewString("aws")}
+	CloudProviderTypeAzure        = CloudProviderType{valuer.NewString("azure")}
+	CloudProviderTypeGCP          = CloudProviderType{valuer.NewString("gcp")}
+	CloudProviderTypeOCI          = CloudProviderType{valuer.NewString("oci")}
+	CloudProviderTypeDigitalOcean = CloudProviderType{valuer.NewString("digitalocean")}
 
 	ErrCodeCloudProviderInvalidInput = errors.MustNewCode("cloud_integration_invalid_cloud_provider")
 )
@@ -24,6 +26,10 @@ func NewCloudProvider(provider string) (CloudProviderType, error) {
 		return CloudProviderTypeAzure, nil
 	case CloudProviderTypeGCP.StringValue():
 		return CloudProviderTypeGCP, nil
+	case CloudProviderTypeOCI.StringValue():
+		return CloudProviderTypeOCI, nil
+	case CloudProviderTypeDigitalOcean.StringValue():
+		return CloudProviderTypeDigitalOcean, nil
 	default:
 		return CloudProviderType{}, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider)
 	}
@@ -0,0 +1,199 @@
+package cloudintegrationtypes
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	AgentDigitalOceanAppName       = "signoz-integration"
+	AgentDigitalOceanImageRegistry = "signoz"
+	AgentDigitalOceanImageRepo     = "signoz-integration-agent"
+
+	// App Platform env var names read by the agent.
+	doEnvSignozAPIKey       = "SIGNOZ_API_KEY"
+	doEnvSignozAPIUrl       = "SIGNOZ_API_URL"
+	doEnvSignozIngestionURL = "SIGNOZ_INGESTION_URL"
+	doEnvSignozIngestionKey = "SIGNOZ_INGESTION_KEY"
+	doEnvAccountID          = "SIGNOZ_INTEGRATION_ACCOUNT_ID"
+	doEnvAgentVersion       = "SIGNOZ_INTEGRATION_AGENT_VERSION"
+	// the agent reads the monitoring and logs APIs with the token doctl runs with.
+	doEnvAccessToken = "DIGITALOCEAN_ACCESS_TOKEN"
+
+	digitalOceanCLITemplate = template.Must(template.New("digitalOceanCLI").Parse(digitalOceanCLITemplateStr()))
+)
+
+type DigitalOceanAccountConfig struct {
+	// Datacenter region where the agent app is deployed
+	DeploymentRegion string `json:"deploymentRegion" required:"true"`
+	// Datacenter regions to monitor
+	Regions []string `json:"regions" required:"true" nullable:"false"`
+}
+
+type DigitalOceanPostableAccountConfig = DigitalOceanAccountConfig
+
+type UpdatableDigitalOceanAccountConfig struct {
+	// Datacenter regions to monitor
+	Regions []string `json:"regions" required:"true" nullable:"false"`
+}
+
+type DigitalOceanConnectionArtifact struct {
+	CLICommand string `json:"cliCommand" required:"true"`
+}
+
+type DigitalOceanServiceConfig struct {
+	Logs    *DigitalOceanServiceLogsConfig    `json:"logs,omitempty" required:"false"`
+	Metrics *DigitalOceanServiceMetricsConfig `json:"metrics,omitempty" required:"false"`
+}
+
+type DigitalOceanServiceLogsConfig struct {
+	Enabled bool `json:"enabled" required:"true"`
+}
+
+type DigitalOceanServiceMetricsConfig struct {
+	Enabled bool `json:"enabled" required:"true"`
+}
+
+type DigitalOceanTelemetryCollectionStrategy struct {
+	// Resource type as named by the monitoring API, https://docs.digitalocean.com/reference/api/api-reference/#tag/Monitoring
+	ResourceType string                                 `json:"resourceType" required:"true"`
+	Metrics      *DigitalOceanMetricsCollectionStrategy `json:"metrics,omitempty" required:"false" nullable:"false"`
+	Logs         *DigitalOceanLogsCollectionStrategy    `json:"logs,omitempty" required:"false" nullable:"false"`
+}
+
+// DigitalOceanMetricsCollectionStrategy no additional config required for metrics, the agent
+// reads every metric the monitoring API has for the resource type.
+type DigitalOceanMetricsCollectionStrategy struct{}
+
+type DigitalOceanLogsCollectionStrategy struct {
+	// How the resources forward their logs to the agent: log_sink for the managed databases,
+	// log_destination for the apps.
+	Forwarding string `json:"forwarding" required:"true"`
+}
+
+type DigitalOceanIntegrationConfig struct {
+	DeploymentRegion            string                                     `json:"deploymentRegion" required:"true"`
+	Regions                     []string                                   `json:"regions" required:"true" nullable:"false"`
+	TelemetryCollectionStrategy []*DigitalOceanTelemetryCollectionStrategy `json:"telemetryCollectionStrategy" required:"true" nullable:"false"`
+}
+
+// digitalOceanTemplateData is the data struct passed to the CLI command template.
+type digitalOceanTemplateData struct {
+	AppName            string
+	AppRegion          string
+	ImageRegistry      string
+	ImageRepository    string
+	AgentVersion       string
+	SignozAPIKey       string
+	SignozAPIUrl       string
+	SignozIngestionURL string
+	SignozIngestionKey string
+	AccountID          string
+	// App Platform env var names (from package-level vars).
+	EnvSignozAPIKey       string
+	EnvSignozAPIUrl       string
+	EnvSignozIngestionURL string
+	EnvSignozIngestionKey string
+	EnvAccountID          string
+	EnvAgentVersion       string
+	EnvAccessToken        string
+}
+
+func NewDigitalOceanIntegrationConfig(
+	deploymentRegion string,
+	regions []string,
+	strategies []*DigitalOceanTelemetryCollectionStrategy,
+) *DigitalOceanIntegrationConfig {
+	return &DigitalOceanIntegrationConfig{
+		DeploymentRegion:            deploymentRegion,
+		Regions:                     regions,
+		TelemetryCollectionStrategy: strategies,
+	}
+}
+
+func NewDigitalOceanConnectionArtifact(
+	accountID valuer.UUID,
+	agentVersion string,
+	creds *Credentials,
+	cfg *DigitalOceanPostableAccountConfig,
+) (*DigitalOceanConnectionArtifact, error) {
+	cliCommand, err := newDigitalOceanConnectionCLICommand(digitalOceanTemplateData{
+		AppName:               AgentDigitalOceanAppName,
+		AppRegion:             digitalOceanAppRegion(cfg.DeploymentRegion),
+		ImageRegistry:         AgentDigitalOceanImageRegistry,
+		ImageRepository:       AgentDigitalOceanImageRepo,
+		AgentVersion:          agentVersion,
+		SignozAPIKey:          creds.SigNozAPIKey,
+		SignozAPIUrl:          creds.SigNozAPIURL,
+		SignozIngestionURL:    creds.IngestionURL,
+		SignozIngestionKey:    creds.IngestionKey,
+		AccountID:             accountID.StringValue(),
+		EnvSignozAPIKey:       doEnvSignozAPIKey,
+		EnvSignozAPIUrl:       doEnvSignozAPIUrl,
+		EnvSignozIngestionURL: doEnvSignozIngestionURL,
+		EnvSignozIngestionKey: doEnvSignozIngestionKey,
+		EnvAccountID:          doEnvAccountID,
+		EnvAgentVersion:       doEnvAgentVersion,
+		EnvAccessToken:        doEnvAccessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DigitalOceanConnectionArtifact{
+		CLICommand: cliCommand,
+	}, nil
+}
+
+func newDigitalOceanConnectionCLICommand(data digitalOceanTemplateData) (string, error) {
+	var buf bytes.Buffer
+	err := digitalOceanCLITemplate.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// digitalOceanAppRegion returns the App Platform region of a datacenter region, e.g. nyc for nyc3.
+func digitalOceanAppRegion(region string) string {
+	return strings.TrimRight(region, "0123456789")
+}
+
+// digitalOceanCLITemplateStr deploys the agent as an App Platform worker. The heredoc is not quoted
+// so that the shell expands the access token doctl is authenticated with.
+func digitalOceanCLITemplateStr() string {
+	return `doctl apps create --upsert --spec - <<EOF
+name: {{.AppName}}
+region: {{.AppRegion}}
+workers:
+  - name: {{.AppName}}-agent
+    instance_count: 1
+    instance_size_slug: apps-s-1vcpu-0.5gb
+    image:
+      registry_type: GHCR
+      registry: {{.ImageRegistry}}
+      repository: {{.ImageRepository}}
+      tag: {{.AgentVersion}}
+    envs:
+      - key: {{.EnvSignozAPIUrl}}
+        value: '{{.SignozAPIUrl}}'
+      - key: {{.EnvSignozAPIKey}}
+        value: '{{.SignozAPIKey}}'
+        type: SECRET
+      - key: {{.EnvSignozIngestionURL}}
+        value: '{{.SignozIngestionURL}}'
+      - key: {{.EnvSignozIngestionKey}}
+        value: '{{.SignozIngestionKey}}'
+        type: SECRET
+      - key: {{.EnvAccountID}}
+        value: '{{.AccountID}}'
+      - key: {{.EnvAgentVersion}}
+        value: '{{.AgentVersion}}'
+      - key: {{.EnvAccessToken}}
+        value: '${{.EnvAccessToken}}'
+        type: SECRET
+EOF`
+}
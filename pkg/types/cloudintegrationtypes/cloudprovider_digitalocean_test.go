@@ -0,0 +1,63 @@
+package cloudintegrationtypes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func TestNewDigitalOceanConnectionArtifact(t *testing.T) {
+	accountID := valuer.GenerateUUID()
+	agentVersion := "v0.1.0"
+	creds := &Credentials{
+		SigNozAPIURL: "https://signoz.example.com",
+		SigNozAPIKey: "test-api-key",
+		IngestionURL: "https://ingest.example.com",
+		IngestionKey: "test-ingest-key",
+	}
+	cfg := &DigitalOceanPostableAccountConfig{
+		DeploymentRegion: "nyc3",
+		Regions:          []string{"nyc3", "sfo3"},
+	}
+
+	artifact, err := NewDigitalOceanConnectionArtifact(accountID, agentVersion, creds, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mustContain := []string{
+		"doctl apps create --upsert --spec -",
+		fmt.Sprintf("name: %s", AgentDigitalOceanAppName),
+		"region: nyc\n",
+		fmt.Sprintf("repository: %s", AgentDigitalOceanImageRepo),
+		fmt.Sprintf("tag: %s", agentVersion),
+		fmt.Sprintf("value: '%s'", accountID.StringValue()),
+		fmt.Sprintf("value: '%s'", creds.SigNozAPIKey),
+		fmt.Sprintf("value: '%s'", creds.IngestionKey),
+		fmt.Sprintf("value: '$%s'", doEnvAccessToken),
+	}
+
+	for _, fragment := range mustContain {
+		if !strings.Contains(artifact.CLICommand, fragment) {
+			t.Errorf("CLI command missing %q\ngot:\n%s", fragment, artifact.CLICommand)
+		}
+	}
+
+	if !strings.HasSuffix(artifact.CLICommand, "\nEOF") {
+		t.Errorf("CLI command must end with the heredoc delimiter\ngot:\n%s", artifact.CLICommand)
+	}
+}
+
+func TestAgentCheckInRequestValidateDigitalOcean(t *testing.T) {
+	req := &AgentCheckInRequest{ProviderAccountID: valuer.GenerateUUID().StringValue()}
+	if err := req.Validate(CloudProviderTypeDigitalOcean); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	req = &AgentCheckInRequest{ProviderAccountID: "team"}
+	if err := req.Validate(CloudProviderTypeDigitalOcean); err == nil {
+		t.Error("expected an error for a non UUID team id")
+	}
+}
@@ -0,0 +1,201 @@
+package cloudintegrationtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	AgentOCIStackStorePath = "https://signoz-integrations.s3.us-east-1.amazonaws.com/oci-resource-manager-stack-%s.zip"
+	AgentOCIStackName      = "signoz-integration"
+
+	// Resource Manager stack variable names used in the cloud shell command.
+	ociVarRegion             = "region"
+	ociVarCompartmentID      = "compartment_ocid"
+	ociVarSignozAPIKey       = "signoz_api_key"
+	ociVarSignozAPIUrl       = "signoz_api_url"
+	ociVarSignozIngestionURL = "signoz_ingestion_url"
+	ociVarSignozIngestionKey = "signoz_ingestion_key"
+	ociVarAccountID          = "signoz_integration_account_id"
+	ociVarAgentVersion       = "signoz_integration_agent_version"
+
+	ociCloudShellTemplate = template.Must(template.New("ociCloudShell").Parse(ociCloudShellTemplateStr()))
+)
+
+type OCIAccountConfig struct {
+	// Region where the agent stack and the service connectors are deployed
+	DeploymentRegion string `json:"deploymentRegion" required:"true"`
+	// OCID of the compartment the agent stack is created in
+	DeploymentCompartmentID string `json:"deploymentCompartmentId" required:"true"`
+	// OCIDs of the compartments to monitor
+	CompartmentIDs []string `json:"compartmentIds" required:"true" nullable:"false"`
+}
+
+type OCIPostableAccountConfig = OCIAccountConfig
+
+type UpdatableOCIAccountConfig struct {
+	// OCIDs of the compartments to monitor
+	CompartmentIDs []string `json:"compartmentIds" required:"true" nullable:"false"`
+}
+
+type OCIConnectionArtifact struct {
+	CloudShellCommand string `json:"cloudShellCommand" required:"true"`
+}
+
+type OCIServiceConfig struct {
+	Logs    *OCIServiceLogsConfig    `json:"logs,omitempty" required:"false"`
+	Metrics *OCIServiceMetricsConfig `json:"metrics,omitempty" required:"false"`
+}
+
+type OCIServiceLogsConfig struct {
+	Enabled bool `json:"enabled" required:"true"`
+}
+
+type OCIServiceMetricsConfig struct {
+	Enabled bool `json:"enabled" required:"true"`
+}
+
+type OCITelemetryCollectionStrategy struct {
+	// Monitoring namespace of the service, https://docs.oracle.com/en-us/iaas/Content/Monitoring/Concepts/monitoringoverview.htm#Namespaces
+	Namespace string                        `json:"namespace" required:"true"`
+	Metrics   *OCIMetricsCollectionStrategy `json:"metrics,omitempty" required:"false" nullable:"false"`
+	Logs      *OCILogsCollectionStrategy    `json:"logs,omitempty" required:"false" nullable:"false"`
+}
+
+// OCIMetricsCollectionStrategy no additional config required for metrics, the service connector
+// moves every metric of the namespace.
+type OCIMetricsCollectionStrategy struct{}
+
+type OCILogsCollectionStrategy struct {
+	// Service and categories of the service logs enabled on the resources,
+	// https://docs.oracle.com/en-us/iaas/Content/Logging/Reference/service_log_reference.htm
+	Service    string   `json:"service" required:"true"`
+	Categories []string `json:"categories" required:"true" nullable:"false"`
+}
+
+type OCIIntegrationConfig struct {
+	DeploymentRegion            string                            `json:"deploymentRegion" required:"true"`
+	CompartmentIDs              []string                          `json:"compartmentIds" required:"true" nullable:"false"`
+	TelemetryCollectionStrategy []*OCITelemetryCollectionStrategy `json:"telemetryCollectionStrategy" required:"true" nullable:"false"`
+}
+
+// ociTemplateData is the data struct passed to the cloud shell command template.
+type ociTemplateData struct {
+	StackURL      string
+	StackName     string
+	Region        string
+	CompartmentID string
+	// Variables is the JSON object of the stack variables.
+	Variables string
+}
+
+func NewOCIIntegrationConfig(
+	deploymentRegion string,
+	compartmentIDs []string,
+	strategies []*OCITelemetryCollectionStrategy,
+) *OCIIntegrationConfig {
+	return &OCIIntegrationConfig{
+		DeploymentRegion:            deploymentRegion,
+		CompartmentIDs:              compartmentIDs,
+		TelemetryCollectionStrategy: strategies,
+	}
+}
+
+func NewOCIConnectionArtifact(
+	accountID valuer.UUID,
+	agentVersion string,
+	creds *Credentials,
+	cfg *OCIPostableAccountConfig,
+) (*OCIConnectionArtifact, error) {
+	variables, err := json.Marshal(map[string]string{
+		ociVarRegion:             cfg.DeploymentRegion,
+		ociVarCompartmentID:      cfg.DeploymentCompartmentID,
+		ociVarSignozAPIKey:       creds.SigNozAPIKey,
+		ociVarSignozAPIUrl:       creds.SigNozAPIURL,
+		ociVarSignozIngestionURL: creds.IngestionURL,
+		ociVarSignozIngestionKey: creds.IngestionKey,
+		ociVarAccountID:          accountID.StringValue(),
+		ociVarAgentVersion:       agentVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := newOCIConnectionCloudShellCommand(ociTemplateData{
+		StackURL:      fmt.Sprintf(AgentOCIStackStorePath, agentVersion),
+		StackName:     AgentOCIStackName,
+		Region:        cfg.DeploymentRegion,
+		CompartmentID: cfg.DeploymentCompartmentID,
+		Variables:     string(variables),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OCIConnectionArtifact{
+		CloudShellCommand: command,
+	}, nil
+}
+
+func newOCIConnectionCloudShellCommand(data ociTemplateData) (string, error) {
+	var buf bytes.Buffer
+	err := ociCloudShellTemplate.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ociCloudShellTemplateStr creates the agent stack with Resource Manager and applies it.
+func ociCloudShellTemplateStr() string {
+	return `curl -sSL -o {{.StackName}}.zip {{.StackURL}} && \
+oci resource-manager job create-apply-job \
+  --region {{.Region}} \
+  --execution-plan-strategy AUTO_APPROVED \
+  --stack-id "$(oci resource-manager stack create \
+    --region {{.Region}} \
+    --display-name {{.StackName}} \
+    --compartment-id '{{.CompartmentID}}' \
+    --config-source {{.StackName}}.zip \
+    --variables '{{.Variables}}' \
+    --query 'data.id' --raw-output)"`
+}
+
+// validateOCIID validates the OCID of the given resource type,
+// https://docs.oracle.com/en-us/iaas/Content/General/Concepts/identifiers.htm
+func validateOCIID(resourceType string, id string) error {
+	if !strings.HasPrefix(id, "ocid1."+resourceType+".") {
+		return errors.NewInvalidInputf(ErrCodeInvalidInput, "invalid OCI %s OCID: %q", resourceType, id)
+	}
+
+	return nil
+}
+
+// validateOCICompartmentID validates the OCID of a compartment, the tenancy being the root compartment.
+func validateOCICompartmentID(compartmentID string) error {
+	if validateOCIID("tenancy", compartmentID) == nil {
+		return nil
+	}
+
+	return validateOCIID("compartment", compartmentID)
+}
+
+func validateOCICompartmentIDs(compartmentIDs []string) error {
+	if len(compartmentIDs) == 0 {
+		return errors.NewInvalidInputf(ErrCodeInvalidInput, "at least one compartment id is required for OCI provider")
+	}
+
+	for _, compartmentID := range compartmentIDs {
+		if err := validateOCICompartmentID(compartmentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package cloudintegrationtypes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func TestNewOCIConnectionArtifact(t *testing.T) {
+	accountID := valuer.GenerateUUID()
+	agentVersion := "v0.1.0"
+	creds := &Credentials{
+		SigNozAPIURL: "https://signoz.example.com",
+		SigNozAPIKey: "test-api-key",
+		IngestionURL: "https://ingest.example.com",
+		IngestionKey: "test-ingest-key",
+	}
+	cfg := &OCIPostableAccountConfig{
+		DeploymentRegion:        "us-ashburn-1",
+		DeploymentCompartmentID: "ocid1.compartment.oc1..aaaa",
+		CompartmentIDs:          []string{"ocid1.compartment.oc1..aaaa"},
+	}
+
+	artifact, err := NewOCIConnectionArtifact(accountID, agentVersion, creds, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mustContain := []string{
+		fmt.Sprintf(AgentOCIStackStorePath, agentVersion),
+		"oci resource-manager stack create",
+		"oci resource-manager job create-apply-job",
+		"--region us-ashburn-1",
+		fmt.Sprintf("--compartment-id '%s'", cfg.DeploymentCompartmentID),
+		fmt.Sprintf("%q:%q", ociVarAccountID, accountID.StringValue()),
+		fmt.Sprintf("%q:%q", ociVarAgentVersion, agentVersion),
+		fmt.Sprintf("%q:%q", ociVarSignozAPIKey, creds.SigNozAPIKey),
+		fmt.Sprintf("%q:%q", ociVarSignozIngestionKey, creds.IngestionKey),
+	}
+
+	for _, fragment := range mustContain {
+		if !strings.Contains(artifact.CloudShellCommand, fragment) {
+			t.Errorf("cloud shell command missing %q\ngot:\n%s", fragment, artifact.CloudShellCommand)
+		}
+	}
+}
+
+func TestValidateOCICompartmentIDs(t *testing.T) {
+	testCases := []struct {
+		name           string
+		compartmentIDs []string
+		valid          bool
+	}{
+		{name: "Tenancy", compartmentIDs: []string{"ocid1.tenancy.oc1..aaaa"}, valid: true},
+		{name: "Compartments", compartmentIDs: []string{"ocid1.compartment.oc1..aaaa", "ocid1.compartment.oc1..bbbb"}, valid: true},
+		{name: "Empty", compartmentIDs: nil, valid: false},
+		{name: "OtherResource", compartmentIDs: []string{"ocid1.instance.oc1.iad.aaaa"}, valid: false},
+		{name: "NotAnOCID", compartmentIDs: []string{"compartment"}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateOCICompartmentIDs(tc.compartmentIDs)
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestAgentCheckInRequestValidateOCI(t *testing.T) {
+	req := &AgentCheckInRequest{ProviderAccountID: "ocid1.tenancy.oc1..aaaa"}
+	if err := req.Validate(CloudProviderTypeOCI); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	req = &AgentCheckInRequest{ProviderAccountID: "ocid1.compartment.oc1..aaaa"}
+	if err := req.Validate(CloudProviderTypeOCI); err == nil {
+		t.Error("expected an error for a compartment OCID")
+	}
+}
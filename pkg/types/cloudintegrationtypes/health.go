@@ -0,0 +1,266 @@
+package cloudintegrationtypes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type HealthStatus struct{ valuer.String }
+
+var (
+	HealthStatusHealthy   = HealthStatus{valuer.NewString("healthy")}
+	HealthStatusDegraded  = HealthStatus{valuer.NewString("degraded")}
+	HealthStatusUnhealthy = HealthStatus{valuer.NewString("unhealthy")}
+)
+
+func (HealthStatus) Enum() []any {
+	return []any{
+		HealthStatusHealthy,
+		HealthStatusDegraded,
+		HealthStatusUnhealthy,
+	}
+}
+
+// providerAccountAttributes is the metric attribute carrying the provider account id of the
+// telemetry collected for each cloud provider.
+var providerAccountAttributes = map[CloudProviderType]string{
+	CloudProviderTypeAWS:          "cloud.account.id",
+	CloudProviderTypeAzure:        "azuremonitor.subscription_id",
+	CloudProviderTypeGCP:          "project_id",
+	CloudProviderTypeOCI:          "oci.tenancy.id",
+	CloudProviderTypeDigitalOcean: "digitalocean.team.id",
+}
+
+// AccountHealth is the health of a connected account: whether its agent still checks in, runs
+// the latest config, and whether the metrics of its enabled services still arrive from it.
+type AccountHealth struct {
+	AccountID valuer.UUID      `json:"accountId" required:"true"`
+	Status    HealthStatus     `json:"status" required:"true"`
+	CheckIn   *CheckInHealth   `json:"checkIn" required:"true" nullable:"false"`
+	Config    *ConfigHealth    `json:"config" required:"true" nullable:"false"`
+	Services  []*ServiceHealth `json:"services" required:"true" nullable:"false"`
+}
+
+type CheckInHealth struct {
+	LastCheckInAt *time.Time `json:"lastCheckInAt" required:"true" nullable:"true"`
+	// Stale is set when the agent never checked in or did not check in within the staleness window.
+	Stale bool `json:"stale" required:"true"`
+}
+
+type ConfigHealth struct {
+	// ExpectedVersion is the version of the integration config the agent would get on check-in now.
+	ExpectedVersion string `json:"expectedVersion" required:"true"`
+	// ReportedVersion is the version the agent reported running on its last check-in,
+	// empty for agents not reporting it.
+	ReportedVersion string `json:"reportedVersion" required:"true"`
+	Drifted         bool   `json:"drifted" required:"true"`
+}
+
+type ServiceHealth struct {
+	ServiceID ServiceID    `json:"serviceId" required:"true"`
+	Status    HealthStatus `json:"status" required:"true"`
+	// Metrics and Logs are nil when the signal is not enabled or the service definition lists nothing to expect.
+	Metrics *SignalHealth `json:"metrics" required:"true" nullable:"true"`
+	Logs    *SignalHealth `json:"logs" required:"true" nullable:"true"`
+}
+
+type SignalHealth struct {
+	Status     HealthStatus         `json:"status" required:"true"`
+	LastSeenAt *time.Time           `json:"lastSeenAt" required:"true" nullable:"true"`
+	Expected   []*ExpectedTelemetry `json:"expected" required:"true" nullable:"false"`
+	// OrgWide is set when the telemetry counts as seen when any account of the org sends it, in
+	// which case the signal is left out of the service status.
+	OrgWide bool `json:"orgWide" required:"true"`
+}
+
+// ExpectedTelemetry is a metric or log field listed in the service definition.
+type ExpectedTelemetry struct {
+	Name       string     `json:"name" required:"true"`
+	LastSeenAt *time.Time `json:"lastSeenAt" required:"true" nullable:"true"`
+	Stale      bool       `json:"stale" required:"true"`
+}
+
+// NewConfigVersion returns the version of an integration config, a hash of its JSON.
+func NewConfigVersion(config *ProviderIntegrationConfig) (string, error) {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return "", errors.WrapInternalf(err, errors.CodeInternal, "couldn't serialize integration config to JSON")
+	}
+
+	sum := sha256.Sum256(configJSON)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// ExpectedMetricNames returns the names of the metrics the service definition lists.
+func (def *ServiceDefinition) ExpectedMetricNames() []string {
+	names := make([]string, 0, len(def.DataCollected.Metrics))
+	for _, metric := range def.DataCollected.Metrics {
+		names = append(names, metric.Name)
+	}
+
+	return names
+}
+
+// ExpectedLogFields returns the log fields the service definition lists. Paths other than
+// resources.* and attributes.* are left out.
+func (def *ServiceDefinition) ExpectedLogFields() []telemetrytypes.FieldCardinalityKey {
+	keys := make([]telemetrytypes.FieldCardinalityKey, 0, len(def.DataCollected.Logs))
+	for _, log := range def.DataCollected.Logs {
+		if key, ok := newLogFieldKey(log.Path); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func newLogFieldKey(path string) (telemetrytypes.FieldCardinalityKey, bool) {
+	if name, ok := strings.CutPrefix(path, "resources."); ok {
+		return telemetrytypes.FieldCardinalityKey{Name: name, FieldContext: telemetrytypes.FieldContextResource}, true
+	}
+
+	if name, ok := strings.CutPrefix(path, "attributes."); ok {
+		return telemetrytypes.FieldCardinalityKey{Name: name, FieldContext: telemetrytypes.FieldContextAttribute}, true
+	}
+
+	return telemetrytypes.FieldCardinalityKey{}, false
+}
+
+// NewServiceHealth compares the telemetry the definition of an enabled service lists against when
+// it was last seen. Metrics are expected to be last seen for the account, and decide the service
+// status. Log fields can only be looked up for the whole org, so the logs signal is org-wide.
+func NewServiceHealth(
+	provider CloudProviderType,
+	def *ServiceDefinition,
+	config *ServiceConfig,
+	metricsLastSeen map[string]int64,
+	logsLastSeen map[telemetrytypes.FieldCardinalityKey]int64,
+	now time.Time,
+	staleAfter time.Duration,
+) (*ServiceHealth, error) {
+	serviceID, err := NewServiceID(provider, def.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &ServiceHealth{ServiceID: serviceID, Status: HealthStatusHealthy}
+
+	if config.IsMetricsEnabled(provider) {
+		names := def.ExpectedMetricNames()
+		lastSeen := make([]int64, len(names))
+		for i, name := range names {
+			lastSeen[i] = metricsLastSeen[name]
+		}
+
+		health.Metrics = newSignalHealth(names, lastSeen, now, staleAfter)
+	}
+
+	if config.IsLogsEnabled(provider) {
+		keys := def.ExpectedLogFields()
+		names := make([]string, len(keys))
+		lastSeen := make([]int64, len(keys))
+		for i, key := range keys {
+			names[i] = key.FieldContext.StringValue() + "." + key.Name
+			lastSeen[i] = logsLastSeen[key]
+		}
+
+		health.Logs = newSignalHealth(names, lastSeen, now, staleAfter)
+		if health.Logs != nil {
+			health.Logs.OrgWide = true
+		}
+	}
+
+	for _, signal := range []*SignalHealth{health.Metrics, health.Logs} {
+		if signal != nil && !signal.OrgWide && signal.Status == HealthStatusUnhealthy {
+			health.Status = HealthStatusUnhealthy
+		}
+	}
+
+	return health, nil
+}
+
+// newSignalHealth is unhealthy when none of the expected telemetry was seen within the staleness
+// window. Definitions list every telemetry a service can send, some of which depend on how the
+// resources are set up, so single stale entries are only flagged.
+func newSignalHealth(names []string, lastSeen []int64, now time.Time, staleAfter time.Duration) *SignalHealth {
+	if len(names) == 0 {
+		return nil
+	}
+
+	signal := &SignalHealth{
+		Status:   HealthStatusUnhealthy,
+		Expected: make([]*ExpectedTelemetry, 0, len(names)),
+	}
+
+	for i, name := range names {
+		expected := &ExpectedTelemetry{Name: name, Stale: true}
+		if lastSeen[i] > 0 {
+			seenAt := time.UnixMilli(lastSeen[i])
+			expected.LastSeenAt = &seenAt
+			expected.Stale = now.Sub(seenAt) > staleAfter
+
+			if signal.LastSeenAt == nil || seenAt.After(*signal.LastSeenAt) {
+				signal.LastSeenAt = &seenAt
+			}
+		}
+
+		if !expected.Stale {
+			signal.Status = HealthStatusHealthy
+		}
+
+		signal.Expected = append(signal.Expected, expected)
+	}
+
+	return signal
+}
+
+// NewAccountHealth rolls the check-in, config and service health up into the account health. The
+// account is unhealthy when its agent stopped checking in or none of its enabled services sends
+// telemetry, and degraded when some do not or the agent runs an older config.
+func NewAccountHealth(account *Account, expectedConfigVersion string, services []*ServiceHealth, now time.Time, checkInStaleAfter time.Duration) *AccountHealth {
+	health := &AccountHealth{
+		AccountID: account.ID,
+		Status:    HealthStatusHealthy,
+		CheckIn:   &CheckInHealth{Stale: true},
+		Config:    &ConfigHealth{ExpectedVersion: expectedConfigVersion},
+		Services:  services,
+	}
+
+	if account.AgentReport != nil {
+		checkInAt := time.UnixMilli(account.AgentReport.TimestampMillis)
+		health.CheckIn.LastCheckInAt = &checkInAt
+		health.CheckIn.Stale = now.Sub(checkInAt) > checkInStaleAfter
+
+		health.Config.ReportedVersion = account.AgentReport.ConfigVersion
+		health.Config.Drifted = account.AgentReport.ConfigVersion != "" && account.AgentReport.ConfigVersion != expectedConfigVersion
+	}
+
+	unhealthyServices := 0
+	for _, service := range services {
+		if service.Status == HealthStatusUnhealthy {
+			unhealthyServices++
+		}
+	}
+
+	switch {
+	case health.CheckIn.Stale, len(services) > 0 && unhealthyServices == len(services):
+		health.Status = HealthStatusUnhealthy
+	case health.Config.Drifted, unhealthyServices > 0:
+		health.Status = HealthStatusDegraded
+	}
+
+	return health
+}
+
+// ProviderAccountAttribute returns the metric attribute carrying the provider account id of the
+// telemetry collected for the cloud provider.
+func ProviderAccountAttribute(provider CloudProviderType) string {
+	return providerAccountAttributes[provider]
+}
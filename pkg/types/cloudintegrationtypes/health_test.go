@@ -0,0 +1,223 @@
+package cloudintegrationtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func TestNewConfigVersion(t *testing.T) {
+	config := &ProviderIntegrationConfig{AWS: &AWSIntegrationConfig{EnabledRegions: []string{"us-east-1"}}}
+
+	version, err := NewConfigVersion(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	again, err := NewConfigVersion(&ProviderIntegrationConfig{AWS: &AWSIntegrationConfig{EnabledRegions: []string{"us-east-1"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != again {
+		t.Fatalf("expected the same config to have the same version, got %q and %q", version, again)
+	}
+
+	changed, err := NewConfigVersion(&ProviderIntegrationConfig{AWS: &AWSIntegrationConfig{EnabledRegions: []string{"us-east-1", "eu-west-1"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version == changed {
+		t.Fatalf("expected a changed config to have a new version, got %q", changed)
+	}
+}
+
+func TestNewServiceHealth(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	def := &ServiceDefinition{
+		ServiceDefinitionMetadata: ServiceDefinitionMetadata{ID: "ec2", Title: "EC2"},
+		DataCollected: DataCollected{
+			Metrics: []CollectedMetric{{Name: "aws_EC2_CPUUtilization_max"}, {Name: "aws_EC2_NetworkIn_max"}},
+			Logs:    []CollectedLogAttribute{{Path: "resources.aws.cloudwatch.log_group_name"}, {Path: "body"}},
+		},
+	}
+	config := &ServiceConfig{AWS: &AWSServiceConfig{
+		Metrics: &AWSServiceMetricsConfig{Enabled: true},
+		Logs:    &AWSServiceLogsConfig{Enabled: true},
+	}}
+	logKey := telemetrytypes.FieldCardinalityKey{Name: "aws.cloudwatch.log_group_name", FieldContext: telemetrytypes.FieldContextResource}
+
+	testCases := []struct {
+		name            string
+		metricsLastSeen map[string]int64
+		logsLastSeen    map[telemetrytypes.FieldCardinalityKey]int64
+		status          HealthStatus
+		metricsStatus   HealthStatus
+		logsStatus      HealthStatus
+	}{
+		{
+			name:            "AllFresh",
+			metricsLastSeen: map[string]int64{"aws_EC2_CPUUtilization_max": now.Add(-time.Minute).UnixMilli(), "aws_EC2_NetworkIn_max": now.Add(-time.Minute).UnixMilli()},
+			logsLastSeen:    map[telemetrytypes.FieldCardinalityKey]int64{logKey: now.Add(-time.Minute).UnixMilli()},
+			status:          HealthStatusHealthy,
+			metricsStatus:   HealthStatusHealthy,
+			logsStatus:      HealthStatusHealthy,
+		},
+		{
+			name:            "OneMetricFresh",
+			metricsLastSeen: map[string]int64{"aws_EC2_CPUUtilization_max": now.Add(-time.Minute).UnixMilli(), "aws_EC2_NetworkIn_max": now.Add(-3 * time.Hour).UnixMilli()},
+			logsLastSeen:    map[telemetrytypes.FieldCardinalityKey]int64{logKey: now.Add(-time.Minute).UnixMilli()},
+			status:          HealthStatusHealthy,
+			metricsStatus:   HealthStatusHealthy,
+			logsStatus:      HealthStatusHealthy,
+		},
+		{
+			// logs are org-wide and left out of the service status
+			name:            "LogsNeverSeen",
+			metricsLastSeen: map[string]int64{"aws_EC2_CPUUtilization_max": now.Add(-time.Minute).UnixMilli()},
+			logsLastSeen:    map[telemetrytypes.FieldCardinalityKey]int64{},
+			status:          HealthStatusHealthy,
+			metricsStatus:   HealthStatusHealthy,
+			logsStatus:      HealthStatusUnhealthy,
+		},
+		{
+			name:            "MetricsStale",
+			metricsLastSeen: map[string]int64{"aws_EC2_CPUUtilization_max": now.Add(-3 * time.Hour).UnixMilli()},
+			logsLastSeen:    map[telemetrytypes.FieldCardinalityKey]int64{logKey: now.Add(-time.Minute).UnixMilli()},
+			status:          HealthStatusUnhealthy,
+			metricsStatus:   HealthStatusUnhealthy,
+			logsStatus:      HealthStatusHealthy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			health, err := NewServiceHealth(CloudProviderTypeAWS, def, config, tc.metricsLastSeen, tc.logsLastSeen, now, 2*time.Hour)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if health.ServiceID != AWSServiceEC2 {
+				t.Fatalf("expected service id %q, got %q", AWSServiceEC2.StringValue(), health.ServiceID.StringValue())
+			}
+			if health.Status != tc.status {
+				t.Fatalf("expected status %q, got %q", tc.status.StringValue(), health.Status.StringValue())
+			}
+			if health.Metrics.Status != tc.metricsStatus {
+				t.Fatalf("expected metrics status %q, got %q", tc.metricsStatus.StringValue(), health.Metrics.Status.StringValue())
+			}
+			if health.Logs.Status != tc.logsStatus {
+				t.Fatalf("expected logs status %q, got %q", tc.logsStatus.StringValue(), health.Logs.Status.StringValue())
+			}
+			if health.Metrics.OrgWide || !health.Logs.OrgWide {
+				t.Fatalf("expected only the logs signal to be org-wide, got metrics %t and logs %t", health.Metrics.OrgWide, health.Logs.OrgWide)
+			}
+			if len(health.Metrics.Expected) != 2 {
+				t.Fatalf("expected 2 metrics, got %d", len(health.Metrics.Expected))
+			}
+			// only resources.* and attributes.* log paths can be looked up
+			if len(health.Logs.Expected) != 1 || health.Logs.Expected[0].Name != "resource.aws.cloudwatch.log_group_name" {
+				t.Fatalf("expected the resource log field only, got %+v", health.Logs.Expected)
+			}
+		})
+	}
+}
+
+func TestNewServiceHealthSignalDisabled(t *testing.T) {
+	def := &ServiceDefinition{
+		ServiceDefinitionMetadata: ServiceDefinitionMetadata{ID: "ec2"},
+		DataCollected:             DataCollected{Metrics: []CollectedMetric{{Name: "aws_EC2_CPUUtilization_max"}}},
+	}
+	config := &ServiceConfig{AWS: &AWSServiceConfig{Metrics: &AWSServiceMetricsConfig{Enabled: true}}}
+
+	health, err := NewServiceHealth(CloudProviderTypeAWS, def, config, map[string]int64{}, nil, time.Now(), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if health.Logs != nil {
+		t.Fatalf("expected no logs health for disabled logs, got %+v", health.Logs)
+	}
+	if health.Metrics.LastSeenAt != nil || !health.Metrics.Expected[0].Stale {
+		t.Fatalf("expected a never seen metric to be stale, got %+v", health.Metrics.Expected[0])
+	}
+}
+
+func TestNewAccountHealth(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	healthy := &ServiceHealth{Status: HealthStatusHealthy}
+	unhealthy := &ServiceHealth{Status: HealthStatusUnhealthy}
+
+	testCases := []struct {
+		name        string
+		agentReport *AgentReport
+		services    []*ServiceHealth
+		status      HealthStatus
+		stale       bool
+		drifted     bool
+	}{
+		{
+			name:        "Healthy",
+			agentReport: &AgentReport{TimestampMillis: now.Add(-time.Minute).UnixMilli(), ConfigVersion: "v1"},
+			services:    []*ServiceHealth{healthy, healthy},
+			status:      HealthStatusHealthy,
+		},
+		{
+			name:        "AgentNotReportingVersion",
+			agentReport: &AgentReport{TimestampMillis: now.Add(-time.Minute).UnixMilli()},
+			services:    []*ServiceHealth{healthy},
+			status:      HealthStatusHealthy,
+		},
+		{
+			name:        "ConfigDrifted",
+			agentReport: &AgentReport{TimestampMillis: now.Add(-time.Minute).UnixMilli(), ConfigVersion: "v0"},
+			services:    []*ServiceHealth{healthy},
+			status:      HealthStatusDegraded,
+			drifted:     true,
+		},
+		{
+			name:        "SomeServicesUnhealthy",
+			agentReport: &AgentReport{TimestampMillis: now.Add(-time.Minute).UnixMilli(), ConfigVersion: "v1"},
+			services:    []*ServiceHealth{healthy, unhealthy},
+			status:      HealthStatusDegraded,
+		},
+		{
+			name:        "AllServicesUnhealthy",
+			agentReport: &AgentReport{TimestampMillis: now.Add(-time.Minute).UnixMilli(), ConfigVersion: "v1"},
+			services:    []*ServiceHealth{unhealthy},
+			status:      HealthStatusUnhealthy,
+		},
+		{
+			name:        "CheckInStale",
+			agentReport: &AgentReport{TimestampMillis: now.Add(-time.Hour).UnixMilli(), ConfigVersion: "v1"},
+			services:    []*ServiceHealth{healthy},
+			status:      HealthStatusUnhealthy,
+			stale:       true,
+		},
+		{
+			name:     "NeverCheckedIn",
+			services: []*ServiceHealth{},
+			status:   HealthStatusUnhealthy,
+			stale:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := &Account{OrgID: valuer.GenerateUUID(), Provider: CloudProviderTypeAWS, AgentReport: tc.agentReport}
+
+			health := NewAccountHealth(account, "v1", tc.services, now, 30*time.Minute)
+
+			if health.Status != tc.status {
+				t.Fatalf("expected status %q, got %q", tc.status.StringValue(), health.Status.StringValue())
+			}
+			if health.CheckIn.Stale != tc.stale {
+				t.Fatalf("expected stale check-in %v, got %v", tc.stale, health.CheckIn.Stale)
+			}
+			if health.Config.Drifted != tc.drifted {
+				t.Fatalf("expected config drift %v, got %v", tc.drifted, health.Config.Drifted)
+			}
+		})
+	}
+}
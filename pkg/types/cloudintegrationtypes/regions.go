@@ -147,6 +147,63 @@ var (
 	GCPRegionUSWest2             = CloudProviderRegion{valuer.NewString("us-west2")}             // Los Angeles, California. North America.
 	GCPRegionUSWest3             = CloudProviderRegion{valuer.NewString("us-west3")}             // Salt Lake City, Utah. North America.
 	GCPRegionUSWest4             = CloudProviderRegion{valuer.NewString("us-west4")}             // Las Vegas, Nevada. North America.
+
+	// OCI regions.
+	OCIRegionAFJohannesburg1 = CloudProviderRegion{valuer.NewString("af-johannesburg-1")} // Johannesburg, South Africa. Africa.
+	OCIRegionAPBatam1        = CloudProviderRegion{valuer.NewString("ap-batam-1")}        // Batam, Indonesia. APAC.
+	OCIRegionAPChuncheon1    = CloudProviderRegion{valuer.NewString("ap-chuncheon-1")}    // Chuncheon, South Korea. APAC.
+	OCIRegionAPHyderabad1    = CloudProviderRegion{valuer.NewString("ap-hyderabad-1")}    // Hyderabad, India. APAC.
+	OCIRegionAPMelbourne1    = CloudProviderRegion{valuer.NewString("ap-melbourne-1")}    // Melbourne, Australia. APAC.
+	OCIRegionAPMumbai1       = CloudProviderRegion{valuer.NewString("ap-mumbai-1")}       // Mumbai, India. APAC.
+	OCIRegionAPOsaka1        = CloudProviderRegion{valuer.NewString("ap-osaka-1")}        // Osaka, Japan. APAC.
+	OCIRegionAPSeoul1        = CloudProviderRegion{valuer.NewString("ap-seoul-1")}        // Seoul, South Korea. APAC.
+	OCIRegionAPSingapore1    = CloudProviderRegion{valuer.NewString("ap-singapore-1")}    // Singapore. APAC.
+	OCIRegionAPSingapore2    = CloudProviderRegion{valuer.NewString("ap-singapore-2")}    // Singapore West. APAC.
+	OCIRegionAPSydney1       = CloudProviderRegion{valuer.NewString("ap-sydney-1")}       // Sydney, Australia. APAC.
+	OCIRegionAPTokyo1        = CloudProviderRegion{valuer.NewString("ap-tokyo-1")}        // Tokyo, Japan. APAC.
+	OCIRegionCAMontreal1     = CloudProviderRegion{valuer.NewString("ca-montreal-1")}     // Montréal, Canada. North America.
+	OCIRegionCAToronto1      = CloudProviderRegion{valuer.NewString("ca-toronto-1")}      // Toronto, Canada. North America.
+	OCIRegionEUAmsterdam1    = CloudProviderRegion{valuer.NewString("eu-amsterdam-1")}    // Amsterdam, Netherlands. Europe.
+	OCIRegionEUFrankfurt1    = CloudProviderRegion{valuer.NewString("eu-frankfurt-1")}    // Frankfurt, Germany. Europe.
+	OCIRegionEUMadrid1       = CloudProviderRegion{valuer.NewString("eu-madrid-1")}       // Madrid, Spain. Europe.
+	OCIRegionEUMarseille1    = CloudProviderRegion{valuer.NewString("eu-marseille-1")}    // Marseille, France. Europe.
+	OCIRegionEUMilan1        = CloudProviderRegion{valuer.NewString("eu-milan-1")}        // Milan, Italy. Europe.
+	OCIRegionEUParis1        = CloudProviderRegion{valuer.NewString("eu-paris-1")}        // Paris, France. Europe.
+	OCIRegionEUStockholm1    = CloudProviderRegion{valuer.NewString("eu-stockholm-1")}    // Stockholm, Sweden. Europe.
+	OCIRegionEUZurich1       = CloudProviderRegion{valuer.NewString("eu-zurich-1")}       // Zurich, Switzerland. Europe.
+	OCIRegionILJerusalem1    = CloudProviderRegion{valuer.NewString("il-jerusalem-1")}    // Jerusalem, Israel. Middle East.
+	OCIRegionMEAbudhabi1     = CloudProviderRegion{valuer.NewString("me-abudhabi-1")}     // Abu Dhabi, UAE. Middle East.
+	OCIRegionMEDubai1        = CloudProviderRegion{valuer.NewString("me-dubai-1")}        // Dubai, UAE. Middle East.
+	OCIRegionMEJeddah1       = CloudProviderRegion{valuer.NewString("me-jeddah-1")}       // Jeddah, Saudi Arabia. Middle East.
+	OCIRegionMERiyadh1       = CloudProviderRegion{valuer.NewString("me-riyadh-1")}       // Riyadh, Saudi Arabia. Middle East.
+	OCIRegionMXMonterrey1    = CloudProviderRegion{valuer.NewString("mx-monterrey-1")}    // Monterrey, Mexico. North America.
+	OCIRegionMXQueretaro1    = CloudProviderRegion{valuer.NewString("mx-queretaro-1")}    // Querétaro, Mexico. North America.
+	OCIRegionSABogota1       = CloudProviderRegion{valuer.NewString("sa-bogota-1")}       // Bogotá, Colombia. South America.
+	OCIRegionSASantiago1     = CloudProviderRegion{valuer.NewString("sa-santiago-1")}     // Santiago, Chile. South America.
+	OCIRegionSASaopaulo1     = CloudProviderRegion{valuer.NewString("sa-saopaulo-1")}     // São Paulo, Brazil. South America.
+	OCIRegionSAValparaiso1   = CloudProviderRegion{valuer.NewString("sa-valparaiso-1")}   // Valparaíso, Chile. South America.
+	OCIRegionSAVinhedo1      = CloudProviderRegion{valuer.NewString("sa-vinhedo-1")}      // Vinhedo, Brazil. South America.
+	OCIRegionUKCardiff1      = CloudProviderRegion{valuer.NewString("uk-cardiff-1")}      // Newport, Wales. Europe.
+	OCIRegionUKLondon1       = CloudProviderRegion{valuer.NewString("uk-london-1")}       // London, England. Europe.
+	OCIRegionUSAshburn1      = CloudProviderRegion{valuer.NewString("us-ashburn-1")}      // Ashburn, Virginia. North America.
+	OCIRegionUSChicago1      = CloudProviderRegion{valuer.NewString("us-chicago-1")}      // Chicago, Illinois. North America.
+	OCIRegionUSPhoenix1      = CloudProviderRegion{valuer.NewString("us-phoenix-1")}      // Phoenix, Arizona. North America.
+	OCIRegionUSSanjose1      = CloudProviderRegion{valuer.NewString("us-sanjose-1")}      // San Jose, California. North America.
+
+	// DigitalOcean regions.
+	DigitalOceanRegionAMS3 = CloudProviderRegion{valuer.NewString("ams3")} // Amsterdam, Netherlands. Europe.
+	DigitalOceanRegionATL1 = CloudProviderRegion{valuer.NewString("atl1")} // Atlanta, Georgia. North America.
+	DigitalOceanRegionBLR1 = CloudProviderRegion{valuer.NewString("blr1")} // Bangalore, India. APAC.
+	DigitalOceanRegionFRA1 = CloudProviderRegion{valuer.NewString("fra1")} // Frankfurt, Germany. Europe.
+	DigitalOceanRegionLON1 = CloudProviderRegion{valuer.NewString("lon1")} // London, England. Europe.
+	DigitalOceanRegionNYC1 = CloudProviderRegion{valuer.NewString("nyc1")} // New York City, New York. North America.
+	DigitalOceanRegionNYC2 = CloudProviderRegion{valuer.NewString("nyc2")} // New York City, New York. North America.
+	DigitalOceanRegionNYC3 = CloudProviderRegion{valuer.NewString("nyc3")} // New York City, New York. North America.
+	DigitalOceanRegionSFO2 = CloudProviderRegion{valuer.NewString("sfo2")} // San Francisco, California. North America.
+	DigitalOceanRegionSFO3 = CloudProviderRegion{valuer.NewString("sfo3")} // San Francisco, California. North America.
+	DigitalOceanRegionSGP1 = CloudProviderRegion{valuer.NewString("sgp1")} // Singapore. APAC.
+	DigitalOceanRegionSYD1 = CloudProviderRegion{valuer.NewString("syd1")} // Sydney, Australia. APAC.
+	DigitalOceanRegionTOR1 = CloudProviderRegion{valuer.NewString("tor1")} // Toronto, Canada. North America.
 )
 
 func Enum() []any {
@@ -184,6 +241,18 @@ func Enum() []any {
 		GCPRegionSouthamericaEast1, GCPRegionSouthamericaWest1,
 		GCPRegionUSCentral1, GCPRegionUSEast1, GCPRegionUSEast4, GCPRegionUSEast5, GCPRegionUSSouth1,
 		GCPRegionUSWest1, GCPRegionUSWest2, GCPRegionUSWest3, GCPRegionUSWest4,
+		// OCI regions.
+		OCIRegionAFJohannesburg1, OCIRegionAPBatam1, OCIRegionAPChuncheon1, OCIRegionAPHyderabad1, OCIRegionAPMelbourne1, OCIRegionAPMumbai1,
+		OCIRegionAPOsaka1, OCIRegionAPSeoul1, OCIRegionAPSingapore1, OCIRegionAPSingapore2, OCIRegionAPSydney1, OCIRegionAPTokyo1,
+		OCIRegionCAMontreal1, OCIRegionCAToronto1, OCIRegionEUAmsterdam1, OCIRegionEUFrankfurt1, OCIRegionEUMadrid1, OCIRegionEUMarseille1,
+		OCIRegionEUMilan1, OCIRegionEUParis1, OCIRegionEUStockholm1, OCIRegionEUZurich1, OCIRegionILJerusalem1, OCIRegionMEAbudhabi1,
+		OCIRegionMEDubai1, OCIRegionMEJeddah1, OCIRegionMERiyadh1, OCIRegionMXMonterrey1, OCIRegionMXQueretaro1, OCIRegionSABogota1,
+		OCIRegionSASantiago1, OCIRegionSASaopaulo1, OCIRegionSAValparaiso1, OCIRegionSAVinhedo1, OCIRegionUKCardiff1, OCIRegionUKLondon1,
+		OCIRegionUSAshburn1, OCIRegionUSChicago1, OCIRegionUSPhoenix1, OCIRegionUSSanjose1,
+		// DigitalOcean regions.
+		DigitalOceanRegionAMS3, DigitalOceanRegionATL1, DigitalOceanRegionBLR1, DigitalOceanRegionFRA1, DigitalOceanRegionLON1, DigitalOceanRegionNYC1,
+		DigitalOceanRegionNYC2, DigitalOceanRegionNYC3, DigitalOceanRegionSFO2, DigitalOceanRegionSFO3, DigitalOceanRegionSGP1, DigitalOceanRegionSYD1,
+		DigitalOceanRegionTOR1,
 	}
 }
 
@@ -224,6 +293,20 @@ var SupportedRegions = map[CloudProviderType][]CloudProviderRegion{
 		GCPRegionUSCentral1, GCPRegionUSEast1, GCPRegionUSEast4, GCPRegionUSEast5, GCPRegionUSSouth1,
 		GCPRegionUSWest1, GCPRegionUSWest2, GCPRegionUSWest3, GCPRegionUSWest4,
 	},
+	CloudProviderTypeOCI: {
+		OCIRegionAFJohannesburg1, OCIRegionAPBatam1, OCIRegionAPChuncheon1, OCIRegionAPHyderabad1, OCIRegionAPMelbourne1, OCIRegionAPMumbai1,
+		OCIRegionAPOsaka1, OCIRegionAPSeoul1, OCIRegionAPSingapore1, OCIRegionAPSingapore2, OCIRegionAPSydney1, OCIRegionAPTokyo1,
+		OCIRegionCAMontreal1, OCIRegionCAToronto1, OCIRegionEUAmsterdam1, OCIRegionEUFrankfurt1, OCIRegionEUMadrid1, OCIRegionEUMarseille1,
+		OCIRegionEUMilan1, OCIRegionEUParis1, OCIRegionEUStockholm1, OCIRegionEUZurich1, OCIRegionILJerusalem1, OCIRegionMEAbudhabi1,
+		OCIRegionMEDubai1, OCIRegionMEJeddah1, OCIRegionMERiyadh1, OCIRegionMXMonterrey1, OCIRegionMXQueretaro1, OCIRegionSABogota1,
+		OCIRegionSASantiago1, OCIRegionSASaopaulo1, OCIRegionSAValparaiso1, OCIRegionSAVinhedo1, OCIRegionUKCardiff1, OCIRegionUKLondon1,
+		OCIRegionUSAshburn1, OCIRegionUSChicago1, OCIRegionUSPhoenix1, OCIRegionUSSanjose1,
+	},
+	CloudProviderTypeDigitalOcean: {
+		DigitalOceanRegionAMS3, DigitalOceanRegionATL1, DigitalOceanRegionBLR1, DigitalOceanRegionFRA1, DigitalOceanRegionLON1, DigitalOceanRegionNYC1,
+		DigitalOceanRegionNYC2, DigitalOceanRegionNYC3, DigitalOceanRegionSFO2, DigitalOceanRegionSFO3, DigitalOceanRegionSGP1, DigitalOceanRegionSYD1,
+		DigitalOceanRegionTOR1,
+	},
 }
 
 func validateAWSRegion(region string) error {
@@ -255,3 +338,23 @@ func validateGCPRegion(region string) error {
 
 	return errors.NewInvalidInputf(ErrCodeInvalidCloudRegion, "invalid GCP region: %s", region)
 }
+
+func validateOCIRegion(region string) error {
+	for _, r := range SupportedRegions[CloudProviderTypeOCI] {
+		if r.StringValue() == region {
+			return nil
+		}
+	}
+
+	return errors.NewInvalidInputf(ErrCodeInvalidCloudRegion, "invalid OCI region: %s", region)
+}
+
+func validateDigitalOceanRegion(region string) error {
+	for _, r := range SupportedRegions[CloudProviderTypeDigitalOcean] {
+		if r.StringValue() == region {
+			return nil
+		}
+	}
+
+	return errors.NewInvalidInputf(ErrCodeInvalidCloudRegion, "invalid DigitalOcean region: %s", region)
+}
@@ -19,9 +19,11 @@ type CloudIntegrationService struct {
 }
 
 type ServiceConfig struct {
-	AWS   *AWSServiceConfig   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure *AzureServiceConfig `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP   *GCPServiceConfig   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *AWSServiceConfig          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *AzureServiceConfig        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *GCPServiceConfig          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *OCIServiceConfig          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *DigitalOceanServiceConfig `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 // ServiceMetadata helps to quickly list available services and whether it is enabled or not.
@@ -87,9 +89,11 @@ type DataCollected struct {
 // TelemetryCollectionStrategy is cloud provider specific configuration for signal collection,
 // this is used by agent to understand the nitty-gritty for collecting telemetry for the cloud provider.
 type TelemetryCollectionStrategy struct {
-	AWS   *AWSTelemetryCollectionStrategy   `json:"aws,omitempty" required:"false" nullable:"false"`
-	Azure *AzureTelemetryCollectionStrategy `json:"azure,omitempty" required:"false" nullable:"false"`
-	GCP   *GCPTelemetryCollectionStrategy   `json:"gcp,omitempty" required:"false" nullable:"false"`
+	AWS          *AWSTelemetryCollectionStrategy          `json:"aws,omitempty" required:"false" nullable:"false"`
+	Azure        *AzureTelemetryCollectionStrategy        `json:"azure,omitempty" required:"false" nullable:"false"`
+	GCP          *GCPTelemetryCollectionStrategy          `json:"gcp,omitempty" required:"false" nullable:"false"`
+	OCI          *OCITelemetryCollectionStrategy          `json:"oci,omitempty" required:"false" nullable:"false"`
+	DigitalOcean *DigitalOceanTelemetryCollectionStrategy `json:"digitalocean,omitempty" required:"false" nullable:"false"`
 }
 
 // Assets represents the collection of dashboards.
@@ -143,6 +147,14 @@ func NewCloudIntegrationService(serviceID ServiceID, cloudIntegrationID valuer.U
 		if config.GCP == nil {
 			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "GCP config is required for GCP service")
 		}
+	case CloudProviderTypeOCI:
+		if config.OCI == nil {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "OCI config is required for OCI service")
+		}
+	case CloudProviderTypeDigitalOcean:
+		if config.DigitalOcean == nil {
+			return nil, errors.NewInvalidInputf(ErrCodeInvalidInput, "DigitalOcean config is required for DigitalOcean service")
+		}
 	}
 
 	return &CloudIntegrationService{
@@ -275,6 +287,38 @@ func NewServiceConfigFromJSON(provider CloudProviderType, jsonString string) (*S
 		}
 
 		return &ServiceConfig{GCP: gcpServiceConfig}, nil
+	case CloudProviderTypeOCI:
+		ociServiceConfig := new(OCIServiceConfig)
+
+		if storableServiceConfig.OCI.Logs != nil {
+			ociServiceConfig.Logs = &OCIServiceLogsConfig{
+				Enabled: storableServiceConfig.OCI.Logs.Enabled,
+			}
+		}
+
+		if storableServiceConfig.OCI.Metrics != nil {
+			ociServiceConfig.Metrics = &OCIServiceMetricsConfig{
+				Enabled: storableServiceConfig.OCI.Metrics.Enabled,
+			}
+		}
+
+		return &ServiceConfig{OCI: ociServiceConfig}, nil
+	case CloudProviderTypeDigitalOcean:
+		digitalOceanServiceConfig := new(DigitalOceanServiceConfig)
+
+		if storableServiceConfig.DigitalOcean.Logs != nil {
+			digitalOceanServiceConfig.Logs = &DigitalOceanServiceLogsConfig{
+				Enabled: storableServiceConfig.DigitalOcean.Logs.Enabled,
+			}
+		}
+
+		if storableServiceConfig.DigitalOcean.Metrics != nil {
+			digitalOceanServiceConfig.Metrics = &DigitalOceanServiceMetricsConfig{
+				Enabled: storableServiceConfig.DigitalOcean.Metrics.Enabled,
+			}
+		}
+
+		return &ServiceConfig{DigitalOcean: digitalOceanServiceConfig}, nil
 	default:
 		return nil, errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
 	}
@@ -303,6 +347,14 @@ func (service *CloudIntegrationService) Update(provider CloudProviderType, servi
 		if config.GCP == nil {
 			return errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "GCP config is required for GCP service")
 		}
+	case CloudProviderTypeOCI:
+		if config.OCI == nil {
+			return errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "OCI config is required for OCI service")
+		}
+	case CloudProviderTypeDigitalOcean:
+		if config.DigitalOcean == nil {
+			return errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "DigitalOcean config is required for DigitalOcean service")
+		}
 	default:
 		return errors.NewInvalidInputf(ErrCodeCloudProviderInvalidInput, "invalid cloud provider: %s", provider.StringValue())
 	}
@@ -328,6 +380,14 @@ func (config *ServiceConfig) IsServiceEnabled(provider CloudProviderType) bool {
 		logsEnabled := config.GCP.Logs != nil && config.GCP.Logs.Enabled
 		metricsEnabled := config.GCP.Metrics != nil && config.GCP.Metrics.Enabled
 		return logsEnabled || metricsEnabled
+	case CloudProviderTypeOCI:
+		logsEnabled := config.OCI.Logs != nil && config.OCI.Logs.Enabled
+		metricsEnabled := config.OCI.Metrics != nil && config.OCI.Metrics.Enabled
+		return logsEnabled || metricsEnabled
+	case CloudProviderTypeDigitalOcean:
+		logsEnabled := config.DigitalOcean.Logs != nil && config.DigitalOcean.Logs.Enabled
+		metricsEnabled := config.DigitalOcean.Metrics != nil && config.DigitalOcean.Metrics.Enabled
+		return logsEnabled || metricsEnabled
 	default:
 		return false
 	}
@@ -343,6 +403,10 @@ func (config *ServiceConfig) IsMetricsEnabled(provider CloudProviderType) bool {
 		return config.Azure.Metrics != nil && config.Azure.Metrics.Enabled
 	case CloudProviderTypeGCP:
 		return config.GCP.Metrics != nil && config.GCP.Metrics.Enabled
+	case CloudProviderTypeOCI:
+		return config.OCI.Metrics != nil && config.OCI.Metrics.Enabled
+	case CloudProviderTypeDigitalOcean:
+		return config.DigitalOcean.Metrics != nil && config.DigitalOcean.Metrics.Enabled
 	default:
 		return false
 	}
@@ -357,6 +421,10 @@ func (config *ServiceConfig) IsLogsEnabled(provider CloudProviderType) bool {
 		return config.Azure.Logs != nil && config.Azure.Logs.Enabled
 	case CloudProviderTypeGCP:
 		return config.GCP.Logs != nil && config.GCP.Logs.Enabled
+	case CloudProviderTypeOCI:
+		return config.OCI.Logs != nil && config.OCI.Logs.Enabled
+	case CloudProviderTypeDigitalOcean:
+		return config.DigitalOcean.Logs != nil && config.DigitalOcean.Logs.Enabled
 	default:
 		return false
 	}
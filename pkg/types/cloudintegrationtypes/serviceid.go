@@ -47,6 +47,17 @@ var (
 	GCPServiceGKE              = ServiceID{valuer.NewString("gke")}
 	GCPServiceCloudStorage     = ServiceID{valuer.NewString("cloudstorage")}
 	GCPServiceCloudSQLMySQL    = ServiceID{valuer.NewString("cloudsql_mysql")}
+
+	// OCI services.
+	OCIServiceComputeInstance    = ServiceID{valuer.NewString("computeinstance")}
+	OCIServiceObjectStorage      = ServiceID{valuer.NewString("objectstorage")}
+	OCIServiceLoadBalancer       = ServiceID{valuer.NewString("loadbalancer")}
+	OCIServiceAutonomousDatabase = ServiceID{valuer.NewString("autonomousdatabase")}
+
+	// DigitalOcean services.
+	DigitalOceanServiceDroplet         = ServiceID{valuer.NewString("droplet")}
+	DigitalOceanServiceManagedDatabase = ServiceID{valuer.NewString("managed_database")}
+	DigitalOceanServiceAppPlatform     = ServiceID{valuer.NewString("app_platform")}
 )
 
 func (ServiceID) Enum() []any {
@@ -84,6 +95,13 @@ func (ServiceID) Enum() []any {
 		GCPServiceGKE,
 		GCPServiceCloudStorage,
 		GCPServiceCloudSQLMySQL,
+		OCIServiceComputeInstance,
+		OCIServiceObjectStorage,
+		OCIServiceLoadBalancer,
+		OCIServiceAutonomousDatabase,
+		DigitalOceanServiceDroplet,
+		DigitalOceanServiceManagedDatabase,
+		DigitalOceanServiceAppPlatform,
 	}
 }
 
@@ -128,6 +146,17 @@ var SupportedServices = map[CloudProviderType][]ServiceID{
 		GCPServiceCloudStorage,
 		GCPServiceCloudSQLMySQL,
 	},
+	CloudProviderTypeOCI: {
+		OCIServiceComputeInstance,
+		OCIServiceObjectStorage,
+		OCIServiceLoadBalancer,
+		OCIServiceAutonomousDatabase,
+	},
+	CloudProviderTypeDigitalOcean: {
+		DigitalOceanServiceDroplet,
+		DigitalOceanServiceManagedDatabase,
+		DigitalOceanServiceAppPlatform,
+	},
 }
 
 func NewServiceID(provider CloudProviderType, service string) (ServiceID, error) {
@@ -5,10 +5,23 @@ import "context"
 type ctxKey string
 
 const (
-	ClickhouseContextMaxThreadsKey ctxKey = "clickhouse_max_threads"
+	ClickhouseContextMaxThreadsKey    ctxKey = "clickhouse_max_threads"
+	ClickhouseContextMaxRowsToReadKey ctxKey = "clickhouse_max_rows_to_read"
 )
 
 // SetClickhouseMaxThreads stores the max threads value in context.
 func SetClickhouseMaxThreads(ctx context.Context, maxThreads int) context.Context {
 	return context.WithValue(ctx, ClickhouseContextMaxThreadsKey, maxThreads)
 }
+
+// SetClickhouseMaxRowsToRead stores in context the number of rows after which queries
+// stop reading and return the result computed so far.
+func SetClickhouseMaxRowsToRead(ctx context.Context, maxRowsToRead uint64) context.Context {
+	return context.WithValue(ctx, ClickhouseContextMaxRowsToReadKey, maxRowsToRead)
+}
+
+// ClickhouseMaxRowsToReadFromContext returns the value stored by SetClickhouseMaxRowsToRead.
+func ClickhouseMaxRowsToReadFromContext(ctx context.Context) (uint64, bool) {
+	maxRowsToRead, ok := ctx.Value(ClickhouseContextMaxRowsToReadKey).(uint64)
+	return maxRowsToRead, ok
+}
@@ -0,0 +1,23 @@
+package ctxtypes
+
+import "context"
+
+type queryShapeCtxKey struct{}
+
+// QueryShape identifies the filter, group by and aggregations of the builder query an org
+// runs, for the telemetry store to count how often every shape is queried.
+type QueryShape struct {
+	OrgID string
+	Key   string
+}
+
+// NewContextWithQueryShape returns a context carrying the shape of the query run with it.
+func NewContextWithQueryShape(ctx context.Context, shape QueryShape) context.Context {
+	return context.WithValue(ctx, queryShapeCtxKey{}, shape)
+}
+
+// QueryShapeFromContext returns the shape stored by NewContextWithQueryShape.
+func QueryShapeFromContext(ctx context.Context) (QueryShape, bool) {
+	shape, ok := ctx.Value(queryShapeCtxKey{}).(QueryShape)
+	return shape, ok
+}
@@ -9,18 +9,23 @@ type CheckType struct {
 }
 
 var (
-	CheckTypeHosts          = CheckType{valuer.NewString("hosts")}
-	CheckTypeProcesses      = CheckType{valuer.NewString("processes")}
-	CheckTypePods           = CheckType{valuer.NewString("pods")}
-	CheckTypeNodes          = CheckType{valuer.NewString("nodes")}
-	CheckTypeDeployments    = CheckType{valuer.NewString("deployments")}
-	CheckTypeDaemonsets     = CheckType{valuer.NewString("daemonsets")}
-	CheckTypeStatefulsets   = CheckType{valuer.NewString("statefulsets")}
-	CheckTypeJobs           = CheckType{valuer.NewString("jobs")}
-	CheckTypeNamespaces     = CheckType{valuer.NewString("namespaces")}
-	CheckTypeClusters       = CheckType{valuer.NewString("clusters")}
-	CheckTypeVolumes        = CheckType{valuer.NewString("volumes")}
-	CheckTypeKubeContainers = CheckType{valuer.NewString("kube_containers")}
+	CheckTypeHosts            = CheckType{valuer.NewString("hosts")}
+	CheckTypeProcesses        = CheckType{valuer.NewString("processes")}
+	CheckTypePods             = CheckType{valuer.NewString("pods")}
+	CheckTypeNodes            = CheckType{valuer.NewString("nodes")}
+	CheckTypeDeployments      = CheckType{valuer.NewString("deployments")}
+	CheckTypeDaemonsets       = CheckType{valuer.NewString("daemonsets")}
+	CheckTypeStatefulsets     = CheckType{valuer.NewString("statefulsets")}
+	CheckTypeJobs             = CheckType{valuer.NewString("jobs")}
+	CheckTypeNamespaces       = CheckType{valuer.NewString("namespaces")}
+	CheckTypeClusters         = CheckType{valuer.NewString("clusters")}
+	CheckTypeVolumes          = CheckType{valuer.NewString("volumes")}
+	CheckTypeKubeContainers   = CheckType{valuer.NewString("kube_containers")}
+	CheckTypeK8sEvents        = CheckType{valuer.NewString("k8s_events")}
+	CheckTypeECSClusters      = CheckType{valuer.NewString("ecs_clusters")}
+	CheckTypeECSServices      = CheckType{valuer.NewString("ecs_services")}
+	CheckTypeECSTasks         = CheckType{valuer.NewString("ecs_tasks")}
+	CheckTypeDockerContainers = CheckType{valuer.NewString("docker_containers")}
 )
 
 func (CheckType) Enum() []any {
@@ -37,6 +42,11 @@ func (CheckType) Enum() []any {
 		CheckTypeClusters,
 		CheckTypeVolumes,
 		CheckTypeKubeContainers,
+		CheckTypeK8sEvents,
+		CheckTypeECSClusters,
+		CheckTypeECSServices,
+		CheckTypeECSTasks,
+		CheckTypeDockerContainers,
 	}
 }
 
@@ -53,6 +63,11 @@ var ValidCheckTypes = []CheckType{
 	CheckTypeClusters,
 	CheckTypeVolumes,
 	CheckTypeKubeContainers,
+	CheckTypeK8sEvents,
+	CheckTypeECSClusters,
+	CheckTypeECSServices,
+	CheckTypeECSTasks,
+	CheckTypeDockerContainers,
 }
 
 // CheckComponentType tags each AssociatedComponent as either a receiver or a processor.
@@ -0,0 +1,104 @@
+package inframonitoringtypes
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+type DockerContainers struct {
+	Type                   ResponseType            `json:"type" required:"true"`
+	Records                []DockerContainerRecord `json:"records" required:"true" nullable:"false"`
+	Total                  int                     `json:"total" required:"true"`
+	EndTimeBeforeRetention bool                    `json:"endTimeBeforeRetention" required:"true"`
+	Warning                *qbtypes.QueryWarnData  `json:"warning,omitempty"`
+}
+
+type DockerContainerRecord struct {
+	ContainerName  string            `json:"containerName" required:"true"`
+	CPUUtilization float64           `json:"cpuUtilization" required:"true"`
+	MemoryUsage    float64           `json:"memoryUsage" required:"true"`
+	MemoryLimit    float64           `json:"memoryLimit" required:"true"`
+	MemoryPercent  float64           `json:"memoryPercent" required:"true"`
+	NetworkRxRate  float64           `json:"networkRxRate" required:"true"`
+	NetworkTxRate  float64           `json:"networkTxRate" required:"true"`
+	Meta           map[string]string `json:"meta" required:"true"`
+}
+
+// PostableDockerContainers is the request body for the v2 Docker containers list API.
+type PostableDockerContainers struct {
+	Start   int64                `json:"start" required:"true"`
+	End     int64                `json:"end" required:"true"`
+	Filter  *qbtypes.Filter      `json:"filter"`
+	GroupBy []qbtypes.GroupByKey `json:"groupBy"`
+	OrderBy *qbtypes.OrderBy     `json:"orderBy"`
+	Offset  int                  `json:"offset"`
+	Limit   int                  `json:"limit" required:"true"`
+}
+
+// Validate ensures PostableDockerContainers contains acceptable values.
+func (req *PostableDockerContainers) Validate() error {
+	if req == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "request is nil")
+	}
+
+	if req.Start <= 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid start time %d: start must be greater than 0",
+			req.Start,
+		)
+	}
+
+	if req.End <= 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid end time %d: end must be greater than 0",
+			req.End,
+		)
+	}
+
+	if req.Start >= req.End {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid time range: start (%d) must be less than end (%d)",
+			req.Start,
+			req.End,
+		)
+	}
+
+	if req.Limit < 1 || req.Limit > 5000 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 1 and 5000")
+	}
+
+	if req.Offset < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "offset cannot be negative")
+	}
+
+	if req.OrderBy != nil {
+		if !slices.Contains(DockerContainersValidOrderByKeys, req.OrderBy.Key.Name) {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid order by key: %s", req.OrderBy.Key.Name)
+		}
+		if req.OrderBy.Direction != qbtypes.OrderDirectionAsc && req.OrderBy.Direction != qbtypes.OrderDirectionDesc {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid order by direction: %s", req.OrderBy.Direction)
+		}
+		if req.OrderBy.Key.Name == DockerContainerNameAttrKey && len(req.GroupBy) > 0 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "order by '%s' is only allowed when groupBy is empty", DockerContainerNameAttrKey)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON validates input immediately after decoding.
+func (req *PostableDockerContainers) UnmarshalJSON(data []byte) error {
+	type raw PostableDockerContainers
+	var decoded raw
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*req = PostableDockerContainers(decoded)
+	return req.Validate()
+}
@@ -0,0 +1,22 @@
+package inframonitoringtypes
+
+const DockerContainerNameAttrKey = "container.name"
+
+const (
+	DockerContainersOrderByCPU           = "cpu"
+	DockerContainersOrderByMemory        = "memory"
+	DockerContainersOrderByMemoryLimit   = "memory_limit"
+	DockerContainersOrderByMemoryPercent = "memory_percent"
+	DockerContainersOrderByNetworkRx     = "network_rx"
+	DockerContainersOrderByNetworkTx     = "network_tx"
+)
+
+var DockerContainersValidOrderByKeys = []string{
+	DockerContainersOrderByCPU,
+	DockerContainersOrderByMemory,
+	DockerContainersOrderByMemoryLimit,
+	DockerContainersOrderByMemoryPercent,
+	DockerContainersOrderByNetworkRx,
+	DockerContainersOrderByNetworkTx,
+	DockerContainerNameAttrKey,
+}
@@ -0,0 +1,18 @@
+package inframonitoringtypes
+
+const ECSClusterNameAttrKey = "aws.ecs.cluster.name"
+
+const (
+	ECSClustersOrderByCPU            = "cpu"
+	ECSClustersOrderByCPUReserved    = "cpu_reserved"
+	ECSClustersOrderByMemory         = "memory"
+	ECSClustersOrderByMemoryReserved = "memory_reserved"
+)
+
+var ECSClustersValidOrderByKeys = []string{
+	ECSClustersOrderByCPU,
+	ECSClustersOrderByCPUReserved,
+	ECSClustersOrderByMemory,
+	ECSClustersOrderByMemoryReserved,
+	ECSClusterNameAttrKey,
+}
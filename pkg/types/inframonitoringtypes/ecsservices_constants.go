@@ -0,0 +1,18 @@
+package inframonitoringtypes
+
+const ECSServiceNameAttrKey = "aws.ecs.service.name"
+
+const (
+	ECSServicesOrderByCPU            = "cpu"
+	ECSServicesOrderByCPUReserved    = "cpu_reserved"
+	ECSServicesOrderByMemory         = "memory"
+	ECSServicesOrderByMemoryReserved = "memory_reserved"
+)
+
+var ECSServicesValidOrderByKeys = []string{
+	ECSServicesOrderByCPU,
+	ECSServicesOrderByCPUReserved,
+	ECSServicesOrderByMemory,
+	ECSServicesOrderByMemoryReserved,
+	ECSServiceNameAttrKey,
+}
@@ -0,0 +1,22 @@
+package inframonitoringtypes
+
+const ECSTaskIDAttrKey = "aws.ecs.task.id"
+
+const (
+	ECSTasksOrderByCPU            = "cpu"
+	ECSTasksOrderByCPUReserved    = "cpu_reserved"
+	ECSTasksOrderByMemory         = "memory"
+	ECSTasksOrderByMemoryReserved = "memory_reserved"
+	ECSTasksOrderByNetworkRx      = "network_rx"
+	ECSTasksOrderByNetworkTx      = "network_tx"
+)
+
+var ECSTasksValidOrderByKeys = []string{
+	ECSTasksOrderByCPU,
+	ECSTasksOrderByCPUReserved,
+	ECSTasksOrderByMemory,
+	ECSTasksOrderByMemoryReserved,
+	ECSTasksOrderByNetworkRx,
+	ECSTasksOrderByNetworkTx,
+	ECSTaskIDAttrKey,
+}
@@ -0,0 +1,112 @@
+package inframonitoringtypes
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+// EntityGraph is the neighbourhood of a Kubernetes entity: its owners (Parents), the
+// entities it owns (Children) and the entities sharing its owner (Siblings). Membership
+// spans the whole time range, so entities which churned during it are listed too.
+// Events are the most recent Kubernetes events about the entity, for the types events
+// are attached to (pods, nodes and workloads).
+type EntityGraph struct {
+	Entity                 *Entity                `json:"entity" required:"true" nullable:"false"`
+	Parents                []*Entity              `json:"parents" required:"true" nullable:"false"`
+	Children               []*Entity              `json:"children" required:"true" nullable:"false"`
+	Siblings               []*Entity              `json:"siblings" required:"true" nullable:"false"`
+	Events                 []K8sEvent             `json:"events" required:"true" nullable:"false"`
+	EndTimeBeforeRetention bool                   `json:"endTimeBeforeRetention" required:"true"`
+	Warning                *qbtypes.QueryWarnData `json:"warning,omitempty"`
+}
+
+// Entity is a node of the entity graph. Attributes are its identifying k8s.* resource
+// attributes and Metrics its health metrics over the time range, keyed like the order by
+// keys of the list API of its type. Replicasets and cronjobs have no metrics of their own.
+// EventCounts counts the Kubernetes events about pods, nodes and workloads over the time
+// range; it is null for the other types.
+type Entity struct {
+	Type        EntityType         `json:"type" required:"true"`
+	Name        string             `json:"name" required:"true"`
+	Attributes  map[string]string  `json:"attributes" required:"true" nullable:"false"`
+	Metrics     map[string]float64 `json:"metrics" required:"true" nullable:"false"`
+	EventCounts *K8sEventCounts    `json:"eventCounts" required:"true" nullable:"true"`
+}
+
+// EntityRef identifies the entity to build the graph of. Attributes must carry the name
+// attribute of the type, e.g. k8s.pod.name for pods, and may carry the other identifying
+// attributes (k8s.cluster.name, k8s.namespace.name, ...) to disambiguate it.
+type EntityRef struct {
+	Type       EntityType        `json:"type" required:"true"`
+	Attributes map[string]string `json:"attributes" required:"true" nullable:"false"`
+}
+
+// PostableEntityGraph is the request body for the v2 entity graph API.
+type PostableEntityGraph struct {
+	Start  int64     `json:"start" required:"true"`
+	End    int64     `json:"end" required:"true"`
+	Entity EntityRef `json:"entity" required:"true"`
+}
+
+// Validate ensures PostableEntityGraph contains acceptable values.
+func (req *PostableEntityGraph) Validate() error {
+	if req == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "request is nil")
+	}
+
+	if req.Start <= 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid start time %d: start must be greater than 0",
+			req.Start,
+		)
+	}
+
+	if req.End <= 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid end time %d: end must be greater than 0",
+			req.End,
+		)
+	}
+
+	if req.Start >= req.End {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid time range: start (%d) must be less than end (%d)",
+			req.Start,
+			req.End,
+		)
+	}
+
+	if !slices.Contains(ValidEntityTypes, req.Entity.Type) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid entity type: %s", req.Entity.Type)
+	}
+
+	identityKeys := EntityIdentityAttrKeys[req.Entity.Type]
+	for key := range req.Entity.Attributes {
+		if !slices.Contains(identityKeys, key) {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid attribute %s for entity type %s: allowed attributes are %v", key, req.Entity.Type, identityKeys)
+		}
+	}
+
+	if req.Entity.Attributes[req.Entity.Type.NameAttrKey()] == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "attribute %s is required for entity type %s", req.Entity.Type.NameAttrKey(), req.Entity.Type)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON validates input immediately after decoding.
+func (req *PostableEntityGraph) UnmarshalJSON(data []byte) error {
+	type raw PostableEntityGraph
+	var decoded raw
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*req = PostableEntityGraph(decoded)
+	return req.Validate()
+}
@@ -0,0 +1,87 @@
+package inframonitoringtypes
+
+import "github.com/SigNoz/signoz/pkg/valuer"
+
+const (
+	ReplicaSetNameAttrKey = "k8s.replicaset.name"
+	CronJobNameAttrKey    = "k8s.cronjob.name"
+)
+
+// EntityType identifies a kind of Kubernetes entity in the relationship graph.
+type EntityType struct {
+	valuer.String
+}
+
+var (
+	EntityTypeCluster     = EntityType{valuer.NewString("cluster")}
+	EntityTypeNode        = EntityType{valuer.NewString("node")}
+	EntityTypeNamespace   = EntityType{valuer.NewString("namespace")}
+	EntityTypeDeployment  = EntityType{valuer.NewString("deployment")}
+	EntityTypeReplicaSet  = EntityType{valuer.NewString("replicaset")}
+	EntityTypeStatefulSet = EntityType{valuer.NewString("statefulset")}
+	EntityTypeDaemonSet   = EntityType{valuer.NewString("daemonset")}
+	EntityTypeCronJob     = EntityType{valuer.NewString("cronjob")}
+	EntityTypeJob         = EntityType{valuer.NewString("job")}
+	EntityTypePod         = EntityType{valuer.NewString("pod")}
+	EntityTypeContainer   = EntityType{valuer.NewString("container")}
+	EntityTypeVolume      = EntityType{valuer.NewString("volume")}
+)
+
+func (EntityType) Enum() []any {
+	return []any{
+		EntityTypeCluster,
+		EntityTypeNode,
+		EntityTypeNamespace,
+		EntityTypeDeployment,
+		EntityTypeReplicaSet,
+		EntityTypeStatefulSet,
+		EntityTypeDaemonSet,
+		EntityTypeCronJob,
+		EntityTypeJob,
+		EntityTypePod,
+		EntityTypeContainer,
+		EntityTypeVolume,
+	}
+}
+
+var ValidEntityTypes = []EntityType{
+	EntityTypeCluster,
+	EntityTypeNode,
+	EntityTypeNamespace,
+	EntityTypeDeployment,
+	EntityTypeReplicaSet,
+	EntityTypeStatefulSet,
+	EntityTypeDaemonSet,
+	EntityTypeCronJob,
+	EntityTypeJob,
+	EntityTypePod,
+	EntityTypeContainer,
+	EntityTypeVolume,
+}
+
+// EntityIdentityAttrKeys are the resource attributes identifying an entity of each type,
+// from the cluster down to its name. Namespaced entities are only unique within their
+// namespace, and containers within their pod.
+var EntityIdentityAttrKeys = map[EntityType][]string{
+	EntityTypeCluster:     {ClusterNameAttrKey},
+	EntityTypeNode:        {ClusterNameAttrKey, NodeNameAttrKey},
+	EntityTypeNamespace:   {ClusterNameAttrKey, NamespaceNameAttrKey},
+	EntityTypeDeployment:  {ClusterNameAttrKey, NamespaceNameAttrKey, DeploymentNameAttrKey},
+	EntityTypeReplicaSet:  {ClusterNameAttrKey, NamespaceNameAttrKey, ReplicaSetNameAttrKey},
+	EntityTypeStatefulSet: {ClusterNameAttrKey, NamespaceNameAttrKey, StatefulSetNameAttrKey},
+	EntityTypeDaemonSet:   {ClusterNameAttrKey, NamespaceNameAttrKey, DaemonSetNameAttrKey},
+	EntityTypeCronJob:     {ClusterNameAttrKey, NamespaceNameAttrKey, CronJobNameAttrKey},
+	EntityTypeJob:         {ClusterNameAttrKey, NamespaceNameAttrKey, JobNameAttrKey},
+	EntityTypePod:         {ClusterNameAttrKey, NamespaceNameAttrKey, PodNameAttrKey},
+	EntityTypeContainer:   {ClusterNameAttrKey, NamespaceNameAttrKey, PodNameAttrKey, ContainerNameAttrKey},
+	EntityTypeVolume:      {ClusterNameAttrKey, NamespaceNameAttrKey, PersistentVolumeClaimNameAttrKey},
+}
+
+// NameAttrKey returns the resource attribute holding the name of entities of the type.
+func (t EntityType) NameAttrKey() string {
+	keys := EntityIdentityAttrKeys[t]
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[len(keys)-1]
+}
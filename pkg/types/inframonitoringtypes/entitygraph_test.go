@@ -0,0 +1,113 @@
+package inframonitoringtypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableEntityGraph_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *PostableEntityGraph
+		wantErr bool
+	}{
+		{
+			name: "valid pod",
+			req: &PostableEntityGraph{
+				Start: 1000,
+				End:   2000,
+				Entity: EntityRef{
+					Type:       EntityTypePod,
+					Attributes: map[string]string{PodNameAttrKey: "api-0", NamespaceNameAttrKey: "default"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid replicaset",
+			req: &PostableEntityGraph{
+				Start: 1000,
+				End:   2000,
+				Entity: EntityRef{
+					Type:       EntityTypeReplicaSet,
+					Attributes: map[string]string{ReplicaSetNameAttrKey: "api-7d9f"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nil request",
+			req:     nil,
+			wantErr: true,
+		},
+		{
+			name: "start time zero",
+			req: &PostableEntityGraph{
+				Start:  0,
+				End:    2000,
+				Entity: EntityRef{Type: EntityTypeNode, Attributes: map[string]string{NodeNameAttrKey: "node-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "end time zero",
+			req: &PostableEntityGraph{
+				Start:  1000,
+				End:    0,
+				Entity: EntityRef{Type: EntityTypeNode, Attributes: map[string]string{NodeNameAttrKey: "node-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "start time after end time",
+			req: &PostableEntityGraph{
+				Start:  2000,
+				End:    1000,
+				Entity: EntityRef{Type: EntityTypeNode, Attributes: map[string]string{NodeNameAttrKey: "node-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid entity type",
+			req: &PostableEntityGraph{
+				Start:  1000,
+				End:    2000,
+				Entity: EntityRef{Type: EntityType{valuer.NewString("service")}, Attributes: map[string]string{"service.name": "api"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing name attribute",
+			req: &PostableEntityGraph{
+				Start:  1000,
+				End:    2000,
+				Entity: EntityRef{Type: EntityTypePod, Attributes: map[string]string{NamespaceNameAttrKey: "default"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "attribute not identifying the type",
+			req: &PostableEntityGraph{
+				Start:  1000,
+				End:    2000,
+				Entity: EntityRef{Type: EntityTypeNode, Attributes: map[string]string{NodeNameAttrKey: "node-1", NamespaceNameAttrKey: "default"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, errors.Ast(err, errors.TypeInvalidInput), "expected error to be of type InvalidInput")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
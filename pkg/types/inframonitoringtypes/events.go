@@ -0,0 +1,26 @@
+package inframonitoringtypes
+
+// K8sEvent is a Kubernetes event collected by the k8sobjectsreceiver (watching
+// events) and stored as a log record. Timestamp is the unix milli of the record.
+type K8sEvent struct {
+	Timestamp      int64             `json:"timestamp" required:"true"`
+	Type           string            `json:"type" required:"true"`
+	Reason         string            `json:"reason" required:"true"`
+	Message        string            `json:"message" required:"true"`
+	Count          int64             `json:"count" required:"true"`
+	Source         string            `json:"source" required:"true"`
+	InvolvedObject K8sInvolvedObject `json:"involvedObject" required:"true"`
+}
+
+// K8sInvolvedObject is the object a Kubernetes event is about.
+type K8sInvolvedObject struct {
+	Kind      string `json:"kind" required:"true"`
+	Name      string `json:"name" required:"true"`
+	Namespace string `json:"namespace" required:"true"`
+}
+
+// K8sEventCounts counts the Kubernetes events about an entity in a time range.
+type K8sEventCounts struct {
+	Total   int64 `json:"total" required:"true"`
+	Warning int64 `json:"warning" required:"true"`
+}
@@ -0,0 +1,26 @@
+package inframonitoringtypes
+
+// Kubernetes event types, as reported in the type field of events.
+const (
+	K8sEventTypeNormal  = "Normal"
+	K8sEventTypeWarning = "Warning"
+)
+
+// entityTypeEventKinds maps the entity types Kubernetes reports events about to
+// the kind of the involved object of their events.
+var entityTypeEventKinds = map[EntityType]string{
+	EntityTypeNode:        "Node",
+	EntityTypeDeployment:  "Deployment",
+	EntityTypeReplicaSet:  "ReplicaSet",
+	EntityTypeStatefulSet: "StatefulSet",
+	EntityTypeDaemonSet:   "DaemonSet",
+	EntityTypeCronJob:     "CronJob",
+	EntityTypeJob:         "Job",
+	EntityTypePod:         "Pod",
+}
+
+// EventKind returns the kind of the involved object of the events about entities of
+// the type, empty when events are not attached to them.
+func (t EntityType) EventKind() string {
+	return entityTypeEventKinds[t]
+}
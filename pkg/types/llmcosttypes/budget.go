@@ -0,0 +1,207 @@
+package llmcosttypes
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	grammar "github.com/SigNoz/signoz/pkg/parser/filterquery/grammar"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/swaggest/jsonschema-go"
+	"github.com/uptrace/bun"
+)
+
+// budgetNameRegex keeps budget names short and readable.
+var budgetNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\- ]{0,63}$`)
+
+// Budget caps the monthly spend of the gen_ai spans carrying an attribute value, e.g. the
+// spans of the team=search. Months are calendar months in UTC.
+type Budget struct {
+	bun.BaseModel `bun:"table:llm_budget,alias:llm_budget" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID          valuer.UUID `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Name           string      `bun:"name,type:text,notnull" json:"name" required:"true"`
+	AttributeKey   string      `bun:"attribute_key,type:text,notnull" json:"attributeKey" required:"true"`
+	AttributeValue string      `bun:"attribute_value,type:text,notnull" json:"attributeValue" required:"true"`
+	MonthlyLimit   float64     `bun:"monthly_limit,notnull" json:"monthlyLimit" required:"true"`
+	// AlertRuleID is the alert rule created for the budget, which follows its updates and
+	// is deleted with it.
+	AlertRuleID *valuer.UUID `bun:"alert_rule_id,type:text" json:"alertRuleId,omitempty"`
+}
+
+// PostableBudget is the request body to create or update a budget.
+type PostableBudget struct {
+	Name string `json:"name" required:"true"`
+	// AttributeKey is the span or resource attribute the budget applies to, e.g. team or
+	// resource.team.
+	AttributeKey   string  `json:"attributeKey" required:"true"`
+	AttributeValue string  `json:"attributeValue" required:"true"`
+	MonthlyLimit   float64 `json:"monthlyLimit" required:"true"`
+}
+
+func (p *PostableBudget) Validate() error {
+	if !budgetNameRegex.MatchString(p.Name) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "name %q must start with a letter and contain at most 64 letters, digits, spaces, '_' or '-'", p.Name)
+	}
+	if strings.TrimSpace(p.AttributeKey) == "" {
+		return errors.New(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "attributeKey must not be empty")
+	}
+	if !isFieldKey(strings.TrimSpace(p.AttributeKey)) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "attributeKey %q must be a single attribute key, e.g. team or resource.team", p.AttributeKey)
+	}
+	if p.AttributeValue == "" {
+		return errors.New(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "attributeValue must not be empty")
+	}
+	if p.MonthlyLimit <= 0 || math.IsInf(p.MonthlyLimit, 0) || math.IsNaN(p.MonthlyLimit) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "monthlyLimit must be a positive number, got %v", p.MonthlyLimit)
+	}
+	return nil
+}
+
+// isFieldKey reports whether key lexes as exactly one key of the filter grammar, so it
+// cannot change the filter it is put in.
+func isFieldKey(key string) bool {
+	lexer := grammar.NewFilterQueryLexer(antlr.NewInputStream(key))
+	lexer.RemoveErrorListeners()
+
+	tok := lexer.NextToken()
+	if tok.GetTokenType() != grammar.FilterQueryLexerKEY || tok.GetText() != key {
+		return false
+	}
+	return lexer.NextToken().GetTokenType() == antlr.TokenEOF
+}
+
+func NewBudget(orgID valuer.UUID, createdBy string, p *PostableBudget) *Budget {
+	now := time.Now()
+	return &Budget{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:          orgID,
+		Name:           p.Name,
+		AttributeKey:   strings.TrimSpace(p.AttributeKey),
+		AttributeValue: p.AttributeValue,
+		MonthlyLimit:   p.MonthlyLimit,
+	}
+}
+
+// Update replaces the definition of the budget with the one of p.
+func (b *Budget) Update(updatedBy string, p *PostableBudget) {
+	b.Name = p.Name
+	b.AttributeKey = strings.TrimSpace(p.AttributeKey)
+	b.AttributeValue = p.AttributeValue
+	b.MonthlyLimit = p.MonthlyLimit
+	b.UpdatedBy = updatedBy
+	b.UpdatedAt = time.Now()
+}
+
+// FilterExpression returns the filter selecting the spans the budget applies to.
+func (b *Budget) FilterExpression() string {
+	escaped := strings.ReplaceAll(b.AttributeValue, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
+	return b.AttributeKey + " = '" + escaped + "'"
+}
+
+// BudgetStatus is the spend of a budget over the current month, and its spend at the end
+// of the month forecast from the month-to-date rate.
+type BudgetStatus struct {
+	MonthStart time.Time `json:"monthStart" required:"true"`
+	MonthEnd   time.Time `json:"monthEnd" required:"true"`
+	// Spend is the month-to-date spend.
+	Spend       float64 `json:"spend" required:"true"`
+	Remaining   float64 `json:"remaining" required:"true"`
+	UsedPercent float64 `json:"usedPercent" required:"true"`
+	Exceeded    bool    `json:"exceeded" required:"true"`
+	// Forecast is the spend at the end of the month if it keeps the month-to-date rate.
+	Forecast        float64 `json:"forecast" required:"true"`
+	ForecastPercent float64 `json:"forecastPercent" required:"true"`
+	ForecastOverrun bool    `json:"forecastOverrun" required:"true"`
+	// OverrunAt is when the spend is forecast to reach the limit, if it has not yet and
+	// does before the end of the month.
+	OverrunAt *time.Time `json:"overrunAt,omitempty"`
+}
+
+// MonthOf returns the calendar month, in UTC, of t.
+func MonthOf(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// NewBudgetStatus returns the status of a budget of the limit at now, given the spend of
+// the month so far.
+func NewBudgetStatus(limit, spend float64, now time.Time) *BudgetStatus {
+	monthStart, monthEnd := MonthOf(now)
+	status := &BudgetStatus{
+		MonthStart:  monthStart,
+		MonthEnd:    monthEnd,
+		Spend:       spend,
+		Remaining:   limit - spend,
+		UsedPercent: spend / limit * 100,
+		Exceeded:    spend >= limit,
+		Forecast:    spend,
+	}
+
+	elapsed := now.Sub(monthStart)
+	if elapsed > 0 && spend > 0 {
+		rate := spend / elapsed.Seconds()
+		status.Forecast = rate * monthEnd.Sub(monthStart).Seconds()
+		if !status.Exceeded {
+			overrunAt := monthStart.Add(time.Duration(limit / rate * float64(time.Second)))
+			if overrunAt.Before(monthEnd) {
+				status.OverrunAt = &overrunAt
+			}
+		}
+	}
+	status.ForecastPercent = status.Forecast / limit * 100
+	status.ForecastOverrun = status.Forecast > limit
+
+	return status
+}
+
+// GettableBudget is a budget with its status for the current month.
+type GettableBudget struct {
+	Budget
+	Status *BudgetStatus `json:"status" required:"true"`
+}
+
+type GettableBudgets struct {
+	Items []*GettableBudget `json:"items" required:"true" nullable:"false"`
+}
+
+var _ jsonschema.Preparer = &BudgetStatus{}
+
+// PrepareJSONSchema adds description to the BudgetStatus schema.
+func (s *BudgetStatus) PrepareJSONSchema(schema *jsonschema.Schema) error {
+	schema.WithDescription("Spend of the budget over the current calendar month in UTC, and the spend at the end of the month forecast linearly from the month-to-date rate. Early in the month the forecast extrapolates few hours of data and is noisy.")
+	return nil
+}
+
+// Store persists the budgets.
+type Store interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*Budget, error)
+	Get(ctx context.Context, orgID, id valuer.UUID) (*Budget, error)
+	Create(ctx context.Context, budget *Budget) error
+	Update(ctx context.Context, budget *Budget) error
+	Delete(ctx context.Context, orgID, id valuer.UUID) error
+
+	// SetAlertRuleID sets the alert rule of a budget which has none.
+	SetAlertRuleID(ctx context.Context, orgID, id, ruleID valuer.UUID) error
+	// UnsetAlertRuleID unsets the alert rule of a budget if it still is ruleID.
+	UnsetAlertRuleID(ctx context.Context, orgID, id, ruleID valuer.UUID) error
+}
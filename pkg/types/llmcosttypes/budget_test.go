@@ -0,0 +1,85 @@
+package llmcosttypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableBudgetValidate(t *testing.T) {
+	valid := PostableBudget{Name: "search team", AttributeKey: "team", AttributeValue: "search", MonthlyLimit: 500}
+	require.NoError(t, valid.Validate())
+	resourceKey := PostableBudget{Name: "search team", AttributeKey: " resource.team ", AttributeValue: "search", MonthlyLimit: 500}
+	require.NoError(t, resourceKey.Validate())
+
+	testCases := []struct {
+		name   string
+		mutate func(*PostableBudget)
+	}{
+		{name: "invalid name", mutate: func(p *PostableBudget) { p.Name = "1st" }},
+		{name: "empty attribute key", mutate: func(p *PostableBudget) { p.AttributeKey = " " }},
+		{name: "attribute key rewriting the filter", mutate: func(p *PostableBudget) { p.AttributeKey = "x = 'a' OR service.name" }},
+		{name: "attribute key with a quote", mutate: func(p *PostableBudget) { p.AttributeKey = "team'" }},
+		{name: "attribute key keyword", mutate: func(p *PostableBudget) { p.AttributeKey = "OR" }},
+		{name: "empty attribute value", mutate: func(p *PostableBudget) { p.AttributeValue = "" }},
+		{name: "zero limit", mutate: func(p *PostableBudget) { p.MonthlyLimit = 0 }},
+		{name: "negative limit", mutate: func(p *PostableBudget) { p.MonthlyLimit = -1 }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := valid
+			tc.mutate(&p)
+			err := p.Validate()
+			require.Error(t, err)
+			assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+		})
+	}
+}
+
+func TestBudgetFilterExpression(t *testing.T) {
+	budget := &Budget{AttributeKey: "resource.team", AttributeValue: `o'brien\`}
+	assert.Equal(t, `resource.team = 'o\'brien\\'`, budget.FilterExpression())
+}
+
+func TestNewBudgetStatus(t *testing.T) {
+	// 30-day month; 10 days in.
+	now := time.Date(2026, time.June, 11, 0, 0, 0, 0, time.UTC)
+
+	t.Run("on track", func(t *testing.T) {
+		status := NewBudgetStatus(100, 20, now)
+		assert.Equal(t, time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC), status.MonthStart)
+		assert.Equal(t, time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC), status.MonthEnd)
+		assert.InDelta(t, 80, status.Remaining, 1e-9)
+		assert.InDelta(t, 20, status.UsedPercent, 1e-9)
+		assert.InDelta(t, 60, status.Forecast, 1e-9)
+		assert.False(t, status.ForecastOverrun)
+		assert.Nil(t, status.OverrunAt)
+	})
+
+	t.Run("forecast overrun", func(t *testing.T) {
+		status := NewBudgetStatus(100, 50, now)
+		assert.InDelta(t, 150, status.Forecast, 1e-9)
+		assert.True(t, status.ForecastOverrun)
+		assert.False(t, status.Exceeded)
+		require.NotNil(t, status.OverrunAt)
+		assert.Equal(t, time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC), *status.OverrunAt)
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		status := NewBudgetStatus(100, 120, now)
+		assert.True(t, status.Exceeded)
+		assert.True(t, status.ForecastOverrun)
+		assert.InDelta(t, -20, status.Remaining, 1e-9)
+		assert.Nil(t, status.OverrunAt)
+	})
+
+	t.Run("no spend", func(t *testing.T) {
+		status := NewBudgetStatus(100, 0, now)
+		assert.Zero(t, status.Forecast)
+		assert.False(t, status.ForecastOverrun)
+		assert.Nil(t, status.OverrunAt)
+	})
+}
@@ -0,0 +1,95 @@
+package llmcosttypes
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/swaggest/jsonschema-go"
+)
+
+var (
+	ErrCodeLLMCostInvalidInput      = errors.MustNewCode("llm_cost_invalid_input")
+	ErrCodeLLMBudgetNotFound        = errors.MustNewCode("llm_budget_not_found")
+	ErrCodeLLMBudgetExists          = errors.MustNewCode("llm_budget_already_exists")
+	ErrCodeLLMBudgetAlertRuleExists = errors.MustNewCode("llm_budget_alert_rule_already_exists")
+)
+
+const (
+	// MaxGroupBy is the largest number of group by keys of a breakdown.
+	MaxGroupBy = 3
+	// DefaultLimit is the number of groups of a breakdown when none is requested.
+	DefaultLimit = 10
+	// MaxLimit is the largest number of groups of a breakdown.
+	MaxLimit = 100
+)
+
+// PostableCostBreakdown is the request body of the spend breakdown: the window in epoch
+// milliseconds, a filter over the gen_ai spans and the attributes to group the spend by,
+// e.g. gen_ai.request.model, gen_ai.provider.name, gen_ai.agent.name, gen_ai.tool.name or
+// any custom attribute. Costs are attached to the priced LLM spans, so an attribute only
+// breaks the spend down when it is set on those spans.
+type PostableCostBreakdown struct {
+	Start   uint64               `json:"start" required:"true"`
+	End     uint64               `json:"end" required:"true"`
+	Filter  *qbtypes.Filter      `json:"filter"`
+	GroupBy []qbtypes.GroupByKey `json:"groupBy"`
+	// Step is the interval of the series, picked from the window when empty.
+	Step  qbtypes.Step `json:"step"`
+	Limit int          `json:"limit"`
+}
+
+func (p *PostableCostBreakdown) Validate() error {
+	if p.End <= p.Start {
+		return errors.New(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "end must be after start")
+	}
+	if len(p.GroupBy) > MaxGroupBy {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "at most %d group by keys are supported, got %d", MaxGroupBy, len(p.GroupBy))
+	}
+	for _, key := range p.GroupBy {
+		if key.Name == "" {
+			return errors.New(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "group by key name must not be empty")
+		}
+	}
+	if p.Step.Duration < 0 {
+		return errors.New(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "step must not be negative")
+	}
+	if p.Limit < 0 || p.Limit > MaxLimit {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeLLMCostInvalidInput, "limit must be between 0 and %d, got %d", MaxLimit, p.Limit)
+	}
+	if p.Limit == 0 {
+		p.Limit = DefaultLimit
+	}
+	return nil
+}
+
+// CostPoint is the spend of a group over one step of the window.
+type CostPoint struct {
+	Timestamp int64   `json:"timestamp" required:"true"`
+	Cost      float64 `json:"cost" required:"true"`
+}
+
+// CostGroup is the spend of the spans sharing the values of the group by keys, split by
+// the token kind it was priced for.
+type CostGroup struct {
+	Labels         map[string]string `json:"labels" required:"true" nullable:"false"`
+	TotalCost      float64           `json:"totalCost" required:"true"`
+	InputCost      float64           `json:"inputCost" required:"true"`
+	OutputCost     float64           `json:"outputCost" required:"true"`
+	CacheReadCost  float64           `json:"cacheReadCost" required:"true"`
+	CacheWriteCost float64           `json:"cacheWriteCost" required:"true"`
+	Series         []*CostPoint      `json:"series" required:"true" nullable:"false"`
+}
+
+// GettableCostBreakdown is the spend over the window, in total and for the groups
+// spending the most.
+type GettableCostBreakdown struct {
+	TotalCost float64      `json:"totalCost" required:"true"`
+	Groups    []*CostGroup `json:"groups" required:"true" nullable:"false"`
+}
+
+var _ jsonschema.Preparer = &GettableCostBreakdown{}
+
+// PrepareJSONSchema adds description to the GettableCostBreakdown schema.
+func (b *GettableCostBreakdown) PrepareJSONSchema(schema *jsonschema.Schema) error {
+	schema.WithDescription("Spend of the gen_ai spans over the window, from the costs the LLM pricing processor attaches to them, in the currency of the pricing rules. totalCost covers every span matching the filter; groups are the ones spending the most, ordered by totalCost.")
+	return nil
+}
@@ -0,0 +1,44 @@
+package llmcosttypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableCostBreakdownValidate(t *testing.T) {
+	groupBy := func(names ...string) []qbtypes.GroupByKey {
+		keys := make([]qbtypes.GroupByKey, 0, len(names))
+		for _, name := range names {
+			keys = append(keys, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: name}})
+		}
+		return keys
+	}
+
+	t.Run("defaults the limit", func(t *testing.T) {
+		p := &PostableCostBreakdown{Start: 1, End: 2, GroupBy: groupBy("gen_ai.request.model")}
+		require.NoError(t, p.Validate())
+		assert.Equal(t, DefaultLimit, p.Limit)
+	})
+
+	testCases := []struct {
+		name string
+		p    *PostableCostBreakdown
+	}{
+		{name: "end before start", p: &PostableCostBreakdown{Start: 2, End: 1}},
+		{name: "too many group by keys", p: &PostableCostBreakdown{Start: 1, End: 2, GroupBy: groupBy("a", "b", "c", "d")}},
+		{name: "empty group by key", p: &PostableCostBreakdown{Start: 1, End: 2, GroupBy: groupBy("")}},
+		{name: "limit above max", p: &PostableCostBreakdown{Start: 1, End: 2, Limit: MaxLimit + 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.p.Validate()
+			require.Error(t, err)
+			assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+		})
+	}
+}
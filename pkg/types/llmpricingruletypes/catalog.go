@@ -0,0 +1,321 @@
+package llmpricingruletypes
+
+import (
+	"encoding/json"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodePricingCatalogInvalid  = errors.MustNewCode("pricing_catalog_invalid")
+	ErrCodePricingCatalogOutdated = errors.MustNewCode("pricing_catalog_outdated")
+	ErrCodePricingCatalogNotFound = errors.MustNewCode("pricing_catalog_not_found")
+)
+
+type LLMPricingCatalogSource struct {
+	valuer.String
+}
+
+var (
+	// LLMPricingCatalogSourceBundled is the catalog embedded in the binary.
+	LLMPricingCatalogSourceBundled = LLMPricingCatalogSource{valuer.NewString("bundled")}
+	// LLMPricingCatalogSourceImported is a newer catalog an admin imported for the org.
+	LLMPricingCatalogSourceImported = LLMPricingCatalogSource{valuer.NewString("imported")}
+)
+
+type LLMPricingCatalogAction struct {
+	valuer.String
+}
+
+var (
+	// LLMPricingCatalogActionCreate adds the rule of a catalog model the org has no rule for.
+	LLMPricingCatalogActionCreate = LLMPricingCatalogAction{valuer.NewString("create")}
+	// LLMPricingCatalogActionUpdate replaces the rule synced from a catalog model whose
+	// pricing changed.
+	LLMPricingCatalogActionUpdate = LLMPricingCatalogAction{valuer.NewString("update")}
+	// LLMPricingCatalogActionKeepOverride leaves a user-pinned rule as it is although its
+	// catalog model changed; the sync only stamps its synced_at.
+	LLMPricingCatalogActionKeepOverride = LLMPricingCatalogAction{valuer.NewString("keep_override")}
+)
+
+// LLMPricingCatalog is a versioned list of model prices. The rules synced from it are
+// matched to its models by source id, so a model keeps its source id across versions.
+type LLMPricingCatalog struct {
+	// Version increases with every published catalog; only a newer one can be imported.
+	Version     int                       `json:"version" required:"true"`
+	PublishedAt time.Time                 `json:"publishedAt" required:"true"`
+	Models      []*LLMPricingCatalogModel `json:"models" required:"true" nullable:"false"`
+}
+
+type LLMPricingCatalogModel struct {
+	SourceID     valuer.UUID        `json:"sourceId" required:"true"`
+	Model        string             `json:"modelName" required:"true"`
+	Provider     string             `json:"provider" required:"true"`
+	ModelPattern []string           `json:"modelPattern" required:"true" nullable:"false"`
+	Unit         LLMPricingRuleUnit `json:"unit" required:"true"`
+	Pricing      LLMRulePricing     `json:"pricing" required:"true"`
+}
+
+// StorableLLMPricingCatalog is the catalog an admin imported for the org, which takes
+// precedence over the bundled one while its version is newer.
+type StorableLLMPricingCatalog struct {
+	bun.BaseModel `bun:"table:llm_pricing_catalog,alias:llm_pricing_catalog"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID   valuer.UUID `bun:"org_id,type:text,notnull"`
+	Version int         `bun:"version,notnull"`
+	Data    string      `bun:"data,type:text,notnull"`
+}
+
+// GettableLLMPricingCatalog is the catalog the rules of the org are synced from.
+type GettableLLMPricingCatalog struct {
+	LLMPricingCatalog
+	Source LLMPricingCatalogSource `json:"source" required:"true"`
+}
+
+// LLMPricingRuleChange is a field of a synced rule the catalog changes.
+type LLMPricingRuleChange struct {
+	Field string `json:"field" required:"true"`
+	From  string `json:"from" required:"true"`
+	To    string `json:"to" required:"true"`
+}
+
+// LLMPricingCatalogProposal is the change a sync makes to the rule of a catalog model.
+type LLMPricingCatalogProposal struct {
+	Action   LLMPricingCatalogAction `json:"action" required:"true"`
+	SourceID valuer.UUID             `json:"sourceId" required:"true"`
+	Model    string                  `json:"modelName" required:"true"`
+	Provider string                  `json:"provider" required:"true"`
+	// RuleID is the rule synced from the model, unset when it is created.
+	RuleID  *valuer.UUID            `json:"ruleId,omitempty"`
+	Changes []*LLMPricingRuleChange `json:"changes" required:"true" nullable:"false"`
+	// Rule is the entry the sync upserts.
+	Rule *UpdatableLLMPricingRule `json:"rule" required:"true"`
+}
+
+// GettableLLMPricingCatalogDiff is what syncing the rules of the org with the catalog
+// changes.
+type GettableLLMPricingCatalogDiff struct {
+	CatalogVersion int                          `json:"catalogVersion" required:"true"`
+	CatalogSource  LLMPricingCatalogSource      `json:"catalogSource" required:"true"`
+	Proposals      []*LLMPricingCatalogProposal `json:"proposals" required:"true" nullable:"false"`
+	// Unchanged is the number of rules already in sync with the catalog.
+	Unchanged int `json:"unchanged" required:"true"`
+}
+
+// PostableLLMPricingCatalogSync selects the proposals a sync applies.
+type PostableLLMPricingCatalogSync struct {
+	// SourceIDs are the catalog models to sync, all the proposals when empty.
+	SourceIDs []valuer.UUID `json:"sourceIds,omitempty"`
+}
+
+func (LLMPricingCatalogSource) Enum() []any {
+	return []any{LLMPricingCatalogSourceBundled, LLMPricingCatalogSourceImported}
+}
+
+func (LLMPricingCatalogAction) Enum() []any {
+	return []any{LLMPricingCatalogActionCreate, LLMPricingCatalogActionUpdate, LLMPricingCatalogActionKeepOverride}
+}
+
+// NewLLMPricingCatalog parses and validates a catalog file.
+func NewLLMPricingCatalog(data []byte) (*LLMPricingCatalog, error) {
+	catalog := new(LLMPricingCatalog)
+	if err := json.Unmarshal(data, catalog); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "pricing catalog is not valid json")
+	}
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+func (c *LLMPricingCatalog) Validate() error {
+	if c.Version <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "version must be positive, got %d", c.Version)
+	}
+	if len(c.Models) == 0 {
+		return errors.New(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "models must not be empty")
+	}
+
+	seen := make(map[valuer.UUID]struct{}, len(c.Models))
+	for i, m := range c.Models {
+		if m == nil {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "models[%d] is null", i)
+		}
+		if m.SourceID.IsZero() {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "models[%d] has no sourceId", i)
+		}
+		if _, ok := seen[m.SourceID]; ok {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "models[%d] repeats sourceId %s", i, m.SourceID)
+		}
+		seen[m.SourceID] = struct{}{}
+
+		if strings.TrimSpace(m.Model) == "" || strings.TrimSpace(m.Provider) == "" {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "models[%d] must have a modelName and a provider", i)
+		}
+		if len(m.ModelPattern) == 0 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "model %s has no modelPattern", m.Model)
+		}
+		for _, pattern := range m.ModelPattern {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "model %s has an invalid pattern %q", m.Model, pattern)
+			}
+		}
+		if m.Unit != UnitPerMillionTokens {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "model %s has an unsupported unit %q", m.Model, m.Unit.StringValue())
+		}
+		if m.Pricing.Input < 0 || m.Pricing.Output < 0 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "model %s has a negative price", m.Model)
+		}
+		if cache := m.Pricing.Cache; cache != nil {
+			switch cache.Mode {
+			case LLMPricingRuleCacheModeSubtract, LLMPricingRuleCacheModeAdditive, LLMPricingRuleCacheModeUnknown:
+			default:
+				return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "model %s has an unsupported cache mode %q", m.Model, cache.Mode.StringValue())
+			}
+			if cache.Read < 0 || cache.Write < 0 {
+				return errors.Newf(errors.TypeInvalidInput, ErrCodePricingCatalogInvalid, "model %s has a negative cache price", m.Model)
+			}
+		}
+	}
+	return nil
+}
+
+func NewStorableLLMPricingCatalog(orgID valuer.UUID, userEmail string, data []byte, catalog *LLMPricingCatalog, now time.Time) *StorableLLMPricingCatalog {
+	return &StorableLLMPricingCatalog{
+		Identifiable:  types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{CreatedAt: now, UpdatedAt: now},
+		UserAuditable: types.UserAuditable{CreatedBy: userEmail, UpdatedBy: userEmail},
+		OrgID:         orgID,
+		Version:       catalog.Version,
+		Data:          string(data),
+	}
+}
+
+// Update replaces the imported catalog with a newer one.
+func (s *StorableLLMPricingCatalog) Update(userEmail string, data []byte, catalog *LLMPricingCatalog, now time.Time) {
+	s.Version = catalog.Version
+	s.Data = string(data)
+	s.UpdatedAt = now
+	s.UpdatedBy = userEmail
+}
+
+// NewLLMPricingCatalogDiff proposes the changes syncing the rules with the catalog makes:
+// creating the rules of the models the org has none synced from, and updating the synced
+// rules whose model changed unless they are user overrides. Disabled rules stay disabled.
+func NewLLMPricingCatalogDiff(catalog *GettableLLMPricingCatalog, rules []*LLMPricingRule) *GettableLLMPricingCatalogDiff {
+	rulesBySourceID := make(map[valuer.UUID]*LLMPricingRule, len(rules))
+	for _, rule := range rules {
+		if rule.SourceID != nil {
+			rulesBySourceID[*rule.SourceID] = rule
+		}
+	}
+
+	diff := &GettableLLMPricingCatalogDiff{
+		CatalogVersion: catalog.Version,
+		CatalogSource:  catalog.Source,
+		Proposals:      []*LLMPricingCatalogProposal{},
+	}
+	for _, m := range catalog.Models {
+		sourceID := m.SourceID
+		updatable := &UpdatableLLMPricingRule{
+			SourceID:     &sourceID,
+			Model:        m.Model,
+			Provider:     m.Provider,
+			ModelPattern: slices.Clone(m.ModelPattern),
+			Unit:         m.Unit,
+			Pricing:      m.Pricing,
+			Enabled:      true,
+		}
+
+		rule, ok := rulesBySourceID[m.SourceID]
+		if !ok {
+			diff.Proposals = append(diff.Proposals, &LLMPricingCatalogProposal{
+				Action:   LLMPricingCatalogActionCreate,
+				SourceID: m.SourceID,
+				Model:    m.Model,
+				Provider: m.Provider,
+				Changes:  []*LLMPricingRuleChange{},
+				Rule:     updatable,
+			})
+			continue
+		}
+
+		changes := ruleChanges(rule, m)
+		if len(changes) == 0 {
+			diff.Unchanged++
+			continue
+		}
+
+		action := LLMPricingCatalogActionUpdate
+		if rule.IsOverride {
+			action = LLMPricingCatalogActionKeepOverride
+		}
+		updatable.Enabled = rule.Enabled
+		ruleID := rule.ID
+		diff.Proposals = append(diff.Proposals, &LLMPricingCatalogProposal{
+			Action:   action,
+			SourceID: m.SourceID,
+			Model:    m.Model,
+			Provider: m.Provider,
+			RuleID:   &ruleID,
+			Changes:  changes,
+			Rule:     updatable,
+		})
+	}
+	return diff
+}
+
+// Select keeps the proposals of the source ids, all of them when none is given.
+func (d *GettableLLMPricingCatalogDiff) Select(sourceIDs []valuer.UUID) {
+	if len(sourceIDs) == 0 {
+		return
+	}
+	d.Proposals = slices.DeleteFunc(d.Proposals, func(p *LLMPricingCatalogProposal) bool {
+		return !slices.Contains(sourceIDs, p.SourceID)
+	})
+}
+
+// ruleChanges lists the fields of the rule the catalog model changes.
+func ruleChanges(rule *LLMPricingRule, m *LLMPricingCatalogModel) []*LLMPricingRuleChange {
+	changes := make([]*LLMPricingRuleChange, 0)
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, &LLMPricingRuleChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("modelName", rule.Model, m.Model)
+	add("provider", rule.Provider, m.Provider)
+	add("modelPattern", strings.Join(rule.ModelPattern, ","), strings.Join(m.ModelPattern, ","))
+	add("unit", rule.Unit.StringValue(), m.Unit.StringValue())
+	add("pricing.input", formatPrice(rule.Pricing.Input), formatPrice(m.Pricing.Input))
+	add("pricing.output", formatPrice(rule.Pricing.Output), formatPrice(m.Pricing.Output))
+
+	from, to := rule.Pricing.Cache, m.Pricing.Cache
+	if from == nil {
+		from = &LLMPricingCacheCosts{}
+	}
+	if to == nil {
+		to = &LLMPricingCacheCosts{}
+	}
+	add("pricing.cache.mode", from.Mode.StringValue(), to.Mode.StringValue())
+	add("pricing.cache.read", formatPrice(from.Read), formatPrice(to.Read))
+	add("pricing.cache.write", formatPrice(from.Write), formatPrice(to.Write))
+	return changes
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
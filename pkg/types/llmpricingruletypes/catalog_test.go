@@ -0,0 +1,138 @@
+package llmpricingruletypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeCatalogModel(model string, costIn, costOut float64) *LLMPricingCatalogModel {
+	return &LLMPricingCatalogModel{
+		SourceID:     valuer.GenerateUUID(),
+		Model:        model,
+		Provider:     "openai",
+		ModelPattern: []string{model, model + "-20*"},
+		Unit:         UnitPerMillionTokens,
+		Pricing: LLMRulePricing{
+			Input:  costIn,
+			Output: costOut,
+			Cache:  &LLMPricingCacheCosts{Mode: LLMPricingRuleCacheModeSubtract, Read: costIn / 2},
+		},
+	}
+}
+
+func makeSyncedRule(m *LLMPricingCatalogModel) *LLMPricingRule {
+	sourceID := m.SourceID
+	cache := *m.Pricing.Cache
+	return &LLMPricingRule{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		SourceID:     &sourceID,
+		Model:        m.Model,
+		Provider:     m.Provider,
+		ModelPattern: StringSlice(m.ModelPattern),
+		Unit:         m.Unit,
+		Pricing:      LLMRulePricing{Input: m.Pricing.Input, Output: m.Pricing.Output, Cache: &cache},
+		Enabled:      true,
+	}
+}
+
+func TestNewLLMPricingCatalog(t *testing.T) {
+	catalog, err := NewLLMPricingCatalog([]byte(`{
+		"version": 2,
+		"publishedAt": "2026-10-01T00:00:00Z",
+		"models": [{
+			"sourceId": "c98e47ba-5438-5108-b484-d14265e90275",
+			"modelName": "claude-sonnet-4",
+			"provider": "anthropic",
+			"modelPattern": ["claude-sonnet-4*"],
+			"unit": "per_million_tokens",
+			"pricing": {"input": 3, "output": 15, "cache": {"mode": "additive", "read": 0.3, "write": 3.75}}
+		}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, catalog.Version)
+	require.Len(t, catalog.Models, 1)
+	assert.Equal(t, LLMPricingRuleCacheModeAdditive, catalog.Models[0].Pricing.Cache.Mode)
+
+	_, err = NewLLMPricingCatalog([]byte(`{"version":`))
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestLLMPricingCatalog_Validate(t *testing.T) {
+	for name, mutate := range map[string]func(c *LLMPricingCatalog){
+		"zero version":      func(c *LLMPricingCatalog) { c.Version = 0 },
+		"no models":         func(c *LLMPricingCatalog) { c.Models = nil },
+		"repeated sourceId": func(c *LLMPricingCatalog) { c.Models[1].SourceID = c.Models[0].SourceID },
+		"no provider":       func(c *LLMPricingCatalog) { c.Models[0].Provider = " " },
+		"no pattern":        func(c *LLMPricingCatalog) { c.Models[0].ModelPattern = nil },
+		"invalid pattern":   func(c *LLMPricingCatalog) { c.Models[0].ModelPattern = []string{"gpt-["} },
+		"negative price":    func(c *LLMPricingCatalog) { c.Models[0].Pricing.Output = -1 },
+		"unknown mode": func(c *LLMPricingCatalog) {
+			c.Models[0].Pricing.Cache.Mode = LLMPricingRuleCacheMode{valuer.NewString("halve")}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			catalog := &LLMPricingCatalog{Version: 1, Models: []*LLMPricingCatalogModel{makeCatalogModel("gpt-4o", 2.5, 10), makeCatalogModel("gpt-4o-mini", 0.15, 0.6)}}
+			require.NoError(t, catalog.Validate())
+
+			mutate(catalog)
+			assert.True(t, errors.Ast(catalog.Validate(), errors.TypeInvalidInput))
+		})
+	}
+}
+
+func TestNewLLMPricingCatalogDiff(t *testing.T) {
+	created := makeCatalogModel("gpt-4.1", 2, 8)
+	unchanged := makeCatalogModel("gpt-4o-mini", 0.15, 0.6)
+	updated := makeCatalogModel("gpt-4o", 2.5, 10)
+	overridden := makeCatalogModel("o3", 2, 8)
+
+	updatedRule := makeSyncedRule(updated)
+	updatedRule.Pricing.Input = 5
+	updatedRule.Enabled = false
+	overriddenRule := makeSyncedRule(overridden)
+	overriddenRule.Pricing.Cache.Mode = LLMPricingRuleCacheModeUnknown
+	overriddenRule.IsOverride = true
+	manualRule := &LLMPricingRule{Identifiable: types.Identifiable{ID: valuer.GenerateUUID()}, Model: "custom"}
+
+	diff := NewLLMPricingCatalogDiff(
+		&GettableLLMPricingCatalog{
+			LLMPricingCatalog: LLMPricingCatalog{Version: 3, Models: []*LLMPricingCatalogModel{created, unchanged, updated, overridden}},
+			Source:            LLMPricingCatalogSourceImported,
+		},
+		[]*LLMPricingRule{makeSyncedRule(unchanged), updatedRule, overriddenRule, manualRule},
+	)
+
+	assert.Equal(t, 3, diff.CatalogVersion)
+	assert.Equal(t, LLMPricingCatalogSourceImported, diff.CatalogSource)
+	assert.Equal(t, 1, diff.Unchanged)
+	require.Len(t, diff.Proposals, 3)
+
+	create := diff.Proposals[0]
+	assert.Equal(t, LLMPricingCatalogActionCreate, create.Action)
+	assert.Equal(t, created.SourceID, create.SourceID)
+	assert.Nil(t, create.RuleID)
+	assert.Empty(t, create.Changes)
+	assert.True(t, create.Rule.Enabled)
+	assert.Nil(t, create.Rule.IsOverride)
+
+	update := diff.Proposals[1]
+	assert.Equal(t, LLMPricingCatalogActionUpdate, update.Action)
+	assert.Equal(t, &updatedRule.ID, update.RuleID)
+	assert.Equal(t, []*LLMPricingRuleChange{{Field: "pricing.input", From: "5", To: "2.5"}}, update.Changes)
+	assert.False(t, update.Rule.Enabled)
+	assert.Equal(t, 2.5, update.Rule.Pricing.Input)
+
+	keep := diff.Proposals[2]
+	assert.Equal(t, LLMPricingCatalogActionKeepOverride, keep.Action)
+	assert.Equal(t, []*LLMPricingRuleChange{{Field: "pricing.cache.mode", From: "unknown", To: "subtract"}}, keep.Changes)
+	assert.Nil(t, keep.Rule.IsOverride)
+
+	diff.Select([]valuer.UUID{updated.SourceID})
+	require.Len(t, diff.Proposals, 1)
+	assert.Equal(t, updated.SourceID, diff.Proposals[0].SourceID)
+}
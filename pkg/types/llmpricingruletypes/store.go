@@ -14,4 +14,8 @@ type Store interface {
 	Update(ctx context.Context, rule *LLMPricingRule) error
 	Delete(ctx context.Context, orgID, id valuer.UUID) error
 	RunInTx(ctx context.Context, cb func(ctx context.Context) error) error
+
+	GetCatalog(ctx context.Context, orgID valuer.UUID) (*StorableLLMPricingCatalog, error)
+	CreateCatalog(ctx context.Context, catalog *StorableLLMPricingCatalog) error
+	UpdateCatalog(ctx context.Context, catalog *StorableLLMPricingCatalog) error
 }
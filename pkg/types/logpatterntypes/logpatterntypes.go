@@ -0,0 +1,105 @@
+package logpatterntypes
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+const (
+	// DefaultLimit is the number of patterns returned when the request sets none.
+	DefaultLimit = 50
+	// MaxLimit is the highest number of patterns a request can ask for.
+	MaxLimit = 500
+	// DefaultMaxSamples is the number of logs clustered per period when the request sets none.
+	DefaultMaxSamples = 10000
+	// MaxMaxSamples is the highest number of logs a request can have clustered per period.
+	MaxMaxSamples = 100000
+)
+
+// PostableLogPatterns is the request body for the log patterns API.
+// Start and End are epoch milliseconds and StepInterval, the width of the trend buckets,
+// is in seconds; it defaults to the step recommended for the period. The bodies of the
+// logs matching the filter are clustered into patterns; when more than MaxSamples logs
+// match, a sample of them is clustered and the counts are scaled up. When
+// CompareWithPrevious is set, the patterns not seen over the period of the same length
+// ending at Start are flagged as new.
+type PostableLogPatterns struct {
+	Start               uint64          `json:"start" required:"true"`
+	End                 uint64          `json:"end" required:"true"`
+	Filter              *qbtypes.Filter `json:"filter"`
+	StepInterval        uint64          `json:"stepInterval"`
+	Limit               int             `json:"limit"`
+	MaxSamples          int             `json:"maxSamples"`
+	CompareWithPrevious bool            `json:"compareWithPrevious"`
+}
+
+func (p *PostableLogPatterns) Validate() error {
+	if p.Start >= p.End {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start time must be before end time")
+	}
+
+	if p.Limit < 0 || p.Limit > MaxLimit {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 0 and %d", MaxLimit)
+	}
+
+	if p.MaxSamples < 0 || p.MaxSamples > MaxMaxSamples {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "maxSamples must be between 0 and %d", MaxMaxSamples)
+	}
+
+	return nil
+}
+
+// PatternLimit returns the number of patterns to return.
+func (p *PostableLogPatterns) PatternLimit() int {
+	if p.Limit == 0 {
+		return DefaultLimit
+	}
+	return p.Limit
+}
+
+// SampleLimit returns the number of logs to cluster per period.
+func (p *PostableLogPatterns) SampleLimit() int {
+	if p.MaxSamples == 0 {
+		return DefaultMaxSamples
+	}
+	return p.MaxSamples
+}
+
+// PreviousPeriod returns the bounds of the period of the same length ending at Start.
+func (p *PostableLogPatterns) PreviousPeriod() (uint64, uint64) {
+	length := p.End - p.Start
+	if length > p.Start {
+		return 0, p.Start
+	}
+	return p.Start - length, p.Start
+}
+
+// TrendPoint is the number of logs of a pattern in the trend bucket starting at
+// Timestamp, in epoch milliseconds.
+type TrendPoint struct {
+	Timestamp uint64 `json:"timestamp" required:"true"`
+	Count     uint64 `json:"count" required:"true"`
+}
+
+// Pattern is a template shared by a group of log bodies, in which the tokens that vary
+// between them are replaced by <*>. Count is estimated when the logs were sampled.
+// SampleLogID is the ID of one of the logs of the pattern. IsNew and PreviousCount are
+// only set when comparing with the previous period.
+type Pattern struct {
+	Template      string        `json:"template" required:"true"`
+	Count         uint64        `json:"count" required:"true"`
+	SampleLogID   string        `json:"sampleLogId" required:"true"`
+	Trend         []*TrendPoint `json:"trend" required:"true" nullable:"false"`
+	IsNew         bool          `json:"isNew"`
+	PreviousCount uint64        `json:"previousCount"`
+}
+
+// GettableLogPatterns is the response for the log patterns API. Patterns are sorted by
+// count, the highest first. TotalCount is the number of logs matching the filter and
+// SampledCount the number of them that were clustered.
+type GettableLogPatterns struct {
+	Patterns     []*Pattern `json:"patterns" required:"true" nullable:"false"`
+	TotalCount   uint64     `json:"totalCount" required:"true"`
+	SampledCount uint64     `json:"sampledCount" required:"true"`
+	Sampled      bool       `json:"sampled" required:"true"`
+}
@@ -0,0 +1,95 @@
+package metricreductionruletypes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var ErrCodeMetricReductionRuleNoRecommendation = errors.MustNewCode("metric_reduction_rule_no_recommendation")
+
+const (
+	maxRecommendationsLimit = 50
+	// MinRecommendedLabelValues is the distinct value count below which dropping an unused label is
+	// not worth recommending.
+	MinRecommendedLabelValues = 10
+	// MinRecommendedReductionPercent is the estimated series reduction below which no rule is
+	// recommended for a metric.
+	MinRecommendedReductionPercent = 10.0
+)
+
+type ListReductionRuleRecommendationsParams struct {
+	Limit int `query:"limit,default=10" json:"limit"`
+}
+
+func (p *ListReductionRuleRecommendationsParams) Validate() error {
+	if p.Limit <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be greater than 0")
+	}
+	if p.Limit > maxRecommendationsLimit {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must not exceed %d", maxRecommendationsLimit)
+	}
+	return nil
+}
+
+type RecommendedLabel struct {
+	Name           string `json:"name" required:"true"`
+	DistinctValues uint64 `json:"distinctValues" required:"true"`
+}
+
+type GettableReductionRuleRecommendation struct {
+	// Rule is the drop rule to create for the recommendation as is.
+	Rule                   PostableReductionRule `json:"rule" required:"true"`
+	DroppedLabels          []RecommendedLabel    `json:"droppedLabels" required:"true" nullable:"true"`
+	InUseLabels            []string              `json:"inUseLabels" required:"true" nullable:"true"`
+	IngestedSeries         uint64                `json:"ingestedSeries" required:"true"`
+	RetainedSeries         uint64                `json:"retainedSeries" required:"true"`
+	EstimatedSeriesSavings uint64                `json:"estimatedSeriesSavings" required:"true"`
+	ReductionPercent       float64               `json:"reductionPercent" required:"true"`
+}
+
+type GettableReductionRuleRecommendations struct {
+	Recommendations []GettableReductionRuleRecommendation `json:"recommendations" required:"true" nullable:"true"`
+}
+
+type PostableReductionRuleRecommendation struct {
+	MetricName string `json:"metricName" required:"true"`
+}
+
+func (req *PostableReductionRuleRecommendation) Validate() error {
+	if req == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "request is nil")
+	}
+	if req.MetricName == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "metricName is required")
+	}
+	return nil
+}
+
+// NewRecommendedLabels returns the labels worth dropping from a metric given the distinct values of
+// each of its labels: those neither protected nor in use with at least MinRecommendedLabelValues
+// values, highest cardinality first.
+func NewRecommendedLabels(distinctValues map[string]uint64, inUse map[string]struct{}) []RecommendedLabel {
+	labels := make([]RecommendedLabel, 0)
+	for name, values := range distinctValues {
+		if IsProtectedLabel(name) || strings.HasPrefix(name, "__") {
+			continue
+		}
+		if _, ok := inUse[name]; ok {
+			continue
+		}
+		if values < MinRecommendedLabelValues {
+			continue
+		}
+		labels = append(labels, RecommendedLabel{Name: name, DistinctValues: values})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].DistinctValues != labels[j].DistinctValues {
+			return labels[i].DistinctValues > labels[j].DistinctValues
+		}
+		return labels[i].Name < labels[j].Name
+	})
+	return labels
+}
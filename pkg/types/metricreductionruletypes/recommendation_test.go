@@ -0,0 +1,36 @@
+package metricreductionruletypes_test
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/metricreductionruletypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecommendedLabels(t *testing.T) {
+	distinctValues := map[string]uint64{
+		"pod":                    500,
+		"container.id":           500,
+		"host":                   40,
+		"service.name":           12,
+		"status":                 3,
+		"le":                     20,
+		"deployment.environment": 15,
+		"__temporality__":        2,
+	}
+	inUse := map[string]struct{}{"service.name": {}}
+
+	labels := metricreductionruletypes.NewRecommendedLabels(distinctValues, inUse)
+
+	require.Equal(t, []metricreductionruletypes.RecommendedLabel{
+		{Name: "container.id", DistinctValues: 500},
+		{Name: "pod", DistinctValues: 500},
+		{Name: "host", DistinctValues: 40},
+	}, labels)
+}
+
+func TestListReductionRuleRecommendationsParamsValidate(t *testing.T) {
+	require.Error(t, (&metricreductionruletypes.ListReductionRuleRecommendationsParams{}).Validate(), "zero limit")
+	require.Error(t, (&metricreductionruletypes.ListReductionRuleRecommendationsParams{Limit: 51}).Validate(), "limit over max")
+	require.NoError(t, (&metricreductionruletypes.ListReductionRuleRecommendationsParams{Limit: 10}).Validate())
+}
@@ -0,0 +1,166 @@
+package metricsexplorertypes
+
+import (
+	"encoding/json"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/metricreductionruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// DefaultNewValuesWindowMs is the recent window label values first seen in count as new.
+	DefaultNewValuesWindowMs int64 = 60 * 60 * 1000
+	// MaxCardinalityLabels caps the label keys analysed per request, each adds aggregates to the series queries.
+	MaxCardinalityLabels = 50
+)
+
+// CardinalityOrderBy indicates how label keys are ranked in the cardinality response.
+type CardinalityOrderBy struct {
+	valuer.String
+}
+
+var (
+	// CardinalityOrderBySeriesContribution ranks label keys by the series that collapse when the label is dropped.
+	CardinalityOrderBySeriesContribution = CardinalityOrderBy{valuer.NewString("series_contribution")}
+	// CardinalityOrderByDistinctValues ranks label keys by their number of distinct values.
+	CardinalityOrderByDistinctValues = CardinalityOrderBy{valuer.NewString("distinct_values")}
+)
+
+func (CardinalityOrderBy) Enum() []any {
+	return []any{
+		CardinalityOrderBySeriesContribution,
+		CardinalityOrderByDistinctValues,
+	}
+}
+
+// CardinalityRequest represents the payload for the metric cardinality endpoint.
+type CardinalityRequest struct {
+	// MetricName scopes the analysis to one metric, across all metrics when empty.
+	MetricName        string             `json:"metricName"`
+	Start             int64              `json:"start" required:"true"`
+	End               int64              `json:"end" required:"true"`
+	Limit             int                `json:"limit" required:"true"`
+	OrderBy           CardinalityOrderBy `json:"orderBy"`
+	NewValuesWindowMs int64              `json:"newValuesWindowMs"`
+}
+
+// Validate enforces basic constraints on CardinalityRequest and fills in defaults.
+func (req *CardinalityRequest) Validate() error {
+	if req == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "request is nil")
+	}
+
+	if req.Start <= 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid start time %d: start must be greater than 0",
+			req.Start,
+		)
+	}
+
+	if req.End <= 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid end time %d: end must be greater than 0",
+			req.End,
+		)
+	}
+
+	if req.Start >= req.End {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid time range: start (%d) must be less than end (%d)",
+			req.Start,
+			req.End,
+		)
+	}
+
+	if req.Limit < 1 || req.Limit > MaxCardinalityLabels {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 1 and %d", MaxCardinalityLabels)
+	}
+
+	if req.OrderBy.IsZero() {
+		req.OrderBy = CardinalityOrderBySeriesContribution
+	}
+
+	if req.OrderBy != CardinalityOrderBySeriesContribution && req.OrderBy != CardinalityOrderByDistinctValues {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid order by %q: supported values are %q or %q",
+			req.OrderBy,
+			CardinalityOrderBySeriesContribution,
+			CardinalityOrderByDistinctValues,
+		)
+	}
+
+	if req.NewValuesWindowMs < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "newValuesWindowMs cannot be negative")
+	}
+
+	if req.NewValuesWindowMs == 0 {
+		req.NewValuesWindowMs = DefaultNewValuesWindowMs
+	}
+
+	return nil
+}
+
+// UnmarshalJSON validates cardinality requests immediately after decoding.
+func (req *CardinalityRequest) UnmarshalJSON(data []byte) error {
+	type raw CardinalityRequest
+	var decoded raw
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*req = CardinalityRequest(decoded)
+	return req.Validate()
+}
+
+// NewLabelValue is a label value first seen within the new values window.
+type NewLabelValue struct {
+	Value       string `json:"value" required:"true"`
+	FirstSeenAt int64  `json:"firstSeenAt" required:"true"`
+}
+
+// LabelCardinality represents the cardinality of a label key.
+type LabelCardinality struct {
+	Key            string `json:"key" required:"true"`
+	DistinctValues uint64 `json:"distinctValues" required:"true"`
+	// Series is the number of series carrying the label.
+	Series uint64 `json:"series" required:"true"`
+	// SeriesIfDropped is the number of series left when the label is dropped.
+	SeriesIfDropped uint64 `json:"seriesIfDropped" required:"true"`
+	// Contribution is the percentage of all series that collapse when the label is dropped.
+	Contribution  float64         `json:"contribution" required:"true"`
+	NewValueCount uint64          `json:"newValueCount" required:"true"`
+	NewValues     []NewLabelValue `json:"newValues" required:"true" nullable:"true"`
+	// SuggestedReduction is the reduction rule preview dropping the label, set for a single metric only.
+	SuggestedReduction *metricreductionruletypes.PostableReductionRulePreview `json:"suggestedReduction,omitempty"`
+}
+
+// CardinalityTrendPoint is the cardinality of a time bucket.
+type CardinalityTrendPoint struct {
+	Timestamp      int64             `json:"timestamp" required:"true"`
+	Series         uint64            `json:"series" required:"true"`
+	DistinctValues map[string]uint64 `json:"distinctValues" required:"true" nullable:"false"`
+}
+
+// CardinalityResponse is the output structure for the metric cardinality endpoint.
+type CardinalityResponse struct {
+	MetricName  string                  `json:"metricName" required:"true"`
+	TotalSeries uint64                  `json:"totalSeries" required:"true"`
+	Labels      []LabelCardinality      `json:"labels" required:"true" nullable:"true"`
+	Trend       []CardinalityTrendPoint `json:"trend" required:"true" nullable:"true"`
+}
+
+// NewSuggestedReduction returns the preview of the rule dropping the label from the metric over the analysed range.
+func NewSuggestedReduction(metricName string, label string, start, end int64) *metricreductionruletypes.PostableReductionRulePreview {
+	return &metricreductionruletypes.PostableReductionRulePreview{
+		MetricName: metricName,
+		MatchType:  metricreductionruletypes.MatchTypeDrop,
+		Labels:     []string{label},
+		LookbackMs: end - start,
+	}
+}
@@ -0,0 +1,34 @@
+package querybuildertypesv5
+
+import (
+	"regexp"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const (
+	// DistributionScale sets the width of the buckets the values of logs and traces
+	// distributions are counted in: the bounds of consecutive buckets grow by a factor of
+	// 2^(2^-DistributionScale), about 19% at scale 2. The bounds do not depend on the data,
+	// so the buckets of different windows and series line up.
+	DistributionScale = 2
+
+	// DistributionBucketLowerColumn and DistributionBucketUpperColumn are the columns the
+	// statement builders return the bounds of each bucket in, next to its count as __result_0.
+	DistributionBucketLowerColumn = "__bucket_lower"
+	DistributionBucketUpperColumn = "__bucket_upper"
+)
+
+var distributionExprRe = regexp.MustCompile(`(?i)^\s*histogram\(\s*([^(),\s]+)\s*\)\s*$`)
+
+// DistributionKey returns the key whose values the aggregation expression of a logs or
+// traces distribution counts, e.g. duration_nano for `histogram(duration_nano)`.
+func DistributionKey(expression string) (telemetrytypes.TelemetryFieldKey, error) {
+	matches := distributionExprRe.FindStringSubmatch(expression)
+	if matches == nil {
+		return telemetrytypes.TelemetryFieldKey{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid distribution aggregation %q, expected histogram(<key>)", expression).
+			WithAdditional("A distribution counts the values of a numeric key, e.g. histogram(duration_nano)")
+	}
+	return telemetrytypes.GetFieldKeyFromKeyText(matches[1]), nil
+}
@@ -0,0 +1,139 @@
+package querybuildertypesv5
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributionKey(t *testing.T) {
+	cases := []struct {
+		expression string
+		expected   telemetrytypes.TelemetryFieldKey
+		err        bool
+	}{
+		{expression: "histogram(duration_nano)", expected: telemetrytypes.TelemetryFieldKey{Name: "duration_nano"}},
+		{expression: " HISTOGRAM( attribute.latency ) ", expected: telemetrytypes.TelemetryFieldKey{Name: "latency", FieldContext: telemetrytypes.FieldContextAttribute}},
+		{expression: "histogram(payload.size:number)", expected: telemetrytypes.TelemetryFieldKey{Name: "payload.size", FieldDataType: telemetrytypes.FieldDataTypeNumber}},
+		{expression: "count()", err: true},
+		{expression: "histogram()", err: true},
+		{expression: "histogram(a, b)", err: true},
+		{expression: "histogram(toFloat64(a))", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expression, func(t *testing.T) {
+			key, err := DistributionKey(c.expression)
+			if c.err {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "expected histogram(<key>)")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, key)
+		})
+	}
+}
+
+func TestQueryRangeRequest_ValidateDistribution(t *testing.T) {
+	request := func(queries ...QueryEnvelope) QueryRangeRequest {
+		return QueryRangeRequest{
+			Start:          1640995200000,
+			End:            1640998800000,
+			RequestType:    RequestTypeDistribution,
+			CompositeQuery: CompositeQuery{Queries: queries},
+		}
+	}
+	traces := func(q QueryBuilderQuery[TraceAggregation]) QueryEnvelope {
+		q.Name = "A"
+		q.Signal = telemetrytypes.SignalTraces
+		q.StepInterval = Step{Duration: time.Minute}
+		return QueryEnvelope{Type: QueryTypeBuilder, Spec: q}
+	}
+
+	tests := []struct {
+		name    string
+		request QueryRangeRequest
+		errMsg  string
+	}{
+		{
+			name:    "traces histogram",
+			request: request(traces(QueryBuilderQuery[TraceAggregation]{Aggregations: []TraceAggregation{{Expression: "histogram(duration_nano)"}}})),
+		},
+		{
+			name: "metrics histogram",
+			request: request(QueryEnvelope{Type: QueryTypeBuilder, Spec: QueryBuilderQuery[MetricAggregation]{
+				Name:         "A",
+				Signal:       telemetrytypes.SignalMetrics,
+				StepInterval: Step{Duration: time.Minute},
+				Aggregations: []MetricAggregation{{MetricName: "http_server_duration_bucket", Type: metrictypes.HistogramType, SpaceAggregation: metrictypes.SpaceAggregationCount}},
+			}}),
+		},
+		{
+			name:    "not a histogram",
+			request: request(traces(QueryBuilderQuery[TraceAggregation]{Aggregations: []TraceAggregation{{Expression: "p99(duration_nano)"}}})),
+			errMsg:  "expected histogram(<key>)",
+		},
+		{
+			name: "two aggregations",
+			request: request(traces(QueryBuilderQuery[TraceAggregation]{Aggregations: []TraceAggregation{
+				{Expression: "histogram(duration_nano)"}, {Expression: "histogram(duration_nano)"},
+			}})),
+			errMsg: "exactly one aggregation",
+		},
+		{
+			name: "having",
+			request: request(traces(QueryBuilderQuery[TraceAggregation]{
+				Aggregations: []TraceAggregation{{Expression: "histogram(duration_nano)"}},
+				Having:       &Having{Expression: "count() > 1"},
+			})),
+			errMsg: "having is not supported",
+		},
+		{
+			name: "formula",
+			request: request(
+				traces(QueryBuilderQuery[TraceAggregation]{Aggregations: []TraceAggregation{{Expression: "histogram(duration_nano)"}}}),
+				QueryEnvelope{Type: QueryTypeFormula, Spec: QueryBuilderFormula{Name: "F1", Expression: "A * 2"}},
+			),
+			errMsg: "not supported for distribution requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestDistributionBucket_JSON(t *testing.T) {
+	buckets := []*DistributionBucket{
+		{Lower: math.Inf(-1), Upper: -1, Count: 2},
+		{Lower: 0.25, Upper: 0.2973017787506803, Count: 1},
+		{Lower: 10, Upper: math.Inf(1), Count: 3},
+	}
+
+	data, err := json.Marshal(buckets)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"lower": "-Inf", "upper": -1, "count": 2},
+		{"lower": 0.25, "upper": 0.2973017787506803, "count": 1},
+		{"lower": 10, "upper": "Inf", "count": 3}
+	]`, string(data))
+
+	var decoded []*DistributionBucket
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, buckets, decoded)
+}
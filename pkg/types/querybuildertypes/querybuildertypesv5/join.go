@@ -1,6 +1,10 @@
 package querybuildertypesv5
 
 import (
+	"regexp"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -27,6 +31,16 @@ func (JoinType) Enum() []any {
 	}
 }
 
+// joinConditionSeparator splits the On expression of a join into its equality conditions.
+var joinConditionSeparator = regexp.MustCompile(`(?i)\s+AND\s+`)
+
+// JoinCondition is an equality between a group by key of the left query and one of the
+// right query.
+type JoinCondition struct {
+	LeftKey  string
+	RightKey string
+}
+
 type QueryRef struct {
 	Name string `json:"name"`
 }
@@ -146,3 +160,89 @@ func (q QueryBuilderJoin) Copy() QueryBuilderJoin {
 
 	return c
 }
+
+// Conditions parses the On expression into equality conditions. The expression is a list
+// of conditions separated by AND, each either a key name shared by both sides
+// (`service.name`) or an equality between qualified keys (`A.service.name = B.service`).
+// Cross joins have no condition.
+func (q QueryBuilderJoin) Conditions() ([]JoinCondition, error) {
+	on := strings.TrimSpace(q.On)
+	if q.Type == JoinTypeCross {
+		if on != "" {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "cross join %q cannot have an on expression", q.Name)
+		}
+		return nil, nil
+	}
+	if on == "" {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q requires an on expression", q.Name)
+	}
+
+	conditions := []JoinCondition{}
+	for _, part := range joinConditionSeparator.Split(on, -1) {
+		part = strings.TrimSpace(part)
+		lhs, rhs, found := strings.Cut(part, "=")
+		if !found {
+			if strings.ContainsAny(part, " \t\n") {
+				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q condition %q is not a key name", q.Name, part)
+			}
+			conditions = append(conditions, JoinCondition{LeftKey: part, RightKey: part})
+			continue
+		}
+
+		lhs, rhs = strings.TrimSpace(lhs), strings.TrimSpace(rhs)
+		if strings.HasPrefix(lhs, q.Right.Name+".") && strings.HasPrefix(rhs, q.Left.Name+".") {
+			lhs, rhs = rhs, lhs
+		}
+		leftKey, leftOK := strings.CutPrefix(lhs, q.Left.Name+".")
+		rightKey, rightOK := strings.CutPrefix(rhs, q.Right.Name+".")
+		if !leftOK || !rightOK || leftKey == "" || rightKey == "" {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q condition %q must compare a key of %q with a key of %q, e.g. %s.service.name = %s.service.name", q.Name, part, q.Left.Name, q.Right.Name, q.Left.Name, q.Right.Name)
+		}
+		conditions = append(conditions, JoinCondition{LeftKey: leftKey, RightKey: rightKey})
+	}
+	return conditions, nil
+}
+
+// Validate checks the join spec on its own; the conditions are checked against the
+// referenced queries when the join is executed.
+func (q QueryBuilderJoin) Validate() error {
+	if q.Left.Name == "" || q.Right.Name == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q requires both a left and a right query", q.Name)
+	}
+	if q.Left.Name == q.Right.Name {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q cannot join query %q with itself", q.Name, q.Left.Name)
+	}
+	if _, err := q.Conditions(); err != nil {
+		return err
+	}
+
+	unsupported := []string{}
+	if len(q.Aggregations) > 0 {
+		unsupported = append(unsupported, "aggregations")
+	}
+	if len(q.SelectFields) > 0 {
+		unsupported = append(unsupported, "selectFields")
+	}
+	if q.Filter != nil && q.Filter.Expression != "" {
+		unsupported = append(unsupported, "filter")
+	}
+	if len(q.GroupBy) > 0 {
+		unsupported = append(unsupported, "groupBy")
+	}
+	if q.Having != nil && q.Having.Expression != "" {
+		unsupported = append(unsupported, "having")
+	}
+	if len(q.SecondaryAggregations) > 0 {
+		unsupported = append(unsupported, "secondaryAggregations")
+	}
+	if len(unsupported) > 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "join %q does not support post-join %s yet", q.Name, strings.Join(unsupported, ", "))
+	}
+
+	for _, fn := range q.Functions {
+		if err := fn.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
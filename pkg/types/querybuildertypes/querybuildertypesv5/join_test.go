@@ -0,0 +1,110 @@
+package querybuildertypesv5
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilderJoinConditions(t *testing.T) {
+	cases := []struct {
+		name     string
+		join     QueryBuilderJoin
+		expected []JoinCondition
+		err      string
+	}{
+		{
+			name:     "shared key",
+			join:     QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "service.name"},
+			expected: []JoinCondition{{LeftKey: "service.name", RightKey: "service.name"}},
+		},
+		{
+			name: "qualified keys in any order",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeLeft, On: "A.service.name = B.service and B.env=A.deployment.environment"},
+			expected: []JoinCondition{
+				{LeftKey: "service.name", RightKey: "service"},
+				{LeftKey: "deployment.environment", RightKey: "env"},
+			},
+		},
+		{
+			name: "cross join",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeCross},
+		},
+		{
+			name: "cross join with on",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeCross, On: "service.name"},
+			err:  "cannot have an on expression",
+		},
+		{
+			name: "missing on",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner},
+			err:  "requires an on expression",
+		},
+		{
+			name: "unqualified equality",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "service.name = service"},
+			err:  `must compare a key of "A" with a key of "B"`,
+		},
+		{
+			name: "dangling and",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "service.name AND"},
+			err:  "is not a key name",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conditions, err := c.join.Conditions()
+			if c.err != "" {
+				require.ErrorContains(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, conditions)
+		})
+	}
+}
+
+func TestQueryBuilderJoinValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		join QueryBuilderJoin
+		err  string
+	}{
+		{
+			name: "valid",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "service.name"},
+		},
+		{
+			name: "missing right",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Type: JoinTypeInner, On: "service.name"},
+			err:  "requires both a left and a right query",
+		},
+		{
+			name: "self join",
+			join: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "A"}, Type: JoinTypeInner, On: "service.name"},
+			err:  "cannot join query \"A\" with itself",
+		},
+		{
+			name: "post-join clauses",
+			join: QueryBuilderJoin{
+				Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "service.name",
+				Filter: &Filter{Expression: "__result_0 > 1"},
+				Having: &Having{Expression: "__result_0 > 1"},
+			},
+			err: "does not support post-join filter, having yet",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.join.Validate()
+			if c.err != "" {
+				require.ErrorContains(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
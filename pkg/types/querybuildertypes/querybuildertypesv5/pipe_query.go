@@ -0,0 +1,47 @@
+package querybuildertypesv5
+
+import (
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/swaggest/jsonschema-go"
+)
+
+// PostablePipeQuery is a pipe query to validate and compile into a builder query, e.g.
+// source=logs service.name="api" | stats count() by k8s.pod.name | sort -count | head 10.
+type PostablePipeQuery struct {
+	Query string `json:"query" required:"true"`
+}
+
+func (q *PostablePipeQuery) Validate() error {
+	if strings.TrimSpace(q.Query) == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "query is required")
+	}
+	return nil
+}
+
+// PipeQueryError is an error of a pipe query at its position in the query. Line is
+// 1-based and Column 0-based.
+type PipeQueryError struct {
+	Line     int      `json:"line" required:"true"`
+	Column   int      `json:"column" required:"true"`
+	Message  string   `json:"message" required:"true"`
+	Expected []string `json:"expected,omitempty"`
+}
+
+// GettablePipeQuery is the result of validating a pipe query: its errors when it is not
+// valid, the builder query it compiles to and the request type to run it with otherwise.
+type GettablePipeQuery struct {
+	Valid       bool             `json:"valid" required:"true"`
+	Errors      []PipeQueryError `json:"errors" required:"true" nullable:"false"`
+	RequestType RequestType      `json:"requestType,omitempty"`
+	Query       *QueryEnvelope   `json:"query,omitempty"`
+}
+
+var _ jsonschema.Preparer = &GettablePipeQuery{}
+
+// PrepareJSONSchema adds description to the GettablePipeQuery schema.
+func (q *GettablePipeQuery) PrepareJSONSchema(schema *jsonschema.Schema) error {
+	schema.WithDescription("Result of validating a pipe query. Valid queries come with the builder query they compile to, which can be sent as is to the query range endpoint with the given request type.")
+	return nil
+}
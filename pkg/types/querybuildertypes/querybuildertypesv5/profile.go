@@ -0,0 +1,90 @@
+package querybuildertypesv5
+
+import (
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrystoretypes"
+	"github.com/swaggest/jsonschema-go"
+)
+
+// QueryRangeParams are the query-string parameters of the query range endpoint.
+type QueryRangeParams struct {
+	Profile string `query:"profile"`
+}
+
+// Validate parses the query-string parameters. Profile defaults to false and accepts
+// true/1/false/0; any other value is rejected.
+func (p *QueryRangeParams) Validate() (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(p.Profile)) {
+	case "", "false", "0":
+		return false, nil
+	case "true", "1":
+		return true, nil
+	}
+	return false, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid profile value %q (allowed: true, false)", p.Profile)
+}
+
+// QueryRangeProfile is where the time of a request run in profile mode went: running the
+// queries, in ClickHouse and the bucket cache, and post-processing their results.
+type QueryRangeProfile struct {
+	Queries          map[string]*QueryProfile `json:"queries" required:"true" nullable:"false"`
+	ExecutionMS      float64                  `json:"executionMs" required:"true"`
+	PostProcessingMS float64                  `json:"postProcessingMs" required:"true"`
+}
+
+var _ jsonschema.Preparer = &QueryRangeProfile{}
+
+// PrepareJSONSchema adds description to the QueryRangeProfile schema.
+func (p *QueryRangeProfile) PrepareJSONSchema(schema *jsonschema.Schema) error {
+	schema.WithDescription("Profile of a query range request run with ?profile=true. executionMs is the wall time of running all the queries and postProcessingMs the one of post-processing their results (formulas, functions, reduce, formatting). queries breaks both down by query.")
+	return nil
+}
+
+// QueryProfile is the profile of a single query of the request. Cached is set when the
+// query went through the bucket cache, with the ranges it served (CacheHits) and the ones
+// it ran in ClickHouse (CacheMisses). Statements lists every ClickHouse statement the query
+// ran, ClickHouseMS sums their wall time and ExecutionMS adds the cache lookup and the merge.
+type QueryProfile struct {
+	Statements   []ProfileStatement `json:"statements" required:"true" nullable:"false"`
+	Cached       bool               `json:"cached" required:"true"`
+	CacheHits    []ProfileTimeRange `json:"cacheHits" required:"true" nullable:"false"`
+	CacheMisses  []ProfileTimeRange `json:"cacheMisses" required:"true" nullable:"false"`
+	RowsRead     uint64             `json:"rowsRead" required:"true"`
+	BytesRead    uint64             `json:"bytesRead" required:"true"`
+	ExecutionMS  float64            `json:"executionMs" required:"true"`
+	ClickHouseMS float64            `json:"clickhouseMs" required:"true"`
+	FunctionsMS  float64            `json:"functionsMs" required:"true"`
+	ReduceMS     float64            `json:"reduceMs" required:"true"`
+	FormulaMS    float64            `json:"formulaMs" required:"true"`
+	Warnings     []string           `json:"warnings" required:"true" nullable:"false"`
+}
+
+// NewQueryProfile returns an empty QueryProfile, with its lists non-nil.
+func NewQueryProfile() *QueryProfile {
+	return &QueryProfile{
+		Statements:  []ProfileStatement{},
+		CacheHits:   []ProfileTimeRange{},
+		CacheMisses: []ProfileTimeRange{},
+		Warnings:    []string{},
+	}
+}
+
+// ProfileStatement is one ClickHouse statement a query ran, with the query_id it ran
+// with, what it read, its wall time until the last row was read and its granule-skip
+// breakdown. The query/args JSON keys follow the OpenTelemetry db.statement.* convention.
+type ProfileStatement struct {
+	Query      string                        `json:"db.statement.query" required:"true" nullable:"false"`
+	Args       []any                         `json:"db.statement.args" required:"true" nullable:"false"`
+	QueryID    string                        `json:"queryId" required:"true" nullable:"false"`
+	RowsRead   uint64                        `json:"rowsRead" required:"true"`
+	BytesRead  uint64                        `json:"bytesRead" required:"true"`
+	DurationMS float64                       `json:"durationMs" required:"true"`
+	Granules   *telemetrystoretypes.Granules `json:"granules" required:"true" nullable:"true"`
+}
+
+// ProfileTimeRange is a range of a query window in epoch milliseconds.
+type ProfileTimeRange struct {
+	From uint64 `json:"from" required:"true"`
+	To   uint64 `json:"to" required:"true"`
+}
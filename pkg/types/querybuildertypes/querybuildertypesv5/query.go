@@ -28,6 +28,8 @@ type ExecStats struct {
 	RowsScanned   uint64            `json:"rowsScanned"`
 	BytesScanned  uint64            `json:"bytesScanned"`
 	DurationMS    uint64            `json:"durationMs"`
+	QueuedMS      uint64            `json:"queuedMs,omitempty"`
+	Throttled     bool              `json:"throttled,omitempty"`
 	StepIntervals map[string]uint64 `json:"stepIntervals,omitempty"`
 }
 
@@ -35,7 +37,7 @@ var _ jsonschema.Preparer = &ExecStats{}
 
 // PrepareJSONSchema adds description to the ExecStats schema.
 func (e *ExecStats) PrepareJSONSchema(schema *jsonschema.Schema) error {
-	schema.WithDescription("Execution statistics for the query, including rows scanned, bytes scanned, and duration.")
+	schema.WithDescription("Execution statistics for the query, including rows scanned, bytes scanned, and duration. queuedMs is the time the queries waited for a querier slot, and throttled is set when they waited for the concurrency limit of the org or the user.")
 	return nil
 }
 
@@ -24,8 +24,8 @@ func (QueryType) Enum() []any {
 		QueryTypeBuilder,
 		QueryTypeBuilderAI,
 		QueryTypeFormula,
+		QueryTypeSubQuery,
 		// Not yet supported.
-		// QueryTypeSubQuery,
 		// QueryTypeJoin,
 		QueryTypeTraceOperator,
 		QueryTypeClickHouseSQL,
@@ -396,6 +396,16 @@ type QueryRangeRequest struct {
 	// support should not become part of the public request schema.
 	PromQLProvider string `json:"-"`
 
+	// CostOverride runs the request even when its estimated cost goes over the budget of
+	// the caller, when the querier is configured to require an override for such requests.
+	// It is set from the X-SigNoz-Query-Cost-Override header by the API handler.
+	CostOverride bool `json:"-"`
+
+	// Profile runs the request in profile mode: the response carries the statements every
+	// query ran and where the time went. It is set from the profile query-string parameter
+	// by the API handler.
+	Profile bool `json:"-"`
+
 	FormatOptions *FormatOptions `json:"formatOptions,omitempty"`
 }
 
@@ -421,6 +431,14 @@ func (r *QueryRangeRequest) StepIntervalForQuery(name string) (int64, error) {
 			stepsMap[spec.Name] = spec.StepInterval.Milliseconds()
 		}
 	}
+	// a join is bucketed like the queries it joins
+	for _, query := range r.CompositeQuery.Queries {
+		if spec, ok := query.Spec.(QueryBuilderJoin); ok {
+			if step, ok := stepsMap[spec.Left.Name]; ok {
+				stepsMap[spec.Name] = step
+			}
+		}
+	}
 
 	if step, ok := stepsMap[name]; ok {
 		return step, nil
@@ -468,6 +486,10 @@ func (r *QueryRangeRequest) NumAggregationForQuery(name string) int64 {
 			if spec.Name == name {
 				numAgg += 1
 			}
+		case QueryBuilderJoin:
+			if spec.Name == name {
+				numAgg += int(r.NumAggregationForQuery(spec.Left.Name) + r.NumAggregationForQuery(spec.Right.Name))
+			}
 		}
 	}
 	return int64(numAgg)
@@ -1193,6 +1193,107 @@ func TestParseTraceExpression(t *testing.T) {
 			expression:  "",
 			expectError: true,
 		},
+		{
+			name:            "followed by operation",
+			expression:      "A >> B",
+			expectError:     false,
+			expectedOpCount: 1,
+			checkResult: func(t *testing.T, result *TraceOperand) {
+				assert.Equal(t, TraceOperatorFollowedBy, *result.Operator)
+				assert.Equal(t, "A", result.Left.QueryRef.Name)
+				assert.Equal(t, "B", result.Right.QueryRef.Name)
+				assert.Zero(t, result.Window)
+			},
+		},
+		{
+			name:            "followed by within window",
+			expression:      "A >>[500ms] B",
+			expectError:     false,
+			expectedOpCount: 1,
+			checkResult: func(t *testing.T, result *TraceOperand) {
+				assert.Equal(t, TraceOperatorFollowedBy, *result.Operator)
+				assert.Equal(t, "A", result.Left.QueryRef.Name)
+				assert.Equal(t, "B", result.Right.QueryRef.Name)
+				assert.Equal(t, 500*time.Millisecond, result.Window)
+			},
+		},
+		{
+			name:            "not followed by within window",
+			expression:      "A !>> [ 2s ] (B || C)",
+			expectError:     false,
+			expectedOpCount: 2,
+			checkResult: func(t *testing.T, result *TraceOperand) {
+				assert.Equal(t, TraceOperatorNotFollowedBy, *result.Operator)
+				assert.Equal(t, 2*time.Second, result.Window)
+				assert.Equal(t, "A", result.Left.QueryRef.Name)
+				assert.Equal(t, TraceOperatorOr, *result.Right.Operator)
+			},
+		},
+		{
+			name:            "sibling binds tighter than followed by",
+			expression:      "A >> B ~ C",
+			expectError:     false,
+			expectedOpCount: 2,
+			checkResult: func(t *testing.T, result *TraceOperand) {
+				assert.Equal(t, TraceOperatorFollowedBy, *result.Operator)
+				assert.Equal(t, "A", result.Left.QueryRef.Name)
+				assert.Equal(t, TraceOperatorSibling, *result.Right.Operator)
+				assert.Equal(t, "B", result.Right.Left.QueryRef.Name)
+				assert.Equal(t, "C", result.Right.Right.QueryRef.Name)
+			},
+		},
+		{
+			name:            "count condition",
+			expression:      "COUNT(A) >= 3",
+			expectError:     false,
+			expectedOpCount: 1,
+			checkResult: func(t *testing.T, result *TraceOperand) {
+				assert.Equal(t, TraceOperatorCount, *result.Operator)
+				assert.Equal(t, "A", result.Left.QueryRef.Name)
+				assert.Nil(t, result.Right)
+				require.NotNil(t, result.Count)
+				assert.Equal(t, TraceOperatorComparisonGreaterThanOrEq, result.Count.Comparison)
+				assert.Equal(t, uint64(3), result.Count.Value)
+			},
+		},
+		{
+			name:            "count of expression combined with descendant",
+			expression:      "COUNT(A => B) > 1 && C",
+			expectError:     false,
+			expectedOpCount: 3,
+			checkResult: func(t *testing.T, result *TraceOperand) {
+				assert.Equal(t, TraceOperatorAnd, *result.Operator)
+				assert.Equal(t, TraceOperatorCount, *result.Left.Operator)
+				assert.Equal(t, TraceOperatorComparisonGreaterThan, result.Left.Count.Comparison)
+				assert.Equal(t, TraceOperatorDirectDescendant, *result.Left.Left.Operator)
+				assert.Equal(t, "C", result.Right.QueryRef.Name)
+			},
+		},
+		{
+			name:        "invalid time window",
+			expression:  "A >>[soon] B",
+			expectError: true,
+		},
+		{
+			name:        "negative time window",
+			expression:  "A >>[-1s] B",
+			expectError: true,
+		},
+		{
+			name:        "time window above maximum",
+			expression:  "A !>>[48h] B",
+			expectError: true,
+		},
+		{
+			name:        "zero count",
+			expression:  "COUNT(A) >= 0",
+			expectError: true,
+		},
+		{
+			name:        "count without condition",
+			expression:  "COUNT(A)",
+			expectError: true,
+		},
 		{
 			name:            "expression with extra whitespace",
 			expression:      "  A   =>   B  ",
@@ -1228,6 +1329,41 @@ func TestParseTraceExpression(t *testing.T) {
 	}
 }
 
+func TestParseTraceExpression_CountCondition(t *testing.T) {
+	tests := []struct {
+		expression  string
+		expectError bool
+	}{
+		{expression: "COUNT(A) = 0", expectError: true},
+		{expression: "COUNT(A) >= 0", expectError: true},
+		{expression: "COUNT(A) < 1", expectError: true},
+		{expression: "COUNT(A) < 5", expectError: true},
+		{expression: "COUNT(A) <= 0", expectError: true},
+		{expression: "COUNT(A) <= 3", expectError: true},
+		{expression: "COUNT(A) != 2", expectError: true},
+		{expression: "COUNT(A) < 0", expectError: true},
+		{expression: "COUNT(A) != 0"},
+		{expression: "COUNT(A) > 0"},
+		{expression: "COUNT(A) >= 1"},
+		{expression: "COUNT(A) = 2"},
+		{expression: "COUNT(A) > 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			result, _, err := parseTraceExpression(tt.expression)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result.Count)
+			assert.False(t, result.Count.Matches(0))
+		})
+	}
+}
+
 func TestQueryBuilderTraceOperator_ParseExpression_OperatorLimit(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -39,7 +39,7 @@ var (
 	RequestTypeRawStream = RequestType{valuer.NewString("raw_stream")}
 	// [][]any, Specialized SQL result set, paginated.
 	RequestTypeTrace = RequestType{valuer.NewString("trace")}
-	// []Bucket (struct{Lower,Upper,Count float64}), example: histogram.
+	// []Bucket (struct{Lower,Upper,Count float64}) per step, example: histogram and heatmap.
 	RequestTypeDistribution = RequestType{valuer.NewString("distribution")}
 )
 
@@ -60,6 +60,6 @@ func (RequestType) Enum() []any {
 		RequestTypeRaw,
 		RequestTypeRawStream,
 		RequestTypeTrace,
-		// RequestTypeDistribution,
+		RequestTypeDistribution,
 	}
 }
@@ -53,6 +53,7 @@ func (QueryData) JSONSchemaOneOf() []any {
 		TimeSeriesData{},
 		ScalarData{},
 		RawData{},
+		DistributionData{},
 	}
 }
 
@@ -63,6 +64,9 @@ type QueryRangeResponse struct {
 
 	Warning *QueryWarnData `json:"warning,omitempty"`
 
+	// Profile is set when the request runs in profile mode.
+	Profile *QueryRangeProfile `json:"profile,omitempty"`
+
 	QBEvent *QBEvent `json:"-"`
 }
 
@@ -128,7 +132,7 @@ var _ jsonschema.Preparer = &QueryRangeResponse{}
 
 // PrepareJSONSchema adds description to the QueryRangeResponse schema.
 func (q *QueryRangeResponse) PrepareJSONSchema(schema *jsonschema.Schema) error {
-	schema.WithDescription("Response from the v5 query range endpoint. The data.results array contains typed results depending on the requestType: TimeSeriesData for time_series, ScalarData for scalar, or RawData for raw requests.")
+	schema.WithDescription("Response from the v5 query range endpoint. The data.results array contains typed results depending on the requestType: TimeSeriesData for time_series, ScalarData for scalar, RawData for raw, or DistributionData for distribution requests.")
 	return nil
 }
 
@@ -298,6 +302,34 @@ type ScalarData struct {
 	Data      [][]any             `json:"data"`
 }
 
+// DistributionData is the result of a distribution request: the number of values in
+// each bucket, at every step of every series.
+type DistributionData struct {
+	QueryName string                `json:"queryName"`
+	Series    []*DistributionSeries `json:"series"`
+}
+
+type DistributionSeries struct {
+	Labels []*Label             `json:"labels,omitempty"`
+	Values []*DistributionValue `json:"values"`
+}
+
+type DistributionValue struct {
+	Timestamp int64                 `json:"timestamp"`
+	Buckets   []*DistributionBucket `json:"buckets"`
+
+	// true if the step is not covered completely, see TimeSeriesValue.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// DistributionBucket counts the values in (Lower, Upper]. The open ended buckets of
+// histogram metrics have infinite bounds, sent as "-Inf" and "Inf".
+type DistributionBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count float64 `json:"count"`
+}
+
 type RawData struct {
 	QueryName  string    `json:"queryName"`
 	NextCursor string    `json:"nextCursor"`
@@ -492,6 +524,65 @@ func (t TimeSeriesValue) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (b DistributionBucket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Lower any `json:"lower"`
+		Upper any `json:"upper"`
+		Count any `json:"count"`
+	}{
+		Lower: sanitizeBound(b.Lower),
+		Upper: sanitizeBound(b.Upper),
+		Count: sanitizeValue(b.Count),
+	})
+}
+
+func (b *DistributionBucket) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Lower any `json:"lower"`
+		Upper any `json:"upper"`
+		Count any `json:"count"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if b.Lower, err = parseBound(raw.Lower); err != nil {
+		return err
+	}
+	if b.Upper, err = parseBound(raw.Upper); err != nil {
+		return err
+	}
+	b.Count, err = parseBound(raw.Count)
+	return err
+}
+
+// sanitizeBound is sanitizeValue without the rounding, the bounds of a bucket identify it.
+func sanitizeBound(v float64) any {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return sanitizeValue(v)
+	}
+	return v
+}
+
+// parseBound reads back a float written by sanitizeBound or sanitizeValue.
+func parseBound(v any) (float64, error) {
+	switch x := v.(type) {
+	case float64:
+		return x, nil
+	case string:
+		switch x {
+		case "Inf":
+			return math.Inf(1), nil
+		case "-Inf":
+			return math.Inf(-1), nil
+		case "NaN":
+			return math.NaN(), nil
+		}
+	}
+	return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid distribution bucket value %v", v)
+}
+
 func (r RawData) MarshalJSON() ([]byte, error) {
 	type Alias RawData
 	return json.Marshal((*Alias)(&r))
@@ -0,0 +1,85 @@
+package querybuildertypesv5
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	// SubQueryRefPrefix prefixes the name of a builder_sub_query used as the values of an
+	// IN filter, e.g. `trace_id IN @A`.
+	SubQueryRefPrefix = "@"
+	// DefaultSubQueryLimit is the number of values a sub-query returns when it sets no limit.
+	DefaultSubQueryLimit = 1000
+	// MaxSubQueryLimit is the largest number of values a sub-query can return.
+	MaxSubQueryLimit = 10000
+)
+
+var subQueryRefRe = regexp.MustCompile(regexp.QuoteMeta(SubQueryRefPrefix) + `([A-Za-z0-9_]+)`)
+
+// SubQuery is a builder_sub_query compiled for the filters referencing it. The querier
+// hands it to those queries as the variable named after the reference; as it is not a
+// JSON value, a request cannot supply one.
+type SubQuery struct {
+	Name string
+	// Column is the column of the statement holding the values.
+	Column string
+	// Statement renders the statement of the sub-query, read by the filters as a CTE.
+	Statement StatementProvider
+}
+
+// SubQueryRef returns the reference to the sub-query in a filter expression.
+func SubQueryRef(name string) string {
+	return SubQueryRefPrefix + name
+}
+
+// CTEName returns the name of the CTE holding the statement of the sub-query.
+func (s *SubQuery) CTEName() string {
+	return "__sub_query_" + s.Name
+}
+
+// Condition matches the field against the values of the sub-query. Non string fields are
+// compared as strings, the type the values of every group by key are returned as. The
+// condition is escaped to be added as is to a builder.
+func (s *SubQuery) Condition(fieldExpression string, dataType telemetrytypes.FieldDataType, operator FilterOperator) (string, error) {
+	if dataType != telemetrytypes.FieldDataTypeString && dataType != telemetrytypes.FieldDataTypeUnspecified {
+		fieldExpression = fmt.Sprintf("toString(%s)", fieldExpression)
+	}
+
+	switch operator {
+	case FilterOperatorIn:
+		return sqlbuilder.Escape(fmt.Sprintf("%s GLOBAL IN (SELECT `%s` FROM %s)", fieldExpression, s.Column, s.CTEName())), nil
+	case FilterOperatorNotIn:
+		return sqlbuilder.Escape(fmt.Sprintf("%s GLOBAL NOT IN (SELECT `%s` FROM %s)", fieldExpression, s.Column, s.CTEName())), nil
+	default:
+		return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "sub-query %q can only be used with the IN and NOT IN operators", s.Name)
+	}
+}
+
+// SubQueriesIn returns the sub-queries the filter references, ordered by name.
+func SubQueriesIn(filter *Filter, variables map[string]VariableItem) []*SubQuery {
+	if filter == nil || !strings.Contains(filter.Expression, SubQueryRefPrefix) {
+		return nil
+	}
+
+	subQueries := []*SubQuery{}
+	for _, match := range subQueryRefRe.FindAllStringSubmatch(filter.Expression, -1) {
+		item, ok := variables[SubQueryRef(match[1])]
+		if !ok {
+			continue
+		}
+		subQuery, ok := item.Value.(*SubQuery)
+		if !ok || slices.Contains(subQueries, subQuery) {
+			continue
+		}
+		subQueries = append(subQueries, subQuery)
+	}
+	slices.SortFunc(subQueries, func(a, b *SubQuery) int { return strings.Compare(a.Name, b.Name) })
+	return subQueries
+}
@@ -0,0 +1,88 @@
+package querybuildertypesv5
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubQueriesIn(t *testing.T) {
+	a := &SubQuery{Name: "A", Column: "__GROUP_BY_KEY_0_trace_id"}
+	b := &SubQuery{Name: "B", Column: "__GROUP_BY_KEY_0_service.name"}
+	variables := map[string]VariableItem{
+		"@A":      {Value: a},
+		"@B":      {Value: b},
+		"@C":      {Value: "not a sub-query"},
+		"service": {Value: "frontend"},
+	}
+
+	cases := []struct {
+		name     string
+		filter   *Filter
+		expected []*SubQuery
+	}{
+		{name: "nil filter", filter: nil, expected: nil},
+		{name: "no reference", filter: &Filter{Expression: "service.name IN $service"}, expected: nil},
+		{name: "single", filter: &Filter{Expression: "trace_id IN @A"}, expected: []*SubQuery{a}},
+		{name: "ordered and deduplicated", filter: &Filter{Expression: "service.name NOT IN @B AND trace_id IN @A OR parent IN @A"}, expected: []*SubQuery{a, b}},
+		{name: "unknown and non sub-query variables", filter: &Filter{Expression: "trace_id IN @C OR trace_id IN @D"}, expected: []*SubQuery{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, SubQueriesIn(c.filter, variables))
+		})
+	}
+}
+
+func TestSubQueryCondition(t *testing.T) {
+	subQuery := &SubQuery{Name: "A", Column: "__GROUP_BY_KEY_0_trace_id"}
+
+	cases := []struct {
+		name     string
+		dataType telemetrytypes.FieldDataType
+		operator FilterOperator
+		expected string
+		err      string
+	}{
+		{
+			name:     "in",
+			dataType: telemetrytypes.FieldDataTypeString,
+			operator: FilterOperatorIn,
+			expected: "trace_id GLOBAL IN (SELECT `__GROUP_BY_KEY_0_trace_id` FROM __sub_query_A)",
+		},
+		{
+			name:     "not in",
+			dataType: telemetrytypes.FieldDataTypeUnspecified,
+			operator: FilterOperatorNotIn,
+			expected: "trace_id GLOBAL NOT IN (SELECT `__GROUP_BY_KEY_0_trace_id` FROM __sub_query_A)",
+		},
+		{
+			name:     "non string field",
+			dataType: telemetrytypes.FieldDataTypeInt64,
+			operator: FilterOperatorIn,
+			expected: "toString(trace_id) GLOBAL IN (SELECT `__GROUP_BY_KEY_0_trace_id` FROM __sub_query_A)",
+		},
+		{
+			name:     "unsupported operator",
+			dataType: telemetrytypes.FieldDataTypeString,
+			operator: FilterOperatorEqual,
+			err:      "can only be used with the IN and NOT IN operators",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			condition, err := subQuery.Condition("trace_id", c.dataType, c.operator)
+			if c.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, condition)
+		})
+	}
+}
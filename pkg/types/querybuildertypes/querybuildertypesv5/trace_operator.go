@@ -3,7 +3,9 @@ package querybuildertypesv5
 import (
 	"bytes"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/binding"
@@ -20,6 +22,26 @@ var (
 	TraceOperatorOr                 = TraceOperatorType{valuer.NewString("||")}
 	TraceOperatorNot                = TraceOperatorType{valuer.NewString("NOT")}
 	TraceOperatorExclude            = TraceOperatorType{valuer.NewString("NOT")}
+	// TraceOperatorFollowedBy matches spans of the left operand followed, after they end, by a span of the right operand.
+	TraceOperatorFollowedBy = TraceOperatorType{valuer.NewString(">>")}
+	// TraceOperatorNotFollowedBy matches spans of the left operand not followed by any span of the right operand.
+	TraceOperatorNotFollowedBy = TraceOperatorType{valuer.NewString("!>>")}
+	// TraceOperatorSibling matches spans of the left operand sharing their parent with a span of the right operand.
+	TraceOperatorSibling = TraceOperatorType{valuer.NewString("~")}
+	// TraceOperatorCount matches spans of its operand in traces where the number of such spans satisfies a condition.
+	TraceOperatorCount = TraceOperatorType{valuer.NewString("COUNT")}
+)
+
+// TraceOperatorComparison is the comparison of a COUNT condition.
+type TraceOperatorComparison struct{ valuer.String }
+
+var (
+	TraceOperatorComparisonGreaterThanOrEq = TraceOperatorComparison{valuer.NewString(">=")}
+	TraceOperatorComparisonGreaterThan     = TraceOperatorComparison{valuer.NewString(">")}
+	TraceOperatorComparisonLessThanOrEq    = TraceOperatorComparison{valuer.NewString("<=")}
+	TraceOperatorComparisonLessThan        = TraceOperatorComparison{valuer.NewString("<")}
+	TraceOperatorComparisonEq              = TraceOperatorComparison{valuer.NewString("=")}
+	TraceOperatorComparisonNotEq           = TraceOperatorComparison{valuer.NewString("!=")}
 )
 
 type TraceOrderBy struct {
@@ -34,6 +56,15 @@ var (
 const (
 	// MaxTraceOperators defines the maximum number of operators allowed in a trace expression.
 	MaxTraceOperators = 10
+	// MaxTraceOperatorWindow is the largest time window accepted by the sequence operators.
+	MaxTraceOperatorWindow = 24 * time.Hour
+)
+
+var (
+	// countExpressionRegex matches COUNT(<expression>) <comparison> <number>.
+	countExpressionRegex = regexp.MustCompile(`^COUNT\s*\((.+)\)\s*(>=|<=|!=|>|<|=)\s*([0-9]+)$`)
+	// windowRegex matches the optional [<duration>] suffix of the sequence operators.
+	windowRegex = regexp.MustCompile(`^\[\s*([^\]]+?)\s*\]`)
 )
 
 type QueryBuilderTraceOperator struct {
@@ -80,6 +111,38 @@ type TraceOperand struct {
 	Operator *TraceOperatorType `json:"-"`
 	Left     *TraceOperand      `json:"-"`
 	Right    *TraceOperand      `json:"-"`
+
+	// For sequence operators - the largest gap allowed between the end of the left span
+	// and the start of the right span. Zero means no limit.
+	Window time.Duration `json:"-"`
+
+	// For COUNT - the condition the number of spans of the operand in a trace must satisfy.
+	Count *TraceOperatorCountCondition `json:"-"`
+}
+
+// TraceOperatorCountCondition is the condition of a COUNT operand, e.g. COUNT(A) >= 3.
+type TraceOperatorCountCondition struct {
+	Comparison TraceOperatorComparison `json:"-"`
+	Value      uint64                  `json:"-"`
+}
+
+// Matches reports whether the number of spans satisfies the condition.
+func (c *TraceOperatorCountCondition) Matches(count uint64) bool {
+	switch c.Comparison {
+	case TraceOperatorComparisonGreaterThanOrEq:
+		return count >= c.Value
+	case TraceOperatorComparisonGreaterThan:
+		return count > c.Value
+	case TraceOperatorComparisonLessThanOrEq:
+		return count <= c.Value
+	case TraceOperatorComparisonLessThan:
+		return count < c.Value
+	case TraceOperatorComparisonEq:
+		return count == c.Value
+	case TraceOperatorComparisonNotEq:
+		return count != c.Value
+	}
+	return false
 }
 
 // TraceOperatorQueryRef represents a reference to another query.
@@ -435,7 +498,7 @@ func ValidateUniqueTraceOperator(queries []QueryEnvelope) error {
 	return nil
 }
 
-// Handles precedence: NOT (highest) > || > && > => (lowest).
+// Handles precedence: NOT (highest) > || > && > ~ > >>, !>> > => > -> (lowest).
 func parseTraceExpression(expr string) (*TraceOperand, int, error) {
 	expr = strings.TrimSpace(expr)
 
@@ -460,15 +523,25 @@ func parseTraceExpression(expr string) (*TraceOperand, int, error) {
 		}, count + 1, nil // Add 1 for this NOT operator
 	}
 
-	// Find binary operators with lowest precedence first (=> has lowest precedence)
-	// Order: => (lowest) < && < || < NOT (highest)
-	operators := []string{"->", "=>", "&&", "||", " NOT "}
+	// Find binary operators with lowest precedence first (-> has lowest precedence)
+	// Order: -> (lowest) < => < !>>, >> < ~ < && < || < NOT (highest)
+	operators := []string{"->", "=>", "!>>", ">>", "~", "&&", "||", " NOT "}
 
 	for _, op := range operators {
 		if pos := findOperatorPosition(expr, op); pos != -1 {
 			leftExpr := strings.TrimSpace(expr[:pos])
 			rightExpr := strings.TrimSpace(expr[pos+len(op):])
 
+			// Sequence operators take an optional time window, e.g. A >>[500ms] B
+			var window time.Duration
+			if op == "!>>" || op == ">>" {
+				var err error
+				window, rightExpr, err = parseTraceWindow(rightExpr)
+				if err != nil {
+					return nil, 0, err
+				}
+			}
+
 			left, leftCount, err := parseTraceExpression(leftExpr)
 			if err != nil {
 				return nil, 0, err
@@ -491,16 +564,54 @@ func parseTraceExpression(expr string) (*TraceOperand, int, error) {
 				opType = TraceOperatorOr
 			case "NOT":
 				opType = TraceOperatorExclude // Binary NOT (A NOT B)
+			case ">>":
+				opType = TraceOperatorFollowedBy
+			case "!>>":
+				opType = TraceOperatorNotFollowedBy
+			case "~":
+				opType = TraceOperatorSibling
 			}
 
 			return &TraceOperand{
 				Operator: &opType,
 				Left:     left,
 				Right:    right,
+				Window:   window,
 			}, leftCount + rightCount + 1, nil // Add counts from both sides + 1 for this operator
 		}
 	}
 
+	// COUNT(<expression>) <comparison> <number>
+	if matches := countExpressionRegex.FindStringSubmatch(expr); matches != nil && isBalancedParentheses(matches[1]) {
+		operand, count, err := parseTraceExpression(matches[1])
+		if err != nil {
+			return nil, 0, err
+		}
+
+		value, err := strconv.ParseUint(matches[3], 10, 64)
+		if err != nil {
+			return nil, 0, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "invalid count '%s'", matches[3])
+		}
+		condition := &TraceOperatorCountCondition{
+			Comparison: TraceOperatorComparison{valuer.NewString(matches[2])},
+			Value:      value,
+		}
+		// Traces without matching spans never reach the count, so the condition must need at least one
+		if condition.Matches(0) {
+			return nil, 0, errors.WrapInvalidInputf(nil, errors.CodeInvalidInput, "condition in '%s' must require at least one span, traces without spans of the operand are never counted", expr)
+		}
+		if !condition.Matches(max(value, 1)) && !condition.Matches(value+1) {
+			return nil, 0, errors.WrapInvalidInputf(nil, errors.CodeInvalidInput, "condition in '%s' cannot be satisfied", expr)
+		}
+
+		countOp := TraceOperatorCount
+		return &TraceOperand{
+			Operator: &countOp,
+			Left:     operand,
+			Count:    condition,
+		}, count + 1, nil // Add 1 for this COUNT operator
+	}
+
 	// If no operators found, this should be a query reference
 	if matched, _ := regexp.MatchString(`^[A-Za-z][A-Za-z0-9_]*$`, expr); !matched {
 		return nil, 0, errors.WrapInvalidInputf(
@@ -517,6 +628,25 @@ func parseTraceExpression(expr string) (*TraceOperand, int, error) {
 	}, 0, nil
 }
 
+// parseTraceWindow parses the optional [<duration>] prefix of the right operand of a sequence
+// operator and returns the window along with the rest of the operand.
+func parseTraceWindow(expr string) (time.Duration, string, error) {
+	matches := windowRegex.FindStringSubmatch(expr)
+	if matches == nil {
+		return 0, expr, nil
+	}
+
+	window, err := time.ParseDuration(matches[1])
+	if err != nil {
+		return 0, "", errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "invalid time window '%s'", matches[1])
+	}
+	if window <= 0 || window > MaxTraceOperatorWindow {
+		return 0, "", errors.WrapInvalidInputf(nil, errors.CodeInvalidInput, "time window must be positive and at most %s, got %s", MaxTraceOperatorWindow, matches[1])
+	}
+
+	return window, strings.TrimSpace(expr[len(matches[0]):]), nil
+}
+
 // isBalancedParentheses checks if parentheses are balanced in the expression.
 func isBalancedParentheses(expr string) bool {
 	depth := 0
@@ -556,6 +686,10 @@ func findOperatorPosition(expr, op string) int {
 		if depth == 0 && i+opLen <= len(expr) {
 			// Check if the substring matches our operator
 			if expr[i:i+opLen] == op {
+				// For ">>", skip the tail of "!>>"
+				if op == ">>" && i > 0 && expr[i-1] == '!' {
+					continue
+				}
 				// For " NOT " (binary), ensure proper spacing
 				if op == " NOT " {
 					// Make sure it's properly space-padded
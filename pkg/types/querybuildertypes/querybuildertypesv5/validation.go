@@ -79,6 +79,7 @@ type validationConfig struct {
 	skipGroupByValidation          bool
 	withTimestampGroupByValidation bool
 	withReduceToValidation         bool
+	withDistributionValidation     bool
 }
 
 func applyValidationOptions(opts []ValidationOption) validationConfig {
@@ -153,6 +154,15 @@ func WithReduceToValidation() ValidationOption {
 	}
 }
 
+// WithDistributionValidation enables validation that the query has the single
+// aggregation a distribution counts, and nothing applied on top of its buckets.
+// Used for distribution request types.
+func WithDistributionValidation() ValidationOption {
+	return func(cfg *validationConfig) {
+		cfg.withDistributionValidation = true
+	}
+}
+
 // Validate performs preliminary validation on QueryBuilderQuery.
 func (q *QueryBuilderQuery[T]) Validate(opts ...ValidationOption) error {
 	cfg := applyValidationOptions(opts)
@@ -189,6 +199,45 @@ func (q *QueryBuilderQuery[T]) Validate(opts ...ValidationOption) error {
 		return err
 	}
 
+	if err := q.validateDistribution(cfg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *QueryBuilderQuery[T]) validateDistribution(cfg validationConfig) error {
+	if !cfg.withDistributionValidation {
+		return nil
+	}
+
+	if len(q.Aggregations) != 1 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"distribution queries must have exactly one aggregation, got %d",
+			len(q.Aggregations),
+		)
+	}
+	switch v := any(q.Aggregations[0]).(type) {
+	case TraceAggregation:
+		if _, err := DistributionKey(v.Expression); err != nil {
+			return err
+		}
+	case LogAggregation:
+		if _, err := DistributionKey(v.Expression); err != nil {
+			return err
+		}
+	}
+
+	if q.Having != nil && q.Having.Expression != "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "having is not supported for distribution queries")
+	}
+	if len(q.Functions) > 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "functions are not supported for distribution queries")
+	}
+	if len(q.SecondaryAggregations) > 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "secondary aggregations are not supported for distribution queries")
+	}
 	return nil
 }
 
@@ -570,7 +619,7 @@ func (r *QueryRangeRequest) Validate(opts ...ValidationOption) error {
 
 	// Validate request type
 	switch r.RequestType {
-	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTrace, RequestTypeTimeSeries, RequestTypeScalar:
+	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTrace, RequestTypeTimeSeries, RequestTypeScalar, RequestTypeDistribution:
 		opts = append(opts, GetValidationOptions(r.RequestType)...)
 	default:
 		return errors.NewInvalidInputf(
@@ -578,10 +627,14 @@ func (r *QueryRangeRequest) Validate(opts ...ValidationOption) error {
 			"invalid request type: %s",
 			r.RequestType,
 		).WithAdditional(
-			"Valid request types are: raw, timeseries, scalar",
+			"Valid request types are: raw, timeseries, scalar, distribution",
 		)
 	}
 
+	if err := r.validateDistributionQueries(); err != nil {
+		return err
+	}
+
 	// raw/trace request types don't support metric queries;
 	// metrics are always aggregated and there is no raw form.
 	if r.RequestType == RequestTypeRaw || r.RequestType == RequestTypeRawStream || r.RequestType == RequestTypeTrace {
@@ -619,11 +672,15 @@ func (r *QueryRangeRequest) ValidateRequestScope() ([]ValidationOption, error) {
 
 	var opts []ValidationOption
 	switch r.RequestType {
-	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTrace, RequestTypeTimeSeries, RequestTypeScalar:
+	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTrace, RequestTypeTimeSeries, RequestTypeScalar, RequestTypeDistribution:
 		opts = GetValidationOptions(r.RequestType)
 	default:
 		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid request type: %s", r.RequestType).
-			WithAdditional("Valid request types are: raw, timeseries, scalar")
+			WithAdditional("Valid request types are: raw, timeseries, scalar, distribution")
+	}
+
+	if err := r.validateDistributionQueries(); err != nil {
+		return nil, err
 	}
 
 	if r.RequestType == RequestTypeRaw || r.RequestType == RequestTypeRawStream || r.RequestType == RequestTypeTrace {
@@ -677,6 +734,27 @@ func (e QueryEnvelope) Validate(opts ...ValidationOption) error {
 	return validateQueryEnvelope(e, opts...)
 }
 
+// validateDistributionQueries checks that a distribution request has only the builder
+// queries of logs, traces and metrics, the ones a distribution is counted for.
+func (r *QueryRangeRequest) validateDistributionQueries() error {
+	if r.RequestType != RequestTypeDistribution {
+		return nil
+	}
+
+	for _, envelope := range r.CompositeQuery.Queries {
+		if envelope.Type == QueryTypeBuilder {
+			continue
+		}
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"query %q of type %s is not supported for distribution requests",
+			envelope.GetQueryName(),
+			envelope.Type.StringValue(),
+		).WithAdditional("Distribution requests support only builder queries")
+	}
+	return nil
+}
+
 // validateAllQueriesNotDisabled validates that at least one query in the composite query is enabled.
 func (r *QueryRangeRequest) validateAllQueriesNotDisabled() error {
 	for _, envelope := range r.CompositeQuery.Queries {
@@ -833,6 +911,8 @@ func GetValidationOptions(requestType RequestType) []ValidationOption {
 		return []ValidationOption{WithSkipSelectFieldValidation(), WithTimestampGroupByValidation()}
 	case RequestTypeScalar:
 		return []ValidationOption{WithSkipSelectFieldValidation(), WithReduceToValidation()}
+	case RequestTypeDistribution:
+		return []ValidationOption{WithSkipSelectFieldValidation(), WithTimestampGroupByValidation(), WithDistributionValidation()}
 	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTrace:
 		return []ValidationOption{WithSkipAggregationValidation(), WithSkipHavingValidation(), WithSkipAggregationOrderBy(), WithSkipGroupByValidation()}
 	default:
@@ -0,0 +1,364 @@
+package rolluptypes
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/swaggest/jsonschema-go"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeRollupNotFound      = errors.MustNewCode("rollup_not_found")
+	ErrCodeRollupAlreadyExists = errors.MustNewCode("rollup_already_exists")
+	ErrCodeRollupInvalidInput  = errors.MustNewCode("rollup_invalid_input")
+	ErrCodeRollupNotActive     = errors.MustNewCode("rollup_not_active")
+	ErrCodeRollupCreateFailed  = errors.MustNewCode("rollup_create_failed")
+)
+
+const (
+	// MaxGroupBy is the largest number of group by keys of a rollup.
+	MaxGroupBy = 8
+	// MaxAggregations is the largest number of aggregations of a rollup.
+	MaxAggregations = 8
+	// MinInterval is the finest granularity of a rollup.
+	MinInterval = 10 * time.Second
+	// MaxInterval is the coarsest granularity of a rollup.
+	MaxInterval = 24 * time.Hour
+)
+
+// rollupNameRegex keeps rollup names short and readable.
+var rollupNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]{0,63}$`)
+
+// Status is the state of the ClickHouse tables of a rollup.
+type Status struct {
+	valuer.String
+}
+
+var (
+	// StatusPending rollups are being created in ClickHouse.
+	StatusPending = Status{valuer.NewString("pending")}
+	// StatusActive rollups serve the queries they match.
+	StatusActive = Status{valuer.NewString("active")}
+	// StatusFailed rollups could not be created in ClickHouse, see their status message.
+	StatusFailed = Status{valuer.NewString("failed")}
+)
+
+func (Status) Enum() []any {
+	return []any{StatusPending, StatusActive, StatusFailed}
+}
+
+// Source is how a rollup was defined.
+type Source struct {
+	valuer.String
+}
+
+var (
+	// SourceManual rollups are defined by an admin.
+	SourceManual = Source{valuer.NewString("manual")}
+	// SourceDetected rollups are defined from the frequently run query shapes.
+	SourceDetected = Source{valuer.NewString("detected")}
+)
+
+func (Source) Enum() []any {
+	return []any{SourceManual, SourceDetected}
+}
+
+// Strings is a []string stored as a JSON text column.
+type Strings []string
+
+// Rollup pre-aggregates the rows of a signal matching its filter by its group by keys, at
+// its interval, in a ClickHouse materialized view. Queries of its shape, or grouping by a
+// subset of its keys and aggregating a subset of its aggregations, read the rollup instead
+// of the raw rows for the windows after ActiveFrom, aligned to its interval. Rollups are not
+// backfilled: the rows ingested before ActiveFrom are only ever read raw.
+type Rollup struct {
+	bun.BaseModel `bun:"table:rollup,alias:rollup" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID           valuer.UUID           `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Name            string                `bun:"name,type:text,notnull" json:"name" required:"true"`
+	Signal          telemetrytypes.Signal `bun:"signal,type:text,notnull" json:"signal" required:"true"`
+	Filter          string                `bun:"filter,type:text,notnull" json:"filter" required:"true"`
+	GroupBy         Strings               `bun:"group_by,type:text,notnull" json:"groupBy" required:"true" nullable:"false"`
+	Aggregations    Strings               `bun:"aggregations,type:text,notnull" json:"aggregations" required:"true" nullable:"false"`
+	IntervalSeconds int64                 `bun:"interval_seconds,notnull" json:"intervalSeconds" required:"true"`
+	Source          Source                `bun:"source,type:text,notnull" json:"source" required:"true"`
+	Status          Status                `bun:"status,type:text,notnull" json:"status" required:"true"`
+	StatusMessage   string                `bun:"status_message,type:text,notnull" json:"statusMessage"`
+	ActiveFrom      time.Time             `bun:"active_from,notnull" json:"activeFrom" required:"true"`
+}
+
+type GettableRollup = Rollup
+
+type GettableRollups struct {
+	Items []*GettableRollup `json:"items" required:"true" nullable:"false"`
+}
+
+// PostableRollup is the request body to create a rollup.
+type PostableRollup struct {
+	Name            string                `json:"name" required:"true"`
+	Signal          telemetrytypes.Signal `json:"signal" required:"true"`
+	Filter          string                `json:"filter"`
+	GroupBy         []string              `json:"groupBy" required:"true"`
+	Aggregations    []string              `json:"aggregations" required:"true"`
+	IntervalSeconds int64                 `json:"intervalSeconds" required:"true"`
+}
+
+func (p *PostableRollup) Validate() error {
+	if !rollupNameRegex.MatchString(p.Name) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "name %q must start with a letter and contain at most 64 letters, digits, '_' or '-'", p.Name)
+	}
+	interval := time.Duration(p.IntervalSeconds) * time.Second
+	if interval < MinInterval || interval > MaxInterval {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "interval must be between %v and %v, got %v", MinInterval, MaxInterval, interval)
+	}
+	return p.Shape().Validate()
+}
+
+// Shape returns the normalized shape of the rollup.
+func (p *PostableRollup) Shape() Shape {
+	groupBy := make([]telemetrytypes.TelemetryFieldKey, 0, len(p.GroupBy))
+	for _, key := range p.GroupBy {
+		groupBy = append(groupBy, telemetrytypes.GetFieldKeyFromKeyText(key))
+	}
+	return NewShape(p.Signal, p.Filter, groupBy, p.Aggregations)
+}
+
+func NewRollup(orgID valuer.UUID, createdBy string, source Source, p *PostableRollup) *Rollup {
+	now := time.Now()
+	shape := p.Shape()
+	return &Rollup{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:           orgID,
+		Name:            p.Name,
+		Signal:          shape.Signal,
+		Filter:          shape.Filter,
+		GroupBy:         Strings(shape.GroupBy),
+		Aggregations:    Strings(shape.Aggregations),
+		IntervalSeconds: p.IntervalSeconds,
+		Source:          source,
+		Status:          StatusPending,
+	}
+}
+
+// Shape returns the shape the rollup pre-aggregates.
+func (r *Rollup) Shape() Shape {
+	return Shape{Signal: r.Signal, Filter: r.Filter, GroupBy: r.GroupBy, Aggregations: r.Aggregations}
+}
+
+// Interval returns the granularity of the rollup.
+func (r *Rollup) Interval() time.Duration {
+	return time.Duration(r.IntervalSeconds) * time.Second
+}
+
+// Activate marks the rollup as serving the windows from the second whole interval after now:
+// rows inserted before the materialized view existed are not in the rollup, and rows of the
+// next interval may still be in flight.
+func (r *Rollup) Activate(now time.Time) {
+	r.Status = StatusActive
+	r.StatusMessage = ""
+	r.ActiveFrom = now.Truncate(r.Interval()).Add(2 * r.Interval())
+	r.UpdatedAt = now
+}
+
+// Fail marks the rollup as failed with the error creating it.
+func (r *Rollup) Fail(err error) {
+	r.Status = StatusFailed
+	r.StatusMessage = err.Error()
+	r.UpdatedAt = time.Now()
+}
+
+// LocalTableName is the name of the table on every shard the materialized view writes to.
+func (r *Rollup) LocalTableName() string {
+	return "rollup_" + strings.ReplaceAll(r.ID.StringValue(), "-", "")
+}
+
+// TableName is the name of the distributed table over the local tables.
+func (r *Rollup) TableName() string {
+	return "distributed_" + r.LocalTableName()
+}
+
+// ViewName is the name of the materialized view writing to the local table.
+func (r *Rollup) ViewName() string {
+	return r.LocalTableName() + "_mv"
+}
+
+// Match reports whether the rollup can answer a query of the shape over [startMs, endMs),
+// at stepSeconds for time series or 0 for scalar queries, and returns the indexes of the
+// group by keys and aggregations of the query in the rollup when it can. The window must
+// start after ActiveFrom and be aligned, as the step, to the interval of the rollup.
+func (r *Rollup) Match(shape Shape, startMs, endMs uint64, stepSeconds int64) ([]int, []int, bool) {
+	if r.Status != StatusActive {
+		return nil, nil, false
+	}
+
+	intervalMs := uint64(r.IntervalSeconds) * 1000
+	if startMs < uint64(r.ActiveFrom.UnixMilli()) || startMs%intervalMs != 0 || endMs%intervalMs != 0 || endMs <= startMs {
+		return nil, nil, false
+	}
+	if stepSeconds != 0 && stepSeconds%r.IntervalSeconds != 0 {
+		return nil, nil, false
+	}
+
+	return r.Covers(shape)
+}
+
+// Covers reports whether the shape has the signal and filter of the rollup, and group by
+// keys and aggregations among the ones of the rollup, and returns their indexes in it. The
+// filters are compared normalized, for the ones of the rollups created before the filters
+// of shapes were.
+func (r *Rollup) Covers(shape Shape) ([]int, []int, bool) {
+	if shape.Signal != r.Signal || NormalizeFilter(shape.Filter) != NormalizeFilter(r.Filter) {
+		return nil, nil, false
+	}
+
+	groupBy := make([]int, 0, len(shape.GroupBy))
+	for _, key := range shape.GroupBy {
+		idx := slices.Index(r.GroupBy, key)
+		if idx < 0 {
+			return nil, nil, false
+		}
+		groupBy = append(groupBy, idx)
+	}
+
+	aggregations := make([]int, 0, len(shape.Aggregations))
+	for _, aggregation := range shape.Aggregations {
+		idx := slices.Index(r.Aggregations, aggregation)
+		if idx < 0 {
+			return nil, nil, false
+		}
+		aggregations = append(aggregations, idx)
+	}
+
+	return groupBy, aggregations, true
+}
+
+// Candidate is a query shape run often enough to be worth a rollup.
+type Candidate struct {
+	Shape
+	// QueriesPerHour is the number of ClickHouse queries of the shape in the last hours.
+	QueriesPerHour float64 `json:"queriesPerHour" required:"true"`
+	// RollupID is the rollup already serving the shape, if any.
+	RollupID *valuer.UUID `json:"rollupId,omitempty"`
+}
+
+type GettableCandidates struct {
+	Items []*Candidate `json:"items" required:"true" nullable:"false"`
+}
+
+// PostableVerification is the window, in epoch milliseconds, over which to compare the
+// results of a rollup with the ones of the raw rows.
+type PostableVerification struct {
+	Start uint64 `json:"start" required:"true"`
+	End   uint64 `json:"end" required:"true"`
+}
+
+func (p *PostableVerification) Validate() error {
+	if p.End <= p.Start {
+		return errors.New(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "end must be after start")
+	}
+	return nil
+}
+
+// Mismatch is a value of the rollup different from the one of the raw rows.
+type Mismatch struct {
+	Timestamp   time.Time         `json:"timestamp" required:"true"`
+	Group       map[string]string `json:"group" required:"true" nullable:"false"`
+	Aggregation string            `json:"aggregation" required:"true"`
+	Raw         *float64          `json:"raw"`
+	Rollup      *float64          `json:"rollup"`
+}
+
+// GettableVerification is the comparison of the results of a rollup with the ones of the
+// raw rows, at the interval of the rollup, grouping by all its keys.
+type GettableVerification struct {
+	Match      bool   `json:"match" required:"true"`
+	Start      uint64 `json:"start" required:"true"`
+	End        uint64 `json:"end" required:"true"`
+	RawRows    int    `json:"rawRows" required:"true"`
+	RollupRows int    `json:"rollupRows" required:"true"`
+	// MismatchCount is the number of values of the rollup different from the raw ones.
+	MismatchCount int         `json:"mismatchCount" required:"true"`
+	Mismatches    []*Mismatch `json:"mismatches" required:"true" nullable:"false"`
+}
+
+var _ jsonschema.Preparer = &GettableVerification{}
+
+// PrepareJSONSchema adds description to the GettableVerification schema.
+func (v *GettableVerification) PrepareJSONSchema(schema *jsonschema.Schema) error {
+	schema.WithDescription("Comparison of the results of a rollup with the ones of the raw rows over the window, aligned to the interval of the rollup. Only the first mismatches are listed, the count has all of them.")
+	return nil
+}
+
+// Store persists the rollup definitions.
+type Store interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*Rollup, error)
+	Get(ctx context.Context, orgID, id valuer.UUID) (*Rollup, error)
+	Create(ctx context.Context, rollup *Rollup) error
+	Update(ctx context.Context, rollup *Rollup) error
+	Delete(ctx context.Context, orgID, id valuer.UUID) error
+}
+
+// Getter returns the active rollups of an org, for the statement builders to route the
+// queries they match to them.
+type Getter interface {
+	ListActive(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal) ([]*Rollup, error)
+}
+
+// StatementBuilder builds the statements maintaining and reading the rollups of a signal.
+type StatementBuilder interface {
+	// BuildCreate builds the statements creating, on every shard of the cluster, the table
+	// of the rollup, the distributed table over it and the materialized view aggregating the
+	// rows inserted into the signal into it.
+	BuildCreate(ctx context.Context, orgID valuer.UUID, cluster string, rollup *Rollup) ([]string, error)
+	// BuildDrop builds the statements dropping what BuildCreate creates.
+	BuildDrop(cluster string, rollup *Rollup) []string
+	// BuildVerification builds the time series statements reading the rollup and the raw
+	// rows over the window, at the interval of the rollup and grouping by all its keys.
+	BuildVerification(ctx context.Context, orgID valuer.UUID, rollup *Rollup, startMs, endMs uint64) (*qbtypes.Statement, *qbtypes.Statement, error)
+}
+
+func (s Strings) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (s *Strings) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	case nil:
+		return nil
+	default:
+		return errors.NewInternalf(errors.CodeInternal, "rollup: cannot scan %T into %T", src, s)
+	}
+	return json.Unmarshal(raw, s)
+}
@@ -0,0 +1,198 @@
+package rolluptypes
+
+import (
+	"testing"
+	"time"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShapeOf(t *testing.T) {
+	query := qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+		Signal:       telemetrytypes.SignalLogs,
+		Filter:       &qbtypes.Filter{Expression: "  severity_text = 'ERROR'   AND body CONTAINS 'a  b' "},
+		GroupBy:      []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource}}},
+		Aggregations: []qbtypes.LogAggregation{{Expression: "count( )"}},
+	}
+
+	shape, ok := ShapeOf(query)
+	require.True(t, ok)
+	assert.Equal(t, Shape{
+		Signal:       telemetrytypes.SignalLogs,
+		Filter:       "severity_text = 'ERROR' AND body CONTAINS 'a  b'",
+		GroupBy:      []string{"resource.service.name"},
+		Aggregations: []string{"count( )"},
+	}, shape)
+
+	query.Aggregations = nil
+	_, ok = ShapeOf(query)
+	assert.False(t, ok)
+
+	_, ok = ShapeOf(qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+		Signal:       telemetrytypes.SignalMetrics,
+		Aggregations: []qbtypes.MetricAggregation{{MetricName: "m"}},
+	})
+	assert.False(t, ok)
+}
+
+func TestNormalizeFilter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{name: "Empty", filter: "  ", want: ""},
+		{name: "Spacing", filter: "  severity_text='ERROR'and(code>=500 or  code<>404) ", want: "severity_text = 'ERROR' AND (code >= 500 OR code != 404)"},
+		{name: "Keywords", filter: "service.name not in ('a','b') and body contain 'x' and trace_id exist", want: "service.name NOT IN ('a', 'b') AND body CONTAINS 'x' AND trace_id EXISTS"},
+		{name: "Operators", filter: "a == 1 AND b <> 2", want: "a = 1 AND b != 2"},
+		{name: "QuotedStringsKept", filter: `body = 'and  Or=' AND msg = "it\'s  \"x\""`, want: `body = 'and  Or=' AND msg = "it\'s  \"x\""`},
+		{name: "Functions", filter: "has(tags,'a')  AND hasAny( tags , ['a','b'] )", want: "HAS(tags, 'a') AND HASANY(tags, ['a', 'b'])"},
+		{name: "UnclosedQuote", filter: "body  =  'a", want: "body = 'a"},
+		{name: "UnknownOperator", filter: "a  !  b", want: "a ! b"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, NormalizeFilter(tc.filter))
+		})
+	}
+}
+
+func TestShapeKey(t *testing.T) {
+	a := Shape{Signal: telemetrytypes.SignalTraces, GroupBy: []string{"b", "a"}, Aggregations: []string{"count()", "p99(duration_nano)"}}
+	b := Shape{Signal: telemetrytypes.SignalTraces, GroupBy: []string{"a", "b"}, Aggregations: []string{"p99(duration_nano)", "count()"}}
+	assert.Equal(t, a.Key(), b.Key())
+
+	parsed, err := ParseShapeKey(a.Key())
+	require.NoError(t, err)
+	assert.Equal(t, a.Key(), parsed.Key())
+}
+
+func TestRollupMatch(t *testing.T) {
+	activeFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	startMs := uint64(activeFrom.Add(time.Hour).UnixMilli())
+	endMs := uint64(activeFrom.Add(2 * time.Hour).UnixMilli())
+
+	rollup := &Rollup{
+		OrgID:           valuer.GenerateUUID(),
+		Signal:          telemetrytypes.SignalLogs,
+		Filter:          "severity_text = 'ERROR'",
+		GroupBy:         Strings{"resource.service.name", "attribute.http.route"},
+		Aggregations:    Strings{"count()", "avg(duration)"},
+		IntervalSeconds: 60,
+		Status:          StatusActive,
+		ActiveFrom:      activeFrom,
+	}
+
+	shape := Shape{
+		Signal:       telemetrytypes.SignalLogs,
+		Filter:       "severity_text = 'ERROR'",
+		GroupBy:      []string{"attribute.http.route"},
+		Aggregations: []string{"avg(duration)"},
+	}
+
+	testCases := []struct {
+		name             string
+		mutate           func(r *Rollup, s *Shape)
+		startMs, endMs   uint64
+		step             int64
+		wantGroupBy      []int
+		wantAggregations []int
+		wantOk           bool
+	}{
+		{name: "TimeSeries", startMs: startMs, endMs: endMs, step: 300, wantGroupBy: []int{1}, wantAggregations: []int{1}, wantOk: true},
+		{name: "Scalar", startMs: startMs, endMs: endMs, step: 0, wantGroupBy: []int{1}, wantAggregations: []int{1}, wantOk: true},
+		{name: "StepNotMultipleOfInterval", startMs: startMs, endMs: endMs, step: 90},
+		{name: "StartNotAligned", startMs: startMs + 1000, endMs: endMs, step: 60},
+		{name: "EndNotAligned", startMs: startMs, endMs: endMs - 1000, step: 60},
+		{name: "StartBeforeActive", startMs: uint64(activeFrom.Add(-time.Hour).UnixMilli()), endMs: endMs, step: 60},
+		{name: "NotActive", mutate: func(r *Rollup, _ *Shape) { r.Status = StatusPending }, startMs: startMs, endMs: endMs, step: 60},
+		{name: "OtherFilter", mutate: func(_ *Rollup, s *Shape) { s.Filter = "" }, startMs: startMs, endMs: endMs, step: 60},
+		{name: "FilterWrittenDifferently", mutate: func(_ *Rollup, s *Shape) { s.Filter = "severity_text=='ERROR'" }, startMs: startMs, endMs: endMs, step: 60, wantGroupBy: []int{1}, wantAggregations: []int{1}, wantOk: true},
+		{name: "OtherSignal", mutate: func(_ *Rollup, s *Shape) { s.Signal = telemetrytypes.SignalTraces }, startMs: startMs, endMs: endMs, step: 60},
+		{name: "GroupByNotInRollup", mutate: func(_ *Rollup, s *Shape) { s.GroupBy = []string{"host.name"} }, startMs: startMs, endMs: endMs, step: 60},
+		{name: "AggregationNotInRollup", mutate: func(_ *Rollup, s *Shape) { s.Aggregations = []string{"sum(duration)"} }, startMs: startMs, endMs: endMs, step: 60},
+		{
+			name: "AllGroupByAndAggregations",
+			mutate: func(_ *Rollup, s *Shape) {
+				s.GroupBy = []string{"attribute.http.route", "resource.service.name"}
+				s.Aggregations = []string{"count()"}
+			},
+			startMs:          startMs,
+			endMs:            endMs,
+			step:             60,
+			wantGroupBy:      []int{1, 0},
+			wantAggregations: []int{0},
+			wantOk:           true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, s := *rollup, shape
+			if tc.mutate != nil {
+				tc.mutate(&r, &s)
+			}
+
+			groupBy, aggregations, ok := r.Match(s, tc.startMs, tc.endMs, tc.step)
+			assert.Equal(t, tc.wantOk, ok)
+			if tc.wantOk {
+				assert.Equal(t, tc.wantGroupBy, groupBy)
+				assert.Equal(t, tc.wantAggregations, aggregations)
+			}
+		})
+	}
+}
+
+func TestRollupActivate(t *testing.T) {
+	rollup := &Rollup{IntervalSeconds: 60, Status: StatusPending}
+	rollup.Activate(time.Date(2026, 1, 1, 10, 15, 30, 0, time.UTC))
+
+	assert.Equal(t, StatusActive, rollup.Status)
+	assert.Equal(t, time.Date(2026, 1, 1, 10, 17, 0, 0, time.UTC), rollup.ActiveFrom)
+}
+
+func TestPostableRollupValidate(t *testing.T) {
+	valid := func() *PostableRollup {
+		return &PostableRollup{
+			Name:            "errors-by-service",
+			Signal:          telemetrytypes.SignalLogs,
+			Filter:          "severity_text = 'ERROR'",
+			GroupBy:         []string{"resource.service.name"},
+			Aggregations:    []string{"count()"},
+			IntervalSeconds: 60,
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		mutate  func(p *PostableRollup)
+		wantErr string
+	}{
+		{name: "Valid", mutate: func(*PostableRollup) {}},
+		{name: "InvalidName", mutate: func(p *PostableRollup) { p.Name = "1 rollup" }, wantErr: "must start with a letter"},
+		{name: "IntervalTooSmall", mutate: func(p *PostableRollup) { p.IntervalSeconds = 1 }, wantErr: "interval must be between"},
+		{name: "Metrics", mutate: func(p *PostableRollup) { p.Signal = telemetrytypes.SignalMetrics }, wantErr: "only supported for logs and traces"},
+		{name: "Variable", mutate: func(p *PostableRollup) { p.Filter = "service.name = $service" }, wantErr: "cannot use variables"},
+		{name: "NoAggregation", mutate: func(p *PostableRollup) { p.Aggregations = nil }, wantErr: "at least one aggregation"},
+		{name: "RepeatedGroupBy", mutate: func(p *PostableRollup) { p.GroupBy = []string{"a", "a"} }, wantErr: "is repeated"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := valid()
+			tc.mutate(p)
+			err := p.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
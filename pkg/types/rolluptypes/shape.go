@@ -0,0 +1,285 @@
+package rolluptypes
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// Shape is the filter, group by and aggregations of a builder aggregation query, the part
+// of the query a rollup pre-aggregates. Group by keys are in their text form, e.g.
+// resource.service.name:string.
+type Shape struct {
+	Signal       telemetrytypes.Signal `json:"signal" required:"true"`
+	Filter       string                `json:"filter"`
+	GroupBy      []string              `json:"groupBy" required:"true" nullable:"false"`
+	Aggregations []string              `json:"aggregations" required:"true" nullable:"false"`
+}
+
+// NewShape returns the shape of a query with its expressions normalized, so that queries
+// differing only in spacing, or in the case and spelling of the filter keywords and
+// operators, have the same shape.
+func NewShape(signal telemetrytypes.Signal, filter string, groupBy []telemetrytypes.TelemetryFieldKey, aggregations []string) Shape {
+	shape := Shape{
+		Signal:       signal,
+		Filter:       NormalizeFilter(filter),
+		GroupBy:      make([]string, 0, len(groupBy)),
+		Aggregations: make([]string, 0, len(aggregations)),
+	}
+	for i := range groupBy {
+		shape.GroupBy = append(shape.GroupBy, telemetrytypes.TelemetryFieldKeyToText(&groupBy[i]))
+	}
+	for _, aggregation := range aggregations {
+		shape.Aggregations = append(shape.Aggregations, normalizeExpression(aggregation))
+	}
+	return shape
+}
+
+// Key identifies the shape regardless of the order of its group by keys and aggregations.
+// It is the JSON of the shape with both sorted, see ParseShapeKey.
+func (s Shape) Key() string {
+	sorted := Shape{
+		Signal:       s.Signal,
+		Filter:       s.Filter,
+		GroupBy:      slices.Sorted(slices.Values(s.GroupBy)),
+		Aggregations: slices.Sorted(slices.Values(s.Aggregations)),
+	}
+	key, _ := json.Marshal(sorted)
+	return string(key)
+}
+
+// ParseShapeKey returns the shape of a key returned by Key.
+func ParseShapeKey(key string) (Shape, error) {
+	var shape Shape
+	if err := json.Unmarshal([]byte(key), &shape); err != nil {
+		return Shape{}, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "invalid shape key")
+	}
+	return shape, nil
+}
+
+// Validate checks the shape is a logs or traces shape without variables. Whether its
+// aggregations can be pre-aggregated is checked when building the rollup.
+func (s Shape) Validate() error {
+	if s.Signal != telemetrytypes.SignalLogs && s.Signal != telemetrytypes.SignalTraces {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "rollups are only supported for logs and traces, got %q", s.Signal.StringValue())
+	}
+	if strings.Contains(s.Filter, "$") {
+		return errors.New(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "the filter of a rollup cannot use variables")
+	}
+	if len(s.GroupBy) > MaxGroupBy {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "at most %d group by keys are allowed, got %d", MaxGroupBy, len(s.GroupBy))
+	}
+	for i, key := range s.GroupBy {
+		if key == "" {
+			return errors.New(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "group by key cannot be empty")
+		}
+		if slices.Contains(s.GroupBy[:i], key) {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "group by key %q is repeated", key)
+		}
+	}
+	if len(s.Aggregations) == 0 {
+		return errors.New(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "at least one aggregation is required")
+	}
+	if len(s.Aggregations) > MaxAggregations {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "at most %d aggregations are allowed, got %d", MaxAggregations, len(s.Aggregations))
+	}
+	for i, aggregation := range s.Aggregations {
+		if aggregation == "" {
+			return errors.New(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "aggregation cannot be empty")
+		}
+		if slices.Contains(s.Aggregations[:i], aggregation) {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeRollupInvalidInput, "aggregation %q is repeated", aggregation)
+		}
+	}
+	return nil
+}
+
+// filterKeywords maps the keywords of the filter grammar, in upper case, to their canonical
+// spelling. The grammar matches them regardless of case, and EXIST and CONTAIN are aliases.
+var filterKeywords = map[string]string{
+	"AND":      "AND",
+	"OR":       "OR",
+	"NOT":      "NOT",
+	"IN":       "IN",
+	"LIKE":     "LIKE",
+	"ILIKE":    "ILIKE",
+	"BETWEEN":  "BETWEEN",
+	"EXIST":    "EXISTS",
+	"EXISTS":   "EXISTS",
+	"REGEXP":   "REGEXP",
+	"CONTAIN":  "CONTAINS",
+	"CONTAINS": "CONTAINS",
+	"HASTOKEN": "HASTOKEN",
+	"HAS":      "HAS",
+	"HASANY":   "HASANY",
+	"HASALL":   "HASALL",
+	"SEARCH":   "SEARCH",
+	"TRUE":     "true",
+	"FALSE":    "false",
+}
+
+// filterFunctions are the keywords of the filter grammar called with parentheses.
+var filterFunctions = map[string]bool{"HASTOKEN": true, "HAS": true, "HASANY": true, "HASALL": true, "SEARCH": true}
+
+// filterOperators maps the comparison operators of the filter grammar to their canonical
+// spelling.
+var filterOperators = map[string]string{
+	"=":  "=",
+	"==": "=",
+	"!=": "!=",
+	"<>": "!=",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+}
+
+// NormalizeFilter returns the filter expression with its tokens, outside of quoted strings,
+// separated by single spaces, and its keywords and operators spelled the same way, so that
+// filters a rollup and a query write differently but parse alike compare equal. Filters
+// with characters the grammar does not know are only normalized for whitespace.
+func NormalizeFilter(filter string) string {
+	tokens, ok := filterTokens(filter)
+	if !ok {
+		return normalizeExpression(filter)
+	}
+
+	var sb strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			previous := tokens[i-1]
+			call := token == "(" && filterFunctions[strings.ToUpper(previous)]
+			if previous != "(" && previous != "[" && token != ")" && token != "]" && token != "," && !call {
+				sb.WriteByte(' ')
+			}
+		}
+		switch {
+		case filterKeywords[strings.ToUpper(token)] != "":
+			sb.WriteString(filterKeywords[strings.ToUpper(token)])
+		case filterOperators[token] != "":
+			sb.WriteString(filterOperators[token])
+		default:
+			sb.WriteString(token)
+		}
+	}
+	return sb.String()
+}
+
+// filterTokens splits the filter into quoted strings, operators, punctuation and words, and
+// returns false if a quoted string is not closed or an operator is not one of the grammar.
+func filterTokens(filter string) ([]string, bool) {
+	var tokens []string
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			j := i + 1
+			for ; j < len(filter) && filter[j] != c; j++ {
+				if filter[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(filter) {
+				return nil, false
+			}
+			tokens = append(tokens, filter[i:j+1])
+			i = j + 1
+		case strings.IndexByte("=!<>", c) >= 0:
+			j := i + 1
+			for j < len(filter) && strings.IndexByte("=!<>", filter[j]) >= 0 {
+				j++
+			}
+			if filterOperators[filter[i:j]] == "" {
+				return nil, false
+			}
+			tokens = append(tokens, filter[i:j])
+			i = j
+		case strings.IndexByte("()[],", c) >= 0:
+			tokens = append(tokens, filter[i:i+1])
+			i++
+		default:
+			j := i + 1
+			for j < len(filter) && strings.IndexByte(" \t\n\r'\"`=!<>()[],", filter[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, filter[i:j])
+			i = j
+		}
+	}
+	return tokens, true
+}
+
+// normalizeExpression collapses the whitespace of an expression outside of its quoted
+// strings.
+func normalizeExpression(expression string) string {
+	var (
+		sb      strings.Builder
+		quote   rune
+		space   bool
+		escaped bool
+	)
+	for _, r := range strings.TrimSpace(expression) {
+		switch {
+		case quote != 0:
+			if escaped {
+				escaped = false
+			} else if r == '\\' {
+				escaped = true
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// ShapeOf returns the shape of a logs or traces builder aggregation query, false for the
+// queries rollups do not serve.
+func ShapeOf[T any](query qbtypes.QueryBuilderQuery[T]) (Shape, bool) {
+	if query.Signal != telemetrytypes.SignalLogs && query.Signal != telemetrytypes.SignalTraces {
+		return Shape{}, false
+	}
+	if query.Source != telemetrytypes.SourceUnspecified || len(query.Aggregations) == 0 || len(query.SecondaryAggregations) > 0 {
+		return Shape{}, false
+	}
+
+	aggregations := make([]string, 0, len(query.Aggregations))
+	for _, aggregation := range query.Aggregations {
+		switch aggregation := any(aggregation).(type) {
+		case qbtypes.LogAggregation:
+			aggregations = append(aggregations, aggregation.Expression)
+		case qbtypes.TraceAggregation:
+			aggregations = append(aggregations, aggregation.Expression)
+		default:
+			return Shape{}, false
+		}
+	}
+
+	var filter string
+	if query.Filter != nil {
+		filter = query.Filter.Expression
+	}
+
+	groupBy := make([]telemetrytypes.TelemetryFieldKey, 0, len(query.GroupBy))
+	for _, key := range query.GroupBy {
+		groupBy = append(groupBy, key.TelemetryFieldKey)
+	}
+
+	return NewShape(query.Signal, filter, groupBy, aggregations), true
+}
@@ -0,0 +1,172 @@
+package servicetopologytypes
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// ErrCodeTopologyTooManySpans is returned when a period holds more spans than a topology query reads.
+var ErrCodeTopologyTooManySpans = errors.MustNewCode("topology_too_many_spans")
+
+// NodeType is the kind of component a topology node stands for.
+type NodeType struct {
+	valuer.String
+}
+
+var (
+	// NodeTypeService is an instrumented service, identified by service.name.
+	NodeTypeService = NodeType{valuer.NewString("service")}
+	// NodeTypeDatabase is a database called by a client span, identified by its database system.
+	NodeTypeDatabase = NodeType{valuer.NewString("database")}
+	// NodeTypeMessaging is a messaging destination written to by producer spans and read from by consumer spans.
+	NodeTypeMessaging = NodeType{valuer.NewString("messaging")}
+	// NodeTypeExternal is an uninstrumented peer called by a client span, identified by its peer service name.
+	NodeTypeExternal = NodeType{valuer.NewString("external")}
+)
+
+func (NodeType) Enum() []any {
+	return []any{
+		NodeTypeService,
+		NodeTypeDatabase,
+		NodeTypeMessaging,
+		NodeTypeExternal,
+	}
+}
+
+// ChangeStatus describes how a node or an edge differs from the previous period.
+type ChangeStatus struct {
+	valuer.String
+}
+
+var (
+	// ChangeStatusAdded is a node or an edge seen only in the current period.
+	ChangeStatusAdded = ChangeStatus{valuer.NewString("added")}
+	// ChangeStatusRemoved is a node or an edge seen only in the previous period.
+	ChangeStatusRemoved = ChangeStatus{valuer.NewString("removed")}
+	// ChangeStatusExisting is a node or an edge seen in both periods.
+	ChangeStatusExisting = ChangeStatus{valuer.NewString("existing")}
+)
+
+func (ChangeStatus) Enum() []any {
+	return []any{
+		ChangeStatusAdded,
+		ChangeStatusRemoved,
+		ChangeStatusExisting,
+	}
+}
+
+// PostableTopology is the request body for the service topology API.
+// Start and End are epoch milliseconds. The filter selects traces: a trace is part of the
+// topology when at least one of its spans matches the filter. When CompareWithPrevious is
+// set, every node and edge carries its change against the period of the same length
+// ending at Start.
+type PostableTopology struct {
+	Start               uint64          `json:"start" required:"true"`
+	End                 uint64          `json:"end" required:"true"`
+	Filter              *qbtypes.Filter `json:"filter"`
+	CompareWithPrevious bool            `json:"compareWithPrevious"`
+}
+
+func (p *PostableTopology) Validate() error {
+	if p.Start >= p.End {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start time must be before end time")
+	}
+	return nil
+}
+
+// PreviousPeriod returns the bounds of the period of the same length ending at Start.
+func (p *PostableTopology) PreviousPeriod() (uint64, uint64) {
+	length := p.End - p.Start
+	if length > p.Start {
+		return 0, p.Start
+	}
+	return p.Start - length, p.Start
+}
+
+// Stats are the RED metrics of a node or an edge. RequestRate is per second, ErrorRate is
+// a percentage of CallCount and the latencies are in nanoseconds.
+type Stats struct {
+	CallCount   uint64  `json:"callCount" required:"true"`
+	ErrorCount  uint64  `json:"errorCount" required:"true"`
+	RequestRate float64 `json:"requestRate" required:"true"`
+	ErrorRate   float64 `json:"errorRate" required:"true"`
+	P50Nano     float64 `json:"p50Nano" required:"true"`
+	P95Nano     float64 `json:"p95Nano" required:"true"`
+	P99Nano     float64 `json:"p99Nano" required:"true"`
+}
+
+// Change compares the stats of a node or an edge with the previous period.
+// Deltas are current minus previous.
+type Change struct {
+	Status           ChangeStatus `json:"status" required:"true"`
+	Previous         Stats        `json:"previous" required:"true"`
+	RequestRateDelta float64      `json:"requestRateDelta" required:"true"`
+	ErrorRateDelta   float64      `json:"errorRateDelta" required:"true"`
+	P50DeltaNano     float64      `json:"p50DeltaNano" required:"true"`
+	P95DeltaNano     float64      `json:"p95DeltaNano" required:"true"`
+	P99DeltaNano     float64      `json:"p99DeltaNano" required:"true"`
+}
+
+// Node is a component of the topology. The stats of a service node are computed over its
+// entry spans, those of the other nodes over the client, producer and consumer spans
+// pointing at them.
+type Node struct {
+	Name   string   `json:"name" required:"true"`
+	Type   NodeType `json:"type" required:"true"`
+	Stats  Stats    `json:"stats" required:"true"`
+	Change *Change  `json:"change,omitempty"`
+}
+
+// Edge is a caller to callee dependency between two nodes.
+type Edge struct {
+	Source     string   `json:"source" required:"true"`
+	SourceType NodeType `json:"sourceType" required:"true"`
+	Target     string   `json:"target" required:"true"`
+	TargetType NodeType `json:"targetType" required:"true"`
+	Stats      Stats    `json:"stats" required:"true"`
+	Change     *Change  `json:"change,omitempty"`
+}
+
+// GettableTopology is the response for the service topology API.
+type GettableTopology struct {
+	Nodes []*Node `json:"nodes" required:"true" nullable:"false"`
+	Edges []*Edge `json:"edges" required:"true" nullable:"false"`
+}
+
+// NewStats derives the RED metrics of a node or an edge over a period of the given length
+// in milliseconds. Quantiles holds p50, p95 and p99 in that order.
+func NewStats(callCount, errorCount uint64, quantiles []float64, periodMillis uint64) Stats {
+	stats := Stats{CallCount: callCount, ErrorCount: errorCount}
+	if periodMillis > 0 {
+		stats.RequestRate = float64(callCount) * 1000 / float64(periodMillis)
+	}
+	if callCount > 0 {
+		stats.ErrorRate = float64(errorCount) * 100 / float64(callCount)
+	}
+	if len(quantiles) == 3 {
+		stats.P50Nano, stats.P95Nano, stats.P99Nano = quantiles[0], quantiles[1], quantiles[2]
+	}
+	return stats
+}
+
+// NewChange compares the current stats with the previous ones. A nil side means the node
+// or edge was not seen in that period.
+func NewChange(current, previous *Stats) *Change {
+	change := &Change{Status: ChangeStatusExisting}
+	switch {
+	case previous == nil:
+		change.Status = ChangeStatusAdded
+		previous = &Stats{}
+	case current == nil:
+		change.Status = ChangeStatusRemoved
+		current = &Stats{}
+	}
+	change.Previous = *previous
+	change.RequestRateDelta = current.RequestRate - previous.RequestRate
+	change.ErrorRateDelta = current.ErrorRate - previous.ErrorRate
+	change.P50DeltaNano = current.P50Nano - previous.P50Nano
+	change.P95DeltaNano = current.P95Nano - previous.P95Nano
+	change.P99DeltaNano = current.P99Nano - previous.P99Nano
+	return change
+}
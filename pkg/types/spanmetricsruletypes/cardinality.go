@@ -0,0 +1,47 @@
+package spanmetricsruletypes
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const (
+	// MaxDimensionCardinality is the largest number of distinct values a dimension can have.
+	MaxDimensionCardinality uint64 = 1000
+	// MaxSeriesPerRule bounds the product of the cardinalities of the dimensions of a rule,
+	// an upper bound of the number of series it generates per metric.
+	MaxSeriesPerRule uint64 = 100000
+	// CardinalityLookback is how far back the cardinality of the dimensions is looked up.
+	CardinalityLookback = 24 * time.Hour
+)
+
+// CheckCardinality guards against rules generating too many series. Dimensions that were
+// never seen count as a single value: their label takes the default value.
+func CheckCardinality(dimensions []Dimension, cardinalities map[telemetrytypes.FieldCardinalityKey]uint64) error {
+	series := uint64(1)
+	tooHigh := []string{}
+	for _, dimension := range dimensions {
+		cardinality := max(cardinalities[telemetrytypes.FieldCardinalityKey{Name: dimension.Name, FieldContext: dimension.FieldContext}], 1)
+		if cardinality > MaxDimensionCardinality {
+			tooHigh = append(tooHigh, fmt.Sprintf("%s (%d)", dimension.Name, cardinality))
+		}
+		if series > math.MaxUint64/cardinality {
+			series = math.MaxUint64
+		} else {
+			series *= cardinality
+		}
+	}
+
+	if len(tooHigh) > 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleCardinality, "dimensions exceed the maximum cardinality of %d: %s", MaxDimensionCardinality, strings.Join(tooHigh, ", "))
+	}
+	if series > MaxSeriesPerRule {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleCardinality, "dimensions can generate up to %d series per metric, which exceeds the maximum of %d", series, MaxSeriesPerRule)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package spanmetricsruletypes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// ProcessorConfig is the collector config of the span metrics generator processor. Every
+// rule generates the calls and latency metrics of the spans matching its condition, labeled
+// with its dimensions and the rule name.
+type ProcessorConfig struct {
+	Rules []ProcessorRule `mapstructure:"rules" yaml:"rules" json:"rules"`
+}
+
+type ProcessorRule struct {
+	Name                    string               `mapstructure:"name" yaml:"name" json:"name"`
+	Dimensions              []ProcessorDimension `mapstructure:"dimensions" yaml:"dimensions" json:"dimensions"`
+	LatencyHistogramBuckets []string             `mapstructure:"latency_histogram_buckets" yaml:"latency_histogram_buckets" json:"latency_histogram_buckets"`
+	// Condition is an OTTL span condition, empty when the rule applies to every span.
+	Condition string `mapstructure:"condition,omitempty" yaml:"condition,omitempty" json:"condition,omitempty"`
+}
+
+type ProcessorDimension struct {
+	Name    string `mapstructure:"name" yaml:"name" json:"name"`
+	Context string `mapstructure:"context" yaml:"context" json:"context"`
+	Default string `mapstructure:"default" yaml:"default" json:"default"`
+}
+
+// NewProcessorConfig builds the processor config of the enabled rules. Without enabled
+// rules the processor is still deployed, with no rules, so that it stops generating metrics.
+func NewProcessorConfig(rules []*SpanMetricsRule) *ProcessorConfig {
+	config := &ProcessorConfig{Rules: []ProcessorRule{}}
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		dimensions := make([]ProcessorDimension, 0, len(rule.Dimensions))
+		for _, dimension := range rule.Dimensions {
+			dimensions = append(dimensions, ProcessorDimension{
+				Name:    dimension.Name,
+				Context: dimension.FieldContext.StringValue(),
+				Default: dimension.Default,
+			})
+		}
+
+		buckets := make([]string, 0, len(rule.LatencyBuckets))
+		for _, bucket := range rule.LatencyBuckets {
+			buckets = append(buckets, time.Duration(bucket*float64(time.Millisecond)).String())
+		}
+
+		config.Rules = append(config.Rules, ProcessorRule{
+			Name:                    rule.Name,
+			Dimensions:              dimensions,
+			LatencyHistogramBuckets: buckets,
+			Condition:               newCondition(rule.Filters),
+		})
+	}
+	return config
+}
+
+// newCondition turns the filters into a single OTTL span condition requiring all of them.
+func newCondition(filters []Filter) string {
+	conditions := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		path := fmt.Sprintf("attributes[%s]", strconv.Quote(filter.Key))
+		if filter.FieldContext == telemetrytypes.FieldContextResource {
+			path = "resource." + path
+		}
+
+		switch filter.Operator {
+		case FilterOperatorEquals:
+			conditions = append(conditions, fmt.Sprintf("%s == %s", path, strconv.Quote(filter.Value)))
+		case FilterOperatorNotEquals:
+			conditions = append(conditions, fmt.Sprintf("%s != %s", path, strconv.Quote(filter.Value)))
+		case FilterOperatorExists:
+			conditions = append(conditions, fmt.Sprintf("%s != nil", path))
+		case FilterOperatorNotExists:
+			conditions = append(conditions, fmt.Sprintf("%s == nil", path))
+		case FilterOperatorRegex:
+			conditions = append(conditions, fmt.Sprintf("IsMatch(%s, %s)", path, strconv.Quote(filter.Value)))
+		}
+	}
+	return strings.Join(conditions, " and ")
+}
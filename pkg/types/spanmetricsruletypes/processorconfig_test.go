@@ -0,0 +1,50 @@
+package spanmetricsruletypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProcessorConfig(t *testing.T) {
+	rules := []*SpanMetricsRule{
+		{
+			Name: "checkout",
+			Dimensions: Dimensions{
+				{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource},
+				{Name: "http.route", FieldContext: telemetrytypes.FieldContextAttribute, Default: "unknown"},
+			},
+			LatencyBuckets: LatencyBuckets{0.5, 10, 2000},
+			Filters: Filters{
+				{Key: "deployment.environment", FieldContext: telemetrytypes.FieldContextResource, Operator: FilterOperatorEquals, Value: "prod"},
+				{Key: "http.route", FieldContext: telemetrytypes.FieldContextAttribute, Operator: FilterOperatorRegex, Value: "^/api/"},
+				{Key: "db.system", FieldContext: telemetrytypes.FieldContextAttribute, Operator: FilterOperatorNotExists},
+			},
+			Enabled: true,
+		},
+		{
+			Name:           "disabled",
+			Dimensions:     Dimensions{{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource}},
+			LatencyBuckets: LatencyBuckets{1},
+			Enabled:        false,
+		},
+	}
+
+	expected := &ProcessorConfig{
+		Rules: []ProcessorRule{
+			{
+				Name: "checkout",
+				Dimensions: []ProcessorDimension{
+					{Name: "service.name", Context: "resource"},
+					{Name: "http.route", Context: "attribute", Default: "unknown"},
+				},
+				LatencyHistogramBuckets: []string{"500µs", "10ms", "2s"},
+				Condition:               `resource.attributes["deployment.environment"] == "prod" and IsMatch(attributes["http.route"], "^/api/") and attributes["db.system"] == nil`,
+			},
+		},
+	}
+
+	assert.Equal(t, expected, NewProcessorConfig(rules))
+	assert.Equal(t, &ProcessorConfig{Rules: []ProcessorRule{}}, NewProcessorConfig(nil))
+}
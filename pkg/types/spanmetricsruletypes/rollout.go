@@ -0,0 +1,92 @@
+package spanmetricsruletypes
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+// RolloutStatus is the deployment status of the span metrics rules on an agent.
+type RolloutStatus struct {
+	valuer.String
+}
+
+var (
+	RolloutStatusInProgress = RolloutStatus{valuer.NewString("in_progress")}
+	RolloutStatusDeployed   = RolloutStatus{valuer.NewString("deployed")}
+	RolloutStatusFailed     = RolloutStatus{valuer.NewString("failed")}
+)
+
+func (RolloutStatus) Enum() []any {
+	return []any{
+		RolloutStatusInProgress,
+		RolloutStatusDeployed,
+		RolloutStatusFailed,
+	}
+}
+
+// AgentRollout is the status of one deployment of the span metrics rules on one agent.
+// Every deployment of the rules of an org has a new version.
+type AgentRollout struct {
+	bun.BaseModel `bun:"table:span_metrics_rule_rollout,alias:span_metrics_rule_rollout" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+
+	OrgID      valuer.UUID   `bun:"org_id,type:text,notnull" json:"-"`
+	Version    int           `bun:"version,notnull" json:"version" required:"true"`
+	AgentID    string        `bun:"agent_id,type:text,notnull" json:"agentId" required:"true"`
+	ConfigHash string        `bun:"config_hash,type:text,notnull" json:"-"`
+	Status     RolloutStatus `bun:"status,type:text,notnull" json:"status" required:"true"`
+	Message    string        `bun:"message,type:text,notnull" json:"message" required:"true"`
+}
+
+// GettableRollout is the status of the latest deployment of the span metrics rules, per agent.
+// Version is zero when the rules were never deployed.
+type GettableRollout struct {
+	Version int             `json:"version" required:"true"`
+	Agents  []*AgentRollout `json:"agents" required:"true" nullable:"false"`
+}
+
+// NewAgentRollout records the push of a deployment to an agent. The config hash sent by the
+// OpAMP server is binary, it is stored hex encoded. A push error fails the rollout right away.
+func NewAgentRollout(orgID valuer.UUID, version int, agentID string, configHash string, err error) *AgentRollout {
+	now := time.Now()
+	rollout := &AgentRollout{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:      orgID,
+		Version:    version,
+		AgentID:    agentID,
+		ConfigHash: EncodeConfigHash(configHash),
+		Status:     RolloutStatusInProgress,
+		Message:    "Deployment has started",
+	}
+	if err != nil {
+		rollout.Status = RolloutStatusFailed
+		rollout.Message = err.Error()
+	}
+	return rollout
+}
+
+// EncodeConfigHash encodes the binary config hash of the OpAMP server for storage.
+func EncodeConfigHash(configHash string) string {
+	return hex.EncodeToString([]byte(configHash))
+}
+
+func NewGettableRollout(rollouts []*AgentRollout) *GettableRollout {
+	gettable := &GettableRollout{Agents: rollouts}
+	if gettable.Agents == nil {
+		gettable.Agents = []*AgentRollout{}
+	}
+	for _, rollout := range rollouts {
+		gettable.Version = max(gettable.Version, rollout.Version)
+	}
+	return gettable
+}
@@ -0,0 +1,285 @@
+package spanmetricsruletypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeSpanMetricsRuleNotFound      = errors.MustNewCode("span_metrics_rule_not_found")
+	ErrCodeSpanMetricsRuleAlreadyExists = errors.MustNewCode("span_metrics_rule_already_exists")
+	ErrCodeSpanMetricsRuleInvalidInput  = errors.MustNewCode("span_metrics_rule_invalid_input")
+	ErrCodeSpanMetricsRuleCardinality   = errors.MustNewCode("span_metrics_rule_high_cardinality")
+)
+
+const (
+	// MaxDimensions is the largest number of dimensions a rule can turn into metric labels.
+	MaxDimensions = 10
+	// MaxFilters is the largest number of span filters a rule can have.
+	MaxFilters = 10
+	// MaxLatencyBuckets is the largest number of latency histogram buckets a rule can have.
+	MaxLatencyBuckets = 50
+)
+
+// DefaultLatencyBuckets are the latency histogram bucket bounds, in milliseconds, used when
+// a rule does not set its own.
+var DefaultLatencyBuckets = []float64{0.1, 1, 2, 6, 10, 50, 100, 250, 500, 1000, 1400, 2000, 5000, 10000, 20000, 40000, 60000}
+
+// ruleNameRegex keeps rule names usable as a metric label value and a collector config key.
+var ruleNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]{0,63}$`)
+
+// FilterOperator is the comparison of a span filter.
+type FilterOperator struct {
+	valuer.String
+}
+
+var (
+	FilterOperatorEquals    = FilterOperator{valuer.NewString("equals")}
+	FilterOperatorNotEquals = FilterOperator{valuer.NewString("not_equals")}
+	FilterOperatorExists    = FilterOperator{valuer.NewString("exists")}
+	FilterOperatorNotExists = FilterOperator{valuer.NewString("not_exists")}
+	FilterOperatorRegex     = FilterOperator{valuer.NewString("regex")}
+)
+
+func (FilterOperator) Enum() []any {
+	return []any{
+		FilterOperatorEquals,
+		FilterOperatorNotEquals,
+		FilterOperatorExists,
+		FilterOperatorNotExists,
+		FilterOperatorRegex,
+	}
+}
+
+// Dimension is a span attribute or resource attribute turned into a metric label.
+// Default is the label value used for spans without the attribute.
+type Dimension struct {
+	Name         string                      `json:"name" required:"true"`
+	FieldContext telemetrytypes.FieldContext `json:"fieldContext" required:"true"`
+	Default      string                      `json:"default"`
+}
+
+// Filter restricts the spans a rule generates metrics from. All filters of a rule must match.
+type Filter struct {
+	Key          string                      `json:"key" required:"true"`
+	FieldContext telemetrytypes.FieldContext `json:"fieldContext" required:"true"`
+	Operator     FilterOperator              `json:"operator" required:"true"`
+	Value        string                      `json:"value"`
+}
+
+// Dimensions is a []Dimension stored as a JSON text column.
+type Dimensions []Dimension
+
+// Filters is a []Filter stored as a JSON text column.
+type Filters []Filter
+
+// LatencyBuckets are latency histogram bucket bounds in milliseconds, stored as a JSON text column.
+type LatencyBuckets []float64
+
+// SpanMetricsRule describes which RED metrics the collectors generate from spans: the
+// dimensions that become metric labels, the latency histogram buckets and the spans
+// the metrics are computed over.
+type SpanMetricsRule struct {
+	bun.BaseModel `bun:"table:span_metrics_rule,alias:span_metrics_rule" json:"-"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID          valuer.UUID    `bun:"org_id,type:text,notnull" json:"orgId" required:"true"`
+	Name           string         `bun:"name,type:text,notnull" json:"name" required:"true"`
+	Dimensions     Dimensions     `bun:"dimensions,type:text,notnull" json:"dimensions" required:"true" nullable:"false"`
+	LatencyBuckets LatencyBuckets `bun:"latency_buckets,type:text,notnull" json:"latencyBuckets" required:"true" nullable:"false"`
+	Filters        Filters        `bun:"filters,type:text,notnull" json:"filters" required:"true" nullable:"false"`
+	Enabled        bool           `bun:"enabled,notnull,default:true" json:"enabled" required:"true"`
+}
+
+type GettableSpanMetricsRule = SpanMetricsRule
+
+// PostableSpanMetricsRule is the request body to create or update a rule. Empty latency
+// buckets fall back to DefaultLatencyBuckets.
+type PostableSpanMetricsRule struct {
+	Name           string      `json:"name" required:"true"`
+	Dimensions     []Dimension `json:"dimensions" required:"true"`
+	LatencyBuckets []float64   `json:"latencyBuckets"`
+	Filters        []Filter    `json:"filters"`
+	Enabled        bool        `json:"enabled"`
+}
+
+type GettableSpanMetricsRules struct {
+	Items []*GettableSpanMetricsRule `json:"items" required:"true" nullable:"false"`
+}
+
+func NewSpanMetricsRule(orgID valuer.UUID, createdBy string, p *PostableSpanMetricsRule) *SpanMetricsRule {
+	now := time.Now()
+	rule := &SpanMetricsRule{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID: orgID,
+	}
+	rule.set(p)
+	return rule
+}
+
+// Update replaces the rule definition with p.
+func (r *SpanMetricsRule) Update(p *PostableSpanMetricsRule, updatedBy string) {
+	r.set(p)
+	r.UpdatedAt = time.Now()
+	r.UpdatedBy = updatedBy
+}
+
+func (r *SpanMetricsRule) set(p *PostableSpanMetricsRule) {
+	r.Name = p.Name
+	r.Dimensions = Dimensions(slices.Clone(p.Dimensions))
+	r.LatencyBuckets = LatencyBuckets(slices.Clone(p.LatencyBuckets))
+	if len(r.LatencyBuckets) == 0 {
+		r.LatencyBuckets = LatencyBuckets(slices.Clone(DefaultLatencyBuckets))
+	}
+	r.Filters = Filters(slices.Clone(p.Filters))
+	if r.Filters == nil {
+		r.Filters = Filters{}
+	}
+	r.Enabled = p.Enabled
+}
+
+// CardinalityKeys returns the fields whose cardinality bounds the series of the rule.
+func (r *SpanMetricsRule) CardinalityKeys() []telemetrytypes.FieldCardinalityKey {
+	keys := make([]telemetrytypes.FieldCardinalityKey, 0, len(r.Dimensions))
+	for _, dimension := range r.Dimensions {
+		keys = append(keys, telemetrytypes.FieldCardinalityKey{Name: dimension.Name, FieldContext: dimension.FieldContext})
+	}
+	return keys
+}
+
+func (p *PostableSpanMetricsRule) Validate() error {
+	if !ruleNameRegex.MatchString(p.Name) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "name %q must start with a letter and contain at most 64 letters, digits, '_' or '-'", p.Name)
+	}
+
+	if len(p.Dimensions) == 0 {
+		return errors.New(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "at least one dimension is required")
+	}
+	if len(p.Dimensions) > MaxDimensions {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "at most %d dimensions are allowed, got %d", MaxDimensions, len(p.Dimensions))
+	}
+	seen := make(map[telemetrytypes.FieldCardinalityKey]struct{}, len(p.Dimensions))
+	for _, dimension := range p.Dimensions {
+		if dimension.Name == "" {
+			return errors.New(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "dimension name cannot be empty")
+		}
+		if err := validateFieldContext(dimension.Name, dimension.FieldContext); err != nil {
+			return err
+		}
+		key := telemetrytypes.FieldCardinalityKey{Name: dimension.Name, FieldContext: dimension.FieldContext}
+		if _, ok := seen[key]; ok {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "dimension %q is repeated", dimension.Name)
+		}
+		seen[key] = struct{}{}
+	}
+
+	if len(p.LatencyBuckets) > MaxLatencyBuckets {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "at most %d latency buckets are allowed, got %d", MaxLatencyBuckets, len(p.LatencyBuckets))
+	}
+	for i, bucket := range p.LatencyBuckets {
+		if bucket <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "latency buckets must be positive, got %v", bucket)
+		}
+		if i > 0 && bucket <= p.LatencyBuckets[i-1] {
+			return errors.New(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "latency buckets must be in increasing order")
+		}
+	}
+
+	if len(p.Filters) > MaxFilters {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "at most %d filters are allowed, got %d", MaxFilters, len(p.Filters))
+	}
+	for _, filter := range p.Filters {
+		if filter.Key == "" {
+			return errors.New(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "filter key cannot be empty")
+		}
+		if err := validateFieldContext(filter.Key, filter.FieldContext); err != nil {
+			return err
+		}
+		switch filter.Operator {
+		case FilterOperatorExists, FilterOperatorNotExists:
+		case FilterOperatorEquals, FilterOperatorNotEquals:
+		case FilterOperatorRegex:
+			if _, err := regexp.Compile(filter.Value); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "filter on %q has an invalid regex", filter.Key)
+			}
+		default:
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "filter on %q has an unsupported operator %q", filter.Key, filter.Operator.StringValue())
+		}
+	}
+
+	return nil
+}
+
+func validateFieldContext(name string, fieldContext telemetrytypes.FieldContext) error {
+	if fieldContext != telemetrytypes.FieldContextResource && fieldContext != telemetrytypes.FieldContextAttribute {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSpanMetricsRuleInvalidInput, "field %q must have the %q or %q context", name, telemetrytypes.FieldContextResource.StringValue(), telemetrytypes.FieldContextAttribute.StringValue())
+	}
+	return nil
+}
+
+func (d Dimensions) Value() (driver.Value, error) {
+	return marshalJSONColumn(d)
+}
+
+func (d *Dimensions) Scan(src any) error {
+	return scanJSONColumn(src, d)
+}
+
+func (f Filters) Value() (driver.Value, error) {
+	return marshalJSONColumn(f)
+}
+
+func (f *Filters) Scan(src any) error {
+	return scanJSONColumn(src, f)
+}
+
+func (b LatencyBuckets) Value() (driver.Value, error) {
+	return marshalJSONColumn(b)
+}
+
+func (b *LatencyBuckets) Scan(src any) error {
+	return scanJSONColumn(src, b)
+}
+
+func marshalJSONColumn(v any) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func scanJSONColumn(src any, dest any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	case nil:
+		return nil
+	default:
+		return errors.NewInternalf(errors.CodeInternal, "spanmetricsrule: cannot scan %T into %T", src, dest)
+	}
+	return json.Unmarshal(raw, dest)
+}
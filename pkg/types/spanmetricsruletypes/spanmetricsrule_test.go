@@ -0,0 +1,128 @@
+package spanmetricsruletypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableSpanMetricsRuleValidate(t *testing.T) {
+	valid := func() *PostableSpanMetricsRule {
+		return &PostableSpanMetricsRule{
+			Name: "checkout-red",
+			Dimensions: []Dimension{
+				{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource},
+				{Name: "http.route", FieldContext: telemetrytypes.FieldContextAttribute, Default: "unknown"},
+			},
+			LatencyBuckets: []float64{1, 10, 100},
+			Filters: []Filter{
+				{Key: "deployment.environment", FieldContext: telemetrytypes.FieldContextResource, Operator: FilterOperatorEquals, Value: "prod"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		mutate  func(p *PostableSpanMetricsRule)
+		wantErr string
+	}{
+		{name: "Valid", mutate: func(*PostableSpanMetricsRule) {}},
+		{name: "InvalidName", mutate: func(p *PostableSpanMetricsRule) { p.Name = "1 rule" }, wantErr: "must start with a letter"},
+		{name: "NoDimensions", mutate: func(p *PostableSpanMetricsRule) { p.Dimensions = nil }, wantErr: "at least one dimension"},
+		{
+			name: "RepeatedDimension",
+			mutate: func(p *PostableSpanMetricsRule) {
+				p.Dimensions = append(p.Dimensions, Dimension{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource})
+			},
+			wantErr: "is repeated",
+		},
+		{
+			name: "SpanContextDimension",
+			mutate: func(p *PostableSpanMetricsRule) {
+				p.Dimensions[0].FieldContext = telemetrytypes.FieldContextSpan
+			},
+			wantErr: "must have the",
+		},
+		{name: "UnorderedBuckets", mutate: func(p *PostableSpanMetricsRule) { p.LatencyBuckets = []float64{10, 1} }, wantErr: "increasing order"},
+		{name: "NonPositiveBucket", mutate: func(p *PostableSpanMetricsRule) { p.LatencyBuckets = []float64{0, 1} }, wantErr: "must be positive"},
+		{
+			name: "InvalidRegexFilter",
+			mutate: func(p *PostableSpanMetricsRule) {
+				p.Filters[0].Operator = FilterOperatorRegex
+				p.Filters[0].Value = "(prod"
+			},
+			wantErr: "missing closing )",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := valid()
+			tc.mutate(p)
+			err := p.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestNewSpanMetricsRuleDefaults(t *testing.T) {
+	rule := NewSpanMetricsRule(valuer.GenerateUUID(), "admin@signoz.io", &PostableSpanMetricsRule{
+		Name:       "all-spans",
+		Dimensions: []Dimension{{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource}},
+	})
+
+	assert.Equal(t, LatencyBuckets(DefaultLatencyBuckets), rule.LatencyBuckets)
+	assert.NotNil(t, rule.Filters)
+	assert.Equal(t, []telemetrytypes.FieldCardinalityKey{{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource}}, rule.CardinalityKeys())
+}
+
+func TestCheckCardinality(t *testing.T) {
+	dimensions := []Dimension{
+		{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource},
+		{Name: "http.route", FieldContext: telemetrytypes.FieldContextAttribute},
+		{Name: "never.seen", FieldContext: telemetrytypes.FieldContextAttribute},
+	}
+	serviceKey := telemetrytypes.FieldCardinalityKey{Name: "service.name", FieldContext: telemetrytypes.FieldContextResource}
+	routeKey := telemetrytypes.FieldCardinalityKey{Name: "http.route", FieldContext: telemetrytypes.FieldContextAttribute}
+
+	testCases := []struct {
+		name          string
+		cardinalities map[telemetrytypes.FieldCardinalityKey]uint64
+		wantErr       string
+	}{
+		{
+			name:          "WithinLimits",
+			cardinalities: map[telemetrytypes.FieldCardinalityKey]uint64{serviceKey: 50, routeKey: 200},
+		},
+		{
+			name:          "DimensionTooHigh",
+			cardinalities: map[telemetrytypes.FieldCardinalityKey]uint64{serviceKey: 50, routeKey: 5000},
+			wantErr:       "http.route (5000)",
+		},
+		{
+			name:          "SeriesTooHigh",
+			cardinalities: map[telemetrytypes.FieldCardinalityKey]uint64{serviceKey: 500, routeKey: 500},
+			wantErr:       "250000 series",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckCardinality(dimensions, tc.cardinalities)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
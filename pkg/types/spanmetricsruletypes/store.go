@@ -0,0 +1,24 @@
+package spanmetricsruletypes
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Store interface {
+	List(ctx context.Context, orgID valuer.UUID) ([]*SpanMetricsRule, error)
+	Get(ctx context.Context, orgID, id valuer.UUID) (*SpanMetricsRule, error)
+	Create(ctx context.Context, rule *SpanMetricsRule) error
+	Update(ctx context.Context, rule *SpanMetricsRule) error
+	Delete(ctx context.Context, orgID, id valuer.UUID) error
+
+	// CreateRollouts records a deployment of the rules of an org.
+	CreateRollouts(ctx context.Context, rollouts []*AgentRollout) error
+	// ListLatestRollouts returns the per-agent status of the latest deployment of the rules of an org.
+	ListLatestRollouts(ctx context.Context, orgID valuer.UUID) ([]*AgentRollout, error)
+	// UpdateRolloutStatus sets the status of the deployment of the given config hash to an agent.
+	UpdateRolloutStatus(ctx context.Context, orgID valuer.UUID, agentID, configHash string, status RolloutStatus, message string) error
+	// ListRolloutOrgIDs returns the orgs whose rules were ever deployed.
+	ListRolloutOrgIDs(ctx context.Context) ([]valuer.UUID, error)
+}
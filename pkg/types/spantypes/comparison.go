@@ -0,0 +1,312 @@
+package spantypes
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// ComparisonNodeStatus describes how a node of the merged comparison tree differs between the two traces.
+type ComparisonNodeStatus struct {
+	valuer.String
+}
+
+var (
+	// ComparisonNodeStatusMatched is a node present the same number of times in both traces.
+	ComparisonNodeStatusMatched = ComparisonNodeStatus{valuer.NewString("matched")}
+	// ComparisonNodeStatusAdded is a node present only in the compared trace.
+	ComparisonNodeStatusAdded = ComparisonNodeStatus{valuer.NewString("added")}
+	// ComparisonNodeStatusMissing is a node present only in the base trace.
+	ComparisonNodeStatusMissing = ComparisonNodeStatus{valuer.NewString("missing")}
+	// ComparisonNodeStatusRepeated is a node present in both traces but a different number of times.
+	ComparisonNodeStatusRepeated = ComparisonNodeStatus{valuer.NewString("repeated")}
+)
+
+func (ComparisonNodeStatus) Enum() []any {
+	return []any{
+		ComparisonNodeStatusMatched,
+		ComparisonNodeStatusAdded,
+		ComparisonNodeStatusMissing,
+		ComparisonNodeStatusRepeated,
+	}
+}
+
+// PostableTraceComparison is the request body for the trace comparison API.
+type PostableTraceComparison struct {
+	BaseTraceID    string `json:"baseTraceId" required:"true"`
+	CompareTraceID string `json:"compareTraceId" required:"true"`
+}
+
+func (p *PostableTraceComparison) Validate() error {
+	if p.BaseTraceID == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "baseTraceId is required")
+	}
+	if p.CompareTraceID == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "compareTraceId is required")
+	}
+	if p.BaseTraceID == p.CompareTraceID {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "baseTraceId and compareTraceId must be different")
+	}
+	return nil
+}
+
+// TraceComparisonNode is a node of the merged tree. Spans of both traces are aligned
+// by their path of (service name, operation name) from the root, so sibling spans
+// with the same service and operation collapse into a single node.
+// Durations are in nanoseconds and deltas are compare minus base.
+type TraceComparisonNode struct {
+	ServiceName          string                 `json:"serviceName" required:"true"`
+	Name                 string                 `json:"name" required:"true"`
+	Status               ComparisonNodeStatus   `json:"status" required:"true"`
+	Level                uint64                 `json:"level" required:"true"`
+	BaseCount            uint64                 `json:"baseCount" required:"true"`
+	CompareCount         uint64                 `json:"compareCount" required:"true"`
+	BaseErrorCount       uint64                 `json:"baseErrorCount" required:"true"`
+	CompareErrorCount    uint64                 `json:"compareErrorCount" required:"true"`
+	BaseTotalTimeNano    uint64                 `json:"baseTotalTimeNano" required:"true"`
+	CompareTotalTimeNano uint64                 `json:"compareTotalTimeNano" required:"true"`
+	TotalTimeDeltaNano   int64                  `json:"totalTimeDeltaNano" required:"true"`
+	BaseSelfTimeNano     uint64                 `json:"baseSelfTimeNano" required:"true"`
+	CompareSelfTimeNano  uint64                 `json:"compareSelfTimeNano" required:"true"`
+	SelfTimeDeltaNano    int64                  `json:"selfTimeDeltaNano" required:"true"`
+	Children             []*TraceComparisonNode `json:"children" required:"true" nullable:"false"`
+}
+
+// GettableTraceComparison is the response for the trace comparison API.
+type GettableTraceComparison struct {
+	BaseTraceID         string                 `json:"baseTraceId" required:"true"`
+	CompareTraceID      string                 `json:"compareTraceId" required:"true"`
+	BaseDurationNano    uint64                 `json:"baseDurationNano" required:"true"`
+	CompareDurationNano uint64                 `json:"compareDurationNano" required:"true"`
+	DurationDeltaNano   int64                  `json:"durationDeltaNano" required:"true"`
+	BaseSpansCount      uint64                 `json:"baseSpansCount" required:"true"`
+	CompareSpansCount   uint64                 `json:"compareSpansCount" required:"true"`
+	AddedCount          uint64                 `json:"addedCount" required:"true"`
+	MissingCount        uint64                 `json:"missingCount" required:"true"`
+	RepeatedCount       uint64                 `json:"repeatedCount" required:"true"`
+	Roots               []*TraceComparisonNode `json:"roots" required:"true" nullable:"false"`
+}
+
+// comparisonSpan is a span of one side of the comparison with its children and self time resolved.
+type comparisonSpan struct {
+	span     *MinimalSpan
+	selfTime uint64
+	children []*comparisonSpan
+}
+
+// comparisonGroup collects the spans of both traces sharing the same path key.
+type comparisonGroup struct {
+	serviceName string
+	name        string
+	offset      int64
+	base        []*comparisonSpan
+	compare     []*comparisonSpan
+}
+
+type comparisonKey struct {
+	serviceName string
+	name        string
+}
+
+// NewGettableTraceComparison aligns the spans of two traces and builds the merged comparison tree.
+func NewGettableTraceComparison(baseTraceID string, baseSpans []MinimalSpan, compareTraceID string, compareSpans []MinimalSpan) *GettableTraceComparison {
+	result := &GettableTraceComparison{
+		BaseTraceID:         baseTraceID,
+		CompareTraceID:      compareTraceID,
+		BaseDurationNano:    comparisonTraceDuration(baseSpans),
+		CompareDurationNano: comparisonTraceDuration(compareSpans),
+		BaseSpansCount:      uint64(len(baseSpans)),
+		CompareSpansCount:   uint64(len(compareSpans)),
+	}
+	result.DurationDeltaNano = int64(result.CompareDurationNano) - int64(result.BaseDurationNano)
+	result.Roots = mergeComparisonSpans(newComparisonRoots(baseSpans), newComparisonRoots(compareSpans), 0, result)
+	return result
+}
+
+// newComparisonRoots links spans to their parents and returns the roots.
+// Spans whose parent is not part of the trace are treated as roots.
+func newComparisonRoots(spans []MinimalSpan) []*comparisonSpan {
+	nodes := make(map[string]*comparisonSpan, len(spans))
+	ordered := make([]*comparisonSpan, 0, len(spans))
+	for i := range spans {
+		if _, ok := nodes[spans[i].SpanID]; ok {
+			continue
+		}
+		node := &comparisonSpan{span: &spans[i]}
+		nodes[spans[i].SpanID] = node
+		ordered = append(ordered, node)
+	}
+
+	var roots []*comparisonSpan
+	for _, node := range ordered {
+		if parent, ok := nodes[node.span.ParentSpanID]; ok && node.span.ParentSpanID != "" && parent != node {
+			parent.children = append(parent.children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+
+	for _, node := range ordered {
+		node.selfTime = comparisonSelfTime(node)
+	}
+	return roots
+}
+
+// comparisonSelfTime is the part of the span duration not covered by any of its children.
+func comparisonSelfTime(node *comparisonSpan) uint64 {
+	start := node.span.StartTime.UnixNano()
+	end := start + int64(node.span.DurationNano)
+
+	intervals := make([][2]int64, 0, len(node.children))
+	for _, child := range node.children {
+		childStart := max(child.span.StartTime.UnixNano(), start)
+		childEnd := min(child.span.StartTime.UnixNano()+int64(child.span.DurationNano), end)
+		if childEnd > childStart {
+			intervals = append(intervals, [2]int64{childStart, childEnd})
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i][0] < intervals[j][0] })
+
+	var covered, coveredEnd int64
+	for _, interval := range intervals {
+		if interval[1] <= coveredEnd {
+			continue
+		}
+		covered += interval[1] - max(interval[0], coveredEnd)
+		coveredEnd = interval[1]
+	}
+	return node.span.DurationNano - uint64(covered)
+}
+
+// mergeComparisonSpans groups both sides by (service, operation) and recursively merges the children of each group.
+func mergeComparisonSpans(base, compare []*comparisonSpan, level uint64, result *GettableTraceComparison) []*TraceComparisonNode {
+	groups := make(map[comparisonKey]*comparisonGroup)
+	var keys []comparisonKey
+	collect := func(spans []*comparisonSpan, isBase bool) {
+		for _, span := range spans {
+			key := comparisonKey{serviceName: span.span.ServiceName, name: span.span.Name}
+			group, ok := groups[key]
+			if !ok {
+				group = &comparisonGroup{serviceName: key.serviceName, name: key.name}
+				groups[key] = group
+				keys = append(keys, key)
+			}
+			if isBase {
+				group.base = append(group.base, span)
+			} else {
+				group.compare = append(group.compare, span)
+			}
+		}
+	}
+	collect(base, true)
+	collect(compare, false)
+
+	baseEarliest, compareEarliest := comparisonEarliestStart(base), comparisonEarliestStart(compare)
+	for _, group := range groups {
+		if len(group.base) > 0 {
+			group.offset = group.base[0].span.StartTime.UnixNano() - baseEarliest
+		} else {
+			group.offset = group.compare[0].span.StartTime.UnixNano() - compareEarliest
+		}
+	}
+
+	// Order nodes by the relative position of their first span in its own trace so
+	// that the merged tree reads like the waterfall of either trace.
+	slices.SortStableFunc(keys, func(a, b comparisonKey) int {
+		ga, gb := groups[a], groups[b]
+		if ga.offset != gb.offset {
+			if ga.offset < gb.offset {
+				return -1
+			}
+			return 1
+		}
+		if ga.serviceName != gb.serviceName {
+			if ga.serviceName < gb.serviceName {
+				return -1
+			}
+			return 1
+		}
+		if ga.name < gb.name {
+			return -1
+		}
+		if ga.name > gb.name {
+			return 1
+		}
+		return 0
+	})
+
+	nodes := make([]*TraceComparisonNode, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		node := &TraceComparisonNode{
+			ServiceName:  group.serviceName,
+			Name:         group.name,
+			Level:        level,
+			BaseCount:    uint64(len(group.base)),
+			CompareCount: uint64(len(group.compare)),
+		}
+
+		var baseChildren, compareChildren []*comparisonSpan
+		for _, span := range group.base {
+			node.BaseTotalTimeNano += span.span.DurationNano
+			node.BaseSelfTimeNano += span.selfTime
+			if span.span.HasError {
+				node.BaseErrorCount++
+			}
+			baseChildren = append(baseChildren, span.children...)
+		}
+		for _, span := range group.compare {
+			node.CompareTotalTimeNano += span.span.DurationNano
+			node.CompareSelfTimeNano += span.selfTime
+			if span.span.HasError {
+				node.CompareErrorCount++
+			}
+			compareChildren = append(compareChildren, span.children...)
+		}
+		node.TotalTimeDeltaNano = int64(node.CompareTotalTimeNano) - int64(node.BaseTotalTimeNano)
+		node.SelfTimeDeltaNano = int64(node.CompareSelfTimeNano) - int64(node.BaseSelfTimeNano)
+
+		switch {
+		case node.BaseCount == 0:
+			node.Status = ComparisonNodeStatusAdded
+			result.AddedCount++
+		case node.CompareCount == 0:
+			node.Status = ComparisonNodeStatusMissing
+			result.MissingCount++
+		case node.BaseCount != node.CompareCount:
+			node.Status = ComparisonNodeStatusRepeated
+			result.RepeatedCount++
+		default:
+			node.Status = ComparisonNodeStatusMatched
+		}
+
+		node.Children = mergeComparisonSpans(baseChildren, compareChildren, level+1, result)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func comparisonEarliestStart(spans []*comparisonSpan) int64 {
+	var earliest int64
+	for i, span := range spans {
+		if start := span.span.StartTime.UnixNano(); i == 0 || start < earliest {
+			earliest = start
+		}
+	}
+	return earliest
+}
+
+func comparisonTraceDuration(spans []MinimalSpan) uint64 {
+	var start, end int64
+	for i := range spans {
+		spanStart := spans[i].StartTime.UnixNano()
+		spanEnd := spanStart + int64(spans[i].DurationNano)
+		if i == 0 || spanStart < start {
+			start = spanStart
+		}
+		end = max(end, spanEnd)
+	}
+	return uint64(end - start)
+}
@@ -0,0 +1,337 @@
+package spantypes
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+const (
+	maxCriticalPathTraces = 1000
+	// criticalPathBudgetCheckInterval is the number of visited spans between two checks of the time budget.
+	criticalPathBudgetCheckInterval = 1024
+)
+
+// CriticalPathSegment is a span, or the part of a span, on the critical path.
+// Timestamps and durations are in nanoseconds.
+type CriticalPathSegment struct {
+	SpanID            string `json:"spanId" required:"true"`
+	ServiceName       string `json:"serviceName" required:"true"`
+	Name              string `json:"name" required:"true"`
+	StartTimeUnixNano uint64 `json:"startTimeUnixNano" required:"true"`
+	DurationNano      uint64 `json:"durationNano" required:"true"`
+}
+
+// CriticalPathContribution is the time a service, or an operation of a service, spent on the critical path.
+// Name is empty for service level contributions. Percentage is relative to the total critical path duration.
+type CriticalPathContribution struct {
+	ServiceName  string  `json:"serviceName" required:"true"`
+	Name         string  `json:"name,omitempty"`
+	DurationNano uint64  `json:"durationNano" required:"true"`
+	Percentage   float64 `json:"percentage" required:"true"`
+	TraceCount   uint64  `json:"traceCount" required:"true"`
+}
+
+// GettableTraceCriticalPath is the response for the critical path API of a single trace.
+// Truncated is set when the time budget ran out before the whole trace was walked.
+type GettableTraceCriticalPath struct {
+	TraceID                  string                      `json:"traceId" required:"true"`
+	DurationNano             uint64                      `json:"durationNano" required:"true"`
+	CriticalPathDurationNano uint64                      `json:"criticalPathDurationNano" required:"true"`
+	Segments                 []*CriticalPathSegment      `json:"segments" required:"true" nullable:"false"`
+	Services                 []*CriticalPathContribution `json:"services" required:"true" nullable:"false"`
+	Operations               []*CriticalPathContribution `json:"operations" required:"true" nullable:"false"`
+	Truncated                bool                        `json:"truncated" required:"true"`
+}
+
+// PostableCriticalPathAggregation is the request body for the aggregated critical path API.
+// Start and End are epoch milliseconds.
+type PostableCriticalPathAggregation struct {
+	Start  uint64          `json:"start" required:"true"`
+	End    uint64          `json:"end" required:"true"`
+	Filter *qbtypes.Filter `json:"filter"`
+	Limit  int             `json:"limit"`
+}
+
+func (p *PostableCriticalPathAggregation) Validate() error {
+	if p.Start >= p.End {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start time must be before end time")
+	}
+	if p.Limit < 0 || p.Limit > maxCriticalPathTraces {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 0 and %d", maxCriticalPathTraces)
+	}
+	return nil
+}
+
+// GettableCriticalPathAggregation is the response for the aggregated critical path API.
+// SkippedTraceCount counts matching traces left out for being too large or no longer stored.
+// Truncated is set when the time budget ran out before all matching traces were analysed.
+type GettableCriticalPathAggregation struct {
+	TraceCount               uint64                      `json:"traceCount" required:"true"`
+	SkippedTraceCount        uint64                      `json:"skippedTraceCount" required:"true"`
+	CriticalPathDurationNano uint64                      `json:"criticalPathDurationNano" required:"true"`
+	Services                 []*CriticalPathContribution `json:"services" required:"true" nullable:"false"`
+	Operations               []*CriticalPathContribution `json:"operations" required:"true" nullable:"false"`
+	Truncated                bool                        `json:"truncated" required:"true"`
+}
+
+// CriticalPathBudget bounds the time spent walking traces.
+type CriticalPathBudget struct {
+	deadline time.Time
+	visited  int
+	exceeded bool
+}
+
+func NewCriticalPathBudget(timeout time.Duration) *CriticalPathBudget {
+	return &CriticalPathBudget{deadline: time.Now().Add(timeout)}
+}
+
+// Deadline returns the time the budget runs out at.
+func (b *CriticalPathBudget) Deadline() time.Time {
+	return b.deadline
+}
+
+// Exceeded reports whether the budget has run out.
+func (b *CriticalPathBudget) Exceeded() bool {
+	if !b.exceeded && time.Now().After(b.deadline) {
+		b.exceeded = true
+	}
+	return b.exceeded
+}
+
+func (b *CriticalPathBudget) visit() bool {
+	b.visited++
+	if b.visited%criticalPathBudgetCheckInterval == 0 {
+		return !b.Exceeded()
+	}
+	return !b.exceeded
+}
+
+type criticalPathSpan struct {
+	span     *MinimalSpan
+	start    uint64
+	end      uint64
+	children []*criticalPathSpan
+}
+
+// NewGettableTraceCriticalPath computes the critical path of a trace: walking back from
+// the end of the root span, each span is blocked on its last finishing child, and time
+// not covered by a blocking child is attributed to the span itself.
+func NewGettableTraceCriticalPath(traceID string, spans []MinimalSpan, budget *CriticalPathBudget) *GettableTraceCriticalPath {
+	result := &GettableTraceCriticalPath{
+		TraceID:    traceID,
+		Segments:   make([]*CriticalPathSegment, 0),
+		Services:   make([]*CriticalPathContribution, 0),
+		Operations: make([]*CriticalPathContribution, 0),
+	}
+
+	root, traceStart, traceEnd := newCriticalPathTree(spans)
+	if root == nil {
+		return result
+	}
+	result.DurationNano = traceEnd - traceStart
+
+	var reversed []*CriticalPathSegment
+	walkCriticalPath(root, root.start, root.end, budget, &reversed)
+	result.Truncated = budget.exceeded
+
+	for i := len(reversed) - 1; i >= 0; i-- {
+		segment := reversed[i]
+		if n := len(result.Segments); n > 0 {
+			last := result.Segments[n-1]
+			if last.SpanID == segment.SpanID && last.StartTimeUnixNano+last.DurationNano == segment.StartTimeUnixNano {
+				last.DurationNano += segment.DurationNano
+				continue
+			}
+		}
+		result.Segments = append(result.Segments, segment)
+	}
+
+	services := newCriticalPathContributions()
+	operations := newCriticalPathContributions()
+	for _, segment := range result.Segments {
+		result.CriticalPathDurationNano += segment.DurationNano
+		services.add(segment.ServiceName, "", segment.DurationNano, 0)
+		operations.add(segment.ServiceName, segment.Name, segment.DurationNano, 0)
+	}
+	services.endTrace()
+	operations.endTrace()
+	result.Services = services.list(result.CriticalPathDurationNano)
+	result.Operations = operations.list(result.CriticalPathDurationNano)
+	return result
+}
+
+// NewGettableCriticalPathAggregation sums the critical path contributions of several traces.
+func NewGettableCriticalPathAggregation(paths []*GettableTraceCriticalPath, skipped uint64, truncated bool) *GettableCriticalPathAggregation {
+	result := &GettableCriticalPathAggregation{SkippedTraceCount: skipped, Truncated: truncated}
+
+	services := newCriticalPathContributions()
+	operations := newCriticalPathContributions()
+	for _, path := range paths {
+		result.TraceCount++
+		result.CriticalPathDurationNano += path.CriticalPathDurationNano
+		result.Truncated = result.Truncated || path.Truncated
+		for _, contribution := range path.Services {
+			services.add(contribution.ServiceName, "", contribution.DurationNano, contribution.TraceCount)
+		}
+		for _, contribution := range path.Operations {
+			operations.add(contribution.ServiceName, contribution.Name, contribution.DurationNano, contribution.TraceCount)
+		}
+	}
+	result.Services = services.list(result.CriticalPathDurationNano)
+	result.Operations = operations.list(result.CriticalPathDurationNano)
+	return result
+}
+
+// newCriticalPathTree links spans to their parents and returns the root which ends last.
+func newCriticalPathTree(spans []MinimalSpan) (*criticalPathSpan, uint64, uint64) {
+	nodes := make(map[string]*criticalPathSpan, len(spans))
+	ordered := make([]*criticalPathSpan, 0, len(spans))
+	var traceStart, traceEnd uint64
+	for i := range spans {
+		if _, ok := nodes[spans[i].SpanID]; ok {
+			continue
+		}
+		start := uint64(spans[i].StartTime.UnixNano())
+		node := &criticalPathSpan{span: &spans[i], start: start, end: start + spans[i].DurationNano}
+		nodes[spans[i].SpanID] = node
+		ordered = append(ordered, node)
+		if traceStart == 0 || node.start < traceStart {
+			traceStart = node.start
+		}
+		traceEnd = max(traceEnd, node.end)
+	}
+
+	var root *criticalPathSpan
+	for _, node := range ordered {
+		if parent, ok := nodes[node.span.ParentSpanID]; ok && parent != node {
+			parent.children = append(parent.children, node)
+			continue
+		}
+		if root == nil || node.end > root.end || (node.end == root.end && node.start < root.start) {
+			root = node
+		}
+	}
+
+	for _, node := range ordered {
+		sort.SliceStable(node.children, func(i, j int) bool {
+			return node.children[i].end > node.children[j].end
+		})
+	}
+	return root, traceStart, traceEnd
+}
+
+// walkCriticalPath appends the segments of node within [lo, hi) to segments in reverse chronological order.
+func walkCriticalPath(node *criticalPathSpan, lo, hi uint64, budget *CriticalPathBudget, segments *[]*CriticalPathSegment) {
+	if !budget.visit() {
+		return
+	}
+
+	start, cursor := max(node.start, lo), min(node.end, hi)
+	for _, child := range node.children {
+		if cursor <= start {
+			break
+		}
+		if child.start >= cursor || child.end <= start {
+			continue
+		}
+		childEnd := min(child.end, cursor)
+		if childEnd < cursor {
+			*segments = append(*segments, newCriticalPathSegment(node, childEnd, cursor))
+		}
+		walkCriticalPath(child, start, childEnd, budget, segments)
+		if budget.exceeded {
+			return
+		}
+		cursor = max(child.start, start)
+	}
+	if cursor > start {
+		*segments = append(*segments, newCriticalPathSegment(node, start, cursor))
+	}
+}
+
+func newCriticalPathSegment(node *criticalPathSpan, start, end uint64) *CriticalPathSegment {
+	return &CriticalPathSegment{
+		SpanID:            node.span.SpanID,
+		ServiceName:       node.span.ServiceName,
+		Name:              node.span.Name,
+		StartTimeUnixNano: start,
+		DurationNano:      end - start,
+	}
+}
+
+type criticalPathContributionKey struct {
+	serviceName string
+	name        string
+}
+
+// criticalPathContributions accumulates durations by key; seen tracks keys of the trace in progress.
+type criticalPathContributions struct {
+	byKey map[criticalPathContributionKey]*CriticalPathContribution
+	seen  map[criticalPathContributionKey]struct{}
+}
+
+func newCriticalPathContributions() *criticalPathContributions {
+	return &criticalPathContributions{
+		byKey: make(map[criticalPathContributionKey]*CriticalPathContribution),
+		seen:  make(map[criticalPathContributionKey]struct{}),
+	}
+}
+
+// add accumulates duration for the key. A zero traceCount marks the key as seen in the
+// trace in progress, to be counted once by endTrace.
+func (c *criticalPathContributions) add(serviceName, name string, duration, traceCount uint64) {
+	key := criticalPathContributionKey{serviceName: serviceName, name: name}
+	contribution, ok := c.byKey[key]
+	if !ok {
+		contribution = &CriticalPathContribution{ServiceName: serviceName, Name: name}
+		c.byKey[key] = contribution
+	}
+	contribution.DurationNano += duration
+	if traceCount == 0 {
+		c.seen[key] = struct{}{}
+		return
+	}
+	contribution.TraceCount += traceCount
+}
+
+func (c *criticalPathContributions) endTrace() {
+	for key := range c.seen {
+		c.byKey[key].TraceCount++
+	}
+	clear(c.seen)
+}
+
+// list returns the contributions sorted by duration, longest first.
+func (c *criticalPathContributions) list(total uint64) []*CriticalPathContribution {
+	result := make([]*CriticalPathContribution, 0, len(c.byKey))
+	for _, contribution := range c.byKey {
+		if total > 0 {
+			contribution.Percentage = float64(contribution.DurationNano) * 100 / float64(total)
+		}
+		result = append(result, contribution)
+	}
+	slices.SortFunc(result, func(a, b *CriticalPathContribution) int {
+		switch {
+		case a.DurationNano != b.DurationNano:
+			if a.DurationNano > b.DurationNano {
+				return -1
+			}
+			return 1
+		case a.ServiceName != b.ServiceName:
+			if a.ServiceName < b.ServiceName {
+				return -1
+			}
+			return 1
+		case a.Name < b.Name:
+			return -1
+		case a.Name > b.Name:
+			return 1
+		}
+		return 0
+	})
+	return result
+}
@@ -132,6 +132,8 @@ type MinimalSpan struct {
 	DurationNano uint64    `ch:"duration_nano"`
 	HasError     bool      `ch:"has_error"`
 	ServiceName  string    `ch:"resource_string_service$$name"`
+	// Name is only read by GetNamedSpans.
+	Name string `ch:"name"`
 }
 
 func (item *MinimalSpan) ToWaterfallSpan(traceID string) *WaterfallSpan {
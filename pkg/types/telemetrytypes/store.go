@@ -47,6 +47,18 @@ type MetadataStore interface {
 	GetFirstSeenFromMetricMetadata(ctx context.Context, lookupKeys []MetricMetadataLookupKey) (map[MetricMetadataLookupKey]int64, error)
 
 	FetchLastSeenInfoMulti(ctx context.Context, orgID valuer.UUID, metricNames ...string) (map[string]int64, error)
+
+	// FetchLastSeenInfoMultiByLabel returns the last seen unix milli of each of the given metrics on
+	// the series whose label labelKey is labelValue. Metrics never seen are left out.
+	FetchLastSeenInfoMultiByLabel(ctx context.Context, orgID valuer.UUID, labelKey, labelValue string, metricNames ...string) (map[string]int64, error)
+
+	// FetchLogFieldLastSeenInfoMulti returns the last seen unix milli of each of the given log attribute
+	// or resource fields. Fields never seen are left out.
+	FetchLogFieldLastSeenInfoMulti(ctx context.Context, orgID valuer.UUID, keys ...FieldCardinalityKey) (map[FieldCardinalityKey]int64, error)
+
+	// FetchSpanFieldCardinalityMulti estimates the number of distinct values seen since startUnixMilli
+	// for each of the given span attribute or resource fields. Fields never seen are left out.
+	FetchSpanFieldCardinalityMulti(ctx context.Context, orgID valuer.UUID, startUnixMilli int64, keys ...FieldCardinalityKey) (map[FieldCardinalityKey]uint64, error)
 }
 
 type MetricMetadataLookupKey struct {
@@ -54,3 +66,9 @@ type MetricMetadataLookupKey struct {
 	AttributeName  string
 	AttributeValue string
 }
+
+// FieldCardinalityKey identifies a field whose cardinality or last seen time is looked up.
+type FieldCardinalityKey struct {
+	Name         string
+	FieldContext FieldContext
+}
@@ -22,6 +22,7 @@ type MockMetadataStore struct {
 	LogsJSONIndexes            []telemetrytypes.TelemetryFieldKeySkipIndex
 	ColumnEvolutionMetadataMap map[string][]*telemetrytypes.EvolutionEntry
 	LookupKeysMap              map[telemetrytypes.MetricMetadataLookupKey]int64
+	CardinalityMap             map[telemetrytypes.FieldCardinalityKey]uint64
 	// StaticFields holds signal-specific intrinsic field definitions (e.g. logstelemetryschema.IntrinsicFields).
 	StaticFields map[string]telemetrytypes.TelemetryFieldKey
 }
@@ -38,6 +39,7 @@ func NewMockMetadataStore() *MockMetadataStore {
 		LogsJSONIndexes:            []telemetrytypes.TelemetryFieldKeySkipIndex{},
 		ColumnEvolutionMetadataMap: make(map[string][]*telemetrytypes.EvolutionEntry),
 		LookupKeysMap:              make(map[telemetrytypes.MetricMetadataLookupKey]int64),
+		CardinalityMap:             make(map[telemetrytypes.FieldCardinalityKey]uint64),
 		StaticFields:               make(map[string]telemetrytypes.TelemetryFieldKey),
 	}
 }
@@ -422,3 +424,21 @@ func (m *MockMetadataStore) SetFirstSeenFromMetricMetadata(firstSeenMap map[tele
 func (m *MockMetadataStore) FetchLastSeenInfoMulti(ctx context.Context, orgID valuer.UUID, metricNames ...string) (map[string]int64, error) {
 	return make(map[string]int64), nil
 }
+
+func (m *MockMetadataStore) FetchLastSeenInfoMultiByLabel(ctx context.Context, orgID valuer.UUID, labelKey, labelValue string, metricNames ...string) (map[string]int64, error) {
+	return make(map[string]int64), nil
+}
+
+func (m *MockMetadataStore) FetchLogFieldLastSeenInfoMulti(ctx context.Context, orgID valuer.UUID, keys ...telemetrytypes.FieldCardinalityKey) (map[telemetrytypes.FieldCardinalityKey]int64, error) {
+	return make(map[telemetrytypes.FieldCardinalityKey]int64), nil
+}
+
+func (m *MockMetadataStore) FetchSpanFieldCardinalityMulti(ctx context.Context, orgID valuer.UUID, startUnixMilli int64, keys ...telemetrytypes.FieldCardinalityKey) (map[telemetrytypes.FieldCardinalityKey]uint64, error) {
+	result := make(map[telemetrytypes.FieldCardinalityKey]uint64)
+	for _, key := range keys {
+		if cardinality, ok := m.CardinalityMap[key]; ok {
+			result[key] = cardinality
+		}
+	}
+	return result, nil
+}
@@ -8,5 +8,6 @@ mkdir -p pkg/parser
 # Generate Go parser
 antlr -visitor -Dlanguage=Go -o pkg/parser/filterquery grammar/FilterQuery.g4
 antlr -visitor -Dlanguage=Go -o pkg/parser/havingexpression grammar/HavingExpression.g4
+antlr -visitor -Dlanguage=Go -o pkg/parser/pipequery grammar/PipeQuery.g4
 
 echo "Go parser generation complete"